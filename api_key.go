@@ -1,8 +1,60 @@
 package cex
 
+import "fmt"
+
+// KeyType is type of api secret key.
+type KeyType string
+
+const (
+	// KeyTypeHmac is default, secret key is plain HMAC secret.
+	KeyTypeHmac KeyType = "HMAC"
+	// KeyTypeRSA secret key is PEM encoded RSA private key.
+	KeyTypeRSA KeyType = "RSA"
+	// KeyTypeEd25519 secret key is PEM encoded Ed25519 private key.
+	KeyTypeEd25519 KeyType = "ED25519"
+)
+
 type Api struct {
 	Cex        Name   `json:"cex" bson:"cex" yaml:"cex"`
 	ApiKey     string `json:"apiKey,omitempty" bson:"apiKey" yaml:"apiKey"`
 	SecretKey  string `json:"secretKey,omitempty" bson:"secretKey" yaml:"secretKey"`
 	Passphrase string `json:"passphrase,omitempty" bson:"passphrase" yaml:"passphrase"`
+	// Env is mainnet if it is empty.
+	Env Env `json:"env,omitempty" bson:"env" yaml:"env"`
+	// KeyType is KeyTypeHmac if it is empty.
+	// Only binance supports RSA and Ed25519 keys now.
+	KeyType KeyType `json:"keyType,omitempty" bson:"keyType" yaml:"keyType"`
+}
+
+// ApiSigner signs payload by secret key of Api, and returns raw signature.
+type ApiSigner func(payload string) ([]byte, error)
+
+// NewSigner returns signer by KeyType of api.
+// HMAC key signs by HMAC SHA256, RSA key signs by RSASSA-PKCS1-v1_5 with SHA256,
+// and Ed25519 key signs payload directly.
+// PEM secret key is parsed once here, so signer should be reused.
+func (api Api) NewSigner() (ApiSigner, error) {
+	switch api.KeyType {
+	case "", KeyTypeHmac:
+		return func(payload string) ([]byte, error) {
+			return SignByHmacSHA256(payload, api.SecretKey), nil
+		}, nil
+	case KeyTypeRSA:
+		key, err := ParseRSAPrivateKeyPEM([]byte(api.SecretKey))
+		if err != nil {
+			return nil, err
+		}
+		return func(payload string) ([]byte, error) {
+			return SignByRSA(payload, key)
+		}, nil
+	case KeyTypeEd25519:
+		key, err := ParseEd25519PrivateKeyPEM([]byte(api.SecretKey))
+		if err != nil {
+			return nil, err
+		}
+		return func(payload string) ([]byte, error) {
+			return SignByEd25519(payload, key), nil
+		}, nil
+	}
+	return nil, fmt.Errorf("cex: unknown key type %q", api.KeyType)
 }
@@ -0,0 +1,79 @@
+package cex
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApi_NewSigner(t *testing.T) {
+	payload := "symbol=ETHUSDT&timestamp=1"
+
+	signer, err := Api{SecretKey: "secret"}.NewSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig, _ := signer(payload); string(sig) != string(SignByHmacSHA256(payload, "secret")) {
+		t.Error("wrong hmac signature")
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	signer, err = Api{SecretKey: string(rsaPem), KeyType: KeyTypeRSA}.NewSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := signer(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashed := sha256.Sum256([]byte(payload))
+	if err := rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, hashed[:], sig); err != nil {
+		t.Error("wrong rsa signature", err)
+	}
+
+	pub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	signer, err = Api{SecretKey: string(edPem), KeyType: KeyTypeEd25519}.NewSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig, _ := signer(payload); !ed25519.Verify(pub, []byte(payload), sig) {
+		t.Error("wrong ed25519 signature")
+	}
+
+	if _, err := (Api{SecretKey: string(edPem), KeyType: KeyTypeRSA}).NewSigner(); err == nil {
+		t.Error("ed25519 key is not rsa key")
+	}
+	if _, err := (Api{SecretKey: "secret", KeyType: KeyTypeEd25519}).NewSigner(); err == nil {
+		t.Error("secret is not pem")
+	}
+	if _, err := (Api{SecretKey: "secret", KeyType: "UNKNOWN"}).NewSigner(); err == nil {
+		t.Error("key type is unknown")
+	}
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, edPem, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if key, err := ReadPrivateKeyPEM(path); err != nil || key != string(edPem) {
+		t.Error("wrong read private key", err)
+	}
+}
@@ -0,0 +1,172 @@
+package cex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ===========================================================
+// Backend
+// -----------------------------------------------------------
+
+// Backend sends requests made by ReqMakers.
+// Request semantics are the same for all backends,
+// including retry policy, rate limits, failover, cache and parsers.
+//
+// fasthttp is not provided, because it can not send requests by http.RoundTripper,
+// which is required by CltOptTransport, SetSharedTransport and mock transports.
+type Backend string
+
+const (
+	// BackendResty sends requests by resty client, it is default.
+	BackendResty Backend = "resty"
+
+	// BackendNetHTTP sends requests by net/http client directly, without resty middlewares,
+	// for latency sensitive callers.
+	// CltOptRetryCount is ignored by it, use ReqConfig.RetryPolicy instead.
+	BackendNetHTTP Backend = "net/http"
+)
+
+var (
+	muxDefaultBackend sync.RWMutex
+	defaultBackend    = BackendResty
+)
+
+// DefaultBackend returns backend of requests, which do not set backend by CltOptBackend.
+func DefaultBackend() Backend {
+	muxDefaultBackend.RLock()
+	defer muxDefaultBackend.RUnlock()
+	return defaultBackend
+}
+
+// SetDefaultBackend sets backend of requests, which do not set backend by CltOptBackend.
+func SetDefaultBackend(backend Backend) {
+	muxDefaultBackend.Lock()
+	defer muxDefaultBackend.Unlock()
+	defaultBackend = backend
+}
+
+// CltOptBackend sends one request by backend.
+func CltOptBackend(backend Backend) CltOpt {
+	return func(ro *ReqOpts) {
+		ro.Backend = backend
+	}
+}
+
+// CltOptsProvider may be implemented by ReqMaker,
+// whose construction options, ex. transport and backend, are applied to all its requests.
+// Request applies them before CltOpts of request, to select backend and its transport.
+type CltOptsProvider interface {
+	CltOpts() []CltOpt
+}
+
+// newReqOpts returns ReqOpts of CltOpts of reqMaker and opts.
+func newReqOpts(reqMaker ReqMaker, opts ...CltOpt) ReqOpts {
+	if provider, ok := reqMaker.(CltOptsProvider); ok {
+		opts = append(provider.CltOpts(), opts...)
+	}
+	return NewReqOpts(opts...)
+}
+
+// send sends req by backend of ro.
+// Response is not nil, if request is sent, status code is 0 if no response is received.
+func send(ro ReqOpts, req *HTTPRequest) (*Response, error) {
+	backend := ro.Backend
+	if backend == "" {
+		backend = DefaultBackend()
+	}
+	switch backend {
+	case BackendResty:
+		resp, err := newRestyRequest(ro, req).Execute(req.Method, req.URL)
+		return newResponse(resp), err
+	case BackendNetHTTP:
+		return sendNetHTTP(ro, req)
+	}
+	return nil, fmt.Errorf("cex: unknown backend %q", backend)
+}
+
+// sendNetHTTP sends url, query params, headers, body and form data of req, as BackendResty does.
+func sendNetHTTP(ro ReqOpts, req *HTTPRequest) (*Response, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, fmt.Errorf("cex: parse url, %w", err)
+	}
+	// query is appended, so signed query composed by ReqMaker is kept in order
+	if len(req.Query) > 0 {
+		if u.RawQuery == "" {
+			u.RawQuery = req.Query.Encode()
+		} else {
+			u.RawQuery += "&" + req.Query.Encode()
+		}
+	}
+
+	var body io.Reader
+	var contentType string
+	switch b := req.Body.(type) {
+	case nil:
+	case []byte:
+		body = bytes.NewReader(b)
+	case string:
+		body = strings.NewReader(b)
+	case io.Reader:
+		body = b
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("cex: marshal body, %w", err)
+		}
+		body, contentType = bytes.NewReader(data), "application/json"
+	}
+	if body == nil && len(req.Form) > 0 {
+		body, contentType = strings.NewReader(req.Form.Encode()), "application/x-www-form-urlencoded"
+	}
+
+	httpReq, err := http.NewRequestWithContext(req.Context(), req.Method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("cex: new http request, %w", err)
+	}
+	httpReq.Header = req.Header.Clone()
+	if httpReq.Header == nil {
+		httpReq.Header = http.Header{}
+	}
+	if contentType != "" && httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	transport := ro.transport
+	if transport == nil {
+		transport = SharedTransport()
+	}
+	clt := &http.Client{Transport: transport, Timeout: ro.Timeout}
+	for _, f := range ro.httpClientOpts {
+		f(clt)
+	}
+
+	start := time.Now()
+	httpResp, err := clt.Do(httpReq)
+	if err != nil {
+		return &Response{header: http.Header{}, time: time.Since(start), receivedAt: time.Now()}, err
+	}
+	defer httpResp.Body.Close()
+	data, err := io.ReadAll(httpResp.Body)
+	resp := &Response{
+		statusCode: httpResp.StatusCode,
+		status:     httpResp.Status,
+		header:     httpResp.Header,
+		body:       data,
+		time:       time.Since(start),
+		receivedAt: time.Now(),
+	}
+	return resp, err
+}
+
+// -----------------------------------------------------------
+// Backend
+// ===========================================================
@@ -0,0 +1,122 @@
+package cex
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testBackendReqMaker signs query into url, and sets query params, header, body or form data,
+// like ReqMakers of cex packages.
+type testBackendReqMaker struct {
+	form bool
+}
+
+func (m testBackendReqMaker) Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error) {
+	req := NewHTTPRequest(opts...)
+	req.URL = config.BaseUrl + config.Path + "?b=2&a=1&signature=x"
+	req.SetQueryParam("c", "3")
+	req.SetHeader("X-Api-Key", "key")
+	if m.form {
+		req.SetFormData(map[string]string{"symbol": "ETHUSDT"})
+	} else {
+		req.SetBody(map[string]string{"symbol": "ETHUSDT"})
+	}
+	return req, nil
+}
+
+func testBackendServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Echo-Query", r.URL.RawQuery)
+		w.Header().Set("X-Echo-Key", r.Header.Get("X-Api-Key"))
+		w.Header().Set("X-Echo-Type", r.Header.Get("Content-Type"))
+		if r.URL.Query().Get("c") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestBackend(t *testing.T) {
+	sv := testBackendServer()
+	defer sv.Close()
+
+	config := ReqConfig[int, map[string]string]{
+		ReqBaseConfig:         ReqBaseConfig{BaseUrl: sv.URL, Path: "/order", Method: http.MethodPost},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]string],
+	}
+	for _, form := range []bool{false, true} {
+		maker := testBackendReqMaker{form: form}
+		var want *Response
+		for _, backend := range []Backend{BackendResty, BackendNetHTTP} {
+			resp, data, err := Request(maker, config, 1, CltOptBackend(backend))
+			if form {
+				// form body is not json
+				if err.IsNil() {
+					t.Fatal(backend, "form body should fail to unmarshal")
+				}
+			} else if err.IsNotNil() {
+				t.Fatal(backend, err.Error())
+			} else if data["symbol"] != "ETHUSDT" {
+				t.Error(backend, "wrong body", data)
+			}
+			if resp.StatusCode() != http.StatusOK || resp.Header().Get("X-Echo-Query") != "b=2&a=1&signature=x&c=3" {
+				t.Error(backend, "query should be appended to signed url", resp.StatusCode(), resp.Header().Get("X-Echo-Query"))
+			}
+			if err.Meta == nil || err.Meta.StatusCode != http.StatusOK {
+				t.Error(backend, "meta should be set", err.Meta)
+			}
+			if want == nil {
+				want = resp
+				continue
+			}
+			for _, h := range []string{"X-Echo-Query", "X-Echo-Key", "X-Echo-Type"} {
+				if resp.Header().Get(h) != want.Header().Get(h) {
+					t.Error(backend, "header should be the same as resty", h, resp.Header().Get(h), want.Header().Get(h))
+				}
+			}
+			if resp.String() != want.String() || resp.Status() != want.Status() {
+				t.Error(backend, "response should be the same as resty", resp.String(), want.String())
+			}
+		}
+	}
+
+	maker := NewPublicReqMaker(PublicReqMakerOptBackend(BackendNetHTTP), PublicReqMakerOptTransport(SharedTransport()))
+	ro := newReqOpts(maker, CltOptTimeout(1))
+	if ro.Backend != BackendNetHTTP || ro.transport == nil || ro.Timeout != 1 {
+		t.Error("options of req maker should be applied", ro)
+	}
+	if newReqOpts(maker, CltOptBackend(BackendResty)).Backend != BackendResty {
+		t.Error("per call backend should override req maker")
+	}
+	if _, err := send(NewReqOpts(CltOptBackend("fasthttp")), &HTTPRequest{Method: http.MethodGet, URL: sv.URL}); err == nil {
+		t.Error("unknown backend should fail")
+	}
+}
+
+func benchmarkBackend(b *testing.B, backend Backend) {
+	sv := testBackendServer()
+	defer sv.Close()
+	config := ReqConfig[int, map[string]string]{
+		ReqBaseConfig:         ReqBaseConfig{BaseUrl: sv.URL, Path: "/order", Method: http.MethodPost},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]string],
+	}
+	b.ReportAllocs()
+	for range b.N {
+		if _, _, err := Request(testBackendReqMaker{}, config, 1, CltOptBackend(backend)); err.IsNotNil() {
+			b.Fatal(err.Error())
+		}
+	}
+}
+
+func BenchmarkBackendResty(b *testing.B) {
+	benchmarkBackend(b, BackendResty)
+}
+
+func BenchmarkBackendNetHTTP(b *testing.B) {
+	benchmarkBackend(b, BackendNetHTTP)
+}
@@ -0,0 +1,47 @@
+package cex
+
+// WalletType is wallet of balance in cex account.
+type WalletType string
+
+const (
+	WalletTypeSpot    WalletType = "SPOT"
+	WalletTypeFutures WalletType = "FUTURES"
+	WalletTypeMargin  WalletType = "MARGIN"
+	WalletTypeEarn    WalletType = "EARN"
+	WalletTypeFunding WalletType = "FUNDING"
+	// WalletTypeUnified is unified trading account, ex. okx trading account, bybit unified account,
+	// which contains spot and futures assets.
+	WalletTypeUnified WalletType = "UNIFIED"
+)
+
+// Balance is normalized balance of one asset in one wallet of cex account.
+type Balance struct {
+	Cex    Name       `json:"cex" bson:"cex"`
+	Wallet WalletType `json:"wallet" bson:"wallet"`
+	Asset  string     `json:"asset" bson:"asset"`
+
+	// Total is wallet balance without unrealized pnl,
+	// it contains assets locked by orders, margins and earn products.
+	Total float64 `json:"total" bson:"total"`
+	// Free is available to trade or transfer.
+	Free float64 `json:"free" bson:"free"`
+	// UnrealizedPnl is unrealized pnl of futures positions, may be 0.
+	UnrealizedPnl float64 `json:"unrealizedPnl,omitempty" bson:"unrealizedPnl,omitempty"`
+}
+
+func (b Balance) Locked() float64 {
+	return b.Total - b.Free
+}
+
+// Equity is total with unrealized pnl.
+func (b Balance) Equity() float64 {
+	return b.Total + b.UnrealizedPnl
+}
+
+// BalanceQuerier queries normalized balances of all supported wallets of account,
+// ex. spot, futures and earn, so portfolios can be consolidated across cex.
+// Balances of queried wallets are returned with the first error,
+// if some wallets are failed.
+type BalanceQuerier interface {
+	QueryBalances(opts ...CltOpt) ([]Balance, RequestError)
+}
@@ -0,0 +1,191 @@
+package cex
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++            Cex REST Core: Base Url Failover         +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// BaseUrlSelector may be implemented by ReqMaker.
+// If ReqMaker implements it, Request sends request to the selected base url,
+// and reports whether the selected base url failed, i.e. no response or 5xx status code.
+// Failed GET requests are resent to the next selected base url at most maxBaseUrlFailovers times.
+// Rate limits and cache still use ReqBaseConfig.BaseUrl,
+// because alternate base urls share limits of the same cex, ex. binance api1-api4.
+type BaseUrlSelector interface {
+	SelectBaseUrl(baseUrl string) string
+	ReportBaseUrl(selected string, failed bool)
+}
+
+// maxBaseUrlFailovers is max times of resending one GET request to other base urls.
+const maxBaseUrlFailovers = 3
+
+// BaseUrlChecker checks health of base url, ex. by ping endpoint.
+type BaseUrlChecker func(baseUrl string) error
+
+type BaseUrlFailoverOpt func(*BaseUrlFailover)
+
+// BaseUrlFailoverOptMaxFailures sets number of consecutive failures,
+// after which base url is unhealthy, default 1.
+func BaseUrlFailoverOptMaxFailures(n int) BaseUrlFailoverOpt {
+	return func(f *BaseUrlFailover) {
+		f.maxFailures = max(n, 1)
+	}
+}
+
+// BaseUrlFailoverOptCooldown sets how long unhealthy base url is not selected, default 30s.
+// Base url is selectable again after cooldown, or after it passes health check.
+func BaseUrlFailoverOptCooldown(d time.Duration) BaseUrlFailoverOpt {
+	return func(f *BaseUrlFailover) {
+		f.cooldown = d
+	}
+}
+
+type baseUrlState struct {
+	failures       int
+	unhealthyUntil time.Time
+}
+
+// BaseUrlFailover implements BaseUrlSelector.
+// Base url of ReqConfig is the primary, which is selected if it is healthy,
+// otherwise the first healthy alternate is selected.
+// If all base urls are unhealthy, the one whose cooldown ends first is selected.
+// Nil BaseUrlFailover is valid, and always selects the primary.
+//
+//	f := NewBaseUrlFailover().SetBaseUrls("https://api.binance.com", "https://api1.binance.com")
+//	f.Start(ctx, time.Minute, checker)
+type BaseUrlFailover struct {
+	mux         sync.Mutex
+	groups      map[string][]string
+	states      map[string]*baseUrlState
+	maxFailures int
+	cooldown    time.Duration
+}
+
+func NewBaseUrlFailover(opts ...BaseUrlFailoverOpt) *BaseUrlFailover {
+	f := &BaseUrlFailover{
+		groups:      map[string][]string{},
+		states:      map[string]*baseUrlState{},
+		maxFailures: 1,
+		cooldown:    30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// SetBaseUrls sets alternate base urls of primary, alternates are selected in order.
+func (f *BaseUrlFailover) SetBaseUrls(primary string, alternates ...string) *BaseUrlFailover {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	urls := append([]string{primary}, alternates...)
+	f.groups[primary] = urls
+	for _, url := range urls {
+		if f.states[url] == nil {
+			f.states[url] = &baseUrlState{}
+		}
+	}
+	return f
+}
+
+func (f *BaseUrlFailover) healthy(url string, now time.Time) bool {
+	s := f.states[url]
+	return s == nil || s.failures < f.maxFailures || !now.Before(s.unhealthyUntil)
+}
+
+// SelectBaseUrl implements BaseUrlSelector.
+func (f *BaseUrlFailover) SelectBaseUrl(baseUrl string) string {
+	if f == nil {
+		return baseUrl
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	urls, ok := f.groups[baseUrl]
+	if !ok {
+		return baseUrl
+	}
+	now := time.Now()
+	selected := baseUrl
+	for _, url := range urls {
+		if f.healthy(url, now) {
+			return url
+		}
+		if f.states[url].unhealthyUntil.Before(f.states[selected].unhealthyUntil) {
+			selected = url
+		}
+	}
+	return selected
+}
+
+// ReportBaseUrl implements BaseUrlSelector.
+func (f *BaseUrlFailover) ReportBaseUrl(selected string, failed bool) {
+	if f == nil {
+		return
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	s := f.states[selected]
+	if s == nil {
+		return
+	}
+	if !failed {
+		*s = baseUrlState{}
+		return
+	}
+	s.failures++
+	if s.failures >= f.maxFailures {
+		s.unhealthyUntil = time.Now().Add(f.cooldown)
+	}
+}
+
+// Healthy returns false, if base url is in cooldown.
+func (f *BaseUrlFailover) Healthy(baseUrl string) bool {
+	if f == nil {
+		return true
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.healthy(baseUrl, time.Now())
+}
+
+// Check checks all unhealthy base urls by checker once,
+// and restores base urls which pass the check.
+func (f *BaseUrlFailover) Check(checker BaseUrlChecker) {
+	f.mux.Lock()
+	var unhealthy []string
+	now := time.Now()
+	for url := range f.states {
+		if !f.healthy(url, now) {
+			unhealthy = append(unhealthy, url)
+		}
+	}
+	f.mux.Unlock()
+	for _, url := range unhealthy {
+		if checker(url) == nil {
+			f.ReportBaseUrl(url, false)
+		}
+	}
+}
+
+// Start checks unhealthy base urls every interval in a new goroutine until ctx is done.
+func (f *BaseUrlFailover) Start(ctx context.Context, interval time.Duration, checker BaseUrlChecker) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				f.Check(checker)
+			}
+		}
+	}()
+}
@@ -0,0 +1,91 @@
+package cex
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBaseUrlFailover(t *testing.T) {
+	f := NewBaseUrlFailover(BaseUrlFailoverOptMaxFailures(2), BaseUrlFailoverOptCooldown(time.Hour)).
+		SetBaseUrls("https://api.example.com", "https://api1.example.com", "https://api2.example.com")
+	if f.SelectBaseUrl("https://other.example.com") != "https://other.example.com" {
+		t.Error("base url without alternates should not be changed")
+	}
+
+	f.ReportBaseUrl("https://api.example.com", true)
+	if f.SelectBaseUrl("https://api.example.com") != "https://api.example.com" {
+		t.Error("primary should be selected before max failures")
+	}
+	f.ReportBaseUrl("https://api.example.com", true)
+	if f.SelectBaseUrl("https://api.example.com") != "https://api1.example.com" {
+		t.Error("the first healthy alternate should be selected")
+	}
+	f.ReportBaseUrl("https://api1.example.com", true)
+	f.ReportBaseUrl("https://api1.example.com", true)
+	f.ReportBaseUrl("https://api2.example.com", true)
+	f.ReportBaseUrl("https://api2.example.com", true)
+	if f.SelectBaseUrl("https://api.example.com") != "https://api.example.com" {
+		t.Error("base url whose cooldown ends first should be selected, if all are unhealthy")
+	}
+
+	f.Check(func(baseUrl string) error {
+		if baseUrl == "https://api2.example.com" {
+			return nil
+		}
+		return errors.New("unavailable")
+	})
+	if f.SelectBaseUrl("https://api.example.com") != "https://api2.example.com" || f.Healthy("https://api1.example.com") {
+		t.Error("only checked base url should be restored")
+	}
+
+	var nilFailover *BaseUrlFailover
+	if nilFailover.SelectBaseUrl("https://api.example.com") != "https://api.example.com" {
+		t.Error("nil failover should select primary")
+	}
+}
+
+func TestRequest_BaseUrlFailover(t *testing.T) {
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	dead.Close()
+	var hits atomic.Int64
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{"symbol":"ETHUSDT"}`))
+	}))
+	defer live.Close()
+
+	f := NewBaseUrlFailover().SetBaseUrls(dead.URL, live.URL)
+	maker := NewPublicReqMaker(PublicReqMakerOptBaseUrlSelector(f))
+	config := ReqConfig[testPublicParams, map[string]string]{
+		ReqBaseConfig:         ReqBaseConfig{BaseUrl: dead.URL, Path: "/depth", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]string],
+	}
+	_, data, err := Request(maker, config, testPublicParams{})
+	if err.IsNotNil() || data["symbol"] != "ETHUSDT" {
+		t.Fatal("get request should be resent to alternate", err.Error())
+	}
+	if f.Healthy(dead.URL) || hits.Load() != 1 {
+		t.Error("failed base url should be unhealthy")
+	}
+
+	// post request is not resent, but next request is sent to alternate
+	f.ReportBaseUrl(dead.URL, false)
+	config.Method = http.MethodPost
+	if _, _, err = Request(maker, config, testPublicParams{}); err.IsNil() || hits.Load() != 1 {
+		t.Error("post request should not be resent")
+	}
+	if _, _, err = Request(maker, config, testPublicParams{}); err.IsNotNil() || hits.Load() != 2 {
+		t.Error("next request should be sent to alternate", err.Error())
+	}
+
+	// without failover, failed request is not resent
+	config.Method = http.MethodGet
+	if _, _, err = Request(NewPublicReqMaker(), config, testPublicParams{}); err.IsNil() || hits.Load() != 2 {
+		t.Error("request without failover should fail")
+	}
+}
@@ -0,0 +1,239 @@
+package cex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++                Cex REST Core: Batch                 +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ===========================================================
+// Batch Budget
+// -----------------------------------------------------------
+
+// BatchBudget is request budget shared by workers of one or more batches,
+// ex. 600 weight per minute, which leaves other budget of cex to trading.
+// Requests are still limited by DefaultRateLimiter and DefaultWeightBudget in Request.
+type BatchBudget struct {
+	mux    sync.Mutex
+	bucket *tokenBucket
+}
+
+// NewBatchBudget creates budget of limit per interval.
+func NewBatchBudget(limit int64, interval time.Duration) *BatchBudget {
+	return &BatchBudget{bucket: newTokenBucket(limit, interval, time.Now())}
+}
+
+// Acquire blocks until cost is available or ctx is done.
+func (b *BatchBudget) Acquire(ctx context.Context, cost int64) error {
+	if b == nil {
+		return nil
+	}
+	if cost < 1 {
+		cost = 1
+	}
+	for {
+		b.mux.Lock()
+		wait := b.bucket.wait(time.Now(), float64(cost))
+		if wait <= 0 {
+			b.bucket.take(float64(cost))
+		}
+		b.mux.Unlock()
+		if wait <= 0 {
+			return nil
+		}
+		if err := sleepCtx(ctx, wait); err != nil {
+			return fmt.Errorf("%w: %w", ErrRateLimited, err)
+		}
+	}
+}
+
+// Block blocks budget until, ex. rate limit reset time responded by cex.
+func (b *BatchBudget) Block(until time.Time) {
+	if b == nil {
+		return
+	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if until.After(b.bucket.blockedUntil) {
+		b.bucket.blockedUntil = until
+	}
+}
+
+// -----------------------------------------------------------
+// Batch Budget
+// ===========================================================
+
+// ===========================================================
+// Batch
+// -----------------------------------------------------------
+
+// DefaultBatchWorkers is the number of workers of Batch, if it is not set.
+const DefaultBatchWorkers = 5
+
+// BatchResult is result of one item of batch.
+// Index is index of Params in params of Batch.Run.
+type BatchResult[ReqDataType, RespDataType any] struct {
+	Index  int
+	Params ReqDataType
+	Resp   *Response
+	Data   RespDataType
+	Err    RequestError
+}
+
+type BatchResults[ReqDataType, RespDataType any] []BatchResult[ReqDataType, RespDataType]
+
+// Failed returns failed results, including items not requested because of ctx or StopOnError.
+func (rs BatchResults[ReqDataType, RespDataType]) Failed() BatchResults[ReqDataType, RespDataType] {
+	var failed BatchResults[ReqDataType, RespDataType]
+	for _, r := range rs {
+		if r.Err.IsNotNil() {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// Data returns data of succeeded results in order.
+func (rs BatchResults[ReqDataType, RespDataType]) Data() []RespDataType {
+	data := make([]RespDataType, 0, len(rs))
+	for _, r := range rs {
+		if r.Err.IsNil() {
+			data = append(data, r.Data)
+		}
+	}
+	return data
+}
+
+// Batch runs requests of one config concurrently by a worker pool,
+// ex. fetching positions for 200 symbols.
+// Errors are collected per item, so one failed item does not fail others.
+//
+//	results := cex.NewBatch(user, bnc.FuturesPositionsConfig).
+//		SetWorkers(10).
+//		SetBudget(cex.NewBatchBudget(600, time.Minute)).
+//		Run(ctx, params)
+//	for _, r := range results.Failed() {
+//	}
+type Batch[ReqDataType, RespDataType any] struct {
+	reqMaker    ReqMaker
+	config      ReqConfig[ReqDataType, RespDataType]
+	opts        []CltOpt
+	workers     int
+	budget      *BatchBudget
+	cost        int64
+	stopOnError bool
+}
+
+func NewBatch[ReqDataType, RespDataType any](
+	reqMaker ReqMaker,
+	config ReqConfig[ReqDataType, RespDataType],
+	opts ...CltOpt,
+) *Batch[ReqDataType, RespDataType] {
+	return &Batch[ReqDataType, RespDataType]{
+		reqMaker: reqMaker,
+		config:   config,
+		opts:     opts,
+		workers:  DefaultBatchWorkers,
+		cost:     1,
+	}
+}
+
+// SetWorkers sets number of concurrent requests, DefaultBatchWorkers if workers < 1.
+func (b *Batch[ReqDataType, RespDataType]) SetWorkers(workers int) *Batch[ReqDataType, RespDataType] {
+	if workers < 1 {
+		workers = DefaultBatchWorkers
+	}
+	b.workers = workers
+	return b
+}
+
+// SetBudget sets budget shared by workers, nil means no budget of batch.
+// If cex responds rate limit error, budget is blocked until it resets.
+func (b *Batch[ReqDataType, RespDataType]) SetBudget(budget *BatchBudget) *Batch[ReqDataType, RespDataType] {
+	b.budget = budget
+	return b
+}
+
+// SetCost sets cost of every request taken from budget, ex. request weight, default 1.
+func (b *Batch[ReqDataType, RespDataType]) SetCost(cost int64) *Batch[ReqDataType, RespDataType] {
+	b.cost = cost
+	return b
+}
+
+// SetStopOnError stops requesting remaining items after the first failed item.
+func (b *Batch[ReqDataType, RespDataType]) SetStopOnError(stop bool) *Batch[ReqDataType, RespDataType] {
+	b.stopOnError = stop
+	return b
+}
+
+// Run requests every params, and returns results in order of params.
+// Items not requested, because ctx is done or StopOnError, have errors too.
+func (b *Batch[ReqDataType, RespDataType]) Run(ctx context.Context, params []ReqDataType) BatchResults[ReqDataType, RespDataType] {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(BatchResults[ReqDataType, RespDataType], len(params))
+	for i, p := range params {
+		results[i] = BatchResult[ReqDataType, RespDataType]{Index: i, Params: p}
+	}
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for range min(b.workers, len(params)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				b.do(ctx, &results[i])
+				if b.stopOnError && results[i].Err.IsNotNil() {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	sent := 0
+loop:
+	for ; sent < len(params); sent++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case indexes <- sent:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := sent; i < len(params); i++ {
+		results[i].Err = RequestError{
+			ReqBaseConfig: b.config.ReqBaseConfig,
+			Err:           fmt.Errorf("cex: batch item is not requested, %w", context.Cause(ctx)),
+		}
+	}
+	return results
+}
+
+func (b *Batch[ReqDataType, RespDataType]) do(ctx context.Context, r *BatchResult[ReqDataType, RespDataType]) {
+	if err := b.budget.Acquire(ctx, b.cost); err != nil {
+		r.Err = RequestError{ReqBaseConfig: b.config.ReqBaseConfig, Err: err}
+		return
+	}
+	r.Resp, r.Data, r.Err = RequestCtx(ctx, b.reqMaker, b.config, r.Params, b.opts...)
+	if r.Err.IsRateLimited() && !r.Err.RateLimitError.ResetsAt.IsZero() {
+		b.budget.Block(r.Err.RateLimitError.ResetsAt)
+	}
+}
+
+// -----------------------------------------------------------
+// Batch
+// ===========================================================
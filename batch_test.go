@@ -0,0 +1,85 @@
+package cex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int64
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		current, _ := strconv.Atoi(r.URL.Query().Get("current"))
+		if current%5 == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("[" + strconv.Itoa(current*10) + "]"))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[int, []int]{
+		ReqBaseConfig: ReqBaseConfig{BaseUrl: sv.URL, Path: "/batch", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error {
+			if code != http.StatusOK {
+				return ErrHTTPNotFound
+			}
+			return nil
+		},
+		RespBodyUnmarshaler: StdBodyUnmarshaler[[]int],
+		RetryPolicy:         &RetryPolicy{MaxAttempts: 1},
+	}
+	params := make([]int, 20)
+	for i := range params {
+		params[i] = i + 1
+	}
+
+	results := NewBatch(testPageReqMaker{}, config).SetWorkers(4).Run(context.Background(), params)
+	if len(results) != 20 {
+		t.Fatal("wrong results", len(results))
+	}
+	if maxInFlight.Load() > 4 || maxInFlight.Load() < 2 {
+		t.Error("wrong concurrency", maxInFlight.Load())
+	}
+	for i, r := range results {
+		if r.Index != i || r.Params != i+1 {
+			t.Error("wrong result order", i, r.Index, r.Params)
+		}
+	}
+	failed := results.Failed()
+	if len(failed) != 4 || failed[0].Params != 5 || !failed[0].Err.Is(ErrHTTPNotFound) {
+		t.Error("wrong failed results", len(failed))
+	}
+	data := results.Data()
+	if len(data) != 16 || data[0][0] != 10 || data[4][0] != 60 {
+		t.Error("wrong data", data)
+	}
+
+	start := time.Now()
+	budget := NewBatchBudget(2, 100*time.Millisecond)
+	results = NewBatch(testPageReqMaker{}, config).SetWorkers(4).SetBudget(budget).Run(context.Background(), params[:4])
+	if len(results.Data()) != 4 {
+		t.Error("wrong data", results)
+	}
+	if time.Since(start) < 80*time.Millisecond {
+		t.Error("budget should throttle requests", time.Since(start))
+	}
+
+	results = NewBatch(testPageReqMaker{}, config).SetWorkers(1).SetStopOnError(true).Run(context.Background(), params[:10])
+	if len(results.Failed()) != 6 || results[9].Err.IsNil() || results[3].Err.IsNotNil() {
+		t.Error("remaining items should fail after the first failed item", len(results.Failed()))
+	}
+}
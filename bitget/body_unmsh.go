@@ -0,0 +1,55 @@
+package bitget
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dwdwow/cex"
+)
+
+// RespData is the envelope of all bitget v2 responses.
+type RespData[D any] struct {
+	Code        string `json:"code"`
+	Msg         string `json:"msg"`
+	RequestTime int64  `json:"requestTime"`
+	Data        D      `json:"data"`
+}
+
+// bodyUnmsh unmarshals bitget envelope, and maps code and msg into RespBodyUnmarshalerError.
+func bodyUnmsh[D any](body []byte) (D, *cex.RespBodyUnmarshalerError) {
+	resp := RespData[D]{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp.Data, &cex.RespBodyUnmarshalerError{
+			Err: fmt.Errorf("bitget: %w: unmarshal response body, %w", cex.ErrJsonUnmarshal, err),
+		}
+	}
+	if resp.Code == SuccessCode {
+		return resp.Data, nil
+	}
+	intCode, _ := strconv.Atoi(resp.Code)
+	errCtm := cexCustomErrCodes[intCode]
+	if errCtm == nil {
+		errCtm = fmt.Errorf("%v, %v", resp.Code, resp.Msg)
+	}
+	return resp.Data, &cex.RespBodyUnmarshalerError{
+		CexErrCode: intCode,
+		CexErrMsg:  resp.Msg,
+		Err:        fmt.Errorf("bitget: %w", errCtm),
+	}
+}
+
+// firstItemBodyUnmsh unmarshals bitget envelope, which data is a list with only one item.
+func firstItemBodyUnmsh[D any](body []byte) (D, *cex.RespBodyUnmarshalerError) {
+	var d D
+	items, err := bodyUnmsh[[]D](body)
+	if err != nil {
+		return d, err
+	}
+	if len(items) == 0 {
+		return d, &cex.RespBodyUnmarshalerError{
+			Err: fmt.Errorf("bitget: %w: empty response data", cex.ErrUnexpected),
+		}
+	}
+	return items[0], nil
+}
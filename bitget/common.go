@@ -0,0 +1,151 @@
+package bitget
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+const (
+	BaseUrl = "https://api.bitget.com"
+	ApiV2   = "/api/v2"
+)
+
+const (
+	SymbolMid = ""
+
+	// SuccessCode is code of succeeded responses.
+	SuccessCode = "00000"
+)
+
+// ProductType is productType of mix(futures) endpoints.
+// Product types of demo trading are prefixed with S, ex. SUSDT-FUTURES.
+type ProductType string
+
+const (
+	ProductTypeUsdtFutures ProductType = "USDT-FUTURES"
+	ProductTypeCoinFutures ProductType = "COIN-FUTURES"
+	ProductTypeUsdcFutures ProductType = "USDC-FUTURES"
+
+	ProductTypeSUsdtFutures ProductType = "SUSDT-FUTURES"
+	ProductTypeSCoinFutures ProductType = "SCOIN-FUTURES"
+	ProductTypeSUsdcFutures ProductType = "SUSDC-FUTURES"
+)
+
+type MarginMode string
+
+const (
+	MarginModeCrossed  MarginMode = "crossed"
+	MarginModeIsolated MarginMode = "isolated"
+)
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "buy"
+	OrderSideSell OrderSide = "sell"
+)
+
+type OrderType string
+
+const (
+	OrderTypeLimit  OrderType = "limit"
+	OrderTypeMarket OrderType = "market"
+)
+
+// Force is time in force of order.
+type Force string
+
+const (
+	ForceGtc      Force = "gtc"
+	ForceIoc      Force = "ioc"
+	ForceFok      Force = "fok"
+	ForcePostOnly Force = "post_only"
+)
+
+// TradeSide is only required in hedge mode of mix orders.
+type TradeSide string
+
+const (
+	TradeSideOpen  TradeSide = "open"
+	TradeSideClose TradeSide = "close"
+)
+
+type HoldSide string
+
+const (
+	HoldSideLong  HoldSide = "long"
+	HoldSideShort HoldSide = "short"
+)
+
+// YesNo is bool of bitget params, ex. reduceOnly.
+type YesNo string
+
+const (
+	Yes YesNo = "YES"
+	No  YesNo = "NO"
+)
+
+// OrderStatus is status of spot order and state of mix order.
+type OrderStatus string
+
+const (
+	OrderStatusInit            OrderStatus = "init"
+	OrderStatusNew             OrderStatus = "new"
+	OrderStatusLive            OrderStatus = "live"
+	OrderStatusPartiallyFilled OrderStatus = "partially_filled"
+	OrderStatusFilled          OrderStatus = "filled"
+	OrderStatusCancelled       OrderStatus = "cancelled"
+	OrderStatusCanceled        OrderStatus = "canceled"
+)
+
+// Num is number responded as string by bitget.
+// bitget responds empty string if number is not available,
+// which can not be unmarshalled by ",string" tag.
+type Num float64
+
+func (n *Num) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var f float64
+		if err := json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		*n = Num(f)
+		return nil
+	}
+	if s == "" {
+		*n = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Num(f)
+	return nil
+}
+
+func (n Num) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatFloat(float64(n), 'f', -1, 64))
+}
+
+func (n Num) Float64() float64 {
+	return float64(n)
+}
+
+// Symbol returns bitget symbol of spot and mix, ex. BTCUSDT.
+func Symbol(asset, quote string) string {
+	return asset + SymbolMid + quote
+}
+
+// ProductTypeOfQuote returns product type of mix symbol by quote,
+// ex. USDT-FUTURES for BTCUSDT, COIN-FUTURES for BTCUSD.
+func ProductTypeOfQuote(quote string) ProductType {
+	switch quote {
+	case "USDT":
+		return ProductTypeUsdtFutures
+	case "USDC":
+		return ProductTypeUsdcFutures
+	}
+	return ProductTypeCoinFutures
+}
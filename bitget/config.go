@@ -0,0 +1,374 @@
+package bitget
+
+import (
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Public
+// ------------------------------------------------------------
+
+type ServerTime struct {
+	// ServerTime unit is millisecond
+	ServerTime Num `json:"serverTime" bson:"serverTime"`
+}
+
+var ServerTimeConfig = cex.ReqConfig[cex.NilReqData, ServerTime]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/public/time",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   50,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[ServerTime],
+}
+
+type SpotSymbolsParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+}
+
+type SpotSymbol struct {
+	Symbol            string `json:"symbol" bson:"symbol"`
+	BaseCoin          string `json:"baseCoin" bson:"baseCoin"`
+	QuoteCoin         string `json:"quoteCoin" bson:"quoteCoin"`
+	MinTradeAmount    Num    `json:"minTradeAmount" bson:"minTradeAmount"`
+	MaxTradeAmount    Num    `json:"maxTradeAmount" bson:"maxTradeAmount"`
+	TakerFeeRate      Num    `json:"takerFeeRate" bson:"takerFeeRate"`
+	MakerFeeRate      Num    `json:"makerFeeRate" bson:"makerFeeRate"`
+	PricePrecision    Num    `json:"pricePrecision" bson:"pricePrecision"`
+	QuantityPrecision Num    `json:"quantityPrecision" bson:"quantityPrecision"`
+	QuotePrecision    Num    `json:"quotePrecision" bson:"quotePrecision"`
+	MinTradeUSDT      Num    `json:"minTradeUSDT" bson:"minTradeUSDT"`
+	Status            string `json:"status" bson:"status"` // online, offline, gray, halt
+}
+
+var SpotSymbolsConfig = cex.ReqConfig[SpotSymbolsParams, []SpotSymbol]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/spot/public/symbols",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   50,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[[]SpotSymbol],
+}
+
+// ------------------------------------------------------------
+// Public
+// ============================================================
+
+// ============================================================
+// Account
+// ------------------------------------------------------------
+
+type SpotAssetsParams struct {
+	Coin string `s2m:"coin,omitempty"`
+}
+
+type SpotAsset struct {
+	Coin           string `json:"coin" bson:"coin"`
+	Available      Num    `json:"available" bson:"available"`
+	Frozen         Num    `json:"frozen" bson:"frozen"`
+	Locked         Num    `json:"locked" bson:"locked"`
+	LimitAvailable Num    `json:"limitAvailable" bson:"limitAvailable"`
+	UTime          Num    `json:"uTime" bson:"uTime"`
+}
+
+var SpotAssetsConfig = cex.ReqConfig[SpotAssetsParams, []SpotAsset]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/spot/account/assets",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 100,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[[]SpotAsset],
+}
+
+type MixAccountsParams struct {
+	ProductType ProductType `s2m:"productType,omitempty"` // required
+}
+
+type MixAccount struct {
+	MarginCoin           string `json:"marginCoin" bson:"marginCoin"`
+	Locked               Num    `json:"locked" bson:"locked"`
+	Available            Num    `json:"available" bson:"available"`
+	CrossedMaxAvailable  Num    `json:"crossedMaxAvailable" bson:"crossedMaxAvailable"`
+	IsolatedMaxAvailable Num    `json:"isolatedMaxAvailable" bson:"isolatedMaxAvailable"`
+	MaxTransferOut       Num    `json:"maxTransferOut" bson:"maxTransferOut"`
+	AccountEquity        Num    `json:"accountEquity" bson:"accountEquity"`
+	UsdtEquity           Num    `json:"usdtEquity" bson:"usdtEquity"`
+	BtcEquity            Num    `json:"btcEquity" bson:"btcEquity"`
+	CrossedRiskRate      Num    `json:"crossedRiskRate" bson:"crossedRiskRate"`
+	UnrealizedPL         Num    `json:"unrealizedPL" bson:"unrealizedPL"`
+	Coupon               Num    `json:"coupon" bson:"coupon"`
+}
+
+var MixAccountsConfig = cex.ReqConfig[MixAccountsParams, []MixAccount]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/mix/account/accounts",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 100,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[[]MixAccount],
+}
+
+type MixPositionsParams struct {
+	ProductType ProductType `s2m:"productType,omitempty"` // required
+	MarginCoin  string      `s2m:"marginCoin,omitempty"`
+}
+
+type MixPosition struct {
+	MarginCoin       string     `json:"marginCoin" bson:"marginCoin"`
+	Symbol           string     `json:"symbol" bson:"symbol"`
+	HoldSide         HoldSide   `json:"holdSide" bson:"holdSide"`
+	OpenDelegateSize Num        `json:"openDelegateSize" bson:"openDelegateSize"`
+	MarginSize       Num        `json:"marginSize" bson:"marginSize"`
+	Available        Num        `json:"available" bson:"available"`
+	Locked           Num        `json:"locked" bson:"locked"`
+	Total            Num        `json:"total" bson:"total"`
+	Leverage         Num        `json:"leverage" bson:"leverage"`
+	AchievedProfits  Num        `json:"achievedProfits" bson:"achievedProfits"`
+	OpenPriceAvg     Num        `json:"openPriceAvg" bson:"openPriceAvg"`
+	MarginMode       MarginMode `json:"marginMode" bson:"marginMode"`
+	PosMode          string     `json:"posMode" bson:"posMode"` // one_way_mode, hedge_mode
+	UnrealizedPL     Num        `json:"unrealizedPL" bson:"unrealizedPL"`
+	LiquidationPrice Num        `json:"liquidationPrice" bson:"liquidationPrice"`
+	KeepMarginRate   Num        `json:"keepMarginRate" bson:"keepMarginRate"`
+	MarkPrice        Num        `json:"markPrice" bson:"markPrice"`
+	CTime            Num        `json:"cTime" bson:"cTime"`
+	UTime            Num        `json:"uTime" bson:"uTime"`
+}
+
+var MixPositionsConfig = cex.ReqConfig[MixPositionsParams, []MixPosition]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/mix/position/all-position",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 200,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[[]MixPosition],
+}
+
+// ------------------------------------------------------------
+// Account
+// ============================================================
+
+// ============================================================
+// Spot Trade
+// ------------------------------------------------------------
+
+// SpotNewOrderParams is sent as json body.
+// Size of market buy order is quote amount, others are base amount.
+type SpotNewOrderParams struct {
+	Symbol    string    `json:"symbol,omitempty"`
+	Side      OrderSide `json:"side,omitempty"`
+	OrderType OrderType `json:"orderType,omitempty"`
+	Force     Force     `json:"force,omitempty"`
+	Price     float64   `json:"price,string,omitempty"`
+	Size      float64   `json:"size,string,omitempty"`
+	ClientOid string    `json:"clientOid,omitempty"`
+}
+
+// OrderResult is responded by order place and cancel of spot and mix.
+type OrderResult struct {
+	OrderId   string `json:"orderId" bson:"orderId"`
+	ClientOid string `json:"clientOid" bson:"clientOid"`
+}
+
+var SpotNewOrderConfig = cex.ReqConfig[SpotNewOrderParams, OrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/spot/trade/place-order",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[OrderResult],
+}
+
+type SpotCancelOrderParams struct {
+	Symbol    string `json:"symbol,omitempty"`
+	OrderId   string `json:"orderId,omitempty"`
+	ClientOid string `json:"clientOid,omitempty"`
+}
+
+var SpotCancelOrderConfig = cex.ReqConfig[SpotCancelOrderParams, OrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/spot/trade/cancel-order",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[OrderResult],
+}
+
+type SpotQueryOrderParams struct {
+	OrderId   string `s2m:"orderId,omitempty"`
+	ClientOid string `s2m:"clientOid,omitempty"`
+}
+
+type SpotOrder struct {
+	UserId      string      `json:"userId" bson:"userId"`
+	Symbol      string      `json:"symbol" bson:"symbol"`
+	OrderId     string      `json:"orderId" bson:"orderId"`
+	ClientOid   string      `json:"clientOid" bson:"clientOid"`
+	Price       Num         `json:"price" bson:"price"`
+	Size        Num         `json:"size" bson:"size"`
+	OrderType   OrderType   `json:"orderType" bson:"orderType"`
+	Side        OrderSide   `json:"side" bson:"side"`
+	Status      OrderStatus `json:"status" bson:"status"`
+	PriceAvg    Num         `json:"priceAvg" bson:"priceAvg"`
+	BaseVolume  Num         `json:"baseVolume" bson:"baseVolume"`
+	QuoteVolume Num         `json:"quoteVolume" bson:"quoteVolume"`
+	OrderSource string      `json:"orderSource" bson:"orderSource"`
+	// FeeDetail is json string of fee details
+	FeeDetail string `json:"feeDetail" bson:"feeDetail"`
+	CTime     Num    `json:"cTime" bson:"cTime"`
+	UTime     Num    `json:"uTime" bson:"uTime"`
+}
+
+var SpotQueryOrderConfig = cex.ReqConfig[SpotQueryOrderParams, SpotOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/spot/trade/orderInfo",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 50,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   firstItemBodyUnmsh[SpotOrder],
+}
+
+// ------------------------------------------------------------
+// Spot Trade
+// ============================================================
+
+// ============================================================
+// Mix Trade
+// ------------------------------------------------------------
+
+// MixNewOrderParams is sent as json body.
+// Size is base amount, ex. 0.01 BTC.
+// TradeSide is required only in hedge mode.
+type MixNewOrderParams struct {
+	Symbol      string      `json:"symbol,omitempty"`
+	ProductType ProductType `json:"productType,omitempty"`
+	MarginMode  MarginMode  `json:"marginMode,omitempty"`
+	MarginCoin  string      `json:"marginCoin,omitempty"`
+	Size        float64     `json:"size,string,omitempty"`
+	Price       float64     `json:"price,string,omitempty"`
+	Side        OrderSide   `json:"side,omitempty"`
+	TradeSide   TradeSide   `json:"tradeSide,omitempty"`
+	OrderType   OrderType   `json:"orderType,omitempty"`
+	Force       Force       `json:"force,omitempty"`
+	ClientOid   string      `json:"clientOid,omitempty"`
+	ReduceOnly  YesNo       `json:"reduceOnly,omitempty"`
+}
+
+var MixNewOrderConfig = cex.ReqConfig[MixNewOrderParams, OrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/mix/order/place-order",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[OrderResult],
+}
+
+type MixCancelOrderParams struct {
+	Symbol      string      `json:"symbol,omitempty"`
+	ProductType ProductType `json:"productType,omitempty"`
+	MarginCoin  string      `json:"marginCoin,omitempty"`
+	OrderId     string      `json:"orderId,omitempty"`
+	ClientOid   string      `json:"clientOid,omitempty"`
+}
+
+var MixCancelOrderConfig = cex.ReqConfig[MixCancelOrderParams, OrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/mix/order/cancel-order",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[OrderResult],
+}
+
+type MixQueryOrderParams struct {
+	Symbol      string      `s2m:"symbol,omitempty"`
+	ProductType ProductType `s2m:"productType,omitempty"`
+	OrderId     string      `s2m:"orderId,omitempty"`
+	ClientOid   string      `s2m:"clientOid,omitempty"`
+}
+
+type MixOrder struct {
+	Symbol       string      `json:"symbol" bson:"symbol"`
+	Size         Num         `json:"size" bson:"size"`
+	OrderId      string      `json:"orderId" bson:"orderId"`
+	ClientOid    string      `json:"clientOid" bson:"clientOid"`
+	BaseVolume   Num         `json:"baseVolume" bson:"baseVolume"`
+	QuoteVolume  Num         `json:"quoteVolume" bson:"quoteVolume"`
+	PriceAvg     Num         `json:"priceAvg" bson:"priceAvg"`
+	Fee          Num         `json:"fee" bson:"fee"`
+	Price        Num         `json:"price" bson:"price"`
+	State        OrderStatus `json:"state" bson:"state"`
+	Side         OrderSide   `json:"side" bson:"side"`
+	Force        Force       `json:"force" bson:"force"`
+	TotalProfits Num         `json:"totalProfits" bson:"totalProfits"`
+	PosSide      string      `json:"posSide" bson:"posSide"`
+	MarginCoin   string      `json:"marginCoin" bson:"marginCoin"`
+	Leverage     Num         `json:"leverage" bson:"leverage"`
+	MarginMode   MarginMode  `json:"marginMode" bson:"marginMode"`
+	ReduceOnly   YesNo       `json:"reduceOnly" bson:"reduceOnly"`
+	TradeSide    string      `json:"tradeSide" bson:"tradeSide"`
+	PosMode      string      `json:"posMode" bson:"posMode"`
+	OrderType    OrderType   `json:"orderType" bson:"orderType"`
+	OrderSource  string      `json:"orderSource" bson:"orderSource"`
+	CTime        Num         `json:"cTime" bson:"cTime"`
+	UTime        Num         `json:"uTime" bson:"uTime"`
+}
+
+var MixQueryOrderConfig = cex.ReqConfig[MixQueryOrderParams, MixOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV2 + "/mix/order/detail",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 50,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[MixOrder],
+}
+
+// ------------------------------------------------------------
+// Mix Trade
+// ============================================================
@@ -0,0 +1,82 @@
+package bitget
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+/**
+HTTP Ref:
+	https://www.bitget.com/api-doc/common/signature
+Error Codes Ref:
+	https://www.bitget.com/api-doc/common/error-code/restapi
+
+All Endpoints:
+	bitget responds {"code":"00000","msg":"success","requestTime":0,"data":{}},
+	code "00000" means success.
+	HTTP 429 return code is used when breaking a request rate limit.
+	HTTP 5XX return codes are used for internal errors, the execution status is UNKNOWN.
+*/
+
+// =============================================
+// HTTP Errors
+// ---------------------------------------------
+
+var httpErrCodes = map[int]error{
+	http.StatusBadRequest:      cex.ErrHTTPBadRequest,
+	http.StatusUnauthorized:    cex.ErrHTTPForbidden,
+	http.StatusForbidden:       cex.ErrHTTPForbidden,
+	http.StatusNotFound:        cex.ErrHTTPNotFound,
+	http.StatusTooManyRequests: cex.ErrHTTPTooFrequency,
+}
+
+func HTTPStatusCodeChecker(code int) error {
+	if code == 200 {
+		return nil
+	}
+	if code >= 500 {
+		return cex.ErrHTTPCexInnerUnknownStatus
+	}
+	err := httpErrCodes[code]
+	if err != nil {
+		return err
+	}
+	return cex.ErrHTTPCodeNotInEnum
+}
+
+// ---------------------------------------------
+// HTTP Errors
+// =============================================
+
+// =============================================
+// Custom Errors
+// ---------------------------------------------
+
+var (
+	ErrInvalidSign       = errors.New("invalid sign")
+	ErrInvalidApiKey     = errors.New("invalid api key")
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+	ErrInvalidParameter  = errors.New("invalid parameter")
+)
+
+var cexCustomErrCodes = map[int]error{
+	429:   cex.ErrHTTPTooFrequency,
+	40006: ErrInvalidApiKey,
+	40008: cex.ErrInvalidTimestamp,
+	40009: ErrInvalidSign,
+	40011: ErrInvalidPassphrase,
+	40012: ErrInvalidApiKey, // apikey/password is incorrect
+	40017: ErrInvalidParameter,
+	40034: ErrInvalidParameter,
+	40037: ErrInvalidApiKey, // apikey does not exist
+	40762: cex.ErrInsufficientBalance,
+	40768: cex.ErrUnknownOrder,
+	43001: cex.ErrUnknownOrder,
+	43012: cex.ErrInsufficientBalance,
+}
+
+// ---------------------------------------------
+// Custom Errors
+// =============================================
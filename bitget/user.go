@@ -0,0 +1,708 @@
+package bitget
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/s2m"
+)
+
+type UserConfig struct {
+	// marginMode of mix orders, default is crossed
+	marginMode MarginMode
+	// set true if account position mode is hedge mode
+	isHedgeMode bool
+	simulated   bool
+	// timeSync corrects signed timestamp, may be nil
+	timeSync *cex.TimeSync
+	// waitOrderOpts are used by WaitOrder
+	waitOrderOpts []cex.WaitOrderOpt
+	// logger logs requests made by user, cex.DefaultLogger is used if it is nil
+	logger cex.Logger
+	// transport sends requests made by user, shared transport is used if it is nil
+	transport     cex.Transport
+	transportOpts []cex.TransportOpt
+	// backend sends requests made by user, cex.DefaultBackend is used if it is empty
+	backend cex.Backend
+}
+
+type User struct {
+	api cex.Api
+	cfg UserConfig
+
+	// ctx is set to every request made by user, if it is not nil.
+	ctx context.Context
+}
+
+type UserOpt func(*User)
+
+func UserOptMarginMode(mode MarginMode) UserOpt {
+	return func(user *User) {
+		user.cfg.marginMode = mode
+	}
+}
+
+// UserOptHedgeMode should be set if account position mode is hedge mode,
+// so tradeSide will be set to open for mix orders.
+func UserOptHedgeMode() UserOpt {
+	return func(user *User) {
+		user.cfg.isHedgeMode = true
+	}
+}
+
+// UserOptSimulated makes requests to bitget demo trading.
+// Product types of mix orders are switched to demo product types, ex. SUSDT-FUTURES.
+func UserOptSimulated() UserOpt {
+	return func(user *User) {
+		user.cfg.simulated = true
+	}
+}
+
+// UserOptTestnet is same as UserOptSimulated,
+// bitget demo trading shares base url with mainnet.
+func UserOptTestnet() UserOpt {
+	return func(user *User) {
+		user.api.Env = cex.EnvTestnet
+		user.cfg.simulated = true
+	}
+}
+
+// UserOptTimeSync makes user sign requests with server time of ts.
+func UserOptTimeSync(ts *cex.TimeSync) UserOpt {
+	return func(user *User) {
+		user.cfg.timeSync = ts
+	}
+}
+
+// UserOptWaitOrder sets options of WaitOrder, ex. poll interval,
+// transition callback and user data stream as update source.
+func UserOptWaitOrder(opts ...cex.WaitOrderOpt) UserOpt {
+	return func(user *User) {
+		user.cfg.waitOrderOpts = append(user.cfg.waitOrderOpts, opts...)
+	}
+}
+
+// UserOptLogger logs requests made by user with logger,
+// ex. cex.SlogLogger(slog.Default()).
+func UserOptLogger(logger cex.Logger) UserOpt {
+	return func(user *User) {
+		user.cfg.logger = logger
+	}
+}
+
+// UserOptTransport sends requests made by user by transport,
+// ex. cex.NewTransport(cex.TransportOptProxy(proxy)).
+// It can be overridden by cex.CltOptTransport per call.
+func UserOptTransport(transport cex.Transport) UserOpt {
+	return func(user *User) {
+		user.cfg.transport = transport
+	}
+}
+
+// UserOptBackend sends requests made by user by backend,
+// ex. cex.BackendNetHTTP for latency sensitive callers.
+// It can be overridden by cex.CltOptBackend per call.
+func UserOptBackend(backend cex.Backend) UserOpt {
+	return func(user *User) {
+		user.cfg.backend = backend
+	}
+}
+
+// UserOptProxy routes requests made by user through http, https or socks5 proxy,
+// ex. to send requests from the egress ip whitelisted by api key.
+// proxy can be parsed by cex.ParseProxyUrl.
+func UserOptProxy(proxy *url.URL) UserOpt {
+	return func(user *User) {
+		user.cfg.transportOpts = append(user.cfg.transportOpts, cex.TransportOptProxy(proxy))
+	}
+}
+
+// UserOptTLSConfig sets tls config of requests made by user.
+func UserOptTLSConfig(cfg *tls.Config) UserOpt {
+	return func(user *User) {
+		user.cfg.transportOpts = append(user.cfg.transportOpts, cex.TransportOptTLSConfig(cfg))
+	}
+}
+
+func NewUser(apiKey, secretKey, passphrase string, opts ...UserOpt) *User {
+	user := &User{
+		api: cex.Api{Cex: cex.BITGET, ApiKey: apiKey, SecretKey: secretKey, Passphrase: passphrase},
+		cfg: UserConfig{marginMode: MarginModeCrossed},
+	}
+	for _, opt := range opts {
+		opt(user)
+	}
+	user.cfg.transport = cex.ApplyTransportOpts(user.cfg.transport, user.cfg.transportOpts...)
+	return user
+}
+
+var emptyUser = &User{}
+
+func EmptyUser() *User {
+	return emptyUser
+}
+
+// ============================================================
+// User Getter
+// ------------------------------------------------------------
+
+func (u *User) Api() cex.Api {
+	return u.api
+}
+
+func (u *User) Config() UserConfig {
+	return u.cfg
+}
+
+// Context returns context bound to user, may be nil.
+func (u *User) Context() context.Context {
+	return u.ctx
+}
+
+// WithContext returns a shallow copy of user bound to ctx.
+func (u *User) WithContext(ctx context.Context) *User {
+	nu := *u
+	nu.ctx = ctx
+	return &nu
+}
+
+// ------------------------------------------------------------
+// User Getter
+// ============================================================
+
+// ============================================================
+// Public API
+// ------------------------------------------------------------
+
+func (u *User) ServerTime(opts ...cex.CltOpt) (*cex.Response, ServerTime, cex.RequestError) {
+	return cex.Request(u, ServerTimeConfig, nil, opts...)
+}
+
+func (u *User) SpotSymbols(symbol string, opts ...cex.CltOpt) (*cex.Response, []SpotSymbol, cex.RequestError) {
+	return cex.Request(u, SpotSymbolsConfig, SpotSymbolsParams{Symbol: symbol}, opts...)
+}
+
+// QueryServerTime returns bitget server time, unit is millisecond.
+func QueryServerTime() (int64, error) {
+	_, t, err := emptyUser.ServerTime()
+	if err.IsNotNil() {
+		return 0, errors.New(err.Error())
+	}
+	return int64(t.ServerTime), nil
+}
+
+// NewTimeSync creates cex.TimeSync by bitget server time.
+// Should call Sync or Start before using it.
+func NewTimeSync() *cex.TimeSync {
+	return cex.NewTimeSync(QueryServerTime)
+}
+
+// Ping pings bitget by server time endpoint.
+func Ping() cex.PingResult {
+	return cex.Ping(QueryServerTime)
+}
+
+// ------------------------------------------------------------
+// Public API
+// ============================================================
+
+// ============================================================
+// Account API
+// ------------------------------------------------------------
+
+// SpotAssets queries spot assets, coin can be empty.
+func (u *User) SpotAssets(coin string, opts ...cex.CltOpt) (*cex.Response, []SpotAsset, cex.RequestError) {
+	return cex.Request(u, SpotAssetsConfig, SpotAssetsParams{Coin: coin}, opts...)
+}
+
+func (u *User) MixAccounts(productType ProductType, opts ...cex.CltOpt) (*cex.Response, []MixAccount, cex.RequestError) {
+	return cex.Request(u, MixAccountsConfig, MixAccountsParams{ProductType: productType}, opts...)
+}
+
+// QueryBalances implements cex.BalanceQuerier.
+// It queries spot assets, and mix accounts of usdt, usdc and coin futures.
+func (u *User) QueryBalances(opts ...cex.CltOpt) ([]cex.Balance, cex.RequestError) {
+	var balances []cex.Balance
+	var firstErr cex.RequestError
+	if _, assets, err := u.SpotAssets("", opts...); err.IsNil() {
+		for _, a := range assets {
+			total := a.Available + a.Frozen + a.Locked
+			if total == 0 {
+				continue
+			}
+			balances = append(balances, cex.Balance{
+				Cex:    cex.BITGET,
+				Wallet: cex.WalletTypeSpot,
+				Asset:  a.Coin,
+				Total:  total.Float64(),
+				Free:   a.Available.Float64(),
+			})
+		}
+	} else {
+		firstErr = err
+	}
+	for _, productType := range []ProductType{ProductTypeUsdtFutures, ProductTypeUsdcFutures, ProductTypeCoinFutures} {
+		if u.cfg.simulated {
+			productType = "S" + productType
+		}
+		_, accts, err := u.MixAccounts(productType, opts...)
+		if err.IsNotNil() {
+			if firstErr.IsNil() {
+				firstErr = err
+			}
+			continue
+		}
+		for _, a := range accts {
+			if a.AccountEquity == 0 {
+				continue
+			}
+			balances = append(balances, cex.Balance{
+				Cex:           cex.BITGET,
+				Wallet:        cex.WalletTypeFutures,
+				Asset:         a.MarginCoin,
+				Total:         (a.AccountEquity - a.UnrealizedPL).Float64(),
+				Free:          a.Available.Float64(),
+				UnrealizedPnl: a.UnrealizedPL.Float64(),
+			})
+		}
+	}
+	return balances, firstErr
+}
+
+// MixPositions queries all positions of product type, marginCoin can be empty.
+func (u *User) MixPositions(productType ProductType, marginCoin string, opts ...cex.CltOpt) (*cex.Response, []MixPosition, cex.RequestError) {
+	return cex.Request(u, MixPositionsConfig, MixPositionsParams{ProductType: productType, MarginCoin: marginCoin}, opts...)
+}
+
+// ------------------------------------------------------------
+// Account API
+// ============================================================
+
+// ============================================================
+// Trade API
+// ------------------------------------------------------------
+
+func (u *User) NewSpotRawOrder(params SpotNewOrderParams, opts ...cex.CltOpt) (*cex.Response, OrderResult, cex.RequestError) {
+	return cex.Request(u, SpotNewOrderConfig, params, opts...)
+}
+
+func (u *User) CancelSpotRawOrder(symbol, orderId, clientOid string, opts ...cex.CltOpt) (*cex.Response, OrderResult, cex.RequestError) {
+	return cex.Request(u, SpotCancelOrderConfig, SpotCancelOrderParams{Symbol: symbol, OrderId: orderId, ClientOid: clientOid}, opts...)
+}
+
+func (u *User) QuerySpotRawOrder(orderId, clientOid string, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	return cex.Request(u, SpotQueryOrderConfig, SpotQueryOrderParams{OrderId: orderId, ClientOid: clientOid}, opts...)
+}
+
+func (u *User) NewMixRawOrder(params MixNewOrderParams, opts ...cex.CltOpt) (*cex.Response, OrderResult, cex.RequestError) {
+	return cex.Request(u, MixNewOrderConfig, params, opts...)
+}
+
+func (u *User) CancelMixRawOrder(symbol string, productType ProductType, orderId, clientOid string, opts ...cex.CltOpt) (*cex.Response, OrderResult, cex.RequestError) {
+	return cex.Request(u, MixCancelOrderConfig, MixCancelOrderParams{Symbol: symbol, ProductType: productType, OrderId: orderId, ClientOid: clientOid}, opts...)
+}
+
+func (u *User) QueryMixRawOrder(symbol string, productType ProductType, orderId, clientOid string, opts ...cex.CltOpt) (*cex.Response, MixOrder, cex.RequestError) {
+	return cex.Request(u, MixQueryOrderConfig, MixQueryOrderParams{Symbol: symbol, ProductType: productType, OrderId: orderId, ClientOid: clientOid}, opts...)
+}
+
+// ------------------------------------------------------------
+// Trade API
+// ============================================================
+
+// ============================================================
+// Trader Implementation
+// ------------------------------------------------------------
+
+func (u *User) QueryOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	return u.queryOrd(order, opts...)
+}
+
+func (u *User) CancelOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	return u.cancelOrd(order, opts...)
+}
+
+func (u *User) WaitOrder(ctx context.Context, order *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
+	return u.waitOrd(ctx, order, opts...)
+}
+
+// NewSpotOrder places spot order.
+// qty of market buy order is quote amount, because bitget spot market buy size is quote amount.
+func (u *User) NewSpotOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeSpot, asset, quote, tradeType, orderSide, qty, price, opts...)
+}
+
+func (u *User) NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (u *User) NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+// NewSpotMarketBuyOrder places spot market buy order, qty is quote amount.
+func (u *User) NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (u *User) NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+// NewFuturesOrder places mix order, product type is by quote, see ProductTypeOfQuote.
+// qty is base amount.
+func (u *User) NewFuturesOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeFutures, asset, quote, tradeType, orderSide, qty, price, opts...)
+}
+
+func (u *User) NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (u *User) NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (u *User) NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (u *User) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+// ------------------------------------------------------------
+// Trader Implementation
+// ============================================================
+
+// ============================================================
+// Private Trade Functions
+// ------------------------------------------------------------
+
+var ordTypeByCexOrdType = map[cex.OrderType]OrderType{
+	cex.OrderTypeLimit:  OrderTypeLimit,
+	cex.OrderTypeMarket: OrderTypeMarket,
+}
+
+var cexOrdTypeByOrdType = map[OrderType]cex.OrderType{
+	OrderTypeLimit:  cex.OrderTypeLimit,
+	OrderTypeMarket: cex.OrderTypeMarket,
+}
+
+var ordSideByCexOrdSide = map[cex.OrderSide]OrderSide{
+	cex.OrderSideBuy:  OrderSideBuy,
+	cex.OrderSideSell: OrderSideSell,
+}
+
+var cexOrdSideByOrdSide = map[OrderSide]cex.OrderSide{
+	OrderSideBuy:  cex.OrderSideBuy,
+	OrderSideSell: cex.OrderSideSell,
+}
+
+var cexOrdStatusByOrdStatus = map[OrderStatus]cex.OrderStatus{
+	OrderStatusInit:            cex.OrderStatusNew,
+	OrderStatusNew:             cex.OrderStatusNew,
+	OrderStatusLive:            cex.OrderStatusNew,
+	OrderStatusPartiallyFilled: cex.OrderStatusPartiallyFilled,
+	OrderStatusFilled:          cex.OrderStatusFilled,
+	OrderStatusCancelled:       cex.OrderStatusCanceled,
+	OrderStatusCanceled:        cex.OrderStatusCanceled,
+}
+
+// productTypeOfSymbol returns product type of mix symbol,
+// demo product type is returned if user is simulated.
+func (u *User) productTypeOfSymbol(symbol string) ProductType {
+	var productType ProductType
+	switch {
+	case strings.HasSuffix(symbol, "USDT"):
+		productType = ProductTypeUsdtFutures
+	case strings.HasSuffix(symbol, "USDC"):
+		productType = ProductTypeUsdcFutures
+	default:
+		productType = ProductTypeCoinFutures
+	}
+	if u.cfg.simulated {
+		productType = "S" + productType
+	}
+	return productType
+}
+
+func (u *User) newOrd(pairType cex.PairType, asset, quote string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	symbol := Symbol(asset, quote)
+	var force Force
+	if orderType == cex.OrderTypeLimit {
+		force = ForceGtc
+	}
+	ord := &cex.Order{
+		Cex:         cex.BITGET,
+		PairType:    pairType,
+		OrderType:   orderType,
+		OrderSide:   orderSide,
+		Symbol:      symbol,
+		TimeInForce: string(force),
+		ApiKey:      u.api.ApiKey,
+		OriQty:      qty,
+		OriPrice:    price,
+	}
+	var resp *cex.Response
+	var result OrderResult
+	var err cex.RequestError
+	switch pairType {
+	case cex.PairTypeSpot:
+		resp, result, err = u.NewSpotRawOrder(SpotNewOrderParams{
+			Symbol:    symbol,
+			Side:      ordSideByCexOrdSide[orderSide],
+			OrderType: ordTypeByCexOrdType[orderType],
+			Force:     force,
+			Price:     price,
+			Size:      qty,
+		}, opts...)
+	case cex.PairTypeFutures:
+		params := MixNewOrderParams{
+			Symbol:      symbol,
+			ProductType: u.productTypeOfSymbol(symbol),
+			MarginMode:  u.cfg.marginMode,
+			MarginCoin:  quote,
+			Size:        qty,
+			Price:       price,
+			Side:        ordSideByCexOrdSide[orderSide],
+			OrderType:   ordTypeByCexOrdType[orderType],
+			Force:       force,
+		}
+		if u.cfg.isHedgeMode {
+			params.TradeSide = TradeSideOpen
+		}
+		resp, result, err = u.NewMixRawOrder(params, opts...)
+	default:
+		return nil, ord, cex.RequestError{Err: fmt.Errorf("bitget: unknown pair type %v", pairType)}
+	}
+	if err.IsNil() {
+		ord.OrderId = result.OrderId
+		ord.ClientOrderId = result.ClientOid
+		ord.Status = cex.OrderStatusNew
+	}
+	return resp, ord, err
+}
+
+func (u *User) cancelOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	var resp *cex.Response
+	var err cex.RequestError
+	if ord.PairType == cex.PairTypeFutures {
+		resp, _, err = u.CancelMixRawOrder(ord.Symbol, u.productTypeOfSymbol(ord.Symbol), ord.OrderId, ord.ClientOrderId, opts...)
+	} else {
+		resp, _, err = u.CancelSpotRawOrder(ord.Symbol, ord.OrderId, ord.ClientOrderId, opts...)
+	}
+	if err.IsNotNil() {
+		return resp, err
+	}
+	// bitget cancel response does not contain order status
+	return u.queryOrd(ord, opts...)
+}
+
+func (u *User) queryOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	if ord.PairType == cex.PairTypeFutures {
+		resp, rawOrd, err := u.QueryMixRawOrder(ord.Symbol, u.productTypeOfSymbol(ord.Symbol), ord.OrderId, ord.ClientOrderId, opts...)
+		if err.IsNil() {
+			cex.MergeOrderUpdate(ord, SwitchMixOrderToCexOrder(rawOrd))
+		}
+		return resp, err
+	}
+	resp, rawOrd, err := u.QuerySpotRawOrder(ord.OrderId, ord.ClientOrderId, opts...)
+	if err.IsNil() {
+		cex.MergeOrderUpdate(ord, SwitchSpotOrderToCexOrder(rawOrd))
+	}
+	return resp, err
+}
+
+func (u *User) waitOrd(ctx context.Context, ord *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
+	query := func(ctx context.Context, ord *cex.Order) cex.RequestError {
+		_, err := u.WithContext(ctx).queryOrd(ord, opts...)
+		return err
+	}
+	return cex.WaitOrder(ctx, ord, query, u.cfg.waitOrderOpts...)
+}
+
+func SwitchSpotOrderToCexOrder(rawOrd SpotOrder) cex.Order {
+	return cex.Order{
+		Cex:            cex.BITGET,
+		PairType:       cex.PairTypeSpot,
+		OrderType:      cexOrdTypeByOrdType[rawOrd.OrderType],
+		OrderSide:      cexOrdSideByOrdSide[rawOrd.Side],
+		Symbol:         rawOrd.Symbol,
+		ClientOrderId:  rawOrd.ClientOid,
+		OriQty:         rawOrd.Size.Float64(),
+		OriPrice:       rawOrd.Price.Float64(),
+		OrderId:        rawOrd.OrderId,
+		Status:         cexOrdStatusByOrdStatus[rawOrd.Status],
+		FilledQty:      rawOrd.BaseVolume.Float64(),
+		FilledQuote:    rawOrd.QuoteVolume.Float64(),
+		FilledAvgPrice: rawOrd.PriceAvg.Float64(),
+		RawOrder:       rawOrd,
+	}
+}
+
+func SwitchMixOrderToCexOrder(rawOrd MixOrder) cex.Order {
+	ord := cex.Order{
+		Cex:            cex.BITGET,
+		PairType:       cex.PairTypeFutures,
+		OrderType:      cexOrdTypeByOrdType[rawOrd.OrderType],
+		OrderSide:      cexOrdSideByOrdSide[rawOrd.Side],
+		Symbol:         rawOrd.Symbol,
+		TimeInForce:    string(rawOrd.Force),
+		ClientOrderId:  rawOrd.ClientOid,
+		OriQty:         rawOrd.Size.Float64(),
+		OriPrice:       rawOrd.Price.Float64(),
+		OrderId:        rawOrd.OrderId,
+		Status:         cexOrdStatusByOrdStatus[rawOrd.State],
+		FilledQty:      rawOrd.BaseVolume.Float64(),
+		FilledQuote:    rawOrd.QuoteVolume.Float64(),
+		FilledAvgPrice: rawOrd.PriceAvg.Float64(),
+		RawOrder:       rawOrd,
+	}
+	if rawOrd.MarginCoin != "" && rawOrd.Fee != 0 {
+		// bitget fee is negative if it is charged
+		ord.Commissions = map[string]float64{rawOrd.MarginCoin: -rawOrd.Fee.Float64()}
+	}
+	return ord
+}
+
+// ToCexOrder implements cex.RawOrder.
+func (o SpotOrder) ToCexOrder() cex.Order {
+	return SwitchSpotOrderToCexOrder(o)
+}
+
+// ToCexOrder implements cex.RawOrder.
+func (o MixOrder) ToCexOrder() cex.Order {
+	return SwitchMixOrderToCexOrder(o)
+}
+
+// ------------------------------------------------------------
+// Private Trade Functions
+// ============================================================
+
+// ============================================================
+// ReqMaker
+// ------------------------------------------------------------
+
+// Logger implements cex.LoggerProvider.
+func (u *User) Logger() cex.Logger {
+	return u.cfg.logger
+}
+
+// CltOpts implements cex.CltOptsProvider.
+func (u *User) CltOpts() []cex.CltOpt {
+	var opts []cex.CltOpt
+	if u.cfg.transport != nil {
+		opts = append(opts, cex.CltOptTransport(u.cfg.transport))
+	}
+	if u.cfg.backend != "" {
+		opts = append(opts, cex.CltOptBackend(u.cfg.backend))
+	}
+	return opts
+}
+
+func (u *User) Make(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*cex.HTTPRequest, error) {
+	if err := cex.ValidateParams(reqData); err != nil {
+		return nil, err
+	}
+	// per call options override them, because they are applied later
+	opts = append(u.CltOpts(), opts...)
+	requestPath, body, err := composePathAndBody(config, reqData, cex.NewReqOpts(opts...).Query)
+	if err != nil {
+		return nil, err
+	}
+	req := cex.NewHTTPRequest(opts...).
+		SetHeaders(map[string]string{
+			"Content-Type": "application/json",
+			"locale":       "en-US",
+		})
+	req.URL = config.BaseUrl + requestPath
+	if u.cfg.simulated || u.api.Env.IsTestnet() {
+		req.SetHeader("paptrading", "1")
+	}
+	if config.IsUserData {
+		timestamp := strconv.FormatInt(u.cfg.timeSync.Now().UnixMilli(), 10)
+		req.SetHeaders(map[string]string{
+			"ACCESS-KEY":        u.api.ApiKey,
+			"ACCESS-SIGN":       sign(timestamp, config.Method, requestPath, body, u.api.SecretKey),
+			"ACCESS-TIMESTAMP":  timestamp,
+			"ACCESS-PASSPHRASE": u.api.Passphrase,
+		})
+	}
+	if body != "" {
+		req.SetBody(body)
+	}
+	if u.ctx != nil {
+		req.SetContext(u.ctx)
+	}
+	return req, nil
+}
+
+// composePathAndBody composes request path with query for GET and DELETE,
+// and json body for other methods.
+// bitget signs request path with query, so query must be composed by self.
+// query of CltOpts is merged into query of GET and DELETE.
+func composePathAndBody(config cex.ReqBaseConfig, reqData any, query url.Values) (requestPath, body string, err error) {
+	requestPath = config.Path
+	switch config.Method {
+	case http.MethodGet, http.MethodDelete:
+		val := url.Values{}
+		if reqData != nil {
+			m, err := s2m.ToStrMap(reqData)
+			if err != nil {
+				return "", "", fmt.Errorf("bitget: make request, %w: %w", cex.ErrS2M, err)
+			}
+			for k, v := range m {
+				val.Set(k, v)
+			}
+		}
+		for k, vs := range query {
+			val[k] = vs
+		}
+		if len(val) > 0 {
+			requestPath += "?" + val.Encode()
+		}
+	default:
+		if reqData == nil {
+			return
+		}
+		b, err := json.Marshal(reqData)
+		if err != nil {
+			return "", "", fmt.Errorf("bitget: make request, %w: %w", cex.ErrJsonMarshal, err)
+		}
+		body = string(b)
+	}
+	return
+}
+
+// ------------------------------------------------------------
+// ReqMaker
+// ============================================================
+
+// ============================================================
+// Signer
+// ------------------------------------------------------------
+
+// sign signs timestamp + method + requestPath + body by HmacSHA256,
+// and encodes it by base64.
+func sign(timestamp, method, requestPath, body, key string) string {
+	return cex.SignByHmacSHA256ToBase64(timestamp+method+requestPath+body, key)
+}
+
+// ------------------------------------------------------------
+// Signer
+// ============================================================
@@ -0,0 +1,88 @@
+package bitget
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/props"
+)
+
+func readApiKey() cex.Api {
+	apiKeys := cex.MustReadApiKey()
+	apiKey, ok := apiKeys["BITGETTEST"]
+	if !ok {
+		panic("no bitget api key")
+	}
+	return apiKey
+}
+
+func newTestUser() *User {
+	apiKey := readApiKey()
+	return NewUser(apiKey.ApiKey, apiKey.SecretKey, apiKey.Passphrase)
+}
+
+func TestSign(t *testing.T) {
+	s := sign("1695800000000", http.MethodGet, "/api/v2/spot/account/assets?coin=USDT", "", "secret")
+	if s != cex.SignByHmacSHA256ToBase64("1695800000000GET/api/v2/spot/account/assets?coin=USDT", "secret") {
+		t.Error("wrong sign", s)
+	}
+}
+
+func TestComposePathAndBody(t *testing.T) {
+	path, body, err := composePathAndBody(MixQueryOrderConfig.ReqBaseConfig, MixQueryOrderParams{Symbol: "BTCUSDT", ProductType: ProductTypeUsdtFutures, OrderId: "1"}, url.Values{"a": {"b"}})
+	props.PanicIfNotNil(err)
+	if path != "/api/v2/mix/order/detail?a=b&orderId=1&productType=USDT-FUTURES&symbol=BTCUSDT" || body != "" {
+		t.Error("wrong get path or body", path, body)
+	}
+	path, body, err = composePathAndBody(SpotNewOrderConfig.ReqBaseConfig, SpotNewOrderParams{Symbol: "BTCUSDT", Side: OrderSideBuy, OrderType: OrderTypeLimit, Force: ForceGtc, Price: 30000, Size: 0.01}, nil)
+	props.PanicIfNotNil(err)
+	if path != "/api/v2/spot/trade/place-order" || body != `{"symbol":"BTCUSDT","side":"buy","orderType":"limit","force":"gtc","price":"30000","size":"0.01"}` {
+		t.Error("wrong post path or body", path, body)
+	}
+}
+
+func TestBodyUnmsh(t *testing.T) {
+	_, err := bodyUnmsh[OrderResult]([]byte(`{"code":"43012","msg":"Insufficient balance","requestTime":1695800000000,"data":null}`))
+	if err == nil || !errors.Is(err.Err, cex.ErrInsufficientBalance) || err.CexErrCode != 43012 {
+		t.Fatal("err should be insufficient balance, but", err)
+	}
+	rawOrd, err := firstItemBodyUnmsh[SpotOrder]([]byte(`{"code":"00000","msg":"success","requestTime":1695800000000,"data":[{"symbol":"BTCUSDT","orderId":"1","clientOid":"c1","price":"30000","size":"0.01","orderType":"limit","side":"buy","status":"partially_filled","priceAvg":"30000","baseVolume":"0.005","quoteVolume":"150","feeDetail":""}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ord := SwitchSpotOrderToCexOrder(rawOrd)
+	props.PrintlnIndent(ord)
+	if ord.Status != cex.OrderStatusPartiallyFilled || ord.FilledQuote != 150 || ord.ClientOrderId != "c1" || ord.OrderSide != cex.OrderSideBuy {
+		t.Error("wrong order", ord)
+	}
+}
+
+func TestUser_ProductTypeOfSymbol(t *testing.T) {
+	if pt := NewUser("", "", "").productTypeOfSymbol("BTCUSDT"); pt != ProductTypeUsdtFutures {
+		t.Error("wrong product type", pt)
+	}
+	if pt := NewUser("", "", "", UserOptSimulated()).productTypeOfSymbol("BTCUSD"); pt != ProductTypeSCoinFutures {
+		t.Error("wrong demo product type", pt)
+	}
+}
+
+func TestUser_SpotSymbols(t *testing.T) {
+	_, data, err := EmptyUser().SpotSymbols("BTCUSDT")
+	props.PanicIfNotNil(err.Err)
+	props.PrintlnIndent(data)
+}
+
+func TestUser_SpotAssets(t *testing.T) {
+	_, data, err := newTestUser().SpotAssets("")
+	props.PanicIfNotNil(err.Err)
+	props.PrintlnIndent(data)
+}
+
+func TestUser_MixPositions(t *testing.T) {
+	_, data, err := newTestUser().MixPositions(ProductTypeUsdtFutures, "USDT")
+	props.PanicIfNotNil(err.Err)
+	props.PrintlnIndent(data)
+}
@@ -0,0 +1,387 @@
+package bnc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Account Reconciler
+// ------------------------------------------------------------
+
+const (
+	DefaultReconcileInterval  = time.Minute
+	DefaultReconcileTolerance = 1e-8
+)
+
+type AccountDriftKind string
+
+const (
+	// AccountDriftBalance means free or locked of asset is different.
+	AccountDriftBalance AccountDriftKind = "BALANCE"
+	// AccountDriftOrderMissing means order is open in REST, but not in local model.
+	AccountDriftOrderMissing AccountDriftKind = "ORDER_MISSING"
+	// AccountDriftOrderStale means order is open in local model, but not in REST.
+	AccountDriftOrderStale AccountDriftKind = "ORDER_STALE"
+	// AccountDriftOrderMismatch means status or executed qty of open order is different.
+	AccountDriftOrderMismatch AccountDriftKind = "ORDER_MISMATCH"
+)
+
+// AccountDrift is a difference between local model and REST.
+// Asset is set for balance drifts, Symbol and OrderId for order drifts.
+type AccountDrift struct {
+	Kind    AccountDriftKind `json:"kind" bson:"kind"`
+	Asset   string           `json:"asset" bson:"asset"`
+	Symbol  string           `json:"symbol" bson:"symbol"`
+	OrderId int64            `json:"orderId" bson:"orderId"`
+	Local   string           `json:"local" bson:"local"`
+	Remote  string           `json:"remote" bson:"remote"`
+}
+
+// AccountDriftHandler handles drifts of one reconciliation.
+type AccountDriftHandler func(drifts []AccountDrift)
+
+func slogAccountDriftHandler(drifts []AccountDrift) {
+	for _, d := range drifts {
+		slog.Warn("Binance account drift", "kind", d.Kind, "asset", d.Asset, "symbol", d.Symbol, "orderId", d.OrderId, "local", d.Local, "remote", d.Remote)
+	}
+}
+
+type AccountReconcilerOpt func(*AccountReconciler)
+
+// AccountReconcilerOptInterval sets interval of Run, default DefaultReconcileInterval.
+func AccountReconcilerOptInterval(interval time.Duration) AccountReconcilerOpt {
+	return func(r *AccountReconciler) {
+		r.interval = interval
+	}
+}
+
+// AccountReconcilerOptTolerance sets max difference of balances and qty treated as equal.
+func AccountReconcilerOptTolerance(tolerance float64) AccountReconcilerOpt {
+	return func(r *AccountReconciler) {
+		r.tolerance = tolerance
+	}
+}
+
+// AccountReconcilerOptDriftHandler replaces the default handler, which logs drifts by slog at warn level.
+func AccountReconcilerOptDriftHandler(handler AccountDriftHandler) AccountReconcilerOpt {
+	return func(r *AccountReconciler) {
+		r.onDrift = handler
+	}
+}
+
+type reconcilerBalance struct {
+	SpotBalance
+	updateTime int64
+}
+
+// AccountReconciler keeps a local model of spot balances and open orders,
+// which is updated by user data stream events, and periodically reconciled against REST.
+// Drifts found by reconciliation are passed to AccountDriftHandler,
+// and local model is replaced by REST.
+//
+// Assets and orders updated by events after a reconciliation request is sent
+// are not compared, because REST response may be older than them.
+//
+//	r := NewAccountReconciler(user)
+//	go r.Run(ctx)
+//	// for every message of user data stream
+//	err := r.HandleMsg(msg)
+type AccountReconciler struct {
+	user      *User
+	interval  time.Duration
+	tolerance float64
+	onDrift   AccountDriftHandler
+
+	mux      sync.RWMutex
+	balances map[string]reconcilerBalance
+	orders   map[int64]SpotOrder
+	// closedOrders are closed order ids by local events, whose update time is kept,
+	// so closed orders in REST responses older than events are not treated as missing.
+	closedOrders map[int64]int64
+	synced       bool
+}
+
+func NewAccountReconciler(user *User, opts ...AccountReconcilerOpt) *AccountReconciler {
+	r := &AccountReconciler{
+		user:         user,
+		interval:     DefaultReconcileInterval,
+		tolerance:    DefaultReconcileTolerance,
+		onDrift:      slogAccountDriftHandler,
+		balances:     map[string]reconcilerBalance{},
+		orders:       map[int64]SpotOrder{},
+		closedOrders: map[int64]int64{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// HandleMsg applies message of spot user data stream.
+// Messages of other events are ignored.
+func (r *AccountReconciler) HandleMsg(data []byte) error {
+	var event WsUserDataEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return fmt.Errorf("bnc: unmarshal user data event, %w", err)
+	}
+	switch event.EventType {
+	case WsOutboundAccountPosition:
+		var pos WsAccountPosition
+		if err := json.Unmarshal(data, &pos); err != nil {
+			return fmt.Errorf("bnc: unmarshal %v, %w", event.EventType, err)
+		}
+		r.ApplyAccountPosition(pos)
+	case WsBalanceUpdate:
+		var update WsBalanceUpdateStream
+		if err := json.Unmarshal(data, &update); err != nil {
+			return fmt.Errorf("bnc: unmarshal %v, %w", event.EventType, err)
+		}
+		r.ApplyBalanceUpdate(update)
+	case WsExecutionReport:
+		var report WsExecutionReportStream
+		if err := json.Unmarshal(data, &report); err != nil {
+			return fmt.Errorf("bnc: unmarshal %v, %w", event.EventType, err)
+		}
+		r.ApplyExecutionReport(report)
+	}
+	return nil
+}
+
+// ApplyAccountPosition replaces balances of assets in event.
+func (r *AccountReconciler) ApplyAccountPosition(pos WsAccountPosition) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for _, b := range pos.Balances {
+		r.balances[b.Asset] = reconcilerBalance{
+			SpotBalance: SpotBalance{Asset: b.Asset, Free: b.Free, Locked: b.Locked},
+			updateTime:  pos.EventTime,
+		}
+	}
+}
+
+// ApplyBalanceUpdate adds delta to free balance of asset.
+func (r *AccountReconciler) ApplyBalanceUpdate(update WsBalanceUpdateStream) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	b := r.balances[update.Asset]
+	b.Asset = update.Asset
+	b.Free += update.BalanceDelta
+	b.updateTime = update.EventTime
+	r.balances[update.Asset] = b
+}
+
+// ApplyExecutionReport updates open order, order is removed if it is closed.
+func (r *AccountReconciler) ApplyExecutionReport(report WsExecutionReportStream) {
+	ord := report.SpotOrder()
+	ord.UpdateTime = report.EventTime
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if spotOrderClosed(ord.Status) {
+		delete(r.orders, ord.OrderId)
+		r.closedOrders[ord.OrderId] = report.EventTime
+		return
+	}
+	if old, ok := r.orders[ord.OrderId]; ok && old.UpdateTime > ord.UpdateTime {
+		return
+	}
+	r.orders[ord.OrderId] = ord
+}
+
+func spotOrderClosed(status OrderStatus) bool {
+	switch status {
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired, "EXPIRED_IN_MATCH":
+		return true
+	}
+	return false
+}
+
+// Balance returns local balance of asset.
+func (r *AccountReconciler) Balance(asset string) (SpotBalance, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	b, ok := r.balances[asset]
+	return b.SpotBalance, ok
+}
+
+// Balances returns local balances sorted by asset.
+func (r *AccountReconciler) Balances() []SpotBalance {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	balances := make([]SpotBalance, 0, len(r.balances))
+	for _, b := range r.balances {
+		balances = append(balances, b.SpotBalance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Asset < balances[j].Asset
+	})
+	return balances
+}
+
+// OpenOrders returns local open orders of symbol sorted by order id,
+// or of all symbols if symbol is empty.
+func (r *AccountReconciler) OpenOrders(symbol string) []SpotOrder {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	var orders []SpotOrder
+	for _, o := range r.orders {
+		if symbol == "" || o.Symbol == symbol {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].OrderId < orders[j].OrderId
+	})
+	return orders
+}
+
+// Reconcile queries spot account and open orders, compares them with local model,
+// passes drifts to AccountDriftHandler, and replaces local model by them.
+// The first reconciliation initializes local model, and returns no drifts.
+func (r *AccountReconciler) Reconcile(opts ...cex.CltOpt) ([]AccountDrift, cex.RequestError) {
+	// sentAt is compared with event times, so it is server time
+	sentAt := r.user.cfg.timeSync.Now().UnixMilli()
+	_, account, err := r.user.SpotAccount(opts...)
+	if err.IsNotNil() {
+		return nil, err
+	}
+	_, orders, err := r.user.OpenSpotOrders("", opts...)
+	if err.IsNotNil() {
+		return nil, err
+	}
+
+	r.mux.Lock()
+	drifts := r.reconcileBalances(account.Balances, sentAt)
+	drifts = append(drifts, r.reconcileOrders(orders, sentAt)...)
+	if !r.synced {
+		// the first snapshot initializes local model
+		drifts = nil
+		r.synced = true
+	}
+	r.mux.Unlock()
+
+	if len(drifts) > 0 && r.onDrift != nil {
+		r.onDrift(drifts)
+	}
+	return drifts, cex.RequestError{}
+}
+
+func (r *AccountReconciler) reconcileBalances(remote []SpotBalance, sentAt int64) []AccountDrift {
+	var drifts []AccountDrift
+	seen := map[string]bool{}
+	for _, rb := range remote {
+		seen[rb.Asset] = true
+		local, ok := r.balances[rb.Asset]
+		if ok && local.updateTime >= sentAt {
+			continue
+		}
+		if !r.equal(local.Free, rb.Free) || !r.equal(local.Locked, rb.Locked) {
+			drifts = append(drifts, AccountDrift{
+				Kind:   AccountDriftBalance,
+				Asset:  rb.Asset,
+				Local:  fmt.Sprintf("free %v, locked %v", local.Free, local.Locked),
+				Remote: fmt.Sprintf("free %v, locked %v", rb.Free, rb.Locked),
+			})
+		}
+		r.balances[rb.Asset] = reconcilerBalance{SpotBalance: rb}
+	}
+	// assets with zero balance are omitted by REST
+	for asset, local := range r.balances {
+		if seen[asset] || local.updateTime >= sentAt {
+			continue
+		}
+		if !r.equal(local.Free, 0) || !r.equal(local.Locked, 0) {
+			drifts = append(drifts, AccountDrift{
+				Kind:   AccountDriftBalance,
+				Asset:  asset,
+				Local:  fmt.Sprintf("free %v, locked %v", local.Free, local.Locked),
+				Remote: "free 0, locked 0",
+			})
+		}
+		delete(r.balances, asset)
+	}
+	sort.SliceStable(drifts, func(i, j int) bool {
+		return drifts[i].Asset < drifts[j].Asset
+	})
+	return drifts
+}
+
+func (r *AccountReconciler) reconcileOrders(remote []SpotOrder, sentAt int64) []AccountDrift {
+	var drifts []AccountDrift
+	seen := map[int64]bool{}
+	for _, ro := range remote {
+		seen[ro.OrderId] = true
+		if closedAt, ok := r.closedOrders[ro.OrderId]; ok && closedAt >= sentAt {
+			continue
+		}
+		local, ok := r.orders[ro.OrderId]
+		switch {
+		case ok && local.UpdateTime >= sentAt:
+			continue
+		case !ok:
+			drifts = append(drifts, AccountDrift{
+				Kind: AccountDriftOrderMissing, Symbol: ro.Symbol, OrderId: ro.OrderId,
+				Remote: fmt.Sprintf("%v, executed %v", ro.Status, ro.ExecutedQty),
+			})
+		case local.Status != ro.Status || !r.equal(local.ExecutedQty, ro.ExecutedQty):
+			drifts = append(drifts, AccountDrift{
+				Kind: AccountDriftOrderMismatch, Symbol: ro.Symbol, OrderId: ro.OrderId,
+				Local:  fmt.Sprintf("%v, executed %v", local.Status, local.ExecutedQty),
+				Remote: fmt.Sprintf("%v, executed %v", ro.Status, ro.ExecutedQty),
+			})
+		}
+		r.orders[ro.OrderId] = ro
+	}
+	for id, local := range r.orders {
+		if seen[id] || local.UpdateTime >= sentAt {
+			continue
+		}
+		drifts = append(drifts, AccountDrift{
+			Kind: AccountDriftOrderStale, Symbol: local.Symbol, OrderId: id,
+			Local: fmt.Sprintf("%v, executed %v", local.Status, local.ExecutedQty),
+		})
+		delete(r.orders, id)
+	}
+	for id, closedAt := range r.closedOrders {
+		if closedAt < sentAt {
+			delete(r.closedOrders, id)
+		}
+	}
+	sort.SliceStable(drifts, func(i, j int) bool {
+		return drifts[i].OrderId < drifts[j].OrderId
+	})
+	return drifts
+}
+
+func (r *AccountReconciler) equal(a, b float64) bool {
+	return math.Abs(a-b) <= r.tolerance
+}
+
+// Run reconciles immediately, and then every interval until ctx is done.
+// Request errors are logged by slog, and do not stop Run.
+func (r *AccountReconciler) Run(ctx context.Context, opts ...cex.CltOpt) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.Reconcile(opts...); err.IsNotNil() {
+			slog.Error("Binance account reconciliation failed", "err", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ------------------------------------------------------------
+// Account Reconciler
+// ============================================================
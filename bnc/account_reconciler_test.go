@@ -0,0 +1,121 @@
+package bnc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestAccountReconciler(t *testing.T) {
+	account := `{"balances":[{"asset":"ETH","free":"1","locked":"0"},{"asset":"USDT","free":"1000","locked":"100"}]}`
+	openOrders := `[{"symbol":"ETHUSDT","orderId":1,"status":"NEW","executedQty":"0","price":"2000","origQty":"0.05"}]`
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := account
+		if r.URL.Path == ApiV3+"/openOrders" {
+			body = openOrders
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(body))), Request: r}, nil
+	})
+	var drifts []AccountDrift
+	r := NewAccountReconciler(NewUser("key", "secret", UserOptTransport(transport)), AccountReconcilerOptDriftHandler(func(d []AccountDrift) {
+		drifts = d
+	}))
+
+	if d, err := r.Reconcile(); err.IsNotNil() || len(d) != 0 {
+		t.Fatal("first reconciliation should initialize local model", d, err.Error())
+	}
+	if b, ok := r.Balance("USDT"); !ok || b.Locked != 100 || len(r.OpenOrders("ETHUSDT")) != 1 {
+		t.Fatal("wrong local model", b, r.OpenOrders(""))
+	}
+
+	msgs := []string{
+		`{"e":"executionReport","E":1000,"s":"ETHUSDT","c":"c2","S":"BUY","o":"LIMIT","f":"GTC","q":"0.1","p":"2100","x":"NEW","X":"NEW","i":2,"I":9,"z":"0","w":true,"m":false,"M":false,"O":1000,"T":1000,"V":"NONE"}`,
+		`{"e":"outboundAccountPosition","E":1000,"u":1000,"B":[{"a":"USDT","f":"790","l":"310"}]}`,
+		`{"e":"balanceUpdate","E":1001,"a":"ETH","d":"0.5","T":1001}`,
+		`{"e":"executionReport","E":1002,"s":"ETHUSDT","c":"c3","C":"c1","S":"BUY","o":"LIMIT","x":"CANCELED","X":"CANCELED","i":1,"z":"0"}`,
+		`{"e":"listStatus","E":1003}`,
+	}
+	for _, msg := range msgs {
+		if err := r.HandleMsg([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	orders := r.OpenOrders("")
+	if len(orders) != 1 || orders[0].OrderId != 2 || orders[0].ClientOrderId != "c2" || orders[0].Price != 2100 {
+		t.Fatal("wrong open orders", orders)
+	}
+	if b, _ := r.Balance("ETH"); b.Free != 1.5 {
+		t.Error("wrong eth balance", b)
+	}
+
+	// order 1 is closed by stream, but REST missed order 2 and balance update of ETH
+	account = `{"balances":[{"asset":"ETH","free":"1","locked":"0"},{"asset":"USDT","free":"790","locked":"310"}]}`
+	openOrders = `[{"symbol":"ETHUSDT","orderId":2,"status":"PARTIALLY_FILLED","executedQty":"0.05"},{"symbol":"ETHUSDT","orderId":3,"status":"NEW","executedQty":"0"}]`
+	d, err := r.Reconcile()
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(d) != 3 || len(drifts) != 3 {
+		t.Fatal("wrong drifts", d)
+	}
+	if d[0].Kind != AccountDriftBalance || d[0].Asset != "ETH" ||
+		d[1].Kind != AccountDriftOrderMismatch || d[1].OrderId != 2 ||
+		d[2].Kind != AccountDriftOrderMissing || d[2].OrderId != 3 {
+		t.Error("wrong drifts", d)
+	}
+	if b, _ := r.Balance("ETH"); b.Free != 1 || len(r.OpenOrders("")) != 2 {
+		t.Error("local model should be replaced by REST", b, r.OpenOrders(""))
+	}
+
+	openOrders = `[]`
+	d, _ = r.Reconcile()
+	if len(d) != 2 || d[0].Kind != AccountDriftOrderStale || len(r.OpenOrders("")) != 0 {
+		t.Error("stale orders should be removed", d)
+	}
+}
+
+func TestAccountReconciler_TimeSync(t *testing.T) {
+	// server clock is one hour behind local clock
+	ts := cex.NewTimeSync(func() (int64, error) {
+		return time.Now().Add(-time.Hour).UnixMilli(), nil
+	})
+	if err := ts.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	var r *AccountReconciler
+	var onAccount func()
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `[]`
+		if req.URL.Path == ApiV3+"/account" {
+			body = `{"balances":[{"asset":"ETH","free":"1","locked":"0"}]}`
+			if onAccount != nil {
+				onAccount()
+			}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(body))), Request: req}, nil
+	})
+	r = NewAccountReconciler(NewUser("key", "secret", UserOptTransport(transport), UserOptTimeSync(ts)))
+	if _, err := r.Reconcile(); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+
+	// balance update is pushed while account is being queried, so REST may miss it
+	onAccount = func() {
+		msg := fmt.Sprintf(`{"e":"balanceUpdate","E":%v,"a":"ETH","d":"0.5","T":%[1]v}`, ts.Now().UnixMilli())
+		if err := r.HandleMsg([]byte(msg)); err != nil {
+			t.Error(err)
+		}
+	}
+	d, err := r.Reconcile()
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(d) != 0 {
+		t.Error("balance updated after request is sent should not be drift", d)
+	}
+}
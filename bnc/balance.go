@@ -0,0 +1,90 @@
+package bnc
+
+import (
+	"github.com/dwdwow/cex"
+)
+
+// QueryBalances implements cex.BalanceQuerier.
+// It queries spot, usd-m futures, and simple earn flexible and locked balances.
+// Zero balances are omitted.
+// Portfolio margin account is not supported.
+func (u *User) QueryBalances(opts ...cex.CltOpt) ([]cex.Balance, cex.RequestError) {
+	var balances []cex.Balance
+	var firstErr cex.RequestError
+	setErr := func(err cex.RequestError) {
+		if firstErr.IsNil() {
+			firstErr = err
+		}
+	}
+
+	if _, acct, err := u.SpotAccount(opts...); err.IsNil() {
+		balances = append(balances, SpotBalancesToCexBalances(acct.Balances)...)
+	} else {
+		setErr(err)
+	}
+
+	if _, acct, err := u.FuturesAccount(opts...); err.IsNil() {
+		balances = append(balances, FuturesAssetsToCexBalances(acct.Assets)...)
+	} else {
+		setErr(err)
+	}
+
+	if _, page, err := u.SimpleEarnFlexiblePositions("", "", opts...); err.IsNil() {
+		for _, p := range page.Rows {
+			if p.TotalAmount == 0 {
+				continue
+			}
+			balances = append(balances, cex.Balance{Cex: cex.BINANCE, Wallet: cex.WalletTypeEarn, Asset: p.Asset, Total: p.TotalAmount})
+		}
+	} else {
+		setErr(err)
+	}
+
+	if _, page, err := u.SimpleEarnLockedPositions("", "", opts...); err.IsNil() {
+		for _, p := range page.Rows {
+			if p.Amount == 0 {
+				continue
+			}
+			balances = append(balances, cex.Balance{Cex: cex.BINANCE, Wallet: cex.WalletTypeEarn, Asset: p.Asset, Total: p.Amount})
+		}
+	} else {
+		setErr(err)
+	}
+
+	return balances, firstErr
+}
+
+func SpotBalancesToCexBalances(spotBalances []SpotBalance) []cex.Balance {
+	var balances []cex.Balance
+	for _, b := range spotBalances {
+		if b.Free == 0 && b.Locked == 0 {
+			continue
+		}
+		balances = append(balances, cex.Balance{
+			Cex:    cex.BINANCE,
+			Wallet: cex.WalletTypeSpot,
+			Asset:  b.Asset,
+			Total:  b.Free + b.Locked,
+			Free:   b.Free,
+		})
+	}
+	return balances
+}
+
+func FuturesAssetsToCexBalances(assets []FuturesAccountAsset) []cex.Balance {
+	var balances []cex.Balance
+	for _, a := range assets {
+		if a.WalletBalance == 0 && a.UnrealizedProfit == 0 {
+			continue
+		}
+		balances = append(balances, cex.Balance{
+			Cex:           cex.BINANCE,
+			Wallet:        cex.WalletTypeFutures,
+			Asset:         a.Asset,
+			Total:         a.WalletBalance,
+			Free:          a.AvailableBalance,
+			UnrealizedPnl: a.UnrealizedProfit,
+		})
+	}
+	return balances
+}
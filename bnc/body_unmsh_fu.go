@@ -41,7 +41,15 @@ func fuBodyUnmshCodeMsg(body []byte) *cex.RespBodyUnmarshalerError {
 		}
 	}
 
-	errCtm := spotCexCustomErrCodes[code]
+	return &cex.RespBodyUnmarshalerError{
+		CexErrCode: code,
+		CexErrMsg:  msg,
+		Err:        fuCodeMsgErr(code, msg),
+	}
+}
+
+func fuCodeMsgErr(code int, msg string) error {
+	errCtm := customErrByCodeMsg(code, msg)
 	//switch errCtm {
 	//case ErrFutureNoNeedToChangePositionSide:
 	//	return nil
@@ -50,10 +58,21 @@ func fuBodyUnmshCodeMsg(body []byte) *cex.RespBodyUnmarshalerError {
 	if errCtm == nil {
 		errCtm = fmt.Errorf("%v, %v", code, msg)
 	}
+	return fmt.Errorf("bnc: %w", errCtm)
+}
 
-	return &cex.RespBodyUnmarshalerError{
-		CexErrCode: code,
-		CexErrMsg:  msg,
-		Err:        fmt.Errorf("bnc: %w", errCtm),
+// fuBatchOrdersBodyUnmsh unmarshals response of batch orders,
+// which is an array mixed with orders and code msg errors.
+// Err of every result is set by its code and msg.
+func fuBatchOrdersBodyUnmsh(body []byte) ([]FuturesBatchOrderResult, *cex.RespBodyUnmarshalerError) {
+	results, err := cex.StdBodyUnmarshaler[[]FuturesBatchOrderResult](body)
+	if err != nil {
+		return results, err
+	}
+	for i, res := range results {
+		if res.Code < 0 {
+			results[i].Err = fuCodeMsgErr(res.Code, res.Msg)
+		}
 	}
+	return results, nil
 }
@@ -0,0 +1,17 @@
+package bnc
+
+import (
+	"github.com/dwdwow/cex"
+)
+
+// optBodyUnmshWrapper checks code msg of options responses,
+// which are same as futures, code 0 or 200 is success.
+func optBodyUnmshWrapper[D any](unmarshaler cex.RespBodyUnmarshaler[D]) cex.RespBodyUnmarshaler[D] {
+	return func(body []byte) (D, *cex.RespBodyUnmarshalerError) {
+		var d D
+		if err := fuBodyUnmshCodeMsg(body); err != nil {
+			return d, err
+		}
+		return unmarshaler(body)
+	}
+}
@@ -1,10 +1,12 @@
 package bnc
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/dwdwow/cex"
 	"github.com/dwdwow/cex/ob"
@@ -41,6 +43,20 @@ func obBodyUnmsher(body []byte) (OrderBook, *cex.RespBodyUnmarshalerError) {
 	return OrderBook{Bids: bids, Asks: asks, LastUpdateId: raw.LastUpdateId, E: raw.E, T: raw.T}, nil
 }
 
+// arrayBodyUnmshWrapper unmarshals json array body by unmarshaler directly,
+// because error responses are always objects,
+// and checking code msg of wrapper scans the whole body of all symbols once more.
+// Other bodies are checked by wrapper.
+func arrayBodyUnmshWrapper[D any](wrapper func(cex.RespBodyUnmarshaler[D]) cex.RespBodyUnmarshaler[D], unmarshaler cex.RespBodyUnmarshaler[D]) cex.RespBodyUnmarshaler[D] {
+	checked := wrapper(unmarshaler)
+	return func(body []byte) (D, *cex.RespBodyUnmarshalerError) {
+		if b := bytes.TrimLeft(body, " \t\r\n"); len(b) > 0 && b[0] == '[' {
+			return unmarshaler(body)
+		}
+		return checked(body)
+	}
+}
+
 var klineLastIndex = len(klineMapKeys) - 1
 
 func klineBodyUnmsher(body []byte) ([]Kline, *cex.RespBodyUnmarshalerError) {
@@ -72,8 +88,11 @@ func UnmarshalRawKline(kline RawKline) (Kline, error) {
 			s = strconv.FormatInt(v, 10)
 		case float64:
 			s = strconv.FormatFloat(v, 'f', -1, 64)
+		case json.Number:
+			s = v.String()
 		case string:
-			s = v
+			// mark price klines may contain "0 "
+			s = strings.TrimSpace(v)
 		default:
 			return Kline{}, fmt.Errorf("bnc: unknown raw kline element type %v", v)
 		}
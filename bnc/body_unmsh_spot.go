@@ -18,6 +18,21 @@ func spotBodyUnmshWrapper[D any](unmarshaler cex.RespBodyUnmarshaler[D]) cex.Res
 	}
 }
 
+// walletBodyUnmshWrapper is same as spotBodyUnmshWrapper,
+// but SAPI wallet error codes are checked firstly.
+func walletBodyUnmshWrapper[D any](unmarshaler cex.RespBodyUnmarshaler[D]) cex.RespBodyUnmarshaler[D] {
+	return func(body []byte) (D, *cex.RespBodyUnmarshalerError) {
+		var d D
+		if err := spotBodyUnmshCodeMsg(body); err != nil {
+			if errCtm := sapiWalletCustomErrCodes[err.CexErrCode]; errCtm != nil {
+				err.Err = fmt.Errorf("bnc: %w", errCtm)
+			}
+			return d, err
+		}
+		return unmarshaler(body)
+	}
+}
+
 func spotBodyUnmshCodeMsg(body []byte) *cex.RespBodyUnmarshalerError {
 	codeMsg := CodeMsg{}
 
@@ -56,7 +71,7 @@ func spotBodyUnmshCodeMsg(body []byte) *cex.RespBodyUnmarshalerError {
 		}
 	}
 
-	errCtm := spotCexCustomErrCodes[code]
+	errCtm := customErrByCodeMsg(code, msg)
 	if errCtm == nil {
 		errCtm = fmt.Errorf("%v, %v", code, msg)
 	}
@@ -1,19 +1,83 @@
 package bnc
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dwdwow/cex"
+)
+
 const (
 	ApiBaseUrl  = "https://api.binance.com"
 	ApiV3       = "/api/v3"
 	SapiV1      = "/sapi/v1"
 	SapiV2      = "/sapi/v2"
+	SapiV4      = "/sapi/v4"
 	FapiBaseUrl = "https://fapi.binance.com"
 	FapiV1      = "/fapi/v1"
 	FapiV2      = "/fapi/v2"
+	FuturesData = "/futures/data"
 	PapiBaseUrl = "https://papi.binance.com"
 	PapiV1      = "/papi/v1"
 	DapiBaseUrl = "https://dapi.binance.com"
 	DapiV1      = "/dapi/v1"
+	EapiBaseUrl = "https://eapi.binance.com"
+	EapiV1      = "/eapi/v1"
+)
+
+const (
+	TestnetApiBaseUrl  = "https://testnet.binance.vision"
+	TestnetFapiBaseUrl = "https://testnet.binancefuture.com"
+	TestnetDapiBaseUrl = "https://testnet.binancefuture.com"
 )
 
+// testnetBaseUrls maps mainnet base urls to testnet.
+// Portfolio margin and options have no testnet.
+var testnetBaseUrls = map[string]string{
+	ApiBaseUrl:  TestnetApiBaseUrl,
+	FapiBaseUrl: TestnetFapiBaseUrl,
+	DapiBaseUrl: TestnetDapiBaseUrl,
+}
+
+// TestnetBaseUrl returns testnet base url of mainnet base url.
+func TestnetBaseUrl(baseUrl string) (string, error) {
+	testnet, ok := testnetBaseUrls[baseUrl]
+	if !ok {
+		return "", fmt.Errorf("bnc: %w for %v", cex.ErrNoTestnet, baseUrl)
+	}
+	return testnet, nil
+}
+
+// Spot api clusters, which can be used if ApiBaseUrl is unavailable.
+// They share rate limits of ApiBaseUrl.
+const (
+	Api1BaseUrl   = "https://api1.binance.com"
+	Api2BaseUrl   = "https://api2.binance.com"
+	Api3BaseUrl   = "https://api3.binance.com"
+	Api4BaseUrl   = "https://api4.binance.com"
+	ApiGcpBaseUrl = "https://api-gcp.binance.com"
+)
+
+// NewBaseUrlFailover creates cex.BaseUrlFailover,
+// which fails over from ApiBaseUrl to api-gcp and api1-api4 clusters.
+// It can be set by UserOptBaseUrlSelector and PublicClientOptBaseUrlSelector,
+// and be started with CheckSpotBaseUrl to restore clusters.
+func NewBaseUrlFailover(opts ...cex.BaseUrlFailoverOpt) *cex.BaseUrlFailover {
+	return cex.NewBaseUrlFailover(opts...).
+		SetBaseUrls(ApiBaseUrl, ApiGcpBaseUrl, Api1BaseUrl, Api2BaseUrl, Api3BaseUrl, Api4BaseUrl)
+}
+
+// CheckSpotBaseUrl implements cex.BaseUrlChecker by spot ping endpoint.
+func CheckSpotBaseUrl(baseUrl string) error {
+	config := SpotPingConfig
+	config.BaseUrl = baseUrl
+	_, _, err := cex.Request(emptyUser, config, nil)
+	if err.IsNotNil() {
+		return errors.New(err.Error())
+	}
+	return nil
+}
+
 const (
 	SpotSymbolMid    = ""
 	FuturesSymbolMid = ""
@@ -50,7 +114,8 @@ const (
 type AccountType string
 
 const (
-	AccountTypeSpot AccountType = "SPOT"
+	AccountTypeSpot   AccountType = "SPOT"
+	AccountTypeMargin AccountType = "MARGIN"
 )
 
 type WalletType int
@@ -135,6 +200,7 @@ const (
 	OrderTypeStopMarket         OrderType = "STOP_MARKET"
 	OrderTypeTakeProfitMarket   OrderType = "TAKE_PROFIT_MARKET"
 	OrderTypeTrailingStopMarket           = "TRAILING_STOP_MARKET"
+	OrderTypeLiquidation        OrderType = "LIQUIDATION"
 )
 
 type OrderStatus string
@@ -158,14 +224,59 @@ const (
 	OrderExecutionRejected     OrderExecutionType = "REJECTED"
 	OrderExecutionTrade        OrderExecutionType = "TRADE"
 	OrderExecutionExpired      OrderExecutionType = "EXPIRED"
+
+	// just for futures
+	OrderExecutionCalculated OrderExecutionType = "CALCULATED" // liquidation execution
+	OrderExecutionAmendment  OrderExecutionType = "AMENDMENT"
+)
+
+type ContingencyType string
+
+const (
+	ContingencyTypeOCO ContingencyType = "OCO"
+	ContingencyTypeOTO ContingencyType = "OTO"
+)
+
+type OrderListStatusType string
+
+const (
+	OrderListStatusTypeResponse    OrderListStatusType = "RESPONSE"     // used when the ListStatus is responding to a failed action. (E.g. Orderlist placement or cancellation)
+	OrderListStatusTypeExecStarted OrderListStatusType = "EXEC_STARTED" // the order list has been placed or there is an update to the order list status.
+	OrderListStatusTypeAllDone     OrderListStatusType = "ALL_DONE"     // the order list has finished executing and thus no longer active.
+)
+
+type OrderListOrderStatus string
+
+const (
+	OrderListOrderStatusExecuting OrderListOrderStatus = "EXECUTING" // either an order list has been placed or there is an update to the status of the list.
+	OrderListOrderStatusAllDone   OrderListOrderStatus = "ALL_DONE"  // an order list has completed execution and thus no longer active.
+	OrderListOrderStatusReject    OrderListOrderStatus = "REJECT"    // the List Status is responding to a failed action either during order placement or order canceled.
 )
 
 type MarginOrderSideEffectType string
 
 const (
-	MarginOrderSideEffectTypeNoSideEffect MarginOrderSideEffectType = "NO_SIDE_EFFECT"
-	MarginOrderSideEffectTypeMarginBuy    MarginOrderSideEffectType = "MARGIN_BUY"
-	MarginOrderSideEffectTypeAutoRepay    MarginOrderSideEffectType = "AUTO_REPAY"
+	MarginOrderSideEffectTypeNoSideEffect    MarginOrderSideEffectType = "NO_SIDE_EFFECT"
+	MarginOrderSideEffectTypeMarginBuy       MarginOrderSideEffectType = "MARGIN_BUY"
+	MarginOrderSideEffectTypeAutoRepay       MarginOrderSideEffectType = "AUTO_REPAY"
+	MarginOrderSideEffectTypeAutoBorrowRepay MarginOrderSideEffectType = "AUTO_BORROW_REPAY"
+)
+
+type MarginBorrowRepayType string
+
+const (
+	MarginBorrowRepayTypeBorrow MarginBorrowRepayType = "BORROW"
+	MarginBorrowRepayTypeRepay  MarginBorrowRepayType = "REPAY"
+)
+
+type MarginInterestType string
+
+const (
+	MarginInterestTypePeriodic          MarginInterestType = "PERIODIC"            // interest charged per hour
+	MarginInterestTypeOnBorrow          MarginInterestType = "ON_BORROW"           // first interest charged on borrow
+	MarginInterestTypePeriodicConverted MarginInterestType = "PERIODIC_CONVERTED"  // interest charged per hour converted into BNB
+	MarginInterestTypeOnBorrowConverted MarginInterestType = "ON_BORROW_CONVERTED" // first interest charged on borrow converted into BNB
+	MarginInterestTypePortfolio         MarginInterestType = "PORTFOLIO"           // portfolio margin negative balance daily interest
 )
 
 type FuturesPositionSide string
@@ -176,6 +287,31 @@ const (
 	FuturesPositionSideShort = "SHORT"
 )
 
+// FuturesAccountUpdateReason is reason of futures ACCOUNT_UPDATE event.
+type FuturesAccountUpdateReason string
+
+const (
+	FuturesAccountUpdateReasonDeposit             FuturesAccountUpdateReason = "DEPOSIT"
+	FuturesAccountUpdateReasonWithdraw            FuturesAccountUpdateReason = "WITHDRAW"
+	FuturesAccountUpdateReasonOrder               FuturesAccountUpdateReason = "ORDER"
+	FuturesAccountUpdateReasonFundingFee          FuturesAccountUpdateReason = "FUNDING_FEE"
+	FuturesAccountUpdateReasonWithdrawReject      FuturesAccountUpdateReason = "WITHDRAW_REJECT"
+	FuturesAccountUpdateReasonAdjustment          FuturesAccountUpdateReason = "ADJUSTMENT"
+	FuturesAccountUpdateReasonInsuranceClear      FuturesAccountUpdateReason = "INSURANCE_CLEAR"
+	FuturesAccountUpdateReasonAdminDeposit        FuturesAccountUpdateReason = "ADMIN_DEPOSIT"
+	FuturesAccountUpdateReasonAdminWithdraw       FuturesAccountUpdateReason = "ADMIN_WITHDRAW"
+	FuturesAccountUpdateReasonMarginTransfer      FuturesAccountUpdateReason = "MARGIN_TRANSFER"
+	FuturesAccountUpdateReasonMarginTypeChange    FuturesAccountUpdateReason = "MARGIN_TYPE_CHANGE"
+	FuturesAccountUpdateReasonAssetTransfer       FuturesAccountUpdateReason = "ASSET_TRANSFER"
+	FuturesAccountUpdateReasonOptionsPremiumFee   FuturesAccountUpdateReason = "OPTIONS_PREMIUM_FEE"
+	FuturesAccountUpdateReasonOptionsSettleProfit FuturesAccountUpdateReason = "OPTIONS_SETTLE_PROFIT"
+	FuturesAccountUpdateReasonAutoExchange        FuturesAccountUpdateReason = "AUTO_EXCHANGE"
+	FuturesAccountUpdateReasonCoinSwapDeposit     FuturesAccountUpdateReason = "COIN_SWAP_DEPOSIT"
+	FuturesAccountUpdateReasonCoinSwapWithdraw    FuturesAccountUpdateReason = "COIN_SWAP_WITHDRAW"
+	// FuturesAccountUpdateReasonAdl is position reduced by auto-deleveraging.
+	FuturesAccountUpdateReasonAdl FuturesAccountUpdateReason = "ADL"
+)
+
 type FuturesMarginType string
 
 const (
@@ -197,6 +333,21 @@ const (
 	FuturesWorkingTypeContractPrice FuturesWorkingType = "CONTRACT_PRICE"
 )
 
+// FuturesPriceMatch places order by opponent or queue price, can not be set with price.
+type FuturesPriceMatch string
+
+const (
+	FuturesPriceMatchNone       FuturesPriceMatch = "NONE"
+	FuturesPriceMatchOpponent   FuturesPriceMatch = "OPPONENT"
+	FuturesPriceMatchOpponent5  FuturesPriceMatch = "OPPONENT_5"
+	FuturesPriceMatchOpponent10 FuturesPriceMatch = "OPPONENT_10"
+	FuturesPriceMatchOpponent20 FuturesPriceMatch = "OPPONENT_20"
+	FuturesPriceMatchQueue      FuturesPriceMatch = "QUEUE"
+	FuturesPriceMatchQueue5     FuturesPriceMatch = "QUEUE_5"
+	FuturesPriceMatchQueue10    FuturesPriceMatch = "QUEUE_10"
+	FuturesPriceMatchQueue20    FuturesPriceMatch = "QUEUE_20"
+)
+
 type FuturesModifyMarginType int
 
 const (
@@ -250,6 +401,15 @@ const (
 	TimeInForceNone TimeInForce = ""
 	TimeInForceGtc  TimeInForce = "GTC"
 	TimeInForceIoc  TimeInForce = "IOC"
+	TimeInForceFok  TimeInForce = "FOK"
+	// TimeInForceGtx is post only, only for futures
+	TimeInForceGtx TimeInForce = "GTX"
+	// TimeInForceGtd is good till FuturesNewOrderParams.GoodTillDate, only for futures
+	TimeInForceGtd TimeInForce = "GTD"
+	// TimeInForceRpi is retail price improvement, post only, only for futures
+	TimeInForceRpi TimeInForce = "RPI"
+	// TimeInForceGteGtc is of futures orders closing position, only in responses
+	TimeInForceGteGtc TimeInForce = "GTE_GTC"
 )
 
 // OrderResponseType is the response of JSON type. ACK, RESULT, or FULL; MARKET and LIMIT order types default to FULL, all other orders default to ACK.
@@ -307,12 +467,20 @@ const (
 type DepositStatus int
 
 const (
-	DepositStatusNone           DepositStatus = -1
-	DepositStatusPending        DepositStatus = 0
-	DepositStatusCannotWithdraw DepositStatus = 6
-	DepositStatusSuccess        DepositStatus = 1
+	DepositStatusNone               DepositStatus = -1
+	DepositStatusPending            DepositStatus = 0
+	DepositStatusCannotWithdraw     DepositStatus = 6 // credited but cannot withdraw
+	DepositStatusWrongDeposit       DepositStatus = 7
+	DepositStatusWaitingUserConfirm DepositStatus = 8
+	DepositStatusSuccess            DepositStatus = 1
+	DepositStatusRejected           DepositStatus = 2
 )
 
+// IsFinished returns true, if deposit will not change any more.
+func (s DepositStatus) IsFinished() bool {
+	return s == DepositStatusSuccess || s == DepositStatusRejected || s == DepositStatusWrongDeposit
+}
+
 type WithdrawStatus int
 
 const (
@@ -326,6 +494,23 @@ const (
 	WithdrawStatusCompleted
 )
 
+// IsFinished returns true, if withdrawal will not change any more.
+func (s WithdrawStatus) IsFinished() bool {
+	switch s {
+	case WithdrawStatusCancelled, WithdrawStatusRejected, WithdrawStatusFailure, WithdrawStatusCompleted:
+		return true
+	}
+	return false
+}
+
+type AccountSnapshotType string
+
+const (
+	AccountSnapshotTypeSpot    AccountSnapshotType = "SPOT"
+	AccountSnapshotTypeMargin  AccountSnapshotType = "MARGIN"
+	AccountSnapshotTypeFutures AccountSnapshotType = "FUTURES"
+)
+
 type SubObErrorCode int
 
 const (
@@ -383,6 +568,14 @@ const (
 	SimpleEarnFlexibleRedeemDestinationFund SimpleEarnFlexibleRedeemDestination = "FUND"
 )
 
+type SimpleEarnSourceAccount string
+
+const (
+	SimpleEarnSourceAccountSpot SimpleEarnSourceAccount = "SPOT"
+	SimpleEarnSourceAccountFund SimpleEarnSourceAccount = "FUND"
+	SimpleEarnSourceAccountAll  SimpleEarnSourceAccount = "ALL"
+)
+
 type PortfolioMarginAccountStatus string
 
 const (
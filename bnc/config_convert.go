@@ -0,0 +1,179 @@
+package bnc
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+// ErrConvertSlippage is returned by User.ConvertMarket,
+// if quote ratio is worse than expected ratio by more than max slippage.
+var ErrConvertSlippage = errors.New("convert quote exceeds max slippage")
+
+// =============================================
+// Convert Exchange Info
+// ---------------------------------------------
+
+// ConvertExchangeInfoParams
+// At least one of FromAsset and ToAsset is required.
+type ConvertExchangeInfoParams struct {
+	FromAsset string `s2m:"fromAsset,omitempty"`
+	ToAsset   string `s2m:"toAsset,omitempty"`
+}
+
+type ConvertPair struct {
+	FromAsset          string  `json:"fromAsset" bson:"fromAsset"`
+	ToAsset            string  `json:"toAsset" bson:"toAsset"`
+	FromAssetMinAmount float64 `json:"fromAssetMinAmount,string" bson:"fromAssetMinAmount,string"`
+	FromAssetMaxAmount float64 `json:"fromAssetMaxAmount,string" bson:"fromAssetMaxAmount,string"`
+	ToAssetMinAmount   float64 `json:"toAssetMinAmount,string" bson:"toAssetMinAmount,string"`
+	ToAssetMaxAmount   float64 `json:"toAssetMaxAmount,string" bson:"toAssetMaxAmount,string"`
+}
+
+var ConvertExchangeInfoConfig = cex.ReqConfig[ConvertExchangeInfoParams, []ConvertPair]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/convert/exchangeInfo",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]ConvertPair]),
+}
+
+// ---------------------------------------------
+// Convert Exchange Info
+// =============================================
+
+// =============================================
+// Convert Quote
+// ---------------------------------------------
+
+type ConvertWalletType string
+
+const (
+	ConvertWalletTypeSpot        ConvertWalletType = "SPOT"
+	ConvertWalletTypeFunding     ConvertWalletType = "FUNDING"
+	ConvertWalletTypeSpotFunding ConvertWalletType = "SPOT_FUNDING"
+)
+
+type ConvertValidTime string
+
+const (
+	ConvertValidTime10s ConvertValidTime = "10s"
+	ConvertValidTime30s ConvertValidTime = "30s"
+	ConvertValidTime1m  ConvertValidTime = "1m"
+	ConvertValidTime2m  ConvertValidTime = "2m"
+)
+
+// ConvertGetQuoteParams
+// Either FromAmount or ToAmount should be set.
+type ConvertGetQuoteParams struct {
+	FromAsset  string            `s2m:"fromAsset"`
+	ToAsset    string            `s2m:"toAsset"`
+	FromAmount float64           `s2m:"fromAmount,omitempty"` // amount deducted from fromAsset
+	ToAmount   float64           `s2m:"toAmount,omitempty"`   // amount credited to toAsset
+	WalletType ConvertWalletType `s2m:"walletType,omitempty"` // default SPOT
+	ValidTime  ConvertValidTime  `s2m:"validTime,omitempty"`  // default 10s
+}
+
+type ConvertQuote struct {
+	QuoteId        string  `json:"quoteId" bson:"quoteId"`
+	Ratio          float64 `json:"ratio,string" bson:"ratio,string"` // toAmount / fromAmount
+	InverseRatio   float64 `json:"inverseRatio,string" bson:"inverseRatio,string"`
+	ValidTimestamp int64   `json:"validTimestamp" bson:"validTimestamp"`
+	ToAmount       float64 `json:"toAmount,string" bson:"toAmount,string"`
+	FromAmount     float64 `json:"fromAmount,string" bson:"fromAmount,string"`
+}
+
+var ConvertGetQuoteConfig = cex.ReqConfig[ConvertGetQuoteParams, ConvertQuote]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/convert/getQuote",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[ConvertQuote]),
+}
+
+// ---------------------------------------------
+// Convert Quote
+// =============================================
+
+// =============================================
+// Convert Order
+// ---------------------------------------------
+
+type ConvertOrderStatus string
+
+const (
+	ConvertOrderStatusProcess       ConvertOrderStatus = "PROCESS"
+	ConvertOrderStatusAcceptSuccess ConvertOrderStatus = "ACCEPT_SUCCESS"
+	ConvertOrderStatusSuccess       ConvertOrderStatus = "SUCCESS"
+	ConvertOrderStatusFail          ConvertOrderStatus = "FAIL"
+)
+
+type ConvertAcceptQuoteParams struct {
+	QuoteId string `s2m:"quoteId"`
+}
+
+type ConvertAcceptQuoteResult struct {
+	OrderId     string             `json:"orderId" bson:"orderId"`
+	CreateTime  int64              `json:"createTime" bson:"createTime"`
+	OrderStatus ConvertOrderStatus `json:"orderStatus" bson:"orderStatus"`
+}
+
+var ConvertAcceptQuoteConfig = cex.ReqConfig[ConvertAcceptQuoteParams, ConvertAcceptQuoteResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/convert/acceptQuote",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[ConvertAcceptQuoteResult]),
+}
+
+// ConvertOrderStatusParams
+// Either OrderId or QuoteId is required.
+type ConvertOrderStatusParams struct {
+	OrderId string `s2m:"orderId,omitempty"`
+	QuoteId string `s2m:"quoteId,omitempty"`
+}
+
+type ConvertOrder struct {
+	OrderId      int64              `json:"orderId" bson:"orderId"`
+	OrderStatus  ConvertOrderStatus `json:"orderStatus" bson:"orderStatus"`
+	FromAsset    string             `json:"fromAsset" bson:"fromAsset"`
+	FromAmount   float64            `json:"fromAmount,string" bson:"fromAmount,string"`
+	ToAsset      string             `json:"toAsset" bson:"toAsset"`
+	ToAmount     float64            `json:"toAmount,string" bson:"toAmount,string"`
+	Ratio        float64            `json:"ratio,string" bson:"ratio,string"`
+	InverseRatio float64            `json:"inverseRatio,string" bson:"inverseRatio,string"`
+	CreateTime   int64              `json:"createTime" bson:"createTime"`
+}
+
+var ConvertOrderStatusConfig = cex.ReqConfig[ConvertOrderStatusParams, ConvertOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/convert/orderStatus",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[ConvertOrder]),
+}
+
+// ---------------------------------------------
+// Convert Order
+// =============================================
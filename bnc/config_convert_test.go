@@ -0,0 +1,38 @@
+package bnc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUser_ConvertMarket(t *testing.T) {
+	var paths []string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		paths = append(paths, r.URL.Path)
+		body := `{"quoteId":"q1","ratio":"1990","inverseRatio":"0.0005025","validTimestamp":1000,"toAmount":"199","fromAmount":"0.1"}`
+		if r.URL.Path == SapiV1+"/convert/acceptQuote" {
+			body = `{"orderId":"100","createTime":1000,"orderStatus":"PROCESS"}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	_, quote, _, err := user.ConvertMarket("ETH", "USDT", 0.1, 2000, 0.001)
+	if !errors.Is(err.Err, ErrConvertSlippage) || quote.QuoteId != "q1" || len(paths) != 1 {
+		t.Fatal("quote exceeding slippage should not be accepted", err.Err, paths)
+	}
+
+	_, quote, result, err := user.ConvertMarket("ETH", "USDT", 0.1, 2000, 0.01)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if quote.Ratio != 1990 || result.OrderId != "100" || result.OrderStatus != ConvertOrderStatusProcess {
+		t.Error("wrong result", quote, result)
+	}
+	if len(paths) != 3 || paths[2] != SapiV1+"/convert/acceptQuote" {
+		t.Error("quote should be accepted", paths)
+	}
+}
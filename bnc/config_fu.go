@@ -1,8 +1,10 @@
 package bnc
 
 import (
+	"fmt"
 	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/dwdwow/cex"
 )
@@ -88,15 +90,58 @@ type FuturesNewOrderParams struct {
 	ClosePosition           bool                    `s2m:"closePosition,omitempty" json:"closePosition,omitempty"`                     //	true, false；Close-All，used with STOP_MARKET or TAKE_PROFIT_MARKET.
 	StopPrice               float64                 `s2m:"stopPrice,omitempty" json:"stopPrice,omitempty"`                             // Used with STOP/STOP_MARKET or TAKE_PROFIT/TAKE_PROFIT_MARKET orders.
 	ActivationPrice         float64                 `s2m:"activationPrice,omitempty" json:"activationPrice,omitempty"`                 // Used with TRAILING_STOP_MARKET orders, default as the latest price(supporting different workingType)
-	CallbackRate            float64                 `s2m:"callbackRate,omitempty" json:"callbackRate,omitempty"`                       // Used with TRAILING_STOP_MARKET orders, min 0.1, max 5 where 1 for 1%
+	CallbackRate            float64                 `s2m:"callbackRate,omitempty" json:"callbackRate,omitempty"`                       // Used with TRAILING_STOP_MARKET orders, min 0.1, max 10 where 1 for 1%
 	WorkingType             FuturesWorkingType      `s2m:"workingType,omitempty" json:"workingType,omitempty"`                         // stopPrice triggered by: "MARK_PRICE", "CONTRACT_PRICE".Default "CONTRACT_PRICE"
 	PriceProtect            BigBool                 `s2m:"priceProtect,omitempty" json:"priceProtect,omitempty"`                       // "TRUE" or "FALSE", default "FALSE".Used with STOP/STOP_MARKET or TAKE_PROFIT/TAKE_PROFIT_MARKET orders.
 	NewOrderRespType        OrderResponseType       `s2m:"newOrderRespType,omitempty" json:"newOrderRespType,omitempty"`               // "ACK", "RESULT", default "ACK"
-	PriceMatch              string                  `s2m:"priceMatch,omitempty" json:"priceMatch,omitempty"`                           //  only available for LIMIT/STOP/TAKE_PROFIT order, can be set to OPPONENT/ OPPONENT_5/ OPPONENT_10/ OPPONENT_20: /QUEUE/ QUEUE_5/ QUEUE_10/ QUEUE_20. Can't be passed together with price
+	PriceMatch              FuturesPriceMatch       `s2m:"priceMatch,omitempty" json:"priceMatch,omitempty"`                           //  only available for LIMIT/STOP/TAKE_PROFIT order, can be set to OPPONENT/ OPPONENT_5/ OPPONENT_10/ OPPONENT_20: /QUEUE/ QUEUE_5/ QUEUE_10/ QUEUE_20. Can't be passed together with price
 	SelfTradePreventionMode SelfTradePreventionMode `s2m:"selfTradePreventionMode,omitempty" json:"selfTradePreventionMode,omitempty"` // NONE:No STP / EXPIRE_TAKER:expire taker order when STP triggers/ EXPIRE_MAKER:expire maker order when STP triggers/ EXPIRE_BOTH:expire both orders when STP triggers , default NONE
 	GoodTillDate            int64                   `s2m:"goodTillDate,omitempty" json:"goodTillDate,omitempty"`
 }
 
+// Validate checks fields required by order type, and mutually exclusive fields.
+func (p FuturesNewOrderParams) Validate() error {
+	switch {
+	case !p.TimeInForce.IsValid():
+		return fmt.Errorf("%w, invalid time in force %q", cex.ErrInvalidParams, p.TimeInForce)
+	case !p.WorkingType.IsValid():
+		return fmt.Errorf("%w, invalid working type %q", cex.ErrInvalidParams, p.WorkingType)
+	case !p.PriceMatch.IsValid():
+		return fmt.Errorf("%w, invalid price match %q", cex.ErrInvalidParams, p.PriceMatch)
+	case p.Quantity < 0 || p.Price < 0 || p.StopPrice < 0 || p.ActivationPrice < 0:
+		return fmt.Errorf("%w, negative qty or price", cex.ErrInvalidParams)
+	}
+	err := cex.FirstParamsErr(
+		cex.RequireParam("symbol", p.Symbol != ""),
+		cex.RequireParam("side", p.Side != ""),
+		cex.RequireParam("type", p.Type != ""),
+		cex.ExclusiveParams("price", p.Price > 0, "priceMatch", p.PriceMatch != "" && p.PriceMatch != FuturesPriceMatchNone),
+		cex.ExclusiveParams("reduceOnly", p.ReduceOnly == SmallTrue, "closePosition", p.ClosePosition),
+		cex.ExclusiveParams("quantity", p.Quantity > 0, "closePosition", p.ClosePosition),
+	)
+	if err != nil {
+		return err
+	}
+	if p.CallbackRate != 0 {
+		if err := cex.ParamInRange("callbackRate", p.CallbackRate, 0.1, 10); err != nil {
+			return err
+		}
+	}
+	if p.TimeInForce == TimeInForceGtd && p.GoodTillDate == 0 {
+		return fmt.Errorf("%w, goodTillDate is required by GTD", cex.ErrInvalidParams)
+	}
+	switch p.Type {
+	case OrderTypeLimit:
+		return cex.FirstParamsErr(
+			cex.RequireParam("quantity", p.Quantity > 0),
+			cex.RequireOneOfParams("price", p.Price > 0, "priceMatch", p.PriceMatch != ""),
+		)
+	case OrderTypeMarket:
+		return cex.RequireParam("quantity", p.Quantity > 0)
+	}
+	return nil
+}
+
 type FuturesOrder struct {
 	// common
 	Symbol                  string                  `json:"symbol" bson:"symbol"`
@@ -118,7 +163,7 @@ type FuturesOrder struct {
 	UpdateTime              int64                   `json:"updateTime" bson:"updateTime"`
 	WorkingType             FuturesWorkingType      `json:"workingType" bson:"workingType"`
 	PriceProtect            bool                    `json:"priceProtect" bson:"priceProtect"`
-	PriceMatch              string                  `json:"priceMatch" bson:"priceMatch"`
+	PriceMatch              FuturesPriceMatch       `json:"priceMatch" bson:"priceMatch"`
 	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode" bson:"selfTradePreventionMode"`
 	GoodTillDate            int64                   `json:"goodTillDate" bson:"goodTillDate"`
 
@@ -155,14 +200,70 @@ var FuturesNewOrderConfig = cex.ReqConfig[FuturesNewOrderParams, FuturesOrder]{
 	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[FuturesOrder]),
 }
 
+type FuturesBatchOrdersParams struct {
+	// max length: 5
+	// orders are encoded into json by json tags of FuturesNewOrderParams
+	BatchOrders []FuturesNewOrderParams `s2m:"batchOrders,omitempty"`
+}
+
+func (p FuturesBatchOrdersParams) Validate() error {
+	if err := cex.ParamInRange("batchOrders length", len(p.BatchOrders), 1, 5); err != nil {
+		return err
+	}
+	for i, o := range p.BatchOrders {
+		if err := o.Validate(); err != nil {
+			return fmt.Errorf("batch order %v, %w", i, err)
+		}
+	}
+	return nil
+}
+
+// FuturesBatchOrderResult is result of one order in batch orders.
+// Binance returns an array mixed with orders and errors,
+// and the result order is in the same place of the request order.
+type FuturesBatchOrderResult struct {
+	FuturesOrder `bson:",inline"`
+
+	// Err is nil, if order is placed or canceled successfully.
+	// Otherwise, it is mapped from Code and Msg.
+	Err error `json:"-" bson:"-"`
+}
+
+var FuturesBatchOrdersConfig = cex.ReqConfig[FuturesBatchOrdersParams, []FuturesBatchOrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/batchOrders",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(fuBatchOrdersBodyUnmsh),
+}
+
 type FuturesModifyOrderParams struct {
-	OrderId           int64     `s2m:"orderId,omitempty"`
-	OrigClientOrderId string    `s2m:"origClientOrderId,omitempty"`
-	Symbol            string    `s2m:"symbol,omitempty"`
-	Side              OrderSide `s2m:"side,omitempty"` // needs to be same as origin order
-	Quantity          float64   `s2m:"quantity,omitempty"`
-	Price             float64   `s2m:"price,omitempty"`
-	PriceMatch        string    `s2m:"priceMatch,omitempty"`
+	OrderId           int64             `s2m:"orderId,omitempty"`
+	OrigClientOrderId string            `s2m:"origClientOrderId,omitempty"`
+	Symbol            string            `s2m:"symbol,omitempty"`
+	Side              OrderSide         `s2m:"side,omitempty"` // needs to be same as origin order
+	Quantity          float64           `s2m:"quantity,omitempty"`
+	Price             float64           `s2m:"price,omitempty"`
+	PriceMatch        FuturesPriceMatch `s2m:"priceMatch,omitempty"`
+}
+
+func (p FuturesModifyOrderParams) Validate() error {
+	if !p.PriceMatch.IsValid() {
+		return fmt.Errorf("%w, invalid price match %q", cex.ErrInvalidParams, p.PriceMatch)
+	}
+	return cex.FirstParamsErr(
+		cex.RequireParam("symbol", p.Symbol != ""),
+		cex.RequireParam("side", p.Side != ""),
+		cex.RequireOneOfParams("orderId", p.OrderId != 0, "origClientOrderId", p.OrigClientOrderId != ""),
+		cex.RequireParam("quantity", p.Quantity > 0),
+		cex.RequireOneOfParams("price", p.Price > 0, "priceMatch", p.PriceMatch != ""),
+		cex.ExclusiveParams("price", p.Price > 0, "priceMatch", p.PriceMatch != "" && p.PriceMatch != FuturesPriceMatchNone),
+	)
 }
 
 var FuturesModifyOrderConfig = cex.ReqConfig[FuturesModifyOrderParams, FuturesOrder]{
@@ -195,11 +296,11 @@ type FuturesNewMultiOrdersOrderParams struct {
 	ClosePosition           bool                    `s2m:"closePosition,omitempty" json:"closePosition,omitempty"`                     //	true, false；Close-All，used with STOP_MARKET or TAKE_PROFIT_MARKET.
 	StopPrice               string                  `s2m:"stopPrice,omitempty" json:"stopPrice,omitempty"`                             // Used with STOP/STOP_MARKET or TAKE_PROFIT/TAKE_PROFIT_MARKET orders.
 	ActivationPrice         string                  `s2m:"activationPrice,omitempty" json:"activationPrice,omitempty"`                 // Used with TRAILING_STOP_MARKET orders, default as the latest price(supporting different workingType)
-	CallbackRate            string                  `s2m:"callbackRate,omitempty" json:"callbackRate,omitempty"`                       // Used with TRAILING_STOP_MARKET orders, min 0.1, max 5 where 1 for 1%
+	CallbackRate            string                  `s2m:"callbackRate,omitempty" json:"callbackRate,omitempty"`                       // Used with TRAILING_STOP_MARKET orders, min 0.1, max 10 where 1 for 1%
 	WorkingType             FuturesWorkingType      `s2m:"workingType,omitempty" json:"workingType,omitempty"`                         // stopPrice triggered by: "MARK_PRICE", "CONTRACT_PRICE".Default "CONTRACT_PRICE"
 	PriceProtect            BigBool                 `s2m:"priceProtect,omitempty" json:"priceProtect,omitempty"`                       // "TRUE" or "FALSE", default "FALSE".Used with STOP/STOP_MARKET or TAKE_PROFIT/TAKE_PROFIT_MARKET orders.
 	NewOrderRespType        OrderResponseType       `s2m:"newOrderRespType,omitempty" json:"newOrderRespType,omitempty"`               // "ACK", "RESULT", default "ACK"
-	PriceMatch              string                  `s2m:"priceMatch,omitempty" json:"priceMatch,omitempty"`                           //  only available for LIMIT/STOP/TAKE_PROFIT order, can be set to OPPONENT/ OPPONENT_5/ OPPONENT_10/ OPPONENT_20: /QUEUE/ QUEUE_5/ QUEUE_10/ QUEUE_20. Can't be passed together with price
+	PriceMatch              FuturesPriceMatch       `s2m:"priceMatch,omitempty" json:"priceMatch,omitempty"`                           //  only available for LIMIT/STOP/TAKE_PROFIT order, can be set to OPPONENT/ OPPONENT_5/ OPPONENT_10/ OPPONENT_20: /QUEUE/ QUEUE_5/ QUEUE_10/ QUEUE_20. Can't be passed together with price
 	SelfTradePreventionMode SelfTradePreventionMode `s2m:"selfTradePreventionMode,omitempty" json:"selfTradePreventionMode,omitempty"` // NONE:No STP / EXPIRE_TAKER:expire taker order when STP triggers/ EXPIRE_MAKER:expire maker order when STP triggers/ EXPIRE_BOTH:expire both orders when STP triggers , default NONE
 	GoodTillDate            string                  `s2m:"goodTillDate,omitempty" json:"goodTillDate,omitempty"`
 }
@@ -225,13 +326,13 @@ var FuturesPlaceMultiOrdersConfig = cex.ReqConfig[FuturesPlaceMultiOrdersParams,
 }
 
 type FuturesModifyMultiOrdersOrderParams struct {
-	OrderId           string    `s2m:"orderId,omitempty"`
-	OrigClientOrderId string    `s2m:"origClientOrderId,omitempty"`
-	Symbol            string    `s2m:"symbol,omitempty"`
-	Side              OrderSide `s2m:"side,omitempty"` // needs to be same as origin order
-	Quantity          string    `s2m:"quantity,omitempty"`
-	Price             string    `s2m:"price,omitempty"`
-	PriceMatch        string    `s2m:"priceMatch,omitempty"`
+	OrderId           string            `s2m:"orderId,omitempty"`
+	OrigClientOrderId string            `s2m:"origClientOrderId,omitempty"`
+	Symbol            string            `s2m:"symbol,omitempty"`
+	Side              OrderSide         `s2m:"side,omitempty"` // needs to be same as origin order
+	Quantity          string            `s2m:"quantity,omitempty"`
+	Price             string            `s2m:"price,omitempty"`
+	PriceMatch        FuturesPriceMatch `s2m:"priceMatch,omitempty"`
 }
 
 type FuturesModifyMultiOrdersParams struct {
@@ -260,6 +361,21 @@ type FuturesOrderModifyHistoriesParams struct {
 	Limit             int    `s2m:"limit,omitempty"` // Default 1000; max 1000
 }
 
+func (p FuturesOrderModifyHistoriesParams) Validate() error {
+	err := cex.FirstParamsErr(
+		cex.RequireParam("symbol", p.Symbol != ""),
+		cex.RequireOneOfParams("orderId", p.OrderId != 0, "origClientOrderId", p.OrigClientOrderId != ""),
+		cex.ParamInRange("limit", p.Limit, 0, 1000),
+	)
+	if err != nil {
+		return err
+	}
+	if p.EndTime != 0 && p.StartTime > p.EndTime {
+		return fmt.Errorf("%w, startTime %v is after endTime %v", cex.ErrInvalidParams, p.StartTime, p.EndTime)
+	}
+	return nil
+}
+
 type FuturesOrderModifyHistory struct {
 	AmendmentId   int    `json:"amendmentId" bson:"amendmentId"`
 	Symbol        string `json:"symbol" bson:"symbol"`
@@ -278,7 +394,19 @@ type FuturesOrderModifyHistory struct {
 		} `json:"origQty" bson:"origQty"`
 		Count int `json:"count" bson:"count"` // Order modification count, representing the number of times the order has been modified
 	} `json:"amendment" bson:"amendment"`
-	PriceMatch string `json:"priceMatch" bson:"priceMatch"`
+	PriceMatch FuturesPriceMatch `json:"priceMatch" bson:"priceMatch"`
+}
+
+func (h FuturesOrderModifyHistory) ToCexAmendment() cex.OrderAmendment {
+	return cex.OrderAmendment{
+		Id:          strconv.Itoa(h.AmendmentId),
+		Time:        h.Time,
+		PriceBefore: h.Amendment.Price.Before,
+		PriceAfter:  h.Amendment.Price.After,
+		QtyBefore:   h.Amendment.OrigQty.Before,
+		QtyAfter:    h.Amendment.OrigQty.After,
+		Count:       h.Amendment.Count,
+	}
 }
 
 var FuturesOrderModifyHistoriesConfig = cex.ReqConfig[FuturesOrderModifyHistoriesParams, []FuturesOrderModifyHistory]{
@@ -348,7 +476,7 @@ type FuturesCancelMultiOrdersParams struct {
 	OrigClientOrderIdList []string `s2m:"origClientOrderIdList,omitempty"` // max length: 10
 }
 
-var FuturesCancelMultiOrdersConfig = cex.ReqConfig[FuturesCancelMultiOrdersParams, []FuturesOrder]{
+var FuturesCancelMultiOrdersConfig = cex.ReqConfig[FuturesCancelMultiOrdersParams, []FuturesBatchOrderResult]{
 	ReqBaseConfig: cex.ReqBaseConfig{
 		BaseUrl:          FapiBaseUrl,
 		Path:             FapiV1 + "/batchOrders",
@@ -358,7 +486,7 @@ var FuturesCancelMultiOrdersConfig = cex.ReqConfig[FuturesCancelMultiOrdersParam
 		IpTimeInterval:   0,
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
-	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesOrder]),
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(fuBatchOrdersBodyUnmsh),
 }
 
 type FuturesAutoCancelAllOpenOrdersParams struct {
@@ -686,6 +814,39 @@ var FuturesPositionsConfig = cex.ReqConfig[FuturesPositionsParams, []FuturesPosi
 	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesPosition]),
 }
 
+type FuturesAdlQuantileParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+}
+
+// FuturesAdlQuantileValues are adl quantiles of positions, in [0, 4].
+// The higher quantile is, the earlier position may be auto-deleveraged.
+// Long, Short and Hedge are set in hedge mode, Both is set in one-way mode.
+// Hedge is only a sign, ignore it if it is not 0.
+type FuturesAdlQuantileValues struct {
+	Long  int `json:"LONG" bson:"LONG"`
+	Short int `json:"SHORT" bson:"SHORT"`
+	Both  int `json:"BOTH" bson:"BOTH"`
+	Hedge int `json:"HEDGE" bson:"HEDGE"`
+}
+
+type FuturesAdlQuantile struct {
+	Symbol      string                   `json:"symbol" bson:"symbol"`
+	AdlQuantile FuturesAdlQuantileValues `json:"adlQuantile" bson:"adlQuantile"`
+}
+
+var FuturesAdlQuantileConfig = cex.ReqConfig[FuturesAdlQuantileParams, []FuturesAdlQuantile]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/adlQuantile",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesAdlQuantile]),
+}
+
 type FuturesAccountTradeListParams struct {
 	Symbol    string `s2m:"symbol,omitempty"`
 	OrderId   int64  `s2m:"orderId,omitempty"` // This can only be used in combination with symbol
@@ -780,3 +941,37 @@ var FuturesCommissionRateConfig = cex.ReqConfig[FuturesCommissionRateParams, Fut
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
 	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[FuturesCommissionRate]),
 }
+
+type FuturesFeeBurnStatus struct {
+	FeeBurn bool `json:"feeBurn" bson:"feeBurn"` // use BNB to pay futures trading fee
+}
+
+var FuturesFeeBurnStatusConfig = cex.ReqConfig[cex.NilReqData, FuturesFeeBurnStatus]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/feeBurn",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[FuturesFeeBurnStatus]),
+}
+
+type FuturesToggleFeeBurnParams struct {
+	FeeBurn bool `s2m:"feeBurn"`
+}
+
+var FuturesToggleFeeBurnConfig = cex.ReqConfig[FuturesToggleFeeBurnParams, CodeMsg]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/feeBurn",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[CodeMsg]),
+}
@@ -0,0 +1,196 @@
+package bnc
+
+import (
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+// Futures data endpoints only return data of the latest 30 days.
+
+type FuturesDataPeriod string
+
+const (
+	FuturesDataPeriod5m  FuturesDataPeriod = "5m"
+	FuturesDataPeriod15m FuturesDataPeriod = "15m"
+	FuturesDataPeriod30m FuturesDataPeriod = "30m"
+	FuturesDataPeriod1h  FuturesDataPeriod = "1h"
+	FuturesDataPeriod2h  FuturesDataPeriod = "2h"
+	FuturesDataPeriod4h  FuturesDataPeriod = "4h"
+	FuturesDataPeriod6h  FuturesDataPeriod = "6h"
+	FuturesDataPeriod12h FuturesDataPeriod = "12h"
+	FuturesDataPeriod1d  FuturesDataPeriod = "1d"
+)
+
+// FuturesDataParams
+// If StartTime and EndTime are not sent, the most recent data is returned.
+type FuturesDataParams struct {
+	Symbol    string            `s2m:"symbol"`
+	Period    FuturesDataPeriod `s2m:"period"`
+	Limit     int64             `s2m:"limit,omitempty"` // default 30, max 500
+	StartTime int64             `s2m:"startTime,omitempty"`
+	EndTime   int64             `s2m:"endTime,omitempty"`
+}
+
+// =============================================
+// Open Interest
+// ---------------------------------------------
+
+type FuturesOpenInterestHist struct {
+	Symbol               string  `json:"symbol" bson:"symbol"`
+	SumOpenInterest      float64 `json:"sumOpenInterest,string" bson:"sumOpenInterest,string"`           // base asset
+	SumOpenInterestValue float64 `json:"sumOpenInterestValue,string" bson:"sumOpenInterestValue,string"` // quote asset
+	Timestamp            int64   `json:"timestamp" bson:"timestamp"`
+}
+
+var FuturesOpenInterestHistConfig = cex.ReqConfig[FuturesDataParams, []FuturesOpenInterestHist]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FuturesData + "/openInterestHist",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesOpenInterestHist]),
+}
+
+// ---------------------------------------------
+// Open Interest
+// =============================================
+
+// =============================================
+// Long Short Ratio
+// ---------------------------------------------
+
+type FuturesLongShortRatio struct {
+	Symbol         string  `json:"symbol" bson:"symbol"`
+	LongShortRatio float64 `json:"longShortRatio,string" bson:"longShortRatio,string"`
+	LongAccount    float64 `json:"longAccount,string" bson:"longAccount,string"`   // long ratio, of accounts or positions
+	ShortAccount   float64 `json:"shortAccount,string" bson:"shortAccount,string"` // short ratio, of accounts or positions
+	Timestamp      int64   `json:"timestamp" bson:"timestamp"`
+}
+
+// FuturesTopLongShortAccountRatioConfig queries long short ratio of accounts of top 20% traders by margin balance.
+var FuturesTopLongShortAccountRatioConfig = cex.ReqConfig[FuturesDataParams, []FuturesLongShortRatio]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FuturesData + "/topLongShortAccountRatio",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesLongShortRatio]),
+}
+
+// FuturesTopLongShortPositionRatioConfig queries long short ratio of positions of top 20% traders by margin balance.
+var FuturesTopLongShortPositionRatioConfig = cex.ReqConfig[FuturesDataParams, []FuturesLongShortRatio]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FuturesData + "/topLongShortPositionRatio",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesLongShortRatio]),
+}
+
+// FuturesGlobalLongShortAccountRatioConfig queries long short ratio of accounts of all traders.
+var FuturesGlobalLongShortAccountRatioConfig = cex.ReqConfig[FuturesDataParams, []FuturesLongShortRatio]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FuturesData + "/globalLongShortAccountRatio",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesLongShortRatio]),
+}
+
+// ---------------------------------------------
+// Long Short Ratio
+// =============================================
+
+// =============================================
+// Taker Buy Sell Volume
+// ---------------------------------------------
+
+type FuturesTakerLongShortRatio struct {
+	BuySellRatio float64 `json:"buySellRatio,string" bson:"buySellRatio,string"`
+	BuyVol       float64 `json:"buyVol,string" bson:"buyVol,string"`
+	SellVol      float64 `json:"sellVol,string" bson:"sellVol,string"`
+	Timestamp    int64   `json:"timestamp" bson:"timestamp"`
+}
+
+var FuturesTakerLongShortRatioConfig = cex.ReqConfig[FuturesDataParams, []FuturesTakerLongShortRatio]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FuturesData + "/takerlongshortRatio",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesTakerLongShortRatio]),
+}
+
+// ---------------------------------------------
+// Taker Buy Sell Volume
+// =============================================
+
+// =============================================
+// Basis
+// ---------------------------------------------
+
+type FuturesContractType string
+
+const (
+	FuturesContractTypePerpetual      FuturesContractType = "PERPETUAL"
+	FuturesContractTypeCurrentQuarter FuturesContractType = "CURRENT_QUARTER"
+	FuturesContractTypeNextQuarter    FuturesContractType = "NEXT_QUARTER"
+)
+
+type FuturesBasisParams struct {
+	Pair         string              `s2m:"pair"`
+	ContractType FuturesContractType `s2m:"contractType"`
+	Period       FuturesDataPeriod   `s2m:"period"`
+	Limit        int64               `s2m:"limit,omitempty"` // default 30, max 500
+	StartTime    int64               `s2m:"startTime,omitempty"`
+	EndTime      int64               `s2m:"endTime,omitempty"`
+}
+
+type FuturesBasis struct {
+	Pair                string              `json:"pair" bson:"pair"`
+	ContractType        FuturesContractType `json:"contractType" bson:"contractType"`
+	IndexPrice          float64             `json:"indexPrice,string" bson:"indexPrice,string"`
+	FuturesPrice        float64             `json:"futuresPrice,string" bson:"futuresPrice,string"`
+	Basis               float64             `json:"basis,string" bson:"basis,string"`
+	BasisRate           float64             `json:"basisRate,string" bson:"basisRate,string"`
+	AnnualizedBasisRate string              `json:"annualizedBasisRate" bson:"annualizedBasisRate"` // empty for perpetual
+	Timestamp           int64               `json:"timestamp" bson:"timestamp"`
+}
+
+var FuturesBasisConfig = cex.ReqConfig[FuturesBasisParams, []FuturesBasis]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FuturesData + "/basis",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesBasis]),
+}
+
+// ---------------------------------------------
+// Basis
+// =============================================
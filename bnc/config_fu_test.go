@@ -1,6 +1,15 @@
 package bnc
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
 
 func TestFuChangePositionMode(t *testing.T) {
 	testConfig(FuturesChangePositionModeConfig, FuturesChangePositionModParams{DualSidePosition: SmallFalse})
@@ -202,6 +211,55 @@ func TestFuCancelAllOpenOrders(t *testing.T) {
 	})
 }
 
+func TestFuBatchOrders(t *testing.T) {
+	testConfig(FuturesBatchOrdersConfig, FuturesBatchOrdersParams{
+		BatchOrders: []FuturesNewOrderParams{
+			{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGtc, Quantity: 0.02, Price: 1500},
+			{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeLimit, TimeInForce: TimeInForceGtc, Quantity: 0.02, Price: 1501},
+		},
+	})
+}
+
+func TestUser_NewFuturesBatchOrders(t *testing.T) {
+	var batchOrders string
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		batchOrders = req.URL.Query().Get("batchOrders")
+		body := `[{"symbol":"ETHUSDT","orderId":1,"status":"NEW","origQty":"0.02","price":"1500"},{"code":-2011,"msg":"Unknown order sent."}]`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			Request:    req,
+		}, nil
+	})
+	user := NewUser("key", "secret")
+	_, results, err := user.NewFuturesBatchOrders([]FuturesNewOrderParams{
+		{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeLimit, Quantity: 0.02, Price: 1500},
+		{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeLimit, Quantity: 0.02, Price: 1501},
+	}, cex.CltOptTransport(transport))
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	var params []FuturesNewOrderParams
+	if e := json.Unmarshal([]byte(batchOrders), &params); e != nil || len(params) != 2 || params[1].Price != 1501 {
+		t.Error("wrong batch orders", batchOrders, e)
+	}
+	if len(results) != 2 {
+		t.Fatal("wrong results length", len(results))
+	}
+	if results[0].Err != nil || results[0].OrderId != 1 || results[0].OrigQty != 0.02 {
+		t.Error("wrong first result", results[0])
+	}
+	if !errors.Is(results[1].Err, cex.ErrUnknownOrder) {
+		t.Error("wrong second result err", results[1].Err)
+	}
+
+	_, _, err = user.NewFuturesBatchOrders(make([]FuturesNewOrderParams, 6), cex.CltOptTransport(transport))
+	if err.IsNil() {
+		t.Error("batch orders length should be limited")
+	}
+}
+
 func TestFuCancelMultiOrders(t *testing.T) {
 	testConfig(FuturesCancelMultiOrdersConfig, FuturesCancelMultiOrdersParams{
 		Symbol:                "ETHUSDT",
@@ -325,3 +383,7 @@ func TestSimpleEarnFlexiblePositions(t *testing.T) {
 		Size:      0,
 	})
 }
+
+func TestFuAdlQuantile(t *testing.T) {
+	testConfig(FuturesAdlQuantileConfig, FuturesAdlQuantileParams{Symbol: "ETHUSDT"})
+}
@@ -0,0 +1,354 @@
+package bnc
+
+import (
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+// =============================================
+// Margin Account
+// ---------------------------------------------
+
+type MarginUserAsset struct {
+	Asset    string  `json:"asset" bson:"asset"`
+	Borrowed float64 `json:"borrowed,string" bson:"borrowed,string"`
+	Free     float64 `json:"free,string" bson:"free,string"`
+	Interest float64 `json:"interest,string" bson:"interest,string"`
+	Locked   float64 `json:"locked,string" bson:"locked,string"`
+	NetAsset float64 `json:"netAsset,string" bson:"netAsset,string"`
+}
+
+type MarginAccount struct {
+	Created                    bool              `json:"created" bson:"created"`
+	BorrowEnabled              bool              `json:"borrowEnabled" bson:"borrowEnabled"`
+	MarginLevel                float64           `json:"marginLevel,string" bson:"marginLevel,string"`
+	CollateralMarginLevel      float64           `json:"collateralMarginLevel,string" bson:"collateralMarginLevel,string"`
+	TotalAssetOfBtc            float64           `json:"totalAssetOfBtc,string" bson:"totalAssetOfBtc,string"`
+	TotalLiabilityOfBtc        float64           `json:"totalLiabilityOfBtc,string" bson:"totalLiabilityOfBtc,string"`
+	TotalNetAssetOfBtc         float64           `json:"totalNetAssetOfBtc,string" bson:"totalNetAssetOfBtc,string"`
+	TotalCollateralValueInUSDT float64           `json:"TotalCollateralValueInUSDT,string" bson:"TotalCollateralValueInUSDT,string"`
+	TradeEnabled               bool              `json:"tradeEnabled" bson:"tradeEnabled"`
+	TransferInEnabled          bool              `json:"transferInEnabled" bson:"transferInEnabled"`
+	TransferOutEnabled         bool              `json:"transferOutEnabled" bson:"transferOutEnabled"`
+	AccountType                string            `json:"accountType" bson:"accountType"` // MARGIN_1 for Cross Margin Classic, MARGIN_2 for Cross Margin Pro
+	UserAssets                 []MarginUserAsset `json:"userAssets" bson:"userAssets"`
+}
+
+var MarginAccountConfig = cex.ReqConfig[cex.NilReqData, MarginAccount]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/account",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[MarginAccount]),
+}
+
+type IsolatedMarginAccountParams struct {
+	Symbols string `s2m:"symbols,omitempty"` // max 5 symbols separated by comma, ex. BTCUSDT,BNBUSDT
+}
+
+type IsolatedMarginAsset struct {
+	Asset         string  `json:"asset" bson:"asset"`
+	BorrowEnabled bool    `json:"borrowEnabled" bson:"borrowEnabled"`
+	Borrowed      float64 `json:"borrowed,string" bson:"borrowed,string"`
+	Free          float64 `json:"free,string" bson:"free,string"`
+	Interest      float64 `json:"interest,string" bson:"interest,string"`
+	Locked        float64 `json:"locked,string" bson:"locked,string"`
+	NetAsset      float64 `json:"netAsset,string" bson:"netAsset,string"`
+	NetAssetOfBtc float64 `json:"netAssetOfBtc,string" bson:"netAssetOfBtc,string"`
+	RepayEnabled  bool    `json:"repayEnabled" bson:"repayEnabled"`
+	TotalAsset    float64 `json:"totalAsset,string" bson:"totalAsset,string"`
+}
+
+type IsolatedMarginSymbolAccount struct {
+	BaseAsset         IsolatedMarginAsset `json:"baseAsset" bson:"baseAsset"`
+	QuoteAsset        IsolatedMarginAsset `json:"quoteAsset" bson:"quoteAsset"`
+	Symbol            string              `json:"symbol" bson:"symbol"`
+	IsolatedCreated   bool                `json:"isolatedCreated" bson:"isolatedCreated"`
+	Enabled           bool                `json:"enabled" bson:"enabled"` // true-enabled, false-disabled
+	MarginLevel       float64             `json:"marginLevel,string" bson:"marginLevel,string"`
+	MarginLevelStatus string              `json:"marginLevelStatus" bson:"marginLevelStatus"` // EXCESSIVE, NORMAL, MARGIN_CALL, PRE_LIQUIDATION, FORCE_LIQUIDATION
+	MarginRatio       float64             `json:"marginRatio,string" bson:"marginRatio,string"`
+	IndexPrice        float64             `json:"indexPrice,string" bson:"indexPrice,string"`
+	LiquidatePrice    float64             `json:"liquidatePrice,string" bson:"liquidatePrice,string"`
+	LiquidateRate     float64             `json:"liquidateRate,string" bson:"liquidateRate,string"`
+	TradeEnabled      bool                `json:"tradeEnabled" bson:"tradeEnabled"`
+}
+
+type IsolatedMarginAccount struct {
+	Assets []IsolatedMarginSymbolAccount `json:"assets" bson:"assets"`
+	// total fields are not responded, if symbols is set
+	TotalAssetOfBtc     float64 `json:"totalAssetOfBtc,string" bson:"totalAssetOfBtc,string"`
+	TotalLiabilityOfBtc float64 `json:"totalLiabilityOfBtc,string" bson:"totalLiabilityOfBtc,string"`
+	TotalNetAssetOfBtc  float64 `json:"totalNetAssetOfBtc,string" bson:"totalNetAssetOfBtc,string"`
+}
+
+var IsolatedMarginAccountConfig = cex.ReqConfig[IsolatedMarginAccountParams, IsolatedMarginAccount]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/isolated/account",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[IsolatedMarginAccount]),
+}
+
+// IsolatedMarginSymbolParams is params of enabling or disabling isolated margin account of symbol.
+type IsolatedMarginSymbolParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+}
+
+func (p IsolatedMarginSymbolParams) Validate() error {
+	return cex.RequireParam("symbol", p.Symbol != "")
+}
+
+type IsolatedMarginAccountSwitch struct {
+	Success bool   `json:"success" bson:"success"`
+	Symbol  string `json:"symbol" bson:"symbol"`
+}
+
+// IsolatedMarginEnableConfig enables isolated margin account of symbol, which is disabled before,
+// isolated margin account is created by the first transfer into it.
+var IsolatedMarginEnableConfig = cex.ReqConfig[IsolatedMarginSymbolParams, IsolatedMarginAccountSwitch]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/isolated/account",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[IsolatedMarginAccountSwitch]),
+}
+
+// IsolatedMarginDisableConfig disables isolated margin account of symbol, which has no assets and liabilities,
+// disabled accounts do not count toward account limit.
+var IsolatedMarginDisableConfig = cex.ReqConfig[IsolatedMarginSymbolParams, IsolatedMarginAccountSwitch]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/isolated/account",
+		Method:           http.MethodDelete,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[IsolatedMarginAccountSwitch]),
+}
+
+type IsolatedMarginAccountLimit struct {
+	EnabledAccount int64 `json:"enabledAccount" bson:"enabledAccount"`
+	MaxAccount     int64 `json:"maxAccount" bson:"maxAccount"`
+}
+
+var IsolatedMarginAccountLimitConfig = cex.ReqConfig[cex.NilReqData, IsolatedMarginAccountLimit]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/isolated/accountLimit",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[IsolatedMarginAccountLimit]),
+}
+
+// ---------------------------------------------
+// Margin Account
+// =============================================
+
+// =============================================
+// Margin Borrow and Repay
+// ---------------------------------------------
+
+type MarginBorrowRepayParams struct {
+	Asset      string                `s2m:"asset,omitempty"`
+	IsIsolated BigBool               `s2m:"isIsolated,omitempty"` // TRUE for isolated margin, FALSE for cross margin, default FALSE
+	Symbol     string                `s2m:"symbol,omitempty"`     // isolated symbol, only for isolated margin
+	Amount     float64               `s2m:"amount,omitempty"`
+	Type       MarginBorrowRepayType `s2m:"type,omitempty"`
+}
+
+type MarginBorrowRepayResult struct {
+	TranId int64 `json:"tranId" bson:"tranId"`
+}
+
+var MarginBorrowRepayConfig = cex.ReqConfig[MarginBorrowRepayParams, MarginBorrowRepayResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/borrow-repay",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[MarginBorrowRepayResult]),
+}
+
+type MarginMaxBorrowableParams struct {
+	Asset          string `s2m:"asset,omitempty"`
+	IsolatedSymbol string `s2m:"isolatedSymbol,omitempty"`
+}
+
+type MarginMaxBorrowable struct {
+	Amount      float64 `json:"amount,string" bson:"amount,string"`           // account's currently max borrowable amount with sufficient system availability
+	BorrowLimit float64 `json:"borrowLimit,string" bson:"borrowLimit,string"` // max borrowable amount limited by the account level
+}
+
+var MarginMaxBorrowableConfig = cex.ReqConfig[MarginMaxBorrowableParams, MarginMaxBorrowable]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/maxBorrowable",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[MarginMaxBorrowable]),
+}
+
+// MarginInterestHistoriesParams
+// Response in descending order.
+// If isolatedSymbol is not sent, crossed margin data will be returned.
+// The max interval between startTime and endTime is 30 days.
+type MarginInterestHistoriesParams struct {
+	Asset          string `s2m:"asset,omitempty"`
+	IsolatedSymbol string `s2m:"isolatedSymbol,omitempty"`
+	StartTime      int64  `s2m:"startTime,omitempty"`
+	EndTime        int64  `s2m:"endTime,omitempty"`
+	Current        int64  `s2m:"current,omitempty"` // Currently querying page. Start from 1. Default:1
+	Size           int64  `s2m:"size,omitempty"`    // Default:10 Max:100
+}
+
+type MarginInterestHistory struct {
+	TxId                int64              `json:"txId" bson:"txId"`
+	InterestAccuredTime int64              `json:"interestAccuredTime" bson:"interestAccuredTime"`
+	Asset               string             `json:"asset" bson:"asset"`
+	RawAsset            string             `json:"rawAsset" bson:"rawAsset"` // will not be returned for isolated margin
+	Principal           float64            `json:"principal,string" bson:"principal,string"`
+	Interest            float64            `json:"interest,string" bson:"interest,string"`
+	InterestRate        float64            `json:"interestRate,string" bson:"interestRate,string"`
+	Type                MarginInterestType `json:"type" bson:"type"`
+	IsolatedSymbol      string             `json:"isolatedSymbol" bson:"isolatedSymbol"` // isolated symbol, will not be returned for crossed margin
+}
+
+var MarginInterestHistoriesConfig = cex.ReqConfig[MarginInterestHistoriesParams, Page[[]MarginInterestHistory]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/interestHistory",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]MarginInterestHistory]]),
+}
+
+// ---------------------------------------------
+// Margin Borrow and Repay
+// =============================================
+
+// =============================================
+// Margin Trading
+// ---------------------------------------------
+
+type MarginNewOrderParams struct {
+	Symbol                  string                    `s2m:"symbol,omitempty"`
+	IsIsolated              BigBool                   `s2m:"isIsolated,omitempty"` // TRUE for isolated margin, FALSE for cross margin, default FALSE
+	Side                    OrderSide                 `s2m:"side,omitempty"`
+	Type                    OrderType                 `s2m:"type,omitempty"`
+	Quantity                float64                   `s2m:"quantity,omitempty"`
+	QuoteOrderQty           float64                   `s2m:"quoteOrderQty,omitempty"`
+	Price                   float64                   `s2m:"price,omitempty"`
+	StopPrice               float64                   `s2m:"stopPrice,omitempty"` // Used with STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
+	NewClientOrderId        string                    `s2m:"newClientOrderId,omitempty"`
+	IcebergQty              float64                   `s2m:"icebergQty,omitempty"`
+	NewOrderRespType        OrderResponseType         `s2m:"newOrderRespType,omitempty"`
+	SideEffectType          MarginOrderSideEffectType `s2m:"sideEffectType,omitempty"` // default NO_SIDE_EFFECT
+	TimeInForce             TimeInForce               `s2m:"timeInForce,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode   `s2m:"selfTradePreventionMode,omitempty"`
+	AutoRepayAtCancel       SmallBool                 `s2m:"autoRepayAtCancel,omitempty"` // Only when MARGIN_BUY or AUTO_BORROW_REPAY order takes effect, default true
+}
+
+// Validate checks required params, symbol is the isolated symbol, if IsIsolated is TRUE.
+func (p MarginNewOrderParams) Validate() error {
+	return cex.FirstParamsErr(
+		cex.RequireParam("symbol", p.Symbol != ""),
+		cex.RequireParam("side", p.Side != ""),
+		cex.RequireParam("type", p.Type != ""),
+	)
+}
+
+// MarginOrder is responded by margin order endpoints.
+// Its fields are same as SpotOrder, except margin fields.
+type MarginOrder struct {
+	SpotOrder
+
+	IsIsolated            bool    `json:"isIsolated" bson:"isIsolated"`
+	MarginBuyBorrowAmount float64 `json:"marginBuyBorrowAmount" bson:"marginBuyBorrowAmount"` // will not return if no margin trade happens
+	MarginBuyBorrowAsset  string  `json:"marginBuyBorrowAsset" bson:"marginBuyBorrowAsset"`   // will not return if no margin trade happens
+}
+
+var MarginNewOrderConfig = cex.ReqConfig[MarginNewOrderParams, MarginOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/order",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[MarginOrder]),
+}
+
+type MarginQueryOrCancelOrderParams struct {
+	Symbol            string  `s2m:"symbol,omitempty"`
+	IsIsolated        BigBool `s2m:"isIsolated,omitempty"`
+	OrderId           int64   `s2m:"orderId,omitempty"`
+	OrigClientOrderId string  `s2m:"origClientOrderId,omitempty"`
+	NewClientOrderId  string  `s2m:"newClientOrderId,omitempty"` // only for cancel, used to uniquely identify this cancel
+}
+
+var MarginCancelOrderConfig = cex.ReqConfig[MarginQueryOrCancelOrderParams, MarginOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/order",
+		Method:           http.MethodDelete,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[MarginOrder]),
+}
+
+var MarginQueryOrderConfig = cex.ReqConfig[MarginQueryOrCancelOrderParams, MarginOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/margin/order",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[MarginOrder]),
+}
+
+// ---------------------------------------------
+// Margin Trading
+// =============================================
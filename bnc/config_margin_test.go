@@ -0,0 +1,91 @@
+package bnc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestMarginAccount(t *testing.T) {
+	testConfig(MarginAccountConfig, nil)
+}
+
+func TestIsolatedMarginAccount(t *testing.T) {
+	testConfig(IsolatedMarginAccountConfig, IsolatedMarginAccountParams{})
+}
+
+func TestMarginMaxBorrowable(t *testing.T) {
+	testConfig(MarginMaxBorrowableConfig, MarginMaxBorrowableParams{Asset: "USDT"})
+}
+
+func TestMarginInterestHistories(t *testing.T) {
+	testConfig(MarginInterestHistoriesConfig, MarginInterestHistoriesParams{Size: 10})
+}
+
+func TestMarginOrderUnmarshal(t *testing.T) {
+	body := []byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"6gCrw2kRUAF9CvJDGP16IP","transactTime":1507725176595,"price":"1.00000000","origQty":"10.00000000","executedQty":"10.00000000","cummulativeQuoteQty":"10.00000000","status":"FILLED","timeInForce":"GTC","type":"MARKET","side":"SELL","marginBuyBorrowAmount":5,"marginBuyBorrowAsset":"BTC","isIsolated":true}`)
+	ord, err := MarginNewOrderConfig.RespBodyUnmarshaler(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ord.OrderId != 28 || ord.Status != OrderStatusFilled || ord.ExecutedQty != 10 {
+		t.Error("spot order fields are not unmarshalled", ord.SpotOrder)
+	}
+	if !ord.IsIsolated || ord.MarginBuyBorrowAmount != 5 || ord.MarginBuyBorrowAsset != "BTC" {
+		t.Error("margin fields are not unmarshalled", ord)
+	}
+}
+
+func TestIsolatedMarginEnable(t *testing.T) {
+	testConfig(IsolatedMarginEnableConfig, IsolatedMarginSymbolParams{Symbol: "BTCUSDT"})
+}
+
+func TestIsolatedMarginDisable(t *testing.T) {
+	testConfig(IsolatedMarginDisableConfig, IsolatedMarginSymbolParams{Symbol: "BTCUSDT"})
+}
+
+func TestIsolatedMarginAccountLimit(t *testing.T) {
+	testConfig(IsolatedMarginAccountLimitConfig, nil)
+}
+
+func TestUser_IsolatedMargin(t *testing.T) {
+	var queries []url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		queries = append(queries, r.URL.Query())
+		body := `{"symbol":"ETHUSDT","orderId":1,"status":"NEW","isIsolated":true}`
+		if r.URL.Path == "/sapi/v1/asset/transfer" {
+			body = `{"tranId":1}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	_, ord, err := user.NewIsolatedMarginOrder(MarginNewOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeMarket, Quantity: 1})
+	if err.IsNotNil() || !ord.IsIsolated {
+		t.Fatal("isolated order should be placed", err.Error())
+	}
+	if q := queries[0]; q.Get("isIsolated") != "TRUE" || q.Get("symbol") != "ETHUSDT" {
+		t.Error("isolated order should be sent with isIsolated", q)
+	}
+
+	_, _, err = user.IsolatedMarginTransfer(TransferTypeMainIsolatedMargin, "USDT", "", "ETHUSDT", 10)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if q := queries[1]; q.Get("toSymbol") != "ETHUSDT" || q.Has("fromSymbol") {
+		t.Error("transfer into isolated margin should be sent with toSymbol", q)
+	}
+	if _, _, err = user.IsolatedMarginTransfer(TransferTypeIsolatedMarginMain, "USDT", "", "", 10); !errors.Is(err.Err, cex.ErrInvalidParams) || len(queries) != 2 {
+		t.Error("transfer out of isolated margin requires fromSymbol", err.Error())
+	}
+}
@@ -0,0 +1,291 @@
+package bnc
+
+import (
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Options (EAPI), european options settled in USDT
+// ============================================================
+
+type OptionSide string
+
+const (
+	OptionSideCall OptionSide = "CALL"
+	OptionSidePut  OptionSide = "PUT"
+)
+
+// ------------------------------------------------------------
+// Exchange Info
+// ------------------------------------------------------------
+
+type OptionContract struct {
+	BaseAsset   string `json:"baseAsset" bson:"baseAsset"`
+	QuoteAsset  string `json:"quoteAsset" bson:"quoteAsset"`
+	Underlying  string `json:"underlying" bson:"underlying"`
+	SettleAsset string `json:"settleAsset" bson:"settleAsset"`
+}
+
+type OptionAsset struct {
+	Name string `json:"name" bson:"name"`
+}
+
+type OptionSymbol struct {
+	Symbol               string           `json:"symbol" bson:"symbol"` // ex. BTC-220815-50000-C
+	Side                 OptionSide       `json:"side" bson:"side"`
+	StrikePrice          float64          `json:"strikePrice,string" bson:"strikePrice"`
+	Underlying           string           `json:"underlying" bson:"underlying"`
+	Unit                 float64          `json:"unit" bson:"unit"` // quantity of underlying of one contract
+	ExpiryDate           int64            `json:"expiryDate" bson:"expiryDate"`
+	MakerFeeRate         float64          `json:"makerFeeRate,string" bson:"makerFeeRate"`
+	TakerFeeRate         float64          `json:"takerFeeRate,string" bson:"takerFeeRate"`
+	MinQty               float64          `json:"minQty,string" bson:"minQty"`
+	MaxQty               float64          `json:"maxQty,string" bson:"maxQty"`
+	InitialMargin        float64          `json:"initialMargin,string" bson:"initialMargin"`
+	MaintenanceMargin    float64          `json:"maintenanceMargin,string" bson:"maintenanceMargin"`
+	MinInitialMargin     float64          `json:"minInitialMargin,string" bson:"minInitialMargin"`
+	MinMaintenanceMargin float64          `json:"minMaintenanceMargin,string" bson:"minMaintenanceMargin"`
+	PriceScale           int              `json:"priceScale" bson:"priceScale"`
+	QuantityScale        int              `json:"quantityScale" bson:"quantityScale"`
+	QuoteAsset           string           `json:"quoteAsset" bson:"quoteAsset"`
+	Filters              []map[string]any `json:"filters" bson:"filters"`
+}
+
+type OptionExchangeInfo struct {
+	Timezone        string              `json:"timezone" bson:"timezone"`
+	ServerTime      int64               `json:"serverTime" bson:"serverTime"`
+	OptionContracts []OptionContract    `json:"optionContracts" bson:"optionContracts"`
+	OptionAssets    []OptionAsset       `json:"optionAssets" bson:"optionAssets"`
+	OptionSymbols   []OptionSymbol      `json:"optionSymbols" bson:"optionSymbols"`
+	RateLimits      []ExchangeRateLimit `json:"rateLimits" bson:"rateLimits"`
+}
+
+var OptionExchangeInfoConfig = cex.ReqConfig[cex.NilReqData, OptionExchangeInfo]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          EapiBaseUrl,
+		Path:             EapiV1 + "/exchangeInfo",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   optBodyUnmshWrapper(cex.StdBodyUnmarshaler[OptionExchangeInfo]),
+	CacheTTL:              exchangeInfoCacheTTL,
+}
+
+// ------------------------------------------------------------
+// Mark Price
+// ------------------------------------------------------------
+
+// OptionMarkPriceParams
+// Symbol is optional, all symbols are returned if it is empty.
+type OptionMarkPriceParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+}
+
+type OptionMarkPrice struct {
+	Symbol           string  `json:"symbol" bson:"symbol"`
+	MarkPrice        float64 `json:"markPrice,string" bson:"markPrice"`
+	BidIV            float64 `json:"bidIV,string" bson:"bidIV"`
+	AskIV            float64 `json:"askIV,string" bson:"askIV"`
+	MarkIV           float64 `json:"markIV,string" bson:"markIV"`
+	Delta            float64 `json:"delta,string" bson:"delta"`
+	Theta            float64 `json:"theta,string" bson:"theta"`
+	Gamma            float64 `json:"gamma,string" bson:"gamma"`
+	Vega             float64 `json:"vega,string" bson:"vega"`
+	HighPriceLimit   float64 `json:"highPriceLimit,string" bson:"highPriceLimit"`
+	LowPriceLimit    float64 `json:"lowPriceLimit,string" bson:"lowPriceLimit"`
+	RiskFreeInterest float64 `json:"riskFreeInterest,string" bson:"riskFreeInterest"`
+}
+
+var OptionMarkPriceConfig = cex.ReqConfig[OptionMarkPriceParams, []OptionMarkPrice]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          EapiBaseUrl,
+		Path:             EapiV1 + "/mark",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   optBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]OptionMarkPrice]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+// ------------------------------------------------------------
+// Order
+// ------------------------------------------------------------
+
+// OptionNewOrderParams
+// Only LIMIT order is supported by options.
+type OptionNewOrderParams struct {
+	Symbol           string            `s2m:"symbol,omitempty"`
+	Side             OrderSide         `s2m:"side,omitempty"`
+	Type             OrderType         `s2m:"type,omitempty"`
+	Quantity         float64           `s2m:"quantity,omitempty"`
+	Price            float64           `s2m:"price,omitempty"`
+	TimeInForce      TimeInForce       `s2m:"timeInForce,omitempty"` // GTC, IOC, FOK, default GTC
+	ReduceOnly       bool              `s2m:"reduceOnly,omitempty"`
+	PostOnly         bool              `s2m:"postOnly,omitempty"`
+	NewOrderRespType OrderResponseType `s2m:"newOrderRespType,omitempty"` // ACK, RESULT, default ACK
+	ClientOrderId    string            `s2m:"clientOrderId,omitempty"`
+	IsMmp            bool              `s2m:"isMmp,omitempty"` // is market maker protection order
+}
+
+// OptionQueryOrCancelOrderParams
+// Either OrderId or ClientOrderId must be sent.
+type OptionQueryOrCancelOrderParams struct {
+	Symbol        string `s2m:"symbol,omitempty"`
+	OrderId       int64  `s2m:"orderId,omitempty"`
+	ClientOrderId string `s2m:"clientOrderId,omitempty"`
+}
+
+type OptionOrder struct {
+	OrderId       int64       `json:"orderId" bson:"orderId"`
+	ClientOrderId string      `json:"clientOrderId" bson:"clientOrderId"`
+	Symbol        string      `json:"symbol" bson:"symbol"`
+	Price         float64     `json:"price,string" bson:"price"`
+	Quantity      float64     `json:"quantity,string" bson:"quantity"`
+	ExecutedQty   float64     `json:"executedQty,string" bson:"executedQty"`
+	Fee           float64     `json:"fee,string" bson:"fee"`
+	Side          OrderSide   `json:"side" bson:"side"`
+	Type          OrderType   `json:"type" bson:"type"`
+	TimeInForce   TimeInForce `json:"timeInForce" bson:"timeInForce"`
+	ReduceOnly    bool        `json:"reduceOnly" bson:"reduceOnly"`
+	PostOnly      bool        `json:"postOnly" bson:"postOnly"`
+	CreateTime    int64       `json:"createTime" bson:"createTime"`
+	UpdateTime    int64       `json:"updateTime" bson:"updateTime"`
+	Status        OrderStatus `json:"status" bson:"status"` // ACCEPTED, REJECTED, PARTIALLY_FILLED, FILLED, CANCELLED
+	AvgPrice      float64     `json:"avgPrice,string" bson:"avgPrice"`
+	Source        string      `json:"source" bson:"source"`
+	PriceScale    int         `json:"priceScale" bson:"priceScale"`
+	QuantityScale int         `json:"quantityScale" bson:"quantityScale"`
+	OptionSide    OptionSide  `json:"optionSide" bson:"optionSide"`
+	QuoteAsset    string      `json:"quoteAsset" bson:"quoteAsset"`
+	Mmp           bool        `json:"mmp" bson:"mmp"`
+}
+
+var OptionNewOrderConfig = cex.ReqConfig[OptionNewOrderParams, OptionOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          EapiBaseUrl,
+		Path:             EapiV1 + "/order",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   optBodyUnmshWrapper(cex.StdBodyUnmarshaler[OptionOrder]),
+}
+
+var OptionQueryOrderConfig = cex.ReqConfig[OptionQueryOrCancelOrderParams, OptionOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          EapiBaseUrl,
+		Path:             EapiV1 + "/order",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   optBodyUnmshWrapper(cex.StdBodyUnmarshaler[OptionOrder]),
+}
+
+var OptionCancelOrderConfig = cex.ReqConfig[OptionQueryOrCancelOrderParams, OptionOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          EapiBaseUrl,
+		Path:             EapiV1 + "/order",
+		Method:           http.MethodDelete,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   optBodyUnmshWrapper(cex.StdBodyUnmarshaler[OptionOrder]),
+}
+
+// ------------------------------------------------------------
+// Position
+// ------------------------------------------------------------
+
+// OptionPositionParams
+// Symbol is optional, all positions are returned if it is empty.
+type OptionPositionParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+}
+
+type OptionPosition struct {
+	Symbol        string              `json:"symbol" bson:"symbol"`
+	Side          FuturesPositionSide `json:"side" bson:"side"` // LONG, SHORT
+	Quantity      float64             `json:"quantity,string" bson:"quantity"`
+	ReducibleQty  float64             `json:"reducibleQty,string" bson:"reducibleQty"`
+	EntryPrice    float64             `json:"entryPrice,string" bson:"entryPrice"`
+	MarkValue     float64             `json:"markValue,string" bson:"markValue"`
+	Ror           float64             `json:"ror,string" bson:"ror"`
+	UnrealizedPNL float64             `json:"unrealizedPNL,string" bson:"unrealizedPNL"`
+	MarkPrice     float64             `json:"markPrice,string" bson:"markPrice"`
+	StrikePrice   float64             `json:"strikePrice,string" bson:"strikePrice"`
+	PositionCost  float64             `json:"positionCost,string" bson:"positionCost"`
+	ExpiryDate    int64               `json:"expiryDate" bson:"expiryDate"`
+	PriceScale    int                 `json:"priceScale" bson:"priceScale"`
+	QuantityScale int                 `json:"quantityScale" bson:"quantityScale"`
+	OptionSide    OptionSide          `json:"optionSide" bson:"optionSide"`
+	QuoteAsset    string              `json:"quoteAsset" bson:"quoteAsset"`
+}
+
+var OptionPositionConfig = cex.ReqConfig[OptionPositionParams, []OptionPosition]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          EapiBaseUrl,
+		Path:             EapiV1 + "/position",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   optBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]OptionPosition]),
+}
+
+// ------------------------------------------------------------
+// Account
+// ------------------------------------------------------------
+
+type OptionAccountAsset struct {
+	Asset         string  `json:"asset" bson:"asset"`
+	MarginBalance float64 `json:"marginBalance,string" bson:"marginBalance"`
+	Equity        float64 `json:"equity,string" bson:"equity"`
+	Available     float64 `json:"available,string" bson:"available"`
+	Locked        float64 `json:"locked,string" bson:"locked"`
+	UnrealizedPNL float64 `json:"unrealizedPNL,string" bson:"unrealizedPNL"`
+}
+
+type OptionAccountGreek struct {
+	Underlying string  `json:"underlying" bson:"underlying"`
+	Delta      float64 `json:"delta,string" bson:"delta"`
+	Gamma      float64 `json:"gamma,string" bson:"gamma"`
+	Theta      float64 `json:"theta,string" bson:"theta"`
+	Vega       float64 `json:"vega,string" bson:"vega"`
+}
+
+type OptionAccount struct {
+	Asset     []OptionAccountAsset `json:"asset" bson:"asset"`
+	Greek     []OptionAccountGreek `json:"greek" bson:"greek"`
+	Time      int64                `json:"time" bson:"time"`
+	RiskLevel string               `json:"riskLevel" bson:"riskLevel"` // NORMAL, MEDIUM, HIGH
+}
+
+var OptionAccountConfig = cex.ReqConfig[cex.NilReqData, OptionAccount]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          EapiBaseUrl,
+		Path:             EapiV1 + "/account",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   optBodyUnmshWrapper(cex.StdBodyUnmarshaler[OptionAccount]),
+}
@@ -0,0 +1,68 @@
+package bnc
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestOptionConfigs(t *testing.T) {
+	var reqUrl *url.URL
+	var reqMethod string
+	bodies := map[string]string{
+		"/eapi/v1/account":  `{"asset":[{"asset":"USDT","marginBalance":"1877.52","equity":"617.77","available":"0","locked":"2898.92","unrealizedPNL":"222.23"}],"greek":[{"underlying":"BTCUSDT","delta":"-0.05","gamma":"-0.002","theta":"-0.05","vega":"-0.002"}],"time":1592449455993,"riskLevel":"NORMAL"}`,
+		"/eapi/v1/position": `[{"entryPrice":"1000","symbol":"BTC-200730-9000-C","side":"SHORT","quantity":"-0.1","reducibleQty":"0","markValue":"105.00","ror":"-0.05","unrealizedPNL":"-5.00","markPrice":"1050.01","strikePrice":"9000","positionCost":"1000.0000","expiryDate":1593511200000,"priceScale":2,"quantityScale":2,"optionSide":"CALL","quoteAsset":"USDT"}]`,
+		"/eapi/v1/order":    `{"orderId":4611875134427365377,"symbol":"BTC-200730-9000-C","price":"100","quantity":"1","executedQty":"0","fee":"0","side":"BUY","type":"LIMIT","timeInForce":"GTC","reduceOnly":false,"postOnly":false,"createTime":1592465880683,"updateTime":1566818724722,"status":"ACCEPTED","avgPrice":"0","clientOrderId":"","priceScale":2,"quantityScale":2,"optionSide":"CALL","quoteAsset":"USDT","mmp":false}`,
+	}
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		reqUrl, reqMethod = r.URL, r.Method
+		body, status := bodies[r.URL.Path], http.StatusOK
+		if r.Method == http.MethodDelete {
+			body, status = `{"code":-2013,"msg":"Order does not exist."}`, http.StatusBadRequest
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	_, acct, err := user.OptionAccount()
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if reqUrl.Host != "eapi.binance.com" || len(acct.Asset) != 1 || acct.Asset[0].Equity != 617.77 || acct.Greek[0].Delta != -0.05 {
+		t.Error("wrong account", reqUrl.Host, acct)
+	}
+
+	_, positions, err := user.OptionPositions("BTC-200730-9000-C")
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if len(positions) != 1 || positions[0].Quantity != -0.1 || positions[0].OptionSide != OptionSideCall || reqUrl.Query().Get("symbol") != "BTC-200730-9000-C" {
+		t.Error("wrong positions", positions)
+	}
+
+	_, ord, err := user.NewOptionOrder("BTC-200730-9000-C", cex.OrderSideBuy, 1, 100)
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	query := reqUrl.Query()
+	if reqMethod != http.MethodPost || query.Get("type") != "LIMIT" || query.Get("side") != "BUY" || query.Get("timeInForce") != "GTC" {
+		t.Error("wrong new order request", reqMethod, query)
+	}
+	if ord.OrderId != 4611875134427365377 || ord.Price != 100 || ord.Status != "ACCEPTED" {
+		t.Error("wrong order", ord)
+	}
+
+	_, _, err = user.CancelOptionOrder("BTC-200730-9000-C", ord.OrderId, "")
+	if err.IsNil() || err.RespBodyUnmarshalerError == nil || err.RespBodyUnmarshalerError.CexErrCode != -2013 {
+		t.Error("cancel should fail by code msg", err)
+	}
+}
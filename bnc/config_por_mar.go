@@ -63,7 +63,7 @@ var PortfolioMarginAccountDetailConfig = cex.ReqConfig[cex.NilReqData, Portfolio
 }
 
 type PortfolioMarginAccountBalanceParams struct {
-	Symbol string `s2m:"symbol,omitempty"`
+	Asset string `s2m:"asset,omitempty"`
 }
 
 type PortfolioMarginBalance struct {
@@ -98,18 +98,20 @@ type PortfolioMarginUMPositionRisk struct {
 
 type PortfolioMarginCMPositionRisk PortfolioMarginUMPositionRisk
 
-//var PortfolioMarginBalanceConfig = cex.ReqConfig[PortfolioMarginAccountBalanceParams, PortfolioMarginBalance]{
-//	ReqBaseConfig: cex.ReqBaseConfig{
-//		BaseUrl:          PapiBaseUrl,
-//		Path:             PapiV1 + "/balance",
-//		Method:           http.MethodGet,
-//		IsUserData:       true,
-//		UserTimeInterval: 0,
-//		IpTimeInterval:   0,
-//	},
-//	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
-//	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[PortfolioMarginBalance]),
-//}
+// PortfolioMarginBalanceConfig queries balance of one asset,
+// response is an object instead of array if asset is set.
+var PortfolioMarginBalanceConfig = cex.ReqConfig[PortfolioMarginAccountBalanceParams, PortfolioMarginBalance]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          PapiBaseUrl,
+		Path:             PapiV1 + "/balance",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[PortfolioMarginBalance]),
+}
 
 var PortfolioMarginBalancesConfig = cex.ReqConfig[cex.NilReqData, []PortfolioMarginBalance]{
 	ReqBaseConfig: cex.ReqBaseConfig{
@@ -254,6 +256,32 @@ var PortfolioMarginCMPositionsConfig = cex.ReqConfig[FuturesPositionsParams, []P
 	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]PortfolioMarginCMPositionRisk]),
 }
 
+var PortfolioMarginOpenOrdersConfig = cex.ReqConfig[FuturesQueryOrCancelOrderParams, []FuturesOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          PapiBaseUrl,
+		Path:             PapiV1 + "/um/openOrders",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesOrder]),
+}
+
+var PortfolioMarginCMOpenOrdersConfig = cex.ReqConfig[FuturesQueryOrCancelOrderParams, []FuturesOrder]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          PapiBaseUrl,
+		Path:             PapiV1 + "/cm/openOrders",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesOrder]),
+}
+
 type PortfolioMarginBNBTransferParams struct {
 	Amount       float64
 	TransferSide PortfolioMarginBNBTransferSide
@@ -0,0 +1,43 @@
+package bnc
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPortfolioMarginConfigs(t *testing.T) {
+	var reqUrl *url.URL
+	bodies := map[string]string{
+		"/papi/v1/balance":       `{"asset":"USDT","totalWalletBalance":"122607.35","crossMarginAsset":"92.27","crossMarginBorrowed":"10.00","crossMarginFree":"100.00","crossMarginInterest":"0.72","crossMarginLocked":"3.00","umWalletBalance":"0.0","umUnrealizedPNL":"23.72","cmWalletBalance":"23.72","cmUnrealizedPNL":"0","updateTime":1617939110373}`,
+		"/papi/v1/cm/openOrders": `[{"symbol":"BTCUSD_PERP","orderId":1,"clientOrderId":"abc","price":"30000","origQty":"1","executedQty":"0","status":"NEW","type":"LIMIT","side":"BUY"}]`,
+	}
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		reqUrl = r.URL
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(bodies[r.URL.Path])),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport), UserOptSetPortfolioMarginAccount())
+
+	_, bal, err := user.PortfolioMarginBalance("USDT")
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if reqUrl.Host != "papi.binance.com" || reqUrl.Query().Get("asset") != "USDT" || bal.Asset != "USDT" || bal.CrossMarginFree != 100 {
+		t.Error("wrong balance", reqUrl, bal)
+	}
+
+	_, ords, err := user.PortfolioMarginCMOpenOrders("BTCUSD_PERP")
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if len(ords) != 1 || ords[0].OrderId != 1 || ords[0].Price != 30000 || reqUrl.Query().Get("symbol") != "BTCUSD_PERP" {
+		t.Error("wrong open orders", reqUrl, ords)
+	}
+}
@@ -2,11 +2,19 @@ package bnc
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/dwdwow/cex"
 	"github.com/dwdwow/cex/ob"
 )
 
+// CacheTTL of public configs, works only if cex.DefaultResponseCache is set.
+const (
+	exchangeInfoCacheTTL = time.Minute
+	marketDataCacheTTL   = time.Second
+)
+
 type OrderBookParams struct {
 	Symbol string `s2m:"symbol,omitempty"`
 	Limit  int    `s2m:"limit,omitempty"` // default 100, max 5000
@@ -131,6 +139,7 @@ var SpotExchangeInfosConfig = cex.ReqConfig[cex.NilReqData, ExchangeInfo]{
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
 	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[ExchangeInfo]),
+	CacheTTL:              exchangeInfoCacheTTL,
 }
 
 var FuturesExchangeInfosConfig = cex.ReqConfig[cex.NilReqData, ExchangeInfo]{
@@ -144,6 +153,51 @@ var FuturesExchangeInfosConfig = cex.ReqConfig[cex.NilReqData, ExchangeInfo]{
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
 	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[ExchangeInfo]),
+	CacheTTL:              exchangeInfoCacheTTL,
+}
+
+type ServerTime struct {
+	ServerTime int64 `json:"serverTime" bson:"serverTime"`
+}
+
+// SpotPingConfig tests connectivity, response is empty json.
+var SpotPingConfig = cex.ReqConfig[cex.NilReqData, struct{}]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/ping",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[struct{}]),
+}
+
+var SpotServerTimeConfig = cex.ReqConfig[cex.NilReqData, ServerTime]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/time",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[ServerTime]),
+}
+
+var FuturesServerTimeConfig = cex.ReqConfig[cex.NilReqData, ServerTime]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/time",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[ServerTime]),
 }
 
 // FuturesFundingRateHistoriesParams
@@ -227,6 +281,25 @@ var FuturesFundingRatesConfig = cex.ReqConfig[FuturesFundingRatesParams, []Futur
 	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesFundingRate]),
 }
 
+type FuturesPremiumIndexParams struct {
+	Symbol string `s2m:"symbol"`
+}
+
+// FuturesPremiumIndexConfig queries mark price, index price and funding rate of one symbol.
+// FuturesFundingRatesConfig queries all symbols.
+var FuturesPremiumIndexConfig = cex.ReqConfig[FuturesPremiumIndexParams, FuturesFundingRate]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/premiumIndex",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[FuturesFundingRate]),
+}
+
 type KlineInterval string
 
 const (
@@ -236,6 +309,34 @@ const (
 	KlineInterval1h = "1h"
 )
 
+// Duration returns duration of interval, ex. 1s, 3m, 4h, 1d, 1w.
+// Returns 0, if interval is invalid or 1M, because month is not fixed.
+func (i KlineInterval) Duration() time.Duration {
+	if len(i) < 2 {
+		return 0
+	}
+	n, err := strconv.ParseInt(string(i[:len(i)-1]), 10, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	var unit time.Duration
+	switch i[len(i)-1] {
+	case 's':
+		unit = time.Second
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+	return time.Duration(n) * unit
+}
+
 type KlineParams struct {
 	Symbol    string        `s2m:"symbol,omitempty"`
 	Interval  KlineInterval `s2m:"interval,omitempty"`
@@ -275,6 +376,7 @@ var SpotKlineConfig = cex.ReqConfig[KlineParams, []Kline]{
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
 	RespBodyUnmarshaler:   spotBodyUnmshWrapper(klineBodyUnmsher),
+	CacheTTL:              marketDataCacheTTL,
 }
 
 var FuturesKlineConfig = cex.ReqConfig[KlineParams, []Kline]{
@@ -288,6 +390,121 @@ var FuturesKlineConfig = cex.ReqConfig[KlineParams, []Kline]{
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
 	RespBodyUnmarshaler:   spotBodyUnmshWrapper(klineBodyUnmsher),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+// FuturesMarkPriceKlineConfig
+// Only OpenTime, CloseTime and prices of Kline are popular,
+// volumes and trades number of mark price klines are always 0.
+var FuturesMarkPriceKlineConfig = cex.ReqConfig[KlineParams, []Kline]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/markPriceKlines",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(klineBodyUnmsher),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+// TradesParams
+// Limit, default 500, max 1000
+type TradesParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+	Limit  int    `s2m:"limit,omitempty"`
+}
+
+type Trade struct {
+	Id           int64   `json:"id" bson:"id"`
+	Price        float64 `json:"price,string" bson:"price,string"`
+	Qty          float64 `json:"qty,string" bson:"qty,string"`
+	QuoteQty     float64 `json:"quoteQty,string" bson:"quoteQty,string"`
+	Time         int64   `json:"time" bson:"time"`
+	IsBuyerMaker bool    `json:"isBuyerMaker" bson:"isBuyerMaker"`
+	// just for spot
+	IsBestMatch bool `json:"isBestMatch" bson:"isBestMatch"`
+}
+
+var SpotTradesConfig = cex.ReqConfig[TradesParams, []Trade]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/trades",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]Trade]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var FuturesTradesConfig = cex.ReqConfig[TradesParams, []Trade]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/trades",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]Trade]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+// AggTradesParams
+// If StartTime and EndTime are both sent, time between them must be less than 1 hour.
+// Limit, default 500, max 1000
+type AggTradesParams struct {
+	Symbol    string `s2m:"symbol,omitempty"`
+	FromId    int64  `s2m:"fromId,omitempty"`
+	StartTime int64  `s2m:"startTime,omitempty"`
+	EndTime   int64  `s2m:"endTime,omitempty"`
+	Limit     int    `s2m:"limit,omitempty"`
+}
+
+type AggTrade struct {
+	AggTradeId   int64   `json:"a" bson:"a"`
+	Price        float64 `json:"p,string" bson:"p,string"`
+	Qty          float64 `json:"q,string" bson:"q,string"`
+	FirstTradeId int64   `json:"f" bson:"f"`
+	LastTradeId  int64   `json:"l" bson:"l"`
+	Time         int64   `json:"T" bson:"T"`
+	IsBuyerMaker bool    `json:"m" bson:"m"`
+	// just for spot
+	IsBestMatch bool `json:"M" bson:"M"`
+}
+
+var SpotAggTradesConfig = cex.ReqConfig[AggTradesParams, []AggTrade]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/aggTrades",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]AggTrade]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var FuturesAggTradesConfig = cex.ReqConfig[AggTradesParams, []AggTrade]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/aggTrades",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]AggTrade]),
+	CacheTTL:              marketDataCacheTTL,
 }
 
 type SpotPriceTicker struct {
@@ -305,7 +522,8 @@ var SpotPricesConfig = cex.ReqConfig[cex.NilReqData, []SpotPriceTicker]{
 		IpTimeInterval:   0,
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
-	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]SpotPriceTicker]),
+	RespBodyUnmarshaler:   arrayBodyUnmshWrapper(spotBodyUnmshWrapper, cex.StdBodyUnmarshaler[[]SpotPriceTicker]),
+	CacheTTL:              marketDataCacheTTL,
 }
 
 type FuturesPriceTicker struct {
@@ -324,7 +542,192 @@ var FuturesPricesConfig = cex.ReqConfig[cex.NilReqData, []FuturesPriceTicker]{
 		IpTimeInterval:   0,
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
-	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesPriceTicker]),
+	RespBodyUnmarshaler:   arrayBodyUnmshWrapper(fuBodyUnmshWrapper, cex.StdBodyUnmarshaler[[]FuturesPriceTicker]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+// TickerParams
+// Symbol is required by single symbol configs.
+type TickerParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+}
+
+var SpotPriceConfig = cex.ReqConfig[TickerParams, SpotPriceTicker]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/ticker/price",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SpotPriceTicker]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var FuturesPriceConfig = cex.ReqConfig[TickerParams, FuturesPriceTicker]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV2 + "/ticker/price",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[FuturesPriceTicker]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+type BookTicker struct {
+	Symbol   string  `json:"symbol" bson:"symbol"`
+	BidPrice float64 `json:"bidPrice,string" bson:"bidPrice,string"`
+	BidQty   float64 `json:"bidQty,string" bson:"bidQty,string"`
+	AskPrice float64 `json:"askPrice,string" bson:"askPrice,string"`
+	AskQty   float64 `json:"askQty,string" bson:"askQty,string"`
+
+	// just for futures
+	Time         int64 `json:"time" bson:"time"`
+	LastUpdateId int64 `json:"lastUpdateId" bson:"lastUpdateId"`
+}
+
+var SpotBookTickerConfig = cex.ReqConfig[TickerParams, BookTicker]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/ticker/bookTicker",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[BookTicker]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var SpotBookTickersConfig = cex.ReqConfig[cex.NilReqData, []BookTicker]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/ticker/bookTicker",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   arrayBodyUnmshWrapper(spotBodyUnmshWrapper, cex.StdBodyUnmarshaler[[]BookTicker]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var FuturesBookTickerConfig = cex.ReqConfig[TickerParams, BookTicker]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/ticker/bookTicker",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[BookTicker]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var FuturesBookTickersConfig = cex.ReqConfig[cex.NilReqData, []BookTicker]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/ticker/bookTicker",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   arrayBodyUnmshWrapper(fuBodyUnmshWrapper, cex.StdBodyUnmarshaler[[]BookTicker]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+type Ticker24hr struct {
+	Symbol             string  `json:"symbol" bson:"symbol"`
+	PriceChange        float64 `json:"priceChange,string" bson:"priceChange,string"`
+	PriceChangePercent float64 `json:"priceChangePercent,string" bson:"priceChangePercent,string"`
+	WeightedAvgPrice   float64 `json:"weightedAvgPrice,string" bson:"weightedAvgPrice,string"`
+	LastPrice          float64 `json:"lastPrice,string" bson:"lastPrice,string"`
+	LastQty            float64 `json:"lastQty,string" bson:"lastQty,string"`
+	OpenPrice          float64 `json:"openPrice,string" bson:"openPrice,string"`
+	HighPrice          float64 `json:"highPrice,string" bson:"highPrice,string"`
+	LowPrice           float64 `json:"lowPrice,string" bson:"lowPrice,string"`
+	Volume             float64 `json:"volume,string" bson:"volume,string"`
+	QuoteVolume        float64 `json:"quoteVolume,string" bson:"quoteVolume,string"`
+	OpenTime           int64   `json:"openTime" bson:"openTime"`
+	CloseTime          int64   `json:"closeTime" bson:"closeTime"`
+	FirstId            int64   `json:"firstId" bson:"firstId"`
+	LastId             int64   `json:"lastId" bson:"lastId"`
+	Count              int64   `json:"count" bson:"count"`
+
+	// just for spot
+	PrevClosePrice float64 `json:"prevClosePrice,string" bson:"prevClosePrice,string"`
+	BidPrice       float64 `json:"bidPrice,string" bson:"bidPrice,string"`
+	BidQty         float64 `json:"bidQty,string" bson:"bidQty,string"`
+	AskPrice       float64 `json:"askPrice,string" bson:"askPrice,string"`
+	AskQty         float64 `json:"askQty,string" bson:"askQty,string"`
+}
+
+var Spot24hrTickerConfig = cex.ReqConfig[TickerParams, Ticker24hr]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/ticker/24hr",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Ticker24hr]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var Spot24hrTickersConfig = cex.ReqConfig[cex.NilReqData, []Ticker24hr]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/ticker/24hr",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   arrayBodyUnmshWrapper(spotBodyUnmshWrapper, cex.StdBodyUnmarshaler[[]Ticker24hr]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var Futures24hrTickerConfig = cex.ReqConfig[TickerParams, Ticker24hr]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/ticker/24hr",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[Ticker24hr]),
+	CacheTTL:              marketDataCacheTTL,
+}
+
+var Futures24hrTickersConfig = cex.ReqConfig[cex.NilReqData, []Ticker24hr]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/ticker/24hr",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   arrayBodyUnmshWrapper(fuBodyUnmshWrapper, cex.StdBodyUnmarshaler[[]Ticker24hr]),
+	CacheTTL:              marketDataCacheTTL,
 }
 
 type CMPremiumIndex struct {
@@ -356,3 +759,114 @@ var CMPremiumIndexConfig = cex.ReqConfig[CMPremiumIndexParams, []CMPremiumIndex]
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
 	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]CMPremiumIndex]),
 }
+
+type FuturesIndexInfoParams struct {
+	Symbol string `s2m:"symbol,omitempty"` // composite index symbol, all symbols are returned if empty
+}
+
+type FuturesIndexComponent struct {
+	BaseAsset          string  `json:"baseAsset" bson:"baseAsset"`
+	QuoteAsset         string  `json:"quoteAsset" bson:"quoteAsset"`
+	WeightInQuantity   float64 `json:"weightInQuantity,string" bson:"weightInQuantity,string"`
+	WeightInPercentage float64 `json:"weightInPercentage,string" bson:"weightInPercentage,string"`
+}
+
+type FuturesIndexInfo struct {
+	Symbol        string                  `json:"symbol" bson:"symbol"`
+	Time          int64                   `json:"time" bson:"time"`
+	Component     string                  `json:"component" bson:"component"` // component asset type, ex. baseAsset
+	BaseAssetList []FuturesIndexComponent `json:"baseAssetList" bson:"baseAssetList"`
+}
+
+// FuturesIndexInfoConfig queries components and weights of composite indexes.
+var FuturesIndexInfoConfig = cex.ReqConfig[FuturesIndexInfoParams, []FuturesIndexInfo]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/indexInfo",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesIndexInfo]),
+}
+
+type FuturesAssetIndexParams struct {
+	Symbol string `s2m:"symbol,omitempty"` // asset pair, ex. ADAUSD
+}
+
+// FuturesAssetIndex is index price of multi-assets mode margin asset,
+// buffers and rates are used to value margin asset.
+type FuturesAssetIndex struct {
+	Symbol                string  `json:"symbol" bson:"symbol"`
+	Time                  int64   `json:"time" bson:"time"`
+	Index                 float64 `json:"index,string" bson:"index,string"`
+	BidBuffer             float64 `json:"bidBuffer,string" bson:"bidBuffer,string"`
+	AskBuffer             float64 `json:"askBuffer,string" bson:"askBuffer,string"`
+	BidRate               float64 `json:"bidRate,string" bson:"bidRate,string"`
+	AskRate               float64 `json:"askRate,string" bson:"askRate,string"`
+	AutoExchangeBidBuffer float64 `json:"autoExchangeBidBuffer,string" bson:"autoExchangeBidBuffer,string"`
+	AutoExchangeAskBuffer float64 `json:"autoExchangeAskBuffer,string" bson:"autoExchangeAskBuffer,string"`
+	AutoExchangeBidRate   float64 `json:"autoExchangeBidRate,string" bson:"autoExchangeBidRate,string"`
+	AutoExchangeAskRate   float64 `json:"autoExchangeAskRate,string" bson:"autoExchangeAskRate,string"`
+}
+
+// FuturesAssetIndexConfig queries asset index of one symbol.
+// FuturesAssetIndexesConfig queries all symbols.
+var FuturesAssetIndexConfig = cex.ReqConfig[FuturesAssetIndexParams, FuturesAssetIndex]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/assetIndex",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[FuturesAssetIndex]),
+}
+
+var FuturesAssetIndexesConfig = cex.ReqConfig[cex.NilReqData, []FuturesAssetIndex]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/assetIndex",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]FuturesAssetIndex]),
+}
+
+type FuturesIndexConstituentsParams struct {
+	Symbol string `s2m:"symbol"`
+}
+
+type FuturesIndexConstituent struct {
+	Exchange string  `json:"exchange" bson:"exchange"`
+	Symbol   string  `json:"symbol" bson:"symbol"`
+	Price    float64 `json:"price,string" bson:"price,string"`
+	Weight   float64 `json:"weight,string" bson:"weight,string"`
+}
+
+type FuturesIndexConstituents struct {
+	Symbol       string                    `json:"symbol" bson:"symbol"`
+	Time         int64                     `json:"time" bson:"time"`
+	Constituents []FuturesIndexConstituent `json:"constituents" bson:"constituents"`
+}
+
+// FuturesIndexConstituentsConfig queries exchanges, prices and weights of index price of symbol.
+var FuturesIndexConstituentsConfig = cex.ReqConfig[FuturesIndexConstituentsParams, FuturesIndexConstituents]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          FapiBaseUrl,
+		Path:             FapiV1 + "/constituents",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[FuturesIndexConstituents]),
+}
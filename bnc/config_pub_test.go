@@ -56,3 +56,46 @@ func TestFuturesFundingRateInfos(t *testing.T) {
 func TestFuturesFundingRates(t *testing.T) {
 	testPubConfig(FuturesFundingRatesConfig, FuturesFundingRatesParams{Symbol: ""})
 }
+
+func TestFuturesPremiumIndex(t *testing.T) {
+	testPubConfig(FuturesPremiumIndexConfig, FuturesPremiumIndexParams{Symbol: "ETHUSDT"})
+}
+
+func TestFuturesMarkPriceKline(t *testing.T) {
+	testPubConfig(FuturesMarkPriceKlineConfig, KlineParams{Symbol: "ETHUSDT", Interval: KlineInterval1m, Limit: 3})
+}
+
+func TestFuturesMarkPriceKlineUnmarshal(t *testing.T) {
+	body := []byte(`[[1591256400000,"9653.69440000","9653.69640000","9651.38600000","9651.55200000","0 ",1591256459999,"0",60,"0","0","0"]]`)
+	klines, err := klineBodyUnmsher(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 1 || klines[0].ClosePrice != 9651.552 || klines[0].CloseTime != 1591256459999 {
+		t.Error("wrong mark price kline", klines)
+	}
+}
+
+func TestFuturesOpenInterestHist(t *testing.T) {
+	testPubConfig(FuturesOpenInterestHistConfig, FuturesDataParams{Symbol: "ETHUSDT", Period: FuturesDataPeriod1h, Limit: 3})
+}
+
+func TestFuturesTopLongShortPositionRatio(t *testing.T) {
+	testPubConfig(FuturesTopLongShortPositionRatioConfig, FuturesDataParams{Symbol: "ETHUSDT", Period: FuturesDataPeriod1h, Limit: 3})
+}
+
+func TestFuturesTakerLongShortRatio(t *testing.T) {
+	testPubConfig(FuturesTakerLongShortRatioConfig, FuturesDataParams{Symbol: "ETHUSDT", Period: FuturesDataPeriod1h, Limit: 3})
+}
+
+func TestFuturesIndexInfo(t *testing.T) {
+	testPubConfig(FuturesIndexInfoConfig, FuturesIndexInfoParams{})
+}
+
+func TestFuturesAssetIndexes(t *testing.T) {
+	testPubConfig(FuturesAssetIndexesConfig, nil)
+}
+
+func TestFuturesIndexConstituents(t *testing.T) {
+	testPubConfig(FuturesIndexConstituentsConfig, FuturesIndexConstituentsParams{Symbol: "ETHUSDT"})
+}
@@ -1,7 +1,9 @@
 package bnc
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/dwdwow/cex"
 )
@@ -43,6 +45,17 @@ type Coin struct {
 	NetworkList      []CoinNetworkInfo `json:"networkList" bson:"networkList"`
 }
 
+// Network returns network info of coin.
+// If network is empty, returns the default network.
+func (c Coin) Network(network Network) (CoinNetworkInfo, bool) {
+	for _, info := range c.NetworkList {
+		if (network == NetworkNone && info.IsDefault) || Network(info.Network) == network {
+			return info, true
+		}
+	}
+	return CoinNetworkInfo{}, false
+}
+
 var CoinInfoConfig = cex.ReqConfig[cex.NilReqData, []Coin]{
 	ReqBaseConfig: cex.ReqBaseConfig{
 		BaseUrl:          ApiBaseUrl,
@@ -63,25 +76,20 @@ type SpotBalance struct {
 }
 
 type SpotAccount struct {
-	MakerCommission  float64 `json:"makerCommission" bson:"makerCommission"`
-	TakerCommission  float64 `json:"takerCommission" bson:"takerCommission"`
-	BuyerCommission  float64 `json:"buyerCommission" bson:"buyerCommission"`
-	SellerCommission float64 `json:"sellerCommission" bson:"sellerCommission"`
-	CommissionRates  struct {
-		Maker  float64 `json:"maker,string" bson:"maker,string"`
-		Taker  float64 `json:"taker,string" bson:"taker,string"`
-		Buyer  float64 `json:"buyer,string" bson:"buyer,string"`
-		Seller float64 `json:"seller,string" bson:"seller,string"`
-	} `json:"commissionRates" bson:"commissionRates"`
-	CanTrade                   bool          `json:"canTrade" bson:"canTrade"`
-	CanWithdraw                bool          `json:"canWithdraw" bson:"canWithdraw"`
-	CanDeposit                 bool          `json:"canDeposit" bson:"canDeposit"`
-	Brokered                   bool          `json:"brokered" bson:"brokered"`
-	RequireSelfTradePrevention bool          `json:"requireSelfTradePrevention" bson:"requireSelfTradePrevention"`
-	UpdateTime                 int64         `json:"updateTime" bson:"updateTime"`
-	AccountType                AccountType   `json:"accountType" bson:"accountType"`
-	Balances                   []SpotBalance `json:"balances" bson:"balances"`
-	Permissions                []PairType    `json:"permissions" bson:"permissions"`
+	MakerCommission            float64             `json:"makerCommission" bson:"makerCommission"`
+	TakerCommission            float64             `json:"takerCommission" bson:"takerCommission"`
+	BuyerCommission            float64             `json:"buyerCommission" bson:"buyerCommission"`
+	SellerCommission           float64             `json:"sellerCommission" bson:"sellerCommission"`
+	CommissionRates            SpotCommissionRates `json:"commissionRates" bson:"commissionRates"`
+	CanTrade                   bool                `json:"canTrade" bson:"canTrade"`
+	CanWithdraw                bool                `json:"canWithdraw" bson:"canWithdraw"`
+	CanDeposit                 bool                `json:"canDeposit" bson:"canDeposit"`
+	Brokered                   bool                `json:"brokered" bson:"brokered"`
+	RequireSelfTradePrevention bool                `json:"requireSelfTradePrevention" bson:"requireSelfTradePrevention"`
+	UpdateTime                 int64               `json:"updateTime" bson:"updateTime"`
+	AccountType                AccountType         `json:"accountType" bson:"accountType"`
+	Balances                   []SpotBalance       `json:"balances" bson:"balances"`
+	Permissions                []PairType          `json:"permissions" bson:"permissions"`
 }
 
 var SpotAccountConfig = cex.ReqConfig[cex.NilReqData, SpotAccount]{
@@ -97,6 +105,152 @@ var SpotAccountConfig = cex.ReqConfig[cex.NilReqData, SpotAccount]{
 	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SpotAccount]),
 }
 
+type SpotCommissionRates struct {
+	Maker  float64 `json:"maker,string" bson:"maker,string"`
+	Taker  float64 `json:"taker,string" bson:"taker,string"`
+	Buyer  float64 `json:"buyer,string" bson:"buyer,string"`
+	Seller float64 `json:"seller,string" bson:"seller,string"`
+}
+
+type SpotCommissionDiscount struct {
+	EnabledForAccount bool    `json:"enabledForAccount" bson:"enabledForAccount"`
+	EnabledForSymbol  bool    `json:"enabledForSymbol" bson:"enabledForSymbol"`
+	DiscountAsset     string  `json:"discountAsset" bson:"discountAsset"`
+	Discount          float64 `json:"discount,string" bson:"discount,string"` // commission is multiplied by discount if paid by discount asset
+}
+
+type SpotAccountCommissionParams struct {
+	Symbol string `s2m:"symbol"`
+}
+
+type SpotAccountCommission struct {
+	Symbol             string                 `json:"symbol" bson:"symbol"`
+	StandardCommission SpotCommissionRates    `json:"standardCommission" bson:"standardCommission"`
+	TaxCommission      SpotCommissionRates    `json:"taxCommission" bson:"taxCommission"`
+	Discount           SpotCommissionDiscount `json:"discount" bson:"discount"`
+}
+
+var SpotAccountCommissionConfig = cex.ReqConfig[SpotAccountCommissionParams, SpotAccountCommission]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/account/commission",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SpotAccountCommission]),
+}
+
+type SpotTradeFeeParams struct {
+	Symbol string `s2m:"symbol,omitempty"` // all symbols are returned if empty
+}
+
+type SpotTradeFee struct {
+	Symbol          string  `json:"symbol" bson:"symbol"`
+	MakerCommission float64 `json:"makerCommission,string" bson:"makerCommission,string"`
+	TakerCommission float64 `json:"takerCommission,string" bson:"takerCommission,string"`
+}
+
+var SpotTradeFeeConfig = cex.ReqConfig[SpotTradeFeeParams, []SpotTradeFee]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/asset/tradeFee",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]SpotTradeFee]),
+}
+
+type BNBBurnStatus struct {
+	SpotBNBBurn     bool `json:"spotBNBBurn" bson:"spotBNBBurn"`         // use BNB to pay spot trading fee
+	InterestBNBBurn bool `json:"interestBNBBurn" bson:"interestBNBBurn"` // use BNB to pay margin loan interest
+}
+
+var BNBBurnStatusConfig = cex.ReqConfig[cex.NilReqData, BNBBurnStatus]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/bnbBurn",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[BNBBurnStatus]),
+}
+
+// ToggleBNBBurnParams
+// At least one of SpotBNBBurn and InterestBNBBurn should be set, nil value is not changed.
+type ToggleBNBBurnParams struct {
+	SpotBNBBurn     *bool `s2m:"spotBNBBurn,omitempty"`
+	InterestBNBBurn *bool `s2m:"interestBNBBurn,omitempty"`
+}
+
+var ToggleBNBBurnConfig = cex.ReqConfig[ToggleBNBBurnParams, BNBBurnStatus]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/bnbBurn",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[BNBBurnStatus]),
+}
+
+type ApiRestrictions struct {
+	IpRestrict                     bool  `json:"ipRestrict" bson:"ipRestrict"`
+	CreateTime                     int64 `json:"createTime" bson:"createTime"`
+	EnableReading                  bool  `json:"enableReading" bson:"enableReading"`
+	EnableSpotAndMarginTrading     bool  `json:"enableSpotAndMarginTrading" bson:"enableSpotAndMarginTrading"`
+	EnableWithdrawals              bool  `json:"enableWithdrawals" bson:"enableWithdrawals"` // only available if ipRestrict is true
+	EnableInternalTransfer         bool  `json:"enableInternalTransfer" bson:"enableInternalTransfer"`
+	EnableMargin                   bool  `json:"enableMargin" bson:"enableMargin"`
+	EnableFutures                  bool  `json:"enableFutures" bson:"enableFutures"`
+	EnableVanillaOptions           bool  `json:"enableVanillaOptions" bson:"enableVanillaOptions"`
+	EnablePortfolioMarginTrading   bool  `json:"enablePortfolioMarginTrading" bson:"enablePortfolioMarginTrading"`
+	PermitsUniversalTransfer       bool  `json:"permitsUniversalTransfer" bson:"permitsUniversalTransfer"`
+	TradingAuthorityExpirationTime int64 `json:"tradingAuthorityExpirationTime" bson:"tradingAuthorityExpirationTime"` // only returned if spot and margin trading is expirable
+}
+
+var ApiRestrictionsConfig = cex.ReqConfig[cex.NilReqData, ApiRestrictions]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/account/apiRestrictions",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[ApiRestrictions]),
+}
+
+const AccountStatusNormal = "Normal"
+
+type AccountStatus struct {
+	Data string `json:"data" bson:"data"` // Normal if account is normal
+}
+
+var AccountStatusConfig = cex.ReqConfig[cex.NilReqData, AccountStatus]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/account/status",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[AccountStatus]),
+}
+
 type UniversalTransferParams struct {
 	Type       TransferType `s2m:"type,omitempty"`
 	Asset      string       `s2m:"asset,omitempty"`
@@ -105,6 +259,19 @@ type UniversalTransferParams struct {
 	ToSymbol   string       `s2m:"toSymbol,omitempty"`
 }
 
+func (p UniversalTransferParams) Validate() error {
+	if !p.Type.IsValid() {
+		return fmt.Errorf("%w, invalid transfer type %q", cex.ErrInvalidParams, p.Type)
+	}
+	return cex.FirstParamsErr(
+		cex.RequireParam("type", p.Type != ""),
+		cex.RequireParam("asset", p.Asset != ""),
+		cex.RequireParam("amount", p.Amount > 0),
+		cex.RequireParam("fromSymbol", p.FromSymbol != "" || !p.Type.FromIsolatedMargin()),
+		cex.RequireParam("toSymbol", p.ToSymbol != "" || !p.Type.ToIsolatedMargin()),
+	)
+}
+
 type UniversalTransferResp struct {
 	TranId int64 `json:"tranId,omitempty" bson:"tranId,omitempty"`
 }
@@ -152,7 +319,7 @@ var WithdrawConfig = cex.ReqConfig[WithdrawParams, WithdrawResult]{
 		IpTimeInterval:   0,
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
-	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[WithdrawResult]),
+	RespBodyUnmarshaler:   walletBodyUnmshWrapper(cex.StdBodyUnmarshaler[WithdrawResult]),
 }
 
 type DepositAddressParams struct {
@@ -178,7 +345,339 @@ var DepositAddressConfig = cex.ReqConfig[DepositAddressParams, DepositAddress]{
 		IpTimeInterval:   0,
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
-	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[DepositAddress]),
+	RespBodyUnmarshaler:   walletBodyUnmshWrapper(cex.StdBodyUnmarshaler[DepositAddress]),
+}
+
+// WithdrawHistoriesParams
+// network may not be in the response for old withdraw.
+// Please notice the default startTime and endTime to make sure that time interval is within 0-90 days.
+// If both startTime and endTime are sent, time between startTime and endTime must be less than 90 days.
+type WithdrawHistoriesParams struct {
+	Coin            string         `s2m:"coin,omitempty"`
+	WithdrawOrderId string         `s2m:"withdrawOrderId,omitempty"`
+	Status          WithdrawStatus `s2m:"status,omitempty"` // 0(email sent) is omitted
+	Offset          int64          `s2m:"offset,omitempty"`
+	Limit           int64          `s2m:"limit,omitempty"`  // default 1000, max 1000
+	IdList          string         `s2m:"idList,omitempty"` // id list returned in the response of withdraw, separated by ",", max 45 ids
+	StartTime       int64          `s2m:"startTime,omitempty"`
+	EndTime         int64          `s2m:"endTime,omitempty"`
+}
+
+type WithdrawHistory struct {
+	Id              string         `json:"id" bson:"id"`
+	Amount          float64        `json:"amount,string" bson:"amount,string"`
+	TransactionFee  float64        `json:"transactionFee,string" bson:"transactionFee,string"`
+	Coin            string         `json:"coin" bson:"coin"`
+	Status          WithdrawStatus `json:"status" bson:"status"`
+	Address         string         `json:"address" bson:"address"`
+	TxId            string         `json:"txId" bson:"txId"`
+	ApplyTime       string         `json:"applyTime" bson:"applyTime"` // UTC time, ex. 2019-10-12 11:12:02
+	Network         Network        `json:"network" bson:"network"`
+	TransferType    int            `json:"transferType" bson:"transferType"` // 1 for internal transfer, 0 for external transfer
+	WithdrawOrderId string         `json:"withdrawOrderId" bson:"withdrawOrderId"`
+	Info            string         `json:"info" bson:"info"` // reason for withdrawal failure
+	ConfirmNo       int64          `json:"confirmNo" bson:"confirmNo"`
+	WalletType      WalletType     `json:"walletType" bson:"walletType"`
+	TxKey           string         `json:"txKey" bson:"txKey"`
+	CompleteTime    string         `json:"completeTime" bson:"completeTime"` // UTC time, only if status is completed
+}
+
+var WithdrawHistoriesConfig = cex.ReqConfig[WithdrawHistoriesParams, []WithdrawHistory]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/capital/withdraw/history",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   walletBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]WithdrawHistory]),
+}
+
+// DepositHistoriesParams
+// Please notice the default startTime and endTime to make sure that time interval is within 0-90 days.
+// If both startTime and endTime are sent, time between startTime and endTime must be less than 90 days.
+type DepositHistoriesParams struct {
+	IncludeSource SmallBool     `s2m:"includeSource,omitempty"` // default false, if true, source address will be returned
+	Coin          string        `s2m:"coin,omitempty"`
+	Status        DepositStatus `s2m:"status,omitempty"` // 0(pending) is omitted
+	StartTime     int64         `s2m:"startTime,omitempty"`
+	EndTime       int64         `s2m:"endTime,omitempty"`
+	Offset        int64         `s2m:"offset,omitempty"`
+	Limit         int64         `s2m:"limit,omitempty"` // default 1000, max 1000
+	TxId          string        `s2m:"txId,omitempty"`
+}
+
+type DepositHistory struct {
+	Id            string        `json:"id" bson:"id"`
+	Amount        float64       `json:"amount,string" bson:"amount,string"`
+	Coin          string        `json:"coin" bson:"coin"`
+	Network       Network       `json:"network" bson:"network"`
+	Status        DepositStatus `json:"status" bson:"status"`
+	Address       string        `json:"address" bson:"address"`
+	AddressTag    string        `json:"addressTag" bson:"addressTag"`
+	TxId          string        `json:"txId" bson:"txId"`
+	InsertTime    int64         `json:"insertTime" bson:"insertTime"`
+	TransferType  int           `json:"transferType" bson:"transferType"` // 1 for internal transfer, 0 for external transfer
+	ConfirmTimes  string        `json:"confirmTimes" bson:"confirmTimes"` // ex. 12/12
+	UnlockConfirm int64         `json:"unlockConfirm" bson:"unlockConfirm"`
+	WalletType    WalletType    `json:"walletType" bson:"walletType"`
+	SourceAddress string        `json:"sourceAddress" bson:"sourceAddress"` // only if includeSource is true
+}
+
+var DepositHistoriesConfig = cex.ReqConfig[DepositHistoriesParams, []DepositHistory]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/capital/deposit/hisrec",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   walletBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]DepositHistory]),
+}
+
+// DustTransferParams
+// Assets are sent as repeated asset params, ex. asset=BTC&asset=USDT,
+// so it is switched to url values by self.
+type DustTransferParams struct {
+	Assets      []string
+	AccountType string // SPOT or MARGIN, default SPOT
+}
+
+func (p DustTransferParams) UrlValues() url.Values {
+	val := url.Values{"asset": p.Assets}
+	if p.AccountType != "" {
+		val.Set("accountType", p.AccountType)
+	}
+	return val
+}
+
+type DustTransferDetail struct {
+	TranId              int64   `json:"tranId" bson:"tranId"`
+	FromAsset           string  `json:"fromAsset" bson:"fromAsset"`
+	Amount              float64 `json:"amount,string" bson:"amount,string"`
+	TransferedAmount    float64 `json:"transferedAmount,string" bson:"transferedAmount,string"`
+	ServiceChargeAmount float64 `json:"serviceChargeAmount,string" bson:"serviceChargeAmount,string"`
+	OperateTime         int64   `json:"operateTime" bson:"operateTime"`
+}
+
+type DustTransferResult struct {
+	TotalServiceCharge float64              `json:"totalServiceCharge,string" bson:"totalServiceCharge,string"`
+	TotalTransfered    float64              `json:"totalTransfered,string" bson:"totalTransfered,string"` // total BNB
+	TransferResult     []DustTransferDetail `json:"transferResult" bson:"transferResult"`
+}
+
+// DustTransferConfig converts small balances to BNB.
+var DustTransferConfig = cex.ReqConfig[DustTransferParams, DustTransferResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/asset/dust",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   walletBodyUnmshWrapper(cex.StdBodyUnmarshaler[DustTransferResult]),
+}
+
+type DustAssetsParams struct {
+	AccountType AccountType `s2m:"accountType,omitempty"` // SPOT or MARGIN, default SPOT
+}
+
+type DustAsset struct {
+	Asset            string  `json:"asset" bson:"asset"`
+	AssetFullName    string  `json:"assetFullName" bson:"assetFullName"`
+	AmountFree       float64 `json:"amountFree,string" bson:"amountFree,string"`
+	ToBTC            float64 `json:"toBTC,string" bson:"toBTC,string"`
+	ToBNB            float64 `json:"toBNB,string" bson:"toBNB,string"`
+	ToBNBOffExchange float64 `json:"toBNBOffExchange,string" bson:"toBNBOffExchange,string"` // BNB amount after fee
+	Exchange         float64 `json:"exchange,string" bson:"exchange,string"`                 // fee
+}
+
+type DustAssets struct {
+	Details            []DustAsset `json:"details" bson:"details"`
+	TotalTransferBtc   float64     `json:"totalTransferBtc,string" bson:"totalTransferBtc,string"`
+	TotalTransferBNB   float64     `json:"totalTransferBNB,string" bson:"totalTransferBNB,string"`
+	DribbletPercentage float64     `json:"dribbletPercentage,string" bson:"dribbletPercentage,string"` // fee rate
+}
+
+// DustAssetsConfig queries assets that can be converted into BNB.
+var DustAssetsConfig = cex.ReqConfig[DustAssetsParams, DustAssets]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/asset/dust-btc",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   walletBodyUnmshWrapper(cex.StdBodyUnmarshaler[DustAssets]),
+}
+
+// DustLogParams
+// Only return last 100 records.
+// Only return records after 2020/12/01.
+type DustLogParams struct {
+	StartTime int64 `s2m:"startTime,omitempty"`
+	EndTime   int64 `s2m:"endTime,omitempty"`
+}
+
+type DustLogDetail struct {
+	TransId             int64   `json:"transId" bson:"transId"`
+	ServiceChargeAmount float64 `json:"serviceChargeAmount,string" bson:"serviceChargeAmount,string"`
+	Amount              float64 `json:"amount,string" bson:"amount,string"`
+	OperateTime         int64   `json:"operateTime" bson:"operateTime"`
+	TransferedAmount    float64 `json:"transferedAmount,string" bson:"transferedAmount,string"`
+	FromAsset           string  `json:"fromAsset" bson:"fromAsset"`
+}
+
+type DustLog struct {
+	OperateTime              int64           `json:"operateTime" bson:"operateTime"`
+	TotalTransferedAmount    float64         `json:"totalTransferedAmount,string" bson:"totalTransferedAmount,string"`
+	TotalServiceChargeAmount float64         `json:"totalServiceChargeAmount,string" bson:"totalServiceChargeAmount,string"`
+	TransId                  int64           `json:"transId" bson:"transId"`
+	UserAssetDribbletDetails []DustLogDetail `json:"userAssetDribbletDetails" bson:"userAssetDribbletDetails"`
+}
+
+type DustLogs struct {
+	Total              int64     `json:"total" bson:"total"`
+	UserAssetDribblets []DustLog `json:"userAssetDribblets" bson:"userAssetDribblets"`
+}
+
+var DustLogConfig = cex.ReqConfig[DustLogParams, DustLogs]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/asset/dribblet",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   walletBodyUnmshWrapper(cex.StdBodyUnmarshaler[DustLogs]),
+}
+
+// AssetDividendRecordParams
+// There cannot be more than 180 days between parameter startTime and endTime.
+type AssetDividendRecordParams struct {
+	Asset     string `s2m:"asset,omitempty"`
+	StartTime int64  `s2m:"startTime,omitempty"`
+	EndTime   int64  `s2m:"endTime,omitempty"`
+	Limit     int64  `s2m:"limit,omitempty"` // default 20, max 500
+}
+
+type AssetDividendRecord struct {
+	Id      int64   `json:"id" bson:"id"`
+	Amount  float64 `json:"amount,string" bson:"amount,string"`
+	Asset   string  `json:"asset" bson:"asset"`
+	DivTime int64   `json:"divTime" bson:"divTime"`
+	EnInfo  string  `json:"enInfo" bson:"enInfo"`
+	TranId  int64   `json:"tranId" bson:"tranId"`
+}
+
+var AssetDividendRecordConfig = cex.ReqConfig[AssetDividendRecordParams, Page[[]AssetDividendRecord]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/asset/assetDividend",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   walletBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]AssetDividendRecord]]),
+}
+
+// AccountSnapshotParams
+// The query time period must be less than 30 days.
+// Support query within the last one month only.
+// If startTime and endTime not sent, return records of the last 7 days by default.
+type AccountSnapshotParams struct {
+	Type      AccountSnapshotType `s2m:"type,omitempty"` // required
+	StartTime int64               `s2m:"startTime,omitempty"`
+	EndTime   int64               `s2m:"endTime,omitempty"`
+	Limit     int64               `s2m:"limit,omitempty"` // min 7, max 30, default 7
+}
+
+type AccountSnapshotBalance struct {
+	Asset  string  `json:"asset" bson:"asset"`
+	Free   float64 `json:"free,string" bson:"free,string"`
+	Locked float64 `json:"locked,string" bson:"locked,string"`
+}
+
+type AccountSnapshotMarginAsset struct {
+	Asset    string  `json:"asset" bson:"asset"`
+	Borrowed float64 `json:"borrowed,string" bson:"borrowed,string"`
+	Free     float64 `json:"free,string" bson:"free,string"`
+	Interest float64 `json:"interest,string" bson:"interest,string"`
+	Locked   float64 `json:"locked,string" bson:"locked,string"`
+	NetAsset float64 `json:"netAsset,string" bson:"netAsset,string"`
+}
+
+type AccountSnapshotFuturesAsset struct {
+	Asset         string  `json:"asset" bson:"asset"`
+	MarginBalance float64 `json:"marginBalance,string" bson:"marginBalance,string"`
+	WalletBalance float64 `json:"walletBalance,string" bson:"walletBalance,string"`
+}
+
+type AccountSnapshotFuturesPosition struct {
+	Symbol           string  `json:"symbol" bson:"symbol"`
+	EntryPrice       float64 `json:"entryPrice,string" bson:"entryPrice,string"`
+	MarkPrice        float64 `json:"markPrice,string" bson:"markPrice,string"`
+	PositionAmt      float64 `json:"positionAmt,string" bson:"positionAmt,string"`
+	UnRealizedProfit float64 `json:"unRealizedProfit,string" bson:"unRealizedProfit,string"`
+}
+
+// AccountSnapshotData
+// Fields are filled by snapshot type.
+type AccountSnapshotData struct {
+	// spot, margin
+	TotalAssetOfBtc float64 `json:"totalAssetOfBtc,string" bson:"totalAssetOfBtc,string"`
+
+	// spot
+	Balances []AccountSnapshotBalance `json:"balances" bson:"balances"`
+
+	// margin
+	MarginLevel         float64                      `json:"marginLevel,string" bson:"marginLevel,string"`
+	TotalLiabilityOfBtc float64                      `json:"totalLiabilityOfBtc,string" bson:"totalLiabilityOfBtc,string"`
+	TotalNetAssetOfBtc  float64                      `json:"totalNetAssetOfBtc,string" bson:"totalNetAssetOfBtc,string"`
+	UserAssets          []AccountSnapshotMarginAsset `json:"userAssets" bson:"userAssets"`
+
+	// futures
+	Assets   []AccountSnapshotFuturesAsset    `json:"assets" bson:"assets"`
+	Position []AccountSnapshotFuturesPosition `json:"position" bson:"position"`
+}
+
+type AccountSnapshotVo struct {
+	Type       string              `json:"type" bson:"type"` // spot, margin, futures
+	UpdateTime int64               `json:"updateTime" bson:"updateTime"`
+	Data       AccountSnapshotData `json:"data" bson:"data"`
+}
+
+type AccountSnapshots struct {
+	Code        int                 `json:"code" bson:"code"`
+	Msg         string              `json:"msg" bson:"msg"`
+	SnapshotVos []AccountSnapshotVo `json:"snapshotVos" bson:"snapshotVos"`
+}
+
+// AccountSnapshotConfig queries daily account snapshots.
+// Response code is 200 if success, so futures body unmarshaler wrapper is used.
+var AccountSnapshotConfig = cex.ReqConfig[AccountSnapshotParams, AccountSnapshots]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/accountSnapshot",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   fuBodyUnmshWrapper(cex.StdBodyUnmarshaler[AccountSnapshots]),
 }
 
 // ---------------------------------------------
@@ -322,21 +821,208 @@ type SimpleEarnFlexibleAccount struct {
 	TotalLockedInUSDT         string `json:"totalLockedInUSDT"`
 }
 
-var SimpleEarnFlexibleAccountConfig = cex.ReqConfig[cex.NilReqData, SimpleEarnFlexibleAccount]{
+var SimpleEarnFlexibleAccountConfig = cex.ReqConfig[cex.NilReqData, SimpleEarnFlexibleAccount]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/simple-earn/account",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SimpleEarnFlexibleAccount]),
+}
+
+// ---------------------------------------------
+// Flexible Simple Earn
+// =============================================
+
+// =============================================
+// Locked Simple Earn
+// ---------------------------------------------
+
+type SimpleEarnLockedProductListParams struct {
+	Asset   string `s2m:"asset,omitempty"`
+	Current int64  `s2m:"current,omitempty"`
+	Size    int64  `s2m:"size,omitempty"` // default:10, max: 100
+}
+
+type SimpleEarnLockedProductDetail struct {
+	Asset                 string  `json:"asset" bson:"asset"`             // lock up asset
+	RewardAsset           string  `json:"rewardAsset" bson:"rewardAsset"` // earn asset
+	Duration              int64   `json:"duration" bson:"duration"`       // lock period(days)
+	Renewable             bool    `json:"renewable" bson:"renewable"`     // project supports renewal
+	IsSoldOut             bool    `json:"isSoldOut" bson:"isSoldOut"`
+	Apr                   float64 `json:"apr,string" bson:"apr,string"`
+	Status                string  `json:"status" bson:"status"` // CREATED, PURCHASING, END
+	SubscriptionStartTime int64   `json:"subscriptionStartTime" bson:"subscriptionStartTime"`
+	ExtraRewardAsset      string  `json:"extraRewardAsset" bson:"extraRewardAsset"`
+	ExtraRewardAPR        float64 `json:"extraRewardAPR,string" bson:"extraRewardAPR,string"`
+}
+
+type SimpleEarnLockedProductQuota struct {
+	TotalPersonalQuota float64 `json:"totalPersonalQuota,string" bson:"totalPersonalQuota,string"`
+	Minimum            float64 `json:"minimum,string" bson:"minimum,string"`
+}
+
+type SimpleEarnLockedProduct struct {
+	ProjectId string                        `json:"projectId" bson:"projectId"`
+	Detail    SimpleEarnLockedProductDetail `json:"detail" bson:"detail"`
+	Quota     SimpleEarnLockedProductQuota  `json:"quota" bson:"quota"`
+}
+
+var SimpleEarnLockedProductConfig = cex.ReqConfig[SimpleEarnLockedProductListParams, Page[[]SimpleEarnLockedProduct]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/simple-earn/locked/list",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]SimpleEarnLockedProduct]]),
+}
+
+type SimpleEarnLockedSubscribeParams struct {
+	ProjectId     string                  `s2m:"projectId,omitempty"`
+	Amount        float64                 `s2m:"amount,omitempty"`
+	AutoSubscribe SmallBool               `s2m:"autoSubscribe,omitempty"` // true or false, default true
+	SourceAccount SimpleEarnSourceAccount `s2m:"sourceAccount,omitempty"` // SPOT, FUND, ALL, default SPOT
+}
+
+type SimpleEarnLockedSubscribeResult struct {
+	PurchaseId int64  `json:"purchaseId" bson:"purchaseId"`
+	PositionId string `json:"positionId" bson:"positionId"`
+	Success    bool   `json:"success" bson:"success"`
+}
+
+var SimpleEarnLockedSubscribeConfig = cex.ReqConfig[SimpleEarnLockedSubscribeParams, SimpleEarnLockedSubscribeResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/simple-earn/locked/subscribe",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SimpleEarnLockedSubscribeResult]),
+}
+
+type SimpleEarnLockedRedeemParams struct {
+	PositionId string `s2m:"positionId,omitempty"`
+}
+
+type SimpleEarnLockedRedeemResult struct {
+	RedeemId int64 `json:"redeemId" bson:"redeemId"`
+	Success  bool  `json:"success" bson:"success"`
+}
+
+var SimpleEarnLockedRedeemConfig = cex.ReqConfig[SimpleEarnLockedRedeemParams, SimpleEarnLockedRedeemResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/simple-earn/locked/redeem",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SimpleEarnLockedRedeemResult]),
+}
+
+type SimpleEarnLockedPositionsParams struct {
+	Asset      string `s2m:"asset,omitempty"`
+	PositionId string `s2m:"positionId,omitempty"`
+	ProjectId  string `s2m:"projectId,omitempty"`
+	Current    int64  `s2m:"current,omitempty"`
+	Size       int64  `s2m:"size,omitempty"` // default 10, max 100
+}
+
+type SimpleEarnLockedPosition struct {
+	PositionId            int64   `json:"positionId" bson:"positionId"`
+	ParentPositionId      int64   `json:"parentPositionId" bson:"parentPositionId"`
+	ProjectId             string  `json:"projectId" bson:"projectId"`
+	Asset                 string  `json:"asset" bson:"asset"`
+	Amount                float64 `json:"amount,string" bson:"amount,string"`
+	PurchaseTime          int64   `json:"purchaseTime,string" bson:"purchaseTime,string"`
+	Duration              int64   `json:"duration,string" bson:"duration,string"` // lock period(days)
+	AccrualDays           int64   `json:"accrualDays,string" bson:"accrualDays,string"`
+	RewardAsset           string  `json:"rewardAsset" bson:"rewardAsset"`
+	APY                   float64 `json:"APY,string" bson:"APY,string"`
+	RewardAmt             float64 `json:"rewardAmt,string" bson:"rewardAmt,string"`
+	ExtraRewardAsset      string  `json:"extraRewardAsset" bson:"extraRewardAsset"`
+	ExtraRewardAPR        float64 `json:"extraRewardAPR,string" bson:"extraRewardAPR,string"`
+	EstExtraRewardAmt     float64 `json:"estExtraRewardAmt,string" bson:"estExtraRewardAmt,string"`
+	NextPay               float64 `json:"nextPay,string" bson:"nextPay,string"`
+	NextPayDate           int64   `json:"nextPayDate,string" bson:"nextPayDate,string"`
+	PayPeriod             int64   `json:"payPeriod" bson:"payPeriod"`
+	RedeemAmountEarly     float64 `json:"redeemAmountEarly,string" bson:"redeemAmountEarly,string"`
+	RewardsEndDate        int64   `json:"rewardsEndDate,string" bson:"rewardsEndDate,string"`
+	DeliverDate           int64   `json:"deliverDate,string" bson:"deliverDate,string"`
+	RedeemPeriod          int64   `json:"redeemPeriod" bson:"redeemPeriod"`
+	RedeemingAmt          float64 `json:"redeemingAmt,string" bson:"redeemingAmt,string"`
+	RedeemTo              string  `json:"redeemTo" bson:"redeemTo"` // SPOT, FLEXIBLE
+	PartialAmtDeliverDate int64   `json:"partialAmtDeliverDate,string" bson:"partialAmtDeliverDate,string"`
+	CanRedeemEarly        bool    `json:"canRedeemEarly" bson:"canRedeemEarly"`
+	CanFastRedemption     bool    `json:"canFastRedemption" bson:"canFastRedemption"`
+	AutoSubscribe         bool    `json:"autoSubscribe" bson:"autoSubscribe"`
+	Type                  string  `json:"type" bson:"type"`     // AUTO, NORMAL
+	Status                string  `json:"status" bson:"status"` // HOLDING, REDEEMING
+	CanReStake            bool    `json:"canReStake" bson:"canReStake"`
+}
+
+var SimpleEarnLockedPositionsConfig = cex.ReqConfig[SimpleEarnLockedPositionsParams, Page[[]SimpleEarnLockedPosition]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/simple-earn/locked/position",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]SimpleEarnLockedPosition]]),
+}
+
+// SimpleEarnLockedRewardsHistoryParams
+// The time between startTime and endTime cannot be longer than 3 months.
+// If startTime and endTime are both not sent, then the last 30 days' data will be returned.
+type SimpleEarnLockedRewardsHistoryParams struct {
+	PositionId string `s2m:"positionId,omitempty"`
+	Asset      string `s2m:"asset,omitempty"`
+	StartTime  int64  `s2m:"startTime,omitempty"`
+	EndTime    int64  `s2m:"endTime,omitempty"`
+	Current    int64  `s2m:"current,omitempty"` // Currently querying page. Start from 1. Default:1
+	Size       int64  `s2m:"size,omitempty"`    // Default:10, Max:100
+}
+
+type SimpleEarnLockedRewardsHistory struct {
+	PositionId string  `json:"positionId" bson:"positionId"`
+	Time       int64   `json:"time" bson:"time"`
+	Asset      string  `json:"asset" bson:"asset"`
+	LockPeriod string  `json:"lockPeriod" bson:"lockPeriod"`
+	Amount     float64 `json:"amount,string" bson:"amount,string"`
+	Type       string  `json:"type" bson:"type"` // Locked Rewards, Boost Rewards
+}
+
+var SimpleEarnLockedRewardsHistoryConfig = cex.ReqConfig[SimpleEarnLockedRewardsHistoryParams, Page[[]SimpleEarnLockedRewardsHistory]]{
 	ReqBaseConfig: cex.ReqBaseConfig{
 		BaseUrl:          ApiBaseUrl,
-		Path:             SapiV1 + "/simple-earn/account",
+		Path:             SapiV1 + "/simple-earn/locked/history/rewardsRecord",
 		Method:           http.MethodGet,
 		IsUserData:       true,
 		UserTimeInterval: 0,
 		IpTimeInterval:   0,
 	},
 	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
-	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SimpleEarnFlexibleAccount]),
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]SimpleEarnLockedRewardsHistory]]),
 }
 
 // ---------------------------------------------
-// Flexible Simple Earn
+// Locked Simple Earn
 // =============================================
 
 // =============================================
@@ -634,6 +1320,312 @@ var CryptoLoanFlexibleCollateralCoinsConfig = cex.ReqConfig[CryptoLoanFlexibleCo
 // Crypto Flexible Loans
 // =============================================
 
+// =============================================
+// Crypto Stable Loans
+// ---------------------------------------------
+
+type CryptoLoanStableTerm int
+
+const (
+	CryptoLoanStableTerm7d   CryptoLoanStableTerm = 7
+	CryptoLoanStableTerm14d  CryptoLoanStableTerm = 14
+	CryptoLoanStableTerm30d  CryptoLoanStableTerm = 30
+	CryptoLoanStableTerm90d  CryptoLoanStableTerm = 90
+	CryptoLoanStableTerm180d CryptoLoanStableTerm = 180
+)
+
+type CryptoLoanStableBorrowParams struct {
+	LoanCoin         string               `s2m:"loanCoin,omitempty"`
+	LoanAmount       float64              `s2m:"loanAmount,omitempty"` // mandatory when collateralAmount is empty
+	CollateralCoin   string               `s2m:"collateralCoin,omitempty"`
+	CollateralAmount float64              `s2m:"collateralAmount,omitempty"` // mandatory when loanAmount is empty
+	LoanTerm         CryptoLoanStableTerm `s2m:"loanTerm,omitempty"`
+}
+
+type CryptoLoanStableBorrowResult struct {
+	LoanCoin           string  `json:"loanCoin" bson:"loanCoin"`
+	LoanAmount         float64 `json:"loanAmount,string" bson:"loanAmount,string"`
+	CollateralCoin     string  `json:"collateralCoin" bson:"collateralCoin"`
+	CollateralAmount   float64 `json:"collateralAmount,string" bson:"collateralAmount,string"`
+	HourlyInterestRate float64 `json:"hourlyInterestRate,string" bson:"hourlyInterestRate,string"`
+	OrderId            string  `json:"orderId" bson:"orderId"`
+}
+
+var CryptoLoanStableBorrowConfig = cex.ReqConfig[CryptoLoanStableBorrowParams, CryptoLoanStableBorrowResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/borrow",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[CryptoLoanStableBorrowResult]),
+}
+
+type CryptoLoanStableOngoingOrdersParams struct {
+	OrderId        int64  `s2m:"orderId,omitempty"`
+	LoanCoin       string `s2m:"loanCoin,omitempty"`
+	CollateralCoin string `s2m:"collateralCoin,omitempty"`
+	Current        int64  `s2m:"current,omitempty"` // default: 1, max: 1000
+	Limit          int64  `s2m:"limit,omitempty"`   // default: 10, max: 100
+}
+
+type CryptoLoanStableOngoingOrder struct {
+	OrderId          int64   `json:"orderId" bson:"orderId"`
+	LoanCoin         string  `json:"loanCoin" bson:"loanCoin"`
+	TotalDebt        float64 `json:"totalDebt,string" bson:"totalDebt,string"`
+	ResidualInterest float64 `json:"residualInterest,string" bson:"residualInterest,string"`
+	CollateralCoin   string  `json:"collateralCoin" bson:"collateralCoin"`
+	CollateralAmount float64 `json:"collateralAmount,string" bson:"collateralAmount,string"`
+	CurrentLTV       float64 `json:"currentLTV,string" bson:"currentLTV,string"`
+	ExpirationTime   int64   `json:"expirationTime" bson:"expirationTime"`
+}
+
+var CryptoLoanStableOngoingOrdersConfig = cex.ReqConfig[CryptoLoanStableOngoingOrdersParams, Page[[]CryptoLoanStableOngoingOrder]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/ongoing/orders",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]CryptoLoanStableOngoingOrder]]),
+}
+
+type CryptoLoanStableBorrowStatus string
+
+const (
+	CryptoLoanStableBorrowAccruingInterest CryptoLoanStableBorrowStatus = "Accruing_Interest"
+	CryptoLoanStableBorrowOverdue          CryptoLoanStableBorrowStatus = "Overdue"
+	CryptoLoanStableBorrowLiquidating      CryptoLoanStableBorrowStatus = "Liquidating"
+	CryptoLoanStableBorrowRepaying         CryptoLoanStableBorrowStatus = "Repaying"
+	CryptoLoanStableBorrowRepaid           CryptoLoanStableBorrowStatus = "Repaid"
+	CryptoLoanStableBorrowLiquidated       CryptoLoanStableBorrowStatus = "Liquidated"
+	CryptoLoanStableBorrowPending          CryptoLoanStableBorrowStatus = "Pending"
+	CryptoLoanStableBorrowFailed           CryptoLoanStableBorrowStatus = "Failed"
+)
+
+type CryptoLoanStableHistoriesParams struct {
+	OrderId        int64  `s2m:"orderId,omitempty"`
+	LoanCoin       string `s2m:"loanCoin,omitempty"`
+	CollateralCoin string `s2m:"collateralCoin,omitempty"`
+	StartTime      int64  `s2m:"startTime,omitempty"`
+	EndTime        int64  `s2m:"endTime,omitempty"`
+	Current        int64  `s2m:"current,omitempty"` // default: 1, max: 1000
+	Limit          int64  `s2m:"limit,omitempty"`   // default: 10, max: 100
+}
+
+type CryptoLoanStableBorrowHistory struct {
+	OrderId                 int64                        `json:"orderId" bson:"orderId"`
+	LoanCoin                string                       `json:"loanCoin" bson:"loanCoin"`
+	InitialLoanAmount       float64                      `json:"initialLoanAmount,string" bson:"initialLoanAmount,string"`
+	HourlyInterestRate      float64                      `json:"hourlyInterestRate,string" bson:"hourlyInterestRate,string"`
+	LoanTerm                int64                        `json:"loanTerm,string" bson:"loanTerm,string"`
+	CollateralCoin          string                       `json:"collateralCoin" bson:"collateralCoin"`
+	InitialCollateralAmount float64                      `json:"initialCollateralAmount,string" bson:"initialCollateralAmount,string"`
+	BorrowTime              int64                        `json:"borrowTime" bson:"borrowTime"`
+	Status                  CryptoLoanStableBorrowStatus `json:"status" bson:"status"`
+}
+
+// CryptoLoanStableBorrowHistoriesConfig
+// If startTime and endTime are not sent, the recent 90-day data will be returned.
+// The max interval between startTime and endTime is 180 days.
+var CryptoLoanStableBorrowHistoriesConfig = cex.ReqConfig[CryptoLoanStableHistoriesParams, Page[[]CryptoLoanStableBorrowHistory]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/borrow/history",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]CryptoLoanStableBorrowHistory]]),
+}
+
+type CryptoLoanStableRepayType int
+
+const (
+	CryptoLoanStableRepayWithLoanCoin   CryptoLoanStableRepayType = 1
+	CryptoLoanStableRepayWithCollateral CryptoLoanStableRepayType = 2
+)
+
+type CryptoLoanStableRepayParams struct {
+	OrderId          int64                     `s2m:"orderId,omitempty"`
+	Amount           float64                   `s2m:"amount,omitempty"`
+	Type             CryptoLoanStableRepayType `s2m:"type,omitempty"` // default 1
+	CollateralReturn BigBool                   `s2m:"collateralReturn,omitempty"`
+}
+
+type CryptoLoanStableRepayResult struct {
+	LoanCoin            string                    `json:"loanCoin" bson:"loanCoin"`
+	RemainingPrincipal  float64                   `json:"remainingPrincipal,string" bson:"remainingPrincipal,string"`
+	RemainingInterest   float64                   `json:"remainingInterest,string" bson:"remainingInterest,string"`
+	CollateralCoin      string                    `json:"collateralCoin" bson:"collateralCoin"`
+	RemainingCollateral float64                   `json:"remainingCollateral,string" bson:"remainingCollateral,string"`
+	CurrentLTV          float64                   `json:"currentLTV,string" bson:"currentLTV,string"`
+	RepayStatus         CryptoFlexibleRepayStatus `json:"repayStatus" bson:"repayStatus"`
+}
+
+var CryptoLoanStableRepayConfig = cex.ReqConfig[CryptoLoanStableRepayParams, CryptoLoanStableRepayResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/repay",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[CryptoLoanStableRepayResult]),
+}
+
+type CryptoLoanStableRepaymentHistory struct {
+	OrderId          int64                     `json:"orderId" bson:"orderId"`
+	LoanCoin         string                    `json:"loanCoin" bson:"loanCoin"`
+	RepayAmount      float64                   `json:"repayAmount,string" bson:"repayAmount,string"`
+	CollateralCoin   string                    `json:"collateralCoin" bson:"collateralCoin"`
+	CollateralUsed   float64                   `json:"collateralUsed,string" bson:"collateralUsed,string"`
+	CollateralReturn float64                   `json:"collateralReturn,string" bson:"collateralReturn,string"`
+	RepayType        CryptoLoanStableRepayType `json:"repayType,string" bson:"repayType,string"`
+	RepayStatus      CryptoFlexibleRepayStatus `json:"repayStatus" bson:"repayStatus"`
+	RepayTime        int64                     `json:"repayTime" bson:"repayTime"`
+}
+
+var CryptoLoanStableRepaymentHistoriesConfig = cex.ReqConfig[CryptoLoanStableHistoriesParams, Page[[]CryptoLoanStableRepaymentHistory]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/repay/history",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]CryptoLoanStableRepaymentHistory]]),
+}
+
+type CryptoLoanStableAdjustLtvParams struct {
+	OrderId   int64              `s2m:"orderId,omitempty"`
+	Amount    float64            `s2m:"amount,omitempty"`
+	Direction LTVAdjustDirection `s2m:"direction,omitempty"`
+}
+
+type CryptoLoanStableAdjustLtvResult struct {
+	LoanCoin       string             `json:"loanCoin" bson:"loanCoin"`
+	CollateralCoin string             `json:"collateralCoin" bson:"collateralCoin"`
+	Direction      LTVAdjustDirection `json:"direction" bson:"direction"`
+	Amount         float64            `json:"amount,string" bson:"amount,string"`
+	CurrentLTV     float64            `json:"currentLTV,string" bson:"currentLTV,string"`
+}
+
+var CryptoLoanStableAdjustLtvConfig = cex.ReqConfig[CryptoLoanStableAdjustLtvParams, CryptoLoanStableAdjustLtvResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/adjust/ltv",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[CryptoLoanStableAdjustLtvResult]),
+}
+
+type CryptoLoanStableAdjustLtvHistory struct {
+	OrderId        int64              `json:"orderId" bson:"orderId"`
+	LoanCoin       string             `json:"loanCoin" bson:"loanCoin"`
+	CollateralCoin string             `json:"collateralCoin" bson:"collateralCoin"`
+	Direction      LTVAdjustDirection `json:"direction" bson:"direction"`
+	Amount         float64            `json:"amount,string" bson:"amount,string"`
+	PreLTV         float64            `json:"preLTV,string" bson:"preLTV,string"`
+	AfterLTV       float64            `json:"afterLTV,string" bson:"afterLTV,string"`
+	AdjustTime     int64              `json:"adjustTime" bson:"adjustTime"`
+}
+
+var CryptoLoanStableAdjustLtvHistoriesConfig = cex.ReqConfig[CryptoLoanStableHistoriesParams, Page[[]CryptoLoanStableAdjustLtvHistory]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/ltv/adjustment/history",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]CryptoLoanStableAdjustLtvHistory]]),
+}
+
+type CryptoLoanStableLoanAssetsParams struct {
+	LoanCoin string `s2m:"loanCoin,omitempty"`
+	VipLevel int    `s2m:"vipLevel,omitempty"` // default: user's vip level
+}
+
+type CryptoLoanStableLoanAsset struct {
+	LoanCoin               string  `json:"loanCoin" bson:"loanCoin"`
+	HourlyInterestRate7d   float64 `json:"_7dHourlyInterestRate,string" bson:"_7dHourlyInterestRate,string"`
+	DailyInterestRate7d    float64 `json:"_7dDailyInterestRate,string" bson:"_7dDailyInterestRate,string"`
+	HourlyInterestRate14d  float64 `json:"_14dHourlyInterestRate,string" bson:"_14dHourlyInterestRate,string"`
+	DailyInterestRate14d   float64 `json:"_14dDailyInterestRate,string" bson:"_14dDailyInterestRate,string"`
+	HourlyInterestRate30d  float64 `json:"_30dHourlyInterestRate,string" bson:"_30dHourlyInterestRate,string"`
+	DailyInterestRate30d   float64 `json:"_30dDailyInterestRate,string" bson:"_30dDailyInterestRate,string"`
+	HourlyInterestRate90d  float64 `json:"_90dHourlyInterestRate,string" bson:"_90dHourlyInterestRate,string"`
+	DailyInterestRate90d   float64 `json:"_90dDailyInterestRate,string" bson:"_90dDailyInterestRate,string"`
+	HourlyInterestRate180d float64 `json:"_180dHourlyInterestRate,string" bson:"_180dHourlyInterestRate,string"`
+	DailyInterestRate180d  float64 `json:"_180dDailyInterestRate,string" bson:"_180dDailyInterestRate,string"`
+	MinLimit               float64 `json:"minLimit,string" bson:"minLimit,string"`
+	MaxLimit               float64 `json:"maxLimit,string" bson:"maxLimit,string"`
+	VipLevel               int     `json:"vipLevel" bson:"vipLevel"`
+}
+
+var CryptoLoanStableLoanAssetsConfig = cex.ReqConfig[CryptoLoanStableLoanAssetsParams, Page[[]CryptoLoanStableLoanAsset]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/loanable/data",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]CryptoLoanStableLoanAsset]]),
+}
+
+type CryptoLoanStableCollateralCoinsParams struct {
+	CollateralCoin string `s2m:"collateralCoin,omitempty"`
+	VipLevel       int    `s2m:"vipLevel,omitempty"` // default: user's vip level
+}
+
+type CryptoLoanStableCollateralCoin struct {
+	CollateralCoin string  `json:"collateralCoin" bson:"collateralCoin"`
+	InitialLTV     float64 `json:"initialLTV,string" bson:"initialLTV,string"`
+	MarginCallLTV  float64 `json:"marginCallLTV,string" bson:"marginCallLTV,string"`
+	LiquidationLTV float64 `json:"liquidationLTV,string" bson:"liquidationLTV,string"`
+	MaxLimit       float64 `json:"maxLimit,string" bson:"maxLimit,string"`
+	VipLevel       int     `json:"vipLevel" bson:"vipLevel"`
+}
+
+var CryptoLoanStableCollateralCoinsConfig = cex.ReqConfig[CryptoLoanStableCollateralCoinsParams, Page[[]CryptoLoanStableCollateralCoin]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/loan/collateral/data",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[Page[[]CryptoLoanStableCollateralCoin]]),
+}
+
+// ---------------------------------------------
+// Crypto Stable Loans
+// =============================================
+
 // =============================================
 // VIP Flexible Loans
 // ---------------------------------------------
@@ -958,6 +1950,38 @@ type SpotNewOrderParams struct {
 	SelfTradePreventionMode SelfTradePreventionMode `s2m:"selfTradePreventionMode,omitempty"` // The allowed enums is dependent on what is configured on the symbol.The possible supported values are EXPIRE_TAKER, EXPIRE_MAKER, EXPIRE_BOTH, NONE.
 }
 
+// Validate checks fields required by order type, only LIMIT and MARKET are checked in detail.
+func (p SpotNewOrderParams) Validate() error {
+	if !p.TimeInForce.IsValid() {
+		return fmt.Errorf("%w, invalid time in force %q", cex.ErrInvalidParams, p.TimeInForce)
+	}
+	if p.Quantity < 0 || p.Price < 0 || p.QuoteOrderQty < 0 || p.StopPrice < 0 || p.IcebergQty < 0 {
+		return fmt.Errorf("%w, negative qty or price", cex.ErrInvalidParams)
+	}
+	err := cex.FirstParamsErr(
+		cex.RequireParam("symbol", p.Symbol != ""),
+		cex.RequireParam("side", p.Side != ""),
+		cex.RequireParam("type", p.Type != ""),
+	)
+	if err != nil {
+		return err
+	}
+	switch p.Type {
+	case OrderTypeLimit:
+		return cex.FirstParamsErr(
+			cex.RequireParam("quantity", p.Quantity > 0),
+			cex.RequireParam("price", p.Price > 0),
+			cex.RequireParam("timeInForce", p.TimeInForce != ""),
+		)
+	case OrderTypeMarket:
+		return cex.FirstParamsErr(
+			cex.RequireOneOfParams("quantity", p.Quantity > 0, "quoteOrderQty", p.QuoteOrderQty > 0),
+			cex.ExclusiveParams("quantity", p.Quantity > 0, "quoteOrderQty", p.QuoteOrderQty > 0),
+		)
+	}
+	return nil
+}
+
 type SpotOrderFill struct {
 	Price           float64 `json:"price,string" bson:"price,string"`
 	Qty             float64 `json:"qty,string" bson:"qty,string"`
@@ -1028,6 +2052,13 @@ type SpotCancelOrderParams struct {
 	CancelRestrictions OrderCancelRestriction `s2m:"cancelRestrictions,omitempty"` // Supported values: ONLY_NEW - Cancel will succeed if the order status is NEW. ONLY_PARTIALLY_FILLED - Cancel will succeed if order status is PARTIALLY_FILLED
 }
 
+func (p SpotCancelOrderParams) Validate() error {
+	return cex.FirstParamsErr(
+		cex.RequireParam("symbol", p.Symbol != ""),
+		cex.RequireOneOfParams("orderId", p.OrderId != 0, "origClientOrderId", p.OrigClientOrderId != ""),
+	)
+}
+
 var SpotCancelOrderConfig = cex.ReqConfig[SpotCancelOrderParams, SpotOrder]{
 	ReqBaseConfig: cex.ReqBaseConfig{
 		BaseUrl:          ApiBaseUrl,
@@ -1064,6 +2095,13 @@ type SpotQueryOrderParams struct {
 	OrigClientOrderId string `s2m:"origClientOrderId,omitempty"`
 }
 
+func (p SpotQueryOrderParams) Validate() error {
+	return cex.FirstParamsErr(
+		cex.RequireParam("symbol", p.Symbol != ""),
+		cex.RequireOneOfParams("orderId", p.OrderId != 0, "origClientOrderId", p.OrigClientOrderId != ""),
+	)
+}
+
 var SpotQueryOrderConfig = cex.ReqConfig[SpotQueryOrderParams, SpotOrder]{
 	ReqBaseConfig: cex.ReqBaseConfig{
 		BaseUrl:          ApiBaseUrl,
@@ -1185,6 +2223,167 @@ var SpotAllOrdersConfig = cex.ReqConfig[SpotAllOrdersParams, []SpotOrder]{
 	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]SpotOrder]),
 }
 
+// SpotNewOCOParams
+// An OCO has 2 orders called the above order and below order.
+// One of the orders must be a LIMIT_MAKER order and the other must be STOP_LOSS or STOP_LOSS_LIMIT order.
+// Price restrictions:
+// If the OCO is on the SELL side: LIMIT_MAKER price > Last Traded Price > stopPrice
+// If the OCO is on the BUY side: LIMIT_MAKER price < Last Traded Price < stopPrice
+type SpotNewOCOParams struct {
+	Symbol                  string                  `s2m:"symbol,omitempty"`
+	ListClientOrderId       string                  `s2m:"listClientOrderId,omitempty"`
+	Side                    OrderSide               `s2m:"side,omitempty"`
+	Quantity                float64                 `s2m:"quantity,omitempty"`
+	AboveType               OrderType               `s2m:"aboveType,omitempty"` // STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER
+	AboveClientOrderId      string                  `s2m:"aboveClientOrderId,omitempty"`
+	AboveIcebergQty         float64                 `s2m:"aboveIcebergQty,omitempty"`
+	AbovePrice              float64                 `s2m:"abovePrice,omitempty"`
+	AboveStopPrice          float64                 `s2m:"aboveStopPrice,omitempty"` // Can be used if aboveType is STOP_LOSS or STOP_LOSS_LIMIT.
+	AboveTrailingDelta      int64                   `s2m:"aboveTrailingDelta,omitempty"`
+	AboveTimeInForce        TimeInForce             `s2m:"aboveTimeInForce,omitempty"` // Required if the aboveType is STOP_LOSS_LIMIT
+	BelowType               OrderType               `s2m:"belowType,omitempty"`        // STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER
+	BelowClientOrderId      string                  `s2m:"belowClientOrderId,omitempty"`
+	BelowIcebergQty         float64                 `s2m:"belowIcebergQty,omitempty"`
+	BelowPrice              float64                 `s2m:"belowPrice,omitempty"`
+	BelowStopPrice          float64                 `s2m:"belowStopPrice,omitempty"` // Can be used if belowType is STOP_LOSS or STOP_LOSS_LIMIT.
+	BelowTrailingDelta      int64                   `s2m:"belowTrailingDelta,omitempty"`
+	BelowTimeInForce        TimeInForce             `s2m:"belowTimeInForce,omitempty"` // Required if the belowType is STOP_LOSS_LIMIT
+	NewOrderRespType        OrderResponseType       `s2m:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `s2m:"selfTradePreventionMode,omitempty"`
+}
+
+type SpotOrderListOrder struct {
+	Symbol        string `json:"symbol" bson:"symbol"`
+	OrderId       int64  `json:"orderId" bson:"orderId"`
+	ClientOrderId string `json:"clientOrderId" bson:"clientOrderId"`
+}
+
+type SpotOrderList struct {
+	OrderListId       int64                `json:"orderListId" bson:"orderListId"`
+	ContingencyType   ContingencyType      `json:"contingencyType" bson:"contingencyType"`
+	ListStatusType    OrderListStatusType  `json:"listStatusType" bson:"listStatusType"`
+	ListOrderStatus   OrderListOrderStatus `json:"listOrderStatus" bson:"listOrderStatus"`
+	ListClientOrderId string               `json:"listClientOrderId" bson:"listClientOrderId"`
+	TransactionTime   int64                `json:"transactionTime" bson:"transactionTime"`
+	Symbol            string               `json:"symbol" bson:"symbol"`
+	Orders            []SpotOrderListOrder `json:"orders" bson:"orders"`
+
+	// new and cancel oco
+	OrderReports []SpotOrder `json:"orderReports" bson:"orderReports"`
+}
+
+var SpotNewOCOConfig = cex.ReqConfig[SpotNewOCOParams, SpotOrderList]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/orderList/oco",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SpotOrderList]),
+}
+
+// SpotCancelOCOParams
+// Either orderListId or listClientOrderId must be provided.
+// Canceling an individual order from an order list will cancel the entire order list.
+type SpotCancelOCOParams struct {
+	Symbol            string `s2m:"symbol,omitempty"`
+	OrderListId       int64  `s2m:"orderListId,omitempty"`
+	ListClientOrderId string `s2m:"listClientOrderId,omitempty"`
+	NewClientOrderId  string `s2m:"newClientOrderId,omitempty"` // Used to uniquely identify this cancel. Automatically generated by default
+}
+
+var SpotCancelOCOConfig = cex.ReqConfig[SpotCancelOCOParams, SpotOrderList]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/orderList",
+		Method:           http.MethodDelete,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SpotOrderList]),
+}
+
+// SpotQueryOCOParams
+// Either orderListId or origClientOrderId must be provided.
+type SpotQueryOCOParams struct {
+	OrderListId       int64  `s2m:"orderListId,omitempty"`
+	OrigClientOrderId string `s2m:"origClientOrderId,omitempty"`
+}
+
+var SpotQueryOCOConfig = cex.ReqConfig[SpotQueryOCOParams, SpotOrderList]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/orderList",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SpotOrderList]),
+}
+
+var SpotOpenOCOsConfig = cex.ReqConfig[cex.NilReqData, []SpotOrderList]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/openOrderList",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]SpotOrderList]),
+}
+
+// SpotAccountTradeListParams
+// If fromId is set, it will get trades >= that fromId. Otherwise, most recent trades are returned.
+// The time between startTime and endTime can't be longer than 24 hours.
+// fromId cannot be sent with startTime or endTime.
+type SpotAccountTradeListParams struct {
+	// Symbol is required
+	Symbol    string `s2m:"symbol,omitempty"`
+	OrderId   int64  `s2m:"orderId,omitempty"` // This can only be used in combination with symbol.
+	StartTime int64  `s2m:"startTime,omitempty"`
+	EndTime   int64  `s2m:"endTime,omitempty"`
+	FromId    int64  `s2m:"fromId,omitempty"` // TradeId to fetch from. Default gets most recent trades.
+	Limit     int64  `s2m:"limit,omitempty"`  // Default 500; max 1000.
+}
+
+type SpotTradeHistory struct {
+	Symbol          string  `json:"symbol" bson:"symbol"`
+	Id              int64   `json:"id" bson:"id"`
+	OrderId         int64   `json:"orderId" bson:"orderId"`
+	OrderListId     int64   `json:"orderListId" bson:"orderListId"`
+	Price           float64 `json:"price,string" bson:"price,string"`
+	Qty             float64 `json:"qty,string" bson:"qty,string"`
+	QuoteQty        float64 `json:"quoteQty,string" bson:"quoteQty,string"`
+	Commission      float64 `json:"commission,string" bson:"commission,string"`
+	CommissionAsset string  `json:"commissionAsset" bson:"commissionAsset"`
+	Time            int64   `json:"time" bson:"time"`
+	IsBuyer         bool    `json:"isBuyer" bson:"isBuyer"`
+	IsMaker         bool    `json:"isMaker" bson:"isMaker"`
+	IsBestMatch     bool    `json:"isBestMatch" bson:"isBestMatch"`
+}
+
+var SpotAccountTradeListConfig = cex.ReqConfig[SpotAccountTradeListParams, []SpotTradeHistory]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             ApiV3 + "/myTrades",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[[]SpotTradeHistory]),
+}
+
 // ---------------------------------------------
 // Spot Trading
 // =============================================
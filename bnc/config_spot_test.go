@@ -1,6 +1,7 @@
 package bnc
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -46,6 +47,26 @@ func TestSpotAccount(t *testing.T) {
 	testConfig(SpotAccountConfig, nil)
 }
 
+func TestSpotAccountCommission(t *testing.T) {
+	testConfig(SpotAccountCommissionConfig, SpotAccountCommissionParams{Symbol: "ETHUSDT"})
+}
+
+func TestSpotTradeFee(t *testing.T) {
+	testConfig(SpotTradeFeeConfig, SpotTradeFeeParams{Symbol: "ETHUSDT"})
+}
+
+func TestBNBBurnStatus(t *testing.T) {
+	testConfig(BNBBurnStatusConfig, nil)
+}
+
+func TestApiRestrictions(t *testing.T) {
+	testConfig(ApiRestrictionsConfig, nil)
+}
+
+func TestAccountStatus(t *testing.T) {
+	testConfig(AccountStatusConfig, nil)
+}
+
 func TestUniversalTransfer(t *testing.T) {
 	testConfig(UniversalTransferConfig, UniversalTransferParams{
 		Type:       TransferTypeMainUmfuture,
@@ -62,6 +83,20 @@ func TestFlexibleProduct(t *testing.T) {
 	})
 }
 
+func TestLockedProduct(t *testing.T) {
+	testConfig(SimpleEarnLockedProductConfig, SimpleEarnLockedProductListParams{
+		Asset: "BNB",
+	})
+}
+
+func TestLockedPositions(t *testing.T) {
+	testConfig(SimpleEarnLockedPositionsConfig, SimpleEarnLockedPositionsParams{})
+}
+
+func TestLockedRewardsHistory(t *testing.T) {
+	testConfig(SimpleEarnLockedRewardsHistoryConfig, SimpleEarnLockedRewardsHistoryParams{})
+}
+
 func TestCryptoLoansIncomeHistories(t *testing.T) {
 	testConfig(CryptoLoansIncomeHistoriesConfig, CryptoLoansIncomeHistoriesParams{})
 }
@@ -148,6 +183,30 @@ func TestFlexibleCollateralCoins(t *testing.T) {
 	})
 }
 
+func TestStableOngoingOrders(t *testing.T) {
+	testConfig(CryptoLoanStableOngoingOrdersConfig, CryptoLoanStableOngoingOrdersParams{LoanCoin: "USDT"})
+}
+
+func TestStableBorrowHistories(t *testing.T) {
+	testConfig(CryptoLoanStableBorrowHistoriesConfig, CryptoLoanStableHistoriesParams{LoanCoin: "USDT"})
+}
+
+func TestStableRepaymentHistories(t *testing.T) {
+	testConfig(CryptoLoanStableRepaymentHistoriesConfig, CryptoLoanStableHistoriesParams{LoanCoin: "USDT"})
+}
+
+func TestStableAdjustLtvHistories(t *testing.T) {
+	testConfig(CryptoLoanStableAdjustLtvHistoriesConfig, CryptoLoanStableHistoriesParams{LoanCoin: "USDT"})
+}
+
+func TestStableLoanAssets(t *testing.T) {
+	testConfig(CryptoLoanStableLoanAssetsConfig, CryptoLoanStableLoanAssetsParams{LoanCoin: "USDT"})
+}
+
+func TestStableCollateralCoins(t *testing.T) {
+	testConfig(CryptoLoanStableCollateralCoinsConfig, CryptoLoanStableCollateralCoinsParams{CollateralCoin: "ETH"})
+}
+
 func TestNewSpotOrder(t *testing.T) {
 	testConfig(SpotNewOrderConfig, SpotNewOrderParams{
 		Symbol:                  "ETHUSDT",
@@ -236,3 +295,141 @@ func TestSpotAllOrders(t *testing.T) {
 		Limit:     0,
 	})
 }
+
+func TestSpotNewOCO(t *testing.T) {
+	testConfig(SpotNewOCOConfig, SpotNewOCOParams{
+		Symbol:           "ETHUSDT",
+		Side:             OrderSideSell,
+		Quantity:         0.01,
+		AboveType:        OrderTypeLimitMaker,
+		AbovePrice:       10000,
+		BelowType:        OrderTypeStopLossLimit,
+		BelowPrice:       1000,
+		BelowStopPrice:   1001,
+		BelowTimeInForce: TimeInForceGtc,
+	})
+}
+
+func TestSpotCancelOCO(t *testing.T) {
+	testConfig(SpotCancelOCOConfig, SpotCancelOCOParams{Symbol: "ETHUSDT", OrderListId: 1})
+}
+
+func TestSpotQueryOCO(t *testing.T) {
+	testConfig(SpotQueryOCOConfig, SpotQueryOCOParams{OrderListId: 1})
+}
+
+func TestSpotOpenOCOs(t *testing.T) {
+	testConfig(SpotOpenOCOsConfig, nil)
+}
+
+func TestSwitchSpotOrderListToCexOrderList(t *testing.T) {
+	rawList := SpotOrderList{
+		OrderListId:       10,
+		ContingencyType:   ContingencyTypeOCO,
+		ListClientOrderId: "list",
+		Symbol:            "ETHUSDT",
+		Orders: []SpotOrderListOrder{
+			{Symbol: "ETHUSDT", OrderId: 1, ClientOrderId: "a"},
+			{Symbol: "ETHUSDT", OrderId: 2, ClientOrderId: "b"},
+		},
+	}
+	list := SwitchSpotOrderListToCexOrderList(rawList)
+	if list.OrderListId != "10" || list.ListType != cex.OrderListTypeOCO || len(list.Legs) != 2 {
+		t.Fatal("wrong order list", list)
+	}
+	if list.Legs[1].OrderId != "2" || list.Legs[1].Status != "" {
+		t.Error("wrong leg", list.Legs[1])
+	}
+
+	rawList.OrderReports = []SpotOrder{
+		{Symbol: "ETHUSDT", OrderId: 1, Type: OrderTypeStopLossLimit, Side: OrderSideSell, Status: OrderStatusCanceled},
+		{Symbol: "ETHUSDT", OrderId: 2, Type: OrderTypeLimitMaker, Side: OrderSideSell, Status: OrderStatusFilled, OrigQty: 1, ExecutedQty: 1, CummulativeQuoteQty: 3000},
+	}
+	cex.MergeOrderListUpdate(&list, SwitchSpotOrderListToCexOrderList(rawList))
+	if !list.IsFinished() || list.FilledQty() != 1 {
+		t.Error("wrong merged order list", list)
+	}
+	if leg, ok := list.Leg("2"); !ok || leg.FilledAvgPrice != 3000 {
+		t.Error("wrong merged leg", leg)
+	}
+}
+
+func TestWithdrawHistories(t *testing.T) {
+	testConfig(WithdrawHistoriesConfig, WithdrawHistoriesParams{Limit: 10})
+}
+
+func TestDepositHistories(t *testing.T) {
+	testConfig(DepositHistoriesConfig, DepositHistoriesParams{Limit: 10})
+}
+
+func TestWalletBodyUnmarshal(t *testing.T) {
+	_, err := WithdrawConfig.RespBodyUnmarshaler([]byte(`{"code":-4026,"msg":"User has insufficient balance"}`))
+	if err == nil || !errors.Is(err, cex.ErrInsufficientBalance) || err.CexErrCode != -4026 {
+		t.Error("wrong wallet error", err)
+	}
+	_, err = WithdrawConfig.RespBodyUnmarshaler([]byte(`{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`))
+	if err == nil || !errors.Is(err, cex.ErrInvalidApiKey) {
+		t.Error("spot error should be checked", err)
+	}
+	histories, err := DepositHistoriesConfig.RespBodyUnmarshaler([]byte(`[{"id":"769800519366885376","amount":"0.001","coin":"BNB","network":"BNB","status":1,"address":"bnb136ns6lfw4zs5hg4n85vdthaad7hq5m4gtkgf23","addressTag":"101764890","txId":"98A3EA560C6B3336D348B6C83F0F95ECE4F1F5919E94BD006E5BF3BF264FACFC","insertTime":1661493146000,"transferType":0,"confirmTimes":"1/1","unlockConfirm":0,"walletType":0}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(histories) != 1 || histories[0].Amount != 0.001 || !histories[0].Status.IsFinished() {
+		t.Error("wrong deposit histories", histories)
+	}
+}
+
+func TestCoin_Network(t *testing.T) {
+	coin := Coin{Coin: "USDT", NetworkList: []CoinNetworkInfo{
+		{Network: "ETH", IsDefault: true},
+		{Network: "TRX"},
+	}}
+	if info, ok := coin.Network(NetworkTrx); !ok || info.Network != "TRX" {
+		t.Error("wrong network", info)
+	}
+	if info, ok := coin.Network(NetworkNone); !ok || info.Network != "ETH" {
+		t.Error("wrong default network", info)
+	}
+	if _, ok := coin.Network(NetworkSol); ok {
+		t.Error("network should not be found")
+	}
+}
+
+func TestDustAssets(t *testing.T) {
+	testConfig(DustAssetsConfig, DustAssetsParams{})
+}
+
+func TestDustLog(t *testing.T) {
+	testConfig(DustLogConfig, DustLogParams{})
+}
+
+func TestAssetDividendRecord(t *testing.T) {
+	testConfig(AssetDividendRecordConfig, AssetDividendRecordParams{Limit: 10})
+}
+
+func TestAccountSnapshot(t *testing.T) {
+	testConfig(AccountSnapshotConfig, AccountSnapshotParams{Type: AccountSnapshotTypeSpot})
+}
+
+func TestDustTransferParams_Sign(t *testing.T) {
+	signer, err := cex.Api{SecretKey: "secret"}.NewSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := signReqData(DustTransferParams{Assets: []string{"BTC", "USDT"}}, nil, newReqSigner(signer, cex.KeyTypeHmac), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned := "asset=BTC&asset=USDT&timestamp=1"
+	if query != unsigned+"&signature="+cex.SignByHmacSHA256ToHex(unsigned, "secret") {
+		t.Error("wrong dust transfer query", query)
+	}
+}
+
+func TestSpotAccountTradeList(t *testing.T) {
+	testConfig(SpotAccountTradeListConfig, SpotAccountTradeListParams{
+		Symbol: "ETHUSDT",
+		Limit:  10,
+	})
+}
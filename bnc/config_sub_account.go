@@ -0,0 +1,243 @@
+package bnc
+
+import (
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+// All sub-account endpoints must be requested by master account api key.
+
+// =============================================
+// Sub Account List
+// ---------------------------------------------
+
+type SubAccountListParams struct {
+	Email    string  `s2m:"email,omitempty"`
+	IsFreeze BigBool `s2m:"isFreeze,omitempty"`
+	Page     int64   `s2m:"page,omitempty"`  // default 1
+	Limit    int64   `s2m:"limit,omitempty"` // default 1, max 200
+}
+
+type SubAccount struct {
+	Email                       string `json:"email" bson:"email"`
+	IsFreeze                    bool   `json:"isFreeze" bson:"isFreeze"`
+	CreateTime                  int64  `json:"createTime" bson:"createTime"`
+	IsManagedSubAccount         bool   `json:"isManagedSubAccount" bson:"isManagedSubAccount"`
+	IsAssetManagementSubAccount bool   `json:"isAssetManagementSubAccount" bson:"isAssetManagementSubAccount"`
+}
+
+type SubAccountList struct {
+	SubAccounts []SubAccount `json:"subAccounts" bson:"subAccounts"`
+}
+
+var SubAccountListConfig = cex.ReqConfig[SubAccountListParams, SubAccountList]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/sub-account/list",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SubAccountList]),
+}
+
+// ---------------------------------------------
+// Sub Account List
+// =============================================
+
+// =============================================
+// Sub Account Universal Transfer
+// ---------------------------------------------
+
+type SubAccountTransferAccountType string
+
+const (
+	SubAccountTransferAccountTypeSpot           SubAccountTransferAccountType = "SPOT"
+	SubAccountTransferAccountTypeUsdtFuture     SubAccountTransferAccountType = "USDT_FUTURE"
+	SubAccountTransferAccountTypeCoinFuture     SubAccountTransferAccountType = "COIN_FUTURE"
+	SubAccountTransferAccountTypeMargin         SubAccountTransferAccountType = "MARGIN"
+	SubAccountTransferAccountTypeIsolatedMargin SubAccountTransferAccountType = "ISOLATED_MARGIN"
+)
+
+// SubAccountUniversalTransferParams
+// If fromEmail or toEmail is empty, it is master account.
+// Transfer between sub accounts, or from sub account to master, needs master account api key.
+// symbol is required, if account type is ISOLATED_MARGIN.
+type SubAccountUniversalTransferParams struct {
+	FromEmail       string                        `s2m:"fromEmail,omitempty"`
+	ToEmail         string                        `s2m:"toEmail,omitempty"`
+	FromAccountType SubAccountTransferAccountType `s2m:"fromAccountType,omitempty"`
+	ToAccountType   SubAccountTransferAccountType `s2m:"toAccountType,omitempty"`
+	ClientTranId    string                        `s2m:"clientTranId,omitempty"` // must be unique
+	Symbol          string                        `s2m:"symbol,omitempty"`
+	Asset           string                        `s2m:"asset,omitempty"`
+	Amount          float64                       `s2m:"amount,omitempty"`
+}
+
+type SubAccountUniversalTransferResult struct {
+	TranId       int64  `json:"tranId" bson:"tranId"`
+	ClientTranId string `json:"clientTranId" bson:"clientTranId"`
+}
+
+var SubAccountUniversalTransferConfig = cex.ReqConfig[SubAccountUniversalTransferParams, SubAccountUniversalTransferResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/sub-account/universalTransfer",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SubAccountUniversalTransferResult]),
+}
+
+// SubAccountUniversalTransferHistoriesParams
+// fromEmail and toEmail cannot be sent at the same time.
+// If startTime and endTime are not sent, returns records of the last 30 days by default.
+type SubAccountUniversalTransferHistoriesParams struct {
+	FromEmail    string `s2m:"fromEmail,omitempty"`
+	ToEmail      string `s2m:"toEmail,omitempty"`
+	ClientTranId string `s2m:"clientTranId,omitempty"`
+	StartTime    int64  `s2m:"startTime,omitempty"`
+	EndTime      int64  `s2m:"endTime,omitempty"`
+	Page         int64  `s2m:"page,omitempty"`  // default 1
+	Limit        int64  `s2m:"limit,omitempty"` // default 500, max 500
+}
+
+type SubAccountUniversalTransferHistory struct {
+	TranId          int64                         `json:"tranId" bson:"tranId"`
+	FromEmail       string                        `json:"fromEmail" bson:"fromEmail"`
+	ToEmail         string                        `json:"toEmail" bson:"toEmail"`
+	Asset           string                        `json:"asset" bson:"asset"`
+	Amount          float64                       `json:"amount,string" bson:"amount,string"`
+	CreateTimeStamp int64                         `json:"createTimeStamp" bson:"createTimeStamp"`
+	FromAccountType SubAccountTransferAccountType `json:"fromAccountType" bson:"fromAccountType"`
+	ToAccountType   SubAccountTransferAccountType `json:"toAccountType" bson:"toAccountType"`
+	Status          string                        `json:"status" bson:"status"` // SUCCESS
+	ClientTranId    string                        `json:"clientTranId" bson:"clientTranId"`
+}
+
+type SubAccountUniversalTransferHistories struct {
+	Result     []SubAccountUniversalTransferHistory `json:"result" bson:"result"`
+	TotalCount int64                                `json:"totalCount" bson:"totalCount"`
+}
+
+var SubAccountUniversalTransferHistoriesConfig = cex.ReqConfig[SubAccountUniversalTransferHistoriesParams, SubAccountUniversalTransferHistories]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV1 + "/sub-account/universalTransfer",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SubAccountUniversalTransferHistories]),
+}
+
+// ---------------------------------------------
+// Sub Account Universal Transfer
+// =============================================
+
+// =============================================
+// Sub Account Assets
+// ---------------------------------------------
+
+type SubAccountAssetsParams struct {
+	Email string `s2m:"email,omitempty"` // required
+}
+
+type SubAccountBalance struct {
+	Asset       string  `json:"asset" bson:"asset"`
+	Free        float64 `json:"free,string" bson:"free,string"`
+	Locked      float64 `json:"locked,string" bson:"locked,string"`
+	Freeze      float64 `json:"freeze,string" bson:"freeze,string"`
+	Withdrawing float64 `json:"withdrawing,string" bson:"withdrawing,string"`
+}
+
+type SubAccountAssets struct {
+	Balances []SubAccountBalance `json:"balances" bson:"balances"`
+}
+
+var SubAccountAssetsConfig = cex.ReqConfig[SubAccountAssetsParams, SubAccountAssets]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV4 + "/sub-account/assets",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SubAccountAssets]),
+}
+
+// ---------------------------------------------
+// Sub Account Assets
+// =============================================
+
+// =============================================
+// Sub Account Futures Positions
+// ---------------------------------------------
+
+type SubAccountFuturesType int
+
+const (
+	SubAccountFuturesTypeUM SubAccountFuturesType = 1
+	SubAccountFuturesTypeCM SubAccountFuturesType = 2
+)
+
+type SubAccountFuturesPositionRiskParams struct {
+	Email       string                `s2m:"email,omitempty"`       // required
+	FuturesType SubAccountFuturesType `s2m:"futuresType,omitempty"` // required, 1:USDT-margined Futures, 2: Coin-margined Futures
+}
+
+type SubAccountFuturesPositionRisk struct {
+	Symbol           string  `json:"symbol" bson:"symbol"`
+	EntryPrice       float64 `json:"entryPrice,string" bson:"entryPrice,string"`
+	MarkPrice        float64 `json:"markPrice,string" bson:"markPrice,string"`
+	Leverage         float64 `json:"leverage,string" bson:"leverage,string"`
+	LiquidationPrice float64 `json:"liquidationPrice,string" bson:"liquidationPrice,string"`
+
+	PositionAmount   float64 `json:"positionAmount,string" bson:"positionAmount,string"`
+	UnrealizedProfit float64 `json:"unrealizedProfit,string" bson:"unrealizedProfit,string"`
+
+	// um
+	MaxNotional float64 `json:"maxNotional,string" bson:"maxNotional,string"`
+
+	// cm
+	Isolated        bool                `json:"isolated" bson:"isolated"`
+	IsolatedWallet  float64             `json:"isolatedWallet,string" bson:"isolatedWallet,string"`
+	IsolatedMargin  float64             `json:"isolatedMargin,string" bson:"isolatedMargin,string"`
+	IsAutoAddMargin bool                `json:"isAutoAddMargin" bson:"isAutoAddMargin"`
+	PositionSide    FuturesPositionSide `json:"positionSide" bson:"positionSide"`
+}
+
+// SubAccountFuturesPositionRisks
+// FuturePositionRiskVos is responded for um futures,
+// and DeliveryPositionRiskVos for cm futures.
+type SubAccountFuturesPositionRisks struct {
+	FuturePositionRiskVos   []SubAccountFuturesPositionRisk `json:"futurePositionRiskVos" bson:"futurePositionRiskVos"`
+	DeliveryPositionRiskVos []SubAccountFuturesPositionRisk `json:"deliveryPositionRiskVos" bson:"deliveryPositionRiskVos"`
+}
+
+var SubAccountFuturesPositionRiskConfig = cex.ReqConfig[SubAccountFuturesPositionRiskParams, SubAccountFuturesPositionRisks]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          ApiBaseUrl,
+		Path:             SapiV2 + "/sub-account/futures/positionRisk",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   spotBodyUnmshWrapper(cex.StdBodyUnmarshaler[SubAccountFuturesPositionRisks]),
+}
+
+// ---------------------------------------------
+// Sub Account Futures Positions
+// =============================================
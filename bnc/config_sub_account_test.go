@@ -0,0 +1,57 @@
+package bnc
+
+import "testing"
+
+const testSubAccountEmail = "sub@example.com"
+
+func TestSubAccountList(t *testing.T) {
+	testConfig(SubAccountListConfig, SubAccountListParams{Limit: 10})
+}
+
+func TestSubAccountUniversalTransfer(t *testing.T) {
+	testConfig(SubAccountUniversalTransferConfig, SubAccountUniversalTransferParams{
+		ToEmail:         testSubAccountEmail,
+		FromAccountType: SubAccountTransferAccountTypeSpot,
+		ToAccountType:   SubAccountTransferAccountTypeSpot,
+		Asset:           "USDT",
+		Amount:          1,
+	})
+}
+
+func TestSubAccountUniversalTransferHistories(t *testing.T) {
+	testConfig(SubAccountUniversalTransferHistoriesConfig, SubAccountUniversalTransferHistoriesParams{Limit: 10})
+}
+
+func TestSubAccountAssets(t *testing.T) {
+	testConfig(SubAccountAssetsConfig, SubAccountAssetsParams{Email: testSubAccountEmail})
+}
+
+func TestSubAccountFuturesPositionRisk(t *testing.T) {
+	testConfig(SubAccountFuturesPositionRiskConfig, SubAccountFuturesPositionRiskParams{Email: testSubAccountEmail, FuturesType: SubAccountFuturesTypeUM})
+}
+
+func TestSubAccountAssetsUnmarshal(t *testing.T) {
+	body := []byte(`{"balances":[{"freeze":"0","withdrawing":"0","asset":"ADA","free":"10000","locked":"0"},{"freeze":"0","withdrawing":"0","asset":"BNB","free":"10003","locked":"0.5"}]}`)
+	assets, err := SubAccountAssetsConfig.RespBodyUnmarshaler(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assets.Balances) != 2 || assets.Balances[1].Asset != "BNB" || assets.Balances[1].Locked != 0.5 {
+		t.Error("wrong sub account assets", assets)
+	}
+}
+
+func TestSubAccountFuturesPositionRiskUnmarshal(t *testing.T) {
+	body := []byte(`{"futurePositionRiskVos":[{"entryPrice":"9975.12000","leverage":"50","maxNotional":"1000000","liquidationPrice":"7963.54","markPrice":"9973.50770517","positionAmount":"0.010","symbol":"BTCUSDT","unrealizedProfit":"-0.01612295"}]}`)
+	risks, err := SubAccountFuturesPositionRiskConfig.RespBodyUnmarshaler(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(risks.FuturePositionRiskVos) != 1 {
+		t.Fatal("wrong position risks", risks)
+	}
+	risk := risks.FuturePositionRiskVos[0]
+	if risk.Symbol != "BTCUSDT" || risk.PositionAmount != 0.01 || risk.Leverage != 50 || risk.MaxNotional != 1000000 {
+		t.Error("wrong position risk", risk)
+	}
+}
@@ -0,0 +1,168 @@
+package bnc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ============================================================
+// Enum
+// ------------------------------------------------------------
+
+// ErrInvalidEnum is wrapped by errors of marshaling unknown enum values.
+var ErrInvalidEnum = errors.New("bnc: invalid enum")
+
+// marshalEnum fails if enum is unknown, so typos are not sent in json params, ex. batch orders.
+// Enums are valid, if they are empty or known values, empty values are omitted from params.
+// Unmarshaling keeps unknown values, so new values added by binance do not break responses,
+// check them by IsValid.
+func marshalEnum[E ~string](e E, valid bool) ([]byte, error) {
+	if !valid {
+		return nil, fmt.Errorf("%w, %T %q", ErrInvalidEnum, e, string(e))
+	}
+	return []byte(e), nil
+}
+
+func (t TimeInForce) IsValid() bool {
+	switch t {
+	case TimeInForceNone, TimeInForceGtc, TimeInForceIoc, TimeInForceFok, TimeInForceGtx, TimeInForceGtd, TimeInForceRpi, TimeInForceGteGtc:
+		return true
+	}
+	return false
+}
+
+func (t TimeInForce) String() string {
+	return string(t)
+}
+
+func (t TimeInForce) MarshalText() ([]byte, error) {
+	return marshalEnum(t, t.IsValid())
+}
+
+func (t *TimeInForce) UnmarshalText(data []byte) error {
+	*t = TimeInForce(data)
+	return nil
+}
+
+func (t FuturesWorkingType) IsValid() bool {
+	switch t {
+	case "", FuturesWorkingTypeMarkPrice, FuturesWorkingTypeContractPrice:
+		return true
+	}
+	return false
+}
+
+func (t FuturesWorkingType) String() string {
+	return string(t)
+}
+
+func (t FuturesWorkingType) MarshalText() ([]byte, error) {
+	return marshalEnum(t, t.IsValid())
+}
+
+func (t *FuturesWorkingType) UnmarshalText(data []byte) error {
+	*t = FuturesWorkingType(data)
+	return nil
+}
+
+func (m FuturesPriceMatch) IsValid() bool {
+	switch m {
+	case "", FuturesPriceMatchNone,
+		FuturesPriceMatchOpponent, FuturesPriceMatchOpponent5, FuturesPriceMatchOpponent10, FuturesPriceMatchOpponent20,
+		FuturesPriceMatchQueue, FuturesPriceMatchQueue5, FuturesPriceMatchQueue10, FuturesPriceMatchQueue20:
+		return true
+	}
+	return false
+}
+
+func (m FuturesPriceMatch) String() string {
+	return string(m)
+}
+
+func (m FuturesPriceMatch) MarshalText() ([]byte, error) {
+	return marshalEnum(m, m.IsValid())
+}
+
+func (m *FuturesPriceMatch) UnmarshalText(data []byte) error {
+	*m = FuturesPriceMatch(data)
+	return nil
+}
+
+func (t TransferType) IsValid() bool {
+	switch t {
+	case "",
+		TransferTypeMainUmfuture, TransferTypeMainCmfuture, TransferTypeMainMargin,
+		TransferTypeUmfutureMain, TransferTypeUmfutureMargin,
+		TransferTypeCmfutureMain, TransferTypeCmfutureMargin,
+		TransferTypeMarginMain, TransferTypeMarginUmfuture, TransferTypeMarginCmfuture,
+		TransferTypeIsolatedmarginMargin, TransferTypeMarginIsolatedmargin, TransferTypeIsolatedmarginIsolatedmargin,
+		TransferTypeMainFunding, TransferTypeFundingMain,
+		TransferTypeFundingUmfuture, TransferTypeUmfutureFunding,
+		TransferTypeMarginFunding, TransferTypeFundingMargin,
+		TransferTypeFundingCmfuture, TransferTypeCmfutureFunding,
+		TransferTypeMainOption, TransferTypeOptionMain,
+		TransferTypeUmfutureOption, TransferTypeOptionUmfuture,
+		TransferTypeMarginOption, TransferTypeOptionMargin,
+		TransferTypeFundingOption, TransferTypeOptionFunding,
+		TransferTypeMainPortfolioMargin, TransferTypePortfolioMarginMain,
+		TransferTypeMainIsolatedMargin, TransferTypeIsolatedMarginMain:
+		return true
+	}
+	return false
+}
+
+// FromIsolatedMargin reports whether t transfers out of isolated margin account, which requires fromSymbol.
+func (t TransferType) FromIsolatedMargin() bool {
+	switch t {
+	case TransferTypeIsolatedmarginMargin, TransferTypeIsolatedmarginIsolatedmargin, TransferTypeIsolatedMarginMain:
+		return true
+	}
+	return false
+}
+
+// ToIsolatedMargin reports whether t transfers into isolated margin account, which requires toSymbol.
+func (t TransferType) ToIsolatedMargin() bool {
+	switch t {
+	case TransferTypeMarginIsolatedmargin, TransferTypeIsolatedmarginIsolatedmargin, TransferTypeMainIsolatedMargin:
+		return true
+	}
+	return false
+}
+
+func (t TransferType) String() string {
+	return string(t)
+}
+
+func (t TransferType) MarshalText() ([]byte, error) {
+	return marshalEnum(t, t.IsValid())
+}
+
+func (t *TransferType) UnmarshalText(data []byte) error {
+	*t = TransferType(data)
+	return nil
+}
+
+func (t AccountType) IsValid() bool {
+	switch t {
+	case "", AccountTypeSpot, AccountTypeMargin:
+		return true
+	}
+	return false
+}
+
+func (t AccountType) String() string {
+	return string(t)
+}
+
+func (t AccountType) MarshalText() ([]byte, error) {
+	return marshalEnum(t, t.IsValid())
+}
+
+func (t *AccountType) UnmarshalText(data []byte) error {
+	*t = AccountType(data)
+	return nil
+}
+
+// ------------------------------------------------------------
+// Enum
+// ============================================================
@@ -0,0 +1,39 @@
+package bnc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEnum(t *testing.T) {
+	if !TimeInForceGtc.IsValid() || !TimeInForceNone.IsValid() || TimeInForce("GTT").IsValid() {
+		t.Error("wrong time in force validation")
+	}
+	if !FuturesPriceMatchQueue5.IsValid() || FuturesPriceMatch("QUEUE_1").IsValid() {
+		t.Error("wrong price match validation")
+	}
+	if TransferTypeMainUmfuture.String() != "MAIN_UMFUTURE" || TransferType("MAIN_UM").IsValid() {
+		t.Error("wrong transfer type")
+	}
+
+	params := FuturesNewOrderParams{Symbol: "ETHUSDT", TimeInForce: TimeInForceGtx, PriceMatch: FuturesPriceMatchOpponent}
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded FuturesNewOrderParams
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.TimeInForce != TimeInForceGtx || decoded.PriceMatch != FuturesPriceMatchOpponent {
+		t.Error("enums should be marshaled as strings", string(data), err)
+	}
+
+	params.WorkingType = "MARK"
+	if _, err := json.Marshal(params); !errors.Is(err, ErrInvalidEnum) {
+		t.Error("unknown enum should fail marshaling", err)
+	}
+
+	var ord FuturesOrder
+	if err := json.Unmarshal([]byte(`{"timeInForce":"NEW_TIF","workingType":"CONTRACT_PRICE"}`), &ord); err != nil || ord.TimeInForce.IsValid() {
+		t.Error("unknown enum should be kept by unmarshaling", ord.TimeInForce, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/dwdwow/cex"
 )
@@ -108,6 +109,10 @@ var (
 	// Not inner problems, others may also respond this error.
 	// If HTTP method is wrong, will respond this error.
 	ErrCexInnerProblems = errors.New("an unknown error occured while processing the request")
+
+	// ErrTooManyNewOrders means that order count limit is broken.
+	// It wraps cex.ErrRateLimited.
+	ErrTooManyNewOrders = fmt.Errorf("too many new orders, %w", cex.ErrRateLimited)
 )
 
 // ---------------------------------------------
@@ -125,35 +130,136 @@ var (
 	ErrSpotOrderNotAttempted                 = errors.New("order is not attempted")
 )
 
+// Errors of -1021 INVALID_TIMESTAMP, both wrap cex.ErrInvalidTimestamp.
+// ErrTimestampOutsideRecvWindow may be fixed by larger recvWindow, see UserOptRecvWindow,
+// and ErrTimestampAheadOfServer by time sync, see UserOptTimeSync.
+var (
+	ErrTimestampOutsideRecvWindow = fmt.Errorf("timestamp is outside of recvWindow, %w", cex.ErrInvalidTimestamp)
+	ErrTimestampAheadOfServer     = fmt.Errorf("timestamp is ahead of server time, %w", cex.ErrInvalidTimestamp)
+)
+
 var spotCexCustomErrCodes = map[int]error{
 	-1000: ErrCexInnerProblems,
+	-1003: cex.ErrHTTPTooFrequency,
+	-1015: ErrTooManyNewOrders,
 	-1021: cex.ErrInvalidTimestamp,
 	-2010: ErrSpotOrderWouldImmediatelyMatchAndTake,
 	-2011: cex.ErrUnknownOrder,
+	-2013: cex.ErrOrderNotFound, // Order does not exist.
+	-2014: cex.ErrInvalidApiKey, // API-key format invalid.
+	-2015: cex.ErrInvalidApiKey, // Invalid API-key, IP, or permissions for action.
 	-2021: ErrSpotOrderCancelReplacePartiallyFailed,
 	-2022: ErrSpotOrderCancelReplaceFailed,
+
+	// futures codes, because futures responses are checked by this map too
+	-2018: cex.ErrInsufficientBalance,          // Balance is insufficient.
+	-2019: cex.ErrInsufficientBalance,          // Margin is insufficient.
+	-4046: ErrFutureNoNeedToChangeMarginType,   // No need to change margin type.
+	-4059: ErrFutureNoNeedToChangePositionSide, // No need to change position side.
+	-4061: ErrFuturePositionSideMismatch,       // Order's position side does not match user's setting.
+	-4164: cex.ErrMinNotional,                  // Order's notional must be no smaller than min notional.
 }
 
 func SpotCodeMsgChecker(code int) error {
 	return spotCexCustomErrCodes[code]
 }
 
+// customErrByCodeMsg returns custom error of code and msg.
+// Some codes have different meanings by msg, ex.
+// -1021 INVALID_TIMESTAMP: Timestamp for this request is outside of the recvWindow.
+// -2010 NEW_ORDER_REJECTED: Account has insufficient balance for requested action.
+// -1013 INVALID_MESSAGE: Filter failure: NOTIONAL.
+func customErrByCodeMsg(code int, msg string) error {
+	switch code {
+	case -1021:
+		// Timestamp for this request is outside of the recvWindow.
+		// Timestamp for this request was 1000ms ahead of the server's time.
+		switch {
+		case strings.Contains(msg, "recvWindow"):
+			return ErrTimestampOutsideRecvWindow
+		case strings.Contains(msg, "ahead of the server"):
+			return ErrTimestampAheadOfServer
+		}
+	case -2010:
+		if strings.Contains(strings.ToLower(msg), "insufficient balance") {
+			return cex.ErrInsufficientBalance
+		}
+	case -1013:
+		if strings.Contains(msg, "NOTIONAL") {
+			return cex.ErrMinNotional
+		}
+	}
+	return spotCexCustomErrCodes[code]
+}
+
 // ---------------------------------------------
 // Binance Spot Custom Errors
 // =============================================
 
+// =============================================
+// Binance SAPI Wallet Custom Errors
+// ---------------------------------------------
+
+// SAPI wallet error codes conflict to futures custom codes,
+// so they are only checked by wallet endpoints.
+
+var (
+	ErrWithdrawRestricted            = errors.New("withdraw is restricted")
+	ErrWithdrawAssetNotExist         = errors.New("withdraw asset does not exist")
+	ErrWithdrawAssetProhibited       = errors.New("withdraw asset is prohibited")
+	ErrWithdrawAmountNotMultiple     = errors.New("withdraw amount must be multiple of integer multiple")
+	ErrWithdrawAmountTooSmall        = errors.New("withdraw amount is less than min amount")
+	ErrWithdrawAmountTooLarge        = errors.New("withdraw amount is greater than max amount")
+	ErrWithdrawIllegalAddress        = errors.New("withdraw address is illegal")
+	ErrWithdrawAddressNotWhitelisted = errors.New("withdraw address is not in whitelist")
+)
+
+var sapiWalletCustomErrCodes = map[int]error{
+	-4013: ErrWithdrawRestricted,
+	-4014: ErrWithdrawRestricted,
+	-4015: ErrWithdrawRestricted,
+	-4016: ErrWithdrawRestricted,
+	-4017: ErrWithdrawRestricted,
+	-4018: ErrWithdrawAssetNotExist,
+	-4019: ErrWithdrawAssetProhibited,
+	-4021: ErrWithdrawAmountNotMultiple,
+	-4022: ErrWithdrawAmountTooSmall,
+	-4023: ErrWithdrawAmountTooLarge,
+	-4024: cex.ErrInsufficientBalance,
+	-4025: cex.ErrInsufficientBalance,
+	-4026: cex.ErrInsufficientBalance,
+	-4033: ErrWithdrawIllegalAddress,
+	-4035: ErrWithdrawAddressNotWhitelisted,
+}
+
+// ---------------------------------------------
+// Binance SAPI Wallet Custom Errors
+// =============================================
+
 // =============================================
 // Binance Future Custom Errors
 // ---------------------------------------------
 
 var (
 	ErrFutureNoNeedToChangePositionSide = errors.New("no need to change position side")
+	ErrFutureNoNeedToChangeMarginType   = errors.New("no need to change margin type")
+	ErrFuturePositionSideMismatch       = errors.New("position side does not match position mode")
 )
 
 var fuCexCustomErrCodes = map[int]error{
 	-1000: ErrCexInnerProblems,
+	-1003: cex.ErrHTTPTooFrequency,
+	-1015: ErrTooManyNewOrders,
 	-1021: cex.ErrInvalidTimestamp,
+	-2013: cex.ErrOrderNotFound,
+	-2014: cex.ErrInvalidApiKey,
+	-2015: cex.ErrInvalidApiKey,
+	-2018: cex.ErrInsufficientBalance,
+	-2019: cex.ErrInsufficientBalance,
+	-4046: ErrFutureNoNeedToChangeMarginType,
 	-4059: ErrFutureNoNeedToChangePositionSide,
+	-4061: ErrFuturePositionSideMismatch,
+	-4164: cex.ErrMinNotional,
 }
 
 func FutureCodeMsgChecker(code int) error {
@@ -0,0 +1,50 @@
+package bnc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestCustomErrByCodeMsg(t *testing.T) {
+	cases := []struct {
+		code int
+		msg  string
+		err  error
+	}{
+		{-2010, "Account has insufficient balance for requested action.", cex.ErrInsufficientBalance},
+		{-2010, "Order would immediately match and take.", ErrSpotOrderWouldImmediatelyMatchAndTake},
+		{-1013, "Filter failure: NOTIONAL", cex.ErrMinNotional},
+		{-1013, "Filter failure: LOT_SIZE", nil},
+		{-2011, "Unknown order sent.", cex.ErrOrderNotFound},
+		{-2013, "Order does not exist.", cex.ErrOrderNotFound},
+		{-2019, "Margin is insufficient.", cex.ErrInsufficientBalance},
+		{-4164, "Order's notional must be no smaller than 5.", cex.ErrMinNotional},
+		{-1015, "Too many new orders.", cex.ErrRateLimited},
+		{-1021, "Timestamp for this request is outside of the recvWindow.", ErrTimestampOutsideRecvWindow},
+		{-1021, "Timestamp for this request was 1000ms ahead of the server's time.", ErrTimestampAheadOfServer},
+		{-1021, "Timestamp for this request was 1000ms ahead of the server's time.", cex.ErrInvalidTimestamp},
+	}
+	for _, c := range cases {
+		body := []byte(fmt.Sprintf(`{"code":%d,"msg":%q}`, c.code, c.msg))
+		spotErr := spotBodyUnmshCodeMsg(body)
+		fuErr := fuBodyUnmshCodeMsg(body)
+		if spotErr == nil || fuErr == nil {
+			t.Fatal("error should not be nil", c.code, c.msg)
+		}
+		if c.err == nil {
+			continue
+		}
+		if !errors.Is(spotErr, c.err) || !errors.Is(fuErr, c.err) {
+			t.Error("error should be", c.err, c.code, c.msg, spotErr.Err, fuErr.Err)
+		}
+	}
+
+	for _, err := range []error{cex.ErrHTTPTooFrequency, cex.ErrBanned418, ErrTooManyNewOrders} {
+		if !errors.Is(err, cex.ErrRateLimited) {
+			t.Error("error should be rate limited", err)
+		}
+	}
+}
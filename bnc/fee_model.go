@@ -0,0 +1,136 @@
+package bnc
+
+import (
+	"sync"
+
+	"github.com/dwdwow/cex"
+)
+
+// FeeRate is commission rate of maker and taker, ex. 0.001 means 0.1%.
+type FeeRate struct {
+	Maker float64 `json:"maker" bson:"maker"`
+	Taker float64 `json:"taker" bson:"taker"`
+}
+
+// SpotVipFeeRates are regular spot fee rates indexed by VIP level.
+var SpotVipFeeRates = []FeeRate{
+	{0.001, 0.001},
+	{0.0009, 0.001},
+	{0.0008, 0.001},
+	{0.00042, 0.0006},
+	{0.00042, 0.00054},
+	{0.00036, 0.00048},
+	{0.0003, 0.00042},
+	{0.00024, 0.00036},
+	{0.00018, 0.0003},
+	{0.00012, 0.00024},
+}
+
+// FuturesVipFeeRates are usdt-margined futures fee rates indexed by VIP level.
+var FuturesVipFeeRates = []FeeRate{
+	{0.0002, 0.0005},
+	{0.00016, 0.0004},
+	{0.00014, 0.00035},
+	{0.00012, 0.00032},
+	{0.0001, 0.0003},
+	{0.00008, 0.00027},
+	{0.00006, 0.00025},
+	{0.00004, 0.00022},
+	{0.00002, 0.0002},
+	{0, 0.00017},
+}
+
+// BNB discounts, fee is multiplied by 1 - discount if paid by BNB.
+const (
+	SpotBNBFeeDiscount    = 0.25
+	FuturesBNBFeeDiscount = 0.1
+)
+
+func (c SpotAccountCommission) FeeRate() FeeRate {
+	return FeeRate{
+		Maker: c.StandardCommission.Maker + c.TaxCommission.Maker,
+		Taker: c.StandardCommission.Taker + c.TaxCommission.Taker,
+	}
+}
+
+func (f SpotTradeFee) FeeRate() FeeRate {
+	return FeeRate{Maker: f.MakerCommission, Taker: f.TakerCommission}
+}
+
+func (r FuturesCommissionRate) FeeRate() FeeRate {
+	return FeeRate{Maker: r.MakerCommissionRate, Taker: r.TakerCommissionRate}
+}
+
+// FeeModel computes expected fees of orders.
+// Rates of symbols set by SetSymbolRate, ex. queried from commission endpoints,
+// take precedence over VIP level rates.
+// Symbol rates are rates before BNB discount.
+type FeeModel struct {
+	mux         sync.RWMutex
+	vipLevel    int
+	bnbDiscount bool
+	rates       map[cex.PairType]map[string]FeeRate
+}
+
+func NewFeeModel(vipLevel int, bnbDiscount bool) *FeeModel {
+	return &FeeModel{
+		vipLevel:    vipLevel,
+		bnbDiscount: bnbDiscount,
+		rates:       map[cex.PairType]map[string]FeeRate{},
+	}
+}
+
+func (m *FeeModel) SetVipLevel(vipLevel int) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.vipLevel = vipLevel
+}
+
+func (m *FeeModel) SetBNBDiscount(enabled bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.bnbDiscount = enabled
+}
+
+func (m *FeeModel) SetSymbolRate(pairType cex.PairType, symbol string, rate FeeRate) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if m.rates[pairType] == nil {
+		m.rates[pairType] = map[string]FeeRate{}
+	}
+	m.rates[pairType][symbol] = rate
+}
+
+// Rate returns fee rate of symbol after BNB discount.
+// VIP level out of range is treated as the nearest level.
+func (m *FeeModel) Rate(pairType cex.PairType, symbol string) FeeRate {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	rate, ok := m.rates[pairType][symbol]
+	if !ok {
+		rates := SpotVipFeeRates
+		if pairType == cex.PairTypeFutures {
+			rates = FuturesVipFeeRates
+		}
+		rate = rates[max(0, min(m.vipLevel, len(rates)-1))]
+	}
+	if m.bnbDiscount {
+		discount := SpotBNBFeeDiscount
+		if pairType == cex.PairTypeFutures {
+			discount = FuturesBNBFeeDiscount
+		}
+		rate.Maker *= 1 - discount
+		rate.Taker *= 1 - discount
+	}
+	return rate
+}
+
+// Fee returns expected fee of order valued in quote asset.
+// If BNB discount is enabled, fee is actually paid by BNB of the same value.
+func (m *FeeModel) Fee(pairType cex.PairType, symbol string, qty, price float64, isMaker bool) float64 {
+	rate := m.Rate(pairType, symbol)
+	if isMaker {
+		return qty * price * rate.Maker
+	}
+	return qty * price * rate.Taker
+}
@@ -0,0 +1,50 @@
+package bnc
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestFeeModel(t *testing.T) {
+	m := NewFeeModel(0, false)
+	if fee := m.Fee(cex.PairTypeSpot, "ETHUSDT", 1, 2000, false); math.Abs(fee-2) > 1e-9 {
+		t.Error("wrong vip 0 spot fee", fee)
+	}
+	m.SetVipLevel(20)
+	if rate := m.Rate(cex.PairTypeFutures, "ETHUSDT"); rate != FuturesVipFeeRates[9] {
+		t.Error("vip level out of range should use the highest level", rate)
+	}
+	m.SetVipLevel(0)
+	m.SetBNBDiscount(true)
+	if fee := m.Fee(cex.PairTypeSpot, "ETHUSDT", 1, 2000, true); math.Abs(fee-1.5) > 1e-9 {
+		t.Error("wrong discounted spot fee", fee)
+	}
+	if fee := m.Fee(cex.PairTypeFutures, "ETHUSDT", 1, 2000, false); math.Abs(fee-0.9) > 1e-9 {
+		t.Error("wrong discounted futures fee", fee)
+	}
+
+	body := `{"symbol":"ETHUSDT","standardCommission":{"maker":"0.0004","taker":"0.0006","buyer":"0","seller":"0"},"taxCommission":{"maker":"0.0001","taker":"0.0001","buyer":"0","seller":"0"},"discount":{"enabledForAccount":true,"enabledForSymbol":true,"discountAsset":"BNB","discount":"0.75"}}`
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != ApiV3+"/account/commission" || r.URL.Query().Get("symbol") != "ETHUSDT" {
+			t.Error("wrong request", r.URL)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader([]byte(body))), Request: r}, nil
+	})
+	_, commission, err := NewUser("key", "secret", UserOptTransport(transport)).SpotAccountCommission("ETHUSDT")
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if commission.Discount.Discount != 0.75 || commission.Discount.DiscountAsset != "BNB" {
+		t.Error("wrong discount", commission.Discount)
+	}
+	m.SetBNBDiscount(false)
+	m.SetSymbolRate(cex.PairTypeSpot, "ETHUSDT", commission.FeeRate())
+	if fee := m.Fee(cex.PairTypeSpot, "ETHUSDT", 1, 1000, false); math.Abs(fee-0.7) > 1e-9 {
+		t.Error("symbol rate should be used", fee)
+	}
+}
@@ -0,0 +1,735 @@
+package bnc
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// FIX API
+// ------------------------------------------------------------
+
+const (
+	FixOrderEntryAddr        = "fix-oe.binance.com:9000"
+	FixDropCopyAddr          = "fix-dc.binance.com:9000"
+	TestnetFixOrderEntryAddr = "fix-oe.testnet.binance.vision:9000"
+
+	FixTargetCompId = "SPOT"
+
+	// fixTimeLayout is layout of SendingTime and TransactTime,
+	// parsing accepts fractional seconds too.
+	fixTimeLayout = "20060102-15:04:05.000"
+)
+
+// ErrFixClosed is returned by Start of closed FixClient.
+var ErrFixClosed = errors.New("bnc: fix client is closed")
+
+var fixOrdStatuses = map[string]OrderStatus{
+	"0": OrderStatusNew,
+	"1": OrderStatusPartiallyFilled,
+	"2": OrderStatusFilled,
+	"4": OrderStatusCanceled,
+	"6": OrderStatusPendingCancel,
+	"8": OrderStatusRejected,
+	"A": OrderStatusNew, // pending new
+	"C": OrderStatusExpired,
+}
+
+var fixExecTypes = map[string]OrderExecutionType{
+	"0": OrderExecutionTypeNew,
+	"4": OrderExecutionTypeCanceled,
+	"5": OrderExecutionTypeReplaced,
+	"8": OrderExecutionRejected,
+	"C": OrderExecutionExpired,
+	"F": OrderExecutionTrade,
+}
+
+var fixOrdTypes = map[OrderType]string{
+	OrderTypeMarket:        "1",
+	OrderTypeLimit:         "2",
+	OrderTypeLimitMaker:    "2",
+	OrderTypeStopLoss:      "3",
+	OrderTypeStopLossLimit: "4",
+}
+
+// fixOrdTypeByOrdType is not inverted from fixOrdTypes,
+// because limit maker has the same OrdType as limit.
+var fixOrdTypeByOrdType = map[string]OrderType{
+	"1": OrderTypeMarket,
+	"2": OrderTypeLimit,
+	"3": OrderTypeStopLoss,
+	"4": OrderTypeStopLossLimit,
+}
+
+// trigger enums of stop orders, stop loss triggers when price moves against side.
+const (
+	fixTriggerTypePriceMovement  = "4"
+	fixTriggerActionActivate     = "1"
+	fixTriggerPriceTypeLastTrade = "2"
+	fixTriggerUp                 = "U"
+	fixTriggerDown               = "D"
+)
+
+var fixSides = map[OrderSide]string{
+	OrderSideBuy:  "1",
+	OrderSideSell: "2",
+}
+
+var fixTimeInForces = map[TimeInForce]string{
+	TimeInForceGtc: "1",
+	TimeInForceIoc: "3",
+	TimeInForceFok: "4",
+}
+
+// fixKey returns key of value in m, or zero value if not found.
+func fixKey[K comparable](m map[K]string, value string) K {
+	for k, v := range m {
+		if v == value {
+			return k
+		}
+	}
+	var k K
+	return k
+}
+
+// FixExecutionReport is ExecutionReport <8> of binance FIX API.
+type FixExecutionReport struct {
+	ExecId       string             `json:"execId"`
+	ClOrdId      string             `json:"clOrdId"`
+	OrigClOrdId  string             `json:"origClOrdId"`
+	OrderId      int64              `json:"orderId"`
+	Symbol       string             `json:"symbol"`
+	Side         OrderSide          `json:"side"`
+	Type         OrderType          `json:"type"`
+	TimeInForce  TimeInForce        `json:"timeInForce"`
+	OrderQty     float64            `json:"orderQty"`
+	CashOrderQty float64            `json:"cashOrderQty"`
+	Price        float64            `json:"price"`
+	TriggerPrice float64            `json:"triggerPrice"`
+	Status       OrderStatus        `json:"status"`
+	ExecType     OrderExecutionType `json:"execType"`
+	CumQty       float64            `json:"cumQty"`
+	LeavesQty    float64            `json:"leavesQty"`
+	CumQuoteQty  float64            `json:"cumQuoteQty"`
+	LastPx       float64            `json:"lastPx"`
+	LastQty      float64            `json:"lastQty"`
+	TradeId      int64              `json:"tradeId"`
+	IsMaker      bool               `json:"isMaker"`
+	// TransactTime unit is millisecond.
+	TransactTime    int64   `json:"transactTime"`
+	Commission      float64 `json:"commission"`
+	CommissionAsset string  `json:"commissionAsset"`
+	ErrorCode       int     `json:"errorCode"`
+	Text            string  `json:"text"`
+}
+
+// ParseFixExecutionReport parses ExecutionReport <8>, unknown enum values are empty.
+func ParseFixExecutionReport(m *FixMsg) FixExecutionReport {
+	r := FixExecutionReport{
+		ExecId:       m.Get(FixTagExecId),
+		ClOrdId:      m.Get(FixTagClOrdId),
+		OrigClOrdId:  m.Get(FixTagOrigClOrdId),
+		OrderId:      m.Int(FixTagOrderId),
+		Symbol:       m.Get(FixTagSymbol),
+		Side:         fixKey(fixSides, m.Get(FixTagSide)),
+		Type:         fixOrdTypeByOrdType[m.Get(FixTagOrdType)],
+		TimeInForce:  fixKey(fixTimeInForces, m.Get(FixTagTimeInForce)),
+		OrderQty:     m.Float(FixTagOrderQty),
+		CashOrderQty: m.Float(FixTagCashOrderQty),
+		Price:        m.Float(FixTagPrice),
+		TriggerPrice: m.Float(FixTagTriggerPrice),
+		Status:       fixOrdStatuses[m.Get(FixTagOrdStatus)],
+		ExecType:     fixExecTypes[m.Get(FixTagExecType)],
+		CumQty:       m.Float(FixTagCumQty),
+		LeavesQty:    m.Float(FixTagLeavesQty),
+		CumQuoteQty:  m.Float(FixTagCumQuoteQty),
+		LastPx:       m.Float(FixTagLastPx),
+		LastQty:      m.Float(FixTagLastQty),
+		TradeId:      m.Int(FixTagTradeId),
+		IsMaker:      m.Get(FixTagAggressorIndicator) == "N",
+		ErrorCode:    int(m.Int(FixTagErrorCode)),
+		Text:         m.Get(FixTagText),
+	}
+	if t, err := time.Parse("20060102-15:04:05", m.Get(FixTagTransactTime)); err == nil {
+		r.TransactTime = t.UnixMilli()
+	}
+	for _, fee := range m.Groups(FixTagNoMiscFees, FixTagMiscFeeAmt, FixTagMiscFeeCurr, FixTagMiscFeeType) {
+		r.Commission += fee.Float(FixTagMiscFeeAmt)
+		r.CommissionAsset = fee.Get(FixTagMiscFeeCurr)
+	}
+	return r
+}
+
+// SpotOrder converts execution report to order,
+// ClientOrderId is the original one, if order is canceled.
+// Fills contains the trade of report, if execution type is TRADE.
+func (r FixExecutionReport) SpotOrder() SpotOrder {
+	cltOrdId := r.ClOrdId
+	if r.OrigClOrdId != "" {
+		cltOrdId = r.OrigClOrdId
+	}
+	ord := SpotOrder{
+		Symbol:              r.Symbol,
+		OrderId:             r.OrderId,
+		ClientOrderId:       cltOrdId,
+		Price:               r.Price,
+		OrigQty:             r.OrderQty,
+		ExecutedQty:         r.CumQty,
+		CummulativeQuoteQty: r.CumQuoteQty,
+		Status:              r.Status,
+		TimeInForce:         r.TimeInForce,
+		Type:                r.Type,
+		Side:                r.Side,
+		TransactTime:        r.TransactTime,
+		UpdateTime:          r.TransactTime,
+		StopPrice:           r.TriggerPrice,
+		OrigQuoteOrderQty:   r.CashOrderQty,
+		OrigClientOrderId:   r.OrigClOrdId,
+	}
+	if r.ExecType == OrderExecutionTrade {
+		ord.Fills = []SpotOrderFill{{
+			Price:           r.LastPx,
+			Qty:             r.LastQty,
+			Commission:      r.Commission,
+			CommissionAsset: r.CommissionAsset,
+			TradeId:         r.TradeId,
+		}}
+	}
+	return ord
+}
+
+// ToCexOrder implements cex.RawOrder.
+func (r FixExecutionReport) ToCexOrder() cex.Order {
+	return SwitchSpotOrderToCexOrder(r.SpotOrder())
+}
+
+// Fill converts trade execution report to fill, ex. to ingest it into cex.PnlTracker.
+// ok is false, if execution type is not TRADE.
+func (r FixExecutionReport) Fill() (fill cex.Fill, ok bool) {
+	if r.ExecType != OrderExecutionTrade {
+		return
+	}
+	return cex.Fill{
+		TradeId:         strconv.FormatInt(r.TradeId, 10),
+		Price:           r.LastPx,
+		Qty:             r.LastQty,
+		Commission:      r.Commission,
+		CommissionAsset: r.CommissionAsset,
+		IsMaker:         r.IsMaker,
+		Time:            r.TransactTime,
+		Cex:             cex.BINANCE,
+		PairType:        cex.PairTypeSpot,
+		Symbol:          r.Symbol,
+		OrderId:         strconv.FormatInt(r.OrderId, 10),
+		OrderSide:       mapStrStr(r.Side, cexOrdSideByOrdSide),
+		QuoteQty:        r.LastPx * r.LastQty,
+	}, true
+}
+
+// fixMsgErr returns error of rejected report, cancel reject or session reject, nil if msg is not rejected.
+func fixMsgErr(m *FixMsg) error {
+	switch m.MsgType() {
+	case FixMsgTypeExecutionReport:
+		if m.Get(FixTagExecType) != "8" {
+			return nil
+		}
+	case FixMsgTypeOrderCancelReject, FixMsgTypeReject:
+	default:
+		return nil
+	}
+	code, msg := int(m.Int(FixTagErrorCode)), m.Get(FixTagText)
+	errCtm := customErrByCodeMsg(code, msg)
+	if errCtm == nil {
+		errCtm = fmt.Errorf("%v, %v", code, msg)
+	}
+	return fmt.Errorf("bnc: %w", errCtm)
+}
+
+type FixClientOpt func(*FixClient)
+
+// FixClientOptAddr connects to addr instead of order entry addr of user, ex. FixDropCopyAddr.
+func FixClientOptAddr(addr string) FixClientOpt {
+	return func(c *FixClient) {
+		c.addr = addr
+	}
+}
+
+// FixClientOptSenderCompId sets SenderCompID, which must be unique among sessions of api key.
+// Default is random.
+func FixClientOptSenderCompId(id string) FixClientOpt {
+	return func(c *FixClient) {
+		c.senderCompId = id
+	}
+}
+
+// FixClientOptHeartbeat sets heartbeat interval, default is 30 seconds.
+func FixClientOptHeartbeat(interval time.Duration) FixClientOpt {
+	return func(c *FixClient) {
+		c.heartbeat = interval
+	}
+}
+
+// FixClientOptTimeout sets how long to wait for response of order requests, default is 10 seconds.
+// cex.CltOptTimeout overrides it per call.
+func FixClientOptTimeout(timeout time.Duration) FixClientOpt {
+	return func(c *FixClient) {
+		c.timeout = timeout
+	}
+}
+
+// FixClientOptDial dials connections by dial instead of tls, ex. through proxy.
+func FixClientOptDial(dial func(ctx context.Context, addr string) (net.Conn, error)) FixClientOpt {
+	return func(c *FixClient) {
+		c.dial = dial
+	}
+}
+
+// FixClientOptReportHandler handles all execution reports,
+// including reports of orders placed by other sessions, and trades.
+// handler is called in read loop, so it should not block.
+func FixClientOptReportHandler(handler func(FixExecutionReport)) FixClientOpt {
+	return func(c *FixClient) {
+		c.reportHandler = handler
+	}
+}
+
+type fixPending struct {
+	ch  chan *FixMsg
+	seq int64
+}
+
+// FixClient is FIX 4.4 connector of binance spot FIX API,
+// which requires Ed25519 api key with FIX access.
+// It implements OrderTransport, so User methods place and cancel spot orders by it,
+// and orders are converted to the same cex.Order as REST.
+// Futures orders and order types not supported by FIX fall back to REST.
+//
+//	fix := NewFixClient(user, FixClientOptReportHandler(func(r FixExecutionReport) {...}))
+//	err := fix.Start(ctx)
+//	user = user.WithOrderTransport(fix)
+//
+// Lost session is not restored automatically, orders fall back to REST until Start is called again.
+// Sequence numbers are reset by every logon, so missed messages are not resent.
+type FixClient struct {
+	user          *User
+	addr          string
+	senderCompId  string
+	heartbeat     time.Duration
+	timeout       time.Duration
+	dial          func(ctx context.Context, addr string) (net.Conn, error)
+	reportHandler func(FixExecutionReport)
+
+	mux     sync.Mutex
+	conn    net.Conn
+	pending map[string]fixPending
+	// pendingSeqs are client order ids by MsgSeqNum, to match session rejects
+	pendingSeqs map[int64]string
+	closed      bool
+
+	// writeMux keeps MsgSeqNum in order of writing
+	writeMux sync.Mutex
+	seq      int64
+
+	clOrdSeq int64
+}
+
+func NewFixClient(user *User, opts ...FixClientOpt) *FixClient {
+	c := &FixClient{
+		user:         user,
+		senderCompId: fmt.Sprintf("%08x", rand.Uint32()),
+		heartbeat:    30 * time.Second,
+		timeout:      10 * time.Second,
+		dial: func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&tls.Dialer{}).DialContext(ctx, "tcp", addr)
+		},
+		pending:     map[string]fixPending{},
+		pendingSeqs: map[int64]string{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Start logs on, it does nothing if session is alive.
+func (c *FixClient) Start(ctx context.Context) error {
+	c.mux.Lock()
+	closed, alive := c.closed, c.conn != nil
+	c.mux.Unlock()
+	if closed {
+		return ErrFixClosed
+	}
+	if alive {
+		return nil
+	}
+	u := c.user
+	if err := u.resolveCredential(); err != nil {
+		return err
+	}
+	if u.api.KeyType != cex.KeyTypeEd25519 {
+		return fmt.Errorf("bnc: fix requires ed25519 api key, %w", cex.ErrInvalidApiKey)
+	}
+	signer, err := u.apiSigner()
+	if err != nil {
+		return err
+	}
+	addr := c.addr
+	if addr == "" {
+		addr = FixOrderEntryAddr
+		if u.api.Env.IsTestnet() {
+			addr = TestnetFixOrderEntryAddr
+		}
+	}
+
+	conn, err := c.dial(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("bnc: fix dial %v, %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// signature payload is MsgType, SenderCompID, TargetCompID, MsgSeqNum and SendingTime joined by SOH
+	sendingTime := time.Now().UTC().Format(fixTimeLayout)
+	payload := fmt.Sprintf("%v%c%v%c%v%c%v%c%v",
+		FixMsgTypeLogon, fixSoh, c.senderCompId, fixSoh, FixTargetCompId, fixSoh, 1, fixSoh, sendingTime)
+	sig, err := signer(payload)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("bnc: sign, %w", err)
+	}
+	rawData := encodeSignature(sig, u.api.KeyType)
+	logon := NewFixMsg(FixMsgTypeLogon).
+		Set(FixTagEncryptMethod, "0").
+		Set(FixTagHeartBtInt, strconv.Itoa(int(c.heartbeat/time.Second))).
+		Set(FixTagRawDataLength, strconv.Itoa(len(rawData))).
+		Set(FixTagRawData, rawData).
+		Set(FixTagResetSeqNumFlag, "Y").
+		Set(FixTagUsername, u.api.ApiKey).
+		// 2 is SEQUENTIAL, responses are in order of requests
+		Set(FixTagMessageHandling, "2")
+	if _, err := conn.Write(encodeFixMsg(logon, c.senderCompId, FixTargetCompId, 1, sendingTime)); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("bnc: fix logon, %w", err)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := readFixMsg(reader)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("bnc: fix logon, %w", err)
+	}
+	if resp.MsgType() != FixMsgTypeLogon {
+		_ = conn.Close()
+		return fmt.Errorf("bnc: fix logon, %v, %w", resp.Get(FixTagText), cex.ErrInvalidApiKey)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		_ = conn.Close()
+		return ErrFixClosed
+	}
+	c.conn = conn
+	c.mux.Unlock()
+	c.writeMux.Lock()
+	c.seq = 1
+	c.writeMux.Unlock()
+
+	stop := make(chan struct{})
+	go c.read(conn, reader, stop)
+	go c.keepAlive(conn, stop)
+	return nil
+}
+
+// Close logs out and closes connection, pending requests get unknown status errors.
+func (c *FixClient) Close() {
+	c.mux.Lock()
+	c.closed = true
+	conn := c.conn
+	c.mux.Unlock()
+	if conn == nil {
+		return
+	}
+	_, _ = c.send(conn, NewFixMsg(FixMsgTypeLogout), "")
+	_ = conn.Close()
+}
+
+// Available implements OrderTransport, only spot orders are available, if session is logged on.
+func (c *FixClient) Available(pairType cex.PairType) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return pairType == cex.PairTypeSpot && c.conn != nil && !c.closed
+}
+
+func (c *FixClient) NewSpotOrder(user *User, params SpotNewOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	reqErr := cex.RequestError{ReqBaseConfig: cex.ReqBaseConfig{BaseUrl: c.addr, Method: "NewOrderSingle"}}
+	if err := cex.ValidateParams(params); err != nil {
+		return nil, SpotOrder{}, *reqErr.SetErr(err)
+	}
+	ordType, ok := fixOrdTypes[params.Type]
+	if !ok {
+		return nil, SpotOrder{}, *reqErr.SetErr(fmt.Errorf("bnc: fix order type %v, %w", params.Type, ErrOrderTransportUnavailable))
+	}
+	tif, ok := fixTimeInForces[params.TimeInForce]
+	if !ok && params.TimeInForce != TimeInForceNone {
+		return nil, SpotOrder{}, *reqErr.SetErr(fmt.Errorf("bnc: fix time in force %v, %w", params.TimeInForce, ErrOrderTransportUnavailable))
+	}
+	clOrdId := params.NewClientOrderId
+	if clOrdId == "" {
+		clOrdId = c.nextClOrdId(user)
+	}
+	msg := NewFixMsg(FixMsgTypeNewOrderSingle).
+		Set(FixTagClOrdId, clOrdId).
+		Set(FixTagSymbol, params.Symbol).
+		Set(FixTagSide, fixSides[params.Side]).
+		Set(FixTagOrdType, ordType).
+		Set(FixTagTimeInForce, tif).
+		SetFloat(FixTagOrderQty, params.Quantity).
+		SetFloat(FixTagCashOrderQty, params.QuoteOrderQty).
+		SetFloat(FixTagPrice, params.Price)
+	if params.Type == OrderTypeLimitMaker {
+		// participate don't initiate
+		msg.Set(FixTagExecInst, "6")
+	}
+	if params.StopPrice != 0 {
+		// binance triggers stop orders by TriggerPrice of last trade price, instead of StopPx
+		dir := fixTriggerUp
+		if params.Side == OrderSideSell {
+			dir = fixTriggerDown
+		}
+		msg.Set(FixTagTriggerType, fixTriggerTypePriceMovement).
+			Set(FixTagTriggerAction, fixTriggerActionActivate).
+			SetFloat(FixTagTriggerPrice, params.StopPrice).
+			Set(FixTagTriggerPriceType, fixTriggerPriceTypeLastTrade).
+			Set(FixTagTriggerPriceDir, dir)
+	}
+	resp, err := c.request(user, SpotNewOrderConfig.ReqBaseConfig, &reqErr, msg, clOrdId, opts...)
+	if err != nil {
+		return nil, SpotOrder{}, *reqErr.SetErr(err)
+	}
+	ord := ParseFixExecutionReport(resp).SpotOrder()
+	if err := fixMsgErr(resp); err != nil {
+		return nil, ord, *reqErr.SetErr(err)
+	}
+	return nil, ord, reqErr
+}
+
+// CancelSpotOrder cancels order by OrderCancelRequest <F>, whose ClOrdID is generated,
+// returned order has original client order id.
+func (c *FixClient) CancelSpotOrder(user *User, params SpotCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	reqErr := cex.RequestError{ReqBaseConfig: cex.ReqBaseConfig{BaseUrl: c.addr, Method: "OrderCancelRequest"}}
+	if err := cex.ValidateParams(params); err != nil {
+		return nil, SpotOrder{}, *reqErr.SetErr(err)
+	}
+	clOrdId := params.NewClientOrderId
+	if clOrdId == "" {
+		clOrdId = c.nextClOrdId(user)
+	}
+	msg := NewFixMsg(FixMsgTypeOrderCancelRequest).
+		Set(FixTagClOrdId, clOrdId).
+		Set(FixTagOrigClOrdId, params.OrigClientOrderId).
+		Set(FixTagSymbol, params.Symbol)
+	if params.OrderId != 0 {
+		msg.Set(FixTagOrderId, strconv.FormatInt(params.OrderId, 10))
+	}
+	resp, err := c.request(user, SpotCancelOrderConfig.ReqBaseConfig, &reqErr, msg, clOrdId, opts...)
+	if err != nil {
+		return nil, SpotOrder{}, *reqErr.SetErr(err)
+	}
+	if err := fixMsgErr(resp); err != nil {
+		return nil, SpotOrder{}, *reqErr.SetErr(err)
+	}
+	return nil, ParseFixExecutionReport(resp).SpotOrder(), reqErr
+}
+
+// NewFuturesOrder falls back to REST, because binance FIX API is spot only.
+func (c *FixClient) NewFuturesOrder(user *User, params FuturesNewOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return nil, FuturesOrder{}, cex.RequestError{Err: fmt.Errorf("bnc: fix futures, %w", ErrOrderTransportUnavailable)}
+}
+
+// CancelFuturesOrder falls back to REST, because binance FIX API is spot only.
+func (c *FixClient) CancelFuturesOrder(user *User, params FuturesQueryOrCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return nil, FuturesOrder{}, cex.RequestError{Err: fmt.Errorf("bnc: fix futures, %w", ErrOrderTransportUnavailable)}
+}
+
+func (c *FixClient) nextClOrdId(user *User) string {
+	if reg := user.cfg.cltOrdIds; reg != nil {
+		return reg.Next()
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.clOrdSeq++
+	return c.senderCompId + "-" + strconv.FormatInt(c.clOrdSeq, 36)
+}
+
+// send writes msg with the next MsgSeqNum, and registers it for session rejects of clOrdId.
+func (c *FixClient) send(conn net.Conn, msg *FixMsg, clOrdId string) (int64, error) {
+	c.writeMux.Lock()
+	defer c.writeMux.Unlock()
+	seq := c.seq + 1
+	if clOrdId != "" {
+		c.mux.Lock()
+		if p, ok := c.pending[clOrdId]; ok {
+			p.seq = seq
+			c.pending[clOrdId] = p
+			c.pendingSeqs[seq] = clOrdId
+		}
+		c.mux.Unlock()
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, err := conn.Write(encodeFixMsg(msg, c.senderCompId, FixTargetCompId, seq, time.Now().UTC().Format(fixTimeLayout))); err != nil {
+		return seq, err
+	}
+	c.seq = seq
+	return seq, nil
+}
+
+// request returns the first response of clOrdId, it waits by context of user.
+// Requests acquire rate limits of REST config, because they count toward the same order limits.
+// Error wraps ErrOrderTransportUnavailable, if msg is not sent, ex. api key of user is not the one of session,
+// or cex.ErrHTTPCexInnerUnknownStatus, if msg is sent but response is not received.
+func (c *FixClient) request(user *User, config cex.ReqBaseConfig, reqErr *cex.RequestError, msg *FixMsg, clOrdId string, opts ...cex.CltOpt) (*FixMsg, error) {
+	if err := user.resolveCredential(); err != nil {
+		return nil, err
+	}
+	if user.api.ApiKey != c.user.api.ApiKey {
+		return nil, fmt.Errorf("bnc: fix, api key is not the one of session, %w", ErrOrderTransportUnavailable)
+	}
+	ctx := user.requestCtx()
+	if err := acquireOrderLimits(ctx, user, config, reqErr); err != nil {
+		return nil, err
+	}
+	ch := make(chan *FixMsg, 1)
+	c.mux.Lock()
+	conn := c.conn
+	if conn != nil {
+		c.pending[clOrdId] = fixPending{ch: ch}
+	}
+	c.mux.Unlock()
+	if conn == nil {
+		return nil, fmt.Errorf("bnc: fix, not logged on, %w", ErrOrderTransportUnavailable)
+	}
+	if _, err := c.send(conn, msg, clOrdId); err != nil {
+		c.takePending(clOrdId)
+		_ = conn.Close()
+		return nil, fmt.Errorf("bnc: fix, write, %w: %w", ErrOrderTransportUnavailable, err)
+	}
+
+	timeout := c.timeout
+	if ro := cex.NewReqOpts(opts...); ro.Timeout > 0 {
+		timeout = ro.Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, fmt.Errorf("bnc: fix, session is lost, %w", cex.ErrHTTPCexInnerUnknownStatus)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		c.takePending(clOrdId)
+		return nil, fmt.Errorf("bnc: fix, %w: %w", cex.ErrHTTPCexInnerUnknownStatus, ctx.Err())
+	}
+}
+
+func (c *FixClient) takePending(clOrdId string) (fixPending, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	p, ok := c.pending[clOrdId]
+	if ok {
+		delete(c.pending, clOrdId)
+		delete(c.pendingSeqs, p.seq)
+	}
+	return p, ok
+}
+
+// dispatch sends msg to pending request of clOrdId, or of MsgSeqNum rejected by session.
+func (c *FixClient) dispatch(msg *FixMsg) {
+	clOrdId := msg.Get(FixTagClOrdId)
+	if msg.MsgType() == FixMsgTypeReject {
+		c.mux.Lock()
+		clOrdId = c.pendingSeqs[msg.Int(FixTagRefSeqNum)]
+		c.mux.Unlock()
+	}
+	if clOrdId == "" {
+		return
+	}
+	if p, ok := c.takePending(clOrdId); ok {
+		p.ch <- msg
+	}
+}
+
+// read handles msgs until session is lost, then pending requests are closed, because their status is unknown.
+func (c *FixClient) read(conn net.Conn, reader *bufio.Reader, stop chan struct{}) {
+	defer func() {
+		close(stop)
+		_ = conn.Close()
+		c.mux.Lock()
+		if c.conn == conn {
+			c.conn = nil
+		}
+		pending := c.pending
+		c.pending, c.pendingSeqs = map[string]fixPending{}, map[int64]string{}
+		c.mux.Unlock()
+		for _, p := range pending {
+			close(p.ch)
+		}
+	}()
+	for {
+		msg, err := readFixMsg(reader)
+		if err != nil {
+			return
+		}
+		switch msg.MsgType() {
+		case FixMsgTypeTestRequest:
+			hb := NewFixMsg(FixMsgTypeHeartbeat).Set(FixTagTestReqId, msg.Get(FixTagTestReqId))
+			if _, err := c.send(conn, hb, ""); err != nil {
+				return
+			}
+		case FixMsgTypeLogout:
+			return
+		case FixMsgTypeExecutionReport:
+			c.dispatch(msg)
+			if c.reportHandler != nil {
+				c.reportHandler(ParseFixExecutionReport(msg))
+			}
+		case FixMsgTypeOrderCancelReject, FixMsgTypeReject:
+			c.dispatch(msg)
+		}
+	}
+}
+
+func (c *FixClient) keepAlive(conn net.Conn, stop chan struct{}) {
+	if c.heartbeat <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := c.send(conn, NewFixMsg(FixMsgTypeHeartbeat), ""); err != nil {
+				_ = conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// ------------------------------------------------------------
+// FIX API
+// ============================================================
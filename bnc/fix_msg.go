@@ -0,0 +1,300 @@
+package bnc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+// FIX Message
+// ------------------------------------------------------------
+
+// ErrFixMsg is wrapped by errors of malformed FIX messages.
+var ErrFixMsg = errors.New("bnc: malformed fix msg")
+
+const (
+	fixBeginString = "FIX.4.4"
+	fixSoh         = '\x01'
+)
+
+// FIX tags used by binance FIX API.
+const (
+	FixTagAvgPx              = 6
+	FixTagBeginString        = 8
+	FixTagBodyLength         = 9
+	FixTagCheckSum           = 10
+	FixTagClOrdId            = 11
+	FixTagCumQty             = 14
+	FixTagExecId             = 17
+	FixTagExecInst           = 18
+	FixTagLastPx             = 31
+	FixTagLastQty            = 32
+	FixTagMsgSeqNum          = 34
+	FixTagMsgType            = 35
+	FixTagOrderId            = 37
+	FixTagOrderQty           = 38
+	FixTagOrdStatus          = 39
+	FixTagOrdType            = 40
+	FixTagOrigClOrdId        = 41
+	FixTagPrice              = 44
+	FixTagRefSeqNum          = 45
+	FixTagSenderCompId       = 49
+	FixTagSendingTime        = 52
+	FixTagSide               = 54
+	FixTagSymbol             = 55
+	FixTagTargetCompId       = 56
+	FixTagText               = 58
+	FixTagTimeInForce        = 59
+	FixTagTransactTime       = 60
+	FixTagRawDataLength      = 95
+	FixTagRawData            = 96
+	FixTagEncryptMethod      = 98
+	FixTagHeartBtInt         = 108
+	FixTagTestReqId          = 112
+	FixTagNoMiscFees         = 136
+	FixTagMiscFeeAmt         = 137
+	FixTagMiscFeeCurr        = 138
+	FixTagMiscFeeType        = 139
+	FixTagResetSeqNumFlag    = 141
+	FixTagExecType           = 150
+	FixTagLeavesQty          = 151
+	FixTagCashOrderQty       = 152
+	FixTagUsername           = 553
+	FixTagTradeId            = 1003
+	FixTagAggressorIndicator = 1057
+	FixTagTriggerType        = 1100
+	FixTagTriggerAction      = 1101
+	FixTagTriggerPrice       = 1102
+	FixTagTriggerPriceType   = 1107
+	FixTagTriggerPriceDir    = 1109
+	FixTagErrorCode          = 25016
+	FixTagCumQuoteQty        = 25017
+	FixTagMessageHandling    = 25035
+)
+
+// FIX message types used by binance FIX API.
+const (
+	FixMsgTypeHeartbeat          = "0"
+	FixMsgTypeTestRequest        = "1"
+	FixMsgTypeReject             = "3"
+	FixMsgTypeLogout             = "5"
+	FixMsgTypeExecutionReport    = "8"
+	FixMsgTypeOrderCancelReject  = "9"
+	FixMsgTypeLogon              = "A"
+	FixMsgTypeNews               = "B"
+	FixMsgTypeNewOrderSingle     = "D"
+	FixMsgTypeOrderCancelRequest = "F"
+)
+
+type FixField struct {
+	Tag   int
+	Value string
+}
+
+// FixMsg is a FIX 4.4 message, fields are kept in order, so repeating groups can be read.
+// Header fields, except MsgType, and trailer are set by encoding.
+type FixMsg struct {
+	Fields []FixField
+}
+
+func NewFixMsg(msgType string) *FixMsg {
+	return &FixMsg{Fields: []FixField{{FixTagMsgType, msgType}}}
+}
+
+// Set appends field, empty value is ignored, as s2m omitempty.
+func (m *FixMsg) Set(tag int, value string) *FixMsg {
+	if value != "" {
+		m.Fields = append(m.Fields, FixField{tag, value})
+	}
+	return m
+}
+
+// SetFloat appends field, 0 is ignored.
+func (m *FixMsg) SetFloat(tag int, value float64) *FixMsg {
+	if value == 0 {
+		return m
+	}
+	return m.Set(tag, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Get returns value of the first field of tag.
+func (m *FixMsg) Get(tag int) string {
+	for _, f := range m.Fields {
+		if f.Tag == tag {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+func (m *FixMsg) Has(tag int) bool {
+	for _, f := range m.Fields {
+		if f.Tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Float returns 0, if field is absent or invalid.
+func (m *FixMsg) Float(tag int) float64 {
+	v, _ := strconv.ParseFloat(m.Get(tag), 64)
+	return v
+}
+
+// Int returns 0, if field is absent or invalid.
+func (m *FixMsg) Int(tag int) int64 {
+	v, _ := strconv.ParseInt(m.Get(tag), 10, 64)
+	return v
+}
+
+func (m *FixMsg) MsgType() string {
+	return m.Get(FixTagMsgType)
+}
+
+// Groups returns entries of repeating group, whose count field is countTag.
+// tags are fields of entry, every entry starts with tags[0],
+// and group ends at the first field not in tags.
+func (m *FixMsg) Groups(countTag int, tags ...int) []*FixMsg {
+	if len(tags) == 0 {
+		return nil
+	}
+	var groups []*FixMsg
+	inGroup := false
+	for _, f := range m.Fields {
+		switch {
+		case f.Tag == countTag:
+			inGroup = true
+		case !inGroup:
+		case f.Tag == tags[0]:
+			groups = append(groups, &FixMsg{Fields: []FixField{f}})
+		case len(groups) > 0 && slices.Contains(tags, f.Tag):
+			groups[len(groups)-1].Fields = append(groups[len(groups)-1].Fields, f)
+		default:
+			inGroup = false
+		}
+	}
+	return groups
+}
+
+func (m *FixMsg) String() string {
+	return strings.ReplaceAll(string(m.body()), string(fixSoh), "|")
+}
+
+// body returns fields except header and trailer, MsgType is the first one.
+func (m *FixMsg) body() []byte {
+	b := bytes.Buffer{}
+	for _, f := range m.Fields {
+		b.WriteString(strconv.Itoa(f.Tag))
+		b.WriteByte('=')
+		b.WriteString(f.Value)
+		b.WriteByte(fixSoh)
+	}
+	return b.Bytes()
+}
+
+// encodeFixMsg encodes m with header and checksum.
+func encodeFixMsg(m *FixMsg, senderCompId, targetCompId string, seq int64, sendingTime string) []byte {
+	body := bytes.Buffer{}
+	msgType := m.MsgType()
+	fmt.Fprintf(&body, "%d=%s%c%d=%s%c%d=%s%c%d=%d%c%d=%s%c",
+		FixTagMsgType, msgType, fixSoh,
+		FixTagSenderCompId, senderCompId, fixSoh,
+		FixTagTargetCompId, targetCompId, fixSoh,
+		FixTagMsgSeqNum, seq, fixSoh,
+		FixTagSendingTime, sendingTime, fixSoh,
+	)
+	for _, f := range m.Fields {
+		if f.Tag == FixTagMsgType {
+			continue
+		}
+		fmt.Fprintf(&body, "%d=%s%c", f.Tag, f.Value, fixSoh)
+	}
+	msg := bytes.Buffer{}
+	fmt.Fprintf(&msg, "%d=%s%c%d=%d%c", FixTagBeginString, fixBeginString, fixSoh, FixTagBodyLength, body.Len(), fixSoh)
+	msg.Write(body.Bytes())
+	fmt.Fprintf(&msg, "%d=%03d%c", FixTagCheckSum, fixCheckSum(msg.Bytes()), fixSoh)
+	return msg.Bytes()
+}
+
+func fixCheckSum(data []byte) int {
+	sum := 0
+	for _, b := range data {
+		sum += int(b)
+	}
+	return sum % 256
+}
+
+// readFixMsg reads one message, and verifies body length and checksum.
+// Returned message contains all fields, including header and trailer.
+func readFixMsg(r *bufio.Reader) (*FixMsg, error) {
+	raw := bytes.Buffer{}
+	readField := func() (FixField, error) {
+		s, err := r.ReadString(fixSoh)
+		if err != nil {
+			return FixField{}, err
+		}
+		raw.WriteString(s)
+		return parseFixField(s[:len(s)-1])
+	}
+	begin, err := readField()
+	if err != nil {
+		return nil, err
+	}
+	if begin.Tag != FixTagBeginString {
+		return nil, fmt.Errorf("%w, first tag %v", ErrFixMsg, begin.Tag)
+	}
+	length, err := readField()
+	if err != nil {
+		return nil, err
+	}
+	n, errN := strconv.Atoi(length.Value)
+	if length.Tag != FixTagBodyLength || errN != nil || n <= 0 {
+		return nil, fmt.Errorf("%w, body length %v=%v", ErrFixMsg, length.Tag, length.Value)
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	raw.Write(body)
+	sum := fixCheckSum(raw.Bytes())
+	checkSum, err := readField()
+	if err != nil {
+		return nil, err
+	}
+	if checkSum.Tag != FixTagCheckSum || checkSum.Value != fmt.Sprintf("%03d", sum) {
+		return nil, fmt.Errorf("%w, checksum %v, want %03d", ErrFixMsg, checkSum.Value, sum)
+	}
+	m := &FixMsg{Fields: []FixField{begin, length}}
+	for _, s := range strings.Split(strings.TrimSuffix(string(body), string(fixSoh)), string(fixSoh)) {
+		f, err := parseFixField(s)
+		if err != nil {
+			return nil, err
+		}
+		m.Fields = append(m.Fields, f)
+	}
+	m.Fields = append(m.Fields, checkSum)
+	return m, nil
+}
+
+func parseFixField(s string) (FixField, error) {
+	tag, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return FixField{}, fmt.Errorf("%w, field %q", ErrFixMsg, s)
+	}
+	t, err := strconv.Atoi(tag)
+	if err != nil {
+		return FixField{}, fmt.Errorf("%w, tag %q", ErrFixMsg, tag)
+	}
+	return FixField{t, value}, nil
+}
+
+// ------------------------------------------------------------
+// FIX Message
+// ============================================================
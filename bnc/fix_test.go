@@ -0,0 +1,205 @@
+package bnc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestFixMsg(t *testing.T) {
+	m := NewFixMsg(FixMsgTypeExecutionReport).
+		Set(FixTagSymbol, "ETHUSDT").
+		Set(FixTagText, "").
+		SetFloat(FixTagPrice, 2000.5).
+		Set(FixTagNoMiscFees, "2").
+		Set(FixTagMiscFeeAmt, "0.1").Set(FixTagMiscFeeCurr, "BNB").Set(FixTagMiscFeeType, "4").
+		Set(FixTagMiscFeeAmt, "0.2").Set(FixTagMiscFeeCurr, "BNB").Set(FixTagMiscFeeType, "4").
+		Set(FixTagOrderId, "1")
+	raw := encodeFixMsg(m, "sender", "SPOT", 2, "20240101-00:00:00.000")
+	got, err := readFixMsg(bufio.NewReader(bytes.NewReader(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.MsgType() != FixMsgTypeExecutionReport || got.Get(FixTagSenderCompId) != "sender" || got.Int(FixTagMsgSeqNum) != 2 || got.Float(FixTagPrice) != 2000.5 {
+		t.Error("wrong decoded msg", got)
+	}
+	if got.Has(FixTagText) {
+		t.Error("empty value should be ignored")
+	}
+	if groups := got.Groups(FixTagNoMiscFees, FixTagMiscFeeAmt, FixTagMiscFeeCurr, FixTagMiscFeeType); len(groups) != 2 || groups[1].Float(FixTagMiscFeeAmt) != 0.2 || groups[1].Has(FixTagOrderId) {
+		t.Error("wrong groups", groups)
+	}
+
+	raw[len(raw)-2]++
+	if _, err := readFixMsg(bufio.NewReader(bytes.NewReader(raw))); !errors.Is(err, ErrFixMsg) {
+		t.Error("wrong checksum should be checked", err)
+	}
+}
+
+// fakeFixServer verifies logon, and responds to orders like binance.
+func fakeFixServer(conn net.Conn, pub ed25519.PublicKey) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	var seq int64
+	write := func(m *FixMsg) {
+		seq++
+		_, _ = conn.Write(encodeFixMsg(m, FixTargetCompId, "sender", seq, "20240101-00:00:00.000"))
+	}
+	for {
+		m, err := readFixMsg(reader)
+		if err != nil {
+			return
+		}
+		switch m.MsgType() {
+		case FixMsgTypeLogon:
+			payload := fmt.Sprintf("A\x01%v\x01SPOT\x011\x01%v", m.Get(FixTagSenderCompId), m.Get(FixTagSendingTime))
+			sig, _ := base64.StdEncoding.DecodeString(m.Get(FixTagRawData))
+			if m.Get(FixTagUsername) != "key" || !ed25519.Verify(pub, []byte(payload), sig) {
+				write(NewFixMsg(FixMsgTypeLogout).Set(FixTagText, "Signature is invalid."))
+				return
+			}
+			write(NewFixMsg(FixMsgTypeLogon))
+		case FixMsgTypeNewOrderSingle:
+			switch m.Get(FixTagClOrdId) {
+			case "reject":
+				write(NewFixMsg(FixMsgTypeExecutionReport).
+					Set(FixTagClOrdId, "reject").Set(FixTagExecType, "8").Set(FixTagOrdStatus, "8").
+					Set(FixTagErrorCode, "-2010").Set(FixTagText, "Order would immediately match and take."))
+			case "close":
+				return
+			case "stop":
+				if m.Get(FixTagOrdType) != "4" || m.Has(99) || m.Get(FixTagTriggerType) != "4" || m.Get(FixTagTriggerAction) != "1" ||
+					m.Get(FixTagTriggerPriceType) != "2" || m.Get(FixTagTriggerPriceDir) != "D" {
+					write(NewFixMsg(FixMsgTypeExecutionReport).
+						Set(FixTagClOrdId, "stop").Set(FixTagExecType, "8").Set(FixTagOrdStatus, "8").
+						Set(FixTagErrorCode, "-1102").Set(FixTagText, "Mandatory parameter was not sent."))
+					continue
+				}
+				write(NewFixMsg(FixMsgTypeExecutionReport).
+					Set(FixTagClOrdId, "stop").Set(FixTagOrderId, "2").Set(FixTagSymbol, m.Get(FixTagSymbol)).
+					Set(FixTagSide, m.Get(FixTagSide)).Set(FixTagOrdType, m.Get(FixTagOrdType)).Set(FixTagOrderQty, m.Get(FixTagOrderQty)).
+					Set(FixTagPrice, m.Get(FixTagPrice)).Set(FixTagTriggerPrice, m.Get(FixTagTriggerPrice)).
+					Set(FixTagExecType, "0").Set(FixTagOrdStatus, "0"))
+			default:
+				write(NewFixMsg(FixMsgTypeExecutionReport).
+					Set(FixTagClOrdId, m.Get(FixTagClOrdId)).Set(FixTagOrderId, "1").Set(FixTagSymbol, m.Get(FixTagSymbol)).
+					Set(FixTagSide, m.Get(FixTagSide)).Set(FixTagOrdType, m.Get(FixTagOrdType)).Set(FixTagOrderQty, m.Get(FixTagOrderQty)).
+					Set(FixTagPrice, m.Get(FixTagPrice)).Set(FixTagTimeInForce, m.Get(FixTagTimeInForce)).
+					Set(FixTagExecType, "0").Set(FixTagOrdStatus, "0").Set(FixTagTransactTime, "20240101-00:00:00.123"))
+				write(NewFixMsg(FixMsgTypeExecutionReport).
+					Set(FixTagClOrdId, m.Get(FixTagClOrdId)).Set(FixTagOrderId, "1").Set(FixTagSymbol, m.Get(FixTagSymbol)).
+					Set(FixTagSide, m.Get(FixTagSide)).Set(FixTagExecType, "F").Set(FixTagOrdStatus, "2").
+					Set(FixTagLastPx, "2000").Set(FixTagLastQty, "0.01").Set(FixTagCumQty, "0.01").Set(FixTagTradeId, "9").
+					Set(FixTagAggressorIndicator, "N").
+					Set(FixTagNoMiscFees, "1").Set(FixTagMiscFeeAmt, "0.001").Set(FixTagMiscFeeCurr, "BNB").Set(FixTagMiscFeeType, "4"))
+			}
+		case FixMsgTypeOrderCancelRequest:
+			write(NewFixMsg(FixMsgTypeOrderCancelReject).
+				Set(FixTagClOrdId, m.Get(FixTagClOrdId)).Set(FixTagOrigClOrdId, m.Get(FixTagOrigClOrdId)).
+				Set(FixTagErrorCode, "-2011").Set(FixTagText, "Unknown order sent."))
+		case FixMsgTypeLogout:
+			write(NewFixMsg(FixMsgTypeLogout))
+			return
+		}
+	}
+}
+
+func TestFixClient(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := NewUser("key", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), UserOptKeyType(cex.KeyTypeEd25519))
+	dial := func(ctx context.Context, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go fakeFixServer(server, pub)
+		return client, nil
+	}
+	reports := make(chan FixExecutionReport, 10)
+	fix := NewFixClient(user, FixClientOptDial(dial), FixClientOptSenderCompId("sender"),
+		FixClientOptReportHandler(func(r FixExecutionReport) { reports <- r }))
+	if fix.Available(cex.PairTypeSpot) {
+		t.Error("fix should not be available before logon")
+	}
+	if err := fix.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	user = user.WithOrderTransport(fix)
+	if !fix.Available(cex.PairTypeSpot) || fix.Available(cex.PairTypeFutures) {
+		t.Error("fix should be available for spot only")
+	}
+
+	params := SpotNewOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeLimit, Quantity: 0.01, Price: 2000, TimeInForce: TimeInForceGtc, NewClientOrderId: "fix"}
+	_, ord, reqErr := user.routeNewSpotOrder(params)
+	if reqErr.IsNotNil() {
+		t.Fatal(reqErr.Error())
+	}
+	if ord.ClientOrderId != "fix" || ord.Status != OrderStatusNew || ord.Type != OrderTypeLimit || ord.Side != OrderSideBuy || ord.TimeInForce != TimeInForceGtc || ord.Price != 2000 || ord.TransactTime != 1704067200123 {
+		t.Error("wrong order", ord)
+	}
+
+	<-reports
+	trade := <-reports
+	fill, ok := trade.Fill()
+	if !ok || fill.TradeId != "9" || !fill.IsMaker || fill.Commission != 0.001 || fill.CommissionAsset != "BNB" || fill.OrderSide != cex.OrderSideBuy {
+		t.Error("wrong fill", fill)
+	}
+	if cexOrd := trade.ToCexOrder(); cexOrd.Status != cex.OrderStatusFilled || cexOrd.FilledQty != 0.01 {
+		t.Error("wrong cex order", cexOrd)
+	}
+
+	stop := SpotNewOrderParams{Symbol: "ETHUSDT", Side: OrderSideSell, Type: OrderTypeStopLossLimit, Quantity: 0.01, Price: 1890, StopPrice: 1900, TimeInForce: TimeInForceGtc, NewClientOrderId: "stop"}
+	if _, ord, reqErr = user.routeNewSpotOrder(stop); reqErr.IsNotNil() {
+		t.Fatal("stop loss limit should be sent with trigger price", reqErr.Error())
+	}
+	if ord.Type != OrderTypeStopLossLimit || ord.StopPrice != 1900 || ord.Price != 1890 {
+		t.Error("wrong stop order", ord)
+	}
+
+	params.NewClientOrderId = "reject"
+	if _, _, reqErr = user.routeNewSpotOrder(params); !reqErr.Is(ErrSpotOrderWouldImmediatelyMatchAndTake) {
+		t.Error("rejected report should be mapped to error by code", reqErr.Error())
+	}
+	if _, _, reqErr = user.CancelSpotOrder("ETHUSDT", 1, "fix"); !reqErr.Is(cex.ErrUnknownOrder) {
+		t.Error("cancel reject should be mapped to error by code", reqErr.Error())
+	}
+	if _, _, reqErr = fix.NewFuturesOrder(user, FuturesNewOrderParams{}); !reqErr.Is(ErrOrderTransportUnavailable) {
+		t.Error("futures should fall back to rest", reqErr.Error())
+	}
+
+	params.NewClientOrderId = "close"
+	if _, _, reqErr = user.routeNewSpotOrder(params); !reqErr.Is(cex.ErrHTTPCexInnerUnknownStatus) {
+		t.Error("lost response should not fall back to rest", reqErr.Error())
+	}
+	if fix.Available(cex.PairTypeSpot) {
+		t.Error("lost session should not be available")
+	}
+
+	if err := fix.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	fix.Close()
+	if err := fix.Start(context.Background()); err != ErrFixClosed {
+		t.Error("closed fix should not be started", err)
+	}
+
+	fix = NewFixClient(NewUser("key", "secret"), FixClientOptDial(dial))
+	if err := fix.Start(context.Background()); !errors.Is(err, cex.ErrInvalidApiKey) {
+		t.Error("fix requires ed25519 key", err)
+	}
+}
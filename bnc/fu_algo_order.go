@@ -0,0 +1,239 @@
+package bnc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dwdwow/cex"
+)
+
+var ErrInvalidFuturesAlgoOrder = errors.New("bnc: invalid futures algo order")
+
+const (
+	FuturesMinCallbackRate = 0.1
+	FuturesMaxCallbackRate = 10
+)
+
+// FuturesAlgoOrder is a conditional futures order,
+// which is triggered by stop price or trailing callback.
+// It is validated by NewFuturesAlgoOrder before sending,
+// so that invalid combinations are not hand-assembled into FuturesNewOrderParams.
+type FuturesAlgoOrder struct {
+	Symbol string
+	// Type is one of OrderTypeStop, OrderTypeStopMarket, OrderTypeTakeProfit,
+	// OrderTypeTakeProfitMarket and OrderTypeTrailingStopMarket.
+	Type OrderType
+	Side cex.OrderSide
+	// Qty must be 0 if ClosePosition is true.
+	Qty float64
+	// Price is used by STOP and TAKE_PROFIT only.
+	Price float64
+	// StopPrice is used by STOP, STOP_MARKET, TAKE_PROFIT and TAKE_PROFIT_MARKET.
+	StopPrice float64
+	// ActivationPrice is used by TRAILING_STOP_MARKET, 0 means the latest price.
+	ActivationPrice float64
+	// CallbackRate is used by TRAILING_STOP_MARKET, 1 for 1%.
+	CallbackRate float64
+	// WorkingType is price triggering order, default CONTRACT_PRICE.
+	WorkingType  FuturesWorkingType
+	PriceProtect bool
+	ReduceOnly   bool
+	// ClosePosition closes all position, used by STOP_MARKET and TAKE_PROFIT_MARKET only.
+	ClosePosition bool
+}
+
+// Validate checks combinations of fields, which are rejected by binance.
+func (o FuturesAlgoOrder) Validate() error {
+	if o.Symbol == "" {
+		return fmt.Errorf("%w, empty symbol", ErrInvalidFuturesAlgoOrder)
+	}
+	switch o.Side {
+	case cex.OrderSideBuy, cex.OrderSideSell:
+	default:
+		return fmt.Errorf("%w, invalid side %q", ErrInvalidFuturesAlgoOrder, o.Side)
+	}
+	switch o.WorkingType {
+	case "", FuturesWorkingTypeMarkPrice, FuturesWorkingTypeContractPrice:
+	default:
+		return fmt.Errorf("%w, invalid working type %q", ErrInvalidFuturesAlgoOrder, o.WorkingType)
+	}
+	if o.ClosePosition {
+		if o.Type != OrderTypeStopMarket && o.Type != OrderTypeTakeProfitMarket {
+			return fmt.Errorf("%w, close position can not be used with %v", ErrInvalidFuturesAlgoOrder, o.Type)
+		}
+		if o.Qty != 0 {
+			return fmt.Errorf("%w, qty can not be sent with close position", ErrInvalidFuturesAlgoOrder)
+		}
+		if o.ReduceOnly {
+			return fmt.Errorf("%w, reduce only can not be sent with close position", ErrInvalidFuturesAlgoOrder)
+		}
+	} else if o.Qty <= 0 {
+		return fmt.Errorf("%w, invalid qty %v", ErrInvalidFuturesAlgoOrder, o.Qty)
+	}
+	switch o.Type {
+	case OrderTypeStop, OrderTypeTakeProfit:
+		if o.Price <= 0 {
+			return fmt.Errorf("%w, invalid price %v", ErrInvalidFuturesAlgoOrder, o.Price)
+		}
+		if o.StopPrice <= 0 {
+			return fmt.Errorf("%w, invalid stop price %v", ErrInvalidFuturesAlgoOrder, o.StopPrice)
+		}
+	case OrderTypeStopMarket, OrderTypeTakeProfitMarket:
+		if o.Price != 0 {
+			return fmt.Errorf("%w, price can not be sent with %v", ErrInvalidFuturesAlgoOrder, o.Type)
+		}
+		if o.StopPrice <= 0 {
+			return fmt.Errorf("%w, invalid stop price %v", ErrInvalidFuturesAlgoOrder, o.StopPrice)
+		}
+	case OrderTypeTrailingStopMarket:
+		if o.Price != 0 || o.StopPrice != 0 {
+			return fmt.Errorf("%w, price and stop price can not be sent with %v", ErrInvalidFuturesAlgoOrder, o.Type)
+		}
+		if o.CallbackRate < FuturesMinCallbackRate || o.CallbackRate > FuturesMaxCallbackRate {
+			return fmt.Errorf("%w, callback rate %v is out of [%v, %v]", ErrInvalidFuturesAlgoOrder, o.CallbackRate, FuturesMinCallbackRate, FuturesMaxCallbackRate)
+		}
+		if o.ActivationPrice < 0 {
+			return fmt.Errorf("%w, invalid activation price %v", ErrInvalidFuturesAlgoOrder, o.ActivationPrice)
+		}
+		if o.PriceProtect {
+			return fmt.Errorf("%w, price protect can not be used with %v", ErrInvalidFuturesAlgoOrder, o.Type)
+		}
+	default:
+		return fmt.Errorf("%w, unsupported order type %q", ErrInvalidFuturesAlgoOrder, o.Type)
+	}
+	if o.Type != OrderTypeTrailingStopMarket && (o.ActivationPrice != 0 || o.CallbackRate != 0) {
+		return fmt.Errorf("%w, activation price and callback rate can only be used with %v", ErrInvalidFuturesAlgoOrder, OrderTypeTrailingStopMarket)
+	}
+	return nil
+}
+
+func (o FuturesAlgoOrder) params(posSide FuturesPositionSide, cltOrdId string) FuturesNewOrderParams {
+	params := FuturesNewOrderParams{
+		Symbol:           o.Symbol,
+		PositionSide:     posSide,
+		Type:             o.Type,
+		Side:             mapStrStr(o.Side, ordSideByCexOrdSide),
+		Quantity:         o.Qty,
+		Price:            o.Price,
+		NewClientOrderId: cltOrdId,
+		ClosePosition:    o.ClosePosition,
+		StopPrice:        o.StopPrice,
+		ActivationPrice:  o.ActivationPrice,
+		CallbackRate:     o.CallbackRate,
+		WorkingType:      o.WorkingType,
+	}
+	if o.Price > 0 {
+		params.TimeInForce = TimeInForceGtc
+	}
+	if o.ReduceOnly {
+		params.ReduceOnly = SmallTrue
+	}
+	if o.PriceProtect {
+		params.PriceProtect = BigTrue
+	}
+	return params
+}
+
+// NewFuturesAlgoOrder validates and places conditional um futures order.
+// Reduce only can not be sent in hedge mode, position side of user is used instead.
+func (u *User) NewFuturesAlgoOrder(order FuturesAlgoOrder, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	if err := order.Validate(); err != nil {
+		return nil, nil, cex.RequestError{Err: err}
+	}
+	posSide := u.cfg.fuPosSide
+	if order.ReduceOnly && posSide != "" && posSide != FuturesPositionSideBoth {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("%w, reduce only can not be sent in hedge mode", ErrInvalidFuturesAlgoOrder)}
+	}
+	if errSync := u.syncFuturesMode(opts...); errSync.IsNotNil() {
+		return nil, nil, errSync
+	}
+	// close position order closes the opposite side in hedge mode, as reduce only order does
+	posSide, _ = u.futuresPosSide(order.Side, order.ReduceOnly || order.ClosePosition)
+	if posSide == FuturesPositionSideLong || posSide == FuturesPositionSideShort {
+		order.ReduceOnly = false
+	}
+	cltOrdId := u.registerNewOrd(cex.PairTypeFutures, order.Symbol, cex.OrderType(order.Type), order.Side, order.Qty, order.Price, "")
+	params := order.params(posSide, cltOrdId)
+	var resp *cex.Response
+	var rawOrd FuturesOrder
+	var err cex.RequestError
+	if u.cfg.isPortfolioMarginAccount {
+		resp, rawOrd, err = cex.Request(u, PortfolioMarginNewOrderConfig, params, opts...)
+	} else {
+		resp, rawOrd, err = u.routeNewFuturesOrder(params, opts...)
+	}
+	u.dropFuturesModeIfMismatch(err)
+	ord := SwitchFutureOrderToCexOrder(rawOrd)
+	ord.ApiKey = u.api.ApiKey
+	u.resolveNewOrd(&ord, order.Symbol, cltOrdId, resp, err)
+	return resp, &ord, err
+}
+
+// NewFuturesStopMarketOrder places STOP_MARKET order, which is triggered by stopPrice.
+// If qty is 0, it closes all position.
+func (u *User) NewFuturesStopMarketOrder(symbol string, side cex.OrderSide, qty, stopPrice float64, workingType FuturesWorkingType, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesAlgoOrder(FuturesAlgoOrder{
+		Symbol:        symbol,
+		Type:          OrderTypeStopMarket,
+		Side:          side,
+		Qty:           qty,
+		StopPrice:     stopPrice,
+		WorkingType:   workingType,
+		ClosePosition: qty == 0,
+	}, opts...)
+}
+
+// NewFuturesTakeProfitMarketOrder places TAKE_PROFIT_MARKET order, which is triggered by stopPrice.
+// If qty is 0, it closes all position.
+func (u *User) NewFuturesTakeProfitMarketOrder(symbol string, side cex.OrderSide, qty, stopPrice float64, workingType FuturesWorkingType, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesAlgoOrder(FuturesAlgoOrder{
+		Symbol:        symbol,
+		Type:          OrderTypeTakeProfitMarket,
+		Side:          side,
+		Qty:           qty,
+		StopPrice:     stopPrice,
+		WorkingType:   workingType,
+		ClosePosition: qty == 0,
+	}, opts...)
+}
+
+// NewFuturesStopOrder places STOP order, a limit order of price triggered by stopPrice.
+func (u *User) NewFuturesStopOrder(symbol string, side cex.OrderSide, qty, price, stopPrice float64, workingType FuturesWorkingType, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesAlgoOrder(FuturesAlgoOrder{
+		Symbol:      symbol,
+		Type:        OrderTypeStop,
+		Side:        side,
+		Qty:         qty,
+		Price:       price,
+		StopPrice:   stopPrice,
+		WorkingType: workingType,
+	}, opts...)
+}
+
+// NewFuturesTakeProfitOrder places TAKE_PROFIT order, a limit order of price triggered by stopPrice.
+func (u *User) NewFuturesTakeProfitOrder(symbol string, side cex.OrderSide, qty, price, stopPrice float64, workingType FuturesWorkingType, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesAlgoOrder(FuturesAlgoOrder{
+		Symbol:      symbol,
+		Type:        OrderTypeTakeProfit,
+		Side:        side,
+		Qty:         qty,
+		Price:       price,
+		StopPrice:   stopPrice,
+		WorkingType: workingType,
+	}, opts...)
+}
+
+// NewFuturesTrailingStopOrder places TRAILING_STOP_MARKET order,
+// callbackRate is in [FuturesMinCallbackRate, FuturesMaxCallbackRate], 1 for 1%,
+// activationPrice 0 means the latest price.
+func (u *User) NewFuturesTrailingStopOrder(symbol string, side cex.OrderSide, qty, activationPrice, callbackRate float64, workingType FuturesWorkingType, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesAlgoOrder(FuturesAlgoOrder{
+		Symbol:          symbol,
+		Type:            OrderTypeTrailingStopMarket,
+		Side:            side,
+		Qty:             qty,
+		ActivationPrice: activationPrice,
+		CallbackRate:    callbackRate,
+		WorkingType:     workingType,
+	}, opts...)
+}
@@ -0,0 +1,107 @@
+package bnc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestFuturesAlgoOrder_Validate(t *testing.T) {
+	valid := []FuturesAlgoOrder{
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, Qty: 1, StopPrice: 1000},
+		{Symbol: "ETHUSDT", Type: OrderTypeTakeProfitMarket, Side: cex.OrderSideSell, StopPrice: 3000, ClosePosition: true, WorkingType: FuturesWorkingTypeMarkPrice},
+		{Symbol: "ETHUSDT", Type: OrderTypeStop, Side: cex.OrderSideBuy, Qty: 1, Price: 2000, StopPrice: 1990, PriceProtect: true},
+		{Symbol: "ETHUSDT", Type: OrderTypeTrailingStopMarket, Side: cex.OrderSideSell, Qty: 1, CallbackRate: 0.1, ReduceOnly: true},
+	}
+	for _, o := range valid {
+		if err := o.Validate(); err != nil {
+			t.Error("should be valid", o, err)
+		}
+	}
+	invalid := []FuturesAlgoOrder{
+		{Type: OrderTypeStopMarket, Side: cex.OrderSideSell, Qty: 1, StopPrice: 1000},
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Qty: 1, StopPrice: 1000},
+		{Symbol: "ETHUSDT", Type: OrderTypeLimit, Side: cex.OrderSideSell, Qty: 1, Price: 1000},
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, Qty: 1},
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, StopPrice: 1000},
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, Qty: 1, Price: 1000, StopPrice: 1000},
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, Qty: 1, StopPrice: 1000, WorkingType: "LAST_PRICE"},
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, Qty: 1, StopPrice: 1000, ClosePosition: true},
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, StopPrice: 1000, ClosePosition: true, ReduceOnly: true},
+		{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, Qty: 1, StopPrice: 1000, CallbackRate: 1},
+		{Symbol: "ETHUSDT", Type: OrderTypeStop, Side: cex.OrderSideSell, Qty: 1, StopPrice: 1000},
+		{Symbol: "ETHUSDT", Type: OrderTypeStop, Side: cex.OrderSideSell, Price: 1000, StopPrice: 1000, ClosePosition: true},
+		{Symbol: "ETHUSDT", Type: OrderTypeTrailingStopMarket, Side: cex.OrderSideSell, Qty: 1, CallbackRate: 0.05},
+		{Symbol: "ETHUSDT", Type: OrderTypeTrailingStopMarket, Side: cex.OrderSideSell, Qty: 1, CallbackRate: 11},
+		{Symbol: "ETHUSDT", Type: OrderTypeTrailingStopMarket, Side: cex.OrderSideSell, Qty: 1, CallbackRate: 1, StopPrice: 1000},
+		{Symbol: "ETHUSDT", Type: OrderTypeTrailingStopMarket, Side: cex.OrderSideSell, Qty: 1, CallbackRate: 1, PriceProtect: true},
+	}
+	for _, o := range invalid {
+		if err := o.Validate(); !errors.Is(err, ErrInvalidFuturesAlgoOrder) {
+			t.Error("should be invalid", o, err)
+		}
+	}
+}
+
+func TestUser_NewFuturesAlgoOrder(t *testing.T) {
+	var query url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		query = r.URL.Query()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"symbol":"ETHUSDT","orderId":123,"status":"NEW","type":"TRAILING_STOP_MARKET","side":"SELL","origQty":"1","price":"0","activatePrice":"2000","priceRate":"1.5"}`)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	_, ord, err := user.NewFuturesTrailingStopOrder("ETHUSDT", cex.OrderSideSell, 1, 2000, 1.5, FuturesWorkingTypeMarkPrice)
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	for k, v := range map[string]string{
+		"symbol":          "ETHUSDT",
+		"type":            "TRAILING_STOP_MARKET",
+		"side":            "SELL",
+		"quantity":        "1",
+		"activationPrice": "2000",
+		"callbackRate":    "1.5",
+		"workingType":     "MARK_PRICE",
+	} {
+		if query.Get(k) != v {
+			t.Error("wrong param", k, query.Get(k), v)
+		}
+	}
+	if query.Get("stopPrice") != "" || query.Get("timeInForce") != "" {
+		t.Error("unexpected params", query)
+	}
+	if ord.OrderId != "123" || !user.ClientOrderIds().Owns(query.Get("newClientOrderId")) {
+		t.Error("wrong order", ord.OrderId, query.Get("newClientOrderId"))
+	}
+
+	_, _, err = user.NewFuturesStopMarketOrder("ETHUSDT", cex.OrderSideSell, 0, 1000, "")
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if query.Get("closePosition") != "true" || query.Get("quantity") != "" || query.Get("stopPrice") != "1000" {
+		t.Error("wrong close position params", query)
+	}
+
+	query = nil
+	_, _, err = user.NewFuturesTrailingStopOrder("ETHUSDT", cex.OrderSideSell, 1, 0, 20, "")
+	if !errors.Is(err.Err, ErrInvalidFuturesAlgoOrder) || query != nil {
+		t.Error("invalid order should not be sent", err.Err, query)
+	}
+
+	hedgeUser := NewUser("key", "secret", UserOptTransport(transport), UserOptPositionSide(FuturesPositionSideLong))
+	_, _, err = hedgeUser.NewFuturesAlgoOrder(FuturesAlgoOrder{Symbol: "ETHUSDT", Type: OrderTypeStopMarket, Side: cex.OrderSideSell, Qty: 1, StopPrice: 1000, ReduceOnly: true})
+	if !errors.Is(err.Err, ErrInvalidFuturesAlgoOrder) || query != nil {
+		t.Error("reduce only should not be sent in hedge mode", err.Err, query)
+	}
+}
@@ -0,0 +1,280 @@
+package bnc
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Funding Tracker
+// ------------------------------------------------------------
+
+// fundingRatesMaxTimeWindow is 1000 hours, so one window is one page of 1h funding interval.
+const fundingRatesMaxTimeWindow = 1000 * 60 * 60 * 1000
+
+// fundingTimeTolerance is max difference between funding fee income time and funding time, unit is millisecond.
+const fundingTimeTolerance = 60 * 1000
+
+// FundingRateHistoriesBetween pages through funding rate histories of symbol between startTime(ms) and endTime(ms).
+// Zero endTime means now.
+func (u *User) FundingRateHistoriesBetween(symbol string, startTime, endTime int64, opts ...cex.CltOpt) ([]FuturesFundingRateHistory, cex.RequestError) {
+	return walkByTime(startTime, endTime, fundingRatesMaxTimeWindow,
+		func(start, end int64) ([]FuturesFundingRateHistory, cex.RequestError) {
+			_, rates, err := cex.Request(u, FuturesFundingRateHistoriesConfig, FuturesFundingRateHistoriesParams{Symbol: symbol, StartTime: start, EndTime: end, Limit: historyWalkLimit}, opts...)
+			return rates, err
+		},
+		func(r FuturesFundingRateHistory) (int64, int64) { return r.FundingTime, r.FundingTime },
+	)
+}
+
+// FundingPayment is funding fee income attributed to position held at funding time.
+type FundingPayment struct {
+	Symbol string `json:"symbol" bson:"symbol"`
+	Asset  string `json:"asset" bson:"asset"`
+	// Income is negative, if funding fee is paid.
+	Income float64 `json:"income" bson:"income"`
+	Time   int64   `json:"time" bson:"time"`
+	TranId int64   `json:"tranId" bson:"tranId"`
+
+	// Fields below are 0, if no funding rate matches income.
+	FundingTime int64   `json:"fundingTime" bson:"fundingTime"`
+	FundingRate float64 `json:"fundingRate" bson:"fundingRate"`
+	MarkPrice   float64 `json:"markPrice" bson:"markPrice"`
+	// PositionAmt is signed position implied by income, rate and mark price, long: > 0, short: < 0.
+	// It is 0, if mark price or funding rate is 0.
+	PositionAmt float64 `json:"positionAmt" bson:"positionAmt"`
+}
+
+// AttributeFundingPayments matches FUNDING_FEE incomes with funding rates of the same symbol,
+// whose funding time is the nearest within one minute, and infers positions held.
+// Incomes of other types are ignored. Payments are sorted by time.
+func AttributeFundingPayments(incomes []FuturesIncome, rates []FuturesFundingRateHistory) []FundingPayment {
+	ratesBySymbol := map[string][]FuturesFundingRateHistory{}
+	for _, r := range rates {
+		ratesBySymbol[r.Symbol] = append(ratesBySymbol[r.Symbol], r)
+	}
+	for _, rs := range ratesBySymbol {
+		sort.Slice(rs, func(i, j int) bool { return rs[i].FundingTime < rs[j].FundingTime })
+	}
+	var payments []FundingPayment
+	for _, in := range incomes {
+		if in.IncomeType != FuturesIncomeTypeFundingFee {
+			continue
+		}
+		p := FundingPayment{Symbol: in.Symbol, Asset: in.Asset, Income: in.Income, Time: in.Time, TranId: in.TranId}
+		if r, ok := nearestFundingRate(ratesBySymbol[in.Symbol], in.Time); ok {
+			p.FundingTime, p.FundingRate = r.FundingTime, r.FundingRate
+			// mark price may be empty string
+			p.MarkPrice, _ = strconv.ParseFloat(r.MarkPrice, 64)
+			if p.MarkPrice != 0 && p.FundingRate != 0 {
+				// long pays positive rate, income = -positionAmt * markPrice * rate
+				p.PositionAmt = -p.Income / (p.MarkPrice * p.FundingRate)
+			}
+		}
+		payments = append(payments, p)
+	}
+	sort.Slice(payments, func(i, j int) bool { return payments[i].Time < payments[j].Time })
+	return payments
+}
+
+func nearestFundingRate(rates []FuturesFundingRateHistory, t int64) (FuturesFundingRateHistory, bool) {
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].FundingTime >= t })
+	var best FuturesFundingRateHistory
+	var ok bool
+	for _, j := range []int{i - 1, i} {
+		if j < 0 || j >= len(rates) {
+			continue
+		}
+		d := absInt64(rates[j].FundingTime - t)
+		if d <= fundingTimeTolerance && (!ok || d < absInt64(best.FundingTime-t)) {
+			best, ok = rates[j], true
+		}
+	}
+	return best, ok
+}
+
+func absInt64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// FundingSummary is total funding fee of symbol and asset.
+type FundingSummary struct {
+	Symbol   string  `json:"symbol" bson:"symbol"`
+	Asset    string  `json:"asset" bson:"asset"`
+	Paid     float64 `json:"paid" bson:"paid"` // positive
+	Received float64 `json:"received" bson:"received"`
+	Net      float64 `json:"net" bson:"net"`
+	Count    int     `json:"count" bson:"count"`
+}
+
+// SummarizeFundingPayments sums payments by symbol and asset, sorted by symbol and asset.
+func SummarizeFundingPayments(payments []FundingPayment) []FundingSummary {
+	type key struct{ symbol, asset string }
+	sums := map[key]*FundingSummary{}
+	for _, p := range payments {
+		k := key{p.Symbol, p.Asset}
+		s, ok := sums[k]
+		if !ok {
+			s = &FundingSummary{Symbol: p.Symbol, Asset: p.Asset}
+			sums[k] = s
+		}
+		if p.Income < 0 {
+			s.Paid -= p.Income
+		} else {
+			s.Received += p.Income
+		}
+		s.Net += p.Income
+		s.Count++
+	}
+	summaries := make([]FundingSummary, 0, len(sums))
+	for _, s := range sums {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Symbol != summaries[j].Symbol {
+			return summaries[i].Symbol < summaries[j].Symbol
+		}
+		return summaries[i].Asset < summaries[j].Asset
+	})
+	return summaries
+}
+
+// FundingProjection is projected funding fee of position at next funding time.
+type FundingProjection struct {
+	Symbol          string  `json:"symbol" bson:"symbol"`
+	PositionSide    string  `json:"positionSide" bson:"positionSide"`
+	PositionAmt     float64 `json:"positionAmt" bson:"positionAmt"`
+	MarkPrice       float64 `json:"markPrice" bson:"markPrice"`
+	FundingRate     float64 `json:"fundingRate" bson:"fundingRate"`
+	NextFundingTime int64   `json:"nextFundingTime" bson:"nextFundingTime"`
+	// Income is negative, if funding fee will be paid.
+	Income float64 `json:"income" bson:"income"`
+}
+
+// ProjectFunding projects funding fee of positions at next funding time by predicted rates,
+// i.e. lastFundingRate of premium index.
+// Positions without rate and zero positions are ignored.
+func ProjectFunding(positions []FuturesPosition, rates []FuturesFundingRate) []FundingProjection {
+	rateBySymbol := make(map[string]FuturesFundingRate, len(rates))
+	for _, r := range rates {
+		rateBySymbol[r.Symbol] = r
+	}
+	var projections []FundingProjection
+	for _, p := range positions {
+		r, ok := rateBySymbol[p.Symbol]
+		if !ok || p.SignPositionAmt == 0 {
+			continue
+		}
+		markPrice := r.MarkPrice
+		if markPrice == 0 {
+			markPrice = p.MarkPrice
+		}
+		projections = append(projections, FundingProjection{
+			Symbol:          p.Symbol,
+			PositionSide:    p.PositionSide,
+			PositionAmt:     p.SignPositionAmt,
+			MarkPrice:       markPrice,
+			FundingRate:     r.LastFundingRate,
+			NextFundingTime: r.NextFundingTime,
+			Income:          -p.SignPositionAmt * markPrice * r.LastFundingRate,
+		})
+	}
+	sort.Slice(projections, func(i, j int) bool {
+		if projections[i].Symbol != projections[j].Symbol {
+			return projections[i].Symbol < projections[j].Symbol
+		}
+		return projections[i].PositionSide < projections[j].PositionSide
+	})
+	return projections
+}
+
+// FundingTracker pulls funding fee incomes and funding rate histories of usd-m perpetuals,
+// attributes payments to positions held, and projects upcoming funding fee of current positions.
+// Portfolio margin account is not supported.
+//
+//	t := NewFundingTracker(user)
+//	err := t.Sync(since, 0)
+//	summaries := t.Summaries()
+//	_, projections, err := t.Project()
+type FundingTracker struct {
+	user *User
+
+	mux      sync.RWMutex
+	payments map[int64]FundingPayment
+}
+
+func NewFundingTracker(user *User) *FundingTracker {
+	return &FundingTracker{user: user, payments: map[int64]FundingPayment{}}
+}
+
+// Sync pulls funding fee incomes between startTime(ms) and endTime(ms),
+// and funding rates of symbols of incomes, then caches attributed payments by tranId.
+// Zero endTime means now.
+func (t *FundingTracker) Sync(startTime, endTime int64, opts ...cex.CltOpt) cex.RequestError {
+	incomes, err := t.user.FuturesIncomesBetween("", FuturesIncomeTypeFundingFee, startTime, endTime, opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	symbols := map[string]bool{}
+	for _, in := range incomes {
+		symbols[in.Symbol] = true
+	}
+	var rates []FuturesFundingRateHistory
+	for symbol := range symbols {
+		// funding time may be a little earlier than income time
+		rs, err := t.user.FundingRateHistoriesBetween(symbol, startTime-fundingTimeTolerance, endTime, opts...)
+		if err.IsNotNil() {
+			return err
+		}
+		rates = append(rates, rs...)
+	}
+	payments := AttributeFundingPayments(incomes, rates)
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	for _, p := range payments {
+		t.payments[p.TranId] = p
+	}
+	return cex.RequestError{}
+}
+
+// Payments returns cached payments of symbol sorted by time, empty symbol means all.
+func (t *FundingTracker) Payments(symbol string) []FundingPayment {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	var payments []FundingPayment
+	for _, p := range t.payments {
+		if symbol == "" || p.Symbol == symbol {
+			payments = append(payments, p)
+		}
+	}
+	sort.Slice(payments, func(i, j int) bool { return payments[i].Time < payments[j].Time })
+	return payments
+}
+
+// Summaries sums cached payments by symbol and asset.
+func (t *FundingTracker) Summaries() []FundingSummary {
+	return SummarizeFundingPayments(t.Payments(""))
+}
+
+// Project queries current positions and predicted funding rates, and projects funding fee at next funding time.
+func (t *FundingTracker) Project(opts ...cex.CltOpt) (*cex.Response, []FundingProjection, cex.RequestError) {
+	resp, positions, err := t.user.FuturesPositions("", opts...)
+	if err.IsNotNil() {
+		return resp, nil, err
+	}
+	resp, rates, err := cex.Request(t.user, FuturesFundingRatesConfig, FuturesFundingRatesParams{}, opts...)
+	if err.IsNotNil() {
+		return resp, nil, err
+	}
+	return resp, ProjectFunding(positions, rates), cex.RequestError{}
+}
+
+// ------------------------------------------------------------
+// Funding Tracker
+// ============================================================
@@ -0,0 +1,66 @@
+package bnc
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAttributeFundingPayments(t *testing.T) {
+	incomes := []FuturesIncome{
+		{Symbol: "ETHUSDT", IncomeType: FuturesIncomeTypeFundingFee, Income: -2, Asset: "USDT", Time: 28800005, TranId: 2},
+		{Symbol: "ETHUSDT", IncomeType: FuturesIncomeTypeFundingFee, Income: 1, Asset: "USDT", Time: 5, TranId: 1},
+		{Symbol: "ETHUSDT", IncomeType: FuturesIncomeTypeCommission, Income: -1, Asset: "USDT", Time: 10, TranId: 3},
+		{Symbol: "BTCUSDT", IncomeType: FuturesIncomeTypeFundingFee, Income: 3, Asset: "USDT", Time: 5, TranId: 4},
+	}
+	rates := []FuturesFundingRateHistory{
+		{Symbol: "ETHUSDT", FundingTime: 28800000, FundingRate: 0.0001, MarkPrice: "2000"},
+		{Symbol: "ETHUSDT", FundingTime: 0, FundingRate: -0.0001, MarkPrice: "1000"},
+	}
+	payments := AttributeFundingPayments(incomes, rates)
+	if len(payments) != 3 {
+		t.Fatal("wrong payments", payments)
+	}
+	// short 10 receives 1 at negative rate
+	if payments[0].TranId != 1 || payments[0].FundingTime != 0 || math.Abs(payments[0].PositionAmt-10) > 1e-9 {
+		t.Error("wrong first payment", payments[0])
+	}
+	// long 10 pays 2 at positive rate
+	if payments[2].TranId != 2 || payments[2].FundingTime != 28800000 || math.Abs(payments[2].PositionAmt-10) > 1e-9 {
+		t.Error("wrong last payment", payments[2])
+	}
+	if p := payments[1]; p.Symbol != "BTCUSDT" || p.FundingRate != 0 || p.PositionAmt != 0 {
+		t.Error("payment without rate should not be attributed", p)
+	}
+
+	summaries := SummarizeFundingPayments(payments)
+	if len(summaries) != 2 || summaries[1].Symbol != "ETHUSDT" || summaries[1].Paid != 2 || summaries[1].Received != 1 || summaries[1].Net != -1 || summaries[1].Count != 2 {
+		t.Error("wrong summaries", summaries)
+	}
+}
+
+func TestFundingTracker_Project(t *testing.T) {
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := `[{"symbol":"ETHUSDT","markPrice":"2000","lastFundingRate":"0.0001","nextFundingTime":28800000}]`
+		if r.URL.Path == "/fapi/v2/positionRisk" {
+			body = `[{"symbol":"ETHUSDT","positionSide":"BOTH","positionAmt":"-5","markPrice":"1999"},{"symbol":"BTCUSDT","positionSide":"BOTH","positionAmt":"0"}]`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	tracker := NewFundingTracker(NewUser("key", "secret", UserOptTransport(transport)))
+	_, projections, err := tracker.Project()
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	// short receives positive rate
+	if len(projections) != 1 || projections[0].Income != 1 || projections[0].MarkPrice != 2000 || projections[0].NextFundingTime != 28800000 {
+		t.Error("wrong projections", projections)
+	}
+}
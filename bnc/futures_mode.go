@@ -0,0 +1,259 @@
+package bnc
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/dwdwow/cex"
+)
+
+// FuturesAccountMode is position mode and multi-assets mode of usd-m futures account.
+type FuturesAccountMode struct {
+	// DualSidePosition is true in hedge mode, and false in one-way mode.
+	DualSidePosition  bool `json:"dualSidePosition" bson:"dualSidePosition"`
+	MultiAssetsMargin bool `json:"multiAssetsMargin" bson:"multiAssetsMargin"`
+}
+
+// futuresModeSync queries, or changes, futures account mode lazily on first futures order, and caches it.
+type futuresModeSync struct {
+	mux sync.Mutex
+	// dualSidePosition and multiAssetsMargin are wanted modes, nil means keeping current mode
+	dualSidePosition  *bool
+	multiAssetsMargin *bool
+	// mode is nil, if it is not synced
+	mode *FuturesAccountMode
+}
+
+func (u *User) futuresModeSync() *futuresModeSync {
+	if u.cfg.fuModeSync == nil {
+		u.cfg.fuModeSync = &futuresModeSync{}
+	}
+	return u.cfg.fuModeSync
+}
+
+// UserOptSyncFuturesAccountMode makes user query futures account mode on first futures order, and cache it.
+// Position side of orders is decided by cached mode, if it is not set by UserOptPositionSide,
+// BUY opens LONG and SELL opens SHORT in hedge mode, and reduce only orders close the opposite side.
+// Cached mode is dropped, if order is rejected by ErrFuturePositionSideMismatch,
+// so mode changed by others is synced again on next order.
+// Portfolio margin account is not supported.
+func UserOptSyncFuturesAccountMode() UserOpt {
+	return func(user *User) {
+		user.futuresModeSync()
+	}
+}
+
+// UserOptFuturesPositionMode makes user change position mode to dualSidePosition on first futures order,
+// if current mode is different, see UserOptSyncFuturesAccountMode.
+// Binance rejects changing position mode, if there are positions or open orders.
+func UserOptFuturesPositionMode(dualSidePosition bool) UserOpt {
+	return func(user *User) {
+		user.futuresModeSync().dualSidePosition = &dualSidePosition
+	}
+}
+
+// UserOptFuturesMultiAssetsMode makes user change multi-assets mode on first futures order,
+// if current mode is different, see UserOptSyncFuturesAccountMode.
+func UserOptFuturesMultiAssetsMode(multiAssetsMargin bool) UserOpt {
+	return func(user *User) {
+		user.futuresModeSync().multiAssetsMargin = &multiAssetsMargin
+	}
+}
+
+func (u *User) ChangeFuturesPositionMode(dualSidePosition bool, opts ...cex.CltOpt) cex.RequestError {
+	_, _, err := cex.Request(u, FuturesChangePositionModeConfig, FuturesChangePositionModParams{DualSidePosition: smallBool(dualSidePosition)}, opts...)
+	if err.IsNil() {
+		u.setCachedFuturesMode(func(mode *FuturesAccountMode) { mode.DualSidePosition = dualSidePosition })
+	}
+	return err
+}
+
+func (u *User) ChangeFuturesMultiAssetsMode(multiAssetsMargin bool, opts ...cex.CltOpt) cex.RequestError {
+	_, _, err := cex.Request(u, FuturesChangeMultiAssetsModeConfig, FuturesChangeMultiAssetsModeParams{MultiAssetsMargin: smallBool(multiAssetsMargin)}, opts...)
+	if err.IsNil() {
+		u.setCachedFuturesMode(func(mode *FuturesAccountMode) { mode.MultiAssetsMargin = multiAssetsMargin })
+	}
+	return err
+}
+
+func smallBool(b bool) SmallBool {
+	if b {
+		return SmallTrue
+	}
+	return SmallFalse
+}
+
+func (u *User) setCachedFuturesMode(set func(mode *FuturesAccountMode)) {
+	s := u.cfg.fuModeSync
+	if s == nil {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.mode != nil {
+		set(s.mode)
+	}
+}
+
+// QueryFuturesAccountMode queries position mode and multi-assets mode, and caches them,
+// if futures account mode is synced by user.
+func (u *User) QueryFuturesAccountMode(opts ...cex.CltOpt) (FuturesAccountMode, cex.RequestError) {
+	var mode FuturesAccountMode
+	_, posMode, err := cex.Request(u, FuturesPositionModeConfig, nil, opts...)
+	if err.IsNotNil() {
+		return mode, err
+	}
+	_, assetsMode, err := cex.Request(u, FuturesCurrentMultiAssetsModeConfig, nil, opts...)
+	if err.IsNotNil() {
+		return mode, err
+	}
+	mode = FuturesAccountMode{DualSidePosition: posMode.DualSidePosition, MultiAssetsMargin: assetsMode.MultiAssetsMargin}
+	if s := u.cfg.fuModeSync; s != nil {
+		s.mux.Lock()
+		s.mode = &mode
+		s.mux.Unlock()
+	}
+	return mode, cex.RequestError{}
+}
+
+// FuturesAccountMode returns cached futures account mode,
+// ok is false, if futures account mode is not synced yet, or not synced by user.
+func (u *User) FuturesAccountMode() (mode FuturesAccountMode, ok bool) {
+	s := u.cfg.fuModeSync
+	if s == nil {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.mode == nil {
+		return
+	}
+	return *s.mode, true
+}
+
+// syncFuturesMode queries futures account mode, and changes it to wanted mode, if it is not synced.
+// It does nothing, if futures account mode is not synced by user.
+// Concurrent orders wait for the first sync.
+func (u *User) syncFuturesMode(opts ...cex.CltOpt) cex.RequestError {
+	s := u.cfg.fuModeSync
+	if s == nil || u.cfg.isPortfolioMarginAccount {
+		return cex.RequestError{}
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.mode != nil {
+		return cex.RequestError{}
+	}
+	_, posMode, err := cex.Request(u, FuturesPositionModeConfig, nil, opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	mode := FuturesAccountMode{DualSidePosition: posMode.DualSidePosition}
+	if want := s.dualSidePosition; want != nil && *want != mode.DualSidePosition {
+		_, _, err = cex.Request(u, FuturesChangePositionModeConfig, FuturesChangePositionModParams{DualSidePosition: smallBool(*want)}, opts...)
+		if err.IsNotNil() && !errors.Is(&err, ErrFutureNoNeedToChangePositionSide) {
+			return err
+		}
+		mode.DualSidePosition = *want
+	}
+	_, assetsMode, err := cex.Request(u, FuturesCurrentMultiAssetsModeConfig, nil, opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	mode.MultiAssetsMargin = assetsMode.MultiAssetsMargin
+	if want := s.multiAssetsMargin; want != nil && *want != mode.MultiAssetsMargin {
+		_, _, err = cex.Request(u, FuturesChangeMultiAssetsModeConfig, FuturesChangeMultiAssetsModeParams{MultiAssetsMargin: smallBool(*want)}, opts...)
+		if err.IsNotNil() {
+			return err
+		}
+		mode.MultiAssetsMargin = *want
+	}
+	s.mode = &mode
+	return cex.RequestError{}
+}
+
+// dropFuturesModeIfMismatch drops cached futures account mode,
+// if order is rejected because position side does not match position mode.
+func (u *User) dropFuturesModeIfMismatch(err cex.RequestError) {
+	s := u.cfg.fuModeSync
+	if s == nil || !errors.Is(&err, ErrFuturePositionSideMismatch) {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.mode = nil
+}
+
+// futuresPosSide returns position side of new futures order, and whether reduce only can be sent.
+// Position side set by UserOptPositionSide is used as it is.
+// Otherwise, in synced hedge mode, BUY is LONG and SELL is SHORT,
+// and reduce only order closes the opposite side without reduce only flag,
+// which can not be sent in hedge mode.
+func (u *User) futuresPosSide(orderSide cex.OrderSide, reduceOnly bool) (FuturesPositionSide, bool) {
+	if u.cfg.fuPosSide != "" {
+		return u.cfg.fuPosSide, reduceOnly
+	}
+	mode, ok := u.FuturesAccountMode()
+	if !ok || !mode.DualSidePosition {
+		return "", reduceOnly
+	}
+	isLong := orderSide == cex.OrderSideBuy
+	if reduceOnly {
+		isLong = !isLong
+	}
+	if isLong {
+		return FuturesPositionSideLong, false
+	}
+	return FuturesPositionSideShort, false
+}
+
+// hedgePosSide returns position side of order, which opens or closes posSide, and whether reduce only is sent.
+// In hedge mode, posSide is sent without reduce only flag.
+// In one-way mode, position side is not sent, or BOTH if it is set by UserOptPositionSide,
+// and closing order is reduce only.
+// Mode is cached mode, or implied by UserOptPositionSide, otherwise it is queried.
+func (u *User) hedgePosSide(posSide FuturesPositionSide, isClose bool, opts ...cex.CltOpt) (FuturesPositionSide, bool, cex.RequestError) {
+	var dualSide bool
+	if mode, ok := u.FuturesAccountMode(); ok {
+		dualSide = mode.DualSidePosition
+	} else if u.cfg.fuPosSide != "" {
+		dualSide = u.cfg.fuPosSide != FuturesPositionSideBoth
+	} else {
+		_, posMode, err := cex.Request(u, FuturesPositionModeConfig, nil, opts...)
+		if err.IsNotNil() {
+			return "", false, err
+		}
+		dualSide = posMode.DualSidePosition
+	}
+	if dualSide {
+		return posSide, false, cex.RequestError{}
+	}
+	return u.cfg.fuPosSide, isClose, cex.RequestError{}
+}
+
+// OpenLong places usd-m futures BUY order, which opens or increases long position,
+// it sets position side to LONG in hedge mode.
+// Futures account mode should be synced by UserOptSyncFuturesAccountMode, or position side should be set by UserOptPositionSide,
+// otherwise position mode is queried for every order.
+// Portfolio margin account should set position side by UserOptPositionSide.
+func (u *User) OpenLong(asset, quote string, orderType cex.OrderType, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newFuOrd(true, asset, quote, orderType, cex.OrderSideBuy, qty, price, newOrdOpts{positionSide: FuturesPositionSideLong}, opts...)
+}
+
+// CloseLong places usd-m futures SELL order, which reduces long position,
+// it sets position side to LONG in hedge mode, and reduce only in one-way mode, see OpenLong.
+func (u *User) CloseLong(asset, quote string, orderType cex.OrderType, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newFuOrd(true, asset, quote, orderType, cex.OrderSideSell, qty, price, newOrdOpts{positionSide: FuturesPositionSideLong, reduceOnly: true}, opts...)
+}
+
+// OpenShort places usd-m futures SELL order, which opens or increases short position,
+// it sets position side to SHORT in hedge mode, see OpenLong.
+func (u *User) OpenShort(asset, quote string, orderType cex.OrderType, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newFuOrd(true, asset, quote, orderType, cex.OrderSideSell, qty, price, newOrdOpts{positionSide: FuturesPositionSideShort}, opts...)
+}
+
+// CloseShort places usd-m futures BUY order, which reduces short position,
+// it sets position side to SHORT in hedge mode, and reduce only in one-way mode, see OpenLong.
+func (u *User) CloseShort(asset, quote string, orderType cex.OrderType, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newFuOrd(true, asset, quote, orderType, cex.OrderSideBuy, qty, price, newOrdOpts{positionSide: FuturesPositionSideShort, reduceOnly: true}, opts...)
+}
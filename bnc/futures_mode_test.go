@@ -0,0 +1,165 @@
+package bnc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestUser_SyncFuturesAccountMode(t *testing.T) {
+	var calls []string
+	var orderQuery url.Values
+	rejectOrder := false
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		status, body := http.StatusOK, `{"code":200,"msg":"success"}`
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/fapi/v1/positionSide/dual":
+			body = `{"dualSidePosition":false}`
+		case r.Method == http.MethodGet && r.URL.Path == "/fapi/v1/multiAssetsMargin":
+			body = `{"multiAssetsMargin":false}`
+		case r.URL.Path == "/fapi/v1/order":
+			orderQuery = r.URL.Query()
+			body = `{"symbol":"ETHUSDT","orderId":123,"status":"NEW"}`
+			if rejectOrder {
+				status, body = http.StatusBadRequest, `{"code":-4061,"msg":"Order's position side does not match user's setting."}`
+			}
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport), UserOptFuturesPositionMode(true))
+
+	if _, ok := user.FuturesAccountMode(); ok {
+		t.Error("mode should not be synced before first futures order")
+	}
+	_, _, err := user.NewFuturesLimitBuyOrder("ETH", "USDT", 0.5, 1800)
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	want := []string{
+		"GET /fapi/v1/positionSide/dual",
+		"POST /fapi/v1/positionSide/dual",
+		"GET /fapi/v1/multiAssetsMargin",
+		"POST /fapi/v1/order",
+	}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Error("wrong calls", calls)
+	}
+	mode, ok := user.FuturesAccountMode()
+	if !ok || !mode.DualSidePosition || mode.MultiAssetsMargin {
+		t.Error("wrong mode", mode, ok)
+	}
+	if orderQuery.Get("positionSide") != "LONG" {
+		t.Error("buy should open long in hedge mode", orderQuery)
+	}
+
+	calls = nil
+	_, _, err = user.Order("ETH", "USDT").Futures().Market().Sell().Qty(0.5).ReduceOnly().Send()
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if len(calls) != 1 {
+		t.Error("mode should be cached", calls)
+	}
+	if orderQuery.Get("positionSide") != "LONG" || orderQuery.Get("reduceOnly") != "" {
+		t.Error("reduce only sell should close long without reduce only flag", orderQuery)
+	}
+
+	rejectOrder = true
+	_, _, err = user.NewFuturesMarketSellOrder("ETH", "USDT", 0.5)
+	if !errors.Is(&err, ErrFuturePositionSideMismatch) {
+		t.Error("should be position side mismatch", err.Err)
+	}
+	if _, ok := user.FuturesAccountMode(); ok {
+		t.Error("mode should be dropped after mismatch")
+	}
+
+	oneWay := NewUser("key", "secret", UserOptTransport(transport), UserOptSyncFuturesAccountMode())
+	rejectOrder = false
+	calls = nil
+	_, _, err = oneWay.NewFuturesMarketSellOrder("ETH", "USDT", 0.5)
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if len(calls) != 3 || orderQuery.Get("positionSide") != "" {
+		t.Error("one-way mode should only be queried", calls, orderQuery)
+	}
+}
+
+func TestUser_HedgeModeHelpers(t *testing.T) {
+	dualSide := true
+	var calls []string
+	var orderQuery url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		body := `{"symbol":"ETHUSDT","orderId":123,"status":"NEW"}`
+		switch r.URL.Path {
+		case "/fapi/v1/positionSide/dual":
+			body = fmt.Sprintf(`{"dualSidePosition":%v}`, dualSide)
+		case "/fapi/v1/multiAssetsMargin":
+			body = `{"multiAssetsMargin":false}`
+		case "/fapi/v1/order":
+			orderQuery = r.URL.Query()
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	tests := []struct {
+		name                  string
+		user                  *User
+		dualSide              bool
+		wantPosSide, wantSide string
+		wantReduceOnly        string
+		wantCalls             int
+	}{
+		{"hedge", NewUser("key", "secret", UserOptTransport(transport)), true, "LONG", "SELL", "", 2},
+		{"one-way", NewUser("key", "secret", UserOptTransport(transport)), false, "", "SELL", "true", 2},
+		{"synced hedge", NewUser("key", "secret", UserOptTransport(transport), UserOptSyncFuturesAccountMode()), true, "LONG", "SELL", "", 1},
+		{"both side", NewUser("key", "secret", UserOptTransport(transport), UserOptSetPositionBothSide()), true, "BOTH", "SELL", "true", 1},
+	}
+	for _, tt := range tests {
+		dualSide = tt.dualSide
+		calls = nil
+		// the first order syncs mode, if it is synced by user
+		if _, _, err := tt.user.OpenLong("ETH", "USDT", cex.OrderTypeMarket, 0.5, 0); err.IsNotNil() {
+			t.Fatal(tt.name, err.Err)
+		}
+		if orderQuery.Get("side") != "BUY" || orderQuery.Get("positionSide") != tt.wantPosSide || orderQuery.Has("reduceOnly") {
+			t.Error(tt.name, "wrong open long", orderQuery)
+		}
+		calls = nil
+		if _, _, err := tt.user.CloseLong("ETH", "USDT", cex.OrderTypeMarket, 0.5, 0); err.IsNotNil() {
+			t.Fatal(tt.name, err.Err)
+		}
+		if orderQuery.Get("side") != tt.wantSide || orderQuery.Get("positionSide") != tt.wantPosSide || orderQuery.Get("reduceOnly") != tt.wantReduceOnly {
+			t.Error(tt.name, "wrong close long", orderQuery)
+		}
+		if len(calls) != tt.wantCalls {
+			t.Error(tt.name, "wrong calls", calls)
+		}
+	}
+
+	dualSide = true
+	user := NewUser("key", "secret", UserOptTransport(transport), UserOptSyncFuturesAccountMode())
+	if _, _, err := user.OpenShort("ETH", "USDT", cex.OrderTypeLimit, 0.5, 2000); err.IsNotNil() || orderQuery.Get("side") != "SELL" || orderQuery.Get("positionSide") != "SHORT" {
+		t.Error("wrong open short", orderQuery, err.Err)
+	}
+	if _, _, err := user.CloseShort("ETH", "USDT", cex.OrderTypeLimit, 0.5, 1900); err.IsNotNil() || orderQuery.Get("side") != "BUY" || orderQuery.Get("positionSide") != "SHORT" || orderQuery.Has("reduceOnly") {
+		t.Error("wrong close short", orderQuery, err.Err)
+	}
+}
@@ -0,0 +1,388 @@
+package bnc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+const (
+	historyWalkLimit        = 1000
+	historyWalkPageInterval = 200 * time.Millisecond
+	historyWalkMaxRetry     = 5
+
+	spotTradesMaxTimeWindow    = 24 * 60 * 60 * 1000
+	futuresTradesMaxTimeWindow = 7 * 24 * 60 * 60 * 1000
+
+	spotOrdersMaxTimeWindow    = 24 * 60 * 60 * 1000
+	futuresOrdersMaxTimeWindow = 7 * 24 * 60 * 60 * 1000
+
+	futuresIncomesMaxTimeWindow   = 7 * 24 * 60 * 60 * 1000
+	accountSnapshotsMaxTimeWindow = 30 * 24 * 60 * 60 * 1000
+	accountSnapshotsMaxLimit      = 30
+)
+
+// ErrHistoryIncomplete is returned if history can not be walked completely.
+var ErrHistoryIncomplete = errors.New("bnc: history incomplete")
+
+// walkRequest requests config, and waits and retries if request is rate limited.
+func walkRequest[ReqDataType, RespDataType any](
+	maker cex.ReqMaker,
+	config cex.ReqConfig[ReqDataType, RespDataType],
+	params ReqDataType,
+	opts ...cex.CltOpt,
+) (RespDataType, cex.RequestError) {
+	var data RespDataType
+	var err cex.RequestError
+	for i := 0; i < historyWalkMaxRetry; i++ {
+		_, data, err = cex.Request(maker, config, params, opts...)
+		if !err.IsRateLimited() {
+			return data, err
+		}
+		wait := err.RateLimitError.Wait()
+		if wait == 0 {
+			wait = time.Second
+		}
+		time.Sleep(wait)
+	}
+	return data, err
+}
+
+func (u *User) SpotTrades(params SpotAccountTradeListParams, opts ...cex.CltOpt) ([]SpotTradeHistory, cex.RequestError) {
+	return walkRequest(u, SpotAccountTradeListConfig, params, opts...)
+}
+
+// AllSpotTradesSince pages through spot trades of symbol from since(ms) until now.
+// If the most recent page is not full, it contains all trades.
+// Otherwise it finds the first trade since by binary search of fromId,
+// whose requests are bounded by log2 of trade id, instead of time span,
+// and then walks by fromId, which is more reliable than time.
+func (u *User) AllSpotTradesSince(symbol string, since int64, opts ...cex.CltOpt) ([]SpotTradeHistory, cex.RequestError) {
+	recent, err := u.SpotTrades(SpotAccountTradeListParams{Symbol: symbol, Limit: historyWalkLimit}, opts...)
+	if err.IsNotNil() || len(recent) == 0 {
+		return nil, err
+	}
+	if len(recent) < historyWalkLimit {
+		var result []SpotTradeHistory
+		for _, t := range recent {
+			if t.Time >= since {
+				result = append(result, t)
+			}
+		}
+		return result, cex.RequestError{}
+	}
+
+	// first trade with id >= fromId has time >= since, if fromId >= firstId
+	lo, hi := int64(1), recent[len(recent)-1].Id+1
+	firstId := hi
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		time.Sleep(historyWalkPageInterval)
+		trades, err := u.SpotTrades(SpotAccountTradeListParams{Symbol: symbol, FromId: mid, Limit: 1}, opts...)
+		if err.IsNotNil() {
+			return nil, err
+		}
+		switch {
+		case len(trades) == 0:
+			hi = mid
+		case trades[0].Time >= since:
+			firstId, hi = trades[0].Id, mid
+		default:
+			lo = trades[0].Id + 1
+		}
+	}
+
+	var result []SpotTradeHistory
+	for fromId := firstId; ; {
+		time.Sleep(historyWalkPageInterval)
+		trades, err := u.SpotTrades(SpotAccountTradeListParams{
+			Symbol: symbol,
+			FromId: fromId,
+			Limit:  historyWalkLimit,
+		}, opts...)
+		if err.IsNotNil() {
+			return result, err
+		}
+		result = append(result, trades...)
+		if len(trades) < historyWalkLimit {
+			return result, cex.RequestError{}
+		}
+		fromId = trades[len(trades)-1].Id + 1
+	}
+}
+
+func (u *User) FuturesTrades(params FuturesAccountTradeListParams, opts ...cex.CltOpt) ([]FuturesTradeHistory, cex.RequestError) {
+	return walkRequest(u, FuturesAccountTradeListConfig, params, opts...)
+}
+
+// SpotTradesBetween pages through spot trades of symbol between startTime(ms) and endTime(ms).
+// Zero endTime means now.
+func (u *User) SpotTradesBetween(symbol string, startTime, endTime int64, opts ...cex.CltOpt) ([]SpotTradeHistory, cex.RequestError) {
+	return walkByTime(startTime, endTime, spotTradesMaxTimeWindow,
+		func(start, end int64) ([]SpotTradeHistory, cex.RequestError) {
+			return u.SpotTrades(SpotAccountTradeListParams{Symbol: symbol, StartTime: start, EndTime: end, Limit: historyWalkLimit}, opts...)
+		},
+		func(t SpotTradeHistory) (int64, int64) { return t.Id, t.Time },
+	)
+}
+
+// FuturesTradesBetween pages through futures trades of symbol between startTime(ms) and endTime(ms).
+// Zero endTime means now.
+func (u *User) FuturesTradesBetween(symbol string, startTime, endTime int64, opts ...cex.CltOpt) ([]FuturesTradeHistory, cex.RequestError) {
+	return walkByTime(startTime, endTime, futuresTradesMaxTimeWindow,
+		func(start, end int64) ([]FuturesTradeHistory, cex.RequestError) {
+			return u.FuturesTrades(FuturesAccountTradeListParams{Symbol: symbol, StartTime: start, EndTime: end, Limit: historyWalkLimit}, opts...)
+		},
+		func(t FuturesTradeHistory) (int64, int64) { return t.Id, t.Time },
+	)
+}
+
+func (u *User) SpotOrders(params SpotAllOrdersParams, opts ...cex.CltOpt) ([]SpotOrder, cex.RequestError) {
+	return walkRequest(u, SpotAllOrdersConfig, params, opts...)
+}
+
+func (u *User) FuturesOrders(params FuturesAllOrdersParams, opts ...cex.CltOpt) ([]FuturesOrder, cex.RequestError) {
+	return walkRequest(u, FuturesAllOrdersConfig, params, opts...)
+}
+
+// AllSpotOrders pages through spot orders of symbol created between startTime(ms) and endTime(ms),
+// including open, canceled and filled orders.
+// Zero endTime means now.
+func (u *User) AllSpotOrders(symbol string, startTime, endTime int64, opts ...cex.CltOpt) ([]SpotOrder, cex.RequestError) {
+	return walkByTime(startTime, endTime, spotOrdersMaxTimeWindow,
+		func(start, end int64) ([]SpotOrder, cex.RequestError) {
+			return u.SpotOrders(SpotAllOrdersParams{Symbol: symbol, StartTime: start, EndTime: end, Limit: historyWalkLimit}, opts...)
+		},
+		func(o SpotOrder) (int64, int64) { return o.OrderId, o.Time },
+	)
+}
+
+// AllFuturesOrders pages through futures orders of symbol created between startTime(ms) and endTime(ms).
+// Zero endTime means now.
+// Binance does not return canceled or expired orders without fills older than 3 days,
+// and orders older than 90 days.
+func (u *User) AllFuturesOrders(symbol string, startTime, endTime int64, opts ...cex.CltOpt) ([]FuturesOrder, cex.RequestError) {
+	return walkByTime(startTime, endTime, futuresOrdersMaxTimeWindow,
+		func(start, end int64) ([]FuturesOrder, cex.RequestError) {
+			return u.FuturesOrders(FuturesAllOrdersParams{Symbol: symbol, StartTime: start, EndTime: end, Limit: historyWalkLimit}, opts...)
+		},
+		func(o FuturesOrder) (int64, int64) { return o.OrderId, o.Time },
+	)
+}
+
+// walkByTime splits [startTime, endTime] into windows, and queries every window.
+// If a window is full, the next query starts from time of the last item of it,
+// so overlapped items are deduplicated by key.
+// Error wraps ErrHistoryIncomplete, if more than one page items have the same time,
+// because they can not be walked by time.
+func walkByTime[Item any, Key comparable](
+	startTime, endTime, window int64,
+	query func(start, end int64) ([]Item, cex.RequestError),
+	keyAndTime func(Item) (key Key, time int64),
+) ([]Item, cex.RequestError) {
+	if endTime == 0 {
+		endTime = time.Now().UnixMilli()
+	}
+	var result []Item
+	seen := map[Key]bool{}
+	for start := startTime; start <= endTime; {
+		end := min(start+window-1, endTime)
+		items, err := query(start, end)
+		if err.IsNotNil() {
+			return result, err
+		}
+		var added int
+		for _, item := range items {
+			key, _ := keyAndTime(item)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result = append(result, item)
+			added++
+		}
+		if len(items) < historyWalkLimit {
+			start = end + 1
+		} else if added == 0 {
+			return result, cex.RequestError{Err: fmt.Errorf("%w, more than %v items at %v", ErrHistoryIncomplete, historyWalkLimit, start)}
+		} else {
+			_, start = keyAndTime(items[len(items)-1])
+		}
+		if start <= endTime {
+			time.Sleep(historyWalkPageInterval)
+		}
+	}
+	return result, cex.RequestError{}
+}
+
+// QueryFills implements cex.FillQuerier.
+// symbol is required by binance, startTime is required, zero endTime means now.
+func (u *User) QueryFills(pairType cex.PairType, symbol string, startTime, endTime int64, opts ...cex.CltOpt) ([]cex.Fill, cex.RequestError) {
+	switch pairType {
+	case cex.PairTypeSpot:
+		trades, err := u.SpotTradesBetween(symbol, startTime, endTime, opts...)
+		return SpotTradesToCexFills(trades), err
+	case cex.PairTypeFutures:
+		trades, err := u.FuturesTradesBetween(symbol, startTime, endTime, opts...)
+		return FuturesTradesToCexFills(trades), err
+	}
+	return nil, cex.RequestError{Err: fmt.Errorf("bnc: unknown pair type %v", pairType)}
+}
+
+func (u *User) FuturesIncomes(params FuturesIncomeHistoriesParams, opts ...cex.CltOpt) ([]FuturesIncome, cex.RequestError) {
+	return walkRequest(u, FuturesIncomeHistoriesConfig, params, opts...)
+}
+
+// AllIncomeSince pages through futures incomes of all symbols from since(ms) until now.
+func (u *User) AllIncomeSince(since int64, opts ...cex.CltOpt) ([]FuturesIncome, cex.RequestError) {
+	return u.FuturesIncomesBetween("", "", since, 0, opts...)
+}
+
+// FuturesIncomesBetween pages through futures incomes between startTime(ms) and endTime(ms).
+// Empty symbol or incomeType means all.
+// Zero endTime means now.
+func (u *User) FuturesIncomesBetween(symbol string, incomeType FuturesIncomeType, startTime, endTime int64, opts ...cex.CltOpt) ([]FuturesIncome, cex.RequestError) {
+	return walkByTime(startTime, endTime, futuresIncomesMaxTimeWindow,
+		func(start, end int64) ([]FuturesIncome, cex.RequestError) {
+			return u.FuturesIncomes(FuturesIncomeHistoriesParams{Symbol: symbol, IncomeType: incomeType, StartTime: start, EndTime: end, Limit: historyWalkLimit}, opts...)
+		},
+		func(in FuturesIncome) (string, int64) {
+			return fmt.Sprintf("%v-%v-%v-%v-%v", in.TranId, in.IncomeType, in.Asset, in.Symbol, in.Time), in.Time
+		},
+	)
+}
+
+// AccountSnapshotsBetween queries daily account snapshots between startTime(ms) and endTime(ms)
+// by 30 days windows, for historical equity tracking.
+// Binance only keeps snapshots of the last month.
+// Zero endTime means now.
+func (u *User) AccountSnapshotsBetween(snapshotType AccountSnapshotType, startTime, endTime int64, opts ...cex.CltOpt) ([]AccountSnapshotVo, cex.RequestError) {
+	if endTime == 0 {
+		endTime = time.Now().UnixMilli()
+	}
+	var result []AccountSnapshotVo
+	seen := map[int64]bool{}
+	for i, r := range cex.SplitTimeRange(startTime, endTime, accountSnapshotsMaxTimeWindow) {
+		if i > 0 {
+			time.Sleep(historyWalkPageInterval)
+		}
+		snapshots, err := walkRequest(u, AccountSnapshotConfig, AccountSnapshotParams{
+			Type:      snapshotType,
+			StartTime: r.Start,
+			EndTime:   r.End,
+			Limit:     accountSnapshotsMaxLimit,
+		}, opts...)
+		if err.IsNotNil() {
+			return result, err
+		}
+		for _, vo := range snapshots.SnapshotVos {
+			if seen[vo.UpdateTime] {
+				continue
+			}
+			seen[vo.UpdateTime] = true
+			result = append(result, vo)
+		}
+	}
+	return result, cex.RequestError{}
+}
+
+// AccountSnapshotRow is one balance, asset or position of account snapshot,
+// flattened for exporting, ex. by cexexport.WriteCSV.
+// Fields are filled by snapshot type.
+type AccountSnapshotRow struct {
+	Type       string `json:"type"`
+	UpdateTime int64  `json:"updateTime"`
+
+	// spot, margin, futures asset
+	Asset         string  `json:"asset"`
+	Free          float64 `json:"free"`
+	Locked        float64 `json:"locked"`
+	Borrowed      float64 `json:"borrowed"`
+	Interest      float64 `json:"interest"`
+	NetAsset      float64 `json:"netAsset"`
+	MarginBalance float64 `json:"marginBalance"`
+	WalletBalance float64 `json:"walletBalance"`
+
+	// futures position
+	Symbol           string  `json:"symbol"`
+	EntryPrice       float64 `json:"entryPrice"`
+	MarkPrice        float64 `json:"markPrice"`
+	PositionAmt      float64 `json:"positionAmt"`
+	UnRealizedProfit float64 `json:"unRealizedProfit"`
+}
+
+// AccountSnapshotRows flattens balances, assets and positions of snapshots into rows.
+func AccountSnapshotRows(vos []AccountSnapshotVo) []AccountSnapshotRow {
+	var rows []AccountSnapshotRow
+	for _, vo := range vos {
+		base := AccountSnapshotRow{Type: vo.Type, UpdateTime: vo.UpdateTime}
+		for _, b := range vo.Data.Balances {
+			row := base
+			row.Asset, row.Free, row.Locked = b.Asset, b.Free, b.Locked
+			rows = append(rows, row)
+		}
+		for _, a := range vo.Data.UserAssets {
+			row := base
+			row.Asset, row.Free, row.Locked = a.Asset, a.Free, a.Locked
+			row.Borrowed, row.Interest, row.NetAsset = a.Borrowed, a.Interest, a.NetAsset
+			rows = append(rows, row)
+		}
+		for _, a := range vo.Data.Assets {
+			row := base
+			row.Asset, row.MarginBalance, row.WalletBalance = a.Asset, a.MarginBalance, a.WalletBalance
+			rows = append(rows, row)
+		}
+		for _, p := range vo.Data.Position {
+			row := base
+			row.Symbol, row.EntryPrice, row.MarkPrice = p.Symbol, p.EntryPrice, p.MarkPrice
+			row.PositionAmt, row.UnRealizedProfit = p.PositionAmt, p.UnRealizedProfit
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+const pageWalkLimit = 100
+
+// hasNextPage returns true, if binance Page of current has more rows after it.
+func hasNextPage[Row any](page Page[[]Row], current, limit int64) bool {
+	return int64(len(page.Rows)) == limit && current*limit < int64(page.Total)
+}
+
+func pageRows[Row any](page Page[[]Row]) []Row {
+	return page.Rows
+}
+
+// AllCryptoLoanFlexibleBorrowHistories pages through all flexible loan borrow histories by current.
+func (u *User) AllCryptoLoanFlexibleBorrowHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) ([]CryptoLoanFlexibleBorrowHistory, cex.RequestError) {
+	p := cex.Paginate(
+		u,
+		CryptoLoanFlexibleBorrowHistoriesConfig,
+		CryptoLoanFlexibleBorrowHistoriesParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Current: 1, Limit: pageWalkLimit},
+		func(params CryptoLoanFlexibleBorrowHistoriesParams, page Page[[]CryptoLoanFlexibleBorrowHistory]) (CryptoLoanFlexibleBorrowHistoriesParams, bool) {
+			hasNext := hasNextPage(page, params.Current, params.Limit)
+			params.Current++
+			return params, hasNext
+		},
+		opts...,
+	).SetInterval(historyWalkPageInterval)
+	return cex.PaginateAll(p, pageRows[CryptoLoanFlexibleBorrowHistory])
+}
+
+// AllCryptoLoanFlexibleRepaymentHistories pages through all flexible loan repayment histories by current.
+func (u *User) AllCryptoLoanFlexibleRepaymentHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) ([]CryptoLoanFlexibleRepaymentHistory, cex.RequestError) {
+	p := cex.Paginate(
+		u,
+		CryptoLoanFlexibleRepaymentHistoriesConfig,
+		CryptoLoanFlexibleRepaymentHistoriesParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Current: 1, Limit: pageWalkLimit},
+		func(params CryptoLoanFlexibleRepaymentHistoriesParams, page Page[[]CryptoLoanFlexibleRepaymentHistory]) (CryptoLoanFlexibleRepaymentHistoriesParams, bool) {
+			hasNext := hasNextPage(page, params.Current, params.Limit)
+			params.Current++
+			return params, hasNext
+		},
+		opts...,
+	).SetInterval(historyWalkPageInterval)
+	return cex.PaginateAll(p, pageRows[CryptoLoanFlexibleRepaymentHistory])
+}
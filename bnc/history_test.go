@@ -0,0 +1,241 @@
+package bnc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestUser_QueryFills(t *testing.T) {
+	var windows [][2]int64
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		windows = append(windows, [2]int64{start, end})
+		body := `[]`
+		switch req.URL.Path {
+		case ApiV3 + "/myTrades":
+			if len(windows) == 1 {
+				body = `[{"symbol":"ETHUSDT","id":1,"orderId":10,"price":"1500","qty":"0.1","quoteQty":"150","commission":"0.0001","commissionAsset":"ETH","time":1000,"isBuyer":true,"isMaker":true}]`
+			}
+		case FapiV1 + "/userTrades":
+			body = `[{"symbol":"ETHUSDT","id":2,"orderId":20,"side":"SELL","price":"1600","qty":"0.2","quoteQty":"320","realizedPnl":"20","commission":"0.1","commissionAsset":"USDT","time":2000,"maker":false}]`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			Request:    req,
+		}, nil
+	})
+	user := NewUser("key", "secret")
+
+	fills, err := user.QueryFills(cex.PairTypeSpot, "ETHUSDT", 0, spotTradesMaxTimeWindow+1, cex.CltOptTransport(transport))
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(windows) != 2 || windows[0] != [2]int64{0, spotTradesMaxTimeWindow - 1} || windows[1] != [2]int64{spotTradesMaxTimeWindow, spotTradesMaxTimeWindow + 1} {
+		t.Error("wrong time windows", windows)
+	}
+	if len(fills) != 1 {
+		t.Fatal("wrong fills length", len(fills))
+	}
+	f := fills[0]
+	if f.PairType != cex.PairTypeSpot || f.TradeId != "1" || f.OrderId != "10" || f.OrderSide != cex.OrderSideBuy || f.QuoteQty != 150 || !f.IsMaker {
+		t.Error("wrong spot fill", f)
+	}
+
+	fills, err = user.QueryFills(cex.PairTypeFutures, "ETHUSDT", 0, 1, cex.CltOptTransport(transport))
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(fills) != 1 {
+		t.Fatal("wrong fills length", len(fills))
+	}
+	f = fills[0]
+	if f.PairType != cex.PairTypeFutures || f.OrderSide != cex.OrderSideSell || f.RealizedPnl != 20 || f.Commission != 0.1 {
+		t.Error("wrong futures fill", f)
+	}
+
+	if _, err := user.QueryFills("", "ETHUSDT", 0, 1); err.IsNil() {
+		t.Error("unknown pair type should return error")
+	}
+}
+
+func TestUser_AccountSnapshotsBetween(t *testing.T) {
+	var types []string
+	var windows [][2]int64
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		types = append(types, q.Get("type"))
+		windows = append(windows, [2]int64{start, end})
+		// the same snapshot is responded for every window, and should be deduplicated
+		body := `{"code":200,"msg":"","snapshotVos":[{"type":"futures","updateTime":86400000,"data":{"assets":[{"asset":"USDT","marginBalance":"118.99782335","walletBalance":"120.23811389"}],"position":[{"entryPrice":"7130.41000000","markPrice":"7257.66239673","positionAmt":"0.01000000","symbol":"BTCUSDT","unRealizedProfit":"1.24029054"}]}}]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			Request:    req,
+		}, nil
+	})
+	user := NewUser("key", "secret")
+	snapshots, err := user.AccountSnapshotsBetween(AccountSnapshotTypeFutures, 1, accountSnapshotsMaxTimeWindow+1, cex.CltOptTransport(transport))
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(windows) != 2 || windows[0] != [2]int64{1, accountSnapshotsMaxTimeWindow} || windows[1] != [2]int64{accountSnapshotsMaxTimeWindow + 1, accountSnapshotsMaxTimeWindow + 1} {
+		t.Error("wrong time windows", windows)
+	}
+	if types[0] != string(AccountSnapshotTypeFutures) {
+		t.Error("wrong snapshot type", types)
+	}
+	if len(snapshots) != 1 {
+		t.Fatal("snapshots should be deduplicated, but", len(snapshots))
+	}
+	data := snapshots[0].Data
+	if len(data.Assets) != 1 || data.Assets[0].WalletBalance != 120.23811389 || len(data.Position) != 1 || data.Position[0].PositionAmt != 0.01 {
+		t.Error("wrong snapshot data", data)
+	}
+}
+
+func TestAccountSnapshotRows(t *testing.T) {
+	rows := AccountSnapshotRows([]AccountSnapshotVo{
+		{Type: "spot", UpdateTime: 1, Data: AccountSnapshotData{Balances: []AccountSnapshotBalance{{Asset: "BTC", Free: 1, Locked: 0.5}}}},
+		{Type: "futures", UpdateTime: 2, Data: AccountSnapshotData{
+			Assets:   []AccountSnapshotFuturesAsset{{Asset: "USDT", MarginBalance: 100, WalletBalance: 90}},
+			Position: []AccountSnapshotFuturesPosition{{Symbol: "ETHUSDT", PositionAmt: -1, EntryPrice: 2000}},
+		}},
+	})
+	if len(rows) != 3 {
+		t.Fatal("wrong rows number", len(rows))
+	}
+	if rows[0].Asset != "BTC" || rows[0].Locked != 0.5 || rows[1].WalletBalance != 90 || rows[2].Symbol != "ETHUSDT" || rows[2].UpdateTime != 2 || rows[2].Type != "futures" {
+		t.Error("wrong rows", rows)
+	}
+}
+
+func TestUser_AllOrders(t *testing.T) {
+	var paths []string
+	var windows [][2]int64
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.Method+" "+req.URL.Path)
+		q := req.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		windows = append(windows, [2]int64{start, end})
+		body := `[]`
+		switch req.URL.Path {
+		case ApiV3 + "/allOrders":
+			body = `[{"symbol":"ETHUSDT","orderId":1,"status":"CANCELED","time":1000}]`
+		case ApiV3 + "/openOrders":
+			body = `[{"symbol":"ETHUSDT","orderId":2,"status":"NEW","time":2000}]`
+		case FapiV1 + "/allOrders":
+			body = `[{"symbol":"ETHUSDT","orderId":3,"status":"FILLED","time":3000}]`
+		case FapiV1 + "/allOpenOrders":
+			body = `{"code":200,"msg":"The operation of cancel all open order is done."}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			Request:    req,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	spotOrders, err := user.AllSpotOrders("ETHUSDT", 0, spotOrdersMaxTimeWindow+1)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(windows) != 2 || windows[1] != [2]int64{spotOrdersMaxTimeWindow, spotOrdersMaxTimeWindow + 1} {
+		t.Error("wrong time windows", windows)
+	}
+	if len(spotOrders) != 1 || spotOrders[0].OrderId != 1 {
+		t.Error("duplicated orders should be removed", spotOrders)
+	}
+
+	windows = nil
+	fuOrders, err := user.AllFuturesOrders("ETHUSDT", 0, spotOrdersMaxTimeWindow+1)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(windows) != 1 || len(fuOrders) != 1 || fuOrders[0].Status != OrderStatusFilled {
+		t.Error("wrong futures orders", windows, fuOrders)
+	}
+
+	if _, orders, err := user.OpenSpotOrders("ETHUSDT"); err.IsNotNil() || len(orders) != 1 || orders[0].OrderId != 2 {
+		t.Error("wrong open orders", orders, err.Error())
+	}
+	if _, codeMsg, err := user.CancelAllFuturesOpenOrders("ETHUSDT"); err.IsNotNil() || codeMsg.Code != 200 {
+		t.Error("wrong cancel result", codeMsg, err.Error())
+	}
+	if paths[len(paths)-1] != http.MethodDelete+" "+FapiV1+"/allOpenOrders" {
+		t.Error("wrong cancel request", paths[len(paths)-1])
+	}
+}
+
+func TestUser_AllSpotTradesSince_FromId(t *testing.T) {
+	// trades of user have ids 1..1500, and time is id*10
+	var requests int
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		requests++
+		q := req.URL.Query()
+		limit, _ := strconv.ParseInt(q.Get("limit"), 10, 64)
+		from, end := int64(501), int64(1500)
+		if q.Has("fromId") {
+			from, _ = strconv.ParseInt(q.Get("fromId"), 10, 64)
+			end = min(from+limit-1, 1500)
+		}
+		var trades []string
+		for id := from; id <= end; id++ {
+			trades = append(trades, fmt.Sprintf(`{"symbol":"ETHUSDT","id":%v,"time":%v}`, id, id*10))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("[" + strings.Join(trades, ",") + "]")),
+			Request:    req,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+	trades, err := user.AllSpotTradesSince("ETHUSDT", 7005)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(trades) != 800 || trades[0].Id != 701 || trades[len(trades)-1].Id != 1500 {
+		t.Error("wrong trades", len(trades))
+	}
+	if requests > 15 {
+		t.Error("first trade should be found by binary search of from id, requests", requests)
+	}
+}
+
+func TestUser_FuturesIncomesBetween_Incomplete(t *testing.T) {
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		var incomes []string
+		for id := 0; id < historyWalkLimit; id++ {
+			incomes = append(incomes, fmt.Sprintf(`{"tranId":%v,"time":1000}`, id))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("[" + strings.Join(incomes, ",") + "]")),
+			Request:    req,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+	incomes, err := user.FuturesIncomesBetween("", "", 1000, 2000)
+	if !errors.Is(err.Err, ErrHistoryIncomplete) || len(incomes) != historyWalkLimit {
+		t.Error("items of the same time more than one page should not be dropped silently", len(incomes), err.Error())
+	}
+}
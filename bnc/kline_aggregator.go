@@ -0,0 +1,320 @@
+package bnc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// KlineBackfiller requests klines of symbol and interval,
+// whose open time is in [startTime, endTime], in ascending order.
+type KlineBackfiller func(symbol string, interval KlineInterval, startTime, endTime int64) ([]Kline, error)
+
+const klineBackfillLimit = 1000
+
+// NewKlineBackfiller pages through rest klines of pairType by clt.
+func NewKlineBackfiller(clt *PublicClient, pairType cex.PairType, opts ...cex.CltOpt) KlineBackfiller {
+	query := clt.SpotKlines
+	if pairType == cex.PairTypeFutures {
+		query = clt.FuturesKlines
+	}
+	return func(symbol string, interval KlineInterval, startTime, endTime int64) ([]Kline, error) {
+		var all []Kline
+		for startTime <= endTime {
+			_, klines, err := query(KlineParams{
+				Symbol:    symbol,
+				Interval:  interval,
+				StartTime: startTime,
+				EndTime:   endTime,
+				Limit:     klineBackfillLimit,
+			}, opts...)
+			if err.IsNotNil() {
+				return all, &err
+			}
+			all = append(all, klines...)
+			if len(klines) < klineBackfillLimit {
+				break
+			}
+			startTime = klines[len(klines)-1].OpenTime + 1
+		}
+		return all, nil
+	}
+}
+
+type KlineAggregatorOpt func(*KlineAggregator)
+
+// KlineAggregatorOptBackfill enables gap backfill by backfiller.
+// tradeInterval is kline interval used to backfill gaps of trades,
+// ex. 1s for spot, 1m for futures, and must divide aggregated interval.
+// Gaps of ws klines are backfilled by interval of ws klines.
+func KlineAggregatorOptBackfill(backfiller KlineBackfiller, tradeInterval KlineInterval) KlineAggregatorOpt {
+	return func(a *KlineAggregator) {
+		a.backfiller = backfiller
+		a.tradeInterval = tradeInterval
+	}
+}
+
+// KlineAggregator consumes ws trades or klines of one symbol,
+// and produces klines of arbitrary interval, ex. 7s, 2m, which binance does not offer natively.
+// Intervals without any trade are closed as flat klines with previous close price,
+// so produced klines are continuous.
+//
+// Gaps are detected by discontinuous trade ids or kline open times,
+// ex. after ws reconnecting, and backfilled by rest klines if backfiller is set.
+// Backfilled klines are accurate to backfill interval,
+// trades of the interval of the last trade and the interval of the new trade may be lost.
+//
+// Only one of trade, aggTrade or kline streams should be added,
+// because their ids are not the same sequence.
+//
+//	agg, _ := NewKlineAggregator("BTCUSDT", 7*time.Second, KlineAggregatorOptBackfill(NewKlineBackfiller(NewPublicClient(), cex.PairTypeSpot), KlineInterval1s))
+//	for e := range stream.Events() {
+//		klines, err := agg.Handle(e)
+//	}
+type KlineAggregator struct {
+	mux sync.Mutex
+
+	symbol   string
+	interval int64 // ms
+
+	backfiller    KlineBackfiller
+	tradeInterval KlineInterval
+
+	cur *Kline
+
+	// lastTradeId and lastKlineOpenTime are -1, if nothing is added
+	lastTradeId   int64
+	lastTradeTime int64
+	// lastKlineOpenTime is open time of the last closed ws kline
+	lastKlineOpenTime int64
+}
+
+func NewKlineAggregator(symbol string, interval time.Duration, opts ...KlineAggregatorOpt) (*KlineAggregator, error) {
+	a := &KlineAggregator{symbol: symbol, interval: interval.Milliseconds(), lastTradeId: -1, lastKlineOpenTime: -1}
+	if a.interval <= 0 {
+		return nil, fmt.Errorf("bnc: kline aggregator, invalid interval %v", interval)
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.backfiller != nil {
+		if err := a.checkSourceInterval(a.tradeInterval); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func (a *KlineAggregator) Symbol() string {
+	return a.symbol
+}
+
+// Current returns kline of the current interval, which is not closed.
+func (a *KlineAggregator) Current() (Kline, bool) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if a.cur == nil {
+		return Kline{}, false
+	}
+	return *a.cur, true
+}
+
+// Flush closes klines whose close time is before now(ms), and returns them.
+// It should be called by timer, if trades are sparse.
+func (a *KlineAggregator) Flush(now int64) []Kline {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if a.cur == nil {
+		return nil
+	}
+	return a.advance(now - now%a.interval)
+}
+
+// Handle adds aggTrade or kline event of symbol, and returns closed klines.
+// Other events are ignored.
+func (a *KlineAggregator) Handle(e WsMarketEvent) ([]Kline, error) {
+	switch {
+	case e.AggTrade != nil && e.AggTrade.Symbol == a.symbol:
+		return a.AddAggTrade(*e.AggTrade)
+	case e.Kline != nil && e.Kline.Kline.Symbol == a.symbol:
+		return a.AddKline(e.Kline.Kline)
+	}
+	return nil, nil
+}
+
+func (a *KlineAggregator) AddTrade(t WsTradeStream) ([]Kline, error) {
+	return a.addTrade(t.TradeID, 1, t.Price, t.Quantity, t.IsBuyerMaker, t.TradeTime)
+}
+
+func (a *KlineAggregator) AddAggTrade(t WsAggTradeStream) ([]Kline, error) {
+	return a.addTrade(t.AggID, t.LastTradeId-t.FirstTradeId+1, t.Price, t.Quantity, t.IsBuyerMaker, t.TradeTime)
+}
+
+// AddKline adds ws kline, and returns closed klines.
+// Kline is added only if it is closed,
+// and its interval must divide aggregated interval.
+func (a *KlineAggregator) AddKline(k WsKlineData) ([]Kline, error) {
+	if !k.IsClosed {
+		return nil, nil
+	}
+	if err := a.checkSourceInterval(k.Interval); err != nil {
+		return nil, err
+	}
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if k.StartTime <= a.lastKlineOpenTime {
+		// duplicated
+		return nil, nil
+	}
+	var closed []Kline
+	var err error
+	srcMs := k.Interval.Duration().Milliseconds()
+	if a.lastKlineOpenTime >= 0 && k.StartTime > a.lastKlineOpenTime+srcMs {
+		closed, err = a.backfill(k.Interval, a.lastKlineOpenTime+srcMs, k.StartTime-1)
+	}
+	a.lastKlineOpenTime = k.StartTime
+	closed = append(closed, a.merge(Kline{
+		OpenTime:                 k.StartTime,
+		CloseTime:                k.CloseTime,
+		TradesNumber:             k.TradeNum,
+		OpenPrice:                k.OpenPrice,
+		HighPrice:                k.HighPrice,
+		LowPrice:                 k.LowPrice,
+		ClosePrice:               k.ClosePrice,
+		Volume:                   k.Volume,
+		QuoteAssetVolume:         k.QuoteVolume,
+		TakerBuyBaseAssetVolume:  k.TakerBuyVolume,
+		TakerBuyQuoteAssetVolume: k.TakerBuyQuoteVol,
+	})...)
+	return closed, err
+}
+
+func (a *KlineAggregator) addTrade(id, tradesNum int64, price, qty float64, isBuyerMaker bool, tradeTime int64) ([]Kline, error) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if id <= a.lastTradeId {
+		// duplicated
+		return nil, nil
+	}
+	var closed []Kline
+	var err error
+	if a.lastTradeId >= 0 && id > a.lastTradeId+1 {
+		closed, err = a.backfillTrades(tradeTime)
+	}
+	a.lastTradeId = id
+	a.lastTradeTime = max(a.lastTradeTime, tradeTime)
+
+	quote := price * qty
+	takerBuyQty, takerBuyQuote := qty, quote
+	if isBuyerMaker {
+		takerBuyQty, takerBuyQuote = 0, 0
+	}
+	closed = append(closed, a.merge(Kline{
+		OpenTime:                 tradeTime,
+		TradesNumber:             tradesNum,
+		OpenPrice:                price,
+		HighPrice:                price,
+		LowPrice:                 price,
+		ClosePrice:               price,
+		Volume:                   qty,
+		QuoteAssetVolume:         quote,
+		TakerBuyBaseAssetVolume:  takerBuyQty,
+		TakerBuyQuoteAssetVolume: takerBuyQuote,
+	})...)
+	return closed, err
+}
+
+// backfillTrades backfills intervals between the last trade and the new trade.
+func (a *KlineAggregator) backfillTrades(tradeTime int64) ([]Kline, error) {
+	srcMs := a.tradeInterval.Duration().Milliseconds()
+	if a.backfiller == nil || srcMs <= 0 {
+		return nil, nil
+	}
+	startTime := a.lastTradeTime - a.lastTradeTime%srcMs + srcMs
+	endTime := tradeTime - tradeTime%srcMs - 1
+	if startTime > endTime {
+		return nil, nil
+	}
+	return a.backfill(a.tradeInterval, startTime, endTime)
+}
+
+func (a *KlineAggregator) backfill(interval KlineInterval, startTime, endTime int64) ([]Kline, error) {
+	if a.backfiller == nil {
+		return nil, nil
+	}
+	klines, err := a.backfiller(a.symbol, interval, startTime, endTime)
+	var closed []Kline
+	for _, k := range klines {
+		if k.OpenTime < startTime || k.OpenTime > endTime {
+			continue
+		}
+		closed = append(closed, a.merge(k)...)
+	}
+	if err != nil {
+		err = fmt.Errorf("bnc: kline aggregator, backfill %v %v [%v, %v], %w", a.symbol, interval, startTime, endTime, err)
+	}
+	return closed, err
+}
+
+// merge merges k into kline of interval containing k.OpenTime,
+// and returns klines closed before it.
+// k is ignored, if its interval is closed.
+func (a *KlineAggregator) merge(k Kline) []Kline {
+	openTime := k.OpenTime - k.OpenTime%a.interval
+	closed := a.advance(openTime)
+	if openTime < a.cur.OpenTime {
+		// late
+		return closed
+	}
+	b := a.cur
+	if b.TradesNumber == 0 && b.Volume == 0 {
+		b.OpenPrice, b.HighPrice, b.LowPrice = k.OpenPrice, k.HighPrice, k.LowPrice
+	} else {
+		b.HighPrice = max(b.HighPrice, k.HighPrice)
+		b.LowPrice = min(b.LowPrice, k.LowPrice)
+	}
+	b.ClosePrice = k.ClosePrice
+	b.TradesNumber += k.TradesNumber
+	b.Volume += k.Volume
+	b.QuoteAssetVolume += k.QuoteAssetVolume
+	b.TakerBuyBaseAssetVolume += k.TakerBuyBaseAssetVolume
+	b.TakerBuyQuoteAssetVolume += k.TakerBuyQuoteAssetVolume
+	return closed
+}
+
+// advance closes klines before openTime,
+// and intervals without any trade are closed as flat klines.
+func (a *KlineAggregator) advance(openTime int64) []Kline {
+	if a.cur == nil {
+		a.cur = &Kline{OpenTime: openTime, CloseTime: openTime + a.interval - 1}
+		return nil
+	}
+	var closed []Kline
+	for a.cur.OpenTime < openTime {
+		closed = append(closed, *a.cur)
+		next := a.cur.OpenTime + a.interval
+		c := a.cur.ClosePrice
+		a.cur = &Kline{
+			OpenTime:   next,
+			CloseTime:  next + a.interval - 1,
+			OpenPrice:  c,
+			HighPrice:  c,
+			LowPrice:   c,
+			ClosePrice: c,
+		}
+	}
+	return closed
+}
+
+func (a *KlineAggregator) checkSourceInterval(interval KlineInterval) error {
+	srcMs := interval.Duration().Milliseconds()
+	if srcMs <= 0 {
+		return fmt.Errorf("bnc: kline aggregator, invalid source interval %v", interval)
+	}
+	if a.interval%srcMs != 0 {
+		return fmt.Errorf("bnc: kline aggregator, source interval %v can not divide %v", interval, time.Duration(a.interval)*time.Millisecond)
+	}
+	return nil
+}
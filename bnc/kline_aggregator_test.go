@@ -0,0 +1,139 @@
+package bnc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKlineAggregator_Trades(t *testing.T) {
+	agg, err := NewKlineAggregator("ETHUSDT", 7*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	add := func(id, tradeTime int64, price, qty float64, isBuyerMaker bool) []Kline {
+		klines, err := agg.AddTrade(WsTradeStream{Symbol: "ETHUSDT", TradeID: id, TradeTime: tradeTime, Price: price, Quantity: qty, IsBuyerMaker: isBuyerMaker})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return klines
+	}
+
+	if klines := add(1, 0, 10, 1, false); len(klines) != 0 {
+		t.Fatal("no kline should be closed", klines)
+	}
+	add(2, 3000, 12, 2, true)
+	add(2, 3000, 100, 2, true) // duplicated
+	add(3, 6999, 9, 1, false)
+
+	klines := add(4, 15000, 11, 1, false)
+	if len(klines) != 2 {
+		t.Fatal("should close 1 traded kline and 1 flat kline, but", len(klines))
+	}
+	k := klines[0]
+	if k.OpenTime != 0 || k.CloseTime != 6999 || k.OpenPrice != 10 || k.HighPrice != 12 || k.LowPrice != 9 || k.ClosePrice != 9 ||
+		k.Volume != 4 || k.QuoteAssetVolume != 43 || k.TradesNumber != 3 || k.TakerBuyBaseAssetVolume != 2 {
+		t.Error("wrong first kline", k)
+	}
+	k = klines[1]
+	if k.OpenTime != 7000 || k.OpenPrice != 9 || k.HighPrice != 9 || k.LowPrice != 9 || k.ClosePrice != 9 || k.Volume != 0 {
+		t.Error("wrong flat kline", k)
+	}
+	cur, ok := agg.Current()
+	if !ok || cur.OpenTime != 14000 || cur.OpenPrice != 11 {
+		t.Error("wrong current kline", cur)
+	}
+
+	if klines := agg.Flush(21000); len(klines) != 1 || klines[0].OpenTime != 14000 {
+		t.Error("current kline should be flushed", klines)
+	}
+}
+
+func TestKlineAggregator_BackfillTrades(t *testing.T) {
+	var reqs [][2]int64
+	backfiller := func(symbol string, interval KlineInterval, startTime, endTime int64) ([]Kline, error) {
+		reqs = append(reqs, [2]int64{startTime, endTime})
+		var klines []Kline
+		for ot := startTime; ot <= endTime; ot += 1000 {
+			klines = append(klines, Kline{OpenTime: ot, CloseTime: ot + 999, OpenPrice: 10, HighPrice: 20, LowPrice: 5, ClosePrice: 15, Volume: 1, TradesNumber: 1})
+		}
+		return klines, nil
+	}
+	if _, err := NewKlineAggregator("ETHUSDT", 7*time.Second, KlineAggregatorOptBackfill(backfiller, KlineInterval1m)); err == nil {
+		t.Error("1m can not divide 7s")
+	}
+	agg, err := NewKlineAggregator("ETHUSDT", 7*time.Second, KlineAggregatorOptBackfill(backfiller, KlineInterval1s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := agg.AddAggTrade(WsAggTradeStream{Symbol: "ETHUSDT", AggID: 1, FirstTradeId: 1, LastTradeId: 1, TradeTime: 500, Price: 12, Quantity: 1}); err != nil {
+		t.Fatal(err)
+	}
+	// agg ids 2-4 are missed
+	if _, err := agg.AddAggTrade(WsAggTradeStream{Symbol: "ETHUSDT", AggID: 5, FirstTradeId: 5, LastTradeId: 6, TradeTime: 4500, Price: 16, Quantity: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 1 || reqs[0] != [2]int64{1000, 3999} {
+		t.Fatal("wrong backfill range", reqs)
+	}
+	cur, _ := agg.Current()
+	if cur.OpenPrice != 12 || cur.HighPrice != 20 || cur.LowPrice != 5 || cur.ClosePrice != 16 || cur.Volume != 5 || cur.TradesNumber != 6 {
+		t.Error("wrong backfilled kline", cur)
+	}
+}
+
+func TestKlineAggregator_Klines(t *testing.T) {
+	var reqs [][2]int64
+	backfiller := func(symbol string, interval KlineInterval, startTime, endTime int64) ([]Kline, error) {
+		reqs = append(reqs, [2]int64{startTime, endTime})
+		return []Kline{{OpenTime: startTime, CloseTime: endTime, OpenPrice: 3, HighPrice: 3, LowPrice: 3, ClosePrice: 3, Volume: 3}}, nil
+	}
+	agg, err := NewKlineAggregator("ETHUSDT", 2*time.Minute, KlineAggregatorOptBackfill(backfiller, KlineInterval1m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	minute := int64(time.Minute / time.Millisecond)
+	add := func(openTime int64, price float64, isClosed bool) []Kline {
+		klines, err := agg.Handle(WsMarketEvent{Kline: &WsKlineStream{Kline: WsKlineData{
+			Symbol: "ETHUSDT", Interval: KlineInterval1m, StartTime: openTime, CloseTime: openTime + minute - 1,
+			OpenPrice: price, HighPrice: price, LowPrice: price, ClosePrice: price, Volume: 1, IsClosed: isClosed,
+		}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return klines
+	}
+	add(0, 1, false) // not closed, ignored
+	add(0, 1, true)
+	add(minute, 2, true)
+	// the 3rd minute is missed
+	klines := add(3*minute, 4, true)
+	if len(reqs) != 1 || reqs[0] != [2]int64{2 * minute, 3*minute - 1} {
+		t.Fatal("wrong backfill range", reqs)
+	}
+	if len(klines) != 1 {
+		t.Fatal("should close 1 kline, but", len(klines))
+	}
+	if k := klines[0]; k.OpenPrice != 1 || k.ClosePrice != 2 || k.Volume != 2 {
+		t.Error("wrong first kline", k)
+	}
+	cur, _ := agg.Current()
+	if cur.OpenTime != 2*minute || cur.OpenPrice != 3 || cur.HighPrice != 4 || cur.ClosePrice != 4 || cur.Volume != 4 {
+		t.Error("wrong current kline", cur)
+	}
+}
+
+func TestKlineInterval_Duration(t *testing.T) {
+	for interval, d := range map[KlineInterval]time.Duration{
+		KlineInterval1s: time.Second,
+		"3m":            3 * time.Minute,
+		"4h":            4 * time.Hour,
+		"1d":            24 * time.Hour,
+		"1w":            7 * 24 * time.Hour,
+		"1M":            0,
+		"":              0,
+	} {
+		if interval.Duration() != d {
+			t.Error("wrong duration", interval, interval.Duration())
+		}
+	}
+}
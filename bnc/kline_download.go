@@ -0,0 +1,101 @@
+package bnc
+
+import (
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// Max klines of one request.
+const (
+	SpotKlineMaxLimit    = 1000
+	FuturesKlineMaxLimit = 1500
+)
+
+// KlineDownloadParams
+// StartTime and EndTime are open time of klines in millisecond, zero EndTime means now.
+// Limit is klines of one request, zero means the max limit of config.
+type KlineDownloadParams struct {
+	Symbol    string
+	Interval  KlineInterval
+	StartTime int64
+	EndTime   int64
+	Limit     int64
+}
+
+func klineMaxLimit(config cex.ReqConfig[KlineParams, []Kline]) int64 {
+	if config.BaseUrl == FapiBaseUrl {
+		return FuturesKlineMaxLimit
+	}
+	return SpotKlineMaxLimit
+}
+
+// WalkKlines downloads klines of [StartTime, EndTime] by config, ex. SpotKlineConfig,
+// chunk by chunk of Limit, and calls callback with every chunk in order.
+// Rate limited requests are waited and retried.
+// Klines of chunk boundaries are deduplicated, so every kline is passed to callback once.
+// Walking stops, if callback returns error.
+func WalkKlines(maker cex.ReqMaker, config cex.ReqConfig[KlineParams, []Kline], params KlineDownloadParams, callback func([]Kline) error, opts ...cex.CltOpt) cex.RequestError {
+	limit := params.Limit
+	if limit <= 0 || limit > klineMaxLimit(config) {
+		limit = klineMaxLimit(config)
+	}
+	endTime := params.EndTime
+	if endTime == 0 {
+		endTime = time.Now().UnixMilli()
+	}
+	lastOpenTime := int64(-1)
+	for start := params.StartTime; start <= endTime; {
+		klines, err := walkRequest(maker, config, KlineParams{
+			Symbol:    params.Symbol,
+			Interval:  params.Interval,
+			StartTime: start,
+			EndTime:   endTime,
+			Limit:     limit,
+		}, opts...)
+		if err.IsNotNil() {
+			return err
+		}
+		chunk := make([]Kline, 0, len(klines))
+		for _, k := range klines {
+			if k.OpenTime <= lastOpenTime || k.OpenTime < start || k.OpenTime > endTime {
+				continue
+			}
+			chunk = append(chunk, k)
+			lastOpenTime = k.OpenTime
+		}
+		if len(chunk) == 0 {
+			return cex.RequestError{}
+		}
+		if err := callback(chunk); err != nil {
+			return cex.RequestError{Err: err}
+		}
+		if int64(len(klines)) < limit {
+			return cex.RequestError{}
+		}
+		start = lastOpenTime + 1
+		if start <= endTime {
+			time.Sleep(historyWalkPageInterval)
+		}
+	}
+	return cex.RequestError{}
+}
+
+// DownloadKlines returns klines of [StartTime, EndTime] in order, see WalkKlines.
+// Downloaded klines are returned, even if error is not nil.
+func DownloadKlines(maker cex.ReqMaker, config cex.ReqConfig[KlineParams, []Kline], params KlineDownloadParams, opts ...cex.CltOpt) ([]Kline, cex.RequestError) {
+	var klines []Kline
+	err := WalkKlines(maker, config, params, func(chunk []Kline) error {
+		klines = append(klines, chunk...)
+		return nil
+	}, opts...)
+	return klines, err
+}
+
+func (c *PublicClient) DownloadSpotKlines(params KlineDownloadParams, opts ...cex.CltOpt) ([]Kline, cex.RequestError) {
+	return DownloadKlines(c, SpotKlineConfig, params, opts...)
+}
+
+func (c *PublicClient) DownloadFuturesKlines(params KlineDownloadParams, opts ...cex.CltOpt) ([]Kline, cex.RequestError) {
+	return DownloadKlines(c, FuturesKlineConfig, params, opts...)
+}
@@ -0,0 +1,71 @@
+package bnc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDownloadKlines(t *testing.T) {
+	const minute = int64(60000)
+	const base = 28333333 * minute
+	var limits []string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		query := r.URL.Query()
+		start, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+		limits = append(limits, query.Get("limit"))
+		// every chunk begins with the last kline of previous chunk
+		first := base
+		if start > base {
+			first = base + (start-1-base)/minute*minute
+		}
+		var rows []string
+		for openTime := first; openTime <= end && int64(len(rows)) < limit; openTime += minute {
+			rows = append(rows, fmt.Sprintf(`[%d,"1","2","0.5","1.5","10",%d,"15",3,"5","7.5","0"]`, openTime, openTime+minute-1))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader("[" + strings.Join(rows, ",") + "]")),
+			Request:    r,
+		}, nil
+	})
+	clt := NewPublicClient(PublicClientOptTransport(transport))
+
+	params := KlineDownloadParams{Symbol: "ETHUSDT", Interval: "1m", StartTime: base, EndTime: base + 25*minute, Limit: 10}
+	klines, err := clt.DownloadSpotKlines(params)
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if len(klines) != 26 {
+		t.Fatal("wrong klines number", len(klines))
+	}
+	for i, k := range klines {
+		if k.OpenTime != base+int64(i)*minute {
+			t.Fatal("klines are not complete and ordered", i, k.OpenTime)
+		}
+	}
+	if len(limits) != 3 {
+		t.Error("wrong requests number", len(limits))
+	}
+
+	limits = nil
+	errStop := errors.New("stop")
+	var chunks int
+	err = WalkKlines(clt, FuturesKlineConfig, KlineDownloadParams{Symbol: "ETHUSDT", Interval: "1m", StartTime: base, EndTime: base + 3000*minute}, func([]Kline) error {
+		chunks++
+		return errStop
+	})
+	if !errors.Is(err.Err, errStop) || chunks != 1 {
+		t.Error("walking should stop by callback error", err.Err, chunks)
+	}
+	if len(limits) != 1 || limits[0] != strconv.Itoa(FuturesKlineMaxLimit) {
+		t.Error("limit should be max limit of futures", limits)
+	}
+}
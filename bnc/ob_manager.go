@@ -0,0 +1,329 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/cex/ob"
+)
+
+// ErrOrderBookNotSynced is returned by OrderBookManager queries,
+// if local book is not synced with binance, ex. before the first snapshot,
+// or after a sequence gap is found.
+var ErrOrderBookNotSynced = errors.New("order book is not synced")
+
+type OrderBookSnapshotQuerier func(symbol string, limit int) (OrderBook, error)
+
+type OrderBookManagerOpt func(*OrderBookManager)
+
+// OrderBookManagerOptSnapshotLimit sets limit of REST depth snapshot, default is 1000.
+func OrderBookManagerOptSnapshotLimit(limit int) OrderBookManagerOpt {
+	return func(m *OrderBookManager) {
+		m.limit = limit
+	}
+}
+
+// OrderBookManagerOptSnapshotQuerier replaces REST depth snapshot querier,
+// default is QuerySpotOrderBook or QueryFuturesOrderBook.
+func OrderBookManagerOptSnapshotQuerier(querier OrderBookSnapshotQuerier) OrderBookManagerOpt {
+	return func(m *OrderBookManager) {
+		m.querySnapshot = querier
+	}
+}
+
+// OrderBookManager maintains local order book of one symbol.
+// It seeds book from REST depth snapshot, and applies diff depth ws updates
+// with sequence validation, see
+// https://binance-docs.github.io/apidocs/spot/en/#how-to-manage-a-local-order-book-correctly
+//
+//	spot:    first update U <= lastUpdateId+1 <= u, then every U == previous u + 1
+//	futures: first update U <= lastUpdateId <= u,   then every pu == previous u
+//
+// Book is reset and re-seeded automatically if any gap is found.
+// All methods are safe for concurrent use.
+type OrderBookManager struct {
+	pairType      cex.PairType
+	symbol        string
+	limit         int
+	querySnapshot OrderBookSnapshotQuerier
+
+	mux          sync.RWMutex
+	book         ob.Data
+	lastUpdateId int64
+	synced       bool
+	syncing      bool
+	// seeded is true after snapshot is set and before the first update is applied
+	seeded bool
+	// buffer keeps updates received before book is synced
+	buffer []WsDepthMsg
+}
+
+func NewOrderBookManager(pairType cex.PairType, symbol string, opts ...OrderBookManagerOpt) *OrderBookManager {
+	m := &OrderBookManager{
+		pairType: pairType,
+		symbol:   symbol,
+		limit:    1000,
+		book:     ob.Empty(cex.BINANCE, pairType, symbol),
+	}
+	if pairType == cex.PairTypeFutures {
+		m.querySnapshot = QueryFuturesOrderBook
+	} else {
+		m.querySnapshot = QuerySpotOrderBook
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Start subscribes diff depth stream, and maintains book until ctx is done.
+func (m *OrderBookManager) Start(ctx context.Context) error {
+	stream := NewWsMarketStream(m.pairType)
+	stream.Start(ctx)
+	if err := stream.SubDepth(m.symbol); err != nil {
+		return err
+	}
+	go m.run(ctx, stream)
+	return nil
+}
+
+func (m *OrderBookManager) run(ctx context.Context, stream *WsMarketStream) {
+	var lastSyncFail time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-stream.Events():
+			if e.Err != nil {
+				m.Reset()
+				continue
+			}
+			if e.Data.Depth == nil {
+				continue
+			}
+			_ = m.Update(*e.Data.Depth)
+			// updates are kept in stream buffer while syncing
+			if m.needSync() && time.Since(lastSyncFail) > 3*time.Second {
+				if err := m.Sync(); err != nil {
+					lastSyncFail = time.Now()
+				}
+			}
+		}
+	}
+}
+
+func (m *OrderBookManager) needSync() bool {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return !m.synced && !m.syncing && len(m.buffer) > 0
+}
+
+// Update applies one diff depth update.
+// Update is buffered if book is not synced.
+// Returns error if a sequence gap is found, and then book should be re-synced by Sync.
+func (m *OrderBookManager) Update(msg WsDepthMsg) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if !m.synced {
+		m.buffer = append(m.buffer, msg)
+		if len(m.buffer) > 1000 {
+			m.buffer = m.buffer[len(m.buffer)-1000:]
+		}
+		return nil
+	}
+	if err := m.apply(msg); err != nil {
+		m.reset(err.Error())
+		m.buffer = []WsDepthMsg{msg}
+		return err
+	}
+	return nil
+}
+
+// Sync queries REST depth snapshot, and applies buffered updates on it.
+func (m *OrderBookManager) Sync() error {
+	m.mux.Lock()
+	if m.syncing {
+		m.mux.Unlock()
+		return errors.New("binance: order book is syncing")
+	}
+	m.syncing = true
+	m.mux.Unlock()
+
+	snapshot, err := m.querySnapshot(m.symbol, m.limit)
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.syncing = false
+	if err != nil {
+		return err
+	}
+	m.book = ob.Data{
+		Cex:     cex.BINANCE,
+		Type:    m.pairType,
+		Symbol:  m.symbol,
+		Version: strconv.FormatInt(snapshot.LastUpdateId, 10),
+		Time:    time.Now().UnixMilli(),
+		Asks:    snapshot.Asks,
+		Bids:    snapshot.Bids,
+	}
+	m.lastUpdateId = snapshot.LastUpdateId
+	m.seeded = true
+	for i, msg := range m.buffer {
+		if err = m.apply(msg); err != nil {
+			m.reset(err.Error())
+			// snapshot is older than buffered updates, keep them for next sync
+			m.buffer = m.buffer[i:]
+			return err
+		}
+	}
+	m.buffer = nil
+	m.synced = true
+	return nil
+}
+
+// isStale returns true if msg is covered by current book.
+func (m *OrderBookManager) isStale(msg WsDepthMsg) bool {
+	if m.pairType == cex.PairTypeFutures {
+		return msg.LastId < m.lastUpdateId
+	}
+	return msg.LastId <= m.lastUpdateId
+}
+
+func (m *OrderBookManager) apply(msg WsDepthMsg) error {
+	if m.isStale(msg) {
+		return nil
+	}
+	last := m.lastUpdateId
+	first := m.seeded
+	switch {
+	case m.pairType == cex.PairTypeFutures && first:
+		if msg.FirstId > last || msg.LastId < last {
+			return fmt.Errorf("binance: order book gap, lastUpdateId %v, U %v, u %v", last, msg.FirstId, msg.LastId)
+		}
+	case m.pairType == cex.PairTypeFutures:
+		if msg.PLastId != last {
+			return fmt.Errorf("binance: order book gap, lastUpdateId %v, pu %v", last, msg.PLastId)
+		}
+	case first:
+		if msg.FirstId > last+1 || msg.LastId < last+1 {
+			return fmt.Errorf("binance: order book gap, lastUpdateId %v, U %v, u %v", last, msg.FirstId, msg.LastId)
+		}
+	default:
+		if msg.FirstId != last+1 {
+			return fmt.Errorf("binance: order book gap, lastUpdateId %v, U %v", last, msg.FirstId)
+		}
+	}
+	asks, err := convRawStrBookToFloatBook(msg.Asks)
+	if err != nil {
+		return err
+	}
+	bids, err := convRawStrBookToFloatBook(msg.Bids)
+	if err != nil {
+		return err
+	}
+	version := strconv.FormatInt(msg.LastId, 10)
+	if err = m.book.UpdateAskDeltas(asks, version); err != nil {
+		return err
+	}
+	if err = m.book.UpdateBidDeltas(bids, version); err != nil {
+		return err
+	}
+	m.lastUpdateId = msg.LastId
+	m.seeded = false
+	return nil
+}
+
+// Reset clears book and buffered updates, and book will be re-synced.
+func (m *OrderBookManager) Reset() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.reset("reset")
+	m.buffer = nil
+}
+
+func (m *OrderBookManager) reset(reason string) {
+	m.synced = false
+	m.seeded = false
+	m.lastUpdateId = 0
+	m.book = ob.Empty(cex.BINANCE, m.pairType, m.symbol)
+	m.book.EmptyReason = reason
+}
+
+func (m *OrderBookManager) Symbol() string {
+	return m.symbol
+}
+
+func (m *OrderBookManager) Synced() bool {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.synced
+}
+
+func (m *OrderBookManager) LastUpdateId() int64 {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.lastUpdateId
+}
+
+// BestBid returns the highest bid price and qty.
+func (m *OrderBookManager) BestBid() (price, qty float64, err error) {
+	return m.best(false)
+}
+
+// BestAsk returns the lowest ask price and qty.
+func (m *OrderBookManager) BestAsk() (price, qty float64, err error) {
+	return m.best(true)
+}
+
+func (m *OrderBookManager) best(ask bool) (price, qty float64, err error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	if !m.synced {
+		return 0, 0, ErrOrderBookNotSynced
+	}
+	book := m.book.Bids
+	if ask {
+		book = m.book.Asks
+	}
+	if len(book) == 0 {
+		return 0, 0, errors.New("binance: order book side is empty")
+	}
+	return book[0][0], book[0][1], nil
+}
+
+// Depth returns copies of the best n levels of asks and bids.
+// All levels are returned, if n <= 0.
+func (m *OrderBookManager) Depth(n int) (asks, bids ob.Book, err error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	if !m.synced {
+		return nil, nil, ErrOrderBookNotSynced
+	}
+	return copyBookTop(m.book.Asks, n), copyBookTop(m.book.Bids, n), nil
+}
+
+// Snapshot returns a copy of the whole local book.
+func (m *OrderBookManager) Snapshot() (ob.Data, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	if !m.synced {
+		return ob.Data{}, ErrOrderBookNotSynced
+	}
+	return *m.book.Copy(), nil
+}
+
+func copyBookTop(book ob.Book, n int) ob.Book {
+	if n <= 0 || n > len(book) {
+		n = len(book)
+	}
+	top := make(ob.Book, 0, n)
+	for _, pq := range book[:n] {
+		top = append(top, ob.PQ{pq[0], pq[1]})
+	}
+	return top
+}
@@ -0,0 +1,88 @@
+package bnc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/cex/ob"
+)
+
+func testSnapshotQuerier(lastUpdateId int64) OrderBookSnapshotQuerier {
+	return func(symbol string, limit int) (OrderBook, error) {
+		return OrderBook{
+			LastUpdateId: lastUpdateId,
+			Asks:         ob.Book{{101, 1}, {102, 2}},
+			Bids:         ob.Book{{99, 1}, {98, 2}},
+		}, nil
+	}
+}
+
+func TestOrderBookManager_Spot(t *testing.T) {
+	m := NewOrderBookManager(cex.PairTypeSpot, "ETHUSDT", OrderBookManagerOptSnapshotQuerier(testSnapshotQuerier(10)))
+	if _, _, err := m.BestBid(); !errors.Is(err, ErrOrderBookNotSynced) {
+		t.Fatal("book should not be synced before snapshot")
+	}
+	// stale update
+	_ = m.Update(WsDepthMsg{FirstId: 5, LastId: 9, Bids: [][]string{{"97", "1"}}})
+	// first update, U <= lastUpdateId+1 <= u
+	_ = m.Update(WsDepthMsg{FirstId: 9, LastId: 12, Asks: [][]string{{"101", "0"}}})
+	if err := m.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	price, qty, err := m.BestAsk()
+	if err != nil || price != 102 || qty != 2 {
+		t.Error("first update should be applied", price, qty, err)
+	}
+	if err = m.Update(WsDepthMsg{FirstId: 13, LastId: 14, Bids: [][]string{{"100", "3"}}}); err != nil {
+		t.Fatal(err)
+	}
+	price, qty, _ = m.BestBid()
+	if price != 100 || qty != 3 || m.LastUpdateId() != 14 {
+		t.Error("update should be applied", price, qty, m.LastUpdateId())
+	}
+	_, bids, _ := m.Depth(2)
+	if len(bids) != 2 || bids[1][0] != 99 {
+		t.Error("wrong depth", bids)
+	}
+
+	// gap
+	if err = m.Update(WsDepthMsg{FirstId: 16, LastId: 17}); err == nil {
+		t.Fatal("gap should be found")
+	}
+	if m.Synced() {
+		t.Error("book should be reset after gap")
+	}
+}
+
+func TestOrderBookManager_SnapshotTooOld(t *testing.T) {
+	m := NewOrderBookManager(cex.PairTypeSpot, "ETHUSDT", OrderBookManagerOptSnapshotQuerier(testSnapshotQuerier(10)))
+	_ = m.Update(WsDepthMsg{FirstId: 20, LastId: 21})
+	if err := m.Sync(); err == nil {
+		t.Fatal("snapshot older than updates should not be synced")
+	}
+	m.querySnapshot = testSnapshotQuerier(20)
+	if err := m.Sync(); err != nil {
+		t.Fatal("updates should be kept for next sync", err)
+	}
+	if m.LastUpdateId() != 21 {
+		t.Error("buffered update should be applied", m.LastUpdateId())
+	}
+}
+
+func TestOrderBookManager_Futures(t *testing.T) {
+	m := NewOrderBookManager(cex.PairTypeFutures, "ETHUSDT", OrderBookManagerOptSnapshotQuerier(testSnapshotQuerier(10)))
+	// first update, U <= lastUpdateId <= u
+	_ = m.Update(WsDepthMsg{FirstId: 8, LastId: 11, PLastId: 7})
+	_ = m.Update(WsDepthMsg{FirstId: 12, LastId: 15, PLastId: 11, Asks: [][]string{{"100.5", "1"}}})
+	if err := m.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	price, _, _ := m.BestAsk()
+	if price != 100.5 {
+		t.Error("buffered updates should be applied", price)
+	}
+	if err := m.Update(WsDepthMsg{FirstId: 17, LastId: 18, PLastId: 16}); err == nil {
+		t.Error("pu gap should be found")
+	}
+}
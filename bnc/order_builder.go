@@ -0,0 +1,347 @@
+package bnc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+var ErrInvalidOrderOptions = errors.New("bnc: invalid order options")
+
+const (
+	// FuturesMinGoodTillDuration is min duration from now to goodTillDate of GTD order.
+	FuturesMinGoodTillDuration = 600 * time.Second
+	// FuturesMaxGoodTillDate is max goodTillDate of GTD order, unit is millisecond.
+	FuturesMaxGoodTillDate int64 = 253402300799000
+)
+
+// OrderBuilder builds new order fluently, and validates conflicting options before sending.
+// Spot order is built by default.
+//
+//	_, ord, err := u.Order("ETH", "USDT").Limit().Buy().Qty(0.5).Price(1800).PostOnly().Send()
+//
+// Setting conflicting options, ex. PostOnly().IOC(), or Buy().Sell(), fails Validate and Send.
+type OrderBuilder struct {
+	u           *User
+	asset       string
+	quote       string
+	pairType    cex.PairType
+	isUm        bool
+	pairTypeSet bool
+	orderType   cex.OrderType
+	orderSide   cex.OrderSide
+	qty         float64
+	price       float64
+	timeInForce TimeInForce
+	postOnly    bool
+	reduceOnly  bool
+	cltOrdId    string
+	// goodTillDate is used with GTD, unit is millisecond
+	goodTillDate int64
+	stpMode      SelfTradePreventionMode
+	// err is the first conflict of setters
+	err error
+}
+
+// Order creates spot OrderBuilder of asset and quote.
+func (u *User) Order(asset, quote string) *OrderBuilder {
+	return &OrderBuilder{u: u, asset: asset, quote: quote, pairType: cex.PairTypeSpot}
+}
+
+func (b *OrderBuilder) conflict(format string, a ...any) *OrderBuilder {
+	if b.err == nil {
+		b.err = fmt.Errorf("%w, "+format, append([]any{ErrInvalidOrderOptions}, a...)...)
+	}
+	return b
+}
+
+func (b *OrderBuilder) setPairType(pairType cex.PairType, isUm bool) *OrderBuilder {
+	if b.pairTypeSet && (b.pairType != pairType || b.isUm != isUm) {
+		return b.conflict("pair type is set more than once")
+	}
+	b.pairType, b.isUm, b.pairTypeSet = pairType, isUm, true
+	return b
+}
+
+// Spot builds spot order, it is default.
+func (b *OrderBuilder) Spot() *OrderBuilder {
+	return b.setPairType(cex.PairTypeSpot, false)
+}
+
+// Futures builds um futures order.
+func (b *OrderBuilder) Futures() *OrderBuilder {
+	return b.setPairType(cex.PairTypeFutures, true)
+}
+
+// CM builds cm futures order.
+func (b *OrderBuilder) CM() *OrderBuilder {
+	return b.setPairType(cex.PairTypeFutures, false)
+}
+
+func (b *OrderBuilder) setType(orderType cex.OrderType) *OrderBuilder {
+	if b.orderType != "" && b.orderType != orderType {
+		return b.conflict("order type %v conflicts with %v", orderType, b.orderType)
+	}
+	b.orderType = orderType
+	return b
+}
+
+func (b *OrderBuilder) Limit() *OrderBuilder {
+	return b.setType(cex.OrderTypeLimit)
+}
+
+func (b *OrderBuilder) Market() *OrderBuilder {
+	return b.setType(cex.OrderTypeMarket)
+}
+
+func (b *OrderBuilder) setSide(orderSide cex.OrderSide) *OrderBuilder {
+	if b.orderSide != "" && b.orderSide != orderSide {
+		return b.conflict("order side %v conflicts with %v", orderSide, b.orderSide)
+	}
+	b.orderSide = orderSide
+	return b
+}
+
+func (b *OrderBuilder) Buy() *OrderBuilder {
+	return b.setSide(cex.OrderSideBuy)
+}
+
+func (b *OrderBuilder) Sell() *OrderBuilder {
+	return b.setSide(cex.OrderSideSell)
+}
+
+func (b *OrderBuilder) Side(orderSide cex.OrderSide) *OrderBuilder {
+	return b.setSide(orderSide)
+}
+
+func (b *OrderBuilder) Qty(qty float64) *OrderBuilder {
+	b.qty = qty
+	return b
+}
+
+func (b *OrderBuilder) Price(price float64) *OrderBuilder {
+	b.price = price
+	return b
+}
+
+func (b *OrderBuilder) setTimeInForce(tif TimeInForce) *OrderBuilder {
+	if b.timeInForce != TimeInForceNone && b.timeInForce != tif {
+		return b.conflict("time in force %v conflicts with %v", tif, b.timeInForce)
+	}
+	b.timeInForce = tif
+	return b
+}
+
+// GTC is default time in force of limit order.
+func (b *OrderBuilder) GTC() *OrderBuilder {
+	return b.setTimeInForce(TimeInForceGtc)
+}
+
+func (b *OrderBuilder) IOC() *OrderBuilder {
+	return b.setTimeInForce(TimeInForceIoc)
+}
+
+func (b *OrderBuilder) FOK() *OrderBuilder {
+	return b.setTimeInForce(TimeInForceFok)
+}
+
+// GTD is only for futures order, goodTillDate unit is millisecond,
+// and it must be at least FuturesMinGoodTillDuration later than now.
+func (b *OrderBuilder) GTD(goodTillDate int64) *OrderBuilder {
+	b.goodTillDate = goodTillDate
+	return b.setTimeInForce(TimeInForceGtd)
+}
+
+// PostOnly places LIMIT_MAKER spot order, or GTX futures order,
+// which is rejected or expired if it would take liquidity.
+func (b *OrderBuilder) PostOnly() *OrderBuilder {
+	b.postOnly = true
+	return b
+}
+
+// STP sets self trade prevention mode, which overrides default mode of user.
+func (b *OrderBuilder) STP(mode SelfTradePreventionMode) *OrderBuilder {
+	if b.stpMode != "" && b.stpMode != mode {
+		return b.conflict("self trade prevention mode %v conflicts with %v", mode, b.stpMode)
+	}
+	b.stpMode = mode
+	return b
+}
+
+// ReduceOnly is only for futures order in one-way mode.
+func (b *OrderBuilder) ReduceOnly() *OrderBuilder {
+	b.reduceOnly = true
+	return b
+}
+
+// ClientOrderId sets client order id instead of the generated one.
+func (b *OrderBuilder) ClientOrderId(id string) *OrderBuilder {
+	b.cltOrdId = id
+	return b
+}
+
+// Validate returns the first conflict of setters,
+// or invalid combination of options, which would be rejected by binance.
+func (b *OrderBuilder) Validate() error {
+	if b.err != nil {
+		return b.err
+	}
+	if b.asset == "" || b.quote == "" {
+		return fmt.Errorf("%w, empty asset or quote", ErrInvalidOrderOptions)
+	}
+	switch b.orderSide {
+	case cex.OrderSideBuy, cex.OrderSideSell:
+	case "":
+		return fmt.Errorf("%w, order side is not set", ErrInvalidOrderOptions)
+	default:
+		return fmt.Errorf("%w, invalid order side %q", ErrInvalidOrderOptions, b.orderSide)
+	}
+	if b.qty <= 0 {
+		return fmt.Errorf("%w, invalid qty %v", ErrInvalidOrderOptions, b.qty)
+	}
+	switch b.orderType {
+	case cex.OrderTypeLimit:
+		if b.price <= 0 {
+			return fmt.Errorf("%w, invalid limit price %v", ErrInvalidOrderOptions, b.price)
+		}
+	case cex.OrderTypeMarket:
+		if b.price != 0 {
+			return fmt.Errorf("%w, price can not be used with market order", ErrInvalidOrderOptions)
+		}
+		if b.timeInForce != TimeInForceNone || b.postOnly {
+			return fmt.Errorf("%w, time in force and post only can not be used with market order", ErrInvalidOrderOptions)
+		}
+	default:
+		return fmt.Errorf("%w, order type is not set", ErrInvalidOrderOptions)
+	}
+	if b.postOnly && b.timeInForce != TimeInForceNone && b.timeInForce != TimeInForceGtc {
+		return fmt.Errorf("%w, post only conflicts with %v", ErrInvalidOrderOptions, b.timeInForce)
+	}
+	switch b.stpMode {
+	case "", SelfTradePreventionModeNone, SelfTradePreventionModeExpireTaker, SelfTradePreventionModeExpireMaker, SelfTradePreventionModeExpireBoth:
+	default:
+		return fmt.Errorf("%w, invalid self trade prevention mode %q", ErrInvalidOrderOptions, b.stpMode)
+	}
+	if b.timeInForce == TimeInForceGtd {
+		if b.pairType != cex.PairTypeFutures {
+			return fmt.Errorf("%w, GTD is only for futures", ErrInvalidOrderOptions)
+		}
+		if err := validateGoodTillDate(b.goodTillDate, b.u.cfg.timeSync.Now()); err != nil {
+			return err
+		}
+	}
+	if b.reduceOnly {
+		if b.pairType != cex.PairTypeFutures {
+			return fmt.Errorf("%w, reduce only is only for futures", ErrInvalidOrderOptions)
+		}
+		if posSide := b.u.cfg.fuPosSide; posSide != "" && posSide != FuturesPositionSideBoth {
+			return fmt.Errorf("%w, reduce only can not be sent in hedge mode", ErrInvalidOrderOptions)
+		}
+	}
+	return nil
+}
+
+// validateGoodTillDate checks goodTillDate of GTD order, which is rejected by binance,
+// if it is less than now + FuturesMinGoodTillDuration, or greater than FuturesMaxGoodTillDate.
+func validateGoodTillDate(goodTillDate int64, now time.Time) error {
+	if goodTillDate < now.Add(FuturesMinGoodTillDuration).UnixMilli() {
+		return fmt.Errorf("%w, good till date %v is earlier than now + %v", ErrInvalidOrderOptions, goodTillDate, FuturesMinGoodTillDuration)
+	}
+	if goodTillDate > FuturesMaxGoodTillDate {
+		return fmt.Errorf("%w, good till date %v is greater than %v", ErrInvalidOrderOptions, goodTillDate, FuturesMaxGoodTillDate)
+	}
+	return nil
+}
+
+func (b *OrderBuilder) opts() newOrdOpts {
+	return b.u.withDefaults(newOrdOpts{
+		timeInForce:   b.timeInForce,
+		postOnly:      b.postOnly,
+		reduceOnly:    b.reduceOnly,
+		clientOrderId: b.cltOrdId,
+		goodTillDate:  b.goodTillDate,
+		stpMode:       b.stpMode,
+	})
+}
+
+// SpotParams returns params of spot order without sending it.
+// Qty and price are not rounded by order validator of user.
+func (b *OrderBuilder) SpotParams() (SpotNewOrderParams, error) {
+	if err := b.Validate(); err != nil {
+		return SpotNewOrderParams{}, err
+	}
+	if b.pairType != cex.PairTypeSpot {
+		return SpotNewOrderParams{}, fmt.Errorf("%w, not spot order", ErrInvalidOrderOptions)
+	}
+	return b.opts().spotParams(b.asset+b.quote, b.orderType, b.orderSide, b.qty, b.price, b.cltOrdId), nil
+}
+
+// FuturesParams returns params of um or cm futures order without sending it.
+// Qty and price are not rounded by order validator of user,
+// and position side of um order is decided by cached futures account mode without syncing it.
+func (b *OrderBuilder) FuturesParams() (FuturesNewOrderParams, error) {
+	if err := b.Validate(); err != nil {
+		return FuturesNewOrderParams{}, err
+	}
+	if b.pairType != cex.PairTypeFutures {
+		return FuturesNewOrderParams{}, fmt.Errorf("%w, not futures order", ErrInvalidOrderOptions)
+	}
+	o := b.opts()
+	posSide := b.u.cfg.fuPosSide
+	if b.isUm {
+		posSide, o.reduceOnly = b.u.futuresPosSide(b.orderSide, o.reduceOnly)
+	}
+	return o.futuresParams(posSide, b.asset+b.quote, b.orderType, b.orderSide, b.qty, b.price, b.cltOrdId), nil
+}
+
+// Send validates and places order by the same path as NewSpotOrder, NewFuturesOrder or NewFuturesCMOrder.
+func (b *OrderBuilder) Send(opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	if err := b.Validate(); err != nil {
+		return nil, nil, cex.RequestError{Err: err}
+	}
+	if b.pairType == cex.PairTypeFutures {
+		return b.u.newFuOrd(b.isUm, b.asset, b.quote, b.orderType, b.orderSide, b.qty, b.price, b.opts(), opts...)
+	}
+	return b.u.newSpotOrd(b.asset, b.quote, b.orderType, b.orderSide, b.qty, b.price, b.opts(), opts...)
+}
+
+// ============================================================
+// Time In Force Orders
+// ------------------------------------------------------------
+
+// NewSpotLimitMakerOrder places LIMIT_MAKER order, which is rejected if it would take liquidity.
+func (u *User) NewSpotLimitMakerOrder(asset, quote string, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.Order(asset, quote).Limit().Side(orderSide).Qty(qty).Price(price).PostOnly().Send(opts...)
+}
+
+func (u *User) NewSpotLimitIOCOrder(asset, quote string, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.Order(asset, quote).Limit().Side(orderSide).Qty(qty).Price(price).IOC().Send(opts...)
+}
+
+func (u *User) NewSpotLimitFOKOrder(asset, quote string, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.Order(asset, quote).Limit().Side(orderSide).Qty(qty).Price(price).FOK().Send(opts...)
+}
+
+// NewFuturesPostOnlyOrder places GTX limit order, which is expired if it would take liquidity.
+func (u *User) NewFuturesPostOnlyOrder(asset, quote string, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.Order(asset, quote).Futures().Limit().Side(orderSide).Qty(qty).Price(price).PostOnly().Send(opts...)
+}
+
+func (u *User) NewFuturesLimitIOCOrder(asset, quote string, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.Order(asset, quote).Futures().Limit().Side(orderSide).Qty(qty).Price(price).IOC().Send(opts...)
+}
+
+func (u *User) NewFuturesLimitFOKOrder(asset, quote string, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.Order(asset, quote).Futures().Limit().Side(orderSide).Qty(qty).Price(price).FOK().Send(opts...)
+}
+
+// NewFuturesLimitGTDOrder places limit order, which is expired at goodTillDate, unit is millisecond.
+func (u *User) NewFuturesLimitGTDOrder(asset, quote string, orderSide cex.OrderSide, qty, price float64, goodTillDate int64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.Order(asset, quote).Futures().Limit().Side(orderSide).Qty(qty).Price(price).GTD(goodTillDate).Send(opts...)
+}
+
+// ------------------------------------------------------------
+// Time In Force Orders
+// ============================================================
@@ -0,0 +1,211 @@
+package bnc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestOrderBuilder_Validate(t *testing.T) {
+	u := NewUser("key", "secret")
+	invalid := []*OrderBuilder{
+		u.Order("ETH", "USDT").Limit().Buy().Qty(0.5).Price(1800).PostOnly().IOC(),
+		u.Order("ETH", "USDT").Limit().Buy().Qty(0.5).Price(1800).IOC().FOK(),
+		u.Order("ETH", "USDT").Limit().Buy().Sell().Qty(0.5).Price(1800),
+		u.Order("ETH", "USDT").Limit().Market().Buy().Qty(0.5),
+		u.Order("ETH", "USDT").Futures().CM().Limit().Buy().Qty(0.5).Price(1800),
+		u.Order("ETH", "USDT").Limit().Buy().Qty(0.5),
+		u.Order("ETH", "USDT").Market().Buy().Qty(0.5).Price(1800),
+		u.Order("ETH", "USDT").Market().Buy().Qty(0.5).IOC(),
+		u.Order("ETH", "USDT").Market().Buy(),
+		u.Order("ETH", "USDT").Buy().Qty(0.5),
+		u.Order("ETH", "USDT").Market().Sell().Qty(0.5).ReduceOnly(),
+	}
+	for i, b := range invalid {
+		if err := b.Validate(); !errors.Is(err, ErrInvalidOrderOptions) {
+			t.Error("should be invalid", i, err)
+		}
+	}
+	hedgeUser := NewUser("key", "secret", UserOptPositionSide(FuturesPositionSideLong))
+	if err := hedgeUser.Order("ETH", "USDT").Futures().Market().Sell().Qty(0.5).ReduceOnly().Validate(); !errors.Is(err, ErrInvalidOrderOptions) {
+		t.Error("reduce only should be invalid in hedge mode", err)
+	}
+
+	spot, err := u.Order("ETH", "USDT").Limit().Buy().Qty(0.5).Price(1800).PostOnly().SpotParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spot.Symbol != "ETHUSDT" || spot.Type != OrderTypeLimitMaker || spot.TimeInForce != TimeInForceNone || spot.Side != OrderSideBuy {
+		t.Error("wrong spot post only params", spot)
+	}
+	fu, err := u.Order("ETH", "USDT").Futures().Limit().Sell().Qty(0.5).Price(1800).PostOnly().ReduceOnly().FuturesParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fu.Type != OrderTypeLimit || fu.TimeInForce != TimeInForceGtx || fu.ReduceOnly != SmallTrue {
+		t.Error("wrong futures post only params", fu)
+	}
+	if _, err := u.Order("ETH", "USDT").Limit().Buy().Qty(0.5).Price(1800).FuturesParams(); !errors.Is(err, ErrInvalidOrderOptions) {
+		t.Error("spot order should not build futures params", err)
+	}
+}
+
+func TestOrderBuilder_Send(t *testing.T) {
+	var path string
+	var query url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		path, query = r.URL.Path, r.URL.Query()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"symbol":"ETHUSDT","orderId":123,"status":"NEW","type":"LIMIT","side":"BUY","origQty":"0.5","price":"1800"}`)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	_, ord, err := user.Order("ETH", "USDT").Limit().Buy().Qty(0.5).Price(1800).IOC().Send()
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if path != "/api/v3/order" || query.Get("type") != "LIMIT" || query.Get("timeInForce") != "IOC" || query.Get("quantity") != "0.5" {
+		t.Error("wrong spot params", path, query)
+	}
+	if ord.OrderId != "123" || !user.ClientOrderIds().Owns(query.Get("newClientOrderId")) {
+		t.Error("wrong order", ord.OrderId, query.Get("newClientOrderId"))
+	}
+
+	_, _, err = user.Order("ETH", "USDT").Futures().Limit().Buy().Qty(0.5).Price(1800).PostOnly().ClientOrderId("my-id").Send()
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if path != "/fapi/v1/order" || query.Get("timeInForce") != "GTX" || query.Get("newClientOrderId") != "my-id" {
+		t.Error("wrong futures params", path, query)
+	}
+
+	query = nil
+	_, _, err = user.Order("ETH", "USDT").Limit().Buy().Qty(0.5).Price(1800).PostOnly().IOC().Send()
+	if !errors.Is(err.Err, ErrInvalidOrderOptions) || query != nil {
+		t.Error("invalid order should not be sent", err.Err, query)
+	}
+}
+
+func TestOrderBuilder_GTD(t *testing.T) {
+	u := NewUser("key", "secret")
+	later := time.Now().Add(time.Hour).UnixMilli()
+	invalid := []*OrderBuilder{
+		u.Order("ETH", "USDT").Limit().Buy().Qty(0.5).Price(1800).GTD(later),
+		u.Order("ETH", "USDT").Futures().Limit().Buy().Qty(0.5).Price(1800).GTD(time.Now().UnixMilli()),
+		u.Order("ETH", "USDT").Futures().Limit().Buy().Qty(0.5).Price(1800).GTD(FuturesMaxGoodTillDate + 1),
+		u.Order("ETH", "USDT").Futures().Limit().Buy().Qty(0.5).Price(1800).GTD(later).IOC(),
+		u.Order("ETH", "USDT").Futures().Limit().Buy().Qty(0.5).Price(1800).GTD(later).PostOnly(),
+	}
+	for i, b := range invalid {
+		if err := b.Validate(); !errors.Is(err, ErrInvalidOrderOptions) {
+			t.Error("should be invalid", i, err)
+		}
+	}
+	params, err := u.Order("ETH", "USDT").Futures().Limit().Buy().Qty(0.5).Price(1800).GTD(later).FuturesParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.TimeInForce != TimeInForceGtd || params.GoodTillDate != later {
+		t.Error("wrong GTD params", params)
+	}
+}
+
+func TestUser_NewTimeInForceOrders(t *testing.T) {
+	var query url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		query = r.URL.Query()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"symbol":"ETHUSDT","orderId":123,"status":"NEW"}`)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+	later := time.Now().Add(time.Hour).UnixMilli()
+	cases := []struct {
+		send    func() cex.RequestError
+		typ     string
+		tif     string
+		gtdDate string
+	}{
+		{func() cex.RequestError {
+			_, _, err := user.NewSpotLimitMakerOrder("ETH", "USDT", cex.OrderSideBuy, 0.5, 1800)
+			return err
+		}, "LIMIT_MAKER", "", ""},
+		{func() cex.RequestError {
+			_, _, err := user.NewSpotLimitFOKOrder("ETH", "USDT", cex.OrderSideBuy, 0.5, 1800)
+			return err
+		}, "LIMIT", "FOK", ""},
+		{func() cex.RequestError {
+			_, _, err := user.NewFuturesPostOnlyOrder("ETH", "USDT", cex.OrderSideSell, 0.5, 1800)
+			return err
+		}, "LIMIT", "GTX", ""},
+		{func() cex.RequestError {
+			_, _, err := user.NewFuturesLimitGTDOrder("ETH", "USDT", cex.OrderSideSell, 0.5, 1800, later)
+			return err
+		}, "LIMIT", "GTD", strconv.FormatInt(later, 10)},
+	}
+	for i, c := range cases {
+		if err := c.send(); err.IsNotNil() {
+			t.Fatal(i, err.Err)
+		}
+		if query.Get("type") != c.typ || query.Get("timeInForce") != c.tif || query.Get("goodTillDate") != c.gtdDate {
+			t.Error("wrong params", i, query)
+		}
+	}
+}
+
+func TestOrderBuilder_STP(t *testing.T) {
+	var query url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		query = r.URL.Query()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"symbol":"ETHUSDT","orderId":123,"status":"NEW","selfTradePreventionMode":"EXPIRE_MAKER"}`)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport), UserOptSelfTradePreventionMode(SelfTradePreventionModeExpireTaker))
+
+	_, ord, err := user.NewSpotLimitBuyOrder("ETH", "USDT", 0.5, 1800)
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if query.Get("selfTradePreventionMode") != "EXPIRE_TAKER" {
+		t.Error("default mode should be sent", query)
+	}
+	if ord.SelfTradePreventionMode != "EXPIRE_MAKER" {
+		t.Error("wrong order mode", ord.SelfTradePreventionMode)
+	}
+
+	_, _, err = user.Order("ETH", "USDT").Futures().Market().Sell().Qty(0.5).STP(SelfTradePreventionModeExpireBoth).Send()
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if query.Get("selfTradePreventionMode") != "EXPIRE_BOTH" {
+		t.Error("builder mode should override default", query)
+	}
+
+	invalid := []*OrderBuilder{
+		user.Order("ETH", "USDT").Market().Buy().Qty(0.5).STP("EXPIRE_ALL"),
+		user.Order("ETH", "USDT").Market().Buy().Qty(0.5).STP(SelfTradePreventionModeExpireMaker).STP(SelfTradePreventionModeExpireBoth),
+	}
+	for i, b := range invalid {
+		if err := b.Validate(); !errors.Is(err, ErrInvalidOrderOptions) {
+			t.Error("should be invalid", i, err)
+		}
+	}
+}
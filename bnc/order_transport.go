@@ -0,0 +1,169 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dwdwow/cex"
+)
+
+// ErrOrderTransportUnavailable should be wrapped by OrderTransport,
+// if request is not sent, ex. ws session is down.
+// Only this error makes User fall back to REST,
+// because other errors mean that request may have been received by binance,
+// and resending it by REST may place order twice.
+var ErrOrderTransportUnavailable = errors.New("order transport is unavailable")
+
+// OrderTransport is an alternative transport for order operations,
+// ex. binance WebSocket API.
+// If User is set an OrderTransport, order place and cancel will be routed
+// by it firstly, and fall back to REST transparently, if it is unavailable.
+// *cex.Response may be nil, if transport is not REST.
+// user is the calling User, whose context, credential and time sync should be used,
+// because it may be a copy of the User creating transport, ex. by WithContext.
+type OrderTransport interface {
+	// Available returns true, if orders of pairType can be routed by transport.
+	Available(pairType cex.PairType) bool
+	NewSpotOrder(user *User, params SpotNewOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError)
+	CancelSpotOrder(user *User, params SpotCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError)
+	NewFuturesOrder(user *User, params FuturesNewOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError)
+	CancelFuturesOrder(user *User, params FuturesQueryOrCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError)
+}
+
+func UserOptOrderTransport(transport OrderTransport) UserOpt {
+	return func(user *User) {
+		user.cfg.orderTransport = transport
+	}
+}
+
+// OrderRoute is preferred route of order operations of User with an OrderTransport.
+type OrderRoute string
+
+const (
+	// OrderRouteTransport routes orders by OrderTransport firstly, ex. WsApiClient, it is default.
+	OrderRouteTransport OrderRoute = "TRANSPORT"
+	// OrderRouteRest routes orders by REST only, even if User has an OrderTransport.
+	OrderRouteRest OrderRoute = "REST"
+)
+
+// UserOptOrderRoute sets preferred route of order operations.
+func UserOptOrderRoute(route OrderRoute) UserOpt {
+	return func(user *User) {
+		user.cfg.orderRoute = route
+	}
+}
+
+// WithOrderTransport returns a shallow copy of u, whose orders are routed by transport,
+// ex. WsApiClient created by u.
+func (u *User) WithOrderTransport(transport OrderTransport) *User {
+	nu := *u
+	nu.cfg.orderTransport = transport
+	return &nu
+}
+
+// WithOrderRoute returns a shallow copy of u, whose orders are routed by route,
+// ex. u.WithOrderRoute(OrderRouteRest) for one REST call.
+func (u *User) WithOrderRoute(route OrderRoute) *User {
+	nu := *u
+	nu.cfg.orderRoute = route
+	return &nu
+}
+
+// orderTransport returns nil, if orders should be routed by REST only.
+func (u *User) orderTransport() OrderTransport {
+	if u.cfg.orderRoute == OrderRouteRest {
+		return nil
+	}
+	return u.cfg.orderTransport
+}
+
+// routeOrderReq requests by order transport if it is available for pairType,
+// and falls back to rest if order transport is unavailable.
+func routeOrderReq[ReqDataType, RespDataType any](
+	u *User,
+	transport OrderTransport,
+	pairType cex.PairType,
+	transportRequest func(*User, ReqDataType, ...cex.CltOpt) (*cex.Response, RespDataType, cex.RequestError),
+	restRequest func(ReqDataType, ...cex.CltOpt) (*cex.Response, RespDataType, cex.RequestError),
+	params ReqDataType,
+	opts ...cex.CltOpt,
+) (*cex.Response, RespDataType, cex.RequestError) {
+	if transport != nil && transport.Available(pairType) {
+		resp, data, err := transportRequest(u, params, opts...)
+		if !err.Is(ErrOrderTransportUnavailable) {
+			return resp, data, err
+		}
+	}
+	return restRequest(params, opts...)
+}
+
+func (u *User) restNewSpotOrder(params SpotNewOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	return cex.Request(u, SpotNewOrderConfig, params, opts...)
+}
+
+func (u *User) restCancelSpotOrder(params SpotCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	return cex.Request(u, SpotCancelOrderConfig, params, opts...)
+}
+
+func (u *User) restNewFuturesOrder(params FuturesNewOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return cex.Request(u, FuturesNewOrderConfig, params, opts...)
+}
+
+func (u *User) restCancelFuturesOrder(params FuturesQueryOrCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return cex.Request(u, FuturesCancelOrderConfig, params, opts...)
+}
+
+func (u *User) routeNewSpotOrder(params SpotNewOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	t := u.orderTransport()
+	if t == nil {
+		return u.restNewSpotOrder(params, opts...)
+	}
+	return routeOrderReq(u, t, cex.PairTypeSpot, t.NewSpotOrder, u.restNewSpotOrder, params, opts...)
+}
+
+func (u *User) routeCancelSpotOrder(params SpotCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	t := u.orderTransport()
+	if t == nil {
+		return u.restCancelSpotOrder(params, opts...)
+	}
+	return routeOrderReq(u, t, cex.PairTypeSpot, t.CancelSpotOrder, u.restCancelSpotOrder, params, opts...)
+}
+
+func (u *User) routeNewFuturesOrder(params FuturesNewOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	t := u.orderTransport()
+	if t == nil {
+		return u.restNewFuturesOrder(params, opts...)
+	}
+	return routeOrderReq(u, t, cex.PairTypeFutures, t.NewFuturesOrder, u.restNewFuturesOrder, params, opts...)
+}
+
+func (u *User) routeCancelFuturesOrder(params FuturesQueryOrCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	t := u.orderTransport()
+	if t == nil {
+		return u.restCancelFuturesOrder(params, opts...)
+	}
+	return routeOrderReq(u, t, cex.PairTypeFutures, t.CancelFuturesOrder, u.restCancelFuturesOrder, params, opts...)
+}
+
+// acquireOrderLimits acquires cex.DefaultRateLimiter and cex.DefaultWeightBudget by REST config of order operation,
+// because orders of transports count toward the same binance order limits as REST.
+func acquireOrderLimits(ctx context.Context, u *User, config cex.ReqBaseConfig, reqErr *cex.RequestError) error {
+	if err := cex.DefaultRateLimiter().Acquire(ctx, config, u.api.ApiKey, 1); err != nil {
+		errors.As(err, &reqErr.RateLimitError)
+		return fmt.Errorf("cex: local rate limiter, %w", err)
+	}
+	if err := cex.DefaultWeightBudget().Acquire(ctx, config, u.api.ApiKey, 1); err != nil {
+		errors.As(err, &reqErr.RateLimitError)
+		return fmt.Errorf("cex: weight budget, %w", err)
+	}
+	return nil
+}
+
+// requestCtx returns context of user requests.
+func (u *User) requestCtx() context.Context {
+	if u.ctx == nil {
+		return context.Background()
+	}
+	return u.ctx
+}
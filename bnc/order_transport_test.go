@@ -0,0 +1,55 @@
+package bnc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+type testOrderTransport struct {
+	available bool
+	err       error
+}
+
+func (t testOrderTransport) Available(pairType cex.PairType) bool {
+	return t.available
+}
+
+func (t testOrderTransport) NewSpotOrder(user *User, params SpotNewOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	return nil, SpotOrder{ClientOrderId: "ws"}, cex.RequestError{Err: t.err}
+}
+
+func (t testOrderTransport) CancelSpotOrder(user *User, params SpotCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	return nil, SpotOrder{ClientOrderId: "ws"}, cex.RequestError{Err: t.err}
+}
+
+func (t testOrderTransport) NewFuturesOrder(user *User, params FuturesNewOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return nil, FuturesOrder{ClientOrderId: "ws"}, cex.RequestError{Err: t.err}
+}
+
+func (t testOrderTransport) CancelFuturesOrder(user *User, params FuturesQueryOrCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return nil, FuturesOrder{ClientOrderId: "ws"}, cex.RequestError{Err: t.err}
+}
+
+func TestRouteOrderReq(t *testing.T) {
+	rest := func(params SpotNewOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+		return nil, SpotOrder{ClientOrderId: "rest"}, cex.RequestError{}
+	}
+	cases := []struct {
+		transport testOrderTransport
+		want      string
+	}{
+		{testOrderTransport{available: true}, "ws"},
+		{testOrderTransport{available: false}, "rest"},
+		{testOrderTransport{available: true, err: fmt.Errorf("bnc: ws api, %w", ErrOrderTransportUnavailable)}, "rest"},
+		// status unknown, should not fall back
+		{testOrderTransport{available: true, err: cex.ErrHTTPCexInnerUnknownStatus}, "ws"},
+	}
+	for _, c := range cases {
+		_, ord, _ := routeOrderReq(EmptyUser(), c.transport, cex.PairTypeSpot, c.transport.NewSpotOrder, rest, SpotNewOrderParams{})
+		if ord.ClientOrderId != c.want {
+			t.Error("should route to", c.want, "but", ord.ClientOrderId)
+		}
+	}
+}
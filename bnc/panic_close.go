@@ -0,0 +1,306 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Panic Close
+// ------------------------------------------------------------
+
+type PanicCloseAction string
+
+const (
+	PanicCloseCancelSpotOrders     PanicCloseAction = "CANCEL_SPOT_ORDERS"
+	PanicCloseCancelFuturesOrders  PanicCloseAction = "CANCEL_FUTURES_ORDERS"
+	PanicCloseCloseFuturesPosition PanicCloseAction = "CLOSE_FUTURES_POSITION"
+)
+
+// PanicCloseStep is one request of PanicClose.
+type PanicCloseStep struct {
+	Action PanicCloseAction `json:"action" bson:"action"`
+	Symbol string           `json:"symbol" bson:"symbol"`
+	// OpenOrders is number of open orders to be canceled.
+	OpenOrders int `json:"openOrders" bson:"openOrders"`
+	// PositionSide, Side and Qty are of market order closing position.
+	PositionSide FuturesPositionSide `json:"positionSide" bson:"positionSide"`
+	Side         OrderSide           `json:"side" bson:"side"`
+	Qty          float64             `json:"qty" bson:"qty"`
+	// Done is false in dry run, or if Err is not nil.
+	Done bool  `json:"done" bson:"done"`
+	Err  error `json:"-" bson:"-"`
+}
+
+// PanicCloseAccount is report of one user.
+type PanicCloseAccount struct {
+	ApiKey string           `json:"apiKey" bson:"apiKey"`
+	Steps  []PanicCloseStep `json:"steps" bson:"steps"`
+	// Err joins errors of querying open orders and positions,
+	// steps of failed queries are missing.
+	Err error `json:"-" bson:"-"`
+}
+
+// PanicCloseReport is report of PanicClose.
+type PanicCloseReport struct {
+	DryRun bool `json:"dryRun" bson:"dryRun"`
+	// Accounts are in the same order as users.
+	Accounts []PanicCloseAccount `json:"accounts" bson:"accounts"`
+	// Time is finished time, unit is millisecond.
+	Time int64 `json:"time" bson:"time"`
+}
+
+// Err joins errors of all accounts and steps, returns nil if all are succeeded.
+func (r PanicCloseReport) Err() error {
+	var errs []error
+	for _, a := range r.Accounts {
+		if a.Err != nil {
+			errs = append(errs, fmt.Errorf("%v, %w", a.ApiKey, a.Err))
+		}
+		for _, s := range a.Steps {
+			if s.Err != nil {
+				errs = append(errs, fmt.Errorf("%v %v %v, %w", a.ApiKey, s.Action, s.Symbol, s.Err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type panicCloseConfig struct {
+	dryRun      bool
+	skipSpot    bool
+	skipFutures bool
+}
+
+type PanicCloseOpt func(*panicCloseConfig)
+
+// PanicCloseOptDryRun only queries open orders and positions, and reports steps without sending them.
+func PanicCloseOptDryRun() PanicCloseOpt {
+	return func(c *panicCloseConfig) {
+		c.dryRun = true
+	}
+}
+
+// PanicCloseOptSkipSpot does not cancel spot open orders.
+func PanicCloseOptSkipSpot() PanicCloseOpt {
+	return func(c *panicCloseConfig) {
+		c.skipSpot = true
+	}
+}
+
+// PanicCloseOptSkipFutures does not cancel futures open orders, and does not close futures positions.
+func PanicCloseOptSkipFutures() PanicCloseOpt {
+	return func(c *panicCloseConfig) {
+		c.skipFutures = true
+	}
+}
+
+// PanicClose concurrently cancels all spot and usd-m futures open orders of users,
+// and closes all usd-m futures positions by market orders, after futures orders are canceled.
+// Every symbol is a step, and failed steps do not stop others.
+// All requests are sent with ctx, so in-flight queries and steps are cancelled when ctx is done,
+// and steps not started before ctx is done fail by ctx error.
+// Portfolio margin account is not supported.
+//
+//	report := PanicClose(ctx, []*User{user1, user2}, PanicCloseOptDryRun())
+//	if err := report.Err(); err != nil {...}
+func PanicClose(ctx context.Context, users []*User, opts ...PanicCloseOpt) PanicCloseReport {
+	cfg := panicCloseConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	report := PanicCloseReport{DryRun: cfg.dryRun, Accounts: make([]PanicCloseAccount, len(users))}
+	var wg sync.WaitGroup
+	for i, user := range users {
+		wg.Add(1)
+		go func(acct *PanicCloseAccount) {
+			defer wg.Done()
+			*acct = user.panicClose(ctx, cfg)
+		}(&report.Accounts[i])
+	}
+	wg.Wait()
+	report.Time = time.Now().UnixMilli()
+	return report
+}
+
+// PanicClose is PanicClose of user only.
+func (u *User) PanicClose(ctx context.Context, opts ...PanicCloseOpt) PanicCloseReport {
+	return PanicClose(ctx, []*User{u}, opts...)
+}
+
+func (u *User) panicClose(ctx context.Context, cfg panicCloseConfig) PanicCloseAccount {
+	acct := PanicCloseAccount{ApiKey: u.Api().ApiKey}
+	if u.cfg.isPortfolioMarginAccount {
+		acct.Err = errors.New("bnc: panic close, portfolio margin account is not supported")
+		return acct
+	}
+	// requests are cancelled by ctx, instead of context of user
+	cu := u.WithContext(ctx)
+	var errs []error
+	var cancelSteps []PanicCloseStep
+	var positions []FuturesPosition
+	var mux sync.Mutex
+	var wg sync.WaitGroup
+	query := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(); err != nil {
+				mux.Lock()
+				errs = append(errs, err)
+				mux.Unlock()
+			}
+		}()
+	}
+	if !cfg.skipSpot {
+		query(func() error {
+			_, orders, err := cu.OpenSpotOrders("")
+			if err.IsNotNil() {
+				return fmt.Errorf("query spot open orders, %w", &err)
+			}
+			counts := map[string]int{}
+			for _, o := range orders {
+				counts[o.Symbol]++
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			cancelSteps = append(cancelSteps, panicCloseCancelSteps(PanicCloseCancelSpotOrders, counts)...)
+			return nil
+		})
+	}
+	if !cfg.skipFutures {
+		query(func() error {
+			_, orders, err := cu.OpenFuturesOrders("")
+			if err.IsNotNil() {
+				return fmt.Errorf("query futures open orders, %w", &err)
+			}
+			counts := map[string]int{}
+			for _, o := range orders {
+				counts[o.Symbol]++
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			cancelSteps = append(cancelSteps, panicCloseCancelSteps(PanicCloseCancelFuturesOrders, counts)...)
+			return nil
+		})
+		query(func() error {
+			_, ps, err := cu.FuturesPositions("")
+			if err.IsNotNil() {
+				return fmt.Errorf("query futures positions, %w", &err)
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			positions = ps
+			return nil
+		})
+	}
+	wg.Wait()
+	acct.Err = errors.Join(errs...)
+
+	var closeSteps []PanicCloseStep
+	for _, p := range positions {
+		if p.SignPositionAmt == 0 {
+			continue
+		}
+		side := OrderSideSell
+		if p.SignPositionAmt < 0 {
+			side = OrderSideBuy
+		}
+		closeSteps = append(closeSteps, PanicCloseStep{
+			Action:       PanicCloseCloseFuturesPosition,
+			Symbol:       p.Symbol,
+			PositionSide: FuturesPositionSide(p.PositionSide),
+			Side:         side,
+			Qty:          p.AbsPositionAmt(),
+		})
+	}
+	sort.Slice(cancelSteps, func(i, j int) bool {
+		if cancelSteps[i].Action != cancelSteps[j].Action {
+			return cancelSteps[i].Action > cancelSteps[j].Action
+		}
+		return cancelSteps[i].Symbol < cancelSteps[j].Symbol
+	})
+	sort.Slice(closeSteps, func(i, j int) bool {
+		if closeSteps[i].Symbol != closeSteps[j].Symbol {
+			return closeSteps[i].Symbol < closeSteps[j].Symbol
+		}
+		return closeSteps[i].PositionSide < closeSteps[j].PositionSide
+	})
+	if !cfg.dryRun {
+		// orders are canceled firstly, so open reduce only orders do not make closing orders rejected
+		cu.runPanicCloseSteps(ctx, cancelSteps)
+		cu.runPanicCloseSteps(ctx, closeSteps)
+	}
+	acct.Steps = append(cancelSteps, closeSteps...)
+	return acct
+}
+
+func panicCloseCancelSteps(action PanicCloseAction, counts map[string]int) []PanicCloseStep {
+	var steps []PanicCloseStep
+	for symbol, n := range counts {
+		steps = append(steps, PanicCloseStep{Action: action, Symbol: symbol, OpenOrders: n})
+	}
+	return steps
+}
+
+// runPanicCloseSteps runs steps by u, whose context should be ctx.
+func (u *User) runPanicCloseSteps(ctx context.Context, steps []PanicCloseStep) {
+	var wg sync.WaitGroup
+	for i := range steps {
+		wg.Add(1)
+		go func(step *PanicCloseStep) {
+			defer wg.Done()
+			if err := ctx.Err(); err != nil {
+				step.Err = err
+				return
+			}
+			var err cex.RequestError
+			switch step.Action {
+			case PanicCloseCancelSpotOrders:
+				_, _, err = u.CancelAllSpotOpenOrders(step.Symbol)
+			case PanicCloseCancelFuturesOrders:
+				_, _, err = u.CancelAllFuturesOpenOrders(step.Symbol)
+			case PanicCloseCloseFuturesPosition:
+				_, _, err = u.closeFuturesPosition(step.Symbol, step.PositionSide, step.Side, step.Qty)
+			}
+			if err.IsNotNil() {
+				step.Err = &err
+				return
+			}
+			step.Done = true
+		}(&steps[i])
+	}
+	wg.Wait()
+}
+
+// closeFuturesPosition closes position by market order, reduce only is only sent in one-way mode.
+func (u *User) closeFuturesPosition(symbol string, posSide FuturesPositionSide, side OrderSide, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	orderSide := mapStrStr(side, cexOrdSideByOrdSide)
+	cltOrdId := u.registerNewOrd(cex.PairTypeFutures, symbol, cex.OrderTypeMarket, orderSide, qty, 0, "")
+	params := FuturesNewOrderParams{
+		Symbol:           symbol,
+		PositionSide:     posSide,
+		Type:             OrderTypeMarket,
+		Side:             side,
+		Quantity:         qty,
+		NewClientOrderId: cltOrdId,
+	}
+	if posSide == "" || posSide == FuturesPositionSideBoth {
+		params.ReduceOnly = SmallTrue
+	}
+	resp, rawOrd, err := u.routeNewFuturesOrder(params, opts...)
+	ord := SwitchFutureOrderToCexOrder(rawOrd)
+	ord.ApiKey = u.api.ApiKey
+	u.resolveNewOrd(&ord, symbol, cltOrdId, resp, err)
+	return resp, &ord, err
+}
+
+// ------------------------------------------------------------
+// Panic Close
+// ============================================================
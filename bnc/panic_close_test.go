@@ -0,0 +1,123 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPanicClose(t *testing.T) {
+	var mux sync.Mutex
+	var sent []string
+	var closeQueries []url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := "{}"
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v3/openOrders":
+			body = `[{"symbol":"ETHUSDT","orderId":1},{"symbol":"ETHUSDT","orderId":2},{"symbol":"BNBUSDT","orderId":3}]`
+		case "GET /fapi/v1/openOrders":
+			body = `[{"symbol":"BTCUSDT","orderId":4}]`
+		case "GET /fapi/v2/positionRisk":
+			body = `[{"symbol":"BTCUSDT","positionSide":"BOTH","positionAmt":"-0.5"},{"symbol":"ETHUSDT","positionSide":"LONG","positionAmt":"2"},{"symbol":"BNBUSDT","positionSide":"BOTH","positionAmt":"0"}]`
+		case "DELETE /api/v3/openOrders":
+			body = `[]`
+		case "DELETE /fapi/v1/allOpenOrders":
+			body = `{"code":200,"msg":"The operation of cancel all open order is done."}`
+		case "POST /fapi/v1/order":
+			body = `{"symbol":"BTCUSDT","orderId":5,"status":"NEW"}`
+		}
+		mux.Lock()
+		if r.Method != http.MethodGet {
+			sent = append(sent, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("symbol"))
+		}
+		if r.Method == http.MethodPost {
+			closeQueries = append(closeQueries, r.URL.Query())
+		}
+		mux.Unlock()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	report := PanicClose(context.Background(), []*User{user}, PanicCloseOptDryRun())
+	if err := report.Err(); err != nil || !report.DryRun || len(sent) != 0 {
+		t.Fatal("dry run should not send any request", err, sent)
+	}
+	steps := report.Accounts[0].Steps
+	if len(steps) != 5 {
+		t.Fatal("wrong steps", steps)
+	}
+	if s := steps[1]; s.Action != PanicCloseCancelSpotOrders || s.Symbol != "ETHUSDT" || s.OpenOrders != 2 || s.Done {
+		t.Error("wrong spot step", s)
+	}
+	if s := steps[3]; s.Action != PanicCloseCloseFuturesPosition || s.Symbol != "BTCUSDT" || s.Side != OrderSideBuy || s.Qty != 0.5 {
+		t.Error("wrong close step", s)
+	}
+
+	report = user.PanicClose(context.Background())
+	if err := report.Err(); err != nil || len(sent) != 5 {
+		t.Fatal("all steps should be sent", err, sent)
+	}
+	for _, s := range report.Accounts[0].Steps {
+		if !s.Done {
+			t.Error("step should be done", s)
+		}
+	}
+	for _, q := range closeQueries {
+		oneWay := q.Get("positionSide") == FuturesPositionSideBoth
+		if q.Get("type") != "MARKET" || oneWay != (q.Get("reduceOnly") == "true") {
+			t.Error("position should be closed by market order, and reduce only is only sent in one-way mode", q)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sent = nil
+	report = PanicClose(ctx, []*User{user}, PanicCloseOptSkipSpot())
+	if len(report.Accounts[0].Steps) != 3 || len(sent) != 0 || report.Err() == nil {
+		t.Error("steps should fail after ctx is done", report.Accounts[0].Steps, sent)
+	}
+}
+
+func TestPanicClose_CancelInFlight(t *testing.T) {
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := `[]`
+		switch r.Method + " " + r.URL.Path {
+		case "GET /fapi/v1/openOrders":
+			body = `[{"symbol":"BTCUSDT","orderId":4}]`
+		case "DELETE /fapi/v1/allOpenOrders":
+			// cancel request hangs until it is cancelled
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	report := user.PanicClose(ctx, PanicCloseOptSkipSpot())
+	if time.Since(start) > 5*time.Second {
+		t.Error("in-flight step should be cancelled by ctx", time.Since(start))
+	}
+	steps := report.Accounts[0].Steps
+	if len(steps) != 1 || steps[0].Done || !errors.Is(steps[0].Err, context.DeadlineExceeded) {
+		t.Error("hung step should fail by ctx error", steps)
+	}
+}
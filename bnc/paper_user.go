@@ -0,0 +1,324 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// PaperQuoter returns the best bid and ask price of symbol,
+// ex. from rest order book or ws book ticker.
+type PaperQuoter func(pairType cex.PairType, symbol string) (bid, ask float64, err error)
+
+// NewPaperQuoter quotes by rest order book of clt.
+func NewPaperQuoter(clt *PublicClient, opts ...cex.CltOpt) PaperQuoter {
+	return func(pairType cex.PairType, symbol string) (bid, ask float64, err error) {
+		query := clt.SpotOrderBook
+		if pairType == cex.PairTypeFutures {
+			query = clt.FuturesOrderBook
+		}
+		_, book, reqErr := query(symbol, 5, opts...)
+		if reqErr.IsNotNil() {
+			return 0, 0, &reqErr
+		}
+		if len(book.Bids) == 0 || len(book.Asks) == 0 {
+			return 0, 0, fmt.Errorf("bnc: paper quoter, %v %v order book is empty", pairType, symbol)
+		}
+		if bid, err = book.Bids[0].P(); err != nil {
+			return
+		}
+		ask, err = book.Asks[0].P()
+		return
+	}
+}
+
+type PaperUserOpt func(*PaperUser)
+
+// PaperUserOptQuoter replaces default quoter, which queries rest order book.
+func PaperUserOptQuoter(quoter PaperQuoter) PaperUserOpt {
+	return func(u *PaperUser) {
+		u.quoter = quoter
+	}
+}
+
+// PaperUserOptCommissionRates sets maker and taker commission rates, default 0.001.
+func PaperUserOptCommissionRates(maker, taker float64) PaperUserOpt {
+	return func(u *PaperUser) {
+		u.makerRate = maker
+		u.takerRate = taker
+	}
+}
+
+func PaperUserOptWaitOrderOpts(opts ...cex.WaitOrderOpt) PaperUserOpt {
+	return func(u *PaperUser) {
+		u.waitOrderOpts = opts
+	}
+}
+
+const paperDefaultCommissionRate = 0.001
+
+// PaperUser implements cex.Trader without real execution,
+// so strategies can be tested against live market data.
+// Orders are simulated by quoter:
+//   - market orders are filled at the best opposite price immediately, as taker
+//   - marketable limit orders are filled at the best opposite price immediately, as taker
+//   - other limit orders are filled at limit price, as maker,
+//     when QueryOrder finds the best opposite price crosses limit price
+//
+// Orders are always filled entirely, and commissions are charged in received asset.
+//
+//	var trader cex.Trader = NewPaperUser()
+//	_, ord, err := trader.NewSpotLimitBuyOrder("ETH", "USDT", 0.01, 1500)
+type PaperUser struct {
+	mux sync.Mutex
+
+	quoter        PaperQuoter
+	makerRate     float64
+	takerRate     float64
+	waitOrderOpts []cex.WaitOrderOpt
+
+	lastOrderId int64
+	lastTradeId int64
+	orders      map[string]*cex.Order
+}
+
+func NewPaperUser(opts ...PaperUserOpt) *PaperUser {
+	u := &PaperUser{
+		makerRate: paperDefaultCommissionRate,
+		takerRate: paperDefaultCommissionRate,
+		orders:    map[string]*cex.Order{},
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	if u.quoter == nil {
+		u.quoter = NewPaperQuoter(NewPublicClient())
+	}
+	return u
+}
+
+// Orders returns copies of all simulated orders.
+func (u *PaperUser) Orders() []cex.Order {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	var orders []cex.Order
+	for _, ord := range u.orders {
+		orders = append(orders, copyPaperOrder(ord))
+	}
+	return orders
+}
+
+func (u *PaperUser) QueryOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	u.mux.Lock()
+	stored, err := u.storedOrder(order)
+	if err.IsNotNil() {
+		u.mux.Unlock()
+		return nil, err
+	}
+	finished, pairType, symbol := stored.IsFinished(), stored.PairType, stored.Symbol
+	u.mux.Unlock()
+
+	var bid, ask float64
+	if !finished {
+		var errQuote error
+		// quote without lock, because quoter may request cex
+		bid, ask, errQuote = u.quoter(pairType, symbol)
+		if errQuote != nil {
+			return nil, cex.RequestError{Err: fmt.Errorf("bnc: paper user, quote, %w", errQuote)}
+		}
+	}
+
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	if !stored.IsFinished() && crossPrice(stored.OrderSide, stored.OriPrice, bid, ask) {
+		u.fill(stored, stored.OriPrice, true)
+	}
+	*order = copyPaperOrder(stored)
+	return nil, cex.RequestError{}
+}
+
+func (u *PaperUser) CancelOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	stored, err := u.storedOrder(order)
+	if err.IsNotNil() {
+		return nil, err
+	}
+	if stored.IsFinished() {
+		return nil, cex.RequestError{Err: fmt.Errorf("bnc: paper user, order %v is %v, %w", stored.OrderId, stored.Status, cex.ErrUnknownOrder)}
+	}
+	stored.Status = cex.OrderStatusCanceled
+	*order = copyPaperOrder(stored)
+	return nil, cex.RequestError{}
+}
+
+func (u *PaperUser) WaitOrder(ctx context.Context, order *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
+	query := func(ctx context.Context, ord *cex.Order) cex.RequestError {
+		_, err := u.QueryOrder(ord, opts...)
+		return err
+	}
+	return cex.WaitOrder(ctx, order, query, u.waitOrderOpts...)
+}
+
+func (u *PaperUser) NewSpotOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeSpot, asset, quote, tradeType, orderSide, qty, price)
+}
+
+func (u *PaperUser) NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (u *PaperUser) NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (u *PaperUser) NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (u *PaperUser) NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+func (u *PaperUser) NewFuturesOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeFutures, asset, quote, tradeType, orderSide, qty, price)
+}
+
+func (u *PaperUser) NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (u *PaperUser) NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (u *PaperUser) NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (u *PaperUser) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+func (u *PaperUser) newOrd(pairType cex.PairType, asset, quote string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64) (*cex.Response, *cex.Order, cex.RequestError) {
+	if qty <= 0 {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: paper user, invalid qty %v", qty)}
+	}
+	if orderType == cex.OrderTypeLimit && price <= 0 {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: paper user, invalid limit price %v", price)}
+	}
+	if orderType != cex.OrderTypeLimit && orderType != cex.OrderTypeMarket {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: paper user, unknown order type %v", orderType)}
+	}
+	if orderSide != cex.OrderSideBuy && orderSide != cex.OrderSideSell {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: paper user, unknown order side %v", orderSide)}
+	}
+	symbol := asset + quote
+	bid, ask, errQuote := u.quoter(pairType, symbol)
+	if errQuote != nil {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: paper user, quote, %w", errQuote)}
+	}
+
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	u.lastOrderId++
+	id := strconv.FormatInt(u.lastOrderId, 10)
+	ord := &cex.Order{
+		Cex:           cex.BINANCE,
+		PairType:      pairType,
+		OrderType:     orderType,
+		OrderSide:     orderSide,
+		Symbol:        symbol,
+		ClientOrderId: "paper-" + id,
+		OriQty:        qty,
+		OriPrice:      price,
+		OrderId:       id,
+		Status:        cex.OrderStatusNew,
+		Commissions:   map[string]float64{},
+		RawOrder:      paperRawOrder{Asset: asset, Quote: quote},
+	}
+	if orderType == cex.OrderTypeLimit {
+		ord.TimeInForce = string(TimeInForceGtc)
+	}
+	if orderType == cex.OrderTypeMarket || crossPrice(orderSide, price, bid, ask) {
+		fillPrice := ask
+		if orderSide == cex.OrderSideSell {
+			fillPrice = bid
+		}
+		u.fill(ord, fillPrice, false)
+	}
+	u.orders[id] = ord
+	o := copyPaperOrder(ord)
+	return nil, &o, cex.RequestError{}
+}
+
+// paperRawOrder is RawOrder of orders simulated by PaperUser.
+type paperRawOrder struct {
+	Asset string
+	Quote string
+}
+
+// fill fills ord entirely at price.
+func (u *PaperUser) fill(ord *cex.Order, price float64, isMaker bool) {
+	rate := u.takerRate
+	if isMaker {
+		rate = u.makerRate
+	}
+	raw, _ := ord.RawOrder.(paperRawOrder)
+	quoteQty := price * ord.OriQty
+	commission, commissionAsset := quoteQty*rate, raw.Quote
+	if ord.PairType == cex.PairTypeSpot && ord.OrderSide == cex.OrderSideBuy {
+		commission, commissionAsset = ord.OriQty*rate, raw.Asset
+	}
+	u.lastTradeId++
+	ord.AddFills(cex.Fill{
+		TradeId:         strconv.FormatInt(u.lastTradeId, 10),
+		Price:           price,
+		Qty:             ord.OriQty,
+		Commission:      commission,
+		CommissionAsset: commissionAsset,
+		IsMaker:         isMaker,
+		Time:            time.Now().UnixMilli(),
+		Cex:             ord.Cex,
+		PairType:        ord.PairType,
+		Symbol:          ord.Symbol,
+		OrderId:         ord.OrderId,
+		OrderSide:       ord.OrderSide,
+		QuoteQty:        quoteQty,
+	})
+	ord.Status = cex.OrderStatusFilled
+}
+
+func (u *PaperUser) storedOrder(order *cex.Order) (*cex.Order, cex.RequestError) {
+	if order == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	stored, ok := u.orders[order.OrderId]
+	if !ok {
+		return nil, cex.RequestError{Err: fmt.Errorf("bnc: paper user, order %v, %w", order.OrderId, cex.ErrUnknownOrder)}
+	}
+	return stored, cex.RequestError{}
+}
+
+// crossPrice returns true, if limit order of side and price can be filled by the best bid and ask.
+func crossPrice(side cex.OrderSide, price, bid, ask float64) bool {
+	if side == cex.OrderSideBuy {
+		return ask > 0 && ask <= price
+	}
+	return bid > 0 && bid >= price
+}
+
+func copyPaperOrder(ord *cex.Order) cex.Order {
+	o := *ord
+	o.Fills = append([]cex.Fill(nil), ord.Fills...)
+	o.Commissions = map[string]float64{}
+	for asset, c := range ord.Commissions {
+		o.Commissions[asset] = c
+	}
+	return o
+}
@@ -0,0 +1,78 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestPaperUser(t *testing.T) {
+	var mux sync.Mutex
+	bid, ask := 1499.0, 1501.0
+	quoter := func(pairType cex.PairType, symbol string) (float64, float64, error) {
+		mux.Lock()
+		defer mux.Unlock()
+		return bid, ask, nil
+	}
+	var trader cex.Trader = NewPaperUser(
+		PaperUserOptQuoter(quoter),
+		PaperUserOptCommissionRates(0.0001, 0.001),
+		PaperUserOptWaitOrderOpts(cex.WaitOrderOptPollInterval(10*time.Millisecond)),
+	)
+
+	_, ord, err := trader.NewSpotMarketBuyOrder("ETH", "USDT", 2)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if ord.Status != cex.OrderStatusFilled || ord.FilledAvgPrice != ask || ord.FilledQty != 2 || ord.Commission("ETH") != 0.002 {
+		t.Error("market buy order should be filled at ask as taker", ord)
+	}
+
+	_, ord, err = trader.NewFuturesLimitSellOrder("ETH", "USDT", 1, 1490)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if ord.Status != cex.OrderStatusFilled || ord.FilledAvgPrice != bid || ord.Commission("USDT") != bid*0.001 {
+		t.Error("marketable limit sell order should be filled at bid", ord)
+	}
+
+	_, ord, err = trader.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1400)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if ord.Status != cex.OrderStatusNew || ord.OrderId == "" {
+		t.Fatal("limit buy order should be resting", ord)
+	}
+	if _, err := trader.QueryOrder(ord); err.IsNotNil() || ord.Status != cex.OrderStatusNew {
+		t.Error("limit buy order should not be filled", ord, err.Error())
+	}
+
+	ch := trader.WaitOrder(context.Background(), ord)
+	mux.Lock()
+	ask = 1399
+	mux.Unlock()
+	if err := <-ch; err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if ord.Status != cex.OrderStatusFilled || ord.FilledAvgPrice != 1400 || !ord.Fills[0].IsMaker || ord.Commission("ETH") != 0.0001 {
+		t.Error("limit buy order should be filled at limit price as maker", ord)
+	}
+
+	_, ord, _ = trader.NewSpotLimitSellOrder("ETH", "USDT", 1, 2000)
+	if _, err := trader.CancelOrder(ord); err.IsNotNil() || ord.Status != cex.OrderStatusCanceled {
+		t.Error("limit sell order should be canceled", ord, err.Error())
+	}
+	if _, err := trader.CancelOrder(ord); !errors.Is(err.Err, cex.ErrUnknownOrder) {
+		t.Error("finished order can not be canceled", err.Error())
+	}
+	if _, err := trader.QueryOrder(&cex.Order{OrderId: "unknown"}); !errors.Is(err.Err, cex.ErrUnknownOrder) {
+		t.Error("unknown order should not be found", err.Error())
+	}
+	if _, _, err := trader.NewSpotLimitBuyOrder("ETH", "USDT", 0, 1400); err.IsNil() {
+		t.Error("zero qty should be rejected")
+	}
+}
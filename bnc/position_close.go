@@ -0,0 +1,193 @@
+package bnc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Position Close
+// ------------------------------------------------------------
+
+var ErrNoFuturesPosition = errors.New("bnc: no futures position")
+
+// CloseFuturesPosition closes pct of every um futures position of symbol by market orders, pct is in (0, 1].
+// Side and position side of orders are decided by position risk, not by user options,
+// so orders can only reduce positions: reduce only is sent in one-way mode,
+// and closing order of LONG or SHORT can not open the opposite side in hedge mode.
+// If pct is 1, the whole position amount is closed,
+// otherwise qty is rounded down to market step size of symbol, by filters of order validator set by UserOptOrderValidator,
+// or by futures exchange info, if validator is not set or does not know symbol.
+// Positions whose rounded qty is 0 are skipped.
+// Positions are closed one by one, and it returns at the first error, with orders placed before.
+// resp is response of the last order.
+func (u *User) CloseFuturesPosition(symbol string, pct float64, opts ...cex.CltOpt) (*cex.Response, []*cex.Order, cex.RequestError) {
+	return u.closeFuturesPositions(symbol, "", pct, opts...)
+}
+
+// CloseFuturesPositionSide is CloseFuturesPosition of long or short position only,
+// in one-way mode, position is closed only if its direction is posSide.
+func (u *User) CloseFuturesPositionSide(symbol string, posSide FuturesPositionSide, pct float64, opts ...cex.CltOpt) (*cex.Response, []*cex.Order, cex.RequestError) {
+	if posSide != FuturesPositionSideLong && posSide != FuturesPositionSideShort {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: close futures position side %q, %w", posSide, cex.ErrInvalidParams)}
+	}
+	return u.closeFuturesPositions(symbol, posSide, pct, opts...)
+}
+
+func (u *User) closeFuturesPositions(symbol string, posSide FuturesPositionSide, pct float64, opts ...cex.CltOpt) (*cex.Response, []*cex.Order, cex.RequestError) {
+	if pct <= 0 || pct > 1 {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: close futures position pct %v is out of (0, 1], %w", pct, cex.ErrInvalidParams)}
+	}
+	resp, positions, err := u.closablePositions(symbol, posSide, opts...)
+	if err.IsNotNil() {
+		return resp, nil, err
+	}
+	var step float64
+	if pct < 1 {
+		resp, step, err = u.closeQtyStep(symbol, opts...)
+		if err.IsNotNil() {
+			return resp, nil, err
+		}
+	}
+	var orders []*cex.Order
+	for _, p := range positions {
+		side, qty := OrderSideSell, p.AbsPositionAmt()
+		if p.SignPositionAmt < 0 {
+			side = OrderSideBuy
+		}
+		if pct < 1 {
+			if qty = RoundToStep(qty*pct, step, true); qty <= 0 {
+				continue
+			}
+			var errValidate cex.RequestError
+			qty, _, errValidate = u.validateOrd(cex.PairTypeFutures, symbol, cex.OrderTypeMarket, qty, 0)
+			if errValidate.IsNotNil() {
+				return resp, orders, errValidate
+			}
+		}
+		var ord *cex.Order
+		resp, ord, err = u.closeFuturesPosition(symbol, FuturesPositionSide(p.PositionSide), side, qty, opts...)
+		if err.IsNotNil() {
+			return resp, orders, err
+		}
+		orders = append(orders, ord)
+	}
+	return resp, orders, cex.RequestError{}
+}
+
+// closeQtyStep returns market step size of symbol by order validator of user,
+// or by futures exchange info, if validator does not know symbol.
+// Error wraps cex.ErrInvalidParams, if step size is unknown.
+func (u *User) closeQtyStep(symbol string, opts ...cex.CltOpt) (*cex.Response, float64, cex.RequestError) {
+	var filters SymbolFilters
+	var ok bool
+	if u.cfg.orderValidator != nil {
+		filters, ok = u.cfg.orderValidator.Filters(cex.PairTypeFutures, symbol)
+	}
+	var resp *cex.Response
+	if !ok {
+		var info ExchangeInfo
+		var err cex.RequestError
+		resp, info, err = cex.Request(u, FuturesExchangeInfosConfig, nil, opts...)
+		if err.IsNotNil() {
+			return resp, 0, err
+		}
+		for _, syb := range info.Symbols {
+			if syb.Symbol != symbol {
+				continue
+			}
+			var errParse error
+			if filters, errParse = ParseSymbolFilters(syb); errParse != nil {
+				return resp, 0, cex.RequestError{Err: errParse}
+			}
+			ok = true
+			break
+		}
+	}
+	step := filters.MarketStepSize
+	if step <= 0 {
+		step = filters.StepSize
+	}
+	if !ok || step <= 0 {
+		return resp, 0, cex.RequestError{Err: fmt.Errorf("bnc: close futures position, step size of %v is unknown, %w", symbol, cex.ErrInvalidParams)}
+	}
+	return resp, step, cex.RequestError{}
+}
+
+// CloseFuturesPositionAtStop places order with closePosition=true, which closes the whole position of posSide,
+// when stopPrice is triggered, ex. stop loss or take profit of position.
+// posSide is required in hedge mode, if both sides have positions, and it can be empty in one-way mode.
+// Side of order is decided by position risk, and order type is STOP_MARKET,
+// if stopPrice is on the losing side of mark price, otherwise it is TAKE_PROFIT_MARKET.
+// Binance accepts closePosition with these two types only, so immediate full close should use CloseFuturesPosition.
+func (u *User) CloseFuturesPositionAtStop(symbol string, posSide FuturesPositionSide, stopPrice float64, workingType FuturesWorkingType, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	resp, positions, err := u.closablePositions(symbol, posSide, opts...)
+	if err.IsNotNil() {
+		return resp, nil, err
+	}
+	if len(positions) > 1 {
+		return resp, nil, cex.RequestError{Err: fmt.Errorf("bnc: %v has long and short positions, position side is required, %w", symbol, cex.ErrInvalidParams)}
+	}
+	p := positions[0]
+	isLong := p.SignPositionAmt > 0
+	order := FuturesAlgoOrder{
+		Symbol:        symbol,
+		Type:          OrderTypeTakeProfitMarket,
+		Side:          cex.OrderSideSell,
+		StopPrice:     stopPrice,
+		WorkingType:   workingType,
+		ClosePosition: true,
+	}
+	if !isLong {
+		order.Side = cex.OrderSideBuy
+	}
+	if isLong == (stopPrice < p.MarkPrice) {
+		order.Type = OrderTypeStopMarket
+	}
+	if errValidate := order.Validate(); errValidate != nil {
+		return resp, nil, cex.RequestError{Err: errValidate}
+	}
+	cltOrdId := u.registerNewOrd(cex.PairTypeFutures, symbol, cex.OrderType(order.Type), order.Side, 0, 0, "")
+	resp, rawOrd, err := u.routeNewFuturesOrder(order.params(FuturesPositionSide(p.PositionSide), cltOrdId), opts...)
+	ord := SwitchFutureOrderToCexOrder(rawOrd)
+	ord.ApiKey = u.api.ApiKey
+	u.resolveNewOrd(&ord, symbol, cltOrdId, resp, err)
+	return resp, &ord, err
+}
+
+// closablePositions returns non-zero positions of symbol, and of posSide, if it is not empty.
+// Error wraps ErrNoFuturesPosition, if there is no position.
+func (u *User) closablePositions(symbol string, posSide FuturesPositionSide, opts ...cex.CltOpt) (*cex.Response, []FuturesPosition, cex.RequestError) {
+	if u.cfg.isPortfolioMarginAccount {
+		return nil, nil, cex.RequestError{Err: errors.New("bnc: close futures position, portfolio margin account is not supported")}
+	}
+	if symbol == "" {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: close futures position, empty symbol, %w", cex.ErrInvalidParams)}
+	}
+	resp, positions, err := u.FuturesPositions(symbol, opts...)
+	if err.IsNotNil() {
+		return resp, nil, err
+	}
+	var closable []FuturesPosition
+	for _, p := range positions {
+		if p.Symbol != symbol || p.SignPositionAmt == 0 {
+			continue
+		}
+		// position of BOTH side is long or short by sign of amount in one-way mode
+		isLong := p.PositionSide == string(FuturesPositionSideLong) || p.PositionSide == string(FuturesPositionSideBoth) && p.SignPositionAmt > 0
+		if posSide == FuturesPositionSideLong && !isLong || posSide == FuturesPositionSideShort && isLong {
+			continue
+		}
+		closable = append(closable, p)
+	}
+	if len(closable) == 0 {
+		return resp, nil, cex.RequestError{Err: fmt.Errorf("%w, %v %v", ErrNoFuturesPosition, symbol, posSide)}
+	}
+	return resp, closable, cex.RequestError{}
+}
+
+// ------------------------------------------------------------
+// Position Close
+// ============================================================
@@ -0,0 +1,122 @@
+package bnc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestUser_CloseFuturesPosition(t *testing.T) {
+	positions := `[{"symbol":"ETHUSDT","positionSide":"LONG","positionAmt":"1.5","markPrice":"2000"},{"symbol":"ETHUSDT","positionSide":"SHORT","positionAmt":"-0.7","markPrice":"2000"}]`
+	var orderQueries []url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := `{"symbol":"ETHUSDT","orderId":1,"status":"NEW"}`
+		switch r.URL.Path {
+		case "/fapi/v2/positionRisk":
+			body = positions
+		case "/fapi/v1/order":
+			orderQueries = append(orderQueries, r.URL.Query())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	v := NewOrderValidator()
+	v.filters[cex.PairTypeFutures] = map[string]SymbolFilters{"ETHUSDT": {Symbol: "ETHUSDT", StepSize: 0.01}}
+	user := NewUser("key", "secret", UserOptTransport(transport), UserOptOrderValidator(v))
+
+	_, orders, err := user.CloseFuturesPosition("ETHUSDT", 0.333)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(orders) != 2 || len(orderQueries) != 2 {
+		t.Fatal("both sides should be closed", orderQueries)
+	}
+	if q := orderQueries[0]; q.Get("side") != "SELL" || q.Get("positionSide") != "LONG" || q.Get("quantity") != "0.49" || q.Has("reduceOnly") {
+		t.Error("long should be closed by sell, and qty should be rounded down", q)
+	}
+	if q := orderQueries[1]; q.Get("side") != "BUY" || q.Get("positionSide") != "SHORT" || q.Get("quantity") != "0.23" {
+		t.Error("short should be closed by buy", q)
+	}
+
+	orderQueries = nil
+	if _, _, err = user.CloseFuturesPositionSide("ETHUSDT", FuturesPositionSideShort, 1); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(orderQueries) != 1 || orderQueries[0].Get("quantity") != "0.7" {
+		t.Error("whole short position should be closed only", orderQueries)
+	}
+
+	orderQueries = nil
+	if _, _, err = user.CloseFuturesPositionAtStop("ETHUSDT", "", 1900, FuturesWorkingTypeMarkPrice); !errors.Is(err.Err, cex.ErrInvalidParams) {
+		t.Error("position side is required, if both sides have positions", err.Error())
+	}
+	if _, _, err = user.CloseFuturesPositionAtStop("ETHUSDT", FuturesPositionSideShort, 1900, FuturesWorkingTypeMarkPrice); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if q := orderQueries[0]; q.Get("type") != "TAKE_PROFIT_MARKET" || q.Get("side") != "BUY" || q.Get("closePosition") != "true" || q.Has("quantity") {
+		t.Error("stop below mark price should take profit of short", q)
+	}
+
+	positions = `[{"symbol":"ETHUSDT","positionSide":"BOTH","positionAmt":"-0.7","markPrice":"2000"}]`
+	orderQueries = nil
+	if _, _, err = user.CloseFuturesPosition("ETHUSDT", 1); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if q := orderQueries[0]; q.Get("side") != "BUY" || q.Get("reduceOnly") != "true" || q.Get("quantity") != "0.7" {
+		t.Error("one-way position should be closed by reduce only order", q)
+	}
+	if _, _, err = user.CloseFuturesPositionSide("ETHUSDT", FuturesPositionSideLong, 1); !errors.Is(err.Err, ErrNoFuturesPosition) {
+		t.Error("short one-way position should not be closed as long", err.Error())
+	}
+	if _, _, err = user.CloseFuturesPositionAtStop("ETHUSDT", "", 2100, ""); err.IsNotNil() || orderQueries[1].Get("type") != "STOP_MARKET" {
+		t.Error("stop above mark price should stop loss of short", orderQueries, err.Error())
+	}
+	if _, _, err = user.CloseFuturesPosition("ETHUSDT", 1.5); !errors.Is(err.Err, cex.ErrInvalidParams) {
+		t.Error("pct should be in (0, 1]", err.Error())
+	}
+}
+
+func TestUser_CloseFuturesPosition_NoValidator(t *testing.T) {
+	var orderQueries []url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := `{"symbol":"ETHUSDT","orderId":1,"status":"NEW"}`
+		switch r.URL.Path {
+		case "/fapi/v2/positionRisk":
+			body = `[{"symbol":"ETHUSDT","positionSide":"LONG","positionAmt":"0.123"},{"symbol":"ETHUSDT","positionSide":"SHORT","positionAmt":"-0.001"},` +
+				`{"symbol":"BTCUSDT","positionSide":"BOTH","positionAmt":"0.5"}]`
+		case "/fapi/v1/exchangeInfo":
+			body = `{"symbols":[{"symbol":"ETHUSDT","contractType":"PERPETUAL","filters":[` +
+				`{"filterType":"LOT_SIZE","stepSize":"0.001","minQty":"0.001","maxQty":"10000"},` +
+				`{"filterType":"MARKET_LOT_SIZE","stepSize":"0.001","minQty":"0.001","maxQty":"1000"}]}]}`
+		case "/fapi/v1/order":
+			orderQueries = append(orderQueries, r.URL.Query())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	_, orders, err := user.CloseFuturesPosition("ETHUSDT", 0.5)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(orders) != 1 || len(orderQueries) != 1 || orderQueries[0].Get("quantity") != "0.061" {
+		t.Error("qty should be rounded down to step size of exchange info, and zero qty should be skipped", orderQueries)
+	}
+	if _, _, err = user.CloseFuturesPosition("BTCUSDT", 0.5); !errors.Is(err.Err, cex.ErrInvalidParams) || len(orderQueries) != 1 {
+		t.Error("pct < 1 should be rejected, if step size is unknown", err.Error())
+	}
+}
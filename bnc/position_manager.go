@@ -0,0 +1,189 @@
+package bnc
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/dwdwow/cex"
+)
+
+type positionKey struct {
+	symbol string
+	side   FuturesPositionSide
+}
+
+// PositionManager caches usd-m futures positions of user,
+// and changes leverage, margin type and isolated margin of them.
+// Positions are refreshed by Refresh, and by methods changing them.
+// Portfolio margin account is not supported.
+//
+//	pm := NewPositionManager(user)
+//	err := pm.Refresh()
+//	pos, ok := pm.Position("ETHUSDT", FuturesPositionSideBoth)
+type PositionManager struct {
+	user *User
+
+	mux       sync.RWMutex
+	positions map[positionKey]FuturesPosition
+	adl       map[string]FuturesAdlQuantileValues
+}
+
+func NewPositionManager(user *User) *PositionManager {
+	return &PositionManager{
+		user:      user,
+		positions: map[positionKey]FuturesPosition{},
+		adl:       map[string]FuturesAdlQuantileValues{},
+	}
+}
+
+// Refresh queries positions of all symbols, and replaces cached positions.
+// Zero positions are not cached.
+func (m *PositionManager) Refresh(opts ...cex.CltOpt) cex.RequestError {
+	_, positions, err := m.user.FuturesPositions("", opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.positions = map[positionKey]FuturesPosition{}
+	for _, p := range positions {
+		m.setPosition(p)
+	}
+	return cex.RequestError{}
+}
+
+// RefreshSymbol queries positions of symbol, and replaces cached positions of symbol.
+func (m *PositionManager) RefreshSymbol(symbol string, opts ...cex.CltOpt) cex.RequestError {
+	_, positions, err := m.user.FuturesPositions(symbol, opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	for k := range m.positions {
+		if k.symbol == symbol {
+			delete(m.positions, k)
+		}
+	}
+	for _, p := range positions {
+		m.setPosition(p)
+	}
+	return cex.RequestError{}
+}
+
+func (m *PositionManager) setPosition(p FuturesPosition) {
+	if p.SignPositionAmt == 0 {
+		return
+	}
+	m.positions[positionKey{p.Symbol, FuturesPositionSide(p.PositionSide)}] = p
+}
+
+// Positions returns cached positions sorted by symbol and position side.
+func (m *PositionManager) Positions() []FuturesPosition {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	positions := make([]FuturesPosition, 0, len(m.positions))
+	for _, p := range m.positions {
+		positions = append(positions, p)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Symbol != positions[j].Symbol {
+			return positions[i].Symbol < positions[j].Symbol
+		}
+		return positions[i].PositionSide < positions[j].PositionSide
+	})
+	return positions
+}
+
+// Position returns cached position of symbol and side.
+// side is FuturesPositionSideBoth in one-way mode.
+func (m *PositionManager) Position(symbol string, side FuturesPositionSide) (FuturesPosition, bool) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	p, ok := m.positions[positionKey{symbol, side}]
+	return p, ok
+}
+
+// SetLeverage changes leverage of symbol, and updates leverage of cached positions.
+func (m *PositionManager) SetLeverage(symbol string, leverage int, opts ...cex.CltOpt) cex.RequestError {
+	_, resp, err := m.user.ChangeFuturesLeverage(symbol, leverage, opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	for k, p := range m.positions {
+		if k.symbol == symbol {
+			p.Leverage = float64(resp.Leverage)
+			p.MaxNotionalValue = resp.MaxNotionalValue
+			m.positions[k] = p
+		}
+	}
+	return cex.RequestError{}
+}
+
+// SetMarginType changes margin type of symbol.
+// It is not an error, if margin type is not changed.
+// Binance rejects changing margin type, if symbol has positions or open orders.
+func (m *PositionManager) SetMarginType(symbol string, marginType FuturesMarginType, opts ...cex.CltOpt) cex.RequestError {
+	_, _, err := m.user.ChangeFuturesMarginType(symbol, marginType, opts...)
+	if err.IsNotNil() && !errors.Is(&err, ErrFutureNoNeedToChangeMarginType) {
+		return err
+	}
+	return cex.RequestError{}
+}
+
+// AddMargin adds margin to isolated position, and refreshes positions of symbol.
+func (m *PositionManager) AddMargin(symbol string, side FuturesPositionSide, amount float64, opts ...cex.CltOpt) cex.RequestError {
+	return m.modifyMargin(symbol, side, amount, FuturesAddMargin, opts...)
+}
+
+// ReduceMargin reduces margin of isolated position, and refreshes positions of symbol.
+func (m *PositionManager) ReduceMargin(symbol string, side FuturesPositionSide, amount float64, opts ...cex.CltOpt) cex.RequestError {
+	return m.modifyMargin(symbol, side, amount, FuturesReduceMargin, opts...)
+}
+
+func (m *PositionManager) modifyMargin(symbol string, side FuturesPositionSide, amount float64, modifyType FuturesModifyMarginType, opts ...cex.CltOpt) cex.RequestError {
+	if side == FuturesPositionSideBoth {
+		// positionSide BOTH is rejected in hedge mode, and default in one-way mode
+		side = ""
+	}
+	_, _, err := m.user.ModifyFuturesIsolatedPositionMargin(symbol, side, amount, modifyType, opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	return m.RefreshSymbol(symbol, opts...)
+}
+
+// RefreshAdlQuantiles queries adl quantiles of all positions.
+func (m *PositionManager) RefreshAdlQuantiles(opts ...cex.CltOpt) cex.RequestError {
+	_, quantiles, err := m.user.FuturesAdlQuantiles("", opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.adl = map[string]FuturesAdlQuantileValues{}
+	for _, q := range quantiles {
+		m.adl[q.Symbol] = q.AdlQuantile
+	}
+	return cex.RequestError{}
+}
+
+// AdlQuantile returns cached adl quantile of position of symbol and side.
+func (m *PositionManager) AdlQuantile(symbol string, side FuturesPositionSide) (int, bool) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	q, ok := m.adl[symbol]
+	if !ok {
+		return 0, false
+	}
+	switch side {
+	case FuturesPositionSideLong:
+		return q.Long, true
+	case FuturesPositionSideShort:
+		return q.Short, true
+	}
+	return q.Both, true
+}
@@ -0,0 +1,86 @@
+package bnc
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPositionManager(t *testing.T) {
+	positions := `[
+		{"symbol":"ETHUSDT","positionSide":"BOTH","positionAmt":"1.5","leverage":"10","isolatedMargin":"100"},
+		{"symbol":"BTCUSDT","positionSide":"BOTH","positionAmt":"0","leverage":"20"},
+		{"symbol":"BNBUSDT","positionSide":"BOTH","positionAmt":"-2","leverage":"5"}
+	]`
+	var posted []string
+	bodies := map[string]string{
+		"/fapi/v1/leverage":       `{"symbol":"ETHUSDT","leverage":20,"maxNotionalValue":"1000000"}`,
+		"/fapi/v1/marginType":     `{"code":-4046,"msg":"No need to change margin type."}`,
+		"/fapi/v1/positionMargin": `{"amount":10,"code":200,"msg":"Successfully modify position margin.","type":1}`,
+		"/fapi/v1/adlQuantile":    `[{"symbol":"ETHUSDT","adlQuantile":{"LONG":0,"SHORT":0,"BOTH":3}}]`,
+	}
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := bodies[r.URL.Path]
+		status := http.StatusOK
+		switch r.URL.Path {
+		case "/fapi/v2/positionRisk":
+			body = positions
+		case "/fapi/v1/marginType":
+			status = http.StatusBadRequest
+		}
+		if r.Method == http.MethodPost {
+			posted = append(posted, r.URL.Path+"?"+r.URL.RawQuery)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	pm := NewPositionManager(NewUser("key", "secret", UserOptTransport(transport)))
+
+	if err := pm.Refresh(); err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if ps := pm.Positions(); len(ps) != 2 || ps[0].Symbol != "BNBUSDT" || ps[1].Symbol != "ETHUSDT" {
+		t.Fatal("zero positions should be omitted, and positions should be sorted", ps)
+	}
+	if _, ok := pm.Position("BTCUSDT", FuturesPositionSideBoth); ok {
+		t.Error("zero position should not be cached")
+	}
+
+	if err := pm.SetLeverage("ETHUSDT", 20); err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if p, _ := pm.Position("ETHUSDT", FuturesPositionSideBoth); p.Leverage != 20 || p.MaxNotionalValue != 1000000 {
+		t.Error("leverage of cached position should be updated", p)
+	}
+
+	if err := pm.SetMarginType("ETHUSDT", FuturesMarginTypeIsolated); err.IsNotNil() {
+		t.Error("no need to change margin type should not be error", err.Err)
+	}
+
+	positions = `[{"symbol":"ETHUSDT","positionSide":"BOTH","positionAmt":"1.5","leverage":"20","isolatedMargin":"110"}]`
+	if err := pm.AddMargin("ETHUSDT", FuturesPositionSideBoth, 10); err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if p, _ := pm.Position("ETHUSDT", FuturesPositionSideBoth); p.IsolatedMargin != 110 {
+		t.Error("position should be refreshed after modifying margin", p)
+	}
+	if _, ok := pm.Position("BNBUSDT", FuturesPositionSideBoth); !ok {
+		t.Error("positions of other symbols should be kept")
+	}
+	last := posted[len(posted)-1]
+	if !strings.Contains(last, "type=1") || !strings.Contains(last, "amount=10") || strings.Contains(last, "positionSide") {
+		t.Error("wrong position margin params", last)
+	}
+
+	if err := pm.RefreshAdlQuantiles(); err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if q, ok := pm.AdlQuantile("ETHUSDT", FuturesPositionSideBoth); !ok || q != 3 {
+		t.Error("wrong adl quantile", q, ok)
+	}
+}
@@ -1,6 +1,7 @@
 package bnc
 
 import (
+	"context"
 	"errors"
 
 	"github.com/dwdwow/cex"
@@ -38,6 +39,70 @@ func QueryFuturesExchangeInfo() (ExchangeInfo, error) {
 	return queryExchangeInfo(FuturesExchangeInfosConfig)
 }
 
+func queryServerTime(config cex.ReqConfig[cex.NilReqData, ServerTime]) (int64, error) {
+	_, t, err := cex.Request(emptyUser, config, nil)
+	if err.IsNotNil() {
+		return 0, errors.New(err.Error())
+	}
+	return t.ServerTime, nil
+}
+
+// QuerySpotServerTime returns spot server time, unit is millisecond.
+func QuerySpotServerTime() (int64, error) {
+	return queryServerTime(SpotServerTimeConfig)
+}
+
+// QueryFuturesServerTime returns futures server time, unit is millisecond.
+func QueryFuturesServerTime() (int64, error) {
+	return queryServerTime(FuturesServerTimeConfig)
+}
+
+// NewSpotTimeSync creates cex.TimeSync by spot server time.
+// Should call Sync or Start before using it.
+func NewSpotTimeSync() *cex.TimeSync {
+	return cex.NewTimeSync(QuerySpotServerTime)
+}
+
+// NewFuturesTimeSync creates cex.TimeSync by futures server time.
+func NewFuturesTimeSync() *cex.TimeSync {
+	return cex.NewTimeSync(QueryFuturesServerTime)
+}
+
+// PingSpot pings spot api by server time endpoint.
+func PingSpot() cex.PingResult {
+	return cex.Ping(QuerySpotServerTime)
+}
+
+// PingFutures pings futures api by server time endpoint.
+func PingFutures() cex.PingResult {
+	return cex.Ping(QueryFuturesServerTime)
+}
+
+// HealthCheck pings spot and futures api concurrently, results are keyed by pair type.
+func HealthCheck(ctx context.Context) map[cex.PairType]cex.PingResult {
+	results := cex.HealthCheck(ctx, map[string]cex.ServerTimeQuerier{
+		string(cex.PairTypeSpot):    QuerySpotServerTime,
+		string(cex.PairTypeFutures): QueryFuturesServerTime,
+	})
+	return map[cex.PairType]cex.PingResult{
+		cex.PairTypeSpot:    results[string(cex.PairTypeSpot)],
+		cex.PairTypeFutures: results[string(cex.PairTypeFutures)],
+	}
+}
+
+// warmupBaseUrls are base urls warmed up by their ping paths.
+var warmupBaseUrls = [][2]string{{ApiBaseUrl, ApiV3 + "/ping"}, {FapiBaseUrl, FapiV1 + "/ping"}}
+
+// Warmup establishes connections of shared transport to spot and futures api, and pings them.
+// Use User.Warmup for users with their own transport or testnet.
+func Warmup(ctx context.Context, opts ...cex.WarmupOpt) []cex.WarmupResult {
+	urls := make([]string, 0, len(warmupBaseUrls))
+	for _, u := range warmupBaseUrls {
+		urls = append(urls, u[0]+u[1])
+	}
+	return cex.Warmup(ctx, urls, append([]cex.WarmupOpt{cex.WarmupOptPing()}, opts...)...)
+}
+
 func queryPairs(exInfoQuerier func() (ExchangeInfo, error)) (pairs []cex.Pair, info ExchangeInfo, err error) {
 	info, err = exInfoQuerier()
 	if err != nil {
@@ -118,6 +183,11 @@ func QueryFundingRates() ([]FuturesFundingRate, error) {
 	return queryInfoAboutFundingRate(FuturesFundingRatesConfig, FuturesFundingRatesParams{Symbol: ""})
 }
 
+// QueryPremiumIndex queries mark price, index price and funding rate of futures symbol.
+func QueryPremiumIndex(symbol string) (FuturesFundingRate, error) {
+	return queryInfoAboutFundingRate(FuturesPremiumIndexConfig, FuturesPremiumIndexParams{Symbol: symbol})
+}
+
 func queryKline(config cex.ReqConfig[KlineParams, []Kline], symbol string, interval KlineInterval, start, end, limit int64) ([]Kline, error) {
 	_, res, err := cex.Request(emptyUser, config, KlineParams{
 		Symbol:    symbol,
@@ -146,6 +216,12 @@ func QueryFuturesKlineWithLimit(symbol string, interval KlineInterval, start, en
 	return queryKline(FuturesKlineConfig, symbol, interval, start, end, limit)
 }
 
+// QueryFuturesMarkPriceKline
+// limit, default 500, max 1500
+func QueryFuturesMarkPriceKline(symbol string, interval KlineInterval, start, end, limit int64) ([]Kline, error) {
+	return queryKline(FuturesMarkPriceKlineConfig, symbol, interval, start, end, limit)
+}
+
 func QueryPortfolioMarginCollateralRates() ([]PortfolioMarginCollateralRate, error) {
 	_, data, reqErr := cex.Request(emptyUser, PortfolioMarginCollateralRatesConfig, nil)
 	if reqErr.IsNotNil() {
@@ -180,3 +256,21 @@ func QueryCMPremiumIndex(symbol, pair string) ([]CMPremiumIndex, error) {
 	}
 	return data, nil
 }
+
+func QueryOptionExchangeInfo() (OptionExchangeInfo, error) {
+	_, info, err := cex.Request(emptyUser, OptionExchangeInfoConfig, nil)
+	if err.IsNotNil() {
+		return OptionExchangeInfo{}, errors.New(err.Error())
+	}
+	return info, nil
+}
+
+// QueryOptionMarkPrices returns mark prices and greeks of options.
+// If symbol is empty, all symbols are returned.
+func QueryOptionMarkPrices(symbol string) ([]OptionMarkPrice, error) {
+	_, prices, err := cex.Request(emptyUser, OptionMarkPriceConfig, OptionMarkPriceParams{Symbol: symbol})
+	if err.IsNotNil() {
+		return nil, errors.New(err.Error())
+	}
+	return prices, nil
+}
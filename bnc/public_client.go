@@ -0,0 +1,281 @@
+package bnc
+
+import (
+	"context"
+
+	"github.com/dwdwow/cex"
+)
+
+type PublicClientOpt func(*publicClientConfig)
+
+type publicClientConfig struct {
+	testnet   bool
+	logger    cex.Logger
+	transport cex.Transport
+	selector  cex.BaseUrlSelector
+	backend   cex.Backend
+}
+
+// PublicClientOptTestnet makes requests to binance testnet.
+func PublicClientOptTestnet() PublicClientOpt {
+	return func(c *publicClientConfig) {
+		c.testnet = true
+	}
+}
+
+// PublicClientOptBaseUrlSelector sends requests to base urls selected by selector,
+// ex. NewBaseUrlFailover().
+func PublicClientOptBaseUrlSelector(selector cex.BaseUrlSelector) PublicClientOpt {
+	return func(c *publicClientConfig) {
+		c.selector = selector
+	}
+}
+
+// PublicClientOptLogger logs requests made by client with logger.
+func PublicClientOptLogger(logger cex.Logger) PublicClientOpt {
+	return func(c *publicClientConfig) {
+		c.logger = logger
+	}
+}
+
+// PublicClientOptTransport sends requests made by client by transport,
+// ex. cex.NewTransport(cex.TransportOptProxy(proxy)).
+func PublicClientOptTransport(transport cex.Transport) PublicClientOpt {
+	return func(c *publicClientConfig) {
+		c.transport = transport
+	}
+}
+
+// PublicClientOptBackend sends requests made by client by backend,
+// ex. cex.BackendNetHTTP for latency sensitive callers.
+func PublicClientOptBackend(backend cex.Backend) PublicClientOpt {
+	return func(c *publicClientConfig) {
+		c.backend = backend
+	}
+}
+
+// PublicClient queries binance market data without api keys.
+// It can also be passed to cex.Request as ReqMaker of any public ReqConfig.
+//
+//	clt := NewPublicClient()
+//	_, book, err := clt.SpotOrderBook("ETHUSDT", 100)
+type PublicClient struct {
+	*cex.PublicReqMaker
+}
+
+func NewPublicClient(opts ...PublicClientOpt) *PublicClient {
+	cfg := publicClientConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var makerOpts []cex.PublicReqMakerOpt
+	if cfg.testnet {
+		makerOpts = append(makerOpts, cex.PublicReqMakerOptBaseUrlRewriter(TestnetBaseUrl))
+	}
+	if cfg.logger != nil {
+		makerOpts = append(makerOpts, cex.PublicReqMakerOptLogger(cfg.logger))
+	}
+	if cfg.transport != nil {
+		makerOpts = append(makerOpts, cex.PublicReqMakerOptTransport(cfg.transport))
+	}
+	if cfg.selector != nil {
+		makerOpts = append(makerOpts, cex.PublicReqMakerOptBaseUrlSelector(cfg.selector))
+	}
+	if cfg.backend != "" {
+		makerOpts = append(makerOpts, cex.PublicReqMakerOptBackend(cfg.backend))
+	}
+	return &PublicClient{cex.NewPublicReqMaker(makerOpts...)}
+}
+
+// WithContext returns a shallow copy of client bound to ctx.
+func (c *PublicClient) WithContext(ctx context.Context) *PublicClient {
+	return &PublicClient{c.PublicReqMaker.WithContext(ctx)}
+}
+
+// ParseRateLimitUsage implements cex.RateLimitUsageParser.
+func (c *PublicClient) ParseRateLimitUsage(resp *cex.Response) *cex.RateLimitUsage {
+	return ParseRateLimitUsage(resp)
+}
+
+// ParseRateLimitError implements cex.RateLimitErrorParser.
+func (c *PublicClient) ParseRateLimitError(resp *cex.Response, reqErr cex.RequestError) *cex.RateLimitError {
+	return ParseRateLimitError(resp, reqErr)
+}
+
+// ============================================================
+// Market Data
+// ------------------------------------------------------------
+
+func (c *PublicClient) SpotPing(opts ...cex.CltOpt) (*cex.Response, struct{}, cex.RequestError) {
+	return cex.Request(c, SpotPingConfig, nil, opts...)
+}
+
+func (c *PublicClient) SpotServerTime(opts ...cex.CltOpt) (*cex.Response, ServerTime, cex.RequestError) {
+	return cex.Request(c, SpotServerTimeConfig, nil, opts...)
+}
+
+func (c *PublicClient) FuturesServerTime(opts ...cex.CltOpt) (*cex.Response, ServerTime, cex.RequestError) {
+	return cex.Request(c, FuturesServerTimeConfig, nil, opts...)
+}
+
+func (c *PublicClient) SpotExchangeInfo(opts ...cex.CltOpt) (*cex.Response, ExchangeInfo, cex.RequestError) {
+	return cex.Request(c, SpotExchangeInfosConfig, nil, opts...)
+}
+
+func (c *PublicClient) FuturesExchangeInfo(opts ...cex.CltOpt) (*cex.Response, ExchangeInfo, cex.RequestError) {
+	return cex.Request(c, FuturesExchangeInfosConfig, nil, opts...)
+}
+
+// SpotOrderBook
+// limit, default 100, max 5000
+func (c *PublicClient) SpotOrderBook(symbol string, limit int, opts ...cex.CltOpt) (*cex.Response, OrderBook, cex.RequestError) {
+	return cex.Request(c, SpotOrderBookConfig, OrderBookParams{Symbol: symbol, Limit: limit}, opts...)
+}
+
+// FuturesOrderBook
+// limit, default 500, valid limits: 5, 10, 20, 50, 100, 500, 1000
+func (c *PublicClient) FuturesOrderBook(symbol string, limit int, opts ...cex.CltOpt) (*cex.Response, OrderBook, cex.RequestError) {
+	return cex.Request(c, FuturesOrderBookConfig, OrderBookParams{Symbol: symbol, Limit: limit}, opts...)
+}
+
+func (c *PublicClient) SpotKlines(params KlineParams, opts ...cex.CltOpt) (*cex.Response, []Kline, cex.RequestError) {
+	return cex.Request(c, SpotKlineConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesKlines(params KlineParams, opts ...cex.CltOpt) (*cex.Response, []Kline, cex.RequestError) {
+	return cex.Request(c, FuturesKlineConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesMarkPriceKlines(params KlineParams, opts ...cex.CltOpt) (*cex.Response, []Kline, cex.RequestError) {
+	return cex.Request(c, FuturesMarkPriceKlineConfig, params, opts...)
+}
+
+func (c *PublicClient) SpotTrades(params TradesParams, opts ...cex.CltOpt) (*cex.Response, []Trade, cex.RequestError) {
+	return cex.Request(c, SpotTradesConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesTrades(params TradesParams, opts ...cex.CltOpt) (*cex.Response, []Trade, cex.RequestError) {
+	return cex.Request(c, FuturesTradesConfig, params, opts...)
+}
+
+func (c *PublicClient) SpotAggTrades(params AggTradesParams, opts ...cex.CltOpt) (*cex.Response, []AggTrade, cex.RequestError) {
+	return cex.Request(c, SpotAggTradesConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesAggTrades(params AggTradesParams, opts ...cex.CltOpt) (*cex.Response, []AggTrade, cex.RequestError) {
+	return cex.Request(c, FuturesAggTradesConfig, params, opts...)
+}
+
+func (c *PublicClient) SpotPrices(opts ...cex.CltOpt) (*cex.Response, []SpotPriceTicker, cex.RequestError) {
+	return cex.Request(c, SpotPricesConfig, nil, opts...)
+}
+
+func (c *PublicClient) FuturesPrices(opts ...cex.CltOpt) (*cex.Response, []FuturesPriceTicker, cex.RequestError) {
+	return cex.Request(c, FuturesPricesConfig, nil, opts...)
+}
+
+func (c *PublicClient) SpotPrice(symbol string, opts ...cex.CltOpt) (*cex.Response, SpotPriceTicker, cex.RequestError) {
+	return cex.Request(c, SpotPriceConfig, TickerParams{Symbol: symbol}, opts...)
+}
+
+func (c *PublicClient) FuturesPrice(symbol string, opts ...cex.CltOpt) (*cex.Response, FuturesPriceTicker, cex.RequestError) {
+	return cex.Request(c, FuturesPriceConfig, TickerParams{Symbol: symbol}, opts...)
+}
+
+func (c *PublicClient) SpotBookTicker(symbol string, opts ...cex.CltOpt) (*cex.Response, BookTicker, cex.RequestError) {
+	return cex.Request(c, SpotBookTickerConfig, TickerParams{Symbol: symbol}, opts...)
+}
+
+func (c *PublicClient) SpotBookTickers(opts ...cex.CltOpt) (*cex.Response, []BookTicker, cex.RequestError) {
+	return cex.Request(c, SpotBookTickersConfig, nil, opts...)
+}
+
+func (c *PublicClient) FuturesBookTicker(symbol string, opts ...cex.CltOpt) (*cex.Response, BookTicker, cex.RequestError) {
+	return cex.Request(c, FuturesBookTickerConfig, TickerParams{Symbol: symbol}, opts...)
+}
+
+func (c *PublicClient) FuturesBookTickers(opts ...cex.CltOpt) (*cex.Response, []BookTicker, cex.RequestError) {
+	return cex.Request(c, FuturesBookTickersConfig, nil, opts...)
+}
+
+func (c *PublicClient) Spot24hrTicker(symbol string, opts ...cex.CltOpt) (*cex.Response, Ticker24hr, cex.RequestError) {
+	return cex.Request(c, Spot24hrTickerConfig, TickerParams{Symbol: symbol}, opts...)
+}
+
+func (c *PublicClient) Spot24hrTickers(opts ...cex.CltOpt) (*cex.Response, []Ticker24hr, cex.RequestError) {
+	return cex.Request(c, Spot24hrTickersConfig, nil, opts...)
+}
+
+func (c *PublicClient) Futures24hrTicker(symbol string, opts ...cex.CltOpt) (*cex.Response, Ticker24hr, cex.RequestError) {
+	return cex.Request(c, Futures24hrTickerConfig, TickerParams{Symbol: symbol}, opts...)
+}
+
+func (c *PublicClient) Futures24hrTickers(opts ...cex.CltOpt) (*cex.Response, []Ticker24hr, cex.RequestError) {
+	return cex.Request(c, Futures24hrTickersConfig, nil, opts...)
+}
+
+func (c *PublicClient) PremiumIndex(symbol string, opts ...cex.CltOpt) (*cex.Response, FuturesFundingRate, cex.RequestError) {
+	return cex.Request(c, FuturesPremiumIndexConfig, FuturesPremiumIndexParams{Symbol: symbol}, opts...)
+}
+
+// FundingRates returns premium index of all futures symbols.
+func (c *PublicClient) FundingRates(opts ...cex.CltOpt) (*cex.Response, []FuturesFundingRate, cex.RequestError) {
+	return cex.Request(c, FuturesFundingRatesConfig, FuturesFundingRatesParams{}, opts...)
+}
+
+func (c *PublicClient) FundingRateHistories(params FuturesFundingRateHistoriesParams, opts ...cex.CltOpt) (*cex.Response, []FuturesFundingRateHistory, cex.RequestError) {
+	return cex.Request(c, FuturesFundingRateHistoriesConfig, params, opts...)
+}
+
+// FuturesIndexInfos queries components of composite index symbol, or of all symbols if symbol is empty.
+func (c *PublicClient) FuturesIndexInfos(symbol string, opts ...cex.CltOpt) (*cex.Response, []FuturesIndexInfo, cex.RequestError) {
+	return cex.Request(c, FuturesIndexInfoConfig, FuturesIndexInfoParams{Symbol: symbol}, opts...)
+}
+
+func (c *PublicClient) FuturesAssetIndex(symbol string, opts ...cex.CltOpt) (*cex.Response, FuturesAssetIndex, cex.RequestError) {
+	return cex.Request(c, FuturesAssetIndexConfig, FuturesAssetIndexParams{Symbol: symbol}, opts...)
+}
+
+func (c *PublicClient) FuturesAssetIndexes(opts ...cex.CltOpt) (*cex.Response, []FuturesAssetIndex, cex.RequestError) {
+	return cex.Request(c, FuturesAssetIndexesConfig, nil, opts...)
+}
+
+func (c *PublicClient) FuturesIndexConstituents(symbol string, opts ...cex.CltOpt) (*cex.Response, FuturesIndexConstituents, cex.RequestError) {
+	return cex.Request(c, FuturesIndexConstituentsConfig, FuturesIndexConstituentsParams{Symbol: symbol}, opts...)
+}
+
+// ------------------------------------------------------------
+// Market Data
+// ============================================================
+
+// ============================================================
+// Futures Data
+// ------------------------------------------------------------
+
+func (c *PublicClient) FuturesOpenInterestHist(params FuturesDataParams, opts ...cex.CltOpt) (*cex.Response, []FuturesOpenInterestHist, cex.RequestError) {
+	return cex.Request(c, FuturesOpenInterestHistConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesTopLongShortAccountRatio(params FuturesDataParams, opts ...cex.CltOpt) (*cex.Response, []FuturesLongShortRatio, cex.RequestError) {
+	return cex.Request(c, FuturesTopLongShortAccountRatioConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesTopLongShortPositionRatio(params FuturesDataParams, opts ...cex.CltOpt) (*cex.Response, []FuturesLongShortRatio, cex.RequestError) {
+	return cex.Request(c, FuturesTopLongShortPositionRatioConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesGlobalLongShortAccountRatio(params FuturesDataParams, opts ...cex.CltOpt) (*cex.Response, []FuturesLongShortRatio, cex.RequestError) {
+	return cex.Request(c, FuturesGlobalLongShortAccountRatioConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesTakerLongShortRatio(params FuturesDataParams, opts ...cex.CltOpt) (*cex.Response, []FuturesTakerLongShortRatio, cex.RequestError) {
+	return cex.Request(c, FuturesTakerLongShortRatioConfig, params, opts...)
+}
+
+func (c *PublicClient) FuturesBasis(params FuturesBasisParams, opts ...cex.CltOpt) (*cex.Response, []FuturesBasis, cex.RequestError) {
+	return cex.Request(c, FuturesBasisConfig, params, opts...)
+}
+
+// ------------------------------------------------------------
+// Futures Data
+// ============================================================
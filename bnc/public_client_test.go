@@ -0,0 +1,171 @@
+package bnc
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+type testRoundTripper func(req *http.Request) (*http.Response, error)
+
+func (f testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPublicClient_Testnet(t *testing.T) {
+	var reqUrl string
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		reqUrl = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"lastUpdateId":1,"asks":[["101","1"]],"bids":[["99","2"]]}`))),
+			Request:    req,
+		}, nil
+	})
+	clt := NewPublicClient(PublicClientOptTestnet())
+	_, book, err := clt.SpotOrderBook("ETHUSDT", 5, cex.CltOptTransport(transport))
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if reqUrl != "https://testnet.binance.vision/api/v3/depth?limit=5&symbol=ETHUSDT" {
+		t.Error("wrong request url", reqUrl)
+	}
+	if book.LastUpdateId != 1 || book.Bids[0][0] != 99 {
+		t.Error("wrong order book", book)
+	}
+}
+
+func TestPublicClient_FuturesKlines(t *testing.T) {
+	_, klines, err := NewPublicClient().FuturesKlines(KlineParams{Symbol: "ETHUSDT", Interval: KlineInterval1m, Limit: 3})
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	t.Log(klines)
+}
+
+func TestPublicClient_Tickers(t *testing.T) {
+	bodies := map[string]string{
+		"/api/v3/ticker/bookTicker": `[{"symbol":"ETHUSDT","bidPrice":"2000.1","bidQty":"3","askPrice":"2000.2","askQty":"4"},{"symbol":"BTCUSDT","bidPrice":"60000","bidQty":"1","askPrice":"60000.1","askQty":"2"}]`,
+		"/fapi/v1/ticker/24hr":      `{"symbol":"ETHUSDT","priceChange":"-10.5","priceChangePercent":"-0.5","weightedAvgPrice":"2010","lastPrice":"2000","lastQty":"0.1","openPrice":"2010.5","highPrice":"2050","lowPrice":"1990","volume":"1000","quoteVolume":"2010000","openTime":1,"closeTime":2,"firstId":10,"lastId":20,"count":11}`,
+		"/api/v3/ticker/24hr":       `{"code":-1121,"msg":"Invalid symbol."}`,
+	}
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		if req.URL.Path == "/api/v3/ticker/24hr" {
+			status = http.StatusBadRequest
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(bodies[req.URL.Path]))),
+			Request:    req,
+		}, nil
+	})
+	clt := NewPublicClient(PublicClientOptTransport(transport))
+
+	_, tickers, err := clt.SpotBookTickers()
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(tickers) != 2 || tickers[1].Symbol != "BTCUSDT" || tickers[0].AskQty != 4 {
+		t.Error("wrong book tickers", tickers)
+	}
+
+	_, ticker, err := clt.Futures24hrTicker("ETHUSDT")
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if ticker.PriceChange != -10.5 || ticker.Count != 11 || ticker.LastId != 20 {
+		t.Error("wrong 24hr ticker", ticker)
+	}
+
+	_, _, err = clt.Spot24hrTicker("NOTEXIST")
+	if err.IsNil() || err.RespBodyUnmarshalerError == nil || err.RespBodyUnmarshalerError.CexErrCode != -1121 {
+		t.Error("invalid symbol should fail by code msg", err)
+	}
+}
+
+func TestPublicClient_FuturesData(t *testing.T) {
+	var reqUrl string
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		reqUrl = req.URL.String()
+		body := `[{"symbol":"ETHUSDT","longShortRatio":"1.5","longAccount":"0.6","shortAccount":"0.4","timestamp":1000}]`
+		if req.URL.Path == FuturesData+"/basis" {
+			body = `[{"indexPrice":"2000","contractType":"PERPETUAL","basisRate":"0.001","futuresPrice":"2002","annualizedBasisRate":"","basis":"2","pair":"ETHUSDT","timestamp":1000}]`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			Request:    req,
+		}, nil
+	})
+	clt := NewPublicClient(PublicClientOptTransport(transport))
+
+	_, ratios, err := clt.FuturesTopLongShortAccountRatio(FuturesDataParams{Symbol: "ETHUSDT", Period: FuturesDataPeriod1h, Limit: 1})
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if reqUrl != FapiBaseUrl+FuturesData+"/topLongShortAccountRatio?limit=1&period=1h&symbol=ETHUSDT" {
+		t.Error("wrong request url", reqUrl)
+	}
+	if len(ratios) != 1 || ratios[0].LongShortRatio != 1.5 || ratios[0].Timestamp != 1000 {
+		t.Error("wrong ratios", ratios)
+	}
+
+	_, basis, err := clt.FuturesBasis(FuturesBasisParams{Pair: "ETHUSDT", ContractType: FuturesContractTypePerpetual, Period: FuturesDataPeriod5m})
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(basis) != 1 || basis[0].Basis != 2 || basis[0].ContractType != FuturesContractTypePerpetual {
+		t.Error("wrong basis", basis)
+	}
+}
+
+func TestPublicClient_FuturesIndexConstituents(t *testing.T) {
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		body := `{"symbol":"ETHUSDT","time":1000,"constituents":[{"exchange":"binance","symbol":"ETHUSDT","price":"2000.1","weight":"0.4"},{"exchange":"okex","symbol":"ETH-USDT","price":"2000.3","weight":"0.6"}]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+			Request:    req,
+		}, nil
+	})
+	_, c, err := NewPublicClient(PublicClientOptTransport(transport)).FuturesIndexConstituents("ETHUSDT")
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(c.Constituents) != 2 || c.Constituents[1].Exchange != "okex" || c.Constituents[1].Weight != 0.6 {
+		t.Error("wrong constituents", c)
+	}
+}
+
+func TestPublicClient_BaseUrlFailover(t *testing.T) {
+	var hosts []string
+	transport := testRoundTripper(func(req *http.Request) (*http.Response, error) {
+		hosts = append(hosts, req.URL.Host)
+		status := http.StatusOK
+		if req.URL.Host == "api.binance.com" {
+			status = http.StatusBadGateway
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{}`))),
+			Request:    req,
+		}, nil
+	})
+	failover := NewBaseUrlFailover()
+	clt := NewPublicClient(PublicClientOptTransport(transport), PublicClientOptBaseUrlSelector(failover))
+	if _, _, err := clt.SpotPing(); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(hosts) != 2 || hosts[1] != "api-gcp.binance.com" || failover.Healthy(ApiBaseUrl) {
+		t.Error("request should fail over to api-gcp", hosts)
+	}
+}
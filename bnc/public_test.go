@@ -124,3 +124,11 @@ func TestQueryFuturesPrices(t *testing.T) {
 func TestQueryCMPremiumIndex(t *testing.T) {
 	publicTestChecker(QueryCMPremiumIndex("", "BTCUSD"))
 }
+
+func TestQueryPremiumIndex(t *testing.T) {
+	publicTestChecker(QueryPremiumIndex("ETHUSDT"))
+}
+
+func TestQueryFuturesMarkPriceKline(t *testing.T) {
+	publicTestChecker(QueryFuturesMarkPriceKline("ETHUSDT", KlineInterval1m, 0, 0, 3))
+}
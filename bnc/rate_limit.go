@@ -0,0 +1,254 @@
+package bnc
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+/**
+Binance rate limit ref:
+	Spot    : https://binance-docs.github.io/apidocs/spot/en/#limits
+	Futures : https://binance-docs.github.io/apidocs/futures/en/#limits
+
+Every response contains headers X-MBX-USED-WEIGHT-(intervalNum)(intervalLetter)
+which show current used weight of the ip.
+Order placing responses contain headers X-MBX-ORDER-COUNT-(intervalNum)(intervalLetter)
+which show current order count of the account.
+Sapi endpoints use X-SAPI-USED-IP-WEIGHT-1M and X-SAPI-USED-UID-WEIGHT-1M.
+
+When a 429 or 418 is received, header Retry-After shows seconds to wait.
+Body code -1003 msg may contain ban time, ex.
+"Way too much request weight used; IP banned until 1700000000000. Please use WebSocket Streams for live updates to avoid bans."
+*/
+
+const (
+	HeaderUsedWeightPrefix        = "X-Mbx-Used-Weight-"
+	HeaderOrderCountPrefix        = "X-Mbx-Order-Count-"
+	HeaderSapiUsedIpWeightPrefix  = "X-Sapi-Used-Ip-Weight-"
+	HeaderSapiUsedUidWeightPrefix = "X-Sapi-Used-Uid-Weight-"
+	HeaderRetryAfter              = "Retry-After"
+)
+
+var bannedUntilRegexp = regexp.MustCompile(`banned until (\d+)`)
+
+// ParseRateLimitUsage implements cex.RateLimitUsageParser.
+func (u *User) ParseRateLimitUsage(resp *cex.Response) *cex.RateLimitUsage {
+	return ParseRateLimitUsage(resp)
+}
+
+// ParseRateLimitUsage parses binance response headers
+// X-MBX-USED-WEIGHT-* and X-MBX-ORDER-COUNT-* into cex.RateLimitUsage.
+// Returns nil if response contains none of them.
+func ParseRateLimitUsage(resp *cex.Response) *cex.RateLimitUsage {
+	if resp == nil {
+		return nil
+	}
+	usage := &cex.RateLimitUsage{
+		UsedWeight: map[string]int64{},
+		OrderCount: map[string]int64{},
+		ReceivedAt: resp.ReceivedAt(),
+	}
+	for k, vs := range resp.Header() {
+		if len(vs) == 0 {
+			continue
+		}
+		ck := http.CanonicalHeaderKey(k)
+		var m map[string]int64
+		interval, ok := strings.CutPrefix(ck, HeaderUsedWeightPrefix)
+		if ok {
+			m = usage.UsedWeight
+		} else if interval, ok = strings.CutPrefix(ck, HeaderOrderCountPrefix); ok {
+			m = usage.OrderCount
+		} else {
+			continue
+		}
+		used, err := strconv.ParseInt(vs[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		m[strings.ToUpper(interval)] = used
+	}
+	if usage.IsEmpty() {
+		return nil
+	}
+	return usage
+}
+
+// ParseRateLimitError implements cex.RateLimitErrorParser.
+func (u *User) ParseRateLimitError(resp *cex.Response, reqErr cex.RequestError) *cex.RateLimitError {
+	return ParseRateLimitError(resp, reqErr)
+}
+
+// ParseRateLimitError parses binance response into cex.RateLimitError.
+// Returns nil if request is not rejected by rate limit.
+func ParseRateLimitError(resp *cex.Response, reqErr cex.RequestError) *cex.RateLimitError {
+	if resp == nil {
+		return nil
+	}
+
+	statusCode := resp.StatusCode()
+
+	var cexCode int
+	var cexMsg string
+	if reqErr.RespBodyUnmarshalerError != nil {
+		cexCode = reqErr.RespBodyUnmarshalerError.CexErrCode
+		cexMsg = reqErr.RespBodyUnmarshalerError.CexErrMsg
+	}
+
+	if statusCode != http.StatusTooManyRequests &&
+		statusCode != http.StatusTeapot &&
+		cexCode != -1003 &&
+		cexCode != -1015 {
+		return nil
+	}
+
+	rlErr := &cex.RateLimitError{
+		LimitType:  cex.RateLimitTypeRequestWeight,
+		Scope:      cex.RateLimitScopeIp,
+		StatusCode: statusCode,
+		CexErrCode: cexCode,
+		CexErrMsg:  cexMsg,
+		UsedWeight: map[string]int64{},
+		Err:        cex.ErrHTTPTooFrequency,
+	}
+
+	if cexCode == -1015 {
+		rlErr.LimitType = cex.RateLimitTypeOrders
+		rlErr.Scope = cex.RateLimitScopeAccount
+		rlErr.Err = ErrTooManyNewOrders
+	}
+
+	header := resp.Header()
+
+	for k, vs := range header {
+		if len(vs) == 0 {
+			continue
+		}
+		ck := http.CanonicalHeaderKey(k)
+		var interval string
+		var ok bool
+		for _, prefix := range []string{HeaderUsedWeightPrefix, HeaderOrderCountPrefix, HeaderSapiUsedIpWeightPrefix, HeaderSapiUsedUidWeightPrefix} {
+			interval, ok = strings.CutPrefix(ck, prefix)
+			if ok {
+				if prefix == HeaderOrderCountPrefix {
+					interval = "ORDER-" + interval
+				} else if prefix == HeaderSapiUsedUidWeightPrefix {
+					interval = "UID-" + interval
+				}
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		w, err := strconv.ParseInt(vs[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		rlErr.UsedWeight[strings.ToUpper(interval)] = w
+	}
+
+	now := resp.ReceivedAt()
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if ra := header.Get(HeaderRetryAfter); ra != "" {
+		if sec, err := strconv.ParseInt(ra, 10, 64); err == nil {
+			rlErr.RetryAfter = time.Duration(sec) * time.Second
+			rlErr.ResetsAt = now.Add(rlErr.RetryAfter)
+		}
+	}
+
+	if statusCode == http.StatusTeapot {
+		rlErr.LimitType = cex.RateLimitTypeIpBanned
+		rlErr.Err = cex.ErrHTTPIpBanned
+	}
+
+	if ms := bannedUntilRegexp.FindStringSubmatch(cexMsg); len(ms) == 2 {
+		if until, err := strconv.ParseInt(ms[1], 10, 64); err == nil {
+			rlErr.LimitType = cex.RateLimitTypeIpBanned
+			rlErr.Err = cex.ErrHTTPIpBanned
+			rlErr.ResetsAt = time.UnixMilli(until)
+			if rlErr.RetryAfter == 0 {
+				rlErr.RetryAfter = rlErr.ResetsAt.Sub(now)
+			}
+		}
+	}
+
+	return rlErr
+}
+
+var rateLimitIntervalUnits = map[string]time.Duration{
+	"SECOND": time.Second,
+	"MINUTE": time.Minute,
+	"HOUR":   time.Hour,
+	"DAY":    24 * time.Hour,
+}
+
+// ExchangeRateLimitsToBudgets switches exchange info rate limits to cex.RateLimitBudget.
+func ExchangeRateLimitsToBudgets(limits []ExchangeRateLimit) ([]cex.RateLimitBudget, error) {
+	var budgets []cex.RateLimitBudget
+	for _, l := range limits {
+		unit, ok := rateLimitIntervalUnits[l.Interval]
+		if !ok {
+			return nil, fmt.Errorf("bnc: unknown rate limit interval %v", l.Interval)
+		}
+		num := l.IntervalNum
+		if num <= 0 {
+			num = 1
+		}
+		limitType := cex.RateLimitType(l.RateLimitType)
+		scope := cex.RateLimitScopeIp
+		if limitType == cex.RateLimitTypeOrders {
+			scope = cex.RateLimitScopeAccount
+		}
+		budgets = append(budgets, cex.RateLimitBudget{
+			LimitType: limitType,
+			Scope:     scope,
+			Interval:  unit * time.Duration(num),
+			Limit:     int64(l.Limit),
+		})
+	}
+	return budgets, nil
+}
+
+func loadRateLimits(baseUrl string, exInfoQuerier func() (ExchangeInfo, error)) error {
+	info, err := exInfoQuerier()
+	if err != nil {
+		return err
+	}
+	budgets, err := ExchangeRateLimitsToBudgets(info.RateLimits)
+	if err != nil {
+		return err
+	}
+	cex.SetRateLimitBudgets(baseUrl, budgets)
+	return nil
+}
+
+// LoadSpotRateLimits reads rate limits from spot exchange info,
+// and sets them as budgets of ApiBaseUrl.
+func LoadSpotRateLimits() error {
+	return loadRateLimits(ApiBaseUrl, QuerySpotExchangeInfo)
+}
+
+// LoadFuturesRateLimits reads rate limits from futures exchange info,
+// and sets them as budgets of FapiBaseUrl.
+func LoadFuturesRateLimits() error {
+	return loadRateLimits(FapiBaseUrl, QueryFuturesExchangeInfo)
+}
+
+// LoadRateLimits loads spot and futures rate limits.
+// Should be called at startup.
+func LoadRateLimits() error {
+	if err := LoadSpotRateLimits(); err != nil {
+		return err
+	}
+	return LoadFuturesRateLimits()
+}
@@ -0,0 +1,104 @@
+package bnc
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/props"
+)
+
+func TestParseRateLimitError(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", "6001")
+	header.Set("Retry-After", "30")
+	resp := cex.NewResponse(http.StatusTeapot, header, nil)
+	reqErr := cex.RequestError{
+		RespBodyUnmarshalerError: &cex.RespBodyUnmarshalerError{
+			CexErrCode: -1003,
+			CexErrMsg:  "Way too much request weight used; IP banned until 1700000000000. Please use WebSocket Streams for live updates to avoid bans.",
+		},
+	}
+	rlErr := ParseRateLimitError(resp, reqErr)
+	if rlErr == nil {
+		t.Fatal("rate limit error should not be nil")
+	}
+	props.PrintlnIndent(rlErr)
+	if rlErr.LimitType != cex.RateLimitTypeIpBanned {
+		t.Error("limit type should be ip banned, but", rlErr.LimitType)
+	}
+	if rlErr.ResetsAt.UnixMilli() != 1700000000000 {
+		t.Error("resets at should be ban time, but", rlErr.ResetsAt)
+	}
+	if rlErr.UsedWeight["1M"] != 6001 {
+		t.Error("used weight 1M should be 6001, but", rlErr.UsedWeight["1M"])
+	}
+	if !errors.Is(rlErr, cex.ErrHTTPIpBanned) {
+		t.Error("rate limit error should be ErrHTTPIpBanned")
+	}
+
+	resp = cex.NewResponse(http.StatusOK, http.Header{}, nil)
+	if ParseRateLimitError(resp, cex.RequestError{}) != nil {
+		t.Error("rate limit error should be nil")
+	}
+}
+
+func TestParseRateLimitUsage(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", "120")
+	header.Set("X-MBX-ORDER-COUNT-10S", "3")
+	header.Set("X-MBX-ORDER-COUNT-1D", "50")
+	resp := cex.NewResponse(http.StatusOK, header, nil)
+	usage := ParseRateLimitUsage(resp)
+	if usage == nil {
+		t.Fatal("usage should not be nil")
+	}
+	props.PrintlnIndent(usage)
+	if usage.UsedWeight["1M"] != 120 {
+		t.Error("used weight 1M should be 120, but", usage.UsedWeight["1M"])
+	}
+	if usage.OrderCount["10S"] != 3 || usage.OrderCount["1D"] != 50 {
+		t.Error("wrong order count", usage.OrderCount)
+	}
+
+	resp = cex.NewResponse(http.StatusOK, http.Header{}, nil)
+	if ParseRateLimitUsage(resp) != nil {
+		t.Error("usage should be nil")
+	}
+}
+
+func TestParseResponseMeta(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", "120")
+	header.Set("X-MBX-UUID", "uuid-1")
+	header.Set("Content-Type", "application/json")
+	resp := cex.NewResponse(http.StatusOK, header, nil)
+	meta := cex.NewResponseMeta(resp)
+	ParseResponseMeta(resp, meta)
+	if meta.RequestId != "uuid-1" {
+		t.Error("wrong request id", meta.RequestId)
+	}
+	if meta.Headers.Get("X-Mbx-Used-Weight-1m") != "120" || meta.Headers.Get("Content-Type") != "" {
+		t.Error("only headers of interest should be kept", meta.Headers)
+	}
+}
+
+func TestExchangeRateLimitsToBudgets(t *testing.T) {
+	budgets, err := ExchangeRateLimitsToBudgets([]ExchangeRateLimit{
+		{RateLimitType: "REQUEST_WEIGHT", Interval: "MINUTE", IntervalNum: 1, Limit: 6000},
+		{RateLimitType: "ORDERS", Interval: "SECOND", IntervalNum: 10, Limit: 100},
+	})
+	props.PanicIfNotNil(err)
+	props.PrintlnIndent(budgets)
+	if budgets[1].Interval != 10*time.Second || budgets[1].Scope != cex.RateLimitScopeAccount {
+		t.Error("wrong orders budget", budgets[1])
+	}
+}
+
+func TestLoadRateLimits(t *testing.T) {
+	props.PanicIfNotNil(LoadRateLimits())
+	props.PrintlnIndent(cex.RateLimitBudgets(ApiBaseUrl))
+	props.PrintlnIndent(cex.RateLimitBudgets(FapiBaseUrl))
+}
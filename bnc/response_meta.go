@@ -0,0 +1,55 @@
+package bnc
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/dwdwow/cex"
+)
+
+// HeaderUuid is request id of binance response.
+const HeaderUuid = "X-Mbx-Uuid"
+
+var metaHeaderPrefixes = []string{
+	HeaderUsedWeightPrefix,
+	HeaderOrderCountPrefix,
+	HeaderSapiUsedIpWeightPrefix,
+	HeaderSapiUsedUidWeightPrefix,
+}
+
+// ParseResponseMeta implements cex.ResponseMetaParser.
+func (u *User) ParseResponseMeta(resp *cex.Response, meta *cex.ResponseMeta) {
+	ParseResponseMeta(resp, meta)
+}
+
+// ParseResponseMeta sets request id, rate limit headers and Retry-After of binance response to meta.
+func ParseResponseMeta(resp *cex.Response, meta *cex.ResponseMeta) {
+	if resp == nil || meta == nil {
+		return
+	}
+	for k, vs := range resp.Header() {
+		ck := http.CanonicalHeaderKey(k)
+		if ck == HeaderUuid {
+			if len(vs) > 0 {
+				meta.RequestId = vs[0]
+			}
+			continue
+		}
+		if ck != HeaderRetryAfter && !hasAnyPrefix(ck, metaHeaderPrefixes) {
+			continue
+		}
+		if meta.Headers == nil {
+			meta.Headers = http.Header{}
+		}
+		meta.Headers[ck] = vs
+	}
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
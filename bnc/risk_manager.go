@@ -0,0 +1,623 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Risk Manager
+// ------------------------------------------------------------
+
+// ErrRiskRejected is wrapped by RiskError, orders rejected by RiskManager are not sent.
+var ErrRiskRejected = errors.New("bnc: order is rejected by risk manager")
+
+type RiskRule string
+
+const (
+	RiskRuleKillSwitch       RiskRule = "KILL_SWITCH"
+	RiskRuleSymbolNotAllowed RiskRule = "SYMBOL_NOT_ALLOWED"
+	RiskRuleOrderNotional    RiskRule = "ORDER_NOTIONAL"
+	// RiskRuleNoPrice rejects market order, if max order notional is set but price of symbol is unknown.
+	RiskRuleNoPrice   RiskRule = "NO_PRICE"
+	RiskRulePosition  RiskRule = "POSITION"
+	RiskRuleDailyLoss RiskRule = "DAILY_LOSS"
+)
+
+// RiskError is returned by RiskManager in cex.RequestError.Err, if order breaks rule.
+//
+//	var riskErr *RiskError
+//	if errors.As(err.Err, &riskErr) && riskErr.Rule == RiskRulePosition {...}
+type RiskError struct {
+	Rule     RiskRule     `json:"rule"`
+	PairType cex.PairType `json:"pairType"`
+	Symbol   string       `json:"symbol"`
+	// Value is what breaks Limit, ex. order notional, position after order, or daily loss.
+	Value float64 `json:"value"`
+	Limit float64 `json:"limit"`
+}
+
+func (e *RiskError) Error() string {
+	return fmt.Sprintf("%v, rule: %v, %v %v, value: %v, limit: %v", ErrRiskRejected, e.Rule, e.PairType, e.Symbol, e.Value, e.Limit)
+}
+
+func (e *RiskError) Unwrap() error {
+	return ErrRiskRejected
+}
+
+type RiskManagerOpt func(*RiskManager)
+
+// RiskManagerOptMaxOrderNotional sets max notional of one order in quote asset, 0 means no limit.
+func RiskManagerOptMaxOrderNotional(notional float64) RiskManagerOpt {
+	return func(m *RiskManager) {
+		m.maxOrderNotional = notional
+	}
+}
+
+// RiskManagerOptMaxPosition sets max absolute position of symbol in base asset, 0 means no limit.
+// Empty symbol sets limit of all symbols without their own limits.
+func RiskManagerOptMaxPosition(symbol string, qty float64) RiskManagerOpt {
+	return func(m *RiskManager) {
+		m.maxPositions[symbol] = qty
+	}
+}
+
+// RiskManagerOptMaxDailyLoss sets max loss of PnL since 00:00 UTC, 0 means no limit.
+func RiskManagerOptMaxDailyLoss(loss float64) RiskManagerOpt {
+	return func(m *RiskManager) {
+		m.maxDailyLoss = loss
+	}
+}
+
+// RiskManagerOptAllowedSymbols sets symbol whitelist, all symbols are allowed by default.
+func RiskManagerOptAllowedSymbols(symbols ...string) RiskManagerOpt {
+	return func(m *RiskManager) {
+		m.allowedSymbols = map[string]bool{}
+		for _, symbol := range symbols {
+			m.allowedSymbols[symbol] = true
+		}
+	}
+}
+
+// RiskManagerOptPnlTracker sets PnL tracker of daily loss, which may be shared with others.
+func RiskManagerOptPnlTracker(tracker *cex.PnlTracker) RiskManagerOpt {
+	return func(m *RiskManager) {
+		m.pnl = tracker
+	}
+}
+
+// RiskManager wraps order methods of User, and checks orders before they are sent.
+// It implements cex.Trader, so it can replace User in strategies.
+// Orders breaking limits are rejected by *RiskError, which wraps ErrRiskRejected.
+//
+// Orders placed by RiskManager are tracked by order id.
+// Unfilled qty of open orders is reserved in the same lock of checking,
+// so concurrent orders can not exceed max position together,
+// and it is released when order is finished, by results of New, Query, Cancel and Wait methods.
+// If result of new order is unknown, ex. timeout or 5xx, the order may be live,
+// so its reservation is kept by client order id until QueryOrder or ReconcileOrder resolves it.
+// Reservation is released by new order failure, only if the order is rejected definitely.
+// Filled qty of tracked orders is counted as position immediately,
+// and it is replaced by fills applied by ApplyFill with the same order id.
+// So fills of tracked orders should be applied by ApplyFill, instead of the shared PnL tracker.
+//
+// PnL and positions of other orders are maintained by fills applied by ApplyFill, ex. from user data stream,
+// and initial positions should be set by SetPosition.
+// Prices of market orders and unrealized PnL are set by SetMarkPrice.
+//
+// Kill switch rejects all new orders, and max daily loss rejects orders which increase positions.
+// Cancel and query are never blocked.
+//
+//	m := NewRiskManager(user, RiskManagerOptMaxOrderNotional(10000), RiskManagerOptMaxDailyLoss(500))
+//	_, ord, err := m.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1800)
+//	m.ApplyFill(fill)
+//	m.Kill()
+type RiskManager struct {
+	user *User
+	pnl  *cex.PnlTracker
+	now  func() time.Time
+
+	maxOrderNotional float64
+	maxPositions     map[string]float64
+	maxDailyLoss     float64
+	allowedSymbols   map[string]bool
+
+	mux    sync.Mutex
+	killed bool
+	// offsets are positions set by SetPosition and filled qty of tracked orders, minus positions of pnl tracker
+	offsets map[cex.PnlKey]float64
+	prices  map[cex.PnlKey]float64
+	// orders are tracked orders, including in-flight orders without order id
+	orders     map[*riskOrder]bool
+	ordersById map[riskOrderKey]*riskOrder
+	// ordersByCltId are orders with unknown result, which are not resolved by order id yet
+	ordersByCltId map[riskOrderKey]*riskOrder
+	// earlyFills are filled qty of order ids applied while orders of the same symbol are unresolved,
+	// they are applied to order resolved with the order id later
+	earlyFills map[riskOrderKey]float64
+	// day is days since unix epoch, and dayStartPnl is total PnL at the first check of day
+	day         int64
+	dayStartPnl float64
+}
+
+// riskOrderKey is order id or client order id of symbol.
+type riskOrderKey struct {
+	key cex.PnlKey
+	id  string
+}
+
+// riskOrder is an order placed by RiskManager.
+type riskOrder struct {
+	key      cex.PnlKey
+	orderId  string
+	cltOrdId string
+	// sign is 1 for buy and -1 for sell
+	sign float64
+	qty  float64
+	// open is reserved qty, which is unfilled qty of open order
+	open float64
+	// booked is filled qty by order status, and applied is filled qty by fills of ApplyFill
+	booked  float64
+	applied float64
+}
+
+// unapplied is filled qty added to offsets, which is not applied by fills yet.
+func (o *riskOrder) unapplied() float64 {
+	return max(o.booked-o.applied, 0)
+}
+
+func NewRiskManager(user *User, opts ...RiskManagerOpt) *RiskManager {
+	m := &RiskManager{
+		user:         user,
+		now:          time.Now,
+		maxPositions: map[string]float64{},
+		offsets:      map[cex.PnlKey]float64{},
+		prices:       map[cex.PnlKey]float64{},
+		orders:       map[*riskOrder]bool{},
+		ordersById:   map[riskOrderKey]*riskOrder{},
+
+		ordersByCltId: map[riskOrderKey]*riskOrder{},
+		earlyFills:    map[riskOrderKey]float64{},
+		day:           -1,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.pnl == nil {
+		m.pnl = cex.NewPnlTracker()
+	}
+	return m
+}
+
+func (m *RiskManager) User() *User {
+	return m.user
+}
+
+// Kill turns on kill switch, all new orders are rejected until Resume.
+func (m *RiskManager) Kill() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.killed = true
+}
+
+func (m *RiskManager) Resume() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.killed = false
+}
+
+func (m *RiskManager) Killed() bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.killed
+}
+
+func riskKey(pairType cex.PairType, symbol string) cex.PnlKey {
+	return cex.PnlKey{Cex: cex.BINANCE, PairType: pairType, Symbol: symbol}
+}
+
+// SetPosition sets signed position of symbol in base asset, short position is negative.
+func (m *RiskManager) SetPosition(pairType cex.PairType, symbol string, qty float64) {
+	key := riskKey(pairType, symbol)
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	tracked, _ := m.pnl.Pnl(key)
+	m.offsets[key] = qty - tracked.Qty
+}
+
+// Position returns signed position of symbol in base asset.
+func (m *RiskManager) Position(pairType cex.PairType, symbol string) float64 {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.position(riskKey(pairType, symbol))
+}
+
+// position should be called with lock.
+func (m *RiskManager) position(key cex.PnlKey) float64 {
+	tracked, _ := m.pnl.Pnl(key)
+	return m.offsets[key] + tracked.Qty
+}
+
+// OpenQty returns reserved qty of open and in-flight buy and sell orders of symbol.
+func (m *RiskManager) OpenQty(pairType cex.PairType, symbol string) (buy, sell float64) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.openQty(riskKey(pairType, symbol))
+}
+
+// openQty should be called with lock.
+func (m *RiskManager) openQty(key cex.PnlKey) (buy, sell float64) {
+	for o := range m.orders {
+		if o.key != key {
+			continue
+		}
+		if o.sign > 0 {
+			buy += o.open
+		} else {
+			sell += o.open
+		}
+	}
+	return
+}
+
+// SetMarkPrice sets price of market orders and unrealized PnL of symbol.
+func (m *RiskManager) SetMarkPrice(pairType cex.PairType, symbol string, price float64) {
+	key := riskKey(pairType, symbol)
+	m.pnl.SetMarkPrice(key, price)
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.prices[key] = price
+	m.rollDay()
+}
+
+// ApplyFill updates positions and PnL by fills, fills with duplicate trade id are ignored.
+func (m *RiskManager) ApplyFill(fills ...cex.Fill) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	// day start PnL should not contain fills of today
+	m.rollDay()
+	for _, f := range fills {
+		if m.pnl.Ingest(f) == 0 {
+			continue
+		}
+		if f.OrderId == "" {
+			continue
+		}
+		id := riskOrderKey{riskKey(f.PairType, f.Symbol), f.OrderId}
+		o, ok := m.ordersById[id]
+		if !ok {
+			if m.unresolved(id.key) {
+				m.earlyFills[id] += f.Qty
+			}
+			continue
+		}
+		unapplied := o.unapplied()
+		o.applied += f.Qty
+		// fill is counted by pnl tracker, so it is removed from offsets
+		m.offsets[o.key] -= o.sign * (unapplied - o.unapplied())
+		m.untrack(o, false)
+	}
+}
+
+func (m *RiskManager) totalPnl() float64 {
+	s := m.pnl.Snapshot()
+	return s.RealizedPnl + s.UnrealizedPnl
+}
+
+// rollDay resets day start PnL, if it is a new day in UTC.
+// It should be called with lock.
+func (m *RiskManager) rollDay() {
+	day := m.now().UTC().Unix() / (24 * 60 * 60)
+	if day != m.day {
+		m.day = day
+		m.dayStartPnl = m.totalPnl()
+	}
+}
+
+// DailyPnl returns PnL since 00:00 UTC, or since the first fill or price of today.
+func (m *RiskManager) DailyPnl() float64 {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.dailyPnl()
+}
+
+// dailyPnl should be called with lock.
+func (m *RiskManager) dailyPnl() float64 {
+	m.rollDay()
+	return m.totalPnl() - m.dayStartPnl
+}
+
+// Check checks order, price of market order should be 0.
+// Reserved qty of open orders placed by RiskManager is counted,
+// but order checked by Check is not reserved.
+func (m *RiskManager) Check(pairType cex.PairType, symbol string, orderSide cex.OrderSide, qty, price float64) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.check(pairType, symbol, orderSide, qty, price)
+}
+
+// check should be called with lock.
+func (m *RiskManager) check(pairType cex.PairType, symbol string, orderSide cex.OrderSide, qty, price float64) error {
+	key := riskKey(pairType, symbol)
+	reject := func(rule RiskRule, value, limit float64) error {
+		return &RiskError{Rule: rule, PairType: pairType, Symbol: symbol, Value: value, Limit: limit}
+	}
+	if m.killed {
+		return reject(RiskRuleKillSwitch, 0, 0)
+	}
+	if m.allowedSymbols != nil && !m.allowedSymbols[symbol] {
+		return reject(RiskRuleSymbolNotAllowed, 0, 0)
+	}
+	if m.maxOrderNotional > 0 {
+		if price == 0 {
+			price = m.prices[key]
+		}
+		if price <= 0 {
+			return reject(RiskRuleNoPrice, 0, m.maxOrderNotional)
+		}
+		if notional := qty * price; notional > m.maxOrderNotional {
+			return reject(RiskRuleOrderNotional, notional, m.maxOrderNotional)
+		}
+	}
+	// position is the worst case, if all open orders of the same side are filled
+	openBuy, openSell := m.openQty(key)
+	position := m.position(key) + openBuy
+	newPosition := position + qty
+	if orderSide == cex.OrderSideSell {
+		position -= openBuy + openSell
+		newPosition = position - qty
+	}
+	increasing := math.Abs(newPosition) > math.Abs(position)
+	maxPosition, ok := m.maxPositions[symbol]
+	if !ok {
+		maxPosition = m.maxPositions[""]
+	}
+	if maxPosition > 0 && increasing && math.Abs(newPosition) > maxPosition {
+		return reject(RiskRulePosition, newPosition, maxPosition)
+	}
+	if m.maxDailyLoss > 0 && increasing {
+		if loss := -m.dailyPnl(); loss >= m.maxDailyLoss {
+			return reject(RiskRuleDailyLoss, loss, m.maxDailyLoss)
+		}
+	}
+	return nil
+}
+
+// reserve checks order, and reserves its qty in the same lock, if it passes.
+func (m *RiskManager) reserve(pairType cex.PairType, asset, quote string, orderSide cex.OrderSide, qty, price float64) (*riskOrder, cex.RequestError) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	symbol := asset + quote
+	if err := m.check(pairType, symbol, orderSide, qty, price); err != nil {
+		return nil, cex.RequestError{Err: err}
+	}
+	o := &riskOrder{key: riskKey(pairType, symbol), sign: 1, qty: qty, open: qty}
+	if orderSide == cex.OrderSideSell {
+		o.sign = -1
+	}
+	m.orders[o] = true
+	return o, cex.RequestError{}
+}
+
+// settle tracks order by order id after it is placed.
+// If result of order is unknown, order is tracked by client order id with its reservation.
+// Reservation is released, if order is rejected definitely.
+func (m *RiskManager) settle(o *riskOrder, ord *cex.Order, resp *cex.Response, err cex.RequestError) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	switch {
+	case ord != nil && ord.OrderId != "":
+		m.resolve(o, ord.OrderId)
+		m.update(o, ord)
+	case isNewOrdResultUnknown(resp, err):
+		// order without client order id can not be resolved, so it stays reserved
+		if ord != nil && ord.ClientOrderId != "" {
+			o.cltOrdId = ord.ClientOrderId
+			m.ordersByCltId[riskOrderKey{o.key, o.cltOrdId}] = o
+		}
+	default:
+		delete(m.orders, o)
+	}
+}
+
+// resolve tracks order by order id, and applies early fills of it.
+// It should be called with lock.
+func (m *RiskManager) resolve(o *riskOrder, orderId string) {
+	if o.cltOrdId != "" {
+		delete(m.ordersByCltId, riskOrderKey{o.key, o.cltOrdId})
+	}
+	o.orderId = orderId
+	id := riskOrderKey{o.key, orderId}
+	m.ordersById[id] = o
+	o.applied += m.earlyFills[id]
+	delete(m.earlyFills, id)
+	m.clearEarlyFills(o.key)
+}
+
+// unresolved returns true, if any order of key has unknown result.
+// It should be called with lock.
+func (m *RiskManager) unresolved(key cex.PnlKey) bool {
+	for id := range m.ordersByCltId {
+		if id.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// clearEarlyFills drops early fills of key, if no order of key is unresolved.
+// It should be called with lock.
+func (m *RiskManager) clearEarlyFills(key cex.PnlKey) {
+	if m.unresolved(key) {
+		return
+	}
+	for id := range m.earlyFills {
+		if id.key == key {
+			delete(m.earlyFills, id)
+		}
+	}
+}
+
+// updateOrd updates tracked order by order result of query, cancel or wait.
+// Order with unknown result is resolved by its client order id.
+func (m *RiskManager) updateOrd(ord *cex.Order) {
+	if ord == nil {
+		return
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	key := riskKey(ord.PairType, ord.Symbol)
+	if o, ok := m.ordersById[riskOrderKey{key, ord.OrderId}]; ok && ord.OrderId != "" {
+		m.update(o, ord)
+		return
+	}
+	if o, ok := m.ordersByCltId[riskOrderKey{key, ord.ClientOrderId}]; ok && ord.ClientOrderId != "" && ord.OrderId != "" {
+		m.resolve(o, ord.OrderId)
+		m.update(o, ord)
+	}
+}
+
+// release releases reservation of order with unknown result, which is not placed.
+func (m *RiskManager) release(ord *cex.Order) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	id := riskOrderKey{riskKey(ord.PairType, ord.Symbol), ord.ClientOrderId}
+	if o, ok := m.ordersByCltId[id]; ok {
+		delete(m.ordersByCltId, id)
+		delete(m.orders, o)
+		m.clearEarlyFills(id.key)
+	}
+}
+
+// update should be called with lock.
+func (m *RiskManager) update(o *riskOrder, ord *cex.Order) {
+	if ord.FilledQty > o.booked {
+		unapplied := o.unapplied()
+		o.booked = ord.FilledQty
+		m.offsets[o.key] += o.sign * (o.unapplied() - unapplied)
+	}
+	o.open = max(o.qty-o.booked, 0)
+	m.untrack(o, ord.IsFinished())
+}
+
+// untrack releases reservation of finished order,
+// and stops tracking it after all filled qty is applied by fills.
+// It should be called with lock.
+func (m *RiskManager) untrack(o *riskOrder, finished bool) {
+	if finished {
+		o.open = 0
+	}
+	if o.open > 0 || o.unapplied() > 0 {
+		return
+	}
+	delete(m.orders, o)
+	delete(m.ordersById, riskOrderKey{o.key, o.orderId})
+}
+
+// ReconcileOrder resolves order with unknown result by its client order id, see User.ReconcileOrder.
+// Reservation of order is released, if it is not placed.
+func (m *RiskManager) ReconcileOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, bool, cex.RequestError) {
+	resp, placed, err := m.user.ReconcileOrder(order, opts...)
+	switch {
+	case err.IsNotNil():
+	case placed:
+		m.updateOrd(order)
+	default:
+		m.release(order)
+	}
+	return resp, placed, err
+}
+
+func (m *RiskManager) QueryOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	resp, err := m.user.QueryOrder(order, opts...)
+	if err.IsNil() {
+		m.updateOrd(order)
+	}
+	return resp, err
+}
+
+func (m *RiskManager) CancelOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	resp, err := m.user.CancelOrder(order, opts...)
+	if err.IsNil() {
+		m.updateOrd(order)
+	}
+	return resp, err
+}
+
+func (m *RiskManager) WaitOrder(ctx context.Context, order *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
+	ch := make(chan cex.RequestError, 1)
+	go func() {
+		err := <-m.user.WaitOrder(ctx, order, opts...)
+		m.updateOrd(order)
+		ch <- err
+	}()
+	return ch
+}
+
+func (m *RiskManager) NewSpotOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	if tradeType == cex.OrderTypeMarket {
+		price = 0
+	}
+	o, err := m.reserve(cex.PairTypeSpot, asset, quote, orderSide, qty, price)
+	if err.IsNotNil() {
+		return nil, nil, err
+	}
+	resp, ord, err := m.user.NewSpotOrder(asset, quote, tradeType, orderSide, qty, price, opts...)
+	m.settle(o, ord, resp, err)
+	return resp, ord, err
+}
+
+func (m *RiskManager) NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return m.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (m *RiskManager) NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return m.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (m *RiskManager) NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return m.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (m *RiskManager) NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return m.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+func (m *RiskManager) NewFuturesOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	if tradeType == cex.OrderTypeMarket {
+		price = 0
+	}
+	o, err := m.reserve(cex.PairTypeFutures, asset, quote, orderSide, qty, price)
+	if err.IsNotNil() {
+		return nil, nil, err
+	}
+	resp, ord, err := m.user.NewFuturesOrder(asset, quote, tradeType, orderSide, qty, price, opts...)
+	m.settle(o, ord, resp, err)
+	return resp, ord, err
+}
+
+func (m *RiskManager) NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return m.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (m *RiskManager) NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return m.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (m *RiskManager) NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return m.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (m *RiskManager) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return m.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+// ------------------------------------------------------------
+// Risk Manager
+// ============================================================
@@ -0,0 +1,232 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestRiskManager(t *testing.T) {
+	sent := 0
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		status := "NEW"
+		if r.Method == http.MethodDelete {
+			status = "CANCELED"
+		} else {
+			sent++
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"symbol":"ETHUSDT","orderId":123,"status":"` + status + `","type":"LIMIT","side":"BUY","origQty":"1","price":"1800"}`)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+	var _ cex.Trader = (*RiskManager)(nil)
+	m := NewRiskManager(user,
+		RiskManagerOptMaxOrderNotional(5000),
+		RiskManagerOptMaxPosition("", 2),
+		RiskManagerOptMaxPosition("BTCUSDT", 0.1),
+		RiskManagerOptMaxDailyLoss(100),
+		RiskManagerOptAllowedSymbols("ETHUSDT", "BTCUSDT"),
+	)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	rejectedBy := func(err cex.RequestError, rule RiskRule) bool {
+		var riskErr *RiskError
+		return errors.Is(err.Err, ErrRiskRejected) && errors.As(err.Err, &riskErr) && riskErr.Rule == rule
+	}
+
+	_, ord, err := m.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1800)
+	if err.IsNotNil() || sent != 1 {
+		t.Fatal("order in limits should be sent", err.Err)
+	}
+	if buy, _ := m.OpenQty(cex.PairTypeSpot, "ETHUSDT"); buy != 1 {
+		t.Error("open order qty should be reserved", buy)
+	}
+	if _, _, err := m.NewSpotLimitBuyOrder("BNB", "USDT", 1, 300); !rejectedBy(err, RiskRuleSymbolNotAllowed) {
+		t.Error("symbol not in whitelist should be rejected", err.Err)
+	}
+	if _, _, err := m.NewSpotLimitBuyOrder("ETH", "USDT", 3, 1800); !rejectedBy(err, RiskRuleOrderNotional) {
+		t.Error("order notional should be limited", err.Err)
+	}
+	if _, _, err := m.NewFuturesMarketBuyOrder("ETH", "USDT", 1); !rejectedBy(err, RiskRuleNoPrice) {
+		t.Error("market order without price should be rejected", err.Err)
+	}
+	m.SetMarkPrice(cex.PairTypeFutures, "BTCUSDT", 40000)
+	if _, _, err := m.NewFuturesMarketSellOrder("BTC", "USDT", 0.2); !rejectedBy(err, RiskRuleOrderNotional) {
+		t.Error("market order notional should be valued by mark price", err.Err)
+	}
+
+	m.SetPosition(cex.PairTypeSpot, "ETHUSDT", 1.5)
+	if _, _, err := m.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1800); !rejectedBy(err, RiskRulePosition) {
+		t.Error("position should be limited", err.Err)
+	}
+	if err := m.Check(cex.PairTypeSpot, "ETHUSDT", cex.OrderSideSell, 1, 1800); err != nil {
+		t.Error("reducing order should be allowed", err)
+	}
+	if err := m.Check(cex.PairTypeFutures, "BTCUSDT", cex.OrderSideBuy, 0.11, 100); !errors.As(err, new(*RiskError)) {
+		t.Error("symbol limit should override default limit", err)
+	}
+
+	if _, err := m.CancelOrder(ord); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if buy, _ := m.OpenQty(cex.PairTypeSpot, "ETHUSDT"); buy != 0 {
+		t.Error("canceled order qty should be released", buy)
+	}
+
+	m.ApplyFill(
+		cex.Fill{TradeId: "1", Cex: cex.BINANCE, PairType: cex.PairTypeSpot, Symbol: "ETHUSDT", OrderSide: cex.OrderSideBuy, Qty: 0.5, Price: 1800},
+		cex.Fill{TradeId: "2", Cex: cex.BINANCE, PairType: cex.PairTypeSpot, Symbol: "ETHUSDT", OrderSide: cex.OrderSideSell, Qty: 1, Price: 1600},
+	)
+	if pos := m.Position(cex.PairTypeSpot, "ETHUSDT"); pos != 1 {
+		t.Error("position should be updated by fills", pos)
+	}
+	// 0.5 bought at 1800 is sold at 1600, position set by SetPosition has no cost
+	if pnl := m.DailyPnl(); pnl != -100 {
+		t.Error("wrong daily pnl", pnl)
+	}
+	if err := m.Check(cex.PairTypeSpot, "ETHUSDT", cex.OrderSideBuy, 0.1, 1600); !errors.As(err, new(*RiskError)) || err.(*RiskError).Rule != RiskRuleDailyLoss {
+		t.Error("increasing position should be rejected after max daily loss", err)
+	}
+	if err := m.Check(cex.PairTypeSpot, "ETHUSDT", cex.OrderSideSell, 0.1, 1600); err != nil {
+		t.Error("reducing position should be allowed after max daily loss", err)
+	}
+	now = now.Add(24 * time.Hour)
+	if pnl := m.DailyPnl(); pnl != 0 {
+		t.Error("daily pnl should be reset on new day", pnl)
+	}
+
+	m.Kill()
+	if _, _, err := m.NewSpotLimitSellOrder("ETH", "USDT", 0.1, 1800); !rejectedBy(err, RiskRuleKillSwitch) || sent != 1 {
+		t.Error("kill switch should reject all orders", err.Err)
+	}
+	m.Resume()
+	if err := m.Check(cex.PairTypeSpot, "ETHUSDT", cex.OrderSideSell, 0.1, 1800); err != nil || m.Killed() {
+		t.Error("orders should be allowed after resuming", err)
+	}
+}
+
+func TestRiskManager_Reserve(t *testing.T) {
+	var orderId int
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		orderId++
+		body := fmt.Sprintf(`{"symbol":"ETHUSDT","orderId":%v,"status":"NEW","type":"LIMIT","side":"BUY","origQty":"1","price":"1800"}`, orderId)
+		if r.Method == http.MethodPost && r.URL.Query().Get("type") == "MARKET" {
+			body = fmt.Sprintf(`{"symbol":"ETHUSDT","orderId":%v,"status":"FILLED","type":"MARKET","side":"BUY","origQty":"0.5","executedQty":"0.5"}`, orderId)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	m := NewRiskManager(NewUser("key", "secret", UserOptTransport(transport)), RiskManagerOptMaxPosition("", 2))
+
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	var sent, rejected int
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := m.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1800)
+			mux.Lock()
+			defer mux.Unlock()
+			if err.IsNil() {
+				sent++
+			} else if errors.Is(err.Err, ErrRiskRejected) {
+				rejected++
+			}
+		}()
+	}
+	wg.Wait()
+	if sent != 2 || rejected != 3 {
+		t.Error("open orders should be counted in max position", sent, rejected)
+	}
+	if _, _, err := m.NewSpotLimitSellOrder("ETH", "USDT", 1, 1800); err.IsNotNil() {
+		t.Error("reducing order should be allowed", err.Error())
+	}
+
+	m = NewRiskManager(NewUser("key", "secret", UserOptTransport(transport)), RiskManagerOptMaxPosition("", 2))
+	_, ord, err := m.NewSpotMarketBuyOrder("ETH", "USDT", 0.5)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if pos := m.Position(cex.PairTypeSpot, "ETHUSDT"); pos != 0.5 {
+		t.Error("filled qty of order should be counted as position", pos)
+	}
+	fill := cex.Fill{TradeId: "1", Cex: cex.BINANCE, PairType: cex.PairTypeSpot, Symbol: "ETHUSDT", OrderId: ord.OrderId, OrderSide: cex.OrderSideBuy, Qty: 0.5, Price: 1800}
+	m.ApplyFill(fill, fill)
+	if pos := m.Position(cex.PairTypeSpot, "ETHUSDT"); pos != 0.5 || len(m.orders) != 0 {
+		t.Error("fills of tracked order should not be counted twice", pos, len(m.orders))
+	}
+}
+
+func TestRiskManager_UnknownResult(t *testing.T) {
+	var timeout, placed bool
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{"symbol":"ETHUSDT","orderId":1,"status":"NEW","type":"LIMIT","side":"BUY","origQty":"1","price":"1800"}`
+		switch {
+		case r.Method == http.MethodPost && timeout:
+			return nil, context.DeadlineExceeded
+		case r.Method == http.MethodPost && r.URL.Query().Get("quantity") == "0.1":
+			status, body = http.StatusBadRequest, `{"code":-2010,"msg":"Account has insufficient balance for requested action."}`
+		case r.Method == http.MethodGet && !placed:
+			status, body = http.StatusBadRequest, `{"code":-2013,"msg":"Order does not exist."}`
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	m := NewRiskManager(NewUser("key", "secret", UserOptTransport(transport)), RiskManagerOptMaxPosition("", 2))
+
+	if _, _, err := m.NewSpotLimitBuyOrder("ETH", "USDT", 0.1, 1800); err.IsNil() || len(m.orders) != 0 {
+		t.Error("reservation of rejected order should be released", len(m.orders))
+	}
+
+	timeout = true
+	_, ord, err := m.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1800)
+	if err.IsNil() || ord.ClientOrderId == "" {
+		t.Fatal("order should time out with client order id", err.Error())
+	}
+	if buy, _ := m.OpenQty(cex.PairTypeSpot, "ETHUSDT"); buy != 1 {
+		t.Error("order with unknown result should be reserved", buy)
+	}
+	timeout = false
+	if _, _, err := m.NewSpotLimitBuyOrder("ETH", "USDT", 1.5, 1800); !errors.Is(err.Err, ErrRiskRejected) {
+		t.Error("order with unknown result should be counted in max position", err.Error())
+	}
+
+	if _, placed, err := m.ReconcileOrder(ord); err.IsNotNil() || placed {
+		t.Fatal("order should not be placed", err.Error())
+	}
+	if buy, _ := m.OpenQty(cex.PairTypeSpot, "ETHUSDT"); buy != 0 || len(m.orders) != 0 {
+		t.Error("reservation of order not placed should be released", buy)
+	}
+
+	timeout = true
+	_, ord, _ = m.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1800)
+	timeout, placed = false, true
+	if _, placed, err := m.ReconcileOrder(ord); err.IsNotNil() || !placed || ord.OrderId != "1" {
+		t.Fatal("order should be placed", err.Error(), ord.OrderId)
+	}
+	if buy, _ := m.OpenQty(cex.PairTypeSpot, "ETHUSDT"); buy != 1 || len(m.ordersById) != 1 || len(m.ordersByCltId) != 0 {
+		t.Error("placed order should be tracked by order id", buy)
+	}
+}
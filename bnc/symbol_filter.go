@@ -0,0 +1,259 @@
+package bnc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dwdwow/cex"
+)
+
+// ErrOrderFilter is wrapped by errors of OrderValidator,
+// if order violates symbol filters.
+var ErrOrderFilter = errors.New("order violates symbol filter")
+
+type SymbolFilterType string
+
+const (
+	SymbolFilterTypePrice         SymbolFilterType = "PRICE_FILTER"
+	SymbolFilterTypeLotSize       SymbolFilterType = "LOT_SIZE"
+	SymbolFilterTypeMarketLotSize SymbolFilterType = "MARKET_LOT_SIZE"
+	SymbolFilterTypeMinNotional   SymbolFilterType = "MIN_NOTIONAL"
+	SymbolFilterTypeNotional      SymbolFilterType = "NOTIONAL"
+)
+
+// SymbolFilters is parsed from Exchange.Filters.
+// Zero value means no limit.
+type SymbolFilters struct {
+	Symbol string `json:"symbol" bson:"symbol"`
+
+	// PRICE_FILTER
+	TickSize float64 `json:"tickSize" bson:"tickSize"`
+	MinPrice float64 `json:"minPrice" bson:"minPrice"`
+	MaxPrice float64 `json:"maxPrice" bson:"maxPrice"`
+
+	// LOT_SIZE
+	StepSize float64 `json:"stepSize" bson:"stepSize"`
+	MinQty   float64 `json:"minQty" bson:"minQty"`
+	MaxQty   float64 `json:"maxQty" bson:"maxQty"`
+
+	// MARKET_LOT_SIZE
+	MarketStepSize float64 `json:"marketStepSize" bson:"marketStepSize"`
+	MarketMinQty   float64 `json:"marketMinQty" bson:"marketMinQty"`
+	MarketMaxQty   float64 `json:"marketMaxQty" bson:"marketMaxQty"`
+
+	// MIN_NOTIONAL or NOTIONAL
+	// spot: minNotional, maxNotional
+	// futures: notional
+	MinNotional float64 `json:"minNotional" bson:"minNotional"`
+	MaxNotional float64 `json:"maxNotional" bson:"maxNotional"`
+}
+
+func filterFloat(filter map[string]any, key string) (float64, error) {
+	switch v := filter[key].(type) {
+	case nil:
+		return 0, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	default:
+		return 0, fmt.Errorf("bnc: unknown filter %v type %v", key, v)
+	}
+}
+
+// ParseSymbolFilters parses LOT_SIZE, MARKET_LOT_SIZE, PRICE_FILTER,
+// MIN_NOTIONAL and NOTIONAL filters of symbol.
+func ParseSymbolFilters(info Exchange) (SymbolFilters, error) {
+	filters := SymbolFilters{Symbol: info.Symbol}
+	for _, filter := range info.Filters {
+		t, _ := filter["filterType"].(string)
+		var fields map[string]*float64
+		switch SymbolFilterType(t) {
+		case SymbolFilterTypePrice:
+			fields = map[string]*float64{"tickSize": &filters.TickSize, "minPrice": &filters.MinPrice, "maxPrice": &filters.MaxPrice}
+		case SymbolFilterTypeLotSize:
+			fields = map[string]*float64{"stepSize": &filters.StepSize, "minQty": &filters.MinQty, "maxQty": &filters.MaxQty}
+		case SymbolFilterTypeMarketLotSize:
+			fields = map[string]*float64{"stepSize": &filters.MarketStepSize, "minQty": &filters.MarketMinQty, "maxQty": &filters.MarketMaxQty}
+		case SymbolFilterTypeMinNotional:
+			if info.ContractType == "" {
+				fields = map[string]*float64{"minNotional": &filters.MinNotional}
+			} else {
+				fields = map[string]*float64{"notional": &filters.MinNotional}
+			}
+		case SymbolFilterTypeNotional:
+			fields = map[string]*float64{"minNotional": &filters.MinNotional, "maxNotional": &filters.MaxNotional}
+		default:
+			continue
+		}
+		for key, field := range fields {
+			v, err := filterFloat(filter, key)
+			if err != nil {
+				return filters, fmt.Errorf("bnc: parse %v filter of %v, %w", t, info.Symbol, err)
+			}
+			*field = v
+		}
+	}
+	return filters, nil
+}
+
+// stepDecimals returns decimal places of step, ex. 0.001 -> 3.
+func stepDecimals(step float64) int {
+	s := strconv.FormatFloat(step, 'f', -1, 64)
+	if i := strings.Index(s, "."); i != -1 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// RoundToStep rounds v to multiple of step.
+// If floor is true, v is rounded down, otherwise to the nearest.
+// v is returned directly, if step <= 0.
+func RoundToStep(v, step float64, floor bool) float64 {
+	if step <= 0 {
+		return v
+	}
+	n := v / step
+	// tolerate float error, ex. 0.3/0.1 = 2.9999999999999996
+	if floor {
+		n = math.Floor(n + 1e-9)
+	} else {
+		n = math.Round(n)
+	}
+	r, _ := strconv.ParseFloat(strconv.FormatFloat(n*step, 'f', stepDecimals(step), 64), 64)
+	return r
+}
+
+// Validate rounds qty down to step size, and price to the nearest tick size,
+// and then checks rounded qty, price and notional.
+// price of market order can be 0, and then price and notional are not checked.
+// Returned error wraps ErrOrderFilter, if order violates filters.
+func (f SymbolFilters) Validate(orderType cex.OrderType, qty, price float64) (float64, float64, error) {
+	stepSize, minQty, maxQty := f.StepSize, f.MinQty, f.MaxQty
+	if orderType == cex.OrderTypeMarket && f.MarketStepSize > 0 {
+		stepSize, minQty, maxQty = f.MarketStepSize, f.MarketMinQty, f.MarketMaxQty
+	}
+	qty = RoundToStep(qty, stepSize, true)
+	price = RoundToStep(price, f.TickSize, false)
+	if qty <= 0 {
+		return qty, price, fmt.Errorf("%w: %v qty %v <= 0 after rounding to step %v", ErrOrderFilter, f.Symbol, qty, stepSize)
+	}
+	if minQty > 0 && qty < minQty {
+		return qty, price, fmt.Errorf("%w: %v qty %v < min qty %v", ErrOrderFilter, f.Symbol, qty, minQty)
+	}
+	if maxQty > 0 && qty > maxQty {
+		return qty, price, fmt.Errorf("%w: %v qty %v > max qty %v", ErrOrderFilter, f.Symbol, qty, maxQty)
+	}
+	if price == 0 {
+		if orderType == cex.OrderTypeMarket {
+			return qty, price, nil
+		}
+		return qty, price, fmt.Errorf("%w: %v price is 0", ErrOrderFilter, f.Symbol)
+	}
+	if f.MinPrice > 0 && price < f.MinPrice {
+		return qty, price, fmt.Errorf("%w: %v price %v < min price %v", ErrOrderFilter, f.Symbol, price, f.MinPrice)
+	}
+	if f.MaxPrice > 0 && price > f.MaxPrice {
+		return qty, price, fmt.Errorf("%w: %v price %v > max price %v", ErrOrderFilter, f.Symbol, price, f.MaxPrice)
+	}
+	notional := qty * price
+	if f.MinNotional > 0 && notional < f.MinNotional {
+		return qty, price, fmt.Errorf("%w: %v notional %v < min notional %v", ErrOrderFilter, f.Symbol, notional, f.MinNotional)
+	}
+	if f.MaxNotional > 0 && notional > f.MaxNotional {
+		return qty, price, fmt.Errorf("%w: %v notional %v > max notional %v", ErrOrderFilter, f.Symbol, notional, f.MaxNotional)
+	}
+	return qty, price, nil
+}
+
+// OrderValidator validates orders by symbol filters locally before placing,
+// so orders violating filters will not cost request weight.
+// If User is set an OrderValidator, spot and futures orders placed by cex.Trader methods
+// are rounded and validated by it. Unknown symbols are not validated.
+type OrderValidator struct {
+	mux     sync.RWMutex
+	filters map[cex.PairType]map[string]SymbolFilters
+}
+
+func NewOrderValidator() *OrderValidator {
+	return &OrderValidator{filters: map[cex.PairType]map[string]SymbolFilters{}}
+}
+
+// LoadOrderValidator creates OrderValidator by spot and futures exchange info.
+func LoadOrderValidator() (*OrderValidator, error) {
+	v := NewOrderValidator()
+	spotInfo, err := QuerySpotExchangeInfo()
+	if err != nil {
+		return nil, err
+	}
+	if err = v.SetExchangeInfo(cex.PairTypeSpot, spotInfo); err != nil {
+		return nil, err
+	}
+	fuInfo, err := QueryFuturesExchangeInfo()
+	if err != nil {
+		return nil, err
+	}
+	if err = v.SetExchangeInfo(cex.PairTypeFutures, fuInfo); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// SetExchangeInfo replaces filters of pairType by info,
+// can be called periodically to refresh filters.
+func (v *OrderValidator) SetExchangeInfo(pairType cex.PairType, info ExchangeInfo) error {
+	filtersBySyb := map[string]SymbolFilters{}
+	for _, syb := range info.Symbols {
+		filters, err := ParseSymbolFilters(syb)
+		if err != nil {
+			return err
+		}
+		filtersBySyb[syb.Symbol] = filters
+	}
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.filters[pairType] = filtersBySyb
+	return nil
+}
+
+func (v *OrderValidator) Filters(pairType cex.PairType, symbol string) (SymbolFilters, bool) {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+	filters, ok := v.filters[pairType][symbol]
+	return filters, ok
+}
+
+// Validate returns rounded qty and price, see SymbolFilters.Validate.
+// qty and price are returned directly, if symbol is unknown.
+func (v *OrderValidator) Validate(pairType cex.PairType, symbol string, orderType cex.OrderType, qty, price float64) (float64, float64, error) {
+	filters, ok := v.Filters(pairType, symbol)
+	if !ok {
+		return qty, price, nil
+	}
+	return filters.Validate(orderType, qty, price)
+}
+
+func UserOptOrderValidator(validator *OrderValidator) UserOpt {
+	return func(user *User) {
+		user.cfg.orderValidator = validator
+	}
+}
+
+// validateOrd rounds and validates order by order validator of user, if it is set.
+func (u *User) validateOrd(pairType cex.PairType, symbol string, orderType cex.OrderType, qty, price float64) (float64, float64, cex.RequestError) {
+	if u.cfg.orderValidator == nil {
+		return qty, price, cex.RequestError{}
+	}
+	qty, price, err := u.cfg.orderValidator.Validate(pairType, symbol, orderType, qty, price)
+	if err != nil {
+		return qty, price, cex.RequestError{Err: err}
+	}
+	return qty, price, cex.RequestError{}
+}
@@ -0,0 +1,72 @@
+package bnc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+var testSpotExchange = Exchange{
+	Symbol: "ETHUSDT",
+	Filters: []map[string]any{
+		{"filterType": "PRICE_FILTER", "minPrice": "0.01000000", "maxPrice": "1000000.00000000", "tickSize": "0.01000000"},
+		{"filterType": "LOT_SIZE", "minQty": "0.00010000", "maxQty": "9000.00000000", "stepSize": "0.00010000"},
+		{"filterType": "MARKET_LOT_SIZE", "minQty": "0.00000000", "maxQty": "1000.00000000", "stepSize": "0.00000000"},
+		{"filterType": "NOTIONAL", "minNotional": "5.00000000", "applyMinToMarket": true, "maxNotional": "9000000.00000000"},
+	},
+}
+
+func TestParseSymbolFilters(t *testing.T) {
+	filters, err := ParseSymbolFilters(testSpotExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filters.TickSize != 0.01 || filters.StepSize != 0.0001 || filters.MinNotional != 5 || filters.MarketMaxQty != 1000 {
+		t.Error("wrong filters", filters)
+	}
+
+	fu, err := ParseSymbolFilters(Exchange{
+		Symbol:       "ETHUSDT",
+		ContractType: "PERPETUAL",
+		Filters:      []map[string]any{{"filterType": "MIN_NOTIONAL", "notional": "20"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fu.MinNotional != 20 {
+		t.Error("futures min notional should be parsed from notional", fu)
+	}
+}
+
+func TestSymbolFilters_Validate(t *testing.T) {
+	filters, _ := ParseSymbolFilters(testSpotExchange)
+	qty, price, err := filters.Validate(cex.OrderTypeLimit, 0.30019, 2000.126)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qty != 0.3001 || price != 2000.13 {
+		t.Error("qty and price should be rounded", qty, price)
+	}
+	if _, _, err = filters.Validate(cex.OrderTypeLimit, 0.001, 2000); !errors.Is(err, ErrOrderFilter) {
+		t.Error("order under min notional should be rejected, but", err)
+	}
+	if _, _, err = filters.Validate(cex.OrderTypeLimit, 0.00001, 2000); !errors.Is(err, ErrOrderFilter) {
+		t.Error("order under step should be rejected, but", err)
+	}
+	if _, _, err = filters.Validate(cex.OrderTypeMarket, 0.01, 0); err != nil {
+		t.Error("market order without price should pass, but", err)
+	}
+}
+
+func TestUser_OrderValidator(t *testing.T) {
+	v := NewOrderValidator()
+	if err := v.SetExchangeInfo(cex.PairTypeSpot, ExchangeInfo{Symbols: []Exchange{testSpotExchange}}); err != nil {
+		t.Fatal(err)
+	}
+	user := NewUser("", "", UserOptOrderValidator(v))
+	_, _, err := user.NewSpotLimitBuyOrder("ETH", "USDT", 0.001, 2000)
+	if !errors.Is(err.Err, ErrOrderFilter) {
+		t.Error("order should be rejected locally, but", err.Error())
+	}
+}
@@ -0,0 +1,103 @@
+package bnc
+
+import (
+	"fmt"
+
+	"github.com/dwdwow/cex"
+)
+
+// TransferWallet is a wallet node of universal transfer.
+// Its value is the wallet name used in TransferType.
+type TransferWallet string
+
+const (
+	TransferWalletSpot      TransferWallet = "MAIN"
+	TransferWalletUmFutures TransferWallet = "UMFUTURE"
+	TransferWalletCmFutures TransferWallet = "CMFUTURE"
+	TransferWalletMargin    TransferWallet = "MARGIN"
+	TransferWalletFunding   TransferWallet = "FUNDING"
+)
+
+// transferWallets is in order of preference,
+// when there are several shortest paths.
+var transferWallets = []TransferWallet{
+	TransferWalletSpot,
+	TransferWalletFunding,
+	TransferWalletMargin,
+	TransferWalletUmFutures,
+	TransferWalletCmFutures,
+}
+
+// transferEdges are direct transfers between wallets.
+// There is no direct transfer between um futures and cm futures.
+var transferEdges = map[[2]TransferWallet]TransferType{
+	{TransferWalletSpot, TransferWalletUmFutures}:    TransferTypeMainUmfuture,
+	{TransferWalletSpot, TransferWalletCmFutures}:    TransferTypeMainCmfuture,
+	{TransferWalletSpot, TransferWalletMargin}:       TransferTypeMainMargin,
+	{TransferWalletSpot, TransferWalletFunding}:      TransferTypeMainFunding,
+	{TransferWalletUmFutures, TransferWalletSpot}:    TransferTypeUmfutureMain,
+	{TransferWalletUmFutures, TransferWalletMargin}:  TransferTypeUmfutureMargin,
+	{TransferWalletUmFutures, TransferWalletFunding}: TransferTypeUmfutureFunding,
+	{TransferWalletCmFutures, TransferWalletSpot}:    TransferTypeCmfutureMain,
+	{TransferWalletCmFutures, TransferWalletMargin}:  TransferTypeCmfutureMargin,
+	{TransferWalletCmFutures, TransferWalletFunding}: TransferTypeCmfutureFunding,
+	{TransferWalletMargin, TransferWalletSpot}:       TransferTypeMarginMain,
+	{TransferWalletMargin, TransferWalletUmFutures}:  TransferTypeMarginUmfuture,
+	{TransferWalletMargin, TransferWalletCmFutures}:  TransferTypeMarginCmfuture,
+	{TransferWalletMargin, TransferWalletFunding}:    TransferTypeMarginFunding,
+	{TransferWalletFunding, TransferWalletSpot}:      TransferTypeFundingMain,
+	{TransferWalletFunding, TransferWalletUmFutures}: TransferTypeFundingUmfuture,
+	{TransferWalletFunding, TransferWalletCmFutures}: TransferTypeFundingCmfuture,
+	{TransferWalletFunding, TransferWalletMargin}:    TransferTypeFundingMargin,
+}
+
+// TransferPath returns the shortest sequence of transfer types
+// which moves asset from wallet from to wallet to.
+// It returns nil, if from is to.
+func TransferPath(from, to TransferWallet) ([]TransferType, error) {
+	if from == to {
+		return nil, nil
+	}
+	prev := map[TransferWallet]TransferWallet{from: from}
+	queue := []TransferWallet{from}
+	for len(queue) > 0 && prev[to] == "" {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range transferWallets {
+			if _, ok := transferEdges[[2]TransferWallet{cur, next}]; !ok || prev[next] != "" {
+				continue
+			}
+			prev[next] = cur
+			queue = append(queue, next)
+		}
+	}
+	if prev[to] == "" {
+		return nil, fmt.Errorf("bnc: no transfer path from %v to %v", from, to)
+	}
+	var path []TransferType
+	for w := to; w != from; w = prev[w] {
+		path = append([]TransferType{transferEdges[[2]TransferWallet{prev[w], w}]}, path...)
+	}
+	return path, nil
+}
+
+// TransferBetween moves amount of asset from wallet from to wallet to,
+// by transfers of TransferPath in sequence.
+// If any transfer fails, following transfers are not executed,
+// and asset may stay in an intermediate wallet.
+// Results of executed transfers are returned.
+func (u *User) TransferBetween(from, to TransferWallet, asset string, amount float64, opts ...cex.CltOpt) ([]UniversalTransferResp, cex.RequestError) {
+	path, err := TransferPath(from, to)
+	if err != nil {
+		return nil, cex.RequestError{Err: err}
+	}
+	var results []UniversalTransferResp
+	for _, tranType := range path {
+		_, result, err := u.Transfer(tranType, asset, amount, opts...)
+		if err.IsNotNil() {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, cex.RequestError{}
+}
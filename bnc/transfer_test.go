@@ -0,0 +1,30 @@
+package bnc
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTransferPath(t *testing.T) {
+	for _, c := range []struct {
+		from, to TransferWallet
+		path     []TransferType
+	}{
+		{TransferWalletSpot, TransferWalletSpot, nil},
+		{TransferWalletSpot, TransferWalletUmFutures, []TransferType{TransferTypeMainUmfuture}},
+		{TransferWalletFunding, TransferWalletCmFutures, []TransferType{TransferTypeFundingCmfuture}},
+		{TransferWalletUmFutures, TransferWalletCmFutures, []TransferType{TransferTypeUmfutureMain, TransferTypeMainCmfuture}},
+		{TransferWalletCmFutures, TransferWalletUmFutures, []TransferType{TransferTypeCmfutureMain, TransferTypeMainUmfuture}},
+	} {
+		path, err := TransferPath(c.from, c.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(path, c.path) {
+			t.Error("wrong path", c.from, c.to, path)
+		}
+	}
+	if _, err := TransferPath(TransferWalletSpot, "OPTION"); err == nil {
+		t.Error("option wallet should not be reachable")
+	}
+}
@@ -0,0 +1,333 @@
+package bnc
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Triangular Arbitrage Scanner
+// ------------------------------------------------------------
+
+// TriArbLeg is one executable leg of triangular arbitrage, which converts From asset to To asset by taker order.
+type TriArbLeg struct {
+	Symbol string        `json:"symbol" bson:"symbol"`
+	Side   cex.OrderSide `json:"side" bson:"side"`
+	From   string        `json:"from" bson:"from"`
+	To     string        `json:"to" bson:"to"`
+	// Price is best ask of BUY leg, and best bid of SELL leg.
+	Price float64 `json:"price" bson:"price"`
+	// Qty is base asset qty of order, if the whole MaxStartQty is executed.
+	Qty float64 `json:"qty" bson:"qty"`
+	// TopQty is base asset qty of best bid or ask.
+	TopQty  float64 `json:"topQty" bson:"topQty"`
+	FeeRate float64 `json:"feeRate" bson:"feeRate"`
+}
+
+// TriArbOpportunity is a cycle StartAsset -> legs -> StartAsset, whose profit is above threshold after taker fees.
+type TriArbOpportunity struct {
+	StartAsset string       `json:"startAsset" bson:"startAsset"`
+	Legs       [3]TriArbLeg `json:"legs" bson:"legs"`
+	// Rate is StartAsset qty got back by 1 StartAsset.
+	Rate      float64 `json:"rate" bson:"rate"`
+	ProfitBps float64 `json:"profitBps" bson:"profitBps"`
+	// MaxStartQty is max StartAsset qty executable by best bids and asks.
+	MaxStartQty float64 `json:"maxStartQty" bson:"maxStartQty"`
+	// Time is unit of millisecond.
+	Time int64 `json:"time" bson:"time"`
+}
+
+type TriArbScannerOpt func(*TriArbScanner)
+
+// TriArbScannerOptMinProfitBps sets threshold, only opportunities whose profit is above it are emitted, default 0.
+func TriArbScannerOptMinProfitBps(bps float64) TriArbScannerOpt {
+	return func(s *TriArbScanner) {
+		s.minProfitBps = bps
+	}
+}
+
+// TriArbScannerOptFeeModel sets taker fee rates of legs, fees are ignored by default.
+func TriArbScannerOptFeeModel(model *FeeModel) TriArbScannerOpt {
+	return func(s *TriArbScanner) {
+		s.feeModel = model
+	}
+}
+
+// TriArbScannerOptStartAssets only scans cycles containing one of assets,
+// and the cycle starts from the first of assets it contains.
+// Cycles start from the smallest asset in alphabet by default.
+func TriArbScannerOptStartAssets(assets ...string) TriArbScannerOpt {
+	return func(s *TriArbScanner) {
+		s.startAssets = assets
+	}
+}
+
+// TriArbScannerOptHandler sets handler of opportunities found by Run.
+func TriArbScannerOptHandler(handler func(TriArbOpportunity)) TriArbScannerOpt {
+	return func(s *TriArbScanner) {
+		s.onOpportunity = handler
+	}
+}
+
+// triArbEdge converts from asset to to asset by symbol.
+type triArbEdge struct {
+	symbol string
+	from   string
+	to     string
+	// sell is true, if from is base asset
+	sell bool
+}
+
+type triArbCycle struct {
+	start string
+	edges [3]triArbEdge
+}
+
+type triArbQuote struct {
+	bid, bidQty, ask, askQty float64
+}
+
+// TriArbScanner maintains currency graph of spot symbols by book tickers,
+// and finds triangular arbitrage opportunities, whose profit after taker fees is above threshold.
+// Every symbol is an edge base -> quote by selling at best bid, and an edge quote -> base by buying at best ask.
+// Cycles are evaluated, when book ticker of any of their symbols is updated.
+//
+//	_, info, _ := NewPublicClient().SpotExchangeInfo()
+//	s := NewTriArbScanner(info, TriArbScannerOptMinProfitBps(5), TriArbScannerOptHandler(handler))
+//	p := NewWsCombinedMarketPublisher(cex.PairTypeSpot)
+//	p.Start(ctx)
+//	err := s.Run(ctx, p)
+type TriArbScanner struct {
+	minProfitBps  float64
+	feeModel      *FeeModel
+	startAssets   []string
+	onOpportunity func(TriArbOpportunity)
+
+	pairs    map[string]Exchange
+	cycles   []triArbCycle
+	bySymbol map[string][]int
+
+	mux    sync.RWMutex
+	quotes map[string]triArbQuote
+}
+
+// NewTriArbScanner builds currency graph by trading symbols of spot exchange info.
+func NewTriArbScanner(info ExchangeInfo, opts ...TriArbScannerOpt) *TriArbScanner {
+	s := &TriArbScanner{
+		pairs:    map[string]Exchange{},
+		bySymbol: map[string][]int{},
+		quotes:   map[string]triArbQuote{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	// graph[from][to] is edge from -> to
+	graph := map[string]map[string]triArbEdge{}
+	addEdge := func(e triArbEdge) {
+		if graph[e.from] == nil {
+			graph[e.from] = map[string]triArbEdge{}
+		}
+		graph[e.from][e.to] = e
+	}
+	for _, pair := range info.Symbols {
+		if pair.Status != ExchangeTrading || pair.BaseAsset == "" || pair.QuoteAsset == "" {
+			continue
+		}
+		s.pairs[pair.Symbol] = pair
+		addEdge(triArbEdge{symbol: pair.Symbol, from: pair.BaseAsset, to: pair.QuoteAsset, sell: true})
+		addEdge(triArbEdge{symbol: pair.Symbol, from: pair.QuoteAsset, to: pair.BaseAsset})
+	}
+	for a, aEdges := range graph {
+		for b, ab := range aEdges {
+			for c, bc := range graph[b] {
+				ca, ok := graph[c][a]
+				// every directed cycle is found 3 times, keep the one starting from the smallest asset
+				if !ok || c == a || a > b || a > c {
+					continue
+				}
+				cycle, ok := s.rotate([3]triArbEdge{ab, bc, ca})
+				if !ok {
+					continue
+				}
+				for _, e := range cycle.edges {
+					s.bySymbol[e.symbol] = append(s.bySymbol[e.symbol], len(s.cycles))
+				}
+				s.cycles = append(s.cycles, cycle)
+			}
+		}
+	}
+	return s
+}
+
+// rotate rotates cycle to start from start assets, ok is false if cycle does not contain any of them.
+func (s *TriArbScanner) rotate(edges [3]triArbEdge) (triArbCycle, bool) {
+	if len(s.startAssets) == 0 {
+		return triArbCycle{start: edges[0].from, edges: edges}, true
+	}
+	for _, asset := range s.startAssets {
+		for i, e := range edges {
+			if e.from == asset {
+				return triArbCycle{start: asset, edges: [3]triArbEdge{edges[i], edges[(i+1)%3], edges[(i+2)%3]}}, true
+			}
+		}
+	}
+	return triArbCycle{}, false
+}
+
+// Symbols returns symbols of all cycles in alphabet, which should be subscribed.
+func (s *TriArbScanner) Symbols() []string {
+	symbols := make([]string, 0, len(s.bySymbol))
+	for symbol := range s.bySymbol {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Cycles returns number of cycles scanned.
+func (s *TriArbScanner) Cycles() int {
+	return len(s.cycles)
+}
+
+// Handle updates book ticker, and returns opportunities of cycles containing its symbol,
+// sorted by profit in descending order.
+// Other events are ignored.
+func (s *TriArbScanner) Handle(e WsMarketEvent) []TriArbOpportunity {
+	if e.BookTicker == nil {
+		return nil
+	}
+	return s.UpdateBookTicker(*e.BookTicker)
+}
+
+// UpdateBookTicker updates book ticker, and returns opportunities of cycles containing its symbol,
+// sorted by profit in descending order.
+// Book ticker without positive prices removes quote of symbol.
+func (s *TriArbScanner) UpdateBookTicker(t WsBookTickerStream) []TriArbOpportunity {
+	cycleIds, ok := s.bySymbol[t.Symbol]
+	if !ok {
+		return nil
+	}
+	s.mux.Lock()
+	if t.BidPrice <= 0 || t.AskPrice <= 0 {
+		delete(s.quotes, t.Symbol)
+	} else {
+		s.quotes[t.Symbol] = triArbQuote{bid: t.BidPrice, bidQty: t.BidQty, ask: t.AskPrice, askQty: t.AskQty}
+	}
+	s.mux.Unlock()
+	now := time.Now().UnixMilli()
+	var opportunities []TriArbOpportunity
+	for _, id := range cycleIds {
+		o, ok := s.evaluate(s.cycles[id])
+		if !ok || o.ProfitBps <= s.minProfitBps {
+			continue
+		}
+		o.Time = now
+		opportunities = append(opportunities, o)
+	}
+	sort.Slice(opportunities, func(i, j int) bool { return opportunities[i].ProfitBps > opportunities[j].ProfitBps })
+	return opportunities
+}
+
+func (s *TriArbScanner) takerFeeRate(symbol string) float64 {
+	if s.feeModel == nil {
+		return 0
+	}
+	return s.feeModel.Rate(cex.PairTypeSpot, symbol).Taker
+}
+
+// evaluate returns opportunity of cycle by current quotes, ok is false if any quote is missing.
+func (s *TriArbScanner) evaluate(cycle triArbCycle) (o TriArbOpportunity, ok bool) {
+	o = TriArbOpportunity{StartAsset: cycle.start, Rate: 1, MaxStartQty: math.Inf(1)}
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	for i, e := range cycle.edges {
+		q, ok := s.quotes[e.symbol]
+		if !ok {
+			return o, false
+		}
+		leg := TriArbLeg{Symbol: e.symbol, From: e.from, To: e.to, FeeRate: s.takerFeeRate(e.symbol)}
+		// maxIn is max qty of from asset executable by best price
+		var rate, maxIn float64
+		if e.sell {
+			leg.Side, leg.Price, leg.TopQty = cex.OrderSideSell, q.bid, q.bidQty
+			rate, maxIn = q.bid, q.bidQty
+		} else {
+			leg.Side, leg.Price, leg.TopQty = cex.OrderSideBuy, q.ask, q.askQty
+			rate, maxIn = 1/q.ask, q.askQty*q.ask
+		}
+		// o.Rate is from asset qty got by 1 start asset now
+		o.MaxStartQty = math.Min(o.MaxStartQty, maxIn/o.Rate)
+		o.Rate *= rate * (1 - leg.FeeRate)
+		o.Legs[i] = leg
+	}
+	o.ProfitBps = (o.Rate - 1) * 10000
+	in := o.MaxStartQty
+	for i := range o.Legs {
+		leg := &o.Legs[i]
+		if leg.Side == cex.OrderSideSell {
+			leg.Qty = in
+			in *= leg.Price
+		} else {
+			leg.Qty = in / leg.Price
+			in = leg.Qty
+		}
+		in *= 1 - leg.FeeRate
+	}
+	return o, true
+}
+
+// Run subscribes book tickers of Symbols by publisher, which should be started,
+// and sends opportunities to handler until ctx is done.
+// Stream errors are ignored, quotes are updated by new book tickers after reconnecting.
+func (s *TriArbScanner) Run(ctx context.Context, pub *WsMarketPublisher, opts ...WsSubscriptionOpt) error {
+	opts = append([]WsSubscriptionOpt{WsSubscriptionOptSlowConsumerPolicy(SlowConsumerDropOldest)}, opts...)
+	var subs []*WsMarketSubscription
+	defer func() {
+		for _, sub := range subs {
+			_ = sub.Close()
+		}
+	}()
+	for _, symbol := range s.Symbols() {
+		sub, err := pub.SubBookTicker(symbol, opts...)
+		if err != nil {
+			return err
+		}
+		subs = append(subs, sub)
+	}
+	var wg sync.WaitGroup
+	for _, sub := range subs {
+		wg.Add(1)
+		go func(sub *WsMarketSubscription) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case e, ok := <-sub.Events():
+					if !ok {
+						return
+					}
+					if e.Err != nil {
+						continue
+					}
+					for _, o := range s.Handle(e.Data) {
+						if s.onOpportunity != nil {
+							s.onOpportunity(o)
+						}
+					}
+				}
+			}
+		}(sub)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// ------------------------------------------------------------
+// Triangular Arbitrage Scanner
+// ============================================================
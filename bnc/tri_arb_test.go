@@ -0,0 +1,103 @@
+package bnc
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+func testTriArbExchangeInfo() ExchangeInfo {
+	return ExchangeInfo{Symbols: []Exchange{
+		{Symbol: "BTCUSDT", Status: ExchangeTrading, BaseAsset: "BTC", QuoteAsset: "USDT"},
+		{Symbol: "ETHUSDT", Status: ExchangeTrading, BaseAsset: "ETH", QuoteAsset: "USDT"},
+		{Symbol: "ETHBTC", Status: ExchangeTrading, BaseAsset: "ETH", QuoteAsset: "BTC"},
+		{Symbol: "BNBUSDT", Status: ExchangeTrading, BaseAsset: "BNB", QuoteAsset: "USDT"},
+		{Symbol: "BNBBTC", Status: "BREAK", BaseAsset: "BNB", QuoteAsset: "BTC"},
+	}}
+}
+
+func TestTriArbScanner(t *testing.T) {
+	s := NewTriArbScanner(testTriArbExchangeInfo(), TriArbScannerOptMinProfitBps(100))
+	if s.Cycles() != 2 || len(s.Symbols()) != 3 {
+		t.Fatal("only 2 directed cycles of BTC, ETH and USDT should be scanned", s.Cycles(), s.Symbols())
+	}
+	s.UpdateBookTicker(WsBookTickerStream{Symbol: "BTCUSDT", BidPrice: 100, BidQty: 1, AskPrice: 100, AskQty: 1})
+	if os := s.UpdateBookTicker(WsBookTickerStream{Symbol: "ETHBTC", BidPrice: 0.1, BidQty: 100, AskPrice: 0.1, AskQty: 100}); len(os) != 0 {
+		t.Fatal("cycle with missing quote should not be evaluated", os)
+	}
+	os := s.Handle(WsMarketEvent{BookTicker: &WsBookTickerStream{Symbol: "ETHUSDT", BidPrice: 11, BidQty: 5, AskPrice: 11, AskQty: 5}})
+	if len(os) != 1 {
+		t.Fatal("only one direction should be profitable", os)
+	}
+	o := os[0]
+	// BTC -> ETH by ETHBTC ask, ETH -> USDT by ETHUSDT bid, USDT -> BTC by BTCUSDT ask
+	if o.StartAsset != "BTC" || math.Abs(o.Rate-1.1) > 1e-9 || math.Abs(o.ProfitBps-1000) > 1e-6 {
+		t.Error("wrong rate", o)
+	}
+	legs := [3]struct {
+		symbol string
+		side   cex.OrderSide
+	}{{"ETHBTC", cex.OrderSideBuy}, {"ETHUSDT", cex.OrderSideSell}, {"BTCUSDT", cex.OrderSideBuy}}
+	for i, leg := range legs {
+		if o.Legs[i].Symbol != leg.symbol || o.Legs[i].Side != leg.side {
+			t.Error("wrong leg", i, o.Legs[i])
+		}
+	}
+	// 5 ETH of ETHUSDT bid limits 0.5 BTC, which is less than 1 BTC of BTCUSDT ask
+	if math.Abs(o.MaxStartQty-0.5) > 1e-9 || math.Abs(o.Legs[0].Qty-5) > 1e-9 || math.Abs(o.Legs[2].Qty-0.55) > 1e-9 {
+		t.Error("wrong max start qty", o.MaxStartQty, o.Legs)
+	}
+
+	fees := NewFeeModel(0, false)
+	// other symbols are charged by vip 0 taker fee 0.1%
+	fees.SetSymbolRate(cex.PairTypeSpot, "ETHUSDT", FeeRate{Taker: 0.01})
+	s = NewTriArbScanner(testTriArbExchangeInfo(), TriArbScannerOptFeeModel(fees), TriArbScannerOptStartAssets("USDT"))
+	s.UpdateBookTicker(WsBookTickerStream{Symbol: "BTCUSDT", BidPrice: 100, BidQty: 1, AskPrice: 100, AskQty: 1})
+	s.UpdateBookTicker(WsBookTickerStream{Symbol: "ETHBTC", BidPrice: 0.1, BidQty: 100, AskPrice: 0.1, AskQty: 100})
+	if os := s.UpdateBookTicker(WsBookTickerStream{Symbol: "ETHUSDT", BidPrice: 11, BidQty: 5, AskPrice: 11, AskQty: 5}); len(os) != 1 || os[0].StartAsset != "USDT" || os[0].Legs[0].Symbol != "BTCUSDT" || math.Abs(os[0].Rate-1.1*0.99*0.999*0.999) > 1e-9 {
+		t.Error("cycle should start from USDT, and fee should be deducted", os)
+	}
+}
+
+func TestTriArbScanner_Run(t *testing.T) {
+	source := &testWsMarketSource{events: make(chan cex.WsStreamEvent[WsMarketEvent])}
+	p := newWsMarketPublisher(source, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.Start(ctx)
+
+	found := make(chan TriArbOpportunity, 10)
+	s := NewTriArbScanner(testTriArbExchangeInfo(), TriArbScannerOptHandler(func(o TriArbOpportunity) { found <- o }))
+	done := make(chan error)
+	go func() { done <- s.Run(ctx, p) }()
+	for p.Subscribers(CreateBookTickerTopic("ETHUSDT")) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for _, ticker := range []WsBookTickerStream{
+		{Symbol: "BTCUSDT", BidPrice: 100, BidQty: 1, AskPrice: 100, AskQty: 1},
+		{Symbol: "ETHBTC", BidPrice: 0.1, BidQty: 100, AskPrice: 0.1, AskQty: 100},
+	} {
+		source.events <- cex.WsStreamEvent[WsMarketEvent]{Data: WsMarketEvent{Event: WsBookTicker, BookTicker: &ticker}}
+	}
+	// quotes are handled concurrently, so wait until the last cycle symbol finds opportunity
+	ticker := WsBookTickerStream{Symbol: "ETHUSDT", BidPrice: 11, BidQty: 5, AskPrice: 11, AskQty: 5}
+	for {
+		source.events <- cex.WsStreamEvent[WsMarketEvent]{Data: WsMarketEvent{Event: WsBookTicker, BookTicker: &ticker}}
+		select {
+		case o := <-found:
+			if o.StartAsset != "BTC" || o.ProfitBps <= 0 {
+				t.Error("wrong opportunity", o)
+			}
+		case <-time.After(10 * time.Millisecond):
+			continue
+		}
+		break
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Error("run should return ctx error", err)
+	}
+}
@@ -2,27 +2,65 @@ package bnc
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
-	"time"
+	"sync"
 
 	"github.com/dwdwow/cex"
 	"github.com/dwdwow/s2m"
-	"github.com/go-resty/resty/v2"
 )
 
 type UserConfig struct {
 	fuPosSide                FuturesPositionSide
 	isPortfolioMarginAccount bool
+	orderTransport           OrderTransport
+	orderRoute               OrderRoute
+	// orderValidator rounds and validates orders before placing, may be nil
+	orderValidator *OrderValidator
+	// timeSync corrects signed timestamp, may be nil
+	timeSync *cex.TimeSync
+	// waitOrderOpts are used by WaitOrder
+	waitOrderOpts []cex.WaitOrderOpt
+	// logger logs requests made by user, cex.DefaultLogger is used if it is nil
+	logger cex.Logger
+	// recvWindow of signed requests, unit is millisecond, 0 means binance default 5000
+	recvWindow int64
+	// transport sends requests made by user, shared transport is used if it is nil
+	transport     cex.Transport
+	transportOpts []cex.TransportOpt
+	// backend sends requests made by user, cex.DefaultBackend is used if it is empty
+	backend cex.Backend
+	// cltOrdIds generates client order ids of new orders, and tracks pending orders
+	cltOrdIds *cex.ClientOrderIdRegistry
+	// baseUrlSelector selects base urls of requests made by user, may be nil
+	baseUrlSelector cex.BaseUrlSelector
+	// stpMode is default self trade prevention mode of new orders, empty means binance default
+	stpMode SelfTradePreventionMode
+	// fuModeSync syncs futures account mode lazily, may be nil
+	fuModeSync *futuresModeSync
 }
 
 type User struct {
 	api cex.Api
 	cfg UserConfig
+
+	// signer is created by api in NewUser,
+	// signerErr is returned by signed requests, if secret key is invalid.
+	signer    cex.ApiSigner
+	signerErr error
+
+	// cred resolves api lazily, if user is created with UserOptCredentialProvider
+	cred *userCredential
+
+	// ctx is set to every request made by user, if it is not nil.
+	ctx context.Context
 }
 
 type UserOpt func(*User)
@@ -45,6 +83,168 @@ func UserOptSetPortfolioMarginAccount() func(*User) {
 	}
 }
 
+// UserOptTimeSync makes user sign requests with server time of ts,
+// so requests are not rejected on skewed local clocks.
+// ts can be shared by users.
+func UserOptTimeSync(ts *cex.TimeSync) UserOpt {
+	return func(user *User) {
+		user.cfg.timeSync = ts
+	}
+}
+
+// UserOptSelfTradePreventionMode sets default self trade prevention mode of new orders,
+// which are placed by NewSpotOrder, NewFuturesOrder, their convenience methods and OrderBuilder.
+// It can be overridden by OrderBuilder.STP per order.
+func UserOptSelfTradePreventionMode(mode SelfTradePreventionMode) UserOpt {
+	return func(user *User) {
+		user.cfg.stpMode = mode
+	}
+}
+
+// UserOptTestnet makes requests to binance testnet.
+// Testnet api key is different from mainnet, see https://testnet.binance.vision.
+func UserOptTestnet() UserOpt {
+	return func(user *User) {
+		user.api.Env = cex.EnvTestnet
+	}
+}
+
+// UserOptWaitOrder sets options of WaitOrder, ex. poll interval,
+// transition callback and user data stream as update source, see WsOrderUpdateMsgHandler.
+func UserOptWaitOrder(opts ...cex.WaitOrderOpt) UserOpt {
+	return func(user *User) {
+		user.cfg.waitOrderOpts = append(user.cfg.waitOrderOpts, opts...)
+	}
+}
+
+// UserOptLogger logs requests made by user with logger,
+// ex. cex.SlogLogger(slog.Default()).
+func UserOptLogger(logger cex.Logger) UserOpt {
+	return func(user *User) {
+		user.cfg.logger = logger
+	}
+}
+
+// UserOptTransport sends requests made by user by transport,
+// ex. cex.NewTransport(cex.TransportOptProxy(proxy)).
+// It can be overridden by cex.CltOptTransport per call.
+func UserOptTransport(transport cex.Transport) UserOpt {
+	return func(user *User) {
+		user.cfg.transport = transport
+	}
+}
+
+// UserOptBackend sends requests made by user by backend,
+// ex. cex.BackendNetHTTP for latency sensitive callers.
+// It can be overridden by cex.CltOptBackend per call.
+func UserOptBackend(backend cex.Backend) UserOpt {
+	return func(user *User) {
+		user.cfg.backend = backend
+	}
+}
+
+// UserOptBaseUrlSelector sends requests made by user to base urls selected by selector,
+// ex. NewBaseUrlFailover() fails over to api1-api4 clusters.
+// selector can be shared by users.
+func UserOptBaseUrlSelector(selector cex.BaseUrlSelector) UserOpt {
+	return func(user *User) {
+		user.cfg.baseUrlSelector = selector
+	}
+}
+
+// UserOptProxy routes requests made by user through http, https or socks5 proxy,
+// ex. to send requests from the egress ip whitelisted by api key.
+// proxy can be parsed by cex.ParseProxyUrl.
+func UserOptProxy(proxy *url.URL) UserOpt {
+	return func(user *User) {
+		user.cfg.transportOpts = append(user.cfg.transportOpts, cex.TransportOptProxy(proxy))
+	}
+}
+
+// UserOptTLSConfig sets tls config of requests made by user.
+func UserOptTLSConfig(cfg *tls.Config) UserOpt {
+	return func(user *User) {
+		user.cfg.transportOpts = append(user.cfg.transportOpts, cex.TransportOptTLSConfig(cfg))
+	}
+}
+
+// UserOptKeyType sets type of secret key, default is cex.KeyTypeHmac.
+// Secret key of cex.KeyTypeRSA and cex.KeyTypeEd25519 is PEM encoded private key,
+// which can be read by cex.ReadPrivateKeyPEM.
+func UserOptKeyType(keyType cex.KeyType) UserOpt {
+	return func(user *User) {
+		user.api.KeyType = keyType
+	}
+}
+
+// UserOptClientOrderIdRegistry sets registry generating client order ids of new orders,
+// ex. to share one registry by users, or to set prefix of ids.
+// Default registry of prefix cex.DefaultClientOrderIdPrefix is created by NewUser.
+func UserOptClientOrderIdRegistry(reg *cex.ClientOrderIdRegistry) UserOpt {
+	return func(user *User) {
+		user.cfg.cltOrdIds = reg
+	}
+}
+
+// UserOptRecvWindow sets recvWindow of all signed requests, unit is millisecond.
+// Binance rejects requests whose timestamp is older than recvWindow with ErrTimestampOutsideRecvWindow,
+// larger recvWindow tolerates more network latency and clock skew, max is 60000.
+// It can be overridden by cex.CltOptRecvWindow per call.
+func UserOptRecvWindow(recvWindow int64) UserOpt {
+	return func(user *User) {
+		user.cfg.recvWindow = recvWindow
+	}
+}
+
+// UserOptCredentialProvider makes user resolve api key of name by provider lazily,
+// at the first request, ex. NewUser("", "", UserOptCredentialProvider(cex.EnvCredentialProvider{}, "bnc-main")).
+// Env and KeyType of resolved api override those of opts, if they are not empty.
+// If resolving fails, request fails, and resolving is retried by the next request.
+func UserOptCredentialProvider(provider cex.CredentialProvider, name string) UserOpt {
+	return func(user *User) {
+		user.cred = &userCredential{provider: provider, name: name}
+	}
+}
+
+type userCredential struct {
+	provider cex.CredentialProvider
+	name     string
+
+	mux      sync.Mutex
+	resolved bool
+}
+
+// resolveCredential resolves api by credential provider once.
+func (u *User) resolveCredential() error {
+	if u.cred == nil {
+		return nil
+	}
+	u.cred.mux.Lock()
+	defer u.cred.mux.Unlock()
+	if u.cred.resolved {
+		return nil
+	}
+	ctx := u.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	api, err := u.cred.provider.Credential(ctx, u.cred.name)
+	if err != nil {
+		return fmt.Errorf("bnc: resolve credential %v, %w", u.cred.name, err)
+	}
+	u.api.ApiKey = api.ApiKey
+	u.api.SecretKey = api.SecretKey
+	if api.Env != "" {
+		u.api.Env = api.Env
+	}
+	if api.KeyType != "" {
+		u.api.KeyType = api.KeyType
+	}
+	u.signer, u.signerErr = u.api.NewSigner()
+	u.cred.resolved = true
+	return nil
+}
+
 func NewUser(apiKey, secretKey string, opts ...UserOpt) *User {
 	user := &User{
 		api: cex.Api{Cex: cex.BINANCE, ApiKey: apiKey, SecretKey: secretKey},
@@ -53,9 +253,31 @@ func NewUser(apiKey, secretKey string, opts ...UserOpt) *User {
 	for _, opt := range opts {
 		opt(user)
 	}
+	user.cfg.transport = cex.ApplyTransportOpts(user.cfg.transport, user.cfg.transportOpts...)
+	user.signer, user.signerErr = user.api.NewSigner()
+	if user.cfg.cltOrdIds == nil {
+		user.cfg.cltOrdIds = cex.NewClientOrderIdRegistry(cex.DefaultClientOrderIdPrefix)
+	}
 	return user
 }
 
+// NewUserKeyPool creates users by apis with the same user opts,
+// and holds them in a cex.KeyPool.
+func NewUserKeyPool(apis []cex.Api, poolOpts []cex.KeyPoolOpt, userOpts ...UserOpt) *cex.KeyPool[*User] {
+	users := make([]*User, 0, len(apis))
+	for _, api := range apis {
+		opts := userOpts
+		if api.Env.IsTestnet() {
+			opts = append(opts[:len(opts):len(opts)], UserOptTestnet())
+		}
+		if api.KeyType != "" {
+			opts = append(opts[:len(opts):len(opts)], UserOptKeyType(api.KeyType))
+		}
+		users = append(users, NewUser(api.ApiKey, api.SecretKey, opts...))
+	}
+	return cex.NewKeyPool(users, poolOpts...)
+}
+
 var emptyUser = &User{}
 
 func EmptyUser() *User {
@@ -66,7 +288,9 @@ func EmptyUser() *User {
 // User Getter
 // ------------------------------------------------------------
 
+// Api returns api of user, which is resolved by credential provider firstly, if it is set.
 func (u *User) Api() cex.Api {
+	_ = u.resolveCredential()
 	return u.api
 }
 
@@ -74,6 +298,33 @@ func (u *User) Config() UserConfig {
 	return u.cfg
 }
 
+// ClientOrderIds returns registry of client order ids,
+// whose pending orders should be reconciled by ReconcileOrder, ex. after restarting network.
+func (u *User) ClientOrderIds() *cex.ClientOrderIdRegistry {
+	return u.cfg.cltOrdIds
+}
+
+// RecvWindow returns recvWindow of signed requests, 0 means binance default.
+func (u *User) RecvWindow() int64 {
+	return u.cfg.recvWindow
+}
+
+// Context returns context bound to user, may be nil.
+func (u *User) Context() context.Context {
+	return u.ctx
+}
+
+// WithContext returns a shallow copy of user bound to ctx.
+// Every request made by the copy carries ctx,
+// so all User methods have ctx-accepting counterparts, ex.
+//
+//	u.WithContext(ctx).SpotAccount()
+func (u *User) WithContext(ctx context.Context) *User {
+	nu := *u
+	nu.ctx = ctx
+	return &nu
+}
+
 // ------------------------------------------------------------
 // User Getter
 // ============================================================
@@ -82,79 +333,378 @@ func (u *User) Config() UserConfig {
 // Account API
 // ------------------------------------------------------------
 
-func (u *User) Coins(opts ...cex.CltOpt) (*resty.Response, []Coin, cex.RequestError) {
+func (u *User) Coins(opts ...cex.CltOpt) (*cex.Response, []Coin, cex.RequestError) {
 	return cex.Request(u, CoinInfoConfig, nil, opts...)
 }
 
-func (u *User) SpotAccount(opts ...cex.CltOpt) (*resty.Response, SpotAccount, cex.RequestError) {
+func (u *User) SpotAccount(opts ...cex.CltOpt) (*cex.Response, SpotAccount, cex.RequestError) {
 	return cex.Request(u, SpotAccountConfig, nil, opts...)
 }
 
-func (u *User) Transfer(tranType TransferType, asset string, amount float64, opts ...cex.CltOpt) (*resty.Response, UniversalTransferResp, cex.RequestError) {
+// SpotAccountCommission queries current commission rates of symbol, including tax and discount.
+func (u *User) SpotAccountCommission(symbol string, opts ...cex.CltOpt) (*cex.Response, SpotAccountCommission, cex.RequestError) {
+	return cex.Request(u, SpotAccountCommissionConfig, SpotAccountCommissionParams{Symbol: symbol}, opts...)
+}
+
+// SpotTradeFees queries spot trade fees of symbol, or of all symbols if symbol is empty.
+func (u *User) SpotTradeFees(symbol string, opts ...cex.CltOpt) (*cex.Response, []SpotTradeFee, cex.RequestError) {
+	return cex.Request(u, SpotTradeFeeConfig, SpotTradeFeeParams{Symbol: symbol}, opts...)
+}
+
+func (u *User) BNBBurnStatus(opts ...cex.CltOpt) (*cex.Response, BNBBurnStatus, cex.RequestError) {
+	return cex.Request(u, BNBBurnStatusConfig, nil, opts...)
+}
+
+// ToggleSpotBNBBurn sets whether spot trading fee is paid by BNB.
+func (u *User) ToggleSpotBNBBurn(enabled bool, opts ...cex.CltOpt) (*cex.Response, BNBBurnStatus, cex.RequestError) {
+	return cex.Request(u, ToggleBNBBurnConfig, ToggleBNBBurnParams{SpotBNBBurn: &enabled}, opts...)
+}
+
+// ToggleInterestBNBBurn sets whether margin loan interest is paid by BNB.
+func (u *User) ToggleInterestBNBBurn(enabled bool, opts ...cex.CltOpt) (*cex.Response, BNBBurnStatus, cex.RequestError) {
+	return cex.Request(u, ToggleBNBBurnConfig, ToggleBNBBurnParams{InterestBNBBurn: &enabled}, opts...)
+}
+
+// ApiRestrictions queries permissions of api key.
+func (u *User) ApiRestrictions(opts ...cex.CltOpt) (*cex.Response, ApiRestrictions, cex.RequestError) {
+	return cex.Request(u, ApiRestrictionsConfig, nil, opts...)
+}
+
+func (u *User) AccountStatus(opts ...cex.CltOpt) (*cex.Response, AccountStatus, cex.RequestError) {
+	return cex.Request(u, AccountStatusConfig, nil, opts...)
+}
+
+func (u *User) Transfer(tranType TransferType, asset string, amount float64, opts ...cex.CltOpt) (*cex.Response, UniversalTransferResp, cex.RequestError) {
 	return cex.Request(u, UniversalTransferConfig, UniversalTransferParams{Type: tranType, Asset: asset, Amount: amount}, opts...)
 }
 
-func (u *User) FuturesAccount(opts ...cex.CltOpt) (*resty.Response, FuturesAccount, cex.RequestError) {
+func (u *User) FuturesAccount(opts ...cex.CltOpt) (*cex.Response, FuturesAccount, cex.RequestError) {
 	return cex.Request(u, FuturesAccountConfig, nil, opts...)
 }
 
-func (u *User) FuturesPositions(symbol string, opts ...cex.CltOpt) (*resty.Response, []FuturesPosition, cex.RequestError) {
+func (u *User) FuturesCommissionRate(symbol string, opts ...cex.CltOpt) (*cex.Response, FuturesCommissionRate, cex.RequestError) {
+	return cex.Request(u, FuturesCommissionRateConfig, FuturesCommissionRateParams{Symbol: symbol}, opts...)
+}
+
+func (u *User) FuturesFeeBurnStatus(opts ...cex.CltOpt) (*cex.Response, FuturesFeeBurnStatus, cex.RequestError) {
+	return cex.Request(u, FuturesFeeBurnStatusConfig, nil, opts...)
+}
+
+// ToggleFuturesFeeBurn sets whether futures trading fee is paid by BNB.
+func (u *User) ToggleFuturesFeeBurn(enabled bool, opts ...cex.CltOpt) (*cex.Response, CodeMsg, cex.RequestError) {
+	return cex.Request(u, FuturesToggleFeeBurnConfig, FuturesToggleFeeBurnParams{FeeBurn: enabled}, opts...)
+}
+
+func (u *User) FuturesPositions(symbol string, opts ...cex.CltOpt) (*cex.Response, []FuturesPosition, cex.RequestError) {
 	return cex.Request(u, FuturesPositionsConfig, FuturesPositionsParams{Symbol: symbol}, opts...)
 }
 
-func (u *User) PortfolioMarginAccountInformation(opts ...cex.CltOpt) (*resty.Response, PortfolioMarginAccountInformation, cex.RequestError) {
+func (u *User) PortfolioMarginAccountInformation(opts ...cex.CltOpt) (*cex.Response, PortfolioMarginAccountInformation, cex.RequestError) {
 	return cex.Request(u, PortfolioMarginAccountInformationConfig, nil, opts...)
 }
 
-func (u *User) PortfolioMarginAccountDetail(opts ...cex.CltOpt) (*resty.Response, PortfolioMarginAccountDetail, cex.RequestError) {
+func (u *User) PortfolioMarginAccountDetail(opts ...cex.CltOpt) (*cex.Response, PortfolioMarginAccountDetail, cex.RequestError) {
 	return cex.Request(u, PortfolioMarginAccountDetailConfig, nil, opts...)
 }
 
-//func (u *User) PortfolioMarginBalance(asset string, opts ...cex.CltOpt) (*resty.Response, PortfolioMarginBalance, cex.RequestError) {
-//	return cex.Request(u, PortfolioMarginBalanceConfig, PortfolioMarginAccountBalanceParams{asset}, opts...)
-//}
+func (u *User) PortfolioMarginBalance(asset string, opts ...cex.CltOpt) (*cex.Response, PortfolioMarginBalance, cex.RequestError) {
+	return cex.Request(u, PortfolioMarginBalanceConfig, PortfolioMarginAccountBalanceParams{asset}, opts...)
+}
 
-func (u *User) PortfolioMarginBalances(opts ...cex.CltOpt) (*resty.Response, []PortfolioMarginBalance, cex.RequestError) {
+func (u *User) PortfolioMarginBalances(opts ...cex.CltOpt) (*cex.Response, []PortfolioMarginBalance, cex.RequestError) {
 	return cex.Request(u, PortfolioMarginBalancesConfig, nil, opts...)
 }
 
-func (u *User) PortfolioMarginPositions(symbol string, opts ...cex.CltOpt) (*resty.Response, []PortfolioMarginUMPositionRisk, cex.RequestError) {
+func (u *User) PortfolioMarginPositions(symbol string, opts ...cex.CltOpt) (*cex.Response, []PortfolioMarginUMPositionRisk, cex.RequestError) {
 	return cex.Request(u, PortfolioMarginPositionsConfig, FuturesPositionsParams{symbol}, opts...)
 }
 
-func (u *User) Withdraw(coin string, network Network, address string, qty float64) (*resty.Response, WithdrawResult, cex.RequestError) {
-	return cex.Request(u, WithdrawConfig, WithdrawParams{Coin: coin, Network: network, Address: address, Amount: qty})
+func (u *User) PortfolioMarginCMPositions(symbol string, opts ...cex.CltOpt) (*cex.Response, []PortfolioMarginCMPositionRisk, cex.RequestError) {
+	return cex.Request(u, PortfolioMarginCMPositionsConfig, FuturesPositionsParams{symbol}, opts...)
 }
 
-func (u *User) DepositAddress(coin string, network Network) (*resty.Response, DepositAddress, cex.RequestError) {
-	return cex.Request(u, DepositAddressConfig, DepositAddressParams{Coin: coin, Network: network})
+// PortfolioMarginOpenOrders returns um open orders, all symbols are returned if symbol is empty.
+func (u *User) PortfolioMarginOpenOrders(symbol string, opts ...cex.CltOpt) (*cex.Response, []FuturesOrder, cex.RequestError) {
+	return cex.Request(u, PortfolioMarginOpenOrdersConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol}, opts...)
+}
+
+// PortfolioMarginCMOpenOrders returns cm open orders, all symbols are returned if symbol is empty.
+func (u *User) PortfolioMarginCMOpenOrders(symbol string, opts ...cex.CltOpt) (*cex.Response, []FuturesOrder, cex.RequestError) {
+	return cex.Request(u, PortfolioMarginCMOpenOrdersConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol}, opts...)
+}
+
+func (u *User) QueryPortfolioMarginCMOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return cex.Request(u, PortfolioMarginQueryCMOrderConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
+}
+
+func (u *User) CancelPortfolioMarginCMOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return cex.Request(u, PortfolioMarginCancelCMOrderConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
+}
+
+func (u *User) OptionAccount(opts ...cex.CltOpt) (*cex.Response, OptionAccount, cex.RequestError) {
+	return cex.Request(u, OptionAccountConfig, nil, opts...)
+}
+
+// OptionPositions returns options positions, all positions are returned if symbol is empty.
+func (u *User) OptionPositions(symbol string, opts ...cex.CltOpt) (*cex.Response, []OptionPosition, cex.RequestError) {
+	return cex.Request(u, OptionPositionConfig, OptionPositionParams{Symbol: symbol}, opts...)
+}
+
+// NewOptionOrder places options limit order, symbol is like BTC-220815-50000-C.
+func (u *User) NewOptionOrder(symbol string, side cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, OptionOrder, cex.RequestError) {
+	return cex.Request(u, OptionNewOrderConfig, OptionNewOrderParams{
+		Symbol:      symbol,
+		Side:        mapStrStr(side, ordSideByCexOrdSide),
+		Type:        OrderTypeLimit,
+		Quantity:    qty,
+		Price:       price,
+		TimeInForce: TimeInForceGtc,
+	}, opts...)
+}
+
+func (u *User) QueryOptionOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, OptionOrder, cex.RequestError) {
+	return cex.Request(u, OptionQueryOrderConfig, OptionQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, ClientOrderId: cltOrdId}, opts...)
+}
+
+func (u *User) CancelOptionOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, OptionOrder, cex.RequestError) {
+	return cex.Request(u, OptionCancelOrderConfig, OptionQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, ClientOrderId: cltOrdId}, opts...)
+}
+
+// Withdraw withdraws coin to address by network.
+// If network is empty, the default network of coin is used.
+func (u *User) Withdraw(coin string, network Network, address string, qty float64, opts ...cex.CltOpt) (*cex.Response, WithdrawResult, cex.RequestError) {
+	return cex.Request(u, WithdrawConfig, WithdrawParams{Coin: coin, Network: network, Address: address, Amount: qty}, opts...)
+}
+
+func (u *User) WithdrawHistories(params WithdrawHistoriesParams, opts ...cex.CltOpt) (*cex.Response, []WithdrawHistory, cex.RequestError) {
+	return cex.Request(u, WithdrawHistoriesConfig, params, opts...)
+}
+
+// QueryWithdraw queries withdrawal by id returned by Withdraw.
+func (u *User) QueryWithdraw(id string, opts ...cex.CltOpt) (*cex.Response, WithdrawHistory, cex.RequestError) {
+	resp, histories, err := u.WithdrawHistories(WithdrawHistoriesParams{IdList: id}, opts...)
+	if err.IsNotNil() {
+		return resp, WithdrawHistory{}, err
+	}
+	for _, h := range histories {
+		if h.Id == id {
+			return resp, h, err
+		}
+	}
+	return resp, WithdrawHistory{}, cex.RequestError{ReqBaseConfig: WithdrawHistoriesConfig.ReqBaseConfig, Err: fmt.Errorf("bnc: withdraw %v is not found", id)}
+}
+
+// DustTransfer converts small balances of assets to BNB.
+func (u *User) DustTransfer(assets []string, opts ...cex.CltOpt) (*cex.Response, DustTransferResult, cex.RequestError) {
+	return cex.Request(u, DustTransferConfig, DustTransferParams{Assets: assets}, opts...)
+}
+
+// DustAssets queries spot assets that can be converted into BNB.
+func (u *User) DustAssets(opts ...cex.CltOpt) (*cex.Response, DustAssets, cex.RequestError) {
+	return cex.Request(u, DustAssetsConfig, DustAssetsParams{}, opts...)
+}
+
+func (u *User) DustLogs(startTime, endTime int64, opts ...cex.CltOpt) (*cex.Response, DustLogs, cex.RequestError) {
+	return cex.Request(u, DustLogConfig, DustLogParams{StartTime: startTime, EndTime: endTime}, opts...)
+}
+
+func (u *User) AssetDividendRecords(asset string, startTime, endTime int64, opts ...cex.CltOpt) (*cex.Response, Page[[]AssetDividendRecord], cex.RequestError) {
+	return cex.Request(u, AssetDividendRecordConfig, AssetDividendRecordParams{Asset: asset, StartTime: startTime, EndTime: endTime, Limit: 500}, opts...)
+}
+
+// AccountSnapshots queries daily account snapshots, max 30 days.
+func (u *User) AccountSnapshots(snapshotType AccountSnapshotType, startTime, endTime int64, opts ...cex.CltOpt) (*cex.Response, AccountSnapshots, cex.RequestError) {
+	return cex.Request(u, AccountSnapshotConfig, AccountSnapshotParams{Type: snapshotType, StartTime: startTime, EndTime: endTime, Limit: accountSnapshotsMaxLimit}, opts...)
+}
+
+// DepositAddress queries deposit address of coin by network.
+// If network is empty, the default network of coin is used.
+func (u *User) DepositAddress(coin string, network Network, opts ...cex.CltOpt) (*cex.Response, DepositAddress, cex.RequestError) {
+	return cex.Request(u, DepositAddressConfig, DepositAddressParams{Coin: coin, Network: network}, opts...)
+}
+
+func (u *User) DepositHistories(params DepositHistoriesParams, opts ...cex.CltOpt) (*cex.Response, []DepositHistory, cex.RequestError) {
+	return cex.Request(u, DepositHistoriesConfig, params, opts...)
 }
 
 // ------------------------------------------------------------
 // Account API
 // ============================================================
 
+// ============================================================
+// Locked Simple Earn API
+// ------------------------------------------------------------
+
+func (u *User) SimpleEarnLockedProducts(asset string, opts ...cex.CltOpt) (*cex.Response, Page[[]SimpleEarnLockedProduct], cex.RequestError) {
+	return cex.Request(u, SimpleEarnLockedProductConfig, SimpleEarnLockedProductListParams{Asset: asset, Size: 100}, opts...)
+}
+
+func (u *User) SimpleEarnLockedSubscribe(projectId string, amount float64, autoSubscribe bool, sourceAccount SimpleEarnSourceAccount, opts ...cex.CltOpt) (*cex.Response, SimpleEarnLockedSubscribeResult, cex.RequestError) {
+	auto := SmallFalse
+	if autoSubscribe {
+		auto = SmallTrue
+	}
+	return cex.Request(u, SimpleEarnLockedSubscribeConfig, SimpleEarnLockedSubscribeParams{ProjectId: projectId, Amount: amount, AutoSubscribe: auto, SourceAccount: sourceAccount}, opts...)
+}
+
+func (u *User) SimpleEarnLockedRedeem(positionId string, opts ...cex.CltOpt) (*cex.Response, SimpleEarnLockedRedeemResult, cex.RequestError) {
+	return cex.Request(u, SimpleEarnLockedRedeemConfig, SimpleEarnLockedRedeemParams{PositionId: positionId}, opts...)
+}
+
+func (u *User) SimpleEarnLockedPositions(asset, projectId string, opts ...cex.CltOpt) (*cex.Response, Page[[]SimpleEarnLockedPosition], cex.RequestError) {
+	return cex.Request(u, SimpleEarnLockedPositionsConfig, SimpleEarnLockedPositionsParams{Asset: asset, ProjectId: projectId, Size: 100}, opts...)
+}
+
+func (u *User) SimpleEarnLockedRewardsHistories(asset string, startTime, endTime int64, opts ...cex.CltOpt) (*cex.Response, Page[[]SimpleEarnLockedRewardsHistory], cex.RequestError) {
+	return cex.Request(u, SimpleEarnLockedRewardsHistoryConfig, SimpleEarnLockedRewardsHistoryParams{Asset: asset, StartTime: startTime, EndTime: endTime, Size: 100}, opts...)
+}
+
+// ------------------------------------------------------------
+// Locked Simple Earn API
+// ============================================================
+
+// ============================================================
+// Margin API
+// ------------------------------------------------------------
+
+func marginIsIsolated(isolatedSymbol string) BigBool {
+	if isolatedSymbol == "" {
+		return BigFalse
+	}
+	return BigTrue
+}
+
+func (u *User) MarginAccount(opts ...cex.CltOpt) (*cex.Response, MarginAccount, cex.RequestError) {
+	return cex.Request(u, MarginAccountConfig, nil, opts...)
+}
+
+// IsolatedMarginAccount queries isolated margin account, symbols can be empty or max 5 symbols separated by comma.
+func (u *User) IsolatedMarginAccount(symbols string, opts ...cex.CltOpt) (*cex.Response, IsolatedMarginAccount, cex.RequestError) {
+	return cex.Request(u, IsolatedMarginAccountConfig, IsolatedMarginAccountParams{Symbols: symbols}, opts...)
+}
+
+func (u *User) EnableIsolatedMarginAccount(symbol string, opts ...cex.CltOpt) (*cex.Response, IsolatedMarginAccountSwitch, cex.RequestError) {
+	return cex.Request(u, IsolatedMarginEnableConfig, IsolatedMarginSymbolParams{Symbol: symbol}, opts...)
+}
+
+func (u *User) DisableIsolatedMarginAccount(symbol string, opts ...cex.CltOpt) (*cex.Response, IsolatedMarginAccountSwitch, cex.RequestError) {
+	return cex.Request(u, IsolatedMarginDisableConfig, IsolatedMarginSymbolParams{Symbol: symbol}, opts...)
+}
+
+func (u *User) IsolatedMarginAccountLimit(opts ...cex.CltOpt) (*cex.Response, IsolatedMarginAccountLimit, cex.RequestError) {
+	return cex.Request(u, IsolatedMarginAccountLimitConfig, nil, opts...)
+}
+
+// IsolatedMarginTransfer transfers asset into or out of isolated margin account by universal transfer,
+// fromSymbol and toSymbol are required by transfer types from and to isolated margin account,
+// ex. TransferTypeMainIsolatedMargin requires toSymbol.
+func (u *User) IsolatedMarginTransfer(tranType TransferType, asset, fromSymbol, toSymbol string, amount float64, opts ...cex.CltOpt) (*cex.Response, UniversalTransferResp, cex.RequestError) {
+	return cex.Request(u, UniversalTransferConfig, UniversalTransferParams{Type: tranType, Asset: asset, Amount: amount, FromSymbol: fromSymbol, ToSymbol: toSymbol}, opts...)
+}
+
+// MarginBorrow borrows from cross margin, if isolatedSymbol is empty,
+// otherwise from isolated margin of isolatedSymbol.
+func (u *User) MarginBorrow(asset, isolatedSymbol string, amount float64, opts ...cex.CltOpt) (*cex.Response, MarginBorrowRepayResult, cex.RequestError) {
+	return cex.Request(u, MarginBorrowRepayConfig, MarginBorrowRepayParams{Asset: asset, IsIsolated: marginIsIsolated(isolatedSymbol), Symbol: isolatedSymbol, Amount: amount, Type: MarginBorrowRepayTypeBorrow}, opts...)
+}
+
+// MarginRepay repays to cross margin, if isolatedSymbol is empty,
+// otherwise to isolated margin of isolatedSymbol.
+func (u *User) MarginRepay(asset, isolatedSymbol string, amount float64, opts ...cex.CltOpt) (*cex.Response, MarginBorrowRepayResult, cex.RequestError) {
+	return cex.Request(u, MarginBorrowRepayConfig, MarginBorrowRepayParams{Asset: asset, IsIsolated: marginIsIsolated(isolatedSymbol), Symbol: isolatedSymbol, Amount: amount, Type: MarginBorrowRepayTypeRepay}, opts...)
+}
+
+func (u *User) MarginMaxBorrowable(asset, isolatedSymbol string, opts ...cex.CltOpt) (*cex.Response, MarginMaxBorrowable, cex.RequestError) {
+	return cex.Request(u, MarginMaxBorrowableConfig, MarginMaxBorrowableParams{Asset: asset, IsolatedSymbol: isolatedSymbol}, opts...)
+}
+
+func (u *User) MarginInterestHistories(asset, isolatedSymbol string, startTime, endTime int64, opts ...cex.CltOpt) (*cex.Response, Page[[]MarginInterestHistory], cex.RequestError) {
+	return cex.Request(u, MarginInterestHistoriesConfig, MarginInterestHistoriesParams{Asset: asset, IsolatedSymbol: isolatedSymbol, StartTime: startTime, EndTime: endTime, Size: 100}, opts...)
+}
+
+func (u *User) NewMarginOrder(params MarginNewOrderParams, opts ...cex.CltOpt) (*cex.Response, MarginOrder, cex.RequestError) {
+	return cex.Request(u, MarginNewOrderConfig, params, opts...)
+}
+
+// NewIsolatedMarginOrder places order of isolated margin account of params.Symbol.
+func (u *User) NewIsolatedMarginOrder(params MarginNewOrderParams, opts ...cex.CltOpt) (*cex.Response, MarginOrder, cex.RequestError) {
+	params.IsIsolated = BigTrue
+	return u.NewMarginOrder(params, opts...)
+}
+
+func (u *User) CancelMarginOrder(symbol string, isIsolated bool, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, MarginOrder, cex.RequestError) {
+	return cex.Request(u, MarginCancelOrderConfig, marginQueryOrCancelOrderParams(symbol, isIsolated, orderId, cltOrdId), opts...)
+}
+
+func (u *User) QueryMarginOrder(symbol string, isIsolated bool, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, MarginOrder, cex.RequestError) {
+	return cex.Request(u, MarginQueryOrderConfig, marginQueryOrCancelOrderParams(symbol, isIsolated, orderId, cltOrdId), opts...)
+}
+
+func marginQueryOrCancelOrderParams(symbol string, isIsolated bool, orderId int64, cltOrdId string) MarginQueryOrCancelOrderParams {
+	params := MarginQueryOrCancelOrderParams{Symbol: symbol, IsIsolated: BigFalse, OrderId: orderId, OrigClientOrderId: cltOrdId}
+	if isIsolated {
+		params.IsIsolated = BigTrue
+	}
+	return params
+}
+
+// ------------------------------------------------------------
+// Margin API
+// ============================================================
+
+// ============================================================
+// Sub Account API
+// ------------------------------------------------------------
+
+// SubAccounts queries sub accounts of master account.
+// email can be empty to query all sub accounts.
+func (u *User) SubAccounts(email string, opts ...cex.CltOpt) (*cex.Response, SubAccountList, cex.RequestError) {
+	return cex.Request(u, SubAccountListConfig, SubAccountListParams{Email: email, Limit: 200}, opts...)
+}
+
+// SubAccountTransfer transfers asset between master and sub accounts.
+// Empty email means master account.
+func (u *User) SubAccountTransfer(params SubAccountUniversalTransferParams, opts ...cex.CltOpt) (*cex.Response, SubAccountUniversalTransferResult, cex.RequestError) {
+	return cex.Request(u, SubAccountUniversalTransferConfig, params, opts...)
+}
+
+func (u *User) SubAccountTransferHistories(params SubAccountUniversalTransferHistoriesParams, opts ...cex.CltOpt) (*cex.Response, SubAccountUniversalTransferHistories, cex.RequestError) {
+	return cex.Request(u, SubAccountUniversalTransferHistoriesConfig, params, opts...)
+}
+
+func (u *User) SubAccountAssets(email string, opts ...cex.CltOpt) (*cex.Response, SubAccountAssets, cex.RequestError) {
+	return cex.Request(u, SubAccountAssetsConfig, SubAccountAssetsParams{Email: email}, opts...)
+}
+
+func (u *User) SubAccountFuturesPositions(email string, futuresType SubAccountFuturesType, opts ...cex.CltOpt) (*cex.Response, SubAccountFuturesPositionRisks, cex.RequestError) {
+	return cex.Request(u, SubAccountFuturesPositionRiskConfig, SubAccountFuturesPositionRiskParams{Email: email, FuturesType: futuresType}, opts...)
+}
+
+// ------------------------------------------------------------
+// Sub Account API
+// ============================================================
+
 // ============================================================
 // Flexible Simple Earn API
 // ------------------------------------------------------------
 
-func (u *User) SimpleEarnFlexibleProducts(asset string, opts ...cex.CltOpt) (*resty.Response, Page[[]SimpleEarnFlexibleProduct], cex.RequestError) {
+func (u *User) SimpleEarnFlexibleProducts(asset string, opts ...cex.CltOpt) (*cex.Response, Page[[]SimpleEarnFlexibleProduct], cex.RequestError) {
 	return cex.Request(u, SimpleEarnFlexibleProductConfig, SimpleEarnFlexibleProductListParams{Asset: asset, Size: 100}, opts...)
 }
 
-func (u *User) SimpleEarnFlexiblePositions(asset, productId string, opts ...cex.CltOpt) (*resty.Response, Page[[]SimpleEarnFlexiblePosition], cex.RequestError) {
+func (u *User) SimpleEarnFlexiblePositions(asset, productId string, opts ...cex.CltOpt) (*cex.Response, Page[[]SimpleEarnFlexiblePosition], cex.RequestError) {
 	return cex.Request(u, SimpleEarnFlexiblePositionsConfig, SimpleEarnFlexiblePositionsParams{Asset: asset, ProductId: productId, Size: 100}, opts...)
 }
 
-func (u *User) SimpleEarnFlexibleRedeem(productId string, redeemAll bool, amount float64, destAccount SimpleEarnFlexibleRedeemDestination, opts ...cex.CltOpt) (*resty.Response, SimpleEarnFlexibleRedeemResponse, cex.RequestError) {
+func (u *User) SimpleEarnFlexibleRedeem(productId string, redeemAll bool, amount float64, destAccount SimpleEarnFlexibleRedeemDestination, opts ...cex.CltOpt) (*cex.Response, SimpleEarnFlexibleRedeemResponse, cex.RequestError) {
 	return cex.Request(u, SimpleEarnFlexibleRedeemConfig, SimpleEarnFlexibleRedeemParams{ProductId: productId, RedeemAll: redeemAll, Amount: amount, DestAccount: destAccount}, opts...)
 }
 
-func (u *User) SimpleEarnFlexibleRateHistories(productId string, startTime, endTime int64, opts ...cex.CltOpt) (*resty.Response, Page[[]SimpleEarnFlexibleRateHistory], cex.RequestError) {
+func (u *User) SimpleEarnFlexibleRateHistories(productId string, startTime, endTime int64, opts ...cex.CltOpt) (*cex.Response, Page[[]SimpleEarnFlexibleRateHistory], cex.RequestError) {
 	return cex.Request(u, SimpleEarnFlexibleRateHistoryConfig, SimpleEarnFlexibleRateHistoryParams{ProductId: productId, StartTime: startTime, EndTime: endTime, Size: 100}, opts...)
 }
 
-func (u *User) SimpleEarnFlexibleAccount(opts ...cex.CltOpt) (*resty.Response, SimpleEarnFlexibleAccount, cex.RequestError) {
+func (u *User) SimpleEarnFlexibleAccount(opts ...cex.CltOpt) (*cex.Response, SimpleEarnFlexibleAccount, cex.RequestError) {
 	return cex.Request(u, SimpleEarnFlexibleAccountConfig, nil, opts...)
 }
 
@@ -166,43 +716,43 @@ func (u *User) SimpleEarnFlexibleAccount(opts ...cex.CltOpt) (*resty.Response, S
 // Flexible Loan API
 // ------------------------------------------------------------
 
-func (u *User) CryptoLoanFlexibleOngoingOrders(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]CryptoLoanFlexibleOngoingOrder], cex.RequestError) {
+func (u *User) CryptoLoanFlexibleOngoingOrders(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanFlexibleOngoingOrder], cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleOngoingOrdersConfig, CryptoLoanFlexibleOngoingOrdersParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
 }
 
-func (u *User) CryptoLoanIncomeHistories(asset string, incomeType CryptoLoanIncomeType, opts ...cex.CltOpt) (*resty.Response, []CryptoLoanIncomeHistory, cex.RequestError) {
+func (u *User) CryptoLoanIncomeHistories(asset string, incomeType CryptoLoanIncomeType, opts ...cex.CltOpt) (*cex.Response, []CryptoLoanIncomeHistory, cex.RequestError) {
 	return cex.Request(u, CryptoLoansIncomeHistoriesConfig, CryptoLoansIncomeHistoriesParams{Asset: asset, Type: incomeType, Limit: 100}, opts...)
 }
 
-func (u *User) CryptoLoanFlexibleBorrow(loanCoin string, collateralCoin string, loanAmount, collateralAmount float64, opts ...cex.CltOpt) (*resty.Response, CryptoLoanFlexibleBorrowResult, cex.RequestError) {
+func (u *User) CryptoLoanFlexibleBorrow(loanCoin string, collateralCoin string, loanAmount, collateralAmount float64, opts ...cex.CltOpt) (*cex.Response, CryptoLoanFlexibleBorrowResult, cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleBorrowConfig, CryptoLoanFlexibleBorrowParams{LoanCoin: loanCoin, LoanAmount: loanAmount, CollateralCoin: collateralCoin, CollateralAmount: collateralAmount}, opts...)
 }
 
-func (u *User) CryptoLoanFlexibleBorrowHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]CryptoLoanFlexibleBorrowHistory], cex.RequestError) {
+func (u *User) CryptoLoanFlexibleBorrowHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanFlexibleBorrowHistory], cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleBorrowHistoriesConfig, CryptoLoanFlexibleBorrowHistoriesParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
 }
 
-func (u *User) CryptoLoanFlexibleRepay(loanCoin, collateralCoin string, repayAmount float64, collateralReturn, fullRepayment BigBool, opts ...cex.CltOpt) (*resty.Response, CryptoLoanFlexibleRepayResult, cex.RequestError) {
+func (u *User) CryptoLoanFlexibleRepay(loanCoin, collateralCoin string, repayAmount float64, collateralReturn, fullRepayment BigBool, opts ...cex.CltOpt) (*cex.Response, CryptoLoanFlexibleRepayResult, cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleRepayConfig, CryptoLoanFlexibleRepayParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, RepayAmount: repayAmount, CollateralReturn: collateralReturn, FullRepayment: fullRepayment}, opts...)
 }
 
-func (u *User) CryptoLoanFlexibleRepaymentHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]CryptoLoanFlexibleRepaymentHistory], cex.RequestError) {
+func (u *User) CryptoLoanFlexibleRepaymentHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanFlexibleRepaymentHistory], cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleRepaymentHistoriesConfig, CryptoLoanFlexibleRepaymentHistoriesParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
 }
 
-func (u *User) CryptoLoanFlexibleAdjustLtv(loanCoin, collateralCoin string, adjustmentAmount float64, direction LTVAdjustDirection, opts ...cex.CltOpt) (*resty.Response, CryptoLoanFlexibleLoanAdjustLtvResult, cex.RequestError) {
+func (u *User) CryptoLoanFlexibleAdjustLtv(loanCoin, collateralCoin string, adjustmentAmount float64, direction LTVAdjustDirection, opts ...cex.CltOpt) (*cex.Response, CryptoLoanFlexibleLoanAdjustLtvResult, cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleLoanAdjustLtvConfig, CryptoLoanFlexibleAdjustLtvParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, AdjustmentAmount: adjustmentAmount, Direction: direction}, opts...)
 }
 
-func (u *User) CryptoLoanFlexibleAdjustLtvHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]CryptoLoanFlexibleAdjustLtvHistory], cex.RequestError) {
+func (u *User) CryptoLoanFlexibleAdjustLtvHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanFlexibleAdjustLtvHistory], cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleAdjustLtvHistoriesConfig, CryptoLoanFlexibleAdjustLtvHistoriesParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
 }
 
-func (u *User) CryptoLoanFlexibleLoanAssets(loanCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]CryptoLoanFlexibleLoanAsset], cex.RequestError) {
+func (u *User) CryptoLoanFlexibleLoanAssets(loanCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanFlexibleLoanAsset], cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleLoanAssetsConfig, CryptoLoanFlexibleLoanAssetsParams{loanCoin}, opts...)
 }
 
-func (u *User) CryptoLoanFlexibleCollateralAssets(collateralCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]CryptoLoanFlexibleCollateralCoin], cex.RequestError) {
+func (u *User) CryptoLoanFlexibleCollateralAssets(collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanFlexibleCollateralCoin], cex.RequestError) {
 	return cex.Request(u, CryptoLoanFlexibleCollateralCoinsConfig, CryptoLoanFlexibleCollateralCoinsParams{collateralCoin}, opts...)
 }
 
@@ -210,43 +760,91 @@ func (u *User) CryptoLoanFlexibleCollateralAssets(collateralCoin string, opts ..
 // Flexible Loan API
 // ============================================================
 
+// ============================================================
+// Stable Loan API
+// ------------------------------------------------------------
+
+func (u *User) CryptoLoanStableBorrow(loanCoin, collateralCoin string, loanAmount, collateralAmount float64, loanTerm CryptoLoanStableTerm, opts ...cex.CltOpt) (*cex.Response, CryptoLoanStableBorrowResult, cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableBorrowConfig, CryptoLoanStableBorrowParams{LoanCoin: loanCoin, LoanAmount: loanAmount, CollateralCoin: collateralCoin, CollateralAmount: collateralAmount, LoanTerm: loanTerm}, opts...)
+}
+
+func (u *User) CryptoLoanStableOngoingOrders(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanStableOngoingOrder], cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableOngoingOrdersConfig, CryptoLoanStableOngoingOrdersParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
+}
+
+func (u *User) CryptoLoanStableBorrowHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanStableBorrowHistory], cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableBorrowHistoriesConfig, CryptoLoanStableHistoriesParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
+}
+
+func (u *User) CryptoLoanStableRepay(orderId int64, amount float64, repayType CryptoLoanStableRepayType, collateralReturn BigBool, opts ...cex.CltOpt) (*cex.Response, CryptoLoanStableRepayResult, cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableRepayConfig, CryptoLoanStableRepayParams{OrderId: orderId, Amount: amount, Type: repayType, CollateralReturn: collateralReturn}, opts...)
+}
+
+func (u *User) CryptoLoanStableRepaymentHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanStableRepaymentHistory], cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableRepaymentHistoriesConfig, CryptoLoanStableHistoriesParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
+}
+
+func (u *User) CryptoLoanStableAdjustLtv(orderId int64, amount float64, direction LTVAdjustDirection, opts ...cex.CltOpt) (*cex.Response, CryptoLoanStableAdjustLtvResult, cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableAdjustLtvConfig, CryptoLoanStableAdjustLtvParams{OrderId: orderId, Amount: amount, Direction: direction}, opts...)
+}
+
+func (u *User) CryptoLoanStableAdjustLtvHistories(loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanStableAdjustLtvHistory], cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableAdjustLtvHistoriesConfig, CryptoLoanStableHistoriesParams{LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
+}
+
+// CryptoLoanStableLoanAssets queries loanable assets and interest rates of loan terms,
+// vipLevel 0 means user's vip level.
+func (u *User) CryptoLoanStableLoanAssets(loanCoin string, vipLevel int, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanStableLoanAsset], cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableLoanAssetsConfig, CryptoLoanStableLoanAssetsParams{LoanCoin: loanCoin, VipLevel: vipLevel}, opts...)
+}
+
+// CryptoLoanStableCollateralAssets queries collateral assets and LTVs,
+// vipLevel 0 means user's vip level.
+func (u *User) CryptoLoanStableCollateralAssets(collateralCoin string, vipLevel int, opts ...cex.CltOpt) (*cex.Response, Page[[]CryptoLoanStableCollateralCoin], cex.RequestError) {
+	return cex.Request(u, CryptoLoanStableCollateralCoinsConfig, CryptoLoanStableCollateralCoinsParams{CollateralCoin: collateralCoin, VipLevel: vipLevel}, opts...)
+}
+
+// ------------------------------------------------------------
+// Stable Loan API
+// ============================================================
+
 // ============================================================
 // VIP Loan API
 // ------------------------------------------------------------
 
-func (u *User) VIPLoanOngoingOrders(orderId, collateralAccountId, loanCoin, collateralCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]VIPLoanOngoingOrder], cex.RequestError) {
+func (u *User) VIPLoanOngoingOrders(orderId, collateralAccountId, loanCoin, collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]VIPLoanOngoingOrder], cex.RequestError) {
 	return cex.Request(u, VIPLoanOngoingOrderQueryConfig, VIPLoanOngoingOrderParams{OrderId: orderId, CollateralAccountId: collateralAccountId, LoanCoin: loanCoin, CollateralCoin: collateralCoin, Limit: 100}, opts...)
 }
 
-func (u *User) VIPLoanRepay(orderId int64, amount float64, opts ...cex.CltOpt) (*resty.Response, VIPLoanRepayResult, cex.RequestError) {
+func (u *User) VIPLoanRepay(orderId int64, amount float64, opts ...cex.CltOpt) (*cex.Response, VIPLoanRepayResult, cex.RequestError) {
 	return cex.Request(u, VIPLoanRepayConfig, VIPLoanRepayParams{OrderId: orderId, Amount: amount}, opts...)
 }
 
-func (u *User) VIPLoanRepayHistories(orderId int64, loanCoin string, startTime, endTime int64, opts ...cex.CltOpt) (*resty.Response, Page[[]VIPLoanRepayHistory], cex.RequestError) {
+func (u *User) VIPLoanRepayHistories(orderId int64, loanCoin string, startTime, endTime int64, opts ...cex.CltOpt) (*cex.Response, Page[[]VIPLoanRepayHistory], cex.RequestError) {
 	return cex.Request(u, VIPLoanRepayHistoryConfig, VIPLoanRepayHistoryParams{OrderId: orderId, LoanCoin: loanCoin, StartTime: startTime, EndTime: endTime, Limit: 100}, opts...)
 }
 
-func (u *User) VIPLoanLockedValue(orderId, collateralAccountId int64, opts ...cex.CltOpt) (*resty.Response, Page[[][]VIPLoanLockedValue], cex.RequestError) {
+func (u *User) VIPLoanLockedValue(orderId, collateralAccountId int64, opts ...cex.CltOpt) (*cex.Response, Page[[][]VIPLoanLockedValue], cex.RequestError) {
 	return cex.Request(u, VIPLoanLockedValueConfig, VIPLoanLockedValueQueryParams{OrderId: orderId, CollateralAccountId: collateralAccountId}, opts...)
 }
 
-func (u *User) VIPLoanBorrow(loanAccountId int64, loanCoin string, loanAmount float64, collateralAccountId, collateralCoin string, isFlexibleRate BigBool, loanTerm int64, opts ...cex.CltOpt) (*resty.Response, VIPLoanBorrowResult, cex.RequestError) {
+func (u *User) VIPLoanBorrow(loanAccountId int64, loanCoin string, loanAmount float64, collateralAccountId, collateralCoin string, isFlexibleRate BigBool, loanTerm int64, opts ...cex.CltOpt) (*cex.Response, VIPLoanBorrowResult, cex.RequestError) {
 	return cex.Request(u, VIPLoanBorrowConfig, VIPLoanBorrowParams{LoanAccountId: loanAccountId, LoanCoin: loanCoin, LoanAmount: loanAmount, CollateralAccountId: collateralAccountId, CollateralCoin: collateralCoin, IsFlexibleRate: isFlexibleRate, LoanTerm: loanTerm}, opts...)
 }
 
-func (u *User) VIPLoanLoanableAssets(loanCoin string, vipLevel int, opts ...cex.CltOpt) (*resty.Response, Page[[]VIPLoanableAsset], cex.RequestError) {
+func (u *User) VIPLoanLoanableAssets(loanCoin string, vipLevel int, opts ...cex.CltOpt) (*cex.Response, Page[[]VIPLoanableAsset], cex.RequestError) {
 	return cex.Request(u, VIPLoanLoanableAssetsConfig, VIPLoanableAssetQueryParams{LoanCoin: loanCoin, VipLevel: vipLevel}, opts...)
 }
 
-func (u *User) VIPLoanCollateralAssets(collateralCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]VIPLoanCollateralAsset], cex.RequestError) {
+func (u *User) VIPLoanCollateralAssets(collateralCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]VIPLoanCollateralAsset], cex.RequestError) {
 	return cex.Request(u, VIPLoanCollateralAssetsConfig, VIPLoanCollateralAssetQueryParams{CollateralCoin: collateralCoin}, opts...)
 }
 
-func (u *User) VIPLoanApplicationStatus(opts ...cex.CltOpt) (*resty.Response, Page[[]VIPLoanApplicationStatusInfo], cex.RequestError) {
+func (u *User) VIPLoanApplicationStatus(opts ...cex.CltOpt) (*cex.Response, Page[[]VIPLoanApplicationStatusInfo], cex.RequestError) {
 	return cex.Request(u, VIPLoanApplicationStatusConfig, VIPLoanApplicationStatusQueryParams{Limit: 100}, opts...)
 }
 
-func (u *User) VIPLoanInterestRates(loanCoin string, opts ...cex.CltOpt) (*resty.Response, Page[[]VIPLoanInterestRateInfo], cex.RequestError) {
+func (u *User) VIPLoanInterestRates(loanCoin string, opts ...cex.CltOpt) (*cex.Response, Page[[]VIPLoanInterestRateInfo], cex.RequestError) {
 	return cex.Request(u, VIPLoanInterestRatesConfig, VIPLoanInterestRateQueryParams{loanCoin}, opts...)
 }
 
@@ -258,55 +856,86 @@ func (u *User) VIPLoanInterestRates(loanCoin string, opts ...cex.CltOpt) (*resty
 // cex.Trader Interface Implementations
 // ------------------------------------------------------------
 
-func (u *User) QueryOrder(order *cex.Order, opts ...cex.CltOpt) (*resty.Response, cex.RequestError) {
+func (u *User) QueryOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
 	return u.queryOrd(order, opts...)
 }
 
-func (u *User) CancelOrder(order *cex.Order, opts ...cex.CltOpt) (*resty.Response, cex.RequestError) {
+func (u *User) CancelOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
 	return u.cancelOrd(order, opts...)
 }
 
+// ReconcileOrder queries order by its client order id,
+// to check if it was placed, after new order request failed by network errors.
+// placed is false, if binance does not know the order,
+// then it is safe to place it again.
+// Order is updated and resolved from pending orders, if result is known.
+func (u *User) ReconcileOrder(order *cex.Order, opts ...cex.CltOpt) (resp *cex.Response, placed bool, err cex.RequestError) {
+	if order == nil {
+		return nil, false, cex.RequestError{Err: errors.New("nil order")}
+	}
+	if order.ClientOrderId == "" {
+		return nil, false, cex.RequestError{Err: errors.New("bnc: reconcile order, empty client order id")}
+	}
+	query := *order
+	// query by client order id only, order id is empty or 0 if new order request failed
+	query.OrderId = ""
+	resp, err = u.queryOrd(&query, opts...)
+	switch {
+	case err.IsNil():
+		cex.MergeOrderUpdate(order, query)
+		placed = true
+	case errors.Is(&err, cex.ErrOrderNotFound), errors.Is(&err, cex.ErrUnknownOrder):
+		err = cex.RequestError{}
+	default:
+		return
+	}
+	if u.cfg.cltOrdIds != nil {
+		u.cfg.cltOrdIds.Resolve(order.ClientOrderId)
+	}
+	return
+}
+
 func (u *User) WaitOrder(ctx context.Context, order *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
 	return u.waitOrd(ctx, order, opts...)
 }
 
-func (u *User) NewSpotOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
-	return u.newSpotOrd(asset, quote, tradeType, orderSide, qty, price, opts...)
+func (u *User) NewSpotOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newSpotOrd(asset, quote, tradeType, orderSide, qty, price, newOrdOpts{}, opts...)
 }
 
-func (u *User) NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
 }
 
-func (u *User) NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
 }
 
-func (u *User) NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
 }
 
-func (u *User) NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
 }
 
-func (u *User) NewFuturesOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
-	return u.newFuOrd(true, asset, quote, tradeType, orderSide, qty, price, opts...)
+func (u *User) NewFuturesOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newFuOrd(true, asset, quote, tradeType, orderSide, qty, price, newOrdOpts{}, opts...)
 }
 
-func (u *User) NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
 }
 
-func (u *User) NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
 }
 
-func (u *User) NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
 }
 
-func (u *User) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
 }
 
@@ -318,23 +947,23 @@ func (u *User) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts
 // CM Order
 // ------------------------------------------------------------
 
-func (u *User) NewFuturesCMOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
-	return u.newFuOrd(false, asset, quote, tradeType, orderSide, qty, price, opts...)
+func (u *User) NewFuturesCMOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newFuOrd(false, asset, quote, tradeType, orderSide, qty, price, newOrdOpts{}, opts...)
 }
 
-func (u *User) NewFuturesLimitBuyCMOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewFuturesLimitBuyCMOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewFuturesCMOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
 }
 
-func (u *User) NewFuturesLimitSellCMOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewFuturesLimitSellCMOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewFuturesCMOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
 }
 
-func (u *User) NewFuturesMarketBuyCMOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewFuturesMarketBuyCMOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewFuturesCMOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
 }
 
-func (u *User) NewFuturesMarketSellCMOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) NewFuturesMarketSellCMOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	return u.NewFuturesCMOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
 }
 
@@ -342,18 +971,159 @@ func (u *User) NewFuturesMarketSellCMOrder(asset, quote string, qty float64, opt
 // CM Order
 // ============================================================
 
+// ============================================================
+// Convert API
+// ------------------------------------------------------------
+
+// ConvertPairs queries convertible pairs and amount limits, at least one of fromAsset and toAsset is required.
+func (u *User) ConvertPairs(fromAsset, toAsset string, opts ...cex.CltOpt) (*cex.Response, []ConvertPair, cex.RequestError) {
+	return cex.Request(u, ConvertExchangeInfoConfig, ConvertExchangeInfoParams{FromAsset: fromAsset, ToAsset: toAsset}, opts...)
+}
+
+func (u *User) ConvertQuote(params ConvertGetQuoteParams, opts ...cex.CltOpt) (*cex.Response, ConvertQuote, cex.RequestError) {
+	return cex.Request(u, ConvertGetQuoteConfig, params, opts...)
+}
+
+func (u *User) AcceptConvertQuote(quoteId string, opts ...cex.CltOpt) (*cex.Response, ConvertAcceptQuoteResult, cex.RequestError) {
+	return cex.Request(u, ConvertAcceptQuoteConfig, ConvertAcceptQuoteParams{QuoteId: quoteId}, opts...)
+}
+
+// QueryConvertOrder queries convert order by orderId or quoteId.
+func (u *User) QueryConvertOrder(orderId, quoteId string, opts ...cex.CltOpt) (*cex.Response, ConvertOrder, cex.RequestError) {
+	return cex.Request(u, ConvertOrderStatusConfig, ConvertOrderStatusParams{OrderId: orderId, QuoteId: quoteId}, opts...)
+}
+
+// ConvertMarket quotes converting fromAmount of fromAsset to toAsset, and accepts the quote immediately.
+// expectedRatio is expected toAmount / fromAmount, ex. from market price.
+// If ratio of quote is lower than expectedRatio * (1 - maxSlippage),
+// the quote is not accepted and error wraps ErrConvertSlippage.
+func (u *User) ConvertMarket(fromAsset, toAsset string, fromAmount, expectedRatio, maxSlippage float64, opts ...cex.CltOpt) (*cex.Response, ConvertQuote, ConvertAcceptQuoteResult, cex.RequestError) {
+	if expectedRatio <= 0 {
+		return nil, ConvertQuote{}, ConvertAcceptQuoteResult{}, cex.RequestError{ReqBaseConfig: ConvertGetQuoteConfig.ReqBaseConfig, Err: fmt.Errorf("bnc: convert expected ratio %v <= 0", expectedRatio)}
+	}
+	resp, quote, err := u.ConvertQuote(ConvertGetQuoteParams{FromAsset: fromAsset, ToAsset: toAsset, FromAmount: fromAmount}, opts...)
+	if err.IsNotNil() {
+		return resp, quote, ConvertAcceptQuoteResult{}, err
+	}
+	if minRatio := expectedRatio * (1 - maxSlippage); quote.Ratio < minRatio {
+		return resp, quote, ConvertAcceptQuoteResult{}, cex.RequestError{
+			ReqBaseConfig: ConvertGetQuoteConfig.ReqBaseConfig,
+			Err:           fmt.Errorf("bnc: %w, %v/%v quote ratio %v < min ratio %v", ErrConvertSlippage, fromAsset, toAsset, quote.Ratio, minRatio),
+		}
+	}
+	resp, result, err := u.AcceptConvertQuote(quote.QuoteId, opts...)
+	return resp, quote, result, err
+}
+
+// ------------------------------------------------------------
+// Convert API
+// ============================================================
+
 // ============================================================
 // Spot API
 // ------------------------------------------------------------
 
-func (u *User) CancelSpotOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*resty.Response, SpotOrder, cex.RequestError) {
-	return cex.Request(u, SpotCancelOrderConfig, SpotCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
+func (u *User) CancelSpotOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	return u.routeCancelSpotOrder(SpotCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
 }
 
-func (u *User) QuerySpotOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*resty.Response, SpotOrder, cex.RequestError) {
+func (u *User) QuerySpotOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
 	return cex.Request(u, SpotQueryOrderConfig, SpotQueryOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
 }
 
+// OpenSpotOrders queries open orders of symbol, or of all symbols if symbol is empty.
+func (u *User) OpenSpotOrders(symbol string, opts ...cex.CltOpt) (*cex.Response, []SpotOrder, cex.RequestError) {
+	return cex.Request(u, SpotCurrentOpenOrdersConfig, SpotCurrentOpenOrdersParams{Symbol: symbol}, opts...)
+}
+
+// CancelAllSpotOpenOrders cancels all open orders of symbol, including orders of order lists.
+func (u *User) CancelAllSpotOpenOrders(symbol string, opts ...cex.CltOpt) (*cex.Response, []SpotOrder, cex.RequestError) {
+	return cex.Request(u, SpotCancelAllOpenOrdersConfig, SpotCancelAllOpenOrdersParams{Symbol: symbol}, opts...)
+}
+
+func (u *User) NewSpotOCO(params SpotNewOCOParams, opts ...cex.CltOpt) (*cex.Response, SpotOrderList, cex.RequestError) {
+	return cex.Request(u, SpotNewOCOConfig, params, opts...)
+}
+
+// CancelSpotOCO cancels oco by orderListId or listClientOrderId.
+func (u *User) CancelSpotOCO(symbol string, orderListId int64, listCltOrdId string, opts ...cex.CltOpt) (*cex.Response, SpotOrderList, cex.RequestError) {
+	return cex.Request(u, SpotCancelOCOConfig, SpotCancelOCOParams{Symbol: symbol, OrderListId: orderListId, ListClientOrderId: listCltOrdId}, opts...)
+}
+
+// QuerySpotOCO queries oco by orderListId or listClientOrderId.
+func (u *User) QuerySpotOCO(orderListId int64, listCltOrdId string, opts ...cex.CltOpt) (*cex.Response, SpotOrderList, cex.RequestError) {
+	return cex.Request(u, SpotQueryOCOConfig, SpotQueryOCOParams{OrderListId: orderListId, OrigClientOrderId: listCltOrdId}, opts...)
+}
+
+func (u *User) SpotOpenOCOs(opts ...cex.CltOpt) (*cex.Response, []SpotOrderList, cex.RequestError) {
+	return cex.Request(u, SpotOpenOCOsConfig, nil, opts...)
+}
+
+// NewSpotOCOOrder places an oco order with a LIMIT_MAKER leg and a STOP_LOSS_LIMIT leg.
+// For sell side, limitPrice is above, and stopPrice and stopLimitPrice are below,
+// for buy side, reversely.
+func (u *User) NewSpotOCOOrder(asset, quote string, orderSide cex.OrderSide, qty, limitPrice, stopPrice, stopLimitPrice float64, opts ...cex.CltOpt) (*cex.Response, *cex.OrderList, cex.RequestError) {
+	params := SpotNewOCOParams{
+		Symbol:   asset + quote,
+		Side:     mapStrStr(orderSide, ordSideByCexOrdSide),
+		Quantity: qty,
+	}
+	if orderSide == cex.OrderSideSell {
+		params.AboveType, params.AbovePrice = OrderTypeLimitMaker, limitPrice
+		params.BelowType, params.BelowPrice, params.BelowStopPrice, params.BelowTimeInForce = OrderTypeStopLossLimit, stopLimitPrice, stopPrice, TimeInForceGtc
+	} else {
+		params.BelowType, params.BelowPrice = OrderTypeLimitMaker, limitPrice
+		params.AboveType, params.AbovePrice, params.AboveStopPrice, params.AboveTimeInForce = OrderTypeStopLossLimit, stopLimitPrice, stopPrice, TimeInForceGtc
+	}
+	resp, rawList, err := u.NewSpotOCO(params, opts...)
+	if err.IsNotNil() {
+		return resp, nil, err
+	}
+	list := SwitchSpotOrderListToCexOrderList(rawList)
+	list.ApiKey = u.api.ApiKey
+	for _, leg := range list.Legs {
+		leg.ApiKey = u.api.ApiKey
+	}
+	return resp, &list, err
+}
+
+// QuerySpotOrderList queries oco by OrderListId or ClientOrderListId of list,
+// and merges legs into list.
+// Query oco response does not contain order details,
+// so legs are queried one by one, if they are not finished.
+func (u *User) QuerySpotOrderList(list *cex.OrderList, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if list == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order list")}
+	}
+	resp, rawList, err := u.QuerySpotOCO(strOrdIdToInt64(list.OrderListId), list.ClientOrderListId, opts...)
+	if err.IsNotNil() {
+		return resp, err
+	}
+	cex.MergeOrderListUpdate(list, SwitchSpotOrderListToCexOrderList(rawList))
+	for _, leg := range list.Legs {
+		if leg.IsFinished() {
+			continue
+		}
+		resp, err = u.querySpotOrd(leg, opts...)
+		if err.IsNotNil() {
+			return resp, err
+		}
+	}
+	return resp, err
+}
+
+// CancelSpotOrderList cancels all legs of list.
+func (u *User) CancelSpotOrderList(list *cex.OrderList, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if list == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order list")}
+	}
+	resp, rawList, err := u.CancelSpotOCO(list.Symbol, strOrdIdToInt64(list.OrderListId), list.ClientOrderListId, opts...)
+	if err.IsNil() {
+		cex.MergeOrderListUpdate(list, SwitchSpotOrderListToCexOrderList(rawList))
+	}
+	return resp, err
+}
+
 // ------------------------------------------------------------
 // Spot API
 // ============================================================
@@ -362,21 +1132,149 @@ func (u *User) QuerySpotOrder(symbol string, orderId int64, cltOrdId string, opt
 // Futures API
 // ------------------------------------------------------------
 
-func (u *User) CancelFuturesOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*resty.Response, FuturesOrder, cex.RequestError) {
+func (u *User) CancelFuturesOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
 	if u.cfg.isPortfolioMarginAccount {
 		return cex.Request(u, PortfolioMarginCancelOrderConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
 	}
-	return cex.Request(u, FuturesCancelOrderConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
+	return u.routeCancelFuturesOrder(FuturesQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
 }
 
-func (u *User) QueryFuturesOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*resty.Response, FuturesOrder, cex.RequestError) {
+func (u *User) QueryFuturesOrder(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
 	if u.cfg.isPortfolioMarginAccount {
 		return cex.Request(u, PortfolioMarginQueryOrderConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
 	}
 	return cex.Request(u, FuturesQueryOrderConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
 }
 
-//func (u *User) CloseFuturesOrder(symbol string, ordType OrderType, side OrderSide, opts ...cex.CltOpt) (*resty.Response, FuturesOrder, cex.RequestError) {
+// OpenFuturesOrders queries open orders of symbol, or of all symbols if symbol is empty.
+func (u *User) OpenFuturesOrders(symbol string, opts ...cex.CltOpt) (*cex.Response, []FuturesOrder, cex.RequestError) {
+	if u.cfg.isPortfolioMarginAccount {
+		return u.PortfolioMarginOpenOrders(symbol, opts...)
+	}
+	return cex.Request(u, FuturesCurrentAllOpenOrdersConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol}, opts...)
+}
+
+// CancelAllFuturesOpenOrders cancels all open orders of symbol.
+func (u *User) CancelAllFuturesOpenOrders(symbol string, opts ...cex.CltOpt) (*cex.Response, CodeMsg, cex.RequestError) {
+	return cex.Request(u, FuturesCancelAllOpenOrdersConfig, FuturesQueryOrCancelOrderParams{Symbol: symbol}, opts...)
+}
+
+// ModifyFuturesOrder modifies qty and price of open usd-m futures limit order,
+// and updates qty and price of ord by response.
+// Binance cancels order, if qty is less than its filled qty.
+// Portfolio margin account is not supported.
+func (u *User) ModifyFuturesOrder(ord *cex.Order, qty, price float64, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	if ord.PairType != cex.PairTypeFutures {
+		return nil, cex.RequestError{Err: fmt.Errorf("bnc: modify order, pair type %v is not futures", ord.PairType)}
+	}
+	resp, rawOrd, err := cex.Request(u, FuturesModifyOrderConfig, FuturesModifyOrderParams{
+		OrderId:           strOrdIdToInt64(ord.OrderId),
+		OrigClientOrderId: ord.ClientOrderId,
+		Symbol:            ord.Symbol,
+		Side:              mapStrStr(ord.OrderSide, ordSideByCexOrdSide),
+		Quantity:          qty,
+		Price:             price,
+	}, opts...)
+	if err.IsNotNil() {
+		return resp, err
+	}
+	UpdateOrderWithRawFuturesOrder(ord, rawOrd)
+	// modified qty and price are newer than current ones, which are not replaced by merging
+	if rawOrd.OrigQty != 0 {
+		ord.OriQty = rawOrd.OrigQty
+	}
+	if rawOrd.Price != 0 {
+		ord.OriPrice = rawOrd.Price
+	}
+	return resp, err
+}
+
+// FuturesOrderAmendments queries modification histories of order, by orderId or cltOrdId.
+func (u *User) FuturesOrderAmendments(symbol string, orderId int64, cltOrdId string, opts ...cex.CltOpt) (*cex.Response, []FuturesOrderModifyHistory, cex.RequestError) {
+	return cex.Request(u, FuturesOrderModifyHistoriesConfig, FuturesOrderModifyHistoriesParams{Symbol: symbol, OrderId: orderId, OrigClientOrderId: cltOrdId}, opts...)
+}
+
+// SyncFuturesOrderAmendments queries modification histories of ord, and adds them to ord,
+// so qty and price of ord are the latest modified ones.
+func (u *User) SyncFuturesOrderAmendments(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	resp, histories, err := u.FuturesOrderAmendments(ord.Symbol, strOrdIdToInt64(ord.OrderId), ord.ClientOrderId, opts...)
+	if err.IsNotNil() {
+		return resp, err
+	}
+	amendments := make([]cex.OrderAmendment, 0, len(histories))
+	for _, h := range histories {
+		amendments = append(amendments, h.ToCexAmendment())
+	}
+	ord.AddAmendments(amendments...)
+	return resp, err
+}
+
+// NewFuturesBatchOrders places at most 5 futures orders in one request.
+// Results are in the same order as orders, check Err of every result.
+// Returned error is not nil only if the whole request fails.
+func (u *User) NewFuturesBatchOrders(orders []FuturesNewOrderParams, opts ...cex.CltOpt) (*cex.Response, []FuturesBatchOrderResult, cex.RequestError) {
+	if len(orders) == 0 || len(orders) > 5 {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: batch orders length %v is not in [1, 5]", len(orders))}
+	}
+	return cex.Request(u, FuturesBatchOrdersConfig, FuturesBatchOrdersParams{BatchOrders: orders}, opts...)
+}
+
+// CancelFuturesBatchOrders cancels at most 10 futures orders of symbol in one request.
+// Do not set orderIds and cltOrdIds together.
+// Results are in the same order as ids, check Err of every result.
+func (u *User) CancelFuturesBatchOrders(symbol string, orderIds []int64, cltOrdIds []string, opts ...cex.CltOpt) (*cex.Response, []FuturesBatchOrderResult, cex.RequestError) {
+	if l := len(orderIds) + len(cltOrdIds); l == 0 || l > 10 {
+		return nil, nil, cex.RequestError{Err: fmt.Errorf("bnc: batch cancel orders length %v is not in [1, 10]", l)}
+	}
+	return cex.Request(u, FuturesCancelMultiOrdersConfig, FuturesCancelMultiOrdersParams{Symbol: symbol, OrderIdList: orderIds, OrigClientOrderIdList: cltOrdIds}, opts...)
+}
+
+// ChangeFuturesLeverage changes initial leverage of symbol, leverage is in [1, 125].
+func (u *User) ChangeFuturesLeverage(symbol string, leverage int, opts ...cex.CltOpt) (*cex.Response, FuturesChangeInitialLeverageResponse, cex.RequestError) {
+	return cex.Request(u, FuturesChangeInitialLeverageConfig, FuturesChangeInitialLeverageParams{Symbol: symbol, Leverage: leverage}, opts...)
+}
+
+// ChangeFuturesMarginType changes margin type of symbol.
+// ErrFutureNoNeedToChangeMarginType is returned, if margin type is not changed.
+func (u *User) ChangeFuturesMarginType(symbol string, marginType FuturesMarginType, opts ...cex.CltOpt) (*cex.Response, CodeMsg, cex.RequestError) {
+	return cex.Request(u, FuturesChangeMarginTypeConfig, FuturesChangeMarginTypeParams{Symbol: symbol, MarginType: marginType}, opts...)
+}
+
+// ModifyFuturesIsolatedPositionMargin adds or reduces margin of isolated position.
+// positionSide must be set in hedge mode.
+func (u *User) ModifyFuturesIsolatedPositionMargin(symbol string, positionSide FuturesPositionSide, amount float64, modifyType FuturesModifyMarginType, opts ...cex.CltOpt) (*cex.Response, FuturesModifyIsolatedPositionMarginResponse, cex.RequestError) {
+	return cex.Request(u, FuturesModifyIsolatedPositionMarginConfig, FuturesModifyIsolatedPositionMarginParams{Symbol: symbol, PositionSide: positionSide, Amount: amount, Type: modifyType}, opts...)
+}
+
+// FuturesAdlQuantiles queries adl quantiles of positions, all symbols if symbol is empty.
+func (u *User) FuturesAdlQuantiles(symbol string, opts ...cex.CltOpt) (*cex.Response, []FuturesAdlQuantile, cex.RequestError) {
+	return cex.Request(u, FuturesAdlQuantileConfig, FuturesAdlQuantileParams{Symbol: symbol}, opts...)
+}
+
+func (u *User) FuturesOrderTrades(symbol string, orderId int64, opts ...cex.CltOpt) (*cex.Response, []FuturesTradeHistory, cex.RequestError) {
+	return cex.Request(u, FuturesAccountTradeListConfig, FuturesAccountTradeListParams{Symbol: symbol, OrderId: orderId, Limit: 1000}, opts...)
+}
+
+// QueryFuturesOrderFills queries trades of futures order, and adds them to order as fills.
+// Futures order responses do not contain fills, so commission can be got only by this way.
+func (u *User) QueryFuturesOrderFills(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	resp, trades, err := u.FuturesOrderTrades(ord.Symbol, strOrdIdToInt64(ord.OrderId), opts...)
+	if err.IsNil() {
+		ord.AddFills(FuturesTradesToCexFills(trades)...)
+	}
+	return resp, err
+}
+
+//func (u *User) CloseFuturesOrder(symbol string, ordType OrderType, side OrderSide, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
 //	return cex.Request(u, FuturesNewOrderConfig, FuturesNewOrderParams{Symbol: symbol, PositionSide: u.cfg.fuPosSide, Type: ordType, Side: side, ReduceOnly: SmallTrue}, opts...)
 //}
 
@@ -388,26 +1286,101 @@ func (u *User) QueryFuturesOrder(symbol string, orderId int64, cltOrdId string,
 // Private Trade Functions
 // ------------------------------------------------------------
 
-func (u *User) newSpotOrd(asset, quote string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+// newOrdOpts are optional fields of new order, which are set by OrderBuilder.
+type newOrdOpts struct {
+	// timeInForce is GTC for limit order if it is empty
+	timeInForce TimeInForce
+	// postOnly is LIMIT_MAKER for spot, and GTX for futures
+	postOnly bool
+	// reduceOnly is only for futures
+	reduceOnly bool
+	// clientOrderId is generated by client order id registry if it is empty
+	clientOrderId string
+	// goodTillDate is used with GTD, unit is millisecond
+	goodTillDate int64
+	// stpMode is default mode of user if it is empty
+	stpMode SelfTradePreventionMode
+	// positionSide is LONG or SHORT position to open or close, which is set by hedge mode helpers,
+	// reduceOnly means closing it, see User.hedgePosSide
+	positionSide FuturesPositionSide
+}
+
+func (o newOrdOpts) tif(orderType cex.OrderType) TimeInForce {
+	if orderType != cex.OrderTypeLimit {
+		return TimeInForceNone
+	}
+	if o.timeInForce != TimeInForceNone {
+		return o.timeInForce
+	}
+	return TimeInForceGtc
+}
+
+func (o newOrdOpts) spotParams(symbol string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, cltOrdId string) SpotNewOrderParams {
+	params := SpotNewOrderParams{
+		Symbol:                  symbol,
+		Type:                    mapStrStr(orderType, ordTypByCexOrdTyp),
+		Side:                    mapStrStr(orderSide, ordSideByCexOrdSide),
+		Quantity:                qty,
+		Price:                   price,
+		TimeInForce:             o.tif(orderType),
+		NewClientOrderId:        cltOrdId,
+		SelfTradePreventionMode: o.stpMode,
+	}
+	if o.postOnly {
+		// LIMIT_MAKER does not accept time in force
+		params.Type, params.TimeInForce = OrderTypeLimitMaker, TimeInForceNone
+	}
+	return params
+}
+
+func (o newOrdOpts) futuresParams(posSide FuturesPositionSide, symbol string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, cltOrdId string) FuturesNewOrderParams {
+	params := FuturesNewOrderParams{
+		Symbol:                  symbol,
+		PositionSide:            posSide,
+		Type:                    mapStrStr(orderType, ordTypByCexOrdTyp),
+		Side:                    mapStrStr(orderSide, ordSideByCexOrdSide),
+		Quantity:                qty,
+		Price:                   price,
+		TimeInForce:             o.tif(orderType),
+		NewClientOrderId:        cltOrdId,
+		SelfTradePreventionMode: o.stpMode,
+	}
+	if o.postOnly {
+		params.TimeInForce = TimeInForceGtx
+	}
+	if o.reduceOnly {
+		params.ReduceOnly = SmallTrue
+	}
+	if params.TimeInForce == TimeInForceGtd {
+		params.GoodTillDate = o.goodTillDate
+	}
+	return params
+}
+
+// withDefaults sets default options of user, which are not set by o.
+func (u *User) withDefaults(o newOrdOpts) newOrdOpts {
+	if o.stpMode == "" {
+		o.stpMode = u.cfg.stpMode
+	}
+	return o
+}
+
+func (u *User) newSpotOrd(asset, quote string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, ordOpts newOrdOpts, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	symbol := asset + quote
-	var tif TimeInForce
-	if orderType == cex.OrderTypeLimit {
-		tif = TimeInForceGtc
+	ordOpts = u.withDefaults(ordOpts)
+	qty, price, errValidate := u.validateOrd(cex.PairTypeSpot, symbol, orderType, qty, price)
+	if errValidate.IsNotNil() {
+		return nil, nil, errValidate
 	}
-	resp, rawOrd, err := cex.Request(u, SpotNewOrderConfig, SpotNewOrderParams{
-		Symbol:      symbol,
-		Type:        mapStrStr(orderType, ordTypByCexOrdTyp),
-		Side:        mapStrStr(orderSide, ordSideByCexOrdSide),
-		Quantity:    qty,
-		Price:       price,
-		TimeInForce: tif,
-	}, opts...)
+	cltOrdId := u.registerNewOrd(cex.PairTypeSpot, symbol, orderType, orderSide, qty, price, ordOpts.clientOrderId)
+	resp, rawOrd, err := u.routeNewSpotOrder(ordOpts.spotParams(symbol, orderType, orderSide, qty, price, cltOrdId), opts...)
 	ord := SwitchSpotOrderToCexOrder(rawOrd)
 	ord.ApiKey = u.api.ApiKey
+	u.resolveNewOrd(&ord, symbol, cltOrdId, resp, err)
 	return resp, &ord, err
 }
 
-func (u *User) cancelSpotOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response, cex.RequestError) {
+func (u *User) cancelSpotOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
 	if ord == nil {
 		return nil, cex.RequestError{Err: errors.New("nil order")}
 	}
@@ -418,7 +1391,7 @@ func (u *User) cancelSpotOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Respons
 	return resp, err
 }
 
-func (u *User) querySpotOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response, cex.RequestError) {
+func (u *User) querySpotOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
 	if ord == nil {
 		return nil, cex.RequestError{Err: errors.New("nil order")}
 	}
@@ -429,24 +1402,36 @@ func (u *User) querySpotOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response
 	return resp, err
 }
 
-func (u *User) newFuOrd(isUm bool, asset, quote string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*resty.Response, *cex.Order, cex.RequestError) {
+func (u *User) newFuOrd(isUm bool, asset, quote string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, ordOpts newOrdOpts, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
 	symbol := asset + quote
-	var tif TimeInForce
-	if orderType == cex.OrderTypeLimit {
-		tif = TimeInForceGtc
+	ordOpts = u.withDefaults(ordOpts)
+	if isUm {
+		var errValidate cex.RequestError
+		qty, price, errValidate = u.validateOrd(cex.PairTypeFutures, symbol, orderType, qty, price)
+		if errValidate.IsNotNil() {
+			return nil, nil, errValidate
+		}
 	}
-	var resp *resty.Response
+	posSide := u.cfg.fuPosSide
+	if isUm {
+		if errSync := u.syncFuturesMode(opts...); errSync.IsNotNil() {
+			return nil, nil, errSync
+		}
+		if ordOpts.positionSide != "" {
+			var errMode cex.RequestError
+			posSide, ordOpts.reduceOnly, errMode = u.hedgePosSide(ordOpts.positionSide, ordOpts.reduceOnly, opts...)
+			if errMode.IsNotNil() {
+				return nil, nil, errMode
+			}
+		} else {
+			posSide, ordOpts.reduceOnly = u.futuresPosSide(orderSide, ordOpts.reduceOnly)
+		}
+	}
+	var resp *cex.Response
 	var rawOrd FuturesOrder
 	var err cex.RequestError
-	params := FuturesNewOrderParams{
-		Symbol:       symbol,
-		PositionSide: u.cfg.fuPosSide,
-		Type:         mapStrStr(orderType, ordTypByCexOrdTyp),
-		Side:         mapStrStr(orderSide, ordSideByCexOrdSide),
-		Quantity:     qty,
-		Price:        price,
-		TimeInForce:  tif,
-	}
+	cltOrdId := u.registerNewOrd(cex.PairTypeFutures, symbol, orderType, orderSide, qty, price, ordOpts.clientOrderId)
+	params := ordOpts.futuresParams(posSide, symbol, orderType, orderSide, qty, price, cltOrdId)
 	if u.cfg.isPortfolioMarginAccount {
 		if isUm {
 			resp, rawOrd, err = cex.Request(u, PortfolioMarginNewOrderConfig, params, opts...)
@@ -454,15 +1439,79 @@ func (u *User) newFuOrd(isUm bool, asset, quote string, orderType cex.OrderType,
 			resp, rawOrd, err = cex.Request(u, PortfolioMarginNewCMOrderConfig, params, opts...)
 		}
 	} else {
-		resp, rawOrd, err = cex.Request(u, FuturesNewOrderConfig, params, opts...)
+		resp, rawOrd, err = u.routeNewFuturesOrder(params, opts...)
 	}
+	u.dropFuturesModeIfMismatch(err)
 
 	ord := SwitchFutureOrderToCexOrder(rawOrd)
 	ord.ApiKey = u.api.ApiKey
+	u.resolveNewOrd(&ord, symbol, cltOrdId, resp, err)
 	return resp, &ord, err
 }
 
-func (u *User) cancelFuturesOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response, cex.RequestError) {
+// registerNewOrd returns client order id of new order,
+// and registers the order as pending until its placement result is known.
+// If cltOrdId is empty, it is generated by registry.
+// It returns cltOrdId as it is, if user has no client order id registry.
+func (u *User) registerNewOrd(pairType cex.PairType, symbol string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, cltOrdId string) string {
+	reg := u.cfg.cltOrdIds
+	if reg == nil {
+		return cltOrdId
+	}
+	if cltOrdId == "" {
+		cltOrdId = reg.Next()
+	}
+	reg.Register(cex.Order{
+		Cex:           cex.BINANCE,
+		PairType:      pairType,
+		OrderType:     orderType,
+		OrderSide:     orderSide,
+		Symbol:        symbol,
+		OriQty:        qty,
+		OriPrice:      price,
+		ClientOrderId: cltOrdId,
+		ApiKey:        u.api.ApiKey,
+	})
+	return cltOrdId
+}
+
+// resolveNewOrd fills identity of ord, which is empty if request failed,
+// so ord can be reconciled by ReconcileOrder.
+// Pending order is resolved, unless its placement result is unknown.
+func (u *User) resolveNewOrd(ord *cex.Order, symbol, cltOrdId string, resp *cex.Response, err cex.RequestError) {
+	if cltOrdId == "" {
+		return
+	}
+	if ord.Symbol == "" {
+		ord.Symbol = symbol
+	}
+	if ord.ClientOrderId == "" {
+		ord.ClientOrderId = cltOrdId
+	}
+	if ord.OrderId == "0" {
+		// order id is unknown, it is set by ReconcileOrder
+		ord.OrderId = ""
+	}
+	if u.cfg.cltOrdIds != nil && !isNewOrdResultUnknown(resp, err) {
+		u.cfg.cltOrdIds.Resolve(cltOrdId)
+	}
+}
+
+// isNewOrdResultUnknown returns true, if new order request failed,
+// but order may have been placed, ex. timeout or 5xx response.
+// It is conservative, errors before sending, ex. local rate limit, are also unknown.
+func isNewOrdResultUnknown(resp *cex.Response, err cex.RequestError) bool {
+	if err.IsNil() {
+		return false
+	}
+	if err.RateLimitError != nil {
+		return false
+	}
+	// status code is 0, if no response is received
+	return resp == nil || resp.StatusCode() == 0 || resp.StatusCode() >= 500
+}
+
+func (u *User) cancelFuturesOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
 	if ord == nil {
 		return nil, cex.RequestError{Err: errors.New("nil order")}
 	}
@@ -473,7 +1522,7 @@ func (u *User) cancelFuturesOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Resp
 	return resp, err
 }
 
-func (u *User) queryFuturesOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response, cex.RequestError) {
+func (u *User) queryFuturesOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
 	if ord == nil {
 		return nil, cex.RequestError{Err: errors.New("nil order")}
 	}
@@ -484,7 +1533,7 @@ func (u *User) queryFuturesOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Respo
 	return resp, err
 }
 
-func (u *User) cancelOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response, cex.RequestError) {
+func (u *User) cancelOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
 	if ord == nil {
 		return nil, cex.RequestError{Err: errors.New("nil order")}
 	}
@@ -498,7 +1547,7 @@ func (u *User) cancelOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response, c
 	}
 }
 
-func (u *User) queryOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response, cex.RequestError) {
+func (u *User) queryOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
 	if ord == nil {
 		return nil, cex.RequestError{Err: errors.New("nil order")}
 	}
@@ -513,31 +1562,11 @@ func (u *User) queryOrd(ord *cex.Order, opts ...cex.CltOpt) (*resty.Response, ce
 }
 
 func (u *User) waitOrd(ctx context.Context, ord *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
-	ch := make(chan cex.RequestError, 1)
-	if ord == nil {
-		ch <- cex.RequestError{Err: errors.New("nil order")}
-		return ch
-	}
-	if ord.IsFinished() {
-		ch <- cex.RequestError{}
-		return ch
-	}
-	go func() {
-		for {
-			_, err := u.queryOrd(ord, opts...)
-			if err.IsNil() && ord.IsFinished() {
-				ch <- cex.RequestError{}
-				return
-			}
-			select {
-			case <-ctx.Done():
-				ch <- cex.RequestError{Err: fmt.Errorf("ctxerr: %w, requesterr: %w", ctx.Err(), err.Err)}
-				return
-			case <-time.After(time.Second):
-			}
-		}
-	}()
-	return ch
+	query := func(ctx context.Context, ord *cex.Order) cex.RequestError {
+		_, err := u.WithContext(ctx).queryOrd(ord, opts...)
+		return err
+	}
+	return cex.WaitOrder(ctx, ord, query, u.cfg.waitOrderOpts...)
 }
 
 func strOrdIdToInt64(id string) int64 {
@@ -618,73 +1647,172 @@ func SwitchSpotOrderToCexOrder(rawOrd SpotOrder) cex.Order {
 		avgp = filledQuote / filledQty
 	}
 
-	return cex.Order{
-		OriQty:         rawOrd.OrigQty,
-		OriPrice:       rawOrd.Price,
-		Cex:            cex.BINANCE,
-		PairType:       cex.PairTypeSpot,
-		OrderType:      ordTyp,
-		OrderSide:      ordSide,
-		Symbol:         rawOrd.Symbol,
-		TimeInForce:    string(rawOrd.TimeInForce),
-		ClientOrderId:  rawOrd.ClientOrderId,
-		ApiKey:         "",
-		OrderId:        strconv.FormatInt(rawOrd.OrderId, 10),
-		Status:         ordStatus,
-		FilledQty:      filledQty,
-		FilledAvgPrice: avgp,
-		FilledQuote:    filledQuote,
-		RawOrder:       rawOrd,
+	ord := cex.Order{
+		OriQty:                  rawOrd.OrigQty,
+		OriPrice:                rawOrd.Price,
+		Cex:                     cex.BINANCE,
+		PairType:                cex.PairTypeSpot,
+		OrderType:               ordTyp,
+		OrderSide:               ordSide,
+		Symbol:                  rawOrd.Symbol,
+		TimeInForce:             string(rawOrd.TimeInForce),
+		SelfTradePreventionMode: string(rawOrd.SelfTradePreventionMode),
+		ClientOrderId:           rawOrd.ClientOrderId,
+		ApiKey:                  "",
+		OrderId:                 strconv.FormatInt(rawOrd.OrderId, 10),
+		Status:                  ordStatus,
+		FilledQty:               filledQty,
+		FilledAvgPrice:          avgp,
+		FilledQuote:             filledQuote,
+		RawOrder:                rawOrd,
+	}
+	ord.AddFills(SpotOrderFillsToCexFills(rawOrd.Fills)...)
+	return ord
+}
+
+// SwitchSpotOrderListToCexOrderList converts raw order list to cex.OrderList.
+// Legs are converted from order reports, if they are responded,
+// otherwise legs only contain identity fields.
+func SwitchSpotOrderListToCexOrderList(rawList SpotOrderList) cex.OrderList {
+	list := cex.OrderList{
+		Cex:               cex.BINANCE,
+		PairType:          cex.PairTypeSpot,
+		ListType:          cex.OrderListType(rawList.ContingencyType),
+		Symbol:            rawList.Symbol,
+		OrderListId:       strconv.FormatInt(rawList.OrderListId, 10),
+		ClientOrderListId: rawList.ListClientOrderId,
+		RawOrderList:      rawList,
+	}
+	if len(rawList.OrderReports) > 0 {
+		for _, rawOrd := range rawList.OrderReports {
+			ord := SwitchSpotOrderToCexOrder(rawOrd)
+			list.Legs = append(list.Legs, &ord)
+		}
+		return list
+	}
+	for _, rawOrd := range rawList.Orders {
+		list.Legs = append(list.Legs, &cex.Order{
+			Cex:           cex.BINANCE,
+			PairType:      cex.PairTypeSpot,
+			Symbol:        rawOrd.Symbol,
+			OrderId:       strconv.FormatInt(rawOrd.OrderId, 10),
+			ClientOrderId: rawOrd.ClientOrderId,
+		})
 	}
+	return list
 }
 
+// UpdateOrderWithRawSpotOrder merges raw spot order into ord by cex.MergeOrderUpdate,
+// so out-of-order responses will not make ord state regress.
 func UpdateOrderWithRawSpotOrder(ord *cex.Order, rawOrd SpotOrder) {
 	if ord == nil {
 		return
 	}
-	filledQty := rawOrd.ExecutedQty
-	filledQuote := rawOrd.CummulativeQuoteQty
-	var avgp float64
-	if filledQty != 0 {
-		avgp = filledQuote / filledQty
-	}
-	ord.Status = mapStrStr(rawOrd.Status, cexOrdStatusByOrdStatus)
-	ord.FilledQty = filledQty
-	ord.FilledQuote = filledQuote
-	ord.FilledAvgPrice = avgp
-	ord.RawOrder = rawOrd
+	cex.MergeOrderUpdate(ord, SwitchSpotOrderToCexOrder(rawOrd))
+}
+
+// ToCexOrder implements cex.RawOrder.
+func (o SpotOrder) ToCexOrder() cex.Order {
+	return SwitchSpotOrderToCexOrder(o)
 }
 
 func SwitchFutureOrderToCexOrder(rawOrd FuturesOrder) cex.Order {
 	return cex.Order{
-		OriQty:         rawOrd.OrigQty,
-		OriPrice:       rawOrd.Price,
-		Cex:            cex.BINANCE,
-		PairType:       cex.PairTypeFutures,
-		OrderType:      mapStrStr(rawOrd.Type, cexOrdTypByOrdTyp),
-		OrderSide:      mapStrStr(rawOrd.Side, cexOrdSideByOrdSide),
-		Symbol:         rawOrd.Symbol,
-		TimeInForce:    string(rawOrd.TimeInForce),
-		ClientOrderId:  rawOrd.ClientOrderId,
-		ApiKey:         "",
-		OrderId:        strconv.FormatInt(rawOrd.OrderId, 10),
-		Status:         mapStrStr(rawOrd.Status, cexOrdStatusByOrdStatus),
-		FilledQty:      rawOrd.ExecutedQty,
-		FilledQuote:    rawOrd.CumQuote,
-		FilledAvgPrice: rawOrd.AvgPrice,
-		RawOrder:       rawOrd,
+		OriQty:                  rawOrd.OrigQty,
+		OriPrice:                rawOrd.Price,
+		Cex:                     cex.BINANCE,
+		PairType:                cex.PairTypeFutures,
+		OrderType:               mapStrStr(rawOrd.Type, cexOrdTypByOrdTyp),
+		OrderSide:               mapStrStr(rawOrd.Side, cexOrdSideByOrdSide),
+		Symbol:                  rawOrd.Symbol,
+		TimeInForce:             string(rawOrd.TimeInForce),
+		SelfTradePreventionMode: string(rawOrd.SelfTradePreventionMode),
+		ClientOrderId:           rawOrd.ClientOrderId,
+		ApiKey:                  "",
+		OrderId:                 strconv.FormatInt(rawOrd.OrderId, 10),
+		Status:                  mapStrStr(rawOrd.Status, cexOrdStatusByOrdStatus),
+		FilledQty:               rawOrd.ExecutedQty,
+		FilledQuote:             rawOrd.CumQuote,
+		FilledAvgPrice:          rawOrd.AvgPrice,
+		RawOrder:                rawOrd,
 	}
 }
 
+// UpdateOrderWithRawFuturesOrder merges raw futures order into ord by cex.MergeOrderUpdate,
+// so out-of-order responses will not make ord state regress.
 func UpdateOrderWithRawFuturesOrder(ord *cex.Order, rawOrd FuturesOrder) {
 	if ord == nil {
 		return
 	}
-	ord.Status = mapStrStr(rawOrd.Status, cexOrdStatusByOrdStatus)
-	ord.FilledQty = rawOrd.ExecutedQty
-	ord.FilledQuote = rawOrd.CumQuote
-	ord.FilledAvgPrice = rawOrd.AvgPrice
-	ord.RawOrder = rawOrd
+	cex.MergeOrderUpdate(ord, SwitchFutureOrderToCexOrder(rawOrd))
+}
+
+// ToCexOrder implements cex.RawOrder.
+func (o FuturesOrder) ToCexOrder() cex.Order {
+	return SwitchFutureOrderToCexOrder(o)
+}
+
+func SpotOrderFillsToCexFills(fills []SpotOrderFill) []cex.Fill {
+	var cexFills []cex.Fill
+	for _, f := range fills {
+		cexFills = append(cexFills, cex.Fill{
+			TradeId:         strconv.FormatInt(f.TradeId, 10),
+			Price:           f.Price,
+			Qty:             f.Qty,
+			Commission:      f.Commission,
+			CommissionAsset: f.CommissionAsset,
+		})
+	}
+	return cexFills
+}
+
+func SpotTradesToCexFills(trades []SpotTradeHistory) []cex.Fill {
+	var cexFills []cex.Fill
+	for _, t := range trades {
+		side := cex.OrderSideSell
+		if t.IsBuyer {
+			side = cex.OrderSideBuy
+		}
+		cexFills = append(cexFills, cex.Fill{
+			TradeId:         strconv.FormatInt(t.Id, 10),
+			Price:           t.Price,
+			Qty:             t.Qty,
+			Commission:      t.Commission,
+			CommissionAsset: t.CommissionAsset,
+			IsMaker:         t.IsMaker,
+			Time:            t.Time,
+			Cex:             cex.BINANCE,
+			PairType:        cex.PairTypeSpot,
+			Symbol:          t.Symbol,
+			OrderId:         strconv.FormatInt(t.OrderId, 10),
+			OrderSide:       side,
+			QuoteQty:        t.QuoteQty,
+		})
+	}
+	return cexFills
+}
+
+func FuturesTradesToCexFills(trades []FuturesTradeHistory) []cex.Fill {
+	var cexFills []cex.Fill
+	for _, t := range trades {
+		cexFills = append(cexFills, cex.Fill{
+			TradeId:         strconv.FormatInt(t.Id, 10),
+			Price:           t.Price,
+			Qty:             t.Qty,
+			Commission:      t.Commission,
+			CommissionAsset: t.CommissionAsset,
+			IsMaker:         t.Maker,
+			Time:            t.Time,
+			Cex:             cex.BINANCE,
+			PairType:        cex.PairTypeFutures,
+			Symbol:          t.Symbol,
+			OrderId:         strconv.FormatInt(t.OrderId, 10),
+			OrderSide:       cex.OrderSide(t.Side),
+			QuoteQty:        t.QuoteQty,
+			RealizedPnl:     t.RealizedPnl,
+		})
+	}
+	return cexFills
 }
 
 // ------------------------------------------------------------
@@ -695,7 +1823,24 @@ func UpdateOrderWithRawFuturesOrder(ord *cex.Order, rawOrd FuturesOrder) {
 // ReqMaker
 // ============================================================
 
-func (u *User) Make(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*resty.Request, error) {
+// CltOpts implements cex.CltOptsProvider.
+func (u *User) CltOpts() []cex.CltOpt {
+	var opts []cex.CltOpt
+	if u.cfg.transport != nil {
+		opts = append(opts, cex.CltOptTransport(u.cfg.transport))
+	}
+	if u.cfg.backend != "" {
+		opts = append(opts, cex.CltOptBackend(u.cfg.backend))
+	}
+	return opts
+}
+
+func (u *User) Make(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*cex.HTTPRequest, error) {
+	if err := cex.ValidateParams(reqData); err != nil {
+		return nil, err
+	}
+	// per call options override them, because they are applied later
+	opts = append(u.CltOpts(), opts...)
 	if config.IsUserData {
 		return u.makePrivateReq(config, reqData, opts...)
 	} else {
@@ -703,7 +1848,57 @@ func (u *User) Make(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (
 	}
 }
 
-func (u *User) makePublicReq(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*resty.Request, error) {
+// Logger implements cex.LoggerProvider.
+func (u *User) Logger() cex.Logger {
+	return u.cfg.logger
+}
+
+// RewriteBaseUrl implements cex.BaseUrlRewriter.
+func (u *User) RewriteBaseUrl(baseUrl string) (string, error) {
+	// env may be set by credential provider
+	if err := u.resolveCredential(); err != nil {
+		return "", err
+	}
+	if !u.api.Env.IsTestnet() {
+		return baseUrl, nil
+	}
+	return TestnetBaseUrl(baseUrl)
+}
+
+// Warmup establishes connections of user transport to spot and futures api,
+// whose base urls are rewritten for testnet and selected by base url selector, and pings them.
+func (u *User) Warmup(ctx context.Context, opts ...cex.WarmupOpt) []cex.WarmupResult {
+	urls := make([]string, 0, len(warmupBaseUrls))
+	for _, bu := range warmupBaseUrls {
+		baseUrl, err := u.RewriteBaseUrl(bu[0])
+		if err != nil {
+			return []cex.WarmupResult{{Url: bu[0] + bu[1], Err: err}}
+		}
+		urls = append(urls, u.SelectBaseUrl(baseUrl)+bu[1])
+	}
+	wopts := []cex.WarmupOpt{cex.WarmupOptPing()}
+	if u.cfg.transport != nil {
+		wopts = append(wopts, cex.WarmupOptTransport(u.cfg.transport))
+	}
+	return cex.Warmup(ctx, urls, append(wopts, opts...)...)
+}
+
+// SelectBaseUrl implements cex.BaseUrlSelector.
+func (u *User) SelectBaseUrl(baseUrl string) string {
+	if u.cfg.baseUrlSelector == nil {
+		return baseUrl
+	}
+	return u.cfg.baseUrlSelector.SelectBaseUrl(baseUrl)
+}
+
+// ReportBaseUrl implements cex.BaseUrlSelector.
+func (u *User) ReportBaseUrl(selected string, failed bool) {
+	if u.cfg.baseUrlSelector != nil {
+		u.cfg.baseUrlSelector.ReportBaseUrl(selected, failed)
+	}
+}
+
+func (u *User) makePublicReq(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*cex.HTTPRequest, error) {
 	m, err := s2m.ToStrMap(reqData)
 	if err != nil {
 		return nil, fmt.Errorf("bnc: make public request, %w", err)
@@ -712,34 +1907,38 @@ func (u *User) makePublicReq(config cex.ReqBaseConfig, reqData any, opts ...cex.
 	for k, v := range m {
 		val.Set(k, v)
 	}
-	clt := resty.New().
-		SetBaseURL(config.BaseUrl + config.Path + "?" + val.Encode())
-	for _, opt := range opts {
-		opt(clt)
+	for k, vs := range cex.NewReqOpts(opts...).Query {
+		val[k] = vs
+	}
+	req := cex.NewHTTPRequest(opts...)
+	req.URL = config.BaseUrl + config.Path + "?" + val.Encode()
+	if u.ctx != nil {
+		req.SetContext(u.ctx)
 	}
-	req := clt.R()
 	return req, nil
 }
 
-func (u *User) makePrivateReq(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*resty.Request, error) {
-	query, err := u.sign(reqData)
+func (u *User) makePrivateReq(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*cex.HTTPRequest, error) {
+	if err := u.resolveCredential(); err != nil {
+		return nil, err
+	}
+	query, err := u.sign(reqData, cex.NewReqOpts(opts...))
 	if err != nil {
 		return nil, err
 	}
 	// must compose url by self
 	// url.Values composing is alphabetical
 	// but binance require signature as the last one
-	clt := resty.New().
-		SetHeader("X-MBX-APIKEY", u.api.ApiKey).
-		SetBaseURL(config.BaseUrl + config.Path + "?" + query)
-	for _, opt := range opts {
-		opt(clt)
+	req := cex.NewHTTPRequest(opts...).
+		SetHeader("X-MBX-APIKEY", u.api.ApiKey)
+	req.URL = config.BaseUrl + config.Path + "?" + query
+	if u.ctx != nil {
+		req.SetContext(u.ctx)
 	}
-	req := clt.R()
 	return req, nil
 }
 
-func (u *User) HandleResp(resp *resty.Response, req *resty.Request) error {
+func (u *User) HandleResp(resp *cex.Response, req *cex.HTTPRequest) error {
 	if resp == nil {
 		return errors.New("bnc: response checker: response is nil")
 	}
@@ -774,24 +1973,103 @@ func (u *User) HandleResp(resp *resty.Response, req *resty.Request) error {
 // Signer
 // ------------------------------------------------------------
 
-func (u *User) sign(data any) (query string, err error) {
-	return signReqData(data, u.api.SecretKey)
+// sign signs data with query and recvWindow of ro.
+// recvWindow of ro overrides recvWindow of user.
+func (u *User) sign(data any, ro cex.ReqOpts) (query string, err error) {
+	signer, err := u.apiSigner()
+	if err != nil {
+		return "", err
+	}
+	return signReqData(data, u.signExtra(ro), newReqSigner(signer, u.api.KeyType), u.cfg.timeSync.Now().UnixMilli())
 }
 
-func signReqData(data any, key string) (query string, err error) {
-	m, err := s2m.ToStrMap(data)
+// signExtra returns query and recvWindow of ro, which are signed with params.
+func (u *User) signExtra(ro cex.ReqOpts) url.Values {
+	extra := url.Values{}
+	for k, vs := range ro.Query {
+		extra[k] = vs
+	}
+	recvWindow := u.cfg.recvWindow
+	if ro.RecvWindow > 0 {
+		recvWindow = ro.RecvWindow
+	}
+	if recvWindow > 0 {
+		extra.Set("recvWindow", strconv.FormatInt(recvWindow, 10))
+	}
+	return extra
+}
+
+func (u *User) apiSigner() (cex.ApiSigner, error) {
+	if u.signer != nil {
+		return u.signer, nil
+	}
+	if u.signerErr != nil {
+		return nil, fmt.Errorf("bnc: sign, %w", u.signerErr)
+	}
+	// user is not created by NewUser
+	signer, err := u.api.NewSigner()
 	if err != nil {
-		err = fmt.Errorf("%w: %w", cex.ErrS2M, err)
-		return
+		return nil, fmt.Errorf("bnc: sign, %w", err)
 	}
-	val := url.Values{
-		"timestamp": []string{strconv.FormatInt(time.Now().UnixMilli(), 10)},
+	return signer, nil
+}
+
+// newReqSigner returns signer which encodes signature as binance requires,
+// hex for HMAC key, and url escaped base64 for RSA and Ed25519 keys.
+func newReqSigner(signer cex.ApiSigner, keyType cex.KeyType) func(query string) (string, error) {
+	return func(query string) (string, error) {
+		sig, err := signer(query)
+		if err != nil {
+			return "", fmt.Errorf("bnc: sign, %w", err)
+		}
+		if keyType == cex.KeyTypeRSA || keyType == cex.KeyTypeEd25519 {
+			return url.QueryEscape(encodeSignature(sig, keyType)), nil
+		}
+		return encodeSignature(sig, keyType), nil
 	}
-	for k, v := range m {
-		val.Set(k, v)
+}
+
+// encodeSignature encodes signature by hex for HMAC key, and base64 for RSA and Ed25519 keys.
+func encodeSignature(sig []byte, keyType cex.KeyType) string {
+	switch keyType {
+	case cex.KeyTypeRSA, cex.KeyTypeEd25519:
+		return base64.StdEncoding.EncodeToString(sig)
 	}
+	return hex.EncodeToString(sig)
+}
+
+// urlValuer is implemented by params which can not be switched by s2m,
+// ex. params with repeated keys.
+type urlValuer interface {
+	UrlValues() url.Values
+}
+
+// signReqData signs data and extra query, values of extra override data.
+func signReqData(data any, extra url.Values, sign func(query string) (string, error), timestamp int64) (query string, err error) {
+	val := url.Values{}
+	if valuer, ok := data.(urlValuer); ok {
+		for k, v := range valuer.UrlValues() {
+			val[k] = v
+		}
+	} else {
+		m, errS2M := s2m.ToStrMap(data)
+		if errS2M != nil {
+			err = fmt.Errorf("%w: %w", cex.ErrS2M, errS2M)
+			return
+		}
+		for k, v := range m {
+			val.Set(k, v)
+		}
+	}
+	for k, vs := range extra {
+		val[k] = vs
+	}
+	val.Set("timestamp", strconv.FormatInt(timestamp, 10))
 	query = val.Encode()
-	sig := cex.SignByHmacSHA256ToHex(query, key)
+	sig, err := sign(query)
+	if err != nil {
+		return
+	}
 	// binance requires that the signature must be the last one
 	query += "&signature=" + sig
 	return
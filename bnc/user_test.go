@@ -2,13 +2,22 @@ package bnc
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dwdwow/cex"
 	"github.com/dwdwow/props"
-	"github.com/go-resty/resty/v2"
 )
 
 func newTestUser() *User {
@@ -21,7 +30,7 @@ func newTestVIPPortmarUser() *User {
 	return NewUser(apiKey.ApiKey, apiKey.SecretKey, UserOptPositionSide(FuturesPositionSideBoth))
 }
 
-func userTestChecker[RespData any](resp *resty.Response, respData RespData, err cex.RequestError) {
+func userTestChecker[RespData any](resp *cex.Response, respData RespData, err cex.RequestError) {
 	props.PanicIfNotNil(err.Err)
 	props.PrintlnIndent(respData)
 }
@@ -258,3 +267,319 @@ func TestUser_PortfolioMarginBalances(t *testing.T) {
 func TestUser_PortfolioMarginPositions(t *testing.T) {
 	userTestChecker(newTestVIPPortmarUser().PortfolioMarginPositions(""))
 }
+
+func TestUser_AllSpotTradesSince(t *testing.T) {
+	trades, err := newTestUser().AllSpotTradesSince("ETHUSDT", time.Now().AddDate(0, 0, -30).UnixMilli())
+	props.PanicIfNotNil(err.Err)
+	fmt.Println(len(trades))
+}
+
+func TestUser_AllIncomeSince(t *testing.T) {
+	incomes, err := newTestUser().AllIncomeSince(time.Now().AddDate(0, 0, -30).UnixMilli())
+	props.PanicIfNotNil(err.Err)
+	fmt.Println(len(incomes))
+}
+
+func TestUser_MakeReqOpts(t *testing.T) {
+	user := NewUser("key", "secret")
+	req, err := user.Make(SpotAccountConfig.ReqBaseConfig, nil,
+		cex.CltOptQuery("omitZeroBalances", "true"), cex.CltOptRecvWindow(3000), cex.CltOptHeader("X-Test", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("omitZeroBalances") != "true" || query.Get("recvWindow") != "3000" {
+		t.Error("query of opts should be signed", req.URL)
+	}
+	unsigned, _, _ := strings.Cut(u.RawQuery, "&signature=")
+	if query.Get("signature") != cex.SignByHmacSHA256ToHex(unsigned, "secret") {
+		t.Error("wrong signature", req.URL)
+	}
+	if req.Header.Get("X-Test") != "a" || req.Header.Get("X-MBX-APIKEY") != "key" {
+		t.Error("wrong headers", req.Header)
+	}
+
+	user = NewUser("key", "secret", UserOptRecvWindow(10000))
+	req, err = user.Make(SpotAccountConfig.ReqBaseConfig, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, _ := url.Parse(req.URL); u.Query().Get("recvWindow") != "10000" {
+		t.Error("recvWindow of user should be signed", req.URL)
+	}
+	req, err = user.Make(SpotAccountConfig.ReqBaseConfig, nil, cex.CltOptRecvWindow(3000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, _ := url.Parse(req.URL); u.Query().Get("recvWindow") != "3000" {
+		t.Error("recvWindow of opts should override user", req.URL)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestUser_Transport(t *testing.T) {
+	errTransport := errors.New("transport")
+	var hosts []string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		hosts = append(hosts, r.URL.Host)
+		return nil, errTransport
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+	if _, _, err := cex.Request(user, SpotAccountConfig, nil); !err.Is(errTransport) {
+		t.Error("private request should be sent by user transport, but", err.Error())
+	}
+	if _, _, err := cex.Request(user, SpotServerTimeConfig, nil); !err.Is(errTransport) {
+		t.Error("public request should be sent by user transport, but", err.Error())
+	}
+	if len(hosts) != 2 {
+		t.Error("user transport should be called twice, but", len(hosts))
+	}
+
+	called := false
+	_, _, _ = cex.Request(user, SpotServerTimeConfig, nil, cex.CltOptTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		called = true
+		return nil, errTransport
+	})))
+	if !called || len(hosts) != 2 {
+		t.Error("transport of opts should override user transport")
+	}
+}
+
+func TestUser_Ed25519Key(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := NewUser("key", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), UserOptKeyType(cex.KeyTypeEd25519))
+	req, err := user.Make(SpotAccountConfig.ReqBaseConfig, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, _, _ := strings.Cut(u.RawQuery, "&signature=")
+	sig, err := base64.StdEncoding.DecodeString(u.Query().Get("signature"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(pub, []byte(unsigned), sig) {
+		t.Error("wrong ed25519 signature", req.URL)
+	}
+
+	user = NewUser("key", "secret", UserOptKeyType(cex.KeyTypeRSA))
+	if _, err := user.Make(SpotAccountConfig.ReqBaseConfig, nil); err == nil {
+		t.Error("secret is not rsa key")
+	}
+}
+
+func TestUser_ReconcileOrder(t *testing.T) {
+	var queryStatus int
+	var queryBody string
+	var newOrdQuery url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodPost {
+			newOrdQuery = r.URL.Query()
+			return nil, errors.New("connection reset")
+		}
+		return &http.Response{
+			StatusCode: queryStatus,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(queryBody)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	_, ord, err := user.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1000)
+	if err.IsNil() {
+		t.Fatal("new order should fail")
+	}
+	cltOrdId := newOrdQuery.Get("newClientOrderId")
+	if cltOrdId == "" || !user.ClientOrderIds().Owns(cltOrdId) {
+		t.Fatal("client order id should be attached", newOrdQuery)
+	}
+	if ord.ClientOrderId != cltOrdId || ord.Symbol != "ETHUSDT" || ord.OrderId != "" {
+		t.Error("wrong order identity", ord.ClientOrderId, ord.Symbol, ord.OrderId)
+	}
+	if pending := user.ClientOrderIds().Pending(); len(pending) != 1 || pending[0].ClientOrderId != cltOrdId {
+		t.Fatal("order should be pending", pending)
+	}
+
+	queryStatus = http.StatusOK
+	queryBody = fmt.Sprintf(`{"symbol":"ETHUSDT","orderId":123,"clientOrderId":%q,"price":"1000","origQty":"1","executedQty":"1","cummulativeQuoteQty":"1000","status":"FILLED","type":"LIMIT","side":"BUY"}`, cltOrdId)
+	_, placed, err := user.ReconcileOrder(ord)
+	if err.IsNotNil() || !placed {
+		t.Fatal("order should be placed", err.Err)
+	}
+	if ord.OrderId != "123" || ord.Status != cex.OrderStatusFilled || ord.FilledQty != 1 {
+		t.Error("order should be updated", ord.OrderId, ord.Status, ord.FilledQty)
+	}
+	if pending := user.ClientOrderIds().Pending(); len(pending) != 0 {
+		t.Error("order should be resolved", pending)
+	}
+
+	_, ord, _ = user.NewSpotLimitBuyOrder("ETH", "USDT", 1, 1000)
+	queryStatus = http.StatusBadRequest
+	queryBody = `{"code":-2013,"msg":"Order does not exist."}`
+	_, placed, err = user.ReconcileOrder(ord)
+	if err.IsNotNil() || placed {
+		t.Error("order should not be placed", err.Err)
+	}
+	if pending := user.ClientOrderIds().Pending(); len(pending) != 0 {
+		t.Error("order should be resolved", pending)
+	}
+}
+
+func TestUser_ModifyFuturesOrder(t *testing.T) {
+	var modifyQuery url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := `{"symbol":"ETHUSDT","orderId":123,"clientOrderId":"c1","price":"1810","origQty":"2","executedQty":"0","status":"NEW","type":"LIMIT","side":"BUY","updateTime":2}`
+		if r.Method == http.MethodPut {
+			modifyQuery = r.URL.Query()
+		} else {
+			body = `[{"amendmentId":1,"symbol":"ETHUSDT","orderId":123,"clientOrderId":"c1","time":2,"amendment":{"price":{"before":"1800","after":"1810"},"origQty":{"before":"1","after":"2"},"count":1}},` +
+				`{"amendmentId":2,"symbol":"ETHUSDT","orderId":123,"clientOrderId":"c1","time":3,"amendment":{"price":{"before":"1810","after":"1820"},"origQty":{"before":"2","after":"2"},"count":2}}]`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+	ord := &cex.Order{Cex: cex.BINANCE, PairType: cex.PairTypeFutures, Symbol: "ETHUSDT", OrderId: "123", ClientOrderId: "c1", OrderSide: cex.OrderSideBuy, OriQty: 1, OriPrice: 1800, Status: cex.OrderStatusNew}
+
+	if _, err := user.ModifyFuturesOrder(ord, 2, 1810); err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if modifyQuery.Get("side") != "BUY" || modifyQuery.Get("quantity") != "2" || modifyQuery.Get("orderId") != "123" {
+		t.Error("wrong modify params", modifyQuery)
+	}
+	if ord.OriQty != 2 || ord.OriPrice != 1810 {
+		t.Error("order should be modified", ord.OriQty, ord.OriPrice)
+	}
+
+	if _, err := user.SyncFuturesOrderAmendments(ord); err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if len(ord.Amendments) != 2 || ord.Amendments[1].Id != "2" || ord.OriPrice != 1820 {
+		t.Error("amendments should be synced", ord.Amendments, ord.OriPrice)
+	}
+}
+
+func TestUser_MakeValidatesParams(t *testing.T) {
+	sent := 0
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"symbol":"ETHUSDT","orderId":1,"status":"NEW"}`)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+
+	invalids := []any{
+		FuturesNewOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeLimit, Quantity: 1, Price: 1800, PriceMatch: FuturesPriceMatchQueue},
+		FuturesNewOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeMarket},
+		FuturesNewOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeTrailingStopMarket, Quantity: 1, CallbackRate: 20},
+		FuturesBatchOrdersParams{BatchOrders: make([]FuturesNewOrderParams, 6)},
+		SpotNewOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeMarket, Quantity: 1, QuoteOrderQty: 100},
+		SpotCancelOrderParams{Symbol: "ETHUSDT"},
+		UniversalTransferParams{Type: "MAIN_UM", Asset: "USDT", Amount: 1},
+		UniversalTransferParams{Type: TransferTypeMainIsolatedMargin, Asset: "USDT", Amount: 1},
+		UniversalTransferParams{Type: TransferTypeIsolatedmarginIsolatedmargin, Asset: "USDT", Amount: 1, FromSymbol: "ETHUSDT"},
+		IsolatedMarginSymbolParams{},
+		MarginNewOrderParams{IsIsolated: BigTrue, Side: OrderSideBuy, Type: OrderTypeMarket, Quantity: 1},
+	}
+	for _, params := range invalids {
+		if _, err := user.Make(FuturesNewOrderConfig.ReqBaseConfig, params); !errors.Is(err, cex.ErrInvalidParams) {
+			t.Errorf("%T should be invalid, %v", params, err)
+		}
+	}
+
+	_, _, err := cex.Request(user, FuturesModifyOrderConfig, FuturesModifyOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, Quantity: 1, Price: 1800})
+	if !errors.Is(err.Err, cex.ErrInvalidParams) || sent != 0 {
+		t.Fatal("invalid request should not be sent", err.Err, sent)
+	}
+	_, _, err = cex.Request(user, FuturesModifyOrderConfig, FuturesModifyOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, OrderId: 1, Quantity: 1, Price: 1800})
+	if err.IsNotNil() || sent != 1 {
+		t.Error("valid request should be sent", err.Err, sent)
+	}
+}
+
+func TestUser_CredentialProvider(t *testing.T) {
+	errProvider := errors.New("provider")
+	calls := 0
+	provider := cex.CredentialProviderFunc(func(ctx context.Context, name string) (cex.Api, error) {
+		calls++
+		if calls == 1 {
+			return cex.Api{}, errProvider
+		}
+		return cex.Api{ApiKey: "key-" + name, SecretKey: "secret", Env: cex.EnvTestnet}, nil
+	})
+	var hosts, apiKeys []string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		hosts = append(hosts, r.URL.Host)
+		apiKeys = append(apiKeys, r.Header.Get("X-MBX-APIKEY"))
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`)), Request: r}, nil
+	})
+	user := NewUser("", "", UserOptTransport(transport), UserOptCredentialProvider(provider, "main"))
+
+	if _, _, err := user.SpotAccount(); !errors.Is(err.Err, errProvider) || len(apiKeys) != 0 {
+		t.Fatal("provider error should be returned", err.Err)
+	}
+	for range 2 {
+		if _, _, err := user.SpotAccount(); err.IsNotNil() {
+			t.Fatal(err.Error())
+		}
+	}
+	if calls != 2 || len(apiKeys) != 2 || apiKeys[0] != "key-main" || user.Api().ApiKey != "key-main" {
+		t.Error("credential should be resolved once", calls, apiKeys)
+	}
+	if hosts[0] != "testnet.binance.vision" {
+		t.Error("env of credential should be used", hosts)
+	}
+}
+
+func TestUser_ToggleSpotBNBBurn(t *testing.T) {
+	var params url.Values
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		params = r.URL.Query()
+		if r.Body != nil {
+			body, _ := io.ReadAll(r.Body)
+			form, _ := url.ParseQuery(string(body))
+			for k, v := range form {
+				params[k] = v
+			}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"spotBNBBurn":false,"interestBNBBurn":true}`)), Request: r}, nil
+	})
+	_, status, err := NewUser("key", "secret", UserOptTransport(transport)).ToggleSpotBNBBurn(false)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if params.Get("spotBNBBurn") != "false" || params.Has("interestBNBBurn") {
+		t.Error("only spotBNBBurn should be sent", params)
+	}
+	if status.SpotBNBBurn || !status.InterestBNBBurn {
+		t.Error("wrong status", status)
+	}
+}
@@ -0,0 +1,331 @@
+package bnc
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Binance Vision, public data archive of https://data.binance.vision
+// ============================================================
+
+const VisionBaseUrl = "https://data.binance.vision"
+
+var ErrVisionArchiveNotFound = errors.New("bnc: vision archive not found")
+
+type VisionMarket string
+
+const (
+	VisionMarketSpot      VisionMarket = "spot"
+	VisionMarketUmFutures VisionMarket = "futures/um"
+	VisionMarketCmFutures VisionMarket = "futures/cm"
+)
+
+// VisionPeriod is period of one archive.
+// Archive of the current day or month is published after it ends.
+type VisionPeriod string
+
+const (
+	VisionPeriodDaily   VisionPeriod = "daily"
+	VisionPeriodMonthly VisionPeriod = "monthly"
+)
+
+func (p VisionPeriod) dateLayout() string {
+	if p == VisionPeriodMonthly {
+		return "2006-01"
+	}
+	return time.DateOnly
+}
+
+type VisionDataType string
+
+const (
+	VisionDataTypeKlines      VisionDataType = "klines"
+	VisionDataTypeTrades      VisionDataType = "trades"
+	VisionDataTypeAggTrades   VisionDataType = "aggTrades"
+	VisionDataTypeFundingRate VisionDataType = "fundingRate" // futures monthly only
+)
+
+// VisionArchive locates one zip archive.
+// Interval is used by klines only, Date is formatted in UTC by Period.
+type VisionArchive struct {
+	Market   VisionMarket
+	Period   VisionPeriod
+	DataType VisionDataType
+	Symbol   string
+	Interval KlineInterval
+	Date     time.Time
+}
+
+// Path returns path of archive, ex.
+// /data/spot/daily/klines/ETHUSDT/1m/ETHUSDT-1m-2024-01-02.zip.
+func (a VisionArchive) Path() string {
+	date := a.Date.UTC().Format(a.Period.dateLayout())
+	dir := fmt.Sprintf("/data/%v/%v/%v/%v", a.Market, a.Period, a.DataType, a.Symbol)
+	if a.DataType == VisionDataTypeKlines {
+		return fmt.Sprintf("%v/%v/%v-%v-%v.zip", dir, a.Interval, a.Symbol, a.Interval, date)
+	}
+	return fmt.Sprintf("%v/%v-%v-%v.zip", dir, a.Symbol, a.DataType, date)
+}
+
+type VisionClientOpt func(*VisionClient)
+
+// VisionClientOptBaseUrl sets base url of archives, ex. a mirror.
+func VisionClientOptBaseUrl(baseUrl string) VisionClientOpt {
+	return func(c *VisionClient) {
+		c.baseUrl = baseUrl
+	}
+}
+
+func VisionClientOptTransport(transport cex.Transport) VisionClientOpt {
+	return func(c *VisionClient) {
+		c.client.Transport = transport
+	}
+}
+
+// VisionClient downloads archives of binance vision,
+// and parses them into the same structs of REST responses,
+// which is cheaper than REST requests for bulk historical backfills.
+//
+//	clt := NewVisionClient()
+//	klines, err := clt.Klines(ctx, VisionMarketSpot, VisionPeriodDaily, "ETHUSDT", "1m", date)
+type VisionClient struct {
+	baseUrl string
+	client  *http.Client
+}
+
+func NewVisionClient(opts ...VisionClientOpt) *VisionClient {
+	c := &VisionClient{
+		baseUrl: VisionBaseUrl,
+		client:  &http.Client{Transport: cex.NewPooledTransport()},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Download returns zip data of archive.
+// ErrVisionArchiveNotFound is returned, if archive is not published.
+func (c *VisionClient) Download(ctx context.Context, archive VisionArchive) ([]byte, error) {
+	url := c.baseUrl + archive.Path()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bnc: new vision request, %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("bnc: download %v, %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w, %v", ErrVisionArchiveNotFound, url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bnc: download %v, http status %v", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("bnc: download %v, %w", url, err)
+	}
+	return data, nil
+}
+
+func (c *VisionClient) Klines(ctx context.Context, market VisionMarket, period VisionPeriod, symbol string, interval KlineInterval, date time.Time) ([]Kline, error) {
+	data, err := c.Download(ctx, VisionArchive{Market: market, Period: period, DataType: VisionDataTypeKlines, Symbol: symbol, Interval: interval, Date: date})
+	if err != nil {
+		return nil, err
+	}
+	return ParseVisionKlines(data)
+}
+
+func (c *VisionClient) Trades(ctx context.Context, market VisionMarket, period VisionPeriod, symbol string, date time.Time) ([]Trade, error) {
+	data, err := c.Download(ctx, VisionArchive{Market: market, Period: period, DataType: VisionDataTypeTrades, Symbol: symbol, Date: date})
+	if err != nil {
+		return nil, err
+	}
+	return ParseVisionTrades(data)
+}
+
+func (c *VisionClient) AggTrades(ctx context.Context, market VisionMarket, period VisionPeriod, symbol string, date time.Time) ([]AggTrade, error) {
+	data, err := c.Download(ctx, VisionArchive{Market: market, Period: period, DataType: VisionDataTypeAggTrades, Symbol: symbol, Date: date})
+	if err != nil {
+		return nil, err
+	}
+	return ParseVisionAggTrades(data)
+}
+
+// FundingRates returns funding rates of month, only futures monthly archives have funding rates.
+func (c *VisionClient) FundingRates(ctx context.Context, market VisionMarket, symbol string, month time.Time) ([]FuturesFundingRateHistory, error) {
+	data, err := c.Download(ctx, VisionArchive{Market: market, Period: VisionPeriodMonthly, DataType: VisionDataTypeFundingRate, Symbol: symbol, Date: month})
+	if err != nil {
+		return nil, err
+	}
+	return ParseVisionFundingRates(symbol, data)
+}
+
+// ParseVisionKlines parses zip data of klines archive.
+// Columns: open time, open, high, low, close, volume, close time,
+// quote volume, trades number, taker buy volume, taker buy quote volume, ignore.
+func ParseVisionKlines(zipData []byte) ([]Kline, error) {
+	return parseVisionCsv(zipData, 12, func(row []string) (Kline, error) {
+		var raw RawKline
+		for i := range raw {
+			raw[i] = row[i]
+		}
+		raw[0] = normVisionTime(row[0])
+		raw[6] = normVisionTime(row[6])
+		return UnmarshalRawKline(raw)
+	})
+}
+
+// ParseVisionTrades parses zip data of trades archive.
+// Columns: id, price, qty, quote qty, time, is buyer maker, is best match(spot only).
+func ParseVisionTrades(zipData []byte) ([]Trade, error) {
+	return parseVisionCsv(zipData, 6, func(row []string) (Trade, error) {
+		var t Trade
+		p := visionRowParser{row: row}
+		t.Id = p.int(0)
+		t.Price = p.float(1)
+		t.Qty = p.float(2)
+		t.QuoteQty = p.float(3)
+		t.Time = p.time(4)
+		t.IsBuyerMaker = p.bool(5)
+		if len(row) > 6 {
+			t.IsBestMatch = p.bool(6)
+		}
+		return t, p.err
+	})
+}
+
+// ParseVisionAggTrades parses zip data of aggTrades archive.
+// Columns: agg trade id, price, qty, first trade id, last trade id, time, is buyer maker, is best match(spot only).
+func ParseVisionAggTrades(zipData []byte) ([]AggTrade, error) {
+	return parseVisionCsv(zipData, 7, func(row []string) (AggTrade, error) {
+		var t AggTrade
+		p := visionRowParser{row: row}
+		t.AggTradeId = p.int(0)
+		t.Price = p.float(1)
+		t.Qty = p.float(2)
+		t.FirstTradeId = p.int(3)
+		t.LastTradeId = p.int(4)
+		t.Time = p.time(5)
+		t.IsBuyerMaker = p.bool(6)
+		if len(row) > 7 {
+			t.IsBestMatch = p.bool(7)
+		}
+		return t, p.err
+	})
+}
+
+// ParseVisionFundingRates parses zip data of fundingRate archive.
+// Columns: calc time, funding interval hours, last funding rate.
+// MarkPrice is empty, because it is not archived.
+func ParseVisionFundingRates(symbol string, zipData []byte) ([]FuturesFundingRateHistory, error) {
+	return parseVisionCsv(zipData, 3, func(row []string) (FuturesFundingRateHistory, error) {
+		var f FuturesFundingRateHistory
+		p := visionRowParser{row: row}
+		f.Symbol = symbol
+		f.FundingTime = p.time(0)
+		f.FundingRate = p.float(2)
+		return f, p.err
+	})
+}
+
+// parseVisionCsv parses every csv file in zipData by parse.
+// Header row is skipped, which is in futures archives, but not in spot archives.
+func parseVisionCsv[T any](zipData []byte, minCols int, parse func(row []string) (T, error)) ([]T, error) {
+	zr, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return nil, fmt.Errorf("bnc: open vision zip, %w", err)
+	}
+	var items []T
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".csv") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("bnc: open %v, %w", f.Name, err)
+		}
+		rows, err := csv.NewReader(rc).ReadAll()
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("bnc: read %v, %w", f.Name, err)
+		}
+		for i, row := range rows {
+			if len(row) < minCols {
+				return nil, fmt.Errorf("bnc: %v row %v has %v columns, want %v", f.Name, i+1, len(row), minCols)
+			}
+			if _, err := strconv.ParseInt(row[0], 10, 64); err != nil && i == 0 {
+				continue
+			}
+			item, err := parse(row)
+			if err != nil {
+				return nil, fmt.Errorf("bnc: parse %v row %v, %w", f.Name, i+1, err)
+			}
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// normVisionTime converts microseconds to milliseconds,
+// spot archives since 2025 are in microseconds.
+func normVisionTime(s string) string {
+	if len(s) > 13 {
+		if t, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return strconv.FormatInt(t/1000, 10)
+		}
+	}
+	return s
+}
+
+// visionRowParser parses columns of row, and keeps the first error.
+type visionRowParser struct {
+	row []string
+	err error
+}
+
+func (p *visionRowParser) int(i int) int64 {
+	v, err := strconv.ParseInt(p.row[i], 10, 64)
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	return v
+}
+
+func (p *visionRowParser) time(i int) int64 {
+	v, err := strconv.ParseInt(normVisionTime(p.row[i]), 10, 64)
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	return v
+}
+
+func (p *visionRowParser) float(i int) float64 {
+	v, err := strconv.ParseFloat(p.row[i], 64)
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	return v
+}
+
+func (p *visionRowParser) bool(i int) bool {
+	v, err := strconv.ParseBool(strings.ToLower(p.row[i]))
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	return v
+}
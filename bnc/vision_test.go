@@ -0,0 +1,113 @@
+package bnc
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testVisionZip(t *testing.T, name, content string) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestVisionArchive_Path(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	paths := map[string]VisionArchive{
+		"/data/spot/daily/klines/ETHUSDT/1m/ETHUSDT-1m-2024-01-02.zip":                 {Market: VisionMarketSpot, Period: VisionPeriodDaily, DataType: VisionDataTypeKlines, Symbol: "ETHUSDT", Interval: "1m", Date: date},
+		"/data/futures/um/monthly/aggTrades/ETHUSDT/ETHUSDT-aggTrades-2024-01.zip":     {Market: VisionMarketUmFutures, Period: VisionPeriodMonthly, DataType: VisionDataTypeAggTrades, Symbol: "ETHUSDT", Date: date},
+		"/data/futures/um/monthly/fundingRate/ETHUSDT/ETHUSDT-fundingRate-2024-01.zip": {Market: VisionMarketUmFutures, Period: VisionPeriodMonthly, DataType: VisionDataTypeFundingRate, Symbol: "ETHUSDT", Date: date},
+	}
+	for path, archive := range paths {
+		if archive.Path() != path {
+			t.Error("wrong path", archive.Path(), path)
+		}
+	}
+}
+
+func TestVisionClient(t *testing.T) {
+	archives := map[string][]byte{
+		"/data/spot/daily/klines/ETHUSDT/1m/ETHUSDT-1m-2025-01-02.zip": testVisionZip(t, "ETHUSDT-1m-2025-01-02.csv",
+			"1735776000000000,3450.1,3455,3449.5,3452.2,100.5,1735776059999999,346946.1,1200,50.2,173300.5,0\n"+
+				"1735776060000000,3452.2,3456,3451,3455,80,1735776119999999,276400,900,40,138200,0\n"),
+		"/data/futures/um/daily/trades/ETHUSDT/ETHUSDT-trades-2024-01-02.zip": testVisionZip(t, "ETHUSDT-trades-2024-01-02.csv",
+			"id,price,qty,quote_qty,time,is_buyer_maker\n"+
+				"3000000001,2350.5,0.2,470.1,1704153600123,true\n"),
+		"/data/spot/daily/aggTrades/ETHUSDT/ETHUSDT-aggTrades-2024-01-02.zip": testVisionZip(t, "ETHUSDT-aggTrades-2024-01-02.csv",
+			"900000001,2350.5,1.5,1000,1002,1704153600123,False,True\n"),
+		"/data/futures/um/monthly/fundingRate/ETHUSDT/ETHUSDT-fundingRate-2024-01.zip": testVisionZip(t, "ETHUSDT-fundingRate-2024-01.csv",
+			"calc_time,funding_interval_hours,last_funding_rate\n"+
+				"1704067200000,8,0.00010000\n"+
+				"1704096000000,8,-0.00005000\n"),
+	}
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		data, ok := archives[r.URL.Path]
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusNotFound
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader(data)), Request: r}, nil
+	})
+	clt := NewVisionClient(VisionClientOptTransport(transport))
+	ctx := context.Background()
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	klines, err := clt.Klines(ctx, VisionMarketSpot, VisionPeriodDaily, "ETHUSDT", "1m", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 2 || klines[0].OpenTime != 1735776000000 || klines[0].CloseTime != 1735776059999 || klines[1].ClosePrice != 3455 || klines[0].TradesNumber != 1200 {
+		t.Error("wrong klines", klines)
+	}
+
+	trades, err := clt.Trades(ctx, VisionMarketUmFutures, VisionPeriodDaily, "ETHUSDT", day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || trades[0].Id != 3000000001 || trades[0].Time != 1704153600123 || !trades[0].IsBuyerMaker || trades[0].QuoteQty != 470.1 {
+		t.Error("wrong trades", trades)
+	}
+
+	aggTrades, err := clt.AggTrades(ctx, VisionMarketSpot, VisionPeriodDaily, "ETHUSDT", day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aggTrades) != 1 || aggTrades[0].LastTradeId != 1002 || aggTrades[0].IsBuyerMaker || !aggTrades[0].IsBestMatch {
+		t.Error("wrong agg trades", aggTrades)
+	}
+
+	rates, err := clt.FundingRates(ctx, VisionMarketUmFutures, "ETHUSDT", day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 2 || rates[1].FundingRate != -0.00005 || rates[0].Symbol != "ETHUSDT" || rates[0].FundingTime != 1704067200000 {
+		t.Error("wrong funding rates", rates)
+	}
+
+	_, err = clt.Klines(ctx, VisionMarketSpot, VisionPeriodDaily, "ETHUSDT", "1m", day)
+	if !errors.Is(err, ErrVisionArchiveNotFound) {
+		t.Error("archive should not be found", err)
+	}
+
+	_, err = ParseVisionTrades(testVisionZip(t, "bad.csv", strings.Repeat("1,", 3)+"1\n"))
+	if err == nil {
+		t.Error("short row should fail")
+	}
+}
@@ -0,0 +1,485 @@
+package bnc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/s2m"
+	"github.com/gorilla/websocket"
+)
+
+// ============================================================
+// WebSocket API
+// ------------------------------------------------------------
+
+const (
+	WsApiBaseUrl        = "wss://ws-api.binance.com:443/ws-api/v3"
+	FuturesWsApiBaseUrl = "wss://ws-fapi.binance.com/ws-fapi/v1"
+
+	TestnetWsApiBaseUrl        = "wss://ws-api.testnet.binance.vision/ws-api/v3"
+	TestnetFuturesWsApiBaseUrl = "wss://testnet.binancefuture.com/ws-fapi/v1"
+)
+
+const (
+	WsApiMethodOrderPlace  = "order.place"
+	WsApiMethodOrderCancel = "order.cancel"
+)
+
+// ErrWsApiClosed is returned by Start of closed WsApiClient.
+var ErrWsApiClosed = errors.New("bnc: ws api client is closed")
+
+// WsApiRequest is request of binance WebSocket API.
+type WsApiRequest struct {
+	Id     string            `json:"id"`
+	Method string            `json:"method"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// WsApiResponse is response of binance WebSocket API.
+// Result is the same as body of REST response, if Status is 200,
+// otherwise Error is code msg.
+type WsApiResponse struct {
+	Id         string           `json:"id"`
+	Status     int              `json:"status"`
+	Result     json.RawMessage  `json:"result,omitempty"`
+	Error      json.RawMessage  `json:"error,omitempty"`
+	RateLimits []WsApiRateLimit `json:"rateLimits,omitempty"`
+}
+
+type WsApiRateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int    `json:"intervalNum"`
+	Limit         int    `json:"limit"`
+	Count         int    `json:"count"`
+}
+
+type WsApiClientOpt func(*WsApiClient)
+
+// WsApiClientOptUrls connects to spot and futures urls instead of mainnet or testnet urls of user.
+func WsApiClientOptUrls(spotUrl, futuresUrl string) WsApiClientOpt {
+	return func(c *WsApiClient) {
+		c.spot.url = spotUrl
+		c.futures.url = futuresUrl
+	}
+}
+
+// WsApiClientOptDialer dials connections by dialer, ex. with proxy.
+func WsApiClientOptDialer(dialer *websocket.Dialer) WsApiClientOpt {
+	return func(c *WsApiClient) {
+		c.spot.dialer = dialer
+		c.futures.dialer = dialer
+	}
+}
+
+// WsApiClientOptTimeout sets how long to wait for response, default is 10 seconds.
+// cex.CltOptTimeout overrides it per call.
+func WsApiClientOptTimeout(timeout time.Duration) WsApiClientOpt {
+	return func(c *WsApiClient) {
+		c.timeout = timeout
+	}
+}
+
+// WsApiClient places and cancels orders by binance WebSocket API,
+// which has lower latency than REST, and does not need listen key.
+// Every request is signed by api key of user, so all key types are supported.
+// It implements OrderTransport, so User methods route orders by it,
+// and fall back to REST if connection is down.
+//
+//	ws := NewWsApiClient(user)
+//	err := ws.Start(ctx)
+//	user = user.WithOrderTransport(ws)
+//	_, ord, err := user.NewSpotLimitBuyOrder("ETH", "USDT", 0.01, 2000)
+//
+// Every request is signed by the calling User, and it carries context of the calling User,
+// so one client can be shared by copies of user, ex. by WithContext.
+// Requests acquire cex.DefaultRateLimiter and cex.DefaultWeightBudget as REST orders.
+//
+// Lost connection is redialed in background by the next request,
+// which falls back to REST meanwhile.
+type WsApiClient struct {
+	user    *User
+	timeout time.Duration
+
+	spot    *wsApiConn
+	futures *wsApiConn
+
+	closed atomic.Bool
+}
+
+func NewWsApiClient(user *User, opts ...WsApiClientOpt) *WsApiClient {
+	c := &WsApiClient{
+		user:    user,
+		timeout: 10 * time.Second,
+		spot:    newWsApiConn(),
+		futures: newWsApiConn(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Start dials spot and futures connections, which are not started.
+// Sides are dialed independently, so orders of the started side are routed by ws,
+// even if the other side fails, and error of failed side is returned.
+// Start can be called again to dial failed side.
+func (c *WsApiClient) Start(ctx context.Context) error {
+	if c.closed.Load() {
+		return ErrWsApiClosed
+	}
+	if err := c.user.resolveCredential(); err != nil {
+		return err
+	}
+	testnet := c.user.api.Env.IsTestnet()
+	if c.spot.url == "" {
+		c.spot.url = WsApiBaseUrl
+		if testnet {
+			c.spot.url = TestnetWsApiBaseUrl
+		}
+	}
+	if c.futures.url == "" {
+		c.futures.url = FuturesWsApiBaseUrl
+		if testnet {
+			c.futures.url = TestnetFuturesWsApiBaseUrl
+		}
+	}
+	var errs []error
+	for _, conn := range []*wsApiConn{c.spot, c.futures} {
+		if conn.started.Load() {
+			continue
+		}
+		if err := conn.dial(ctx); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		conn.started.Store(true)
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes connections, pending requests get unknown status errors.
+func (c *WsApiClient) Close() {
+	c.closed.Store(true)
+	c.spot.close()
+	c.futures.close()
+}
+
+// Available implements OrderTransport, side of pairType is available, if it is started and client is not closed.
+func (c *WsApiClient) Available(pairType cex.PairType) bool {
+	if c.closed.Load() {
+		return false
+	}
+	switch pairType {
+	case cex.PairTypeSpot:
+		return c.spot.started.Load()
+	case cex.PairTypeFutures:
+		return c.futures.started.Load()
+	}
+	return false
+}
+
+func (c *WsApiClient) NewSpotOrder(user *User, params SpotNewOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	return wsApiRequest(c, user, c.spot, WsApiMethodOrderPlace, SpotNewOrderConfig, params, opts...)
+}
+
+func (c *WsApiClient) CancelSpotOrder(user *User, params SpotCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, SpotOrder, cex.RequestError) {
+	return wsApiRequest(c, user, c.spot, WsApiMethodOrderCancel, SpotCancelOrderConfig, params, opts...)
+}
+
+func (c *WsApiClient) NewFuturesOrder(user *User, params FuturesNewOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return wsApiRequest(c, user, c.futures, WsApiMethodOrderPlace, FuturesNewOrderConfig, params, opts...)
+}
+
+func (c *WsApiClient) CancelFuturesOrder(user *User, params FuturesQueryOrCancelOrderParams, opts ...cex.CltOpt) (*cex.Response, FuturesOrder, cex.RequestError) {
+	return wsApiRequest(c, user, c.futures, WsApiMethodOrderCancel, FuturesCancelOrderConfig, params, opts...)
+}
+
+// wsApiRequest sends params signed by user by conn, and checks response by status checker and body unmarshaler of REST config,
+// because results of WebSocket API are the same as REST responses.
+// Returned response is built from status and result or error of WsApiResponse.
+func wsApiRequest[ReqDataType, RespDataType any](
+	c *WsApiClient,
+	user *User,
+	conn *wsApiConn,
+	method string,
+	config cex.ReqConfig[ReqDataType, RespDataType],
+	params ReqDataType,
+	opts ...cex.CltOpt,
+) (*cex.Response, RespDataType, cex.RequestError) {
+	var data RespDataType
+	reqErr := cex.RequestError{ReqBaseConfig: config.ReqBaseConfig}
+	reqErr.ReqBaseConfig.BaseUrl, reqErr.ReqBaseConfig.Path, reqErr.ReqBaseConfig.Method = conn.url, "", method
+
+	if err := cex.ValidateParams(params); err != nil {
+		return nil, data, *reqErr.SetErr(err)
+	}
+	if err := user.resolveCredential(); err != nil {
+		return nil, data, *reqErr.SetErr(err)
+	}
+	ctx := user.requestCtx()
+	// params are signed after rate limits, so timestamp is not stale
+	if err := acquireOrderLimits(ctx, user, config.ReqBaseConfig, &reqErr); err != nil {
+		return nil, data, *reqErr.SetErr(err)
+	}
+	ro := cex.NewReqOpts(opts...)
+	signed, err := signWsApiParams(user, params, ro)
+	if err != nil {
+		return nil, data, *reqErr.SetErr(err)
+	}
+
+	timeout := c.timeout
+	if ro.Timeout > 0 {
+		timeout = ro.Timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	wsResp, err := conn.call(ctx, method, signed)
+	if err != nil {
+		return nil, data, *reqErr.SetErr(err)
+	}
+	body := []byte(wsResp.Result)
+	if wsResp.Status != http.StatusOK {
+		body = wsResp.Error
+	}
+	resp := cex.NewResponse(wsResp.Status, nil, body)
+	reqErr.Meta = cex.NewResponseMeta(resp)
+
+	errHttp := config.HTTPStatusCodeChecker(wsResp.Status)
+	data, errBodyUnmarshal := config.RespBodyUnmarshaler(body)
+	if errHttp == nil && errBodyUnmarshal == nil {
+		return resp, data, reqErr
+	}
+	if errHttp != nil {
+		reqErr.HTTPError = &cex.HTTPError{StatusCode: resp.StatusCode(), Status: resp.Status(), Err: errHttp}
+	}
+	var errBody error
+	if errBodyUnmarshal != nil {
+		reqErr.RespBodyUnmarshalerError = errBodyUnmarshal
+		errBody = errBodyUnmarshal
+	}
+	reqErr.Err = fmt.Errorf("bnc: ws api %v, http err: %w, body unmarshal err: %w", method, errHttp, errBody)
+	return resp, data, reqErr
+}
+
+// signWsApiParams returns params with apiKey, timestamp and signature of u.
+// Query and recvWindow of ro are signed as REST requests.
+func signWsApiParams(u *User, data any, ro cex.ReqOpts) (map[string]string, error) {
+	signer, err := u.apiSigner()
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{}
+	if valuer, ok := data.(urlValuer); ok {
+		for k, vs := range valuer.UrlValues() {
+			params[k] = vs[0]
+		}
+	} else {
+		m, err := s2m.ToStrMap(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", cex.ErrS2M, err)
+		}
+		for k, v := range m {
+			params[k] = v
+		}
+	}
+	for k, vs := range u.signExtra(ro) {
+		params[k] = vs[0]
+	}
+	params["apiKey"] = u.api.ApiKey
+	params["timestamp"] = strconv.FormatInt(u.cfg.timeSync.Now().UnixMilli(), 10)
+	sig, err := signer(wsApiSignPayload(params))
+	if err != nil {
+		return nil, fmt.Errorf("bnc: sign, %w", err)
+	}
+	params["signature"] = encodeSignature(sig, u.api.KeyType)
+	return params, nil
+}
+
+// wsApiSignPayload joins params sorted by key, values are not escaped as REST query.
+func wsApiSignPayload(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	b := strings.Builder{}
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(params[k])
+	}
+	return b.String()
+}
+
+// wsApiConn sends requests by one connection, and matches responses by id.
+type wsApiConn struct {
+	url    string
+	dialer *websocket.Dialer
+
+	mux     sync.Mutex
+	conn    *websocket.Conn
+	pending map[string]chan WsApiResponse
+	dialing bool
+	closed  bool
+
+	writeMux sync.Mutex
+	id       atomic.Int64
+	// started is true after the first dial succeeds
+	started atomic.Bool
+}
+
+func newWsApiConn() *wsApiConn {
+	return &wsApiConn{dialer: websocket.DefaultDialer, pending: map[string]chan WsApiResponse{}}
+}
+
+func (c *wsApiConn) dial(ctx context.Context) error {
+	conn, _, err := c.dialer.DialContext(ctx, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("bnc: ws api dial %v, %w", c.url, err)
+	}
+	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		_ = conn.Close()
+		return ErrWsApiClosed
+	}
+	c.conn = conn
+	c.mux.Unlock()
+	go c.read(conn)
+	return nil
+}
+
+// redial dials in background, if connection is down and no one is dialing.
+func (c *wsApiConn) redial() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.dialing || c.closed || c.conn != nil {
+		return
+	}
+	c.dialing = true
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = c.dial(ctx)
+		c.mux.Lock()
+		c.dialing = false
+		c.mux.Unlock()
+	}()
+}
+
+func (c *wsApiConn) close() {
+	c.mux.Lock()
+	c.closed = true
+	conn := c.conn
+	c.mux.Unlock()
+	if conn != nil {
+		// read loop closes pending requests
+		_ = conn.Close()
+	}
+}
+
+// read dispatches responses to pending requests,
+// and closes them if connection is lost, because their status is unknown.
+func (c *wsApiConn) read(conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			c.mux.Lock()
+			if c.conn == conn {
+				c.conn = nil
+			}
+			pending := c.pending
+			c.pending = map[string]chan WsApiResponse{}
+			c.mux.Unlock()
+			_ = conn.Close()
+			for _, ch := range pending {
+				close(ch)
+			}
+			return
+		}
+		resp := WsApiResponse{}
+		if err := json.Unmarshal(data, &resp); err != nil || resp.Id == "" {
+			continue
+		}
+		c.mux.Lock()
+		ch, ok := c.pending[resp.Id]
+		delete(c.pending, resp.Id)
+		c.mux.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+}
+
+// call returns error wrapping ErrOrderTransportUnavailable, if request is not sent,
+// or cex.ErrHTTPCexInnerUnknownStatus, if request is sent but response is not received.
+func (c *wsApiConn) call(ctx context.Context, method string, params map[string]string) (WsApiResponse, error) {
+	id := strconv.FormatInt(c.id.Add(1), 10)
+	data, err := json.Marshal(WsApiRequest{Id: id, Method: method, Params: params})
+	if err != nil {
+		return WsApiResponse{}, fmt.Errorf("bnc: ws api %v, marshal, %w", method, err)
+	}
+	ch := make(chan WsApiResponse, 1)
+	c.mux.Lock()
+	conn := c.conn
+	if conn != nil {
+		c.pending[id] = ch
+	}
+	c.mux.Unlock()
+	if conn == nil {
+		c.redial()
+		return WsApiResponse{}, fmt.Errorf("bnc: ws api %v, not connected, %w", method, ErrOrderTransportUnavailable)
+	}
+
+	c.writeMux.Lock()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	c.writeMux.Unlock()
+	if err != nil {
+		c.removePending(id)
+		// read loop cleans up and connection is redialed by next request
+		_ = conn.Close()
+		return WsApiResponse{}, fmt.Errorf("bnc: ws api %v, write, %w: %w", method, ErrOrderTransportUnavailable, err)
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return WsApiResponse{}, fmt.Errorf("bnc: ws api %v, connection is lost, %w", method, cex.ErrHTTPCexInnerUnknownStatus)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		c.removePending(id)
+		return WsApiResponse{}, fmt.Errorf("bnc: ws api %v, %w: %w", method, cex.ErrHTTPCexInnerUnknownStatus, ctx.Err())
+	}
+}
+
+func (c *wsApiConn) removePending(id string) {
+	c.mux.Lock()
+	delete(c.pending, id)
+	c.mux.Unlock()
+}
+
+// ------------------------------------------------------------
+// WebSocket API
+// ============================================================
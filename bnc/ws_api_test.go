@@ -0,0 +1,140 @@
+package bnc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+	"github.com/gorilla/websocket"
+)
+
+func TestWsApiClient(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			req := WsApiRequest{}
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			params := map[string]string{}
+			for k, v := range req.Params {
+				if k != "signature" {
+					params[k] = v
+				}
+			}
+			mac := hmac.New(sha256.New, []byte("secret"))
+			mac.Write([]byte(wsApiSignPayload(params)))
+			resp := WsApiResponse{Id: req.Id, Status: http.StatusOK, Result: []byte(`{"symbol":"ETHUSDT","clientOrderId":"ws"}`)}
+			switch {
+			case req.Params["apiKey"] != "key" || req.Params["signature"] != hex.EncodeToString(mac.Sum(nil)):
+				resp.Status, resp.Result, resp.Error = http.StatusUnauthorized, nil, []byte(`{"code":-1022,"msg":"Signature for this request is not valid."}`)
+			case req.Method == WsApiMethodOrderCancel:
+				resp.Status, resp.Result, resp.Error = http.StatusBadRequest, nil, []byte(`{"code":-2011,"msg":"Unknown order sent."}`)
+			case req.Params["newClientOrderId"] == "close":
+				// connection is lost after request is received
+				return
+			case req.Params["newClientOrderId"] == "slow":
+				continue
+			}
+			if err := conn.WriteJSON(resp); err != nil {
+				return
+			}
+		}
+	}))
+	defer sv.Close()
+	wsUrl := "ws" + strings.TrimPrefix(sv.URL, "http")
+
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"symbol":"ETHUSDT","clientOrderId":"rest"}`))),
+		}, nil
+	})
+	user := NewUser("key", "secret", UserOptTransport(transport))
+	ws := NewWsApiClient(user, WsApiClientOptUrls(wsUrl, wsUrl))
+	if ws.Available(cex.PairTypeSpot) {
+		t.Error("ws api should not be available before start")
+	}
+	if err := ws.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	user = user.WithOrderTransport(ws)
+
+	spotOnly := NewWsApiClient(user, WsApiClientOptUrls(wsUrl, "ws://127.0.0.1:1"))
+	if err := spotOnly.Start(context.Background()); err == nil {
+		t.Error("failed futures dial should be returned")
+	}
+	if !spotOnly.Available(cex.PairTypeSpot) || spotOnly.Available(cex.PairTypeFutures) {
+		t.Error("spot should be available, if only futures dial fails")
+	}
+	spotOnly.Close()
+
+	params := SpotNewOrderParams{Symbol: "ETHUSDT", Side: OrderSideBuy, Type: OrderTypeLimit, Quantity: 0.01, Price: 2000, TimeInForce: TimeInForceGtc}
+	_, ord, err := user.routeNewSpotOrder(params)
+	if err.IsNotNil() || ord.ClientOrderId != "ws" {
+		t.Error("order should be placed by ws api", ord.ClientOrderId, err.Error())
+	}
+	_, ord, err = user.WithOrderRoute(OrderRouteRest).routeNewSpotOrder(params)
+	if err.IsNotNil() || ord.ClientOrderId != "rest" {
+		t.Error("order should be placed by rest", ord.ClientOrderId, err.Error())
+	}
+	if _, _, err = user.CancelSpotOrder("ETHUSDT", 1, ""); !err.Is(cex.ErrUnknownOrder) || err.HTTPError == nil {
+		t.Error("ws api error should be checked as rest error, but", err.Error())
+	}
+	_, _, err = user.CancelFuturesOrder("ETHUSDT", 1, "")
+	if !err.Is(cex.ErrUnknownOrder) {
+		t.Error("futures ws api error should be checked as rest error, but", err.Error())
+	}
+
+	// request carries context of calling user, instead of user creating client
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	params.NewClientOrderId = "slow"
+	if _, _, err = user.WithContext(ctx).routeNewSpotOrder(params); !errors.Is(err.Err, context.Canceled) {
+		t.Error("request should be canceled by context of calling user, but", err.Error())
+	}
+
+	limiter := cex.NewRateLimiter(cex.RateLimitModeReject)
+	limiter.Block(SpotNewOrderConfig.BaseUrl, time.Now().Add(time.Minute))
+	defaultLimiter := cex.DefaultRateLimiter()
+	cex.SetDefaultRateLimiter(limiter)
+	params.NewClientOrderId = ""
+	_, _, err = user.routeNewSpotOrder(params)
+	cex.SetDefaultRateLimiter(defaultLimiter)
+	if !err.Is(cex.ErrRateLimited) {
+		t.Error("ws api orders should be limited by default rate limiter, but", err.Error())
+	}
+
+	params.NewClientOrderId = "close"
+	if _, _, err = user.routeNewSpotOrder(params); !err.Is(cex.ErrHTTPCexInnerUnknownStatus) {
+		t.Error("lost response should not fall back to rest, but", err.Error())
+	}
+	params.NewClientOrderId = ""
+	if _, ord, err = user.routeNewSpotOrder(params); err.IsNotNil() || ord.ClientOrderId != "rest" {
+		t.Error("order should fall back to rest while ws api is redialed", ord.ClientOrderId, err.Error())
+	}
+
+	ws.Close()
+	if _, ord, _ = user.routeNewSpotOrder(params); ord.ClientOrderId != "rest" {
+		t.Error("closed ws api should fall back to rest", ord.ClientOrderId)
+	}
+	if err := ws.Start(context.Background()); err != ErrWsApiClosed {
+		t.Error("closed ws api should not be started", err)
+	}
+}
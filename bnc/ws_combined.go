@@ -0,0 +1,94 @@
+package bnc
+
+import (
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/ws/wsclt"
+)
+
+const (
+	// max stream number of one combined stream connection
+	maxCombinedStreamNumPerWs        = 1024
+	maxFuturesCombinedStreamNumPerWs = 200
+
+	// max topic number of one SUBSCRIBE/UNSUBSCRIBE msg
+	maxTopicNumPerSubMsg = 200
+
+	// binance limits incoming msgs to 5/s for spot and 10/s for futures,
+	// pong and ping msgs are included, so leave some room.
+	wsSubMsgInterval        = time.Millisecond * 250
+	futuresWsSubMsgInterval = time.Millisecond * 125
+)
+
+// batchTopics splits topics into batches, size of every batch is not greater than size.
+func batchTopics(topics []string, size int) [][]string {
+	var batches [][]string
+	for len(topics) > size {
+		batches = append(batches, topics[:size])
+		topics = topics[size:]
+	}
+	if len(topics) > 0 {
+		batches = append(batches, topics)
+	}
+	return batches
+}
+
+var wsSubMsgId atomic.Int64
+
+// newBatchTopicSender returns topic suber or unsuber,
+// which sends topics in batches and waits interval between msgs to avoid being disconnected.
+// It is also used to re-subscribe all topics of connection after reconnecting.
+func newBatchTopicSender(method WsMethod, interval time.Duration) func(client *wsclt.BaseClient, topics []string) error {
+	return func(client *wsclt.BaseClient, topics []string) error {
+		for i, batch := range batchTopics(topics, maxTopicNumPerSubMsg) {
+			if i > 0 {
+				time.Sleep(interval)
+			}
+			err := client.WriteJSON(WsSubMsg{
+				Method: method,
+				Params: batch,
+				Id:     wsSubMsgId.Add(1),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// NewWsCombinedMarketMsgHandler creates WsMarketMsgHandler over combined streams.
+// Streams are subscribed and unsubscribed dynamically by SUBSCRIBE and UNSUBSCRIBE msgs,
+// instead of /stream?streams=, so url is not changed after reconnecting.
+// New connection is created if stream number of existing connections reaches limit,
+// and all streams of connection are re-subscribed after reconnecting.
+func NewWsCombinedMarketMsgHandler(pairType cex.PairType, logger *slog.Logger) *WsMarketMsgHandler {
+	url := WsCombinedBaseUrl
+	maxTopicNum := maxCombinedStreamNumPerWs
+	interval := wsSubMsgInterval
+	if pairType == cex.PairTypeFutures {
+		url = FutureWsCombinedBaseUrl
+		maxTopicNum = maxFuturesCombinedStreamNumPerWs
+		interval = futuresWsSubMsgInterval
+	}
+	mgClt := wsclt.
+		NewMergedClient(url, true, maxTopicNum, logger).
+		SetTopicSuber(newBatchTopicSender(WsMethodSub, interval)).
+		SetTopicUnsuber(newBatchTopicSender(WsMethodUnsub, interval)).
+		SetPong(pong)
+	return &WsMarketMsgHandler{pairType: pairType, mgClt: mgClt}
+}
+
+// NewWsCombinedMarketStream creates WsMarketStream over combined streams,
+// which holds much more streams per connection than raw streams.
+//
+//	s := NewWsCombinedMarketStream(cex.PairTypeSpot)
+//	s.Start(ctx)
+//	err := s.SubBookTicker(symbols...)
+//	for e := range s.Events() {...}
+func NewWsCombinedMarketStream(pairType cex.PairType, opts ...cex.WsStreamOpt) *WsMarketStream {
+	return &WsMarketStream{cex.NewWsStream[WsMarketEvent](NewWsCombinedMarketMsgHandler(pairType, nil), opts...)}
+}
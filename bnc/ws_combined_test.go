@@ -0,0 +1,53 @@
+package bnc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/props"
+	"github.com/dwdwow/ws/wsclt"
+	"github.com/gorilla/websocket"
+)
+
+func TestBatchTopics(t *testing.T) {
+	var topics []string
+	for i := range 450 {
+		topics = append(topics, fmt.Sprint(i))
+	}
+	batches := batchTopics(topics, maxTopicNumPerSubMsg)
+	if len(batches) != 3 || len(batches[0]) != 200 || len(batches[2]) != 50 || batches[2][49] != "449" {
+		t.Error("wrong batches", len(batches))
+	}
+	if len(batchTopics(nil, maxTopicNumPerSubMsg)) != 0 {
+		t.Error("empty topics should have no batch")
+	}
+}
+
+func TestWsMarketMsgHandler_HandleCombined(t *testing.T) {
+	h := NewWsCombinedMarketMsgHandler(cex.PairTypeSpot, nil)
+	data := `{"stream":"bnbbtc@trade","data":{"e":"trade","E":123456789,"s":"BNBBTC","t":12345,"p":"0.001","q":"100","T":123456785,"m":true,"M":true}}`
+	events, err := h.Handle(wsclt.MergedClientMsg{MsgType: websocket.TextMessage, Data: []byte(data)})
+	props.PanicIfNotNil(err)
+	if len(events) != 1 || events[0].Trade == nil || events[0].Topic() != "bnbbtc@trade" {
+		t.Fatal("wrong events", events)
+	}
+	events, err = h.Handle(wsclt.MergedClientMsg{MsgType: websocket.TextMessage, Data: []byte(`{"result":null,"id":1}`)})
+	props.PanicIfNotNil(err)
+	if len(events) != 0 {
+		t.Error("sub response should be ignored", events)
+	}
+}
+
+func TestWsCombinedMarketStream(t *testing.T) {
+	s := NewWsCombinedMarketStream(cex.PairTypeFutures)
+	s.Start(context.TODO())
+	props.PanicIfNotNil(s.SubBookTicker("BTCUSDT", "ETHUSDT"))
+	props.PanicIfNotNil(s.SubAggTrade("BTCUSDT"))
+	for i := 0; i < 10; i++ {
+		e := <-s.Events()
+		props.PanicIfNotNil(e.Err)
+		props.PrintlnIndent(e.Data)
+	}
+}
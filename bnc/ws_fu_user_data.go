@@ -0,0 +1,44 @@
+package bnc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WsFuturesUserDataEvent is typed event of futures user data stream.
+// Only the field matching Event is not nil, and all fields are nil for events not supported.
+// Own liquidation and auto-deleveraging activities are:
+//   - MarginCall: positions are at risk of liquidation;
+//   - OrderTradeUpdate.IsLiquidation / IsAdl: orders placed by binance to close positions;
+//   - AccountUpdate.IsAdl: balances and positions changed by auto-deleveraging.
+type WsFuturesUserDataEvent struct {
+	Event            WsEvent                          `json:"event"`
+	MarginCall       *WsFuturesMarginCallStream       `json:"marginCall,omitempty"`
+	AccountUpdate    *WsFuturesAccountUpdateStream    `json:"accountUpdate,omitempty"`
+	OrderTradeUpdate *WsFuturesOrderTradeUpdateStream `json:"orderTradeUpdate,omitempty"`
+}
+
+// ParseWsFuturesUserDataMsg parses msg of usd-m futures user data stream.
+func ParseWsFuturesUserDataMsg(data []byte) (WsFuturesUserDataEvent, error) {
+	var probe WsUserDataEvent
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return WsFuturesUserDataEvent{}, fmt.Errorf("bnc: unmarshal futures user data event, %w", err)
+	}
+	e := WsFuturesUserDataEvent{Event: probe.EventType}
+	var err error
+	switch probe.EventType {
+	case WsMarginCall:
+		e.MarginCall = new(WsFuturesMarginCallStream)
+		err = json.Unmarshal(data, e.MarginCall)
+	case WsAccountUpdate:
+		e.AccountUpdate = new(WsFuturesAccountUpdateStream)
+		err = json.Unmarshal(data, e.AccountUpdate)
+	case WsOrderTradeUpdate:
+		e.OrderTradeUpdate = new(WsFuturesOrderTradeUpdateStream)
+		err = json.Unmarshal(data, e.OrderTradeUpdate)
+	}
+	if err != nil {
+		return WsFuturesUserDataEvent{}, fmt.Errorf("bnc: unmarshal %v, %w", probe.EventType, err)
+	}
+	return e, nil
+}
@@ -0,0 +1,30 @@
+package bnc
+
+import (
+	"testing"
+)
+
+func TestParseWsFuturesUserDataMsg(t *testing.T) {
+	e, err := ParseWsFuturesUserDataMsg([]byte(`{"e":"MARGIN_CALL","E":1587727187525,"cw":"3.16812045","p":[{"s":"ETHUSDT","ps":"LONG","pa":"1.327","mt":"CROSSED","iw":"0","mp":"187.17127","up":"-1.166074","mm":"1.614445"}]}`))
+	if err != nil || e.MarginCall == nil || len(e.MarginCall.Positions) != 1 || e.MarginCall.Positions[0].MaintenanceMarginRequired != 1.614445 {
+		t.Fatal("wrong margin call", err, e)
+	}
+
+	e, err = ParseWsFuturesUserDataMsg([]byte(`{"e":"ACCOUNT_UPDATE","E":1564745798939,"T":1564745798938,"a":{"m":"ADL","B":[{"a":"USDT","wb":"122624.12345678","cw":"100.12345678","bc":"50.12345678"}],"P":[{"s":"BTCUSDT","pa":"0","ep":"0.00000","bep":"0","cr":"200","up":"0","mt":"isolated","iw":"0.00000000","ps":"BOTH"}]}}`))
+	if err != nil || e.AccountUpdate == nil || !e.AccountUpdate.IsAdl() || e.AccountUpdate.Update.Balances[0].WalletBalance != 122624.12345678 {
+		t.Fatal("wrong adl account update", err, e)
+	}
+
+	e, err = ParseWsFuturesUserDataMsg([]byte(`{"e":"ORDER_TRADE_UPDATE","E":1568879465651,"T":1568879465650,"o":{"s":"BTCUSDT","c":"autoclose-1568879465650","S":"SELL","o":"LIQUIDATION","f":"IOC","q":"0.001","p":"0","ap":"0","sp":"7103.04","x":"CALCULATED","X":"FILLED","i":8886774,"l":"0.001","z":"0.001","L":"7100","N":"USDT","n":"0.01","T":1568879465650,"t":0,"b":"0","a":"9.91","m":false,"R":false,"wt":"CONTRACT_PRICE","ot":"LIQUIDATION","ps":"LONG","cp":false,"AP":"7476.89","cr":"5.0","pP":false,"si":0,"ss":0,"rp":"0","V":"NONE","pm":"NONE","gtd":0}}`))
+	if err != nil || e.OrderTradeUpdate == nil || !e.OrderTradeUpdate.IsLiquidation() || e.OrderTradeUpdate.IsAdl() {
+		t.Fatal("wrong liquidation order", err, e)
+	}
+	if o := e.OrderTradeUpdate.Order; o.AvgPrice != 0 || o.ActivationPrice != 7476.89 || o.LastFilledPrice != 7100 || o.ExecutionType != OrderExecutionCalculated {
+		t.Error("case sensitive keys should be unmarshalled into their own fields", o)
+	}
+
+	e, err = ParseWsFuturesUserDataMsg([]byte(`{"e":"listenKeyExpired","E":1576653824250}`))
+	if err != nil || e.Event != "listenKeyExpired" || e.MarginCall != nil || e.AccountUpdate != nil || e.OrderTradeUpdate != nil {
+		t.Error("unsupported event should be empty", err, e)
+	}
+}
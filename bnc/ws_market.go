@@ -0,0 +1,245 @@
+package bnc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/ws/wsclt"
+	"github.com/gorilla/websocket"
+)
+
+func CreateTradeTopic(symbol string) string {
+	return strings.ToLower(symbol) + "@trade"
+}
+
+func CreateAggTradeTopic(symbol string) string {
+	return strings.ToLower(symbol) + "@aggTrade"
+}
+
+func CreateKlineTopic(symbol string, interval KlineInterval) string {
+	return strings.ToLower(symbol) + "@kline_" + string(interval)
+}
+
+func CreateBookTickerTopic(symbol string) string {
+	return strings.ToLower(symbol) + "@bookTicker"
+}
+
+// CreateForceOrderTopic creates liquidation order topic of futures symbol.
+func CreateForceOrderTopic(symbol string) string {
+	return strings.ToLower(symbol) + "@forceOrder"
+}
+
+// AllForceOrderTopic is liquidation order topic of all futures symbols.
+const AllForceOrderTopic = "!forceOrder@arr"
+
+// WsMarketEvent is typed market data event.
+// Only the field matching Event is not nil.
+type WsMarketEvent struct {
+	Event      WsEvent             `json:"event"`
+	Depth      *WsDepthMsg         `json:"depth,omitempty"`
+	Trade      *WsTradeStream      `json:"trade,omitempty"`
+	AggTrade   *WsAggTradeStream   `json:"aggTrade,omitempty"`
+	Kline      *WsKlineStream      `json:"kline,omitempty"`
+	BookTicker *WsBookTickerStream `json:"bookTicker,omitempty"`
+	ForceOrder *WsForceOrderStream `json:"forceOrder,omitempty"`
+}
+
+// Topic returns stream topic of event, or empty string if event is unknown.
+func (e WsMarketEvent) Topic() string {
+	switch {
+	case e.Depth != nil:
+		return CreateObTopic(e.Depth.Symbol)
+	case e.Trade != nil:
+		return CreateTradeTopic(e.Trade.Symbol)
+	case e.AggTrade != nil:
+		return CreateAggTradeTopic(e.AggTrade.Symbol)
+	case e.Kline != nil:
+		return CreateKlineTopic(e.Kline.Symbol, e.Kline.Kline.Interval)
+	case e.BookTicker != nil:
+		return CreateBookTickerTopic(e.BookTicker.Symbol)
+	case e.ForceOrder != nil:
+		return CreateForceOrderTopic(e.ForceOrder.Order.Symbol)
+	}
+	return ""
+}
+
+// allMarketTopic returns all market topic which also contains event, or empty string if there is not.
+func (e WsMarketEvent) allMarketTopic() string {
+	if e.ForceOrder != nil {
+		return AllForceOrderTopic
+	}
+	return ""
+}
+
+// WsMarketMsgHandler implements cex.WsStreamHandler for binance market data streams.
+type WsMarketMsgHandler struct {
+	pairType cex.PairType
+	mgClt    *wsclt.MergedClient
+}
+
+func NewWsMarketMsgHandler(pairType cex.PairType, logger *slog.Logger) *WsMarketMsgHandler {
+	url := WsBaseUrl
+	if pairType == cex.PairTypeFutures {
+		url = FutureWsBaseUrl
+	}
+	mgClt := wsclt.
+		NewMergedClient(url, true, maxTopicNumPerWs, logger).
+		SetTopicSuber(topicSuber).
+		SetTopicUnsuber(topicUnsuber).
+		SetPong(pong)
+	return &WsMarketMsgHandler{pairType: pairType, mgClt: mgClt}
+}
+
+func (h *WsMarketMsgHandler) Type() cex.PairType {
+	return h.pairType
+}
+
+func (h *WsMarketMsgHandler) Client() *wsclt.MergedClient {
+	return h.mgClt
+}
+
+// wsMarketMsgProbe declares upper case keys,
+// because json matches keys case-insensitively.
+type wsMarketMsgProbe struct {
+	EventType WsEvent `json:"e"`
+	EventTime int64   `json:"E"`
+	FirstId   int64   `json:"U"`
+	BidQty    string  `json:"B"`
+	// Id is only contained in sub/unsub responses.
+	Id *int64 `json:"id"`
+	// UpdateId and BidPrice are contained in spot bookTicker, which has no event type.
+	UpdateId int64  `json:"u"`
+	BidPrice string `json:"b"`
+}
+
+// combinedStreamMsgPrefix is prefix of msgs from combined streams,
+// sub/unsub responses have no stream key.
+var combinedStreamMsgPrefix = []byte(`{"stream":`)
+
+// Handle handles msgs from both raw and combined streams.
+func (h *WsMarketMsgHandler) Handle(msg wsclt.MergedClientMsg) ([]WsMarketEvent, error) {
+	if msg.MsgType != websocket.TextMessage {
+		return nil, nil
+	}
+	data := msg.Data
+	if bytes.HasPrefix(data, combinedStreamMsgPrefix) {
+		combined := WsCombinedStreamMsg{}
+		if err := json.Unmarshal(data, &combined); err != nil {
+			return nil, fmt.Errorf("binance: ws combined stream msg unmarshal, msg: %v, %w", string(data), err)
+		}
+		data = combined.Data
+	}
+	probe := wsMarketMsgProbe{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("binance: ws market msg unmarshal, msg: %v, %w", string(data), err)
+	}
+	if probe.Id != nil {
+		// sub/unsub response
+		return nil, nil
+	}
+	event := probe.EventType
+	if event == "" && probe.UpdateId != 0 && probe.BidPrice != "" {
+		event = WsBookTicker
+	}
+	e := WsMarketEvent{Event: event}
+	var err error
+	switch event {
+	case WsEDepthUpdate:
+		e.Depth = new(WsDepthMsg)
+		err = json.Unmarshal(data, e.Depth)
+	case WsTrade:
+		e.Trade = new(WsTradeStream)
+		err = json.Unmarshal(data, e.Trade)
+	case WsAggTrade:
+		e.AggTrade = new(WsAggTradeStream)
+		err = json.Unmarshal(data, e.AggTrade)
+	case WsKline:
+		e.Kline = new(WsKlineStream)
+		err = json.Unmarshal(data, e.Kline)
+	case WsBookTicker:
+		e.BookTicker = new(WsBookTickerStream)
+		err = json.Unmarshal(data, e.BookTicker)
+	case WsForceOrder:
+		e.ForceOrder = new(WsForceOrderStream)
+		err = json.Unmarshal(data, e.ForceOrder)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("binance: ws market msg unmarshal, msg: %v, %w", string(data), err)
+	}
+	return []WsMarketEvent{e}, nil
+}
+
+// WsMarketStream delivers binance depth, trade, aggTrade, kline, bookTicker and futures forceOrder events over channel.
+//
+//	s := NewWsMarketStream(cex.PairTypeSpot)
+//	s.Start(ctx)
+//	err := s.SubBookTicker("BTCUSDT")
+//	for e := range s.Events() {...}
+type WsMarketStream struct {
+	*cex.WsStream[WsMarketEvent]
+}
+
+func NewWsMarketStream(pairType cex.PairType, opts ...cex.WsStreamOpt) *WsMarketStream {
+	return &WsMarketStream{cex.NewWsStream[WsMarketEvent](NewWsMarketMsgHandler(pairType, nil), opts...)}
+}
+
+// SubDepth subscribes diff depth streams.
+func (s *WsMarketStream) SubDepth(symbols ...string) error {
+	var topics []string
+	for _, symbol := range symbols {
+		topics = append(topics, CreateObTopic(symbol))
+	}
+	return s.Sub(topics...)
+}
+
+func (s *WsMarketStream) SubTrade(symbols ...string) error {
+	var topics []string
+	for _, symbol := range symbols {
+		topics = append(topics, CreateTradeTopic(symbol))
+	}
+	return s.Sub(topics...)
+}
+
+func (s *WsMarketStream) SubAggTrade(symbols ...string) error {
+	var topics []string
+	for _, symbol := range symbols {
+		topics = append(topics, CreateAggTradeTopic(symbol))
+	}
+	return s.Sub(topics...)
+}
+
+func (s *WsMarketStream) SubKline(interval KlineInterval, symbols ...string) error {
+	var topics []string
+	for _, symbol := range symbols {
+		topics = append(topics, CreateKlineTopic(symbol, interval))
+	}
+	return s.Sub(topics...)
+}
+
+func (s *WsMarketStream) SubBookTicker(symbols ...string) error {
+	var topics []string
+	for _, symbol := range symbols {
+		topics = append(topics, CreateBookTickerTopic(symbol))
+	}
+	return s.Sub(topics...)
+}
+
+// SubForceOrder subscribes liquidation order streams of futures symbols.
+func (s *WsMarketStream) SubForceOrder(symbols ...string) error {
+	var topics []string
+	for _, symbol := range symbols {
+		topics = append(topics, CreateForceOrderTopic(symbol))
+	}
+	return s.Sub(topics...)
+}
+
+// SubAllForceOrders subscribes liquidation order stream of all futures symbols.
+func (s *WsMarketStream) SubAllForceOrders() error {
+	return s.Sub(AllForceOrderTopic)
+}
@@ -0,0 +1,51 @@
+package bnc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/props"
+	"github.com/dwdwow/ws/wsclt"
+	"github.com/gorilla/websocket"
+)
+
+func TestWsMarketMsgHandler_Handle(t *testing.T) {
+	h := NewWsMarketMsgHandler(cex.PairTypeSpot, nil)
+	msgs := map[WsEvent]string{
+		WsBookTicker: `{"u":400900217,"s":"BNBUSDT","b":"25.35190000","B":"31.21000000","a":"25.36520000","A":"40.66000000"}`,
+		WsAggTrade:   `{"e":"aggTrade","E":123456789,"s":"BNBBTC","a":12345,"p":"0.001","q":"100","f":100,"l":105,"T":123456785,"m":true,"M":true}`,
+		WsKline:      `{"e":"kline","E":123456789,"s":"BNBBTC","k":{"t":123400000,"T":123460000,"s":"BNBBTC","i":"1m","f":100,"L":200,"o":"0.0010","c":"0.0020","h":"0.0025","l":"0.0015","v":"1000","n":100,"x":false,"q":"1.0000","V":"500","Q":"0.500","B":"123456"}}`,
+		WsForceOrder: `{"e":"forceOrder","E":1568014460893,"o":{"s":"BTCUSDT","S":"SELL","o":"LIMIT","f":"IOC","q":"0.014","p":"9910","ap":"9910","X":"FILLED","l":"0.014","z":"0.014","T":1568014460893}}`,
+	}
+	for event, data := range msgs {
+		events, err := h.Handle(wsclt.MergedClientMsg{MsgType: websocket.TextMessage, Data: []byte(data)})
+		props.PanicIfNotNil(err)
+		if len(events) != 1 || events[0].Event != event {
+			t.Fatal("wrong events", event, events)
+		}
+		props.PrintlnIndent(events[0])
+	}
+	events, err := h.Handle(wsclt.MergedClientMsg{MsgType: websocket.TextMessage, Data: []byte(msgs[WsForceOrder])})
+	props.PanicIfNotNil(err)
+	if o := events[0].ForceOrder.Order; o.Symbol != "BTCUSDT" || o.Side != OrderSideSell || o.AvgPrice != 9910 || events[0].Topic() != "btcusdt@forceOrder" {
+		t.Error("wrong force order", o)
+	}
+	events, err = h.Handle(wsclt.MergedClientMsg{MsgType: websocket.TextMessage, Data: []byte(`{"result":null,"id":1}`)})
+	props.PanicIfNotNil(err)
+	if len(events) != 0 {
+		t.Error("sub response should be ignored", events)
+	}
+}
+
+func TestWsMarketStream(t *testing.T) {
+	s := NewWsMarketStream(cex.PairTypeSpot)
+	s.Start(context.TODO())
+	props.PanicIfNotNil(s.SubBookTicker("BTCUSDT"))
+	props.PanicIfNotNil(s.SubKline(KlineInterval1m, "BTCUSDT"))
+	for i := 0; i < 10; i++ {
+		e := <-s.Events()
+		props.PanicIfNotNil(e.Err)
+		props.PrintlnIndent(e.Data)
+	}
+}
@@ -0,0 +1,41 @@
+package bnc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dwdwow/cex"
+)
+
+// WsOrderUpdateMsgHandler returns handler of spot or usd-m futures user data stream messages,
+// which publishes orders of executionReport and ORDER_TRADE_UPDATE events into hub.
+// Messages of other events are ignored.
+// WaitOrder of user is driven by the stream, if hub is set as update source, ex.
+//
+//	hub := cex.NewOrderUpdateHub(64)
+//	user := NewUser(key, secret, UserOptWaitOrder(cex.WaitOrderOptUpdateSource(hub)))
+//	handle := WsOrderUpdateMsgHandler(hub)
+//	// call handle with every message of user data stream
+func WsOrderUpdateMsgHandler(hub *cex.OrderUpdateHub) func(data []byte) error {
+	return func(data []byte) error {
+		var event WsUserDataEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("bnc: unmarshal user data event, %w", err)
+		}
+		switch event.EventType {
+		case WsExecutionReport:
+			var report WsExecutionReportStream
+			if err := json.Unmarshal(data, &report); err != nil {
+				return fmt.Errorf("bnc: unmarshal %v, %w", event.EventType, err)
+			}
+			hub.Publish(report.CexOrder())
+		case WsOrderTradeUpdate:
+			var update WsFuturesOrderTradeUpdateStream
+			if err := json.Unmarshal(data, &update); err != nil {
+				return fmt.Errorf("bnc: unmarshal %v, %w", event.EventType, err)
+			}
+			hub.Publish(update.CexOrder())
+		}
+		return nil
+	}
+}
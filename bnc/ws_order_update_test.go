@@ -0,0 +1,65 @@
+package bnc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+func TestWsOrderUpdateMsgHandler(t *testing.T) {
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := `{"symbol":"ETHUSDT","orderId":1,"clientOrderId":"c1","status":"NEW","type":"LIMIT","side":"BUY","origQty":"1","price":"1800"}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	hub := cex.NewOrderUpdateHub(8)
+	user := NewUser("key", "secret", UserOptTransport(transport),
+		UserOptWaitOrder(cex.WaitOrderOptPollInterval(time.Hour), cex.WaitOrderOptUpdateSource(hub)))
+	handle := WsOrderUpdateMsgHandler(hub)
+
+	msgs := map[cex.PairType]string{
+		cex.PairTypeSpot: `{"e":"executionReport","E":1,"s":"ETHUSDT","c":"c1","S":"BUY","o":"LIMIT","q":"1","p":"1800","x":"TRADE","X":"FILLED",` +
+			`"i":1,"l":"1","z":"1","L":"1800","n":"0.001","N":"BNB","T":2,"t":9,"m":true,"Z":"1800","Y":"1800"}`,
+		cex.PairTypeFutures: `{"e":"ORDER_TRADE_UPDATE","E":1,"T":2,"o":{"s":"ETHUSDT","c":"c1","S":"BUY","o":"LIMIT","q":"1","p":"1800","ap":"1800",` +
+			`"x":"TRADE","X":"FILLED","i":1,"l":"1","z":"1","L":"1800","T":2,"t":9}}`,
+	}
+	for pairType, msg := range msgs {
+		ord := &cex.Order{Cex: cex.BINANCE, PairType: pairType, Symbol: "ETHUSDT", OrderId: "1", ClientOrderId: "c1"}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		done := user.WaitOrder(ctx, ord)
+		// order is published until waiting subscribes the hub
+		ticker := time.NewTicker(10 * time.Millisecond)
+	publishing:
+		for {
+			select {
+			case err := <-done:
+				if err.IsNotNil() {
+					t.Error(pairType, "order should be finished by stream before poll interval", err.Error())
+				}
+				break publishing
+			case <-ticker.C:
+				if err := handle([]byte(msg)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		ticker.Stop()
+		cancel()
+		if ord.Status != cex.OrderStatusFilled || ord.FilledQty != 1 || ord.FilledAvgPrice != 1800 {
+			t.Error(pairType, "order should be updated by stream", ord.Status, ord.FilledQty, ord.FilledAvgPrice)
+		}
+	}
+
+	if err := handle([]byte(`{"e":"outboundAccountPosition","E":1}`)); err != nil {
+		t.Error("other events should be ignored", err)
+	}
+}
@@ -0,0 +1,483 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dwdwow/cex"
+)
+
+// ErrSlowConsumer is returned by WsMarketSubscription.Err,
+// if subscription is closed by SlowConsumerClose policy.
+var ErrSlowConsumer = errors.New("bnc: ws subscription is closed, because consumer is too slow")
+
+// SlowConsumerPolicy decides what WsMarketPublisher does,
+// if buffer of a subscription is full.
+// Latency-sensitive consumers, who only care about latest events,
+// should choose SlowConsumerDropOldest or SlowConsumerRing.
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerBlock waits until event is received,
+	// so all subscribers are delayed by the slowest one.
+	SlowConsumerBlock SlowConsumerPolicy = "BLOCK"
+	// SlowConsumerDrop drops the new event for the slow subscription only.
+	SlowConsumerDrop SlowConsumerPolicy = "DROP"
+	// SlowConsumerDropOldest drops the oldest buffered event of channel to make room for the new one.
+	SlowConsumerDropOldest SlowConsumerPolicy = "DROP_OLDEST"
+	// SlowConsumerRing keeps latest events in a ring buffer, which overwrites the oldest event if it is full.
+	// Events are moved from ring buffer to an unbuffered channel by a goroutine,
+	// so publisher never competes with consumer for channel,
+	// and one more event may be held by the goroutine.
+	SlowConsumerRing SlowConsumerPolicy = "RING"
+	// SlowConsumerClose closes the slow subscription.
+	SlowConsumerClose SlowConsumerPolicy = "CLOSE"
+)
+
+const defaultWsSubscriptionBufSize = 100
+
+type wsSubscriptionConfig struct {
+	bufSize int
+	policy  SlowConsumerPolicy
+}
+
+type WsSubscriptionOpt func(*wsSubscriptionConfig)
+
+// WsSubscriptionOptBufSize sets event buffer size of subscription, default 100.
+func WsSubscriptionOptBufSize(size int) WsSubscriptionOpt {
+	return func(c *wsSubscriptionConfig) {
+		c.bufSize = size
+	}
+}
+
+// WsSubscriptionOptSlowConsumerPolicy sets slow consumer policy, default SlowConsumerDrop.
+func WsSubscriptionOptSlowConsumerPolicy(policy SlowConsumerPolicy) WsSubscriptionOpt {
+	return func(c *wsSubscriptionConfig) {
+		c.policy = policy
+	}
+}
+
+// wsMarketSource is implemented by WsMarketStream.
+type wsMarketSource interface {
+	Events() <-chan cex.WsStreamEvent[WsMarketEvent]
+	Sub(topics ...string) error
+	Unsub(topics ...string) error
+}
+
+// WsMarketPublisher fans out one market data stream to many subscribers,
+// so strategies subscribing the same topic share one ws subscription.
+// Topic is subscribed when the first subscriber comes,
+// and unsubscribed when the last subscriber leaves.
+// Stream errors are delivered to all subscribers.
+//
+//	p := NewWsMarketPublisher(cex.PairTypeSpot)
+//	p.Start(ctx)
+//	sub, err := p.SubBookTicker("BTCUSDT")
+//	for e := range sub.Events() {...}
+type WsMarketPublisher struct {
+	source wsMarketSource
+	start  func(ctx context.Context)
+
+	mux  sync.Mutex
+	subs map[string]map[*WsMarketSubscription]bool
+
+	// dropped events of all subscriptions, including closed ones
+	dropped atomic.Int64
+}
+
+func NewWsMarketPublisher(pairType cex.PairType, opts ...cex.WsStreamOpt) *WsMarketPublisher {
+	stream := NewWsMarketStream(pairType, opts...)
+	return newWsMarketPublisher(stream, stream.Start)
+}
+
+// NewWsCombinedMarketPublisher creates WsMarketPublisher over combined streams.
+func NewWsCombinedMarketPublisher(pairType cex.PairType, opts ...cex.WsStreamOpt) *WsMarketPublisher {
+	stream := NewWsCombinedMarketStream(pairType, opts...)
+	return newWsMarketPublisher(stream, stream.Start)
+}
+
+func newWsMarketPublisher(source wsMarketSource, start func(ctx context.Context)) *WsMarketPublisher {
+	return &WsMarketPublisher{
+		source: source,
+		start:  start,
+		subs:   map[string]map[*WsMarketSubscription]bool{},
+	}
+}
+
+// Start starts stream and fan-out until ctx is done.
+// Should be called before subscribing.
+// All subscriptions are closed after ctx is done.
+func (p *WsMarketPublisher) Start(ctx context.Context) {
+	if p.start != nil {
+		p.start(ctx)
+	}
+	go p.dispatch(ctx)
+}
+
+// Subscribe subscribes raw stream topic, ex. btcusdt@bookTicker.
+func (p *WsMarketPublisher) Subscribe(topic string, opts ...WsSubscriptionOpt) (*WsMarketSubscription, error) {
+	cfg := wsSubscriptionConfig{bufSize: defaultWsSubscriptionBufSize, policy: SlowConsumerDrop}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if len(p.subs[topic]) == 0 {
+		if err := p.source.Sub(topic); err != nil {
+			return nil, err
+		}
+		p.subs[topic] = map[*WsMarketSubscription]bool{}
+	}
+	sub := newWsMarketSubscription(p, topic, cfg)
+	p.subs[topic][sub] = true
+	return sub, nil
+}
+
+func (p *WsMarketPublisher) SubTrade(symbol string, opts ...WsSubscriptionOpt) (*WsMarketSubscription, error) {
+	return p.Subscribe(CreateTradeTopic(symbol), opts...)
+}
+
+func (p *WsMarketPublisher) SubAggTrade(symbol string, opts ...WsSubscriptionOpt) (*WsMarketSubscription, error) {
+	return p.Subscribe(CreateAggTradeTopic(symbol), opts...)
+}
+
+func (p *WsMarketPublisher) SubBookTicker(symbol string, opts ...WsSubscriptionOpt) (*WsMarketSubscription, error) {
+	return p.Subscribe(CreateBookTickerTopic(symbol), opts...)
+}
+
+// SubForceOrder subscribes liquidation orders of futures symbol.
+func (p *WsMarketPublisher) SubForceOrder(symbol string, opts ...WsSubscriptionOpt) (*WsMarketSubscription, error) {
+	return p.Subscribe(CreateForceOrderTopic(symbol), opts...)
+}
+
+// SubAllForceOrders subscribes liquidation orders of all futures symbols.
+// If topics of symbols are subscribed by the same publisher too,
+// binance pushes their events by both streams, and subscribers of either topic receive them twice.
+func (p *WsMarketPublisher) SubAllForceOrders(opts ...WsSubscriptionOpt) (*WsMarketSubscription, error) {
+	return p.Subscribe(AllForceOrderTopic, opts...)
+}
+
+func (p *WsMarketPublisher) SubKline(symbol string, interval KlineInterval, opts ...WsSubscriptionOpt) (*WsMarketSubscription, error) {
+	return p.Subscribe(CreateKlineTopic(symbol, interval), opts...)
+}
+
+// Subscribers returns subscription number of topic.
+func (p *WsMarketPublisher) Subscribers(topic string) int {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return len(p.subs[topic])
+}
+
+// Dropped returns number of events dropped by all subscriptions, including closed ones.
+func (p *WsMarketPublisher) Dropped() int64 {
+	return p.dropped.Load()
+}
+
+// Stats returns delivery metrics of all active subscriptions.
+func (p *WsMarketPublisher) Stats() []WsSubscriptionStat {
+	var stats []WsSubscriptionStat
+	for _, sub := range p.snapshot("", true) {
+		stats = append(stats, sub.Stat())
+	}
+	return stats
+}
+
+// remove removes sub, and unsubscribes topic if sub is the last subscriber.
+func (p *WsMarketPublisher) remove(sub *WsMarketSubscription) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	subs := p.subs[sub.topic]
+	if !subs[sub] {
+		return nil
+	}
+	delete(subs, sub)
+	if len(subs) > 0 {
+		return nil
+	}
+	delete(p.subs, sub.topic)
+	return p.source.Unsub(sub.topic)
+}
+
+// snapshot returns subscriptions of topic, or of all topics if all is true.
+func (p *WsMarketPublisher) snapshot(topic string, all bool) []*WsMarketSubscription {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	var subs []*WsMarketSubscription
+	for t, topicSubs := range p.subs {
+		if !all && t != topic {
+			continue
+		}
+		for sub := range topicSubs {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+func (p *WsMarketPublisher) dispatch(ctx context.Context) {
+	events := p.source.Events()
+	for {
+		select {
+		case <-ctx.Done():
+			p.closeAll(ctx.Err())
+			return
+		case e := <-events:
+			var subs []*WsMarketSubscription
+			if e.Err != nil {
+				subs = p.snapshot("", true)
+			} else {
+				subs = p.snapshot(e.Data.Topic(), false)
+				if topic := e.Data.allMarketTopic(); topic != "" {
+					subs = append(subs, p.snapshot(topic, false)...)
+				}
+			}
+			for _, sub := range subs {
+				if !sub.deliver(ctx, e) {
+					_ = sub.closeWithErr(ErrSlowConsumer)
+				}
+			}
+		}
+	}
+}
+
+func (p *WsMarketPublisher) closeAll(err error) {
+	p.mux.Lock()
+	var subs []*WsMarketSubscription
+	for _, topicSubs := range p.subs {
+		for sub := range topicSubs {
+			subs = append(subs, sub)
+		}
+	}
+	p.subs = map[string]map[*WsMarketSubscription]bool{}
+	p.mux.Unlock()
+	for _, sub := range subs {
+		_ = sub.closeWithErr(err)
+	}
+}
+
+// WsSubscriptionStat is delivery metrics of subscription.
+type WsSubscriptionStat struct {
+	Topic  string             `json:"topic"`
+	Policy SlowConsumerPolicy `json:"policy"`
+	// Buffered is count of events waiting to be received.
+	Buffered int `json:"buffered"`
+	// Published is count of all events published to subscription.
+	Published int64 `json:"published"`
+	// Dropped is count of events dropped by slow consumer policy.
+	Dropped int64 `json:"dropped"`
+}
+
+// WsMarketSubscription receives events of one topic from WsMarketPublisher.
+type WsMarketSubscription struct {
+	pub       *WsMarketPublisher
+	topic     string
+	policy    SlowConsumerPolicy
+	ch        chan cex.WsStreamEvent[WsMarketEvent]
+	done      chan struct{}
+	published atomic.Int64
+	dropped   atomic.Int64
+
+	// only used by SlowConsumerRing
+	ring   *wsEventRing
+	notify chan struct{}
+
+	once   sync.Once
+	mux    sync.Mutex
+	closed bool
+
+	errMux sync.Mutex
+	err    error
+}
+
+func newWsMarketSubscription(pub *WsMarketPublisher, topic string, cfg wsSubscriptionConfig) *WsMarketSubscription {
+	sub := &WsMarketSubscription{
+		pub:    pub,
+		topic:  topic,
+		policy: cfg.policy,
+		done:   make(chan struct{}),
+	}
+	if cfg.policy != SlowConsumerRing {
+		sub.ch = make(chan cex.WsStreamEvent[WsMarketEvent], cfg.bufSize)
+		return sub
+	}
+	sub.ch = make(chan cex.WsStreamEvent[WsMarketEvent])
+	sub.ring = newWsEventRing(max(cfg.bufSize, 1))
+	sub.notify = make(chan struct{}, 1)
+	go sub.pump()
+	return sub
+}
+
+func (s *WsMarketSubscription) Topic() string {
+	return s.topic
+}
+
+// Events returns event channel, which is closed after subscription is closed.
+func (s *WsMarketSubscription) Events() <-chan cex.WsStreamEvent[WsMarketEvent] {
+	return s.ch
+}
+
+// Dropped returns number of events dropped by slow consumer policy.
+func (s *WsMarketSubscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+func (s *WsMarketSubscription) Stat() WsSubscriptionStat {
+	buffered := len(s.ch)
+	if s.ring != nil {
+		s.mux.Lock()
+		buffered = s.ring.len
+		s.mux.Unlock()
+	}
+	return WsSubscriptionStat{
+		Topic:     s.topic,
+		Policy:    s.policy,
+		Buffered:  buffered,
+		Published: s.published.Load(),
+		Dropped:   s.dropped.Load(),
+	}
+}
+
+// Err returns why subscription is closed by publisher,
+// ErrSlowConsumer or error of publisher ctx.
+func (s *WsMarketSubscription) Err() error {
+	s.errMux.Lock()
+	defer s.errMux.Unlock()
+	return s.err
+}
+
+// Close closes subscription, and unsubscribes topic if it is the last subscriber.
+func (s *WsMarketSubscription) Close() error {
+	return s.closeWithErr(nil)
+}
+
+func (s *WsMarketSubscription) closeWithErr(err error) error {
+	var unsubErr error
+	s.once.Do(func() {
+		unsubErr = s.pub.remove(s)
+		// set err before channel is closed, so consumer can get it after channel is closed
+		s.errMux.Lock()
+		s.err = err
+		s.errMux.Unlock()
+		// close done firstly, so blocked deliver returns and releases mux
+		close(s.done)
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		s.closed = true
+		if s.ring == nil {
+			// channel of ring subscription is closed by pump
+			close(s.ch)
+		}
+	})
+	return unsubErr
+}
+
+func (s *WsMarketSubscription) drop() {
+	s.dropped.Add(1)
+	s.pub.dropped.Add(1)
+}
+
+// deliver returns false, if subscription should be closed by slow consumer policy.
+func (s *WsMarketSubscription) deliver(ctx context.Context, e cex.WsStreamEvent[WsMarketEvent]) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.closed {
+		return true
+	}
+	s.published.Add(1)
+	switch s.policy {
+	case SlowConsumerBlock:
+		select {
+		case s.ch <- e:
+		case <-s.done:
+		case <-ctx.Done():
+		}
+		return true
+	case SlowConsumerRing:
+		if s.ring.push(e) {
+			s.drop()
+		}
+		select {
+		case s.notify <- struct{}{}:
+		default:
+		}
+		return true
+	}
+	select {
+	case s.ch <- e:
+		return true
+	default:
+	}
+	if s.policy == SlowConsumerDropOldest {
+		// deliver is the only sender, so there must be room after receiving,
+		// unless channel is unbuffered.
+		select {
+		case <-s.ch:
+		default:
+		}
+		select {
+		case s.ch <- e:
+		default:
+		}
+	}
+	s.drop()
+	return s.policy != SlowConsumerClose
+}
+
+// pump moves events from ring buffer to channel until subscription is closed.
+func (s *WsMarketSubscription) pump() {
+	defer close(s.ch)
+	for {
+		s.mux.Lock()
+		e, ok := s.ring.pop()
+		s.mux.Unlock()
+		if !ok {
+			select {
+			case <-s.notify:
+				continue
+			case <-s.done:
+				return
+			}
+		}
+		select {
+		case s.ch <- e:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// wsEventRing is fixed size fifo, which overwrites the oldest event if it is full.
+type wsEventRing struct {
+	events []cex.WsStreamEvent[WsMarketEvent]
+	head   int
+	len    int
+}
+
+func newWsEventRing(size int) *wsEventRing {
+	return &wsEventRing{events: make([]cex.WsStreamEvent[WsMarketEvent], size)}
+}
+
+// push returns true if the oldest event is overwritten.
+func (r *wsEventRing) push(e cex.WsStreamEvent[WsMarketEvent]) (overwritten bool) {
+	size := len(r.events)
+	r.events[(r.head+r.len)%size] = e
+	if r.len < size {
+		r.len++
+		return false
+	}
+	r.head = (r.head + 1) % size
+	return true
+}
+
+func (r *wsEventRing) pop() (e cex.WsStreamEvent[WsMarketEvent], ok bool) {
+	if r.len == 0 {
+		return
+	}
+	e = r.events[r.head]
+	r.events[r.head] = cex.WsStreamEvent[WsMarketEvent]{}
+	r.head = (r.head + 1) % len(r.events)
+	r.len--
+	return e, true
+}
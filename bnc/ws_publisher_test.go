@@ -0,0 +1,148 @@
+package bnc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+type testWsMarketSource struct {
+	events chan cex.WsStreamEvent[WsMarketEvent]
+	subs   []string
+	unsubs []string
+}
+
+func (s *testWsMarketSource) Events() <-chan cex.WsStreamEvent[WsMarketEvent] {
+	return s.events
+}
+
+func (s *testWsMarketSource) Sub(topics ...string) error {
+	s.subs = append(s.subs, topics...)
+	return nil
+}
+
+func (s *testWsMarketSource) Unsub(topics ...string) error {
+	s.unsubs = append(s.unsubs, topics...)
+	return nil
+}
+
+func testBookTickerEvent(symbol string, updateId int64) cex.WsStreamEvent[WsMarketEvent] {
+	return cex.WsStreamEvent[WsMarketEvent]{Data: WsMarketEvent{Event: WsBookTicker, BookTicker: &WsBookTickerStream{Symbol: symbol, UpdateId: updateId}}}
+}
+
+func TestWsMarketPublisher(t *testing.T) {
+	source := &testWsMarketSource{events: make(chan cex.WsStreamEvent[WsMarketEvent])}
+	p := newWsMarketPublisher(source, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.Start(ctx)
+
+	fast, _ := p.SubBookTicker("BTCUSDT", WsSubscriptionOptBufSize(10))
+	slow, _ := p.SubBookTicker("BTCUSDT", WsSubscriptionOptBufSize(1))
+	closing, _ := p.SubBookTicker("BTCUSDT", WsSubscriptionOptBufSize(1), WsSubscriptionOptSlowConsumerPolicy(SlowConsumerClose))
+	other, _ := p.SubTrade("ETHUSDT")
+	if len(source.subs) != 2 || p.Subscribers(CreateBookTickerTopic("BTCUSDT")) != 3 {
+		t.Fatal("topic should be subscribed once", source.subs)
+	}
+
+	for i := range 3 {
+		source.events <- testBookTickerEvent("BTCUSDT", int64(i))
+	}
+	source.events <- cex.WsStreamEvent[WsMarketEvent]{Err: errors.New("reconnect")}
+	// wait until the last event is dispatched
+	source.events <- testBookTickerEvent("ETHUSDT", 0)
+
+	if len(fast.Events()) != 4 || (<-fast.Events()).Data.BookTicker.UpdateId != 0 {
+		t.Error("fast subscriber should receive all events")
+	}
+	if slow.Dropped() != 3 || (<-slow.Events()).Data.BookTicker.UpdateId != 0 {
+		t.Error("slow subscriber should drop new events", slow.Dropped())
+	}
+	<-closing.Events()
+	if _, ok := <-closing.Events(); ok || !errors.Is(closing.Err(), ErrSlowConsumer) {
+		t.Error("slow subscription should be closed", closing.Err())
+	}
+	if e := <-other.Events(); e.Err == nil {
+		t.Error("error should be delivered to all subscribers")
+	}
+
+	if err := fast.Close(); err != nil || len(source.unsubs) != 0 {
+		t.Error("topic should not be unsubscribed, if it has subscribers", source.unsubs)
+	}
+	_ = slow.Close()
+	if len(source.unsubs) != 1 || source.unsubs[0] != CreateBookTickerTopic("BTCUSDT") {
+		t.Error("topic should be unsubscribed by the last subscriber", source.unsubs)
+	}
+
+	cancel()
+	select {
+	case <-other.Events():
+	case <-time.After(time.Second):
+		t.Fatal("subscriptions should be closed after publisher is stopped")
+	}
+	if _, ok := <-other.Events(); ok || !errors.Is(other.Err(), context.Canceled) {
+		t.Error("subscription should be closed", other.Err())
+	}
+}
+
+func TestWsMarketPublisher_SlowConsumerPolicy(t *testing.T) {
+	source := &testWsMarketSource{events: make(chan cex.WsStreamEvent[WsMarketEvent])}
+	p := newWsMarketPublisher(source, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.Start(ctx)
+
+	dropOldest, _ := p.SubBookTicker("BTCUSDT", WsSubscriptionOptBufSize(2), WsSubscriptionOptSlowConsumerPolicy(SlowConsumerDropOldest))
+	ring, _ := p.SubBookTicker("BTCUSDT", WsSubscriptionOptBufSize(2), WsSubscriptionOptSlowConsumerPolicy(SlowConsumerRing))
+	for i := range 5 {
+		source.events <- testBookTickerEvent("BTCUSDT", int64(i))
+	}
+	// wait until the last event is dispatched
+	source.events <- testBookTickerEvent("ETHUSDT", 0)
+
+	for sub, n := range map[*WsMarketSubscription]int{dropOldest: 2, ring: 3} {
+		var updateIds []int64
+		for len(updateIds) == 0 || updateIds[len(updateIds)-1] != 4 {
+			updateIds = append(updateIds, (<-sub.Events()).Data.BookTicker.UpdateId)
+		}
+		// pump of ring subscription may hold one more event
+		if len(updateIds) > n || updateIds[len(updateIds)-2] != 3 {
+			t.Error(sub.policy, "should keep latest events", updateIds)
+		}
+		stat := sub.Stat()
+		if stat.Published != 5 || stat.Dropped != int64(5-len(updateIds)) || stat.Buffered != 0 {
+			t.Error(sub.policy, "wrong stat", stat)
+		}
+	}
+	dropped := dropOldest.Dropped() + ring.Dropped()
+	if p.Dropped() != dropped || len(p.Stats()) != 2 {
+		t.Error("wrong publisher stats", p.Dropped(), p.Stats())
+	}
+
+	_ = ring.Close()
+	if _, ok := <-ring.Events(); ok || p.Dropped() != dropped {
+		t.Error("ring subscription should be closed")
+	}
+}
+
+func TestWsMarketPublisher_SubAllForceOrders(t *testing.T) {
+	source := &testWsMarketSource{events: make(chan cex.WsStreamEvent[WsMarketEvent])}
+	p := newWsMarketPublisher(source, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.Start(ctx)
+
+	all, _ := p.SubAllForceOrders()
+	btc, _ := p.SubForceOrder("BTCUSDT")
+	for _, symbol := range []string{"BTCUSDT", "ETHUSDT"} {
+		source.events <- cex.WsStreamEvent[WsMarketEvent]{Data: WsMarketEvent{Event: WsForceOrder, ForceOrder: &WsForceOrderStream{Order: WsLiquidationOrder{Symbol: symbol}}}}
+	}
+	// wait until the last event is dispatched
+	source.events <- testBookTickerEvent("ETHUSDT", 0)
+	if len(all.Events()) != 2 || len(btc.Events()) != 1 {
+		t.Error("liquidation orders of all symbols should be delivered to all market subscribers", len(all.Events()), len(btc.Events()))
+	}
+}
@@ -1,8 +1,20 @@
 package bnc
 
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/dwdwow/cex"
+)
+
 const (
 	WsBaseUrl       = "wss://stream.binance.com:9443/ws"
 	FutureWsBaseUrl = "wss://fstream.binance.com/ws"
+
+	// combined stream base urls, streams are subscribed by SUBSCRIBE msgs after connecting
+	WsCombinedBaseUrl       = "wss://stream.binance.com:9443/stream"
+	FutureWsCombinedBaseUrl = "wss://fstream.binance.com/stream"
 )
 
 type WsMethod string
@@ -19,6 +31,9 @@ const (
 	WsEDepthUpdate                  WsEvent = "depthUpdate"
 	WsTrade                         WsEvent = "trade"
 	WsAggTrade                      WsEvent = "aggTrade"
+	WsKline                         WsEvent = "kline"
+	WsBookTicker                    WsEvent = "bookTicker"
+	WsForceOrder                    WsEvent = "forceOrder"
 	WsMarginCall                    WsEvent = "MARGIN_CALL"
 	WsAccountUpdate                 WsEvent = "ACCOUNT_UPDATE"
 	WsOrderTradeUpdate              WsEvent = "ORDER_TRADE_UPDATE"
@@ -26,6 +41,11 @@ const (
 	WsStrategyUpdate                WsEvent = "STRATEGY_UPDATE"
 	WsGridUpdate                    WsEvent = "GRID_UPDATE"
 	WsConditionalOrderTriggerReject WsEvent = "CONDITIONAL_ORDER_TRIGGER_REJECT"
+
+	// spot user data stream
+	WsOutboundAccountPosition WsEvent = "outboundAccountPosition"
+	WsBalanceUpdate           WsEvent = "balanceUpdate"
+	WsExecutionReport         WsEvent = "executionReport"
 )
 
 type WsSubMsg struct {
@@ -34,6 +54,12 @@ type WsSubMsg struct {
 	Id     int64    `json:"id"`
 }
 
+// WsCombinedStreamMsg wraps raw stream data in combined streams.
+type WsCombinedStreamMsg struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
 type WsDepthMsg struct {
 	EventType WsEvent    `json:"e"`
 	EventTime int64      `json:"E"`
@@ -59,6 +85,9 @@ type WsTradeStream struct {
 	SellerOrderID int64   `json:"a"`
 	TradeTime     int64   `json:"T"`
 	IsBuyerMaker  bool    `json:"m"`
+	// Ignore is "M" field of spot trade, must be declared,
+	// or json will unmarshal it into IsBuyerMaker case-insensitively.
+	Ignore bool `json:"M"`
 }
 
 type WsFuAggTradeStream struct {
@@ -73,3 +102,374 @@ type WsFuAggTradeStream struct {
 	TradeTime    int64   `json:"T"`
 	IsBuyerMaker bool    `json:"m"`
 }
+
+// WsAggTradeStream is spot aggTrade stream.
+type WsAggTradeStream struct {
+	EventType    WsEvent `json:"e"`
+	EventTime    int64   `json:"E"`
+	Symbol       string  `json:"s"`
+	AggID        int64   `json:"a"`
+	Price        float64 `json:"p,string"`
+	Quantity     float64 `json:"q,string"`
+	FirstTradeId int64   `json:"f"`
+	LastTradeId  int64   `json:"l"`
+	TradeTime    int64   `json:"T"`
+	IsBuyerMaker bool    `json:"m"`
+	// Ignore is "M" field, must be declared,
+	// or json will unmarshal it into IsBuyerMaker case-insensitively.
+	Ignore bool `json:"M"`
+}
+
+type WsKlineData struct {
+	StartTime        int64         `json:"t"`
+	CloseTime        int64         `json:"T"`
+	Symbol           string        `json:"s"`
+	Interval         KlineInterval `json:"i"`
+	FirstTradeId     int64         `json:"f"`
+	LastTradeId      int64         `json:"L"`
+	OpenPrice        float64       `json:"o,string"`
+	ClosePrice       float64       `json:"c,string"`
+	HighPrice        float64       `json:"h,string"`
+	LowPrice         float64       `json:"l,string"`
+	Volume           float64       `json:"v,string"`
+	TradeNum         int64         `json:"n"`
+	IsClosed         bool          `json:"x"`
+	QuoteVolume      float64       `json:"q,string"`
+	TakerBuyVolume   float64       `json:"V,string"`
+	TakerBuyQuoteVol float64       `json:"Q,string"`
+}
+
+type WsKlineStream struct {
+	EventType WsEvent     `json:"e"`
+	EventTime int64       `json:"E"`
+	Symbol    string      `json:"s"`
+	Kline     WsKlineData `json:"k"`
+}
+
+// WsBookTickerStream
+// Spot bookTicker stream does not contain event type, event time and tx time.
+type WsBookTickerStream struct {
+	EventType WsEvent `json:"e"`
+	UpdateId  int64   `json:"u"`
+	EventTime int64   `json:"E"`
+	TxTime    int64   `json:"T"`
+	Symbol    string  `json:"s"`
+	BidPrice  float64 `json:"b,string"`
+	BidQty    float64 `json:"B,string"`
+	AskPrice  float64 `json:"a,string"`
+	AskQty    float64 `json:"A,string"`
+}
+
+// WsForceOrderStream is futures liquidation order event.
+// Only the latest liquidation order of symbol in every 1000ms is pushed.
+type WsForceOrderStream struct {
+	EventType WsEvent            `json:"e"`
+	EventTime int64              `json:"E"`
+	Order     WsLiquidationOrder `json:"o"`
+}
+
+type WsLiquidationOrder struct {
+	Symbol          string      `json:"s"`
+	Side            OrderSide   `json:"S"`
+	Type            OrderType   `json:"o"`
+	TimeInForce     TimeInForce `json:"f"`
+	OrigQty         float64     `json:"q,string"`
+	Price           float64     `json:"p,string"`
+	AvgPrice        float64     `json:"ap,string"`
+	Status          OrderStatus `json:"X"`
+	LastFilledQty   float64     `json:"l,string"`
+	FilledQty       float64     `json:"z,string"`
+	TransactionTime int64       `json:"T"`
+}
+
+type WsUserDataEvent struct {
+	EventType WsEvent `json:"e"`
+	EventTime int64   `json:"E"`
+}
+
+type WsAccountPositionBalance struct {
+	Asset  string  `json:"a"`
+	Free   float64 `json:"f,string"`
+	Locked float64 `json:"l,string"`
+}
+
+// WsAccountPosition is spot outboundAccountPosition event,
+// which contains balances changed by the event only.
+type WsAccountPosition struct {
+	EventType      WsEvent                    `json:"e"`
+	EventTime      int64                      `json:"E"`
+	LastUpdateTime int64                      `json:"u"`
+	Balances       []WsAccountPositionBalance `json:"B"`
+}
+
+// WsBalanceUpdateStream is spot balanceUpdate event of deposits, withdrawals and transfers.
+type WsBalanceUpdateStream struct {
+	EventType    WsEvent `json:"e"`
+	EventTime    int64   `json:"E"`
+	Asset        string  `json:"a"`
+	BalanceDelta float64 `json:"d,string"`
+	ClearTime    int64   `json:"T"`
+}
+
+// WsExecutionReportStream is spot executionReport event.
+type WsExecutionReportStream struct {
+	EventType               WsEvent                 `json:"e"`
+	EventTime               int64                   `json:"E"`
+	Symbol                  string                  `json:"s"`
+	ClientOrderId           string                  `json:"c"`
+	Side                    OrderSide               `json:"S"`
+	Type                    OrderType               `json:"o"`
+	TimeInForce             TimeInForce             `json:"f"`
+	OrigQty                 float64                 `json:"q,string"`
+	Price                   float64                 `json:"p,string"`
+	StopPrice               float64                 `json:"P,string"`
+	IcebergQty              float64                 `json:"F,string"`
+	OrderListId             int64                   `json:"g"`
+	OrigClientOrderId       string                  `json:"C"` // of canceled order
+	ExecutionType           OrderExecutionType      `json:"x"`
+	Status                  OrderStatus             `json:"X"`
+	RejectReason            string                  `json:"r"`
+	OrderId                 int64                   `json:"i"`
+	LastExecutedQty         float64                 `json:"l,string"`
+	ExecutedQty             float64                 `json:"z,string"`
+	LastExecutedPrice       float64                 `json:"L,string"`
+	Commission              float64                 `json:"n,string"`
+	CommissionAsset         string                  `json:"N"`
+	TransactionTime         int64                   `json:"T"`
+	TradeId                 int64                   `json:"t"`
+	IsWorking               bool                    `json:"w"`
+	IsMaker                 bool                    `json:"m"`
+	CreateTime              int64                   `json:"O"`
+	CummulativeQuoteQty     float64                 `json:"Z,string"`
+	LastQuoteQty            float64                 `json:"Y,string"`
+	OrigQuoteOrderQty       float64                 `json:"Q,string"`
+	WorkingTime             int64                   `json:"W"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"V"`
+	PreventedMatchId        int64                   `json:"v"`
+	// Ignore is "I" field, must be declared,
+	// or json will unmarshal it into OrderId case-insensitively.
+	Ignore int64 `json:"I"`
+	// IgnoreM is "M" field, must be declared,
+	// or json will unmarshal it into IsMaker case-insensitively.
+	IgnoreM bool `json:"M"`
+}
+
+// SpotOrder converts execution report to order,
+// ClientOrderId is the original one, if order is canceled.
+func (r WsExecutionReportStream) SpotOrder() SpotOrder {
+	cltOrdId := r.ClientOrderId
+	if r.OrigClientOrderId != "" {
+		cltOrdId = r.OrigClientOrderId
+	}
+	return SpotOrder{
+		Symbol:                  r.Symbol,
+		OrderId:                 r.OrderId,
+		OrderListId:             r.OrderListId,
+		ClientOrderId:           cltOrdId,
+		Price:                   r.Price,
+		OrigQty:                 r.OrigQty,
+		ExecutedQty:             r.ExecutedQty,
+		CummulativeQuoteQty:     r.CummulativeQuoteQty,
+		Status:                  r.Status,
+		TimeInForce:             r.TimeInForce,
+		Type:                    r.Type,
+		Side:                    r.Side,
+		SelfTradePreventionMode: r.SelfTradePreventionMode,
+		WorkingTime:             r.WorkingTime,
+		StopPrice:               r.StopPrice,
+		IcebergQty:              r.IcebergQty,
+		Time:                    r.CreateTime,
+		UpdateTime:              r.TransactionTime,
+		IsWorking:               r.IsWorking,
+		OrigQuoteOrderQty:       r.OrigQuoteOrderQty,
+	}
+}
+
+// Fill converts trade execution report to fill, ex. to ingest it into cex.PnlTracker.
+// ok is false, if execution type is not TRADE.
+func (r WsExecutionReportStream) Fill() (fill cex.Fill, ok bool) {
+	if r.ExecutionType != OrderExecutionTrade {
+		return
+	}
+	return cex.Fill{
+		TradeId:         strconv.FormatInt(r.TradeId, 10),
+		Price:           r.LastExecutedPrice,
+		Qty:             r.LastExecutedQty,
+		Commission:      r.Commission,
+		CommissionAsset: r.CommissionAsset,
+		IsMaker:         r.IsMaker,
+		Time:            r.TransactionTime,
+		Cex:             cex.BINANCE,
+		PairType:        cex.PairTypeSpot,
+		Symbol:          r.Symbol,
+		OrderId:         strconv.FormatInt(r.OrderId, 10),
+		OrderSide:       mapStrStr(r.Side, cexOrdSideByOrdSide),
+		QuoteQty:        r.LastQuoteQty,
+	}, true
+}
+
+// CexOrder converts execution report to order, ex. to publish it into cex.OrderUpdateHub.
+// Fill of trade execution report is added to order.
+func (r WsExecutionReportStream) CexOrder() cex.Order {
+	ord := SwitchSpotOrderToCexOrder(r.SpotOrder())
+	if fill, ok := r.Fill(); ok {
+		ord.AddFills(fill)
+	}
+	return ord
+}
+
+// WsFuturesMarginCallStream is futures MARGIN_CALL event, which is pushed when positions are at risk of liquidation.
+type WsFuturesMarginCallStream struct {
+	EventType          WsEvent                       `json:"e"`
+	EventTime          int64                         `json:"E"`
+	CrossWalletBalance float64                       `json:"cw,string"` // only pushed with crossed position
+	Positions          []WsFuturesMarginCallPosition `json:"p"`
+}
+
+type WsFuturesMarginCallPosition struct {
+	Symbol                    string              `json:"s"`
+	PositionSide              FuturesPositionSide `json:"ps"`
+	PositionAmt               float64             `json:"pa,string"`
+	MarginType                FuturesMarginType   `json:"mt"`
+	IsolatedWallet            float64             `json:"iw,string"` // if isolated position
+	MarkPrice                 float64             `json:"mp,string"`
+	UnrealizedPnl             float64             `json:"up,string"`
+	MaintenanceMarginRequired float64             `json:"mm,string"`
+}
+
+// WsFuturesAccountUpdateStream is futures ACCOUNT_UPDATE event,
+// which contains balances and positions changed by the event only.
+type WsFuturesAccountUpdateStream struct {
+	EventType       WsEvent                `json:"e"`
+	EventTime       int64                  `json:"E"`
+	TransactionTime int64                  `json:"T"`
+	Update          WsFuturesAccountUpdate `json:"a"`
+}
+
+// IsAdl returns true, if positions are reduced by auto-deleveraging.
+func (s WsFuturesAccountUpdateStream) IsAdl() bool {
+	return s.Update.Reason == FuturesAccountUpdateReasonAdl
+}
+
+type WsFuturesAccountUpdate struct {
+	Reason    FuturesAccountUpdateReason       `json:"m"`
+	Balances  []WsFuturesAccountUpdateBalance  `json:"B"`
+	Positions []WsFuturesAccountUpdatePosition `json:"P"`
+}
+
+type WsFuturesAccountUpdateBalance struct {
+	Asset              string  `json:"a"`
+	WalletBalance      float64 `json:"wb,string"`
+	CrossWalletBalance float64 `json:"cw,string"`
+	BalanceChange      float64 `json:"bc,string"` // except pnl and commission
+}
+
+type WsFuturesAccountUpdatePosition struct {
+	Symbol              string              `json:"s"`
+	PositionAmt         float64             `json:"pa,string"`
+	EntryPrice          float64             `json:"ep,string"`
+	BreakEvenPrice      float64             `json:"bep,string"`
+	AccumulatedRealized float64             `json:"cr,string"` // pre-fee
+	UnrealizedPnl       float64             `json:"up,string"`
+	MarginType          FuturesMarginType   `json:"mt"`
+	IsolatedWallet      float64             `json:"iw,string"`
+	PositionSide        FuturesPositionSide `json:"ps"`
+}
+
+// client order id prefixes of orders placed by binance
+const (
+	futuresLiquidationCltOrdIdPrefix = "autoclose-"
+	futuresAdlCltOrdIdPrefix         = "adl_autoclose"
+)
+
+// WsFuturesOrderTradeUpdateStream is futures ORDER_TRADE_UPDATE event.
+type WsFuturesOrderTradeUpdateStream struct {
+	EventType       WsEvent              `json:"e"`
+	EventTime       int64                `json:"E"`
+	TransactionTime int64                `json:"T"`
+	Order           WsFuturesOrderUpdate `json:"o"`
+}
+
+// IsLiquidation returns true, if order is liquidation order of own account.
+func (s WsFuturesOrderTradeUpdateStream) IsLiquidation() bool {
+	return s.Order.Type == OrderTypeLiquidation || strings.HasPrefix(s.Order.ClientOrderId, futuresLiquidationCltOrdIdPrefix)
+}
+
+// IsAdl returns true, if order is auto-deleveraging order of own account.
+func (s WsFuturesOrderTradeUpdateStream) IsAdl() bool {
+	return strings.HasPrefix(s.Order.ClientOrderId, futuresAdlCltOrdIdPrefix)
+}
+
+// FuturesOrder converts order of event to futures order.
+func (s WsFuturesOrderTradeUpdateStream) FuturesOrder() FuturesOrder {
+	o := s.Order
+	return FuturesOrder{
+		Symbol:                  o.Symbol,
+		OrderId:                 o.OrderId,
+		ClientOrderId:           o.ClientOrderId,
+		Type:                    o.Type,
+		PositionSide:            o.PositionSide,
+		Side:                    o.Side,
+		OrigQty:                 o.OrigQty,
+		Price:                   o.Price,
+		ExecutedQty:             o.FilledQty,
+		AvgPrice:                o.AvgPrice,
+		ReduceOnly:              o.IsReduceOnly,
+		Status:                  o.Status,
+		StopPrice:               o.StopPrice,
+		ClosePosition:           o.ClosePosition,
+		TimeInForce:             o.TimeInForce,
+		OrigType:                o.OrigType,
+		UpdateTime:              s.TransactionTime,
+		WorkingType:             o.WorkingType,
+		PriceProtect:            o.PriceProtect,
+		PriceMatch:              o.PriceMatch,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		GoodTillDate:            o.GoodTillDate,
+		CumQuote:                o.FilledQty * o.AvgPrice,
+		ActivatePrice:           o.ActivationPrice,
+		PriceRate:               o.CallbackRate,
+	}
+}
+
+// CexOrder converts order of event to cex.Order, ex. to publish it into cex.OrderUpdateHub.
+func (s WsFuturesOrderTradeUpdateStream) CexOrder() cex.Order {
+	return SwitchFutureOrderToCexOrder(s.FuturesOrder())
+}
+
+type WsFuturesOrderUpdate struct {
+	Symbol                  string                  `json:"s"`
+	ClientOrderId           string                  `json:"c"`
+	Side                    OrderSide               `json:"S"`
+	Type                    OrderType               `json:"o"`
+	TimeInForce             TimeInForce             `json:"f"`
+	OrigQty                 float64                 `json:"q,string"`
+	Price                   float64                 `json:"p,string"`
+	AvgPrice                float64                 `json:"ap,string"`
+	StopPrice               float64                 `json:"sp,string"`
+	ExecutionType           OrderExecutionType      `json:"x"`
+	Status                  OrderStatus             `json:"X"`
+	OrderId                 int64                   `json:"i"`
+	LastFilledQty           float64                 `json:"l,string"`
+	FilledQty               float64                 `json:"z,string"`
+	LastFilledPrice         float64                 `json:"L,string"`
+	CommissionAsset         string                  `json:"N"`
+	Commission              float64                 `json:"n,string"`
+	TradeTime               int64                   `json:"T"`
+	TradeId                 int64                   `json:"t"`
+	BidsNotional            float64                 `json:"b,string"`
+	AsksNotional            float64                 `json:"a,string"`
+	IsMaker                 bool                    `json:"m"`
+	IsReduceOnly            bool                    `json:"R"`
+	WorkingType             FuturesWorkingType      `json:"wt"`
+	OrigType                OrderType               `json:"ot"`
+	PositionSide            FuturesPositionSide     `json:"ps"`
+	ClosePosition           bool                    `json:"cp"`
+	ActivationPrice         float64                 `json:"AP,string"`
+	CallbackRate            float64                 `json:"cr,string"`
+	PriceProtect            bool                    `json:"pP"`
+	RealizedProfit          float64                 `json:"rp,string"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"V"`
+	PriceMatch              FuturesPriceMatch       `json:"pm"`
+	GoodTillDate            int64                   `json:"gtd"`
+}
@@ -0,0 +1,46 @@
+package bybit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dwdwow/cex"
+)
+
+// RespData is the envelope of all bybit v5 responses.
+type RespData[D any] struct {
+	RetCode    int            `json:"retCode"`
+	RetMsg     string         `json:"retMsg"`
+	Result     D              `json:"result"`
+	RetExtInfo map[string]any `json:"retExtInfo"`
+	Time       int64          `json:"time"`
+}
+
+// List is the result of list endpoints.
+type List[D any] struct {
+	Category       Category `json:"category" bson:"category"`
+	List           []D      `json:"list" bson:"list"`
+	NextPageCursor string   `json:"nextPageCursor" bson:"nextPageCursor"`
+}
+
+// bodyUnmsh unmarshals bybit envelope, and maps retCode and retMsg into RespBodyUnmarshalerError.
+func bodyUnmsh[D any](body []byte) (D, *cex.RespBodyUnmarshalerError) {
+	resp := RespData[D]{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp.Result, &cex.RespBodyUnmarshalerError{
+			Err: fmt.Errorf("bybit: %w: unmarshal response body, %w", cex.ErrJsonUnmarshal, err),
+		}
+	}
+	if resp.RetCode == 0 {
+		return resp.Result, nil
+	}
+	errCtm := cexCustomErrCodes[resp.RetCode]
+	if errCtm == nil {
+		errCtm = fmt.Errorf("%v, %v", resp.RetCode, resp.RetMsg)
+	}
+	return resp.Result, &cex.RespBodyUnmarshalerError{
+		CexErrCode: resp.RetCode,
+		CexErrMsg:  resp.RetMsg,
+		Err:        fmt.Errorf("bybit: %w", errCtm),
+	}
+}
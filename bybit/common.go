@@ -0,0 +1,128 @@
+package bybit
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+const (
+	BaseUrl        = "https://api.bybit.com"
+	TestnetBaseUrl = "https://api-testnet.bybit.com"
+	V5             = "/v5"
+)
+
+const (
+	SymbolMid = ""
+
+	// DefaultRecvWindow is X-BAPI-RECV-WINDOW, unit is millisecond.
+	DefaultRecvWindow = 5000
+)
+
+type Category string
+
+const (
+	CategorySpot    Category = "spot"
+	CategoryLinear  Category = "linear"
+	CategoryInverse Category = "inverse"
+	CategoryOption  Category = "option"
+)
+
+type AccountType string
+
+const (
+	AccountTypeUnified  AccountType = "UNIFIED"
+	AccountTypeContract AccountType = "CONTRACT"
+	AccountTypeSpot     AccountType = "SPOT"
+	AccountTypeFund     AccountType = "FUND"
+)
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "Buy"
+	OrderSideSell OrderSide = "Sell"
+)
+
+type OrderType string
+
+const (
+	OrderTypeLimit  OrderType = "Limit"
+	OrderTypeMarket OrderType = "Market"
+)
+
+type TimeInForce string
+
+const (
+	TimeInForceGtc      TimeInForce = "GTC"
+	TimeInForceIoc      TimeInForce = "IOC"
+	TimeInForceFok      TimeInForce = "FOK"
+	TimeInForcePostOnly TimeInForce = "PostOnly"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusNew                     OrderStatus = "New"
+	OrderStatusPartiallyFilled         OrderStatus = "PartiallyFilled"
+	OrderStatusUntriggered             OrderStatus = "Untriggered"
+	OrderStatusRejected                OrderStatus = "Rejected"
+	OrderStatusPartiallyFilledCanceled OrderStatus = "PartiallyFilledCanceled"
+	OrderStatusFilled                  OrderStatus = "Filled"
+	OrderStatusCancelled               OrderStatus = "Cancelled"
+	OrderStatusTriggered               OrderStatus = "Triggered"
+	OrderStatusDeactivated             OrderStatus = "Deactivated"
+)
+
+// MarketUnit is unit of qty of spot market order.
+// Default of bybit is quoteCoin for market buy order.
+type MarketUnit string
+
+const (
+	MarketUnitBaseCoin  MarketUnit = "baseCoin"
+	MarketUnitQuoteCoin MarketUnit = "quoteCoin"
+)
+
+// PositionIdx
+// 0: one-way mode, 1: hedge-mode buy side, 2: hedge-mode sell side
+type PositionIdx int
+
+const (
+	PositionIdxOneWay    PositionIdx = 0
+	PositionIdxHedgeBuy  PositionIdx = 1
+	PositionIdxHedgeSell PositionIdx = 2
+)
+
+// Num is number responded as string by bybit.
+// bybit responds empty string if number is not available,
+// which can not be unmarshalled by ",string" tag.
+type Num float64
+
+func (n *Num) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var f float64
+		if err := json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		*n = Num(f)
+		return nil
+	}
+	if s == "" {
+		*n = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Num(f)
+	return nil
+}
+
+func (n Num) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatFloat(float64(n), 'f', -1, 64))
+}
+
+func (n Num) Float64() float64 {
+	return float64(n)
+}
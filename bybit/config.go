@@ -0,0 +1,265 @@
+package bybit
+
+import (
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Public
+// ------------------------------------------------------------
+
+type ServerTime struct {
+	TimeSecond string `json:"timeSecond" bson:"timeSecond"`
+	TimeNano   string `json:"timeNano" bson:"timeNano"`
+}
+
+var ServerTimeConfig = cex.ReqConfig[cex.NilReqData, ServerTime]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             V5 + "/market/time",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[ServerTime],
+}
+
+// ------------------------------------------------------------
+// Public
+// ============================================================
+
+// ============================================================
+// Account
+// ------------------------------------------------------------
+
+type WalletBalanceParams struct {
+	AccountType AccountType `s2m:"accountType,omitempty"`
+	// Coin is multiple coins separated by comma, ex. BTC,USDT
+	Coin string `s2m:"coin,omitempty"`
+}
+
+type WalletCoin struct {
+	Coin                string `json:"coin" bson:"coin"`
+	Equity              Num    `json:"equity" bson:"equity"`
+	UsdValue            Num    `json:"usdValue" bson:"usdValue"`
+	WalletBalance       Num    `json:"walletBalance" bson:"walletBalance"`
+	Free                Num    `json:"free" bson:"free"`
+	Locked              Num    `json:"locked" bson:"locked"`
+	AvailableToWithdraw Num    `json:"availableToWithdraw" bson:"availableToWithdraw"`
+	BorrowAmount        Num    `json:"borrowAmount" bson:"borrowAmount"`
+	AccruedInterest     Num    `json:"accruedInterest" bson:"accruedInterest"`
+	TotalOrderIM        Num    `json:"totalOrderIM" bson:"totalOrderIM"`
+	TotalPositionIM     Num    `json:"totalPositionIM" bson:"totalPositionIM"`
+	TotalPositionMM     Num    `json:"totalPositionMM" bson:"totalPositionMM"`
+	UnrealisedPnl       Num    `json:"unrealisedPnl" bson:"unrealisedPnl"`
+	CumRealisedPnl      Num    `json:"cumRealisedPnl" bson:"cumRealisedPnl"`
+	MarginCollateral    bool   `json:"marginCollateral" bson:"marginCollateral"`
+}
+
+type WalletBalance struct {
+	AccountType            AccountType  `json:"accountType" bson:"accountType"`
+	AccountIMRate          Num          `json:"accountIMRate" bson:"accountIMRate"`
+	AccountMMRate          Num          `json:"accountMMRate" bson:"accountMMRate"`
+	TotalEquity            Num          `json:"totalEquity" bson:"totalEquity"`
+	TotalWalletBalance     Num          `json:"totalWalletBalance" bson:"totalWalletBalance"`
+	TotalMarginBalance     Num          `json:"totalMarginBalance" bson:"totalMarginBalance"`
+	TotalAvailableBalance  Num          `json:"totalAvailableBalance" bson:"totalAvailableBalance"`
+	TotalPerpUPL           Num          `json:"totalPerpUPL" bson:"totalPerpUPL"`
+	TotalInitialMargin     Num          `json:"totalInitialMargin" bson:"totalInitialMargin"`
+	TotalMaintenanceMargin Num          `json:"totalMaintenanceMargin" bson:"totalMaintenanceMargin"`
+	Coin                   []WalletCoin `json:"coin" bson:"coin"`
+}
+
+var WalletBalanceConfig = cex.ReqConfig[WalletBalanceParams, List[WalletBalance]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             V5 + "/account/wallet-balance",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 100,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[List[WalletBalance]],
+}
+
+// ------------------------------------------------------------
+// Account
+// ============================================================
+
+// ============================================================
+// Position
+// ------------------------------------------------------------
+
+type PositionsParams struct {
+	Category   Category `s2m:"category,omitempty"`
+	Symbol     string   `s2m:"symbol,omitempty"`
+	BaseCoin   string   `s2m:"baseCoin,omitempty"`
+	SettleCoin string   `s2m:"settleCoin,omitempty"`
+	Limit      int      `s2m:"limit,omitempty"`
+	Cursor     string   `s2m:"cursor,omitempty"`
+}
+
+type Position struct {
+	PositionIdx    PositionIdx `json:"positionIdx" bson:"positionIdx"`
+	Symbol         string      `json:"symbol" bson:"symbol"`
+	Side           OrderSide   `json:"side" bson:"side"`
+	Size           Num         `json:"size" bson:"size"`
+	AvgPrice       Num         `json:"avgPrice" bson:"avgPrice"`
+	PositionValue  Num         `json:"positionValue" bson:"positionValue"`
+	TradeMode      int         `json:"tradeMode" bson:"tradeMode"`
+	Leverage       Num         `json:"leverage" bson:"leverage"`
+	MarkPrice      Num         `json:"markPrice" bson:"markPrice"`
+	LiqPrice       Num         `json:"liqPrice" bson:"liqPrice"`
+	PositionIM     Num         `json:"positionIM" bson:"positionIM"`
+	PositionMM     Num         `json:"positionMM" bson:"positionMM"`
+	UnrealisedPnl  Num         `json:"unrealisedPnl" bson:"unrealisedPnl"`
+	CumRealisedPnl Num         `json:"cumRealisedPnl" bson:"cumRealisedPnl"`
+	CreatedTime    Num         `json:"createdTime" bson:"createdTime"`
+	UpdatedTime    Num         `json:"updatedTime" bson:"updatedTime"`
+}
+
+var PositionsConfig = cex.ReqConfig[PositionsParams, List[Position]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             V5 + "/position/list",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 20,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[List[Position]],
+}
+
+// ------------------------------------------------------------
+// Position
+// ============================================================
+
+// ============================================================
+// Trade
+// ------------------------------------------------------------
+
+// NewOrderParams is sent as json body.
+type NewOrderParams struct {
+	Category    Category    `json:"category,omitempty"`
+	Symbol      string      `json:"symbol,omitempty"`
+	Side        OrderSide   `json:"side,omitempty"`
+	OrderType   OrderType   `json:"orderType,omitempty"`
+	Qty         float64     `json:"qty,string,omitempty"`
+	Price       float64     `json:"price,string,omitempty"`
+	MarketUnit  MarketUnit  `json:"marketUnit,omitempty"`
+	TimeInForce TimeInForce `json:"timeInForce,omitempty"`
+	OrderLinkId string      `json:"orderLinkId,omitempty"`
+	PositionIdx PositionIdx `json:"positionIdx,omitempty"`
+	ReduceOnly  bool        `json:"reduceOnly,omitempty"`
+}
+
+// OrderResult is responded by order place and cancel.
+type OrderResult struct {
+	OrderId     string `json:"orderId" bson:"orderId"`
+	OrderLinkId string `json:"orderLinkId" bson:"orderLinkId"`
+}
+
+var NewOrderConfig = cex.ReqConfig[NewOrderParams, OrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             V5 + "/order/create",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[OrderResult],
+}
+
+type CancelOrderParams struct {
+	Category    Category `json:"category,omitempty"`
+	Symbol      string   `json:"symbol,omitempty"`
+	OrderId     string   `json:"orderId,omitempty"`
+	OrderLinkId string   `json:"orderLinkId,omitempty"`
+}
+
+var CancelOrderConfig = cex.ReqConfig[CancelOrderParams, OrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             V5 + "/order/cancel",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[OrderResult],
+}
+
+type QueryOrderParams struct {
+	Category    Category `s2m:"category,omitempty"`
+	Symbol      string   `s2m:"symbol,omitempty"`
+	OrderId     string   `s2m:"orderId,omitempty"`
+	OrderLinkId string   `s2m:"orderLinkId,omitempty"`
+	Limit       int      `s2m:"limit,omitempty"`
+	Cursor      string   `s2m:"cursor,omitempty"`
+}
+
+type Order struct {
+	OrderId      string      `json:"orderId" bson:"orderId"`
+	OrderLinkId  string      `json:"orderLinkId" bson:"orderLinkId"`
+	Symbol       string      `json:"symbol" bson:"symbol"`
+	Price        Num         `json:"price" bson:"price"`
+	Qty          Num         `json:"qty" bson:"qty"`
+	Side         OrderSide   `json:"side" bson:"side"`
+	PositionIdx  PositionIdx `json:"positionIdx" bson:"positionIdx"`
+	OrderStatus  OrderStatus `json:"orderStatus" bson:"orderStatus"`
+	RejectReason string      `json:"rejectReason" bson:"rejectReason"`
+	AvgPrice     Num         `json:"avgPrice" bson:"avgPrice"`
+	LeavesQty    Num         `json:"leavesQty" bson:"leavesQty"`
+	CumExecQty   Num         `json:"cumExecQty" bson:"cumExecQty"`
+	CumExecValue Num         `json:"cumExecValue" bson:"cumExecValue"`
+	CumExecFee   Num         `json:"cumExecFee" bson:"cumExecFee"`
+	TimeInForce  TimeInForce `json:"timeInForce" bson:"timeInForce"`
+	OrderType    OrderType   `json:"orderType" bson:"orderType"`
+	ReduceOnly   bool        `json:"reduceOnly" bson:"reduceOnly"`
+	MarketUnit   MarketUnit  `json:"marketUnit" bson:"marketUnit"`
+	CreatedTime  Num         `json:"createdTime" bson:"createdTime"`
+	UpdatedTime  Num         `json:"updatedTime" bson:"updatedTime"`
+
+	// Category is not contained in order, but in list.
+	Category Category `json:"category,omitempty" bson:"category,omitempty"`
+}
+
+// OpenOrdersConfig queries open orders and recent closed orders.
+var OpenOrdersConfig = cex.ReqConfig[QueryOrderParams, List[Order]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             V5 + "/order/realtime",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[List[Order]],
+}
+
+var OrderHistoryConfig = cex.ReqConfig[QueryOrderParams, List[Order]]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             V5 + "/order/history",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[List[Order]],
+}
+
+// ------------------------------------------------------------
+// Trade
+// ============================================================
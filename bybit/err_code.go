@@ -0,0 +1,80 @@
+package bybit
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+/**
+HTTP Ref:
+	https://bybit-exchange.github.io/docs/v5/guide
+Error Codes Ref:
+	https://bybit-exchange.github.io/docs/v5/error
+
+All Endpoints:
+	bybit responds {"retCode":0,"retMsg":"OK","result":{},"retExtInfo":{},"time":0},
+	retCode 0 means success.
+	HTTP 403 return code is used when ip is limited by frequency.
+	HTTP 5XX return codes are used for internal errors, the execution status is UNKNOWN.
+*/
+
+// =============================================
+// HTTP Errors
+// ---------------------------------------------
+
+var httpErrCodes = map[int]error{
+	http.StatusBadRequest:      cex.ErrHTTPBadRequest,
+	http.StatusUnauthorized:    cex.ErrHTTPForbidden,
+	http.StatusForbidden:       cex.ErrHTTPTooFrequency,
+	http.StatusNotFound:        cex.ErrHTTPNotFound,
+	http.StatusTooManyRequests: cex.ErrHTTPTooFrequency,
+}
+
+func HTTPStatusCodeChecker(code int) error {
+	if code == 200 {
+		return nil
+	}
+	if code >= 500 {
+		return cex.ErrHTTPCexInnerUnknownStatus
+	}
+	err := httpErrCodes[code]
+	if err != nil {
+		return err
+	}
+	return cex.ErrHTTPCodeNotInEnum
+}
+
+// ---------------------------------------------
+// HTTP Errors
+// =============================================
+
+// =============================================
+// Custom Errors
+// ---------------------------------------------
+
+var (
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrInvalidApiKey    = errors.New("invalid api key")
+	ErrInvalidSign      = errors.New("invalid sign")
+)
+
+var cexCustomErrCodes = map[int]error{
+	10001:  ErrInvalidParameter,
+	10002:  cex.ErrInvalidTimestamp,
+	10003:  ErrInvalidApiKey,
+	10004:  ErrInvalidSign,
+	10006:  cex.ErrHTTPTooFrequency,
+	10016:  cex.ErrHTTPCexInnerUnknownStatus,
+	10018:  cex.ErrHTTPIpBanned,
+	110001: cex.ErrUnknownOrder,
+	110004: cex.ErrInsufficientBalance,
+	110007: cex.ErrInsufficientBalance,
+	170131: cex.ErrInsufficientBalance,
+	170213: cex.ErrUnknownOrder,
+}
+
+// ---------------------------------------------
+// Custom Errors
+// =============================================
@@ -0,0 +1,646 @@
+package bybit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/s2m"
+)
+
+type UserConfig struct {
+	// set true if account position mode is hedge mode
+	isHedgeMode bool
+	recvWindow  int64
+	// timeSync corrects signed timestamp, may be nil
+	timeSync *cex.TimeSync
+	// waitOrderOpts are used by WaitOrder
+	waitOrderOpts []cex.WaitOrderOpt
+	// logger logs requests made by user, cex.DefaultLogger is used if it is nil
+	logger cex.Logger
+	// transport sends requests made by user, shared transport is used if it is nil
+	transport     cex.Transport
+	transportOpts []cex.TransportOpt
+	// backend sends requests made by user, cex.DefaultBackend is used if it is empty
+	backend cex.Backend
+}
+
+type User struct {
+	api cex.Api
+	cfg UserConfig
+
+	// ctx is set to every request made by user, if it is not nil.
+	ctx context.Context
+}
+
+type UserOpt func(*User)
+
+// UserOptHedgeMode should be set if account position mode is hedge mode,
+// so positionIdx will be set for linear orders.
+func UserOptHedgeMode() UserOpt {
+	return func(user *User) {
+		user.cfg.isHedgeMode = true
+	}
+}
+
+func UserOptRecvWindow(recvWindow int64) UserOpt {
+	return func(user *User) {
+		user.cfg.recvWindow = recvWindow
+	}
+}
+
+// UserOptTimeSync makes user sign requests with server time of ts.
+func UserOptTimeSync(ts *cex.TimeSync) UserOpt {
+	return func(user *User) {
+		user.cfg.timeSync = ts
+	}
+}
+
+// UserOptTestnet makes requests to bybit testnet.
+func UserOptTestnet() UserOpt {
+	return func(user *User) {
+		user.api.Env = cex.EnvTestnet
+	}
+}
+
+// UserOptWaitOrder sets options of WaitOrder, ex. poll interval,
+// transition callback and user data stream as update source.
+func UserOptWaitOrder(opts ...cex.WaitOrderOpt) UserOpt {
+	return func(user *User) {
+		user.cfg.waitOrderOpts = append(user.cfg.waitOrderOpts, opts...)
+	}
+}
+
+// UserOptLogger logs requests made by user with logger,
+// ex. cex.SlogLogger(slog.Default()).
+func UserOptLogger(logger cex.Logger) UserOpt {
+	return func(user *User) {
+		user.cfg.logger = logger
+	}
+}
+
+// UserOptTransport sends requests made by user by transport,
+// ex. cex.NewTransport(cex.TransportOptProxy(proxy)).
+// It can be overridden by cex.CltOptTransport per call.
+func UserOptTransport(transport cex.Transport) UserOpt {
+	return func(user *User) {
+		user.cfg.transport = transport
+	}
+}
+
+// UserOptBackend sends requests made by user by backend,
+// ex. cex.BackendNetHTTP for latency sensitive callers.
+// It can be overridden by cex.CltOptBackend per call.
+func UserOptBackend(backend cex.Backend) UserOpt {
+	return func(user *User) {
+		user.cfg.backend = backend
+	}
+}
+
+// UserOptProxy routes requests made by user through http, https or socks5 proxy,
+// ex. to send requests from the egress ip whitelisted by api key.
+// proxy can be parsed by cex.ParseProxyUrl.
+func UserOptProxy(proxy *url.URL) UserOpt {
+	return func(user *User) {
+		user.cfg.transportOpts = append(user.cfg.transportOpts, cex.TransportOptProxy(proxy))
+	}
+}
+
+// UserOptTLSConfig sets tls config of requests made by user.
+func UserOptTLSConfig(cfg *tls.Config) UserOpt {
+	return func(user *User) {
+		user.cfg.transportOpts = append(user.cfg.transportOpts, cex.TransportOptTLSConfig(cfg))
+	}
+}
+
+func NewUser(apiKey, secretKey string, opts ...UserOpt) *User {
+	user := &User{
+		api: cex.Api{Cex: cex.BYBIT, ApiKey: apiKey, SecretKey: secretKey},
+		cfg: UserConfig{recvWindow: DefaultRecvWindow},
+	}
+	for _, opt := range opts {
+		opt(user)
+	}
+	user.cfg.transport = cex.ApplyTransportOpts(user.cfg.transport, user.cfg.transportOpts...)
+	return user
+}
+
+var emptyUser = &User{}
+
+func EmptyUser() *User {
+	return emptyUser
+}
+
+// ============================================================
+// User Getter
+// ------------------------------------------------------------
+
+func (u *User) Api() cex.Api {
+	return u.api
+}
+
+func (u *User) Config() UserConfig {
+	return u.cfg
+}
+
+// Context returns context bound to user, may be nil.
+func (u *User) Context() context.Context {
+	return u.ctx
+}
+
+// WithContext returns a shallow copy of user bound to ctx.
+func (u *User) WithContext(ctx context.Context) *User {
+	nu := *u
+	nu.ctx = ctx
+	return &nu
+}
+
+// ------------------------------------------------------------
+// User Getter
+// ============================================================
+
+// ============================================================
+// Public API
+// ------------------------------------------------------------
+
+func (u *User) ServerTime(opts ...cex.CltOpt) (*cex.Response, ServerTime, cex.RequestError) {
+	return cex.Request(u, ServerTimeConfig, nil, opts...)
+}
+
+// QueryServerTime returns bybit server time, unit is millisecond.
+func QueryServerTime() (int64, error) {
+	_, t, err := emptyUser.ServerTime()
+	if err.IsNotNil() {
+		return 0, errors.New(err.Error())
+	}
+	nano, errParse := strconv.ParseInt(t.TimeNano, 10, 64)
+	if errParse != nil {
+		return 0, fmt.Errorf("bybit: parse server time, %w", errParse)
+	}
+	return nano / int64(time.Millisecond), nil
+}
+
+// NewTimeSync creates cex.TimeSync by bybit server time.
+// Should call Sync or Start before using it.
+func NewTimeSync() *cex.TimeSync {
+	return cex.NewTimeSync(QueryServerTime)
+}
+
+// Ping pings bybit by server time endpoint.
+func Ping() cex.PingResult {
+	return cex.Ping(QueryServerTime)
+}
+
+// ------------------------------------------------------------
+// Public API
+// ============================================================
+
+// ============================================================
+// Account API
+// ------------------------------------------------------------
+
+// WalletBalance queries unified account wallet balance, coin can be empty or multiple coins separated by comma.
+func (u *User) WalletBalance(coin string, opts ...cex.CltOpt) (*cex.Response, List[WalletBalance], cex.RequestError) {
+	return cex.Request(u, WalletBalanceConfig, WalletBalanceParams{AccountType: AccountTypeUnified, Coin: coin}, opts...)
+}
+
+// QueryBalances implements cex.BalanceQuerier.
+// It queries unified trading account only.
+func (u *User) QueryBalances(opts ...cex.CltOpt) ([]cex.Balance, cex.RequestError) {
+	_, list, err := u.WalletBalance("", opts...)
+	if err.IsNotNil() {
+		return nil, err
+	}
+	var balances []cex.Balance
+	for _, w := range list.List {
+		for _, c := range w.Coin {
+			if c.WalletBalance == 0 && c.UnrealisedPnl == 0 {
+				continue
+			}
+			balances = append(balances, cex.Balance{
+				Cex:           cex.BYBIT,
+				Wallet:        cex.WalletTypeUnified,
+				Asset:         c.Coin,
+				Total:         c.WalletBalance.Float64(),
+				Free:          c.AvailableToWithdraw.Float64(),
+				UnrealizedPnl: c.UnrealisedPnl.Float64(),
+			})
+		}
+	}
+	return balances, err
+}
+
+func (u *User) Positions(category Category, symbol, settleCoin string, opts ...cex.CltOpt) (*cex.Response, List[Position], cex.RequestError) {
+	return cex.Request(u, PositionsConfig, PositionsParams{Category: category, Symbol: symbol, SettleCoin: settleCoin}, opts...)
+}
+
+// ------------------------------------------------------------
+// Account API
+// ============================================================
+
+// ============================================================
+// Trade API
+// ------------------------------------------------------------
+
+func (u *User) NewOrder(params NewOrderParams, opts ...cex.CltOpt) (*cex.Response, OrderResult, cex.RequestError) {
+	return cex.Request(u, NewOrderConfig, params, opts...)
+}
+
+func (u *User) CancelRawOrder(category Category, symbol, orderId, orderLinkId string, opts ...cex.CltOpt) (*cex.Response, OrderResult, cex.RequestError) {
+	return cex.Request(u, CancelOrderConfig, CancelOrderParams{Category: category, Symbol: symbol, OrderId: orderId, OrderLinkId: orderLinkId}, opts...)
+}
+
+// QueryRawOrder queries order from open orders firstly,
+// and then from order history, because finished orders may be removed from open orders.
+func (u *User) QueryRawOrder(category Category, symbol, orderId, orderLinkId string, opts ...cex.CltOpt) (*cex.Response, Order, cex.RequestError) {
+	params := QueryOrderParams{Category: category, Symbol: symbol, OrderId: orderId, OrderLinkId: orderLinkId}
+	resp, list, err := cex.Request(u, OpenOrdersConfig, params, opts...)
+	if err.IsNotNil() {
+		return resp, Order{}, err
+	}
+	if len(list.List) == 0 {
+		resp, list, err = cex.Request(u, OrderHistoryConfig, params, opts...)
+		if err.IsNotNil() {
+			return resp, Order{}, err
+		}
+	}
+	if len(list.List) == 0 {
+		return resp, Order{}, cex.RequestError{
+			ReqBaseConfig: OrderHistoryConfig.ReqBaseConfig,
+			Err:           fmt.Errorf("bybit: %w, order id: %v, order link id: %v", cex.ErrUnknownOrder, orderId, orderLinkId),
+		}
+	}
+	rawOrd := list.List[0]
+	rawOrd.Category = list.Category
+	return resp, rawOrd, err
+}
+
+// ------------------------------------------------------------
+// Trade API
+// ============================================================
+
+// ============================================================
+// Trader Implementation
+// ------------------------------------------------------------
+
+func (u *User) QueryOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	return u.queryOrd(order, opts...)
+}
+
+func (u *User) CancelOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	return u.cancelOrd(order, opts...)
+}
+
+func (u *User) WaitOrder(ctx context.Context, order *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
+	return u.waitOrd(ctx, order, opts...)
+}
+
+func (u *User) NewSpotOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeSpot, asset, quote, tradeType, orderSide, qty, price, opts...)
+}
+
+func (u *User) NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (u *User) NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (u *User) NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (u *User) NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+// NewFuturesOrder places linear perpetual order.
+func (u *User) NewFuturesOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeFutures, asset, quote, tradeType, orderSide, qty, price, opts...)
+}
+
+func (u *User) NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (u *User) NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (u *User) NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (u *User) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+// ------------------------------------------------------------
+// Trader Implementation
+// ============================================================
+
+// ============================================================
+// Private Trade Functions
+// ------------------------------------------------------------
+
+var ordTypeByCexOrdType = map[cex.OrderType]OrderType{
+	cex.OrderTypeLimit:  OrderTypeLimit,
+	cex.OrderTypeMarket: OrderTypeMarket,
+}
+
+var cexOrdTypeByOrdType = map[OrderType]cex.OrderType{
+	OrderTypeLimit:  cex.OrderTypeLimit,
+	OrderTypeMarket: cex.OrderTypeMarket,
+}
+
+var ordSideByCexOrdSide = map[cex.OrderSide]OrderSide{
+	cex.OrderSideBuy:  OrderSideBuy,
+	cex.OrderSideSell: OrderSideSell,
+}
+
+var cexOrdSideByOrdSide = map[OrderSide]cex.OrderSide{
+	OrderSideBuy:  cex.OrderSideBuy,
+	OrderSideSell: cex.OrderSideSell,
+}
+
+var cexOrdStatusByOrdStatus = map[OrderStatus]cex.OrderStatus{
+	OrderStatusNew:                     cex.OrderStatusNew,
+	OrderStatusUntriggered:             cex.OrderStatusNew,
+	OrderStatusTriggered:               cex.OrderStatusNew,
+	OrderStatusPartiallyFilled:         cex.OrderStatusPartiallyFilled,
+	OrderStatusFilled:                  cex.OrderStatusFilled,
+	OrderStatusCancelled:               cex.OrderStatusCanceled,
+	OrderStatusPartiallyFilledCanceled: cex.OrderStatusCanceled,
+	OrderStatusDeactivated:             cex.OrderStatusCanceled,
+	OrderStatusRejected:                cex.OrderStatusRejected,
+}
+
+func categoryOfPairType(pairType cex.PairType) Category {
+	if pairType == cex.PairTypeFutures {
+		return CategoryLinear
+	}
+	return CategorySpot
+}
+
+func pairTypeOfCategory(category Category) cex.PairType {
+	switch category {
+	case CategorySpot:
+		return cex.PairTypeSpot
+	case CategoryLinear, CategoryInverse:
+		return cex.PairTypeFutures
+	}
+	return ""
+}
+
+func (u *User) newOrd(pairType cex.PairType, asset, quote string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	params := NewOrderParams{
+		Category:  categoryOfPairType(pairType),
+		Symbol:    asset + SymbolMid + quote,
+		Side:      ordSideByCexOrdSide[orderSide],
+		OrderType: ordTypeByCexOrdType[orderType],
+		Qty:       qty,
+		Price:     price,
+	}
+	if orderType == cex.OrderTypeLimit {
+		params.TimeInForce = TimeInForceGtc
+	}
+	switch pairType {
+	case cex.PairTypeSpot:
+		if orderType == cex.OrderTypeMarket {
+			// keep qty as asset qty for market buy order
+			params.MarketUnit = MarketUnitBaseCoin
+		}
+	case cex.PairTypeFutures:
+		if u.cfg.isHedgeMode {
+			if orderSide == cex.OrderSideBuy {
+				params.PositionIdx = PositionIdxHedgeBuy
+			} else {
+				params.PositionIdx = PositionIdxHedgeSell
+			}
+		}
+	}
+	ord := &cex.Order{
+		Cex:         cex.BYBIT,
+		PairType:    pairType,
+		OrderType:   orderType,
+		OrderSide:   orderSide,
+		Symbol:      params.Symbol,
+		TimeInForce: string(params.TimeInForce),
+		ApiKey:      u.api.ApiKey,
+		OriQty:      qty,
+		OriPrice:    price,
+	}
+	resp, result, err := u.NewOrder(params, opts...)
+	if err.IsNil() {
+		ord.OrderId = result.OrderId
+		ord.ClientOrderId = result.OrderLinkId
+		ord.Status = cex.OrderStatusNew
+	}
+	return resp, ord, err
+}
+
+func (u *User) cancelOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	resp, _, err := u.CancelRawOrder(categoryOfPairType(ord.PairType), ord.Symbol, ord.OrderId, ord.ClientOrderId, opts...)
+	if err.IsNotNil() {
+		return resp, err
+	}
+	// bybit cancel response does not contain order status
+	return u.queryOrd(ord, opts...)
+}
+
+func (u *User) queryOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	resp, rawOrd, err := u.QueryRawOrder(categoryOfPairType(ord.PairType), ord.Symbol, ord.OrderId, ord.ClientOrderId, opts...)
+	if err.IsNil() {
+		UpdateOrderWithRawOrder(ord, ord.PairType, rawOrd)
+	}
+	return resp, err
+}
+
+func (u *User) waitOrd(ctx context.Context, ord *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
+	query := func(ctx context.Context, ord *cex.Order) cex.RequestError {
+		_, err := u.WithContext(ctx).queryOrd(ord, opts...)
+		return err
+	}
+	return cex.WaitOrder(ctx, ord, query, u.cfg.waitOrderOpts...)
+}
+
+// SwitchOrderToCexOrder switches raw order to cex order.
+// pairType is needed, because bybit order does not contain category.
+func SwitchOrderToCexOrder(pairType cex.PairType, rawOrd Order) cex.Order {
+	return cex.Order{
+		Cex:            cex.BYBIT,
+		PairType:       pairType,
+		OrderType:      cexOrdTypeByOrdType[rawOrd.OrderType],
+		OrderSide:      cexOrdSideByOrdSide[rawOrd.Side],
+		Symbol:         rawOrd.Symbol,
+		TimeInForce:    string(rawOrd.TimeInForce),
+		ClientOrderId:  rawOrd.OrderLinkId,
+		OriQty:         rawOrd.Qty.Float64(),
+		OriPrice:       rawOrd.Price.Float64(),
+		OrderId:        rawOrd.OrderId,
+		Status:         cexOrdStatusByOrdStatus[rawOrd.OrderStatus],
+		FilledQty:      rawOrd.CumExecQty.Float64(),
+		FilledQuote:    rawOrd.CumExecValue.Float64(),
+		FilledAvgPrice: rawOrd.AvgPrice.Float64(),
+		RawOrder:       rawOrd,
+	}
+}
+
+// ToCexOrder implements cex.RawOrder.
+// PairType is by Category, which is set by QueryRawOrder.
+func (o Order) ToCexOrder() cex.Order {
+	return SwitchOrderToCexOrder(pairTypeOfCategory(o.Category), o)
+}
+
+// UpdateOrderWithRawOrder merges raw order into ord by cex.MergeOrderUpdate,
+// so out-of-order responses will not make ord state regress.
+func UpdateOrderWithRawOrder(ord *cex.Order, pairType cex.PairType, rawOrd Order) {
+	if ord == nil {
+		return
+	}
+	cex.MergeOrderUpdate(ord, SwitchOrderToCexOrder(pairType, rawOrd))
+}
+
+// ------------------------------------------------------------
+// Private Trade Functions
+// ============================================================
+
+// ============================================================
+// ReqMaker
+// ------------------------------------------------------------
+
+// Logger implements cex.LoggerProvider.
+func (u *User) Logger() cex.Logger {
+	return u.cfg.logger
+}
+
+// RewriteBaseUrl implements cex.BaseUrlRewriter.
+func (u *User) RewriteBaseUrl(baseUrl string) (string, error) {
+	if !u.api.Env.IsTestnet() {
+		return baseUrl, nil
+	}
+	if baseUrl != BaseUrl {
+		return "", fmt.Errorf("bybit: %w for %v", cex.ErrNoTestnet, baseUrl)
+	}
+	return TestnetBaseUrl, nil
+}
+
+// CltOpts implements cex.CltOptsProvider.
+func (u *User) CltOpts() []cex.CltOpt {
+	var opts []cex.CltOpt
+	if u.cfg.transport != nil {
+		opts = append(opts, cex.CltOptTransport(u.cfg.transport))
+	}
+	if u.cfg.backend != "" {
+		opts = append(opts, cex.CltOptBackend(u.cfg.backend))
+	}
+	return opts
+}
+
+func (u *User) Make(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*cex.HTTPRequest, error) {
+	if err := cex.ValidateParams(reqData); err != nil {
+		return nil, err
+	}
+	// per call options override them, because they are applied later
+	opts = append(u.CltOpts(), opts...)
+	ro := cex.NewReqOpts(opts...)
+	query, body, err := composeQueryAndBody(config, reqData)
+	if err != nil {
+		return nil, err
+	}
+	if len(ro.Query) > 0 && (config.Method == http.MethodGet || config.Method == http.MethodDelete) {
+		if query != "" {
+			query += "&"
+		}
+		query += ro.Query.Encode()
+	}
+	fullUrl := config.BaseUrl + config.Path
+	if query != "" {
+		fullUrl += "?" + query
+	}
+	req := cex.NewHTTPRequest(opts...).
+		SetHeader("Content-Type", "application/json")
+	req.URL = fullUrl
+	if config.IsUserData {
+		timestamp := strconv.FormatInt(u.cfg.timeSync.Now().UnixMilli(), 10)
+		recvWindow := strconv.FormatInt(u.cfg.recvWindow, 10)
+		if ro.RecvWindow > 0 {
+			recvWindow = strconv.FormatInt(ro.RecvWindow, 10)
+		}
+		// bybit signs query for GET, and json body for POST
+		payload := query + body
+		req.SetHeaders(map[string]string{
+			"X-BAPI-API-KEY":     u.api.ApiKey,
+			"X-BAPI-SIGN":        sign(timestamp, u.api.ApiKey, recvWindow, payload, u.api.SecretKey),
+			"X-BAPI-TIMESTAMP":   timestamp,
+			"X-BAPI-RECV-WINDOW": recvWindow,
+		})
+	}
+	if body != "" {
+		req.SetBody(body)
+	}
+	if u.ctx != nil {
+		req.SetContext(u.ctx)
+	}
+	return req, nil
+}
+
+// composeQueryAndBody composes query for GET and DELETE,
+// and json body for other methods.
+// bybit signs query string, so query must be composed by self.
+func composeQueryAndBody(config cex.ReqBaseConfig, reqData any) (query, body string, err error) {
+	if reqData == nil {
+		return
+	}
+	switch config.Method {
+	case http.MethodGet, http.MethodDelete:
+		m, err := s2m.ToStrMap(reqData)
+		if err != nil {
+			return "", "", fmt.Errorf("bybit: make request, %w: %w", cex.ErrS2M, err)
+		}
+		val := url.Values{}
+		for k, v := range m {
+			val.Set(k, v)
+		}
+		query = val.Encode()
+	default:
+		b, err := json.Marshal(reqData)
+		if err != nil {
+			return "", "", fmt.Errorf("bybit: make request, %w: %w", cex.ErrJsonMarshal, err)
+		}
+		body = string(b)
+	}
+	return
+}
+
+// ------------------------------------------------------------
+// ReqMaker
+// ============================================================
+
+// ============================================================
+// Signer
+// ------------------------------------------------------------
+
+// sign signs timestamp + api key + recv window + payload by HmacSHA256,
+// and encodes it by hex.
+func sign(timestamp, apiKey, recvWindow, payload, key string) string {
+	return cex.SignByHmacSHA256ToHex(timestamp+apiKey+recvWindow+payload, key)
+}
+
+// ------------------------------------------------------------
+// Signer
+// ============================================================
@@ -0,0 +1,64 @@
+package bybit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/props"
+)
+
+func readApiKey() cex.Api {
+	apiKeys := cex.MustReadApiKey()
+	apiKey, ok := apiKeys["BYBITTEST"]
+	if !ok {
+		panic("no bybit api key")
+	}
+	return apiKey
+}
+
+func newTestUser() *User {
+	apiKey := readApiKey()
+	return NewUser(apiKey.ApiKey, apiKey.SecretKey)
+}
+
+func TestComposeQueryAndBody(t *testing.T) {
+	query, body, err := composeQueryAndBody(PositionsConfig.ReqBaseConfig, PositionsParams{Category: CategoryLinear, Symbol: "BTCUSDT"})
+	props.PanicIfNotNil(err)
+	if query != "category=linear&symbol=BTCUSDT" || body != "" {
+		t.Error("wrong get query or body", query, body)
+	}
+	query, body, err = composeQueryAndBody(NewOrderConfig.ReqBaseConfig, NewOrderParams{Category: CategorySpot, Symbol: "BTCUSDT", Side: OrderSideBuy, OrderType: OrderTypeLimit, Qty: 0.01, Price: 30000, TimeInForce: TimeInForceGtc})
+	props.PanicIfNotNil(err)
+	if query != "" || body != `{"category":"spot","symbol":"BTCUSDT","side":"Buy","orderType":"Limit","qty":"0.01","price":"30000","timeInForce":"GTC"}` {
+		t.Error("wrong post query or body", query, body)
+	}
+}
+
+func TestBodyUnmsh(t *testing.T) {
+	_, err := bodyUnmsh[OrderResult]([]byte(`{"retCode":170131,"retMsg":"Insufficient balance.","result":{},"retExtInfo":{},"time":1700000000000}`))
+	if err == nil || !errors.Is(err.Err, cex.ErrInsufficientBalance) || err.CexErrCode != 170131 {
+		t.Fatal("err should be insufficient balance, but", err)
+	}
+	list, err := bodyUnmsh[List[Order]]([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"orderId":"1","symbol":"BTCUSDT","price":"30000","qty":"0.01","side":"Buy","orderStatus":"PartiallyFilled","avgPrice":"","cumExecQty":"0.005","cumExecValue":"150","orderType":"Limit"}]},"time":1700000000000}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ord := SwitchOrderToCexOrder(cex.PairTypeSpot, list.List[0])
+	props.PrintlnIndent(ord)
+	if ord.Status != cex.OrderStatusPartiallyFilled || ord.FilledQuote != 150 || ord.OrderSide != cex.OrderSideBuy {
+		t.Error("wrong order", ord)
+	}
+}
+
+func TestUser_WalletBalance(t *testing.T) {
+	_, data, err := newTestUser().WalletBalance("")
+	props.PanicIfNotNil(err.Err)
+	props.PrintlnIndent(data)
+}
+
+func TestUser_Positions(t *testing.T) {
+	_, data, err := newTestUser().Positions(CategoryLinear, "", "USDT")
+	props.PanicIfNotNil(err.Err)
+	props.PrintlnIndent(data)
+}
@@ -4,9 +4,12 @@ type Name string
 
 const (
 	BINANCE Name = "BINANCE"
+	OKX     Name = "OKX"
+	BYBIT   Name = "BYBIT"
+	BITGET  Name = "BITGET"
 )
 
-var cexNames = []Name{BINANCE}
+var cexNames = []Name{BINANCE, OKX, BYBIT, BITGET}
 
 func NotCexName(name Name) bool {
 	for _, n := range cexNames {
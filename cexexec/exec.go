@@ -0,0 +1,349 @@
+package cexexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// ErrChildNotFilled is returned by iceberg, if a child order is finished without any fill,
+// ex. canceled outside or rejected, so slicing will not loop forever.
+var ErrChildNotFilled = errors.New("cexexec: child order is finished without fill")
+
+// ParentOrder is the order split into child orders.
+type ParentOrder struct {
+	PairType cex.PairType  `json:"pairType" bson:"pairType"`
+	Asset    string        `json:"asset" bson:"asset"`
+	Quote    string        `json:"quote" bson:"quote"`
+	Side     cex.OrderSide `json:"side" bson:"side"`
+	Qty      float64       `json:"qty" bson:"qty"`
+	// Price is price of limit child orders, 0 means market child orders.
+	Price float64 `json:"price" bson:"price"`
+}
+
+func (p ParentOrder) validate() error {
+	if p.Qty <= 0 {
+		return fmt.Errorf("cexexec: invalid parent qty %v", p.Qty)
+	}
+	if p.Price < 0 {
+		return fmt.Errorf("cexexec: invalid parent price %v", p.Price)
+	}
+	switch p.PairType {
+	case cex.PairTypeSpot, cex.PairTypeFutures:
+	default:
+		return fmt.Errorf("cexexec: unsupported pair type %v", p.PairType)
+	}
+	return nil
+}
+
+// Progress is execution state of parent order.
+type Progress struct {
+	Parent ParentOrder `json:"parent" bson:"parent"`
+	// Children is number of placed child orders.
+	Children int `json:"children" bson:"children"`
+	// PlacedQty is sum of qty of placed child orders.
+	PlacedQty   float64 `json:"placedQty" bson:"placedQty"`
+	FilledQty   float64 `json:"filledQty" bson:"filledQty"`
+	FilledQuote float64 `json:"filledQuote" bson:"filledQuote"`
+	// LastChild is copy of the last placed child order, nil before placing.
+	LastChild *cex.Order `json:"lastChild" bson:"lastChild"`
+	Done      bool       `json:"done" bson:"done"`
+	Err       error      `json:"-" bson:"-"`
+}
+
+type Opt func(*config)
+
+type config struct {
+	onProgress func(Progress)
+	cltOpts    []cex.CltOpt
+	qtyStep    float64
+}
+
+// OptOnProgress sets callback called after every child order and when execution ends.
+// Callback is called in executing goroutine, so it should not block.
+func OptOnProgress(callback func(Progress)) Opt {
+	return func(c *config) {
+		c.onProgress = callback
+	}
+}
+
+// OptCltOpts sets opts of all requests of child orders.
+func OptCltOpts(opts ...cex.CltOpt) Opt {
+	return func(c *config) {
+		c.cltOpts = append(c.cltOpts, opts...)
+	}
+}
+
+// OptQtyStep rounds qty of child orders down to step, ex. lot size of symbol.
+// Remaining qty less than step is not executed.
+func OptQtyStep(step float64) Opt {
+	return func(c *config) {
+		c.qtyStep = step
+	}
+}
+
+// Executor places child orders of a parent order by trader,
+// over time slices (TWAP) or size slices (iceberg).
+// It can be paused and resumed, while Run is executing.
+//
+//	e, _ := cexexec.NewTWAP(user, parent, time.Hour, 60, cexexec.OptOnProgress(func(p cexexec.Progress) {}))
+//	progress, err := e.Run(ctx)
+type Executor struct {
+	trader cex.Trader
+	parent ParentOrder
+	cfg    config
+	run    func(ctx context.Context) error
+
+	mux      sync.Mutex
+	running  bool
+	progress Progress
+	// resumed is closed when resumed, nil if not paused
+	resumed chan struct{}
+}
+
+func newExecutor(trader cex.Trader, parent ParentOrder, opts []Opt) (*Executor, error) {
+	if trader == nil {
+		return nil, errors.New("cexexec: nil trader")
+	}
+	if err := parent.validate(); err != nil {
+		return nil, err
+	}
+	e := &Executor{trader: trader, parent: parent, progress: Progress{Parent: parent}}
+	for _, opt := range opts {
+		opt(&e.cfg)
+	}
+	return e, nil
+}
+
+// NewTWAP splits parent into slices child orders,
+// which are placed evenly in duration, the first one is placed immediately.
+// Child orders are not waited, so market child orders are recommended.
+func NewTWAP(trader cex.Trader, parent ParentOrder, duration time.Duration, slices int, opts ...Opt) (*Executor, error) {
+	if slices <= 0 {
+		return nil, fmt.Errorf("cexexec: invalid twap slices %v", slices)
+	}
+	if duration < 0 {
+		return nil, fmt.Errorf("cexexec: invalid twap duration %v", duration)
+	}
+	e, err := newExecutor(trader, parent, opts)
+	if err != nil {
+		return nil, err
+	}
+	var interval time.Duration
+	if slices > 1 {
+		interval = duration / time.Duration(slices-1)
+	}
+	e.run = func(ctx context.Context) error {
+		return e.runTWAP(ctx, interval, slices)
+	}
+	return e, nil
+}
+
+// NewIceberg splits parent into child orders of sliceQty,
+// the next child is placed after the previous one is finished,
+// and unfilled qty of finished child is placed again.
+func NewIceberg(trader cex.Trader, parent ParentOrder, sliceQty float64, opts ...Opt) (*Executor, error) {
+	if sliceQty <= 0 {
+		return nil, fmt.Errorf("cexexec: invalid iceberg slice qty %v", sliceQty)
+	}
+	e, err := newExecutor(trader, parent, opts)
+	if err != nil {
+		return nil, err
+	}
+	e.run = func(ctx context.Context) error {
+		return e.runIceberg(ctx, sliceQty)
+	}
+	return e, nil
+}
+
+// Run executes parent order until it is done, ctx is done, or any child order fails.
+// It can be called only once.
+func (e *Executor) Run(ctx context.Context) (Progress, error) {
+	e.mux.Lock()
+	if e.running || e.progress.Done {
+		e.mux.Unlock()
+		return e.Progress(), errors.New("cexexec: executor is already run")
+	}
+	e.running = true
+	e.mux.Unlock()
+
+	err := e.run(ctx)
+
+	e.mux.Lock()
+	e.running = false
+	e.progress.Done = true
+	e.progress.Err = err
+	p := e.copyProgress()
+	e.mux.Unlock()
+	if e.cfg.onProgress != nil {
+		e.cfg.onProgress(p)
+	}
+	return p, err
+}
+
+// Pause stops placing child orders, placed child orders are not canceled.
+func (e *Executor) Pause() {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if e.resumed == nil {
+		e.resumed = make(chan struct{})
+	}
+}
+
+func (e *Executor) Resume() {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if e.resumed != nil {
+		close(e.resumed)
+		e.resumed = nil
+	}
+}
+
+func (e *Executor) Paused() bool {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return e.resumed != nil
+}
+
+func (e *Executor) Progress() Progress {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return e.copyProgress()
+}
+
+func (e *Executor) copyProgress() Progress {
+	p := e.progress
+	if p.LastChild != nil {
+		child := *p.LastChild
+		p.LastChild = &child
+	}
+	return p
+}
+
+func (e *Executor) runTWAP(ctx context.Context, interval time.Duration, slices int) error {
+	for i := range slices {
+		if i > 0 {
+			if err := sleepCtx(ctx, interval); err != nil {
+				return err
+			}
+		}
+		if err := e.waitResumed(ctx); err != nil {
+			return err
+		}
+		p := e.Progress()
+		qty := e.roundQty((e.parent.Qty - p.PlacedQty) / float64(slices-i))
+		if qty <= 0 {
+			continue
+		}
+		child, err := e.placeChild(qty)
+		if err != nil {
+			return err
+		}
+		e.record(qty, child)
+	}
+	return nil
+}
+
+func (e *Executor) runIceberg(ctx context.Context, sliceQty float64) error {
+	for {
+		if err := e.waitResumed(ctx); err != nil {
+			return err
+		}
+		p := e.Progress()
+		qty := e.roundQty(min(sliceQty, e.parent.Qty-p.FilledQty))
+		if qty <= 0 {
+			return nil
+		}
+		child, err := e.placeChild(qty)
+		if err != nil {
+			return err
+		}
+		reqErr := <-e.trader.WaitOrder(ctx, child, e.cfg.cltOpts...)
+		e.record(qty, child)
+		if reqErr.IsNotNil() {
+			return &reqErr
+		}
+		if child.FilledQty <= 0 {
+			return fmt.Errorf("%w, order id: %v, status: %v", ErrChildNotFilled, child.OrderId, child.Status)
+		}
+	}
+}
+
+func (e *Executor) placeChild(qty float64) (*cex.Order, error) {
+	orderType := cex.OrderTypeMarket
+	if e.parent.Price > 0 {
+		orderType = cex.OrderTypeLimit
+	}
+	var child *cex.Order
+	var err cex.RequestError
+	switch e.parent.PairType {
+	case cex.PairTypeSpot:
+		_, child, err = e.trader.NewSpotOrder(e.parent.Asset, e.parent.Quote, orderType, e.parent.Side, qty, e.parent.Price, e.cfg.cltOpts...)
+	default:
+		_, child, err = e.trader.NewFuturesOrder(e.parent.Asset, e.parent.Quote, orderType, e.parent.Side, qty, e.parent.Price, e.cfg.cltOpts...)
+	}
+	if err.IsNotNil() {
+		return nil, &err
+	}
+	if child == nil {
+		return nil, errors.New("cexexec: trader returns nil child order")
+	}
+	return child, nil
+}
+
+func (e *Executor) record(placedQty float64, child *cex.Order) {
+	e.mux.Lock()
+	e.progress.Children++
+	e.progress.PlacedQty += placedQty
+	e.progress.FilledQty += child.FilledQty
+	e.progress.FilledQuote += child.FilledQuote
+	last := *child
+	e.progress.LastChild = &last
+	p := e.copyProgress()
+	e.mux.Unlock()
+	if e.cfg.onProgress != nil {
+		e.cfg.onProgress(p)
+	}
+}
+
+func (e *Executor) waitResumed(ctx context.Context) error {
+	e.mux.Lock()
+	resumed := e.resumed
+	e.mux.Unlock()
+	if resumed == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-resumed:
+		return nil
+	}
+}
+
+// roundQty rounds qty down to step, 1e-9 tolerates float errors.
+func (e *Executor) roundQty(qty float64) float64 {
+	if e.cfg.qtyStep <= 0 {
+		return qty
+	}
+	return math.Floor(qty/e.cfg.qtyStep+1e-9) * e.cfg.qtyStep
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
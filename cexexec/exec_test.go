@@ -0,0 +1,196 @@
+package cexexec
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// testTrader fills market orders immediately,
+// and fills fillRatio of limit orders when they are waited.
+type testTrader struct {
+	mux       sync.Mutex
+	orders    []cex.Order
+	fillRatio float64
+	newErr    error
+}
+
+func (t *testTrader) newOrder(pairType cex.PairType, asset, quote string, orderType cex.OrderType, side cex.OrderSide, qty, price float64) (*cex.Response, *cex.Order, cex.RequestError) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if t.newErr != nil {
+		return nil, nil, cex.RequestError{Err: t.newErr}
+	}
+	ord := &cex.Order{PairType: pairType, Symbol: asset + quote, OrderType: orderType, OrderSide: side, OriQty: qty, OriPrice: price, Status: cex.OrderStatusNew}
+	if orderType == cex.OrderTypeMarket {
+		ord.Status, ord.FilledQty, ord.FilledQuote = cex.OrderStatusFilled, qty, qty*100
+	}
+	t.orders = append(t.orders, *ord)
+	return nil, ord, cex.RequestError{}
+}
+
+func (t *testTrader) QueryOrder(*cex.Order, ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	return nil, cex.RequestError{}
+}
+
+func (t *testTrader) CancelOrder(*cex.Order, ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	return nil, cex.RequestError{}
+}
+
+func (t *testTrader) WaitOrder(_ context.Context, ord *cex.Order, _ ...cex.CltOpt) chan cex.RequestError {
+	ch := make(chan cex.RequestError, 1)
+	if !ord.IsFinished() {
+		ord.FilledQty = ord.OriQty * t.fillRatio
+		ord.FilledQuote = ord.FilledQty * ord.OriPrice
+		ord.Status = cex.OrderStatusCanceled
+		if t.fillRatio == 1 {
+			ord.Status = cex.OrderStatusFilled
+		}
+	}
+	ch <- cex.RequestError{}
+	return ch
+}
+
+func (t *testTrader) NewSpotOrder(asset, quote string, tradeType cex.OrderType, side cex.OrderSide, qty, price float64, _ ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.newOrder(cex.PairTypeSpot, asset, quote, tradeType, side, qty, price)
+}
+
+func (t *testTrader) NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (t *testTrader) NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (t *testTrader) NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (t *testTrader) NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+func (t *testTrader) NewFuturesOrder(asset, quote string, tradeType cex.OrderType, side cex.OrderSide, qty, price float64, _ ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.newOrder(cex.PairTypeFutures, asset, quote, tradeType, side, qty, price)
+}
+
+func (t *testTrader) NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (t *testTrader) NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (t *testTrader) NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (t *testTrader) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return t.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+func TestTWAP(t *testing.T) {
+	trader := &testTrader{}
+	parent := ParentOrder{PairType: cex.PairTypeFutures, Asset: "ETH", Quote: "USDT", Side: cex.OrderSideSell, Qty: 1}
+	var progresses []Progress
+	e, err := NewTWAP(trader, parent, 30*time.Millisecond, 3, OptQtyStep(0.01), OptOnProgress(func(p Progress) {
+		progresses = append(progresses, p)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	p, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) < 30*time.Millisecond {
+		t.Error("children should be placed in duration")
+	}
+	if len(trader.orders) != 3 {
+		t.Fatal("should place 3 children, but", len(trader.orders))
+	}
+	for i, qty := range []float64{0.33, 0.33, 0.34} {
+		if o := trader.orders[i]; math.Abs(o.OriQty-qty) > 1e-9 || o.PairType != cex.PairTypeFutures || o.OrderType != cex.OrderTypeMarket {
+			t.Error("wrong child", i, o.OriQty, o.PairType, o.OrderType)
+		}
+	}
+	if !p.Done || p.Children != 3 || math.Abs(p.FilledQty-1) > 1e-9 {
+		t.Error("wrong progress", p)
+	}
+	if len(progresses) != 4 || progresses[0].Children != 1 || !progresses[3].Done {
+		t.Error("progress callback should be called by every child and the end", len(progresses))
+	}
+	if _, err := e.Run(context.Background()); err == nil {
+		t.Error("executor can not run twice")
+	}
+}
+
+func TestTWAP_Pause(t *testing.T) {
+	trader := &testTrader{}
+	parent := ParentOrder{PairType: cex.PairTypeSpot, Asset: "ETH", Quote: "USDT", Side: cex.OrderSideBuy, Qty: 2}
+	e, err := NewTWAP(trader, parent, 20*time.Millisecond, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Pause()
+	if !e.Paused() {
+		t.Fatal("should be paused")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	p, err := e.Run(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || p.Children != 0 {
+		t.Error("paused executor should not place children", err, p.Children)
+	}
+
+	e, _ = NewTWAP(trader, parent, 20*time.Millisecond, 2)
+	e.Pause()
+	time.AfterFunc(30*time.Millisecond, e.Resume)
+	if p, err := e.Run(context.Background()); err != nil || p.Children != 2 {
+		t.Error("resumed executor should place children", err, p.Children)
+	}
+}
+
+func TestIceberg(t *testing.T) {
+	trader := &testTrader{fillRatio: 0.5}
+	parent := ParentOrder{PairType: cex.PairTypeSpot, Asset: "ETH", Quote: "USDT", Side: cex.OrderSideBuy, Qty: 1, Price: 100}
+	e, err := NewIceberg(trader, parent, 0.4, OptQtyStep(0.1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0.4 -> 0.2 filled, 0.4 -> 0.2, 0.4 -> 0.2, 0.4 -> 0.2, 0.2 -> 0.1, 0.1 -> 0.05, remaining 0.05 < step
+	if len(trader.orders) != 6 || trader.orders[0].OrderType != cex.OrderTypeLimit || math.Abs(trader.orders[4].OriQty-0.2) > 1e-9 {
+		t.Error("wrong children", trader.orders)
+	}
+	if math.Abs(p.FilledQty-0.95) > 1e-9 || math.Abs(p.FilledQuote-95) > 1e-9 {
+		t.Error("wrong progress", p)
+	}
+
+	trader = &testTrader{}
+	e, _ = NewIceberg(trader, parent, 0.4)
+	if _, err := e.Run(context.Background()); !errors.Is(err, ErrChildNotFilled) {
+		t.Error("should stop if child is not filled", err)
+	}
+
+	trader = &testTrader{newErr: cex.ErrInsufficientBalance}
+	e, _ = NewIceberg(trader, parent, 0.4)
+	if _, err := e.Run(context.Background()); !errors.Is(err, cex.ErrInsufficientBalance) {
+		t.Error("should return error of new order", err)
+	}
+
+	if _, err := NewIceberg(trader, ParentOrder{PairType: cex.PairTypeSpot}, 1); err == nil {
+		t.Error("parent qty is 0")
+	}
+}
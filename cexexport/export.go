@@ -0,0 +1,155 @@
+// Package cexexport writes slices of flat structs, ex. bnc.Kline, bnc.Trade,
+// to CSV and Parquet, so fetched data can be piped into analytics tools.
+//
+// Columns are exported scalar fields of struct in declaration order,
+// named by json tag, so schema is stable as long as struct is not changed.
+// Fields of other kinds, ex. slices, maps, interfaces, are skipped.
+package cexexport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/dwdwow/cex/ob"
+)
+
+type ColumnType int
+
+const (
+	ColumnTypeInt64 ColumnType = iota + 1
+	ColumnTypeFloat64
+	ColumnTypeString
+	ColumnTypeBool
+)
+
+type Column struct {
+	Name string
+	Type ColumnType
+
+	index int
+}
+
+// Schema returns columns of struct T.
+func Schema[T any]() ([]Column, error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cexexport: %v is not struct", t)
+	}
+	var cols []Column
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		var typ ColumnType
+		switch f.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			typ = ColumnTypeInt64
+		case reflect.Float32, reflect.Float64:
+			typ = ColumnTypeFloat64
+		case reflect.String:
+			typ = ColumnTypeString
+		case reflect.Bool:
+			typ = ColumnTypeBool
+		default:
+			continue
+		}
+		cols = append(cols, Column{Name: name, Type: typ, index: i})
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("cexexport: %v has no scalar fields", t)
+	}
+	return cols, nil
+}
+
+func (c Column) int64(v reflect.Value) int64 {
+	f := v.Field(c.index)
+	if f.CanInt() {
+		return f.Int()
+	}
+	return int64(f.Uint())
+}
+
+func (c Column) format(v reflect.Value) string {
+	f := v.Field(c.index)
+	switch c.Type {
+	case ColumnTypeInt64:
+		return strconv.FormatInt(c.int64(v), 10)
+	case ColumnTypeFloat64:
+		return strconv.FormatFloat(f.Float(), 'f', -1, 64)
+	case ColumnTypeBool:
+		return strconv.FormatBool(f.Bool())
+	default:
+		return f.String()
+	}
+}
+
+// WriteCSV writes header and rows to w.
+func WriteCSV[T any](w io.Writer, rows []T) error {
+	cols, err := Schema[T]()
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	record := make([]string, len(cols))
+	for i, c := range cols {
+		record[i] = c.Name
+	}
+	if err := cw.Write(record); err != nil {
+		return fmt.Errorf("cexexport: write csv header, %w", err)
+	}
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		for i, c := range cols {
+			record[i] = c.format(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("cexexport: write csv row, %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("cexexport: flush csv, %w", err)
+	}
+	return nil
+}
+
+// BookLevel is one price level of order book snapshot,
+// Level starts from 0, which is the best price.
+type BookLevel struct {
+	Time  int64   `json:"time"`
+	Side  string  `json:"side"` // ask, bid
+	Level int     `json:"level"`
+	Price float64 `json:"price"`
+	Qty   float64 `json:"qty"`
+}
+
+// BookLevels flattens order book snapshot of time into rows, asks before bids.
+func BookLevels(time int64, asks, bids ob.Book) ([]BookLevel, error) {
+	levels := make([]BookLevel, 0, len(asks)+len(bids))
+	for side, book := range []ob.Book{asks, bids} {
+		sideName := "ask"
+		if side == 1 {
+			sideName = "bid"
+		}
+		for i, pq := range book {
+			if len(pq) < 2 {
+				return nil, errors.New("cexexport: price and qty of book level is incomplete")
+			}
+			levels = append(levels, BookLevel{Time: time, Side: sideName, Level: i, Price: pq[0], Qty: pq[1]})
+		}
+	}
+	return levels, nil
+}
@@ -0,0 +1,202 @@
+package cexexport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex/ob"
+)
+
+type testSide string
+
+type testRow struct {
+	Id      int64    `json:"id"`
+	Price   float64  `json:"price,string"`
+	Side    testSide `json:"side"`
+	IsMaker bool     `json:"isMaker"`
+	Count   uint     `json:"count"`
+	Name    string
+	Tags    []string `json:"tags"`
+	Ignored int      `json:"-"`
+	hidden  int
+}
+
+var testRows = []testRow{
+	{Id: 1, Price: 2350.5, Side: "BUY", IsMaker: true, Count: 3, Name: "a,b"},
+	{Id: 2, Price: 0.0001, Side: "SELL", Count: 0, Name: ""},
+	{Id: -3, Price: -1, Side: "BUY", IsMaker: true, Count: 10, Name: "long name"},
+}
+
+func TestSchema(t *testing.T) {
+	cols, err := Schema[testRow]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, c := range cols {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, ",") != "id,price,side,isMaker,count,Name" {
+		t.Error("wrong columns", names)
+	}
+	if _, err := Schema[int](); err == nil {
+		t.Error("non struct should fail")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteCSV(buf, testRows); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,price,side,isMaker,count,Name\n" +
+		"1,2350.5,BUY,true,3,\"a,b\"\n" +
+		"2,0.0001,SELL,false,0,\n" +
+		"-3,-1,BUY,true,10,long name\n"
+	if buf.String() != want {
+		t.Error("wrong csv", buf.String())
+	}
+}
+
+func TestBookLevels(t *testing.T) {
+	levels, err := BookLevels(100, ob.Book{{2, 1}, {3, 2}}, ob.Book{{1, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(levels) != 3 || levels[1] != (BookLevel{Time: 100, Side: "ask", Level: 1, Price: 3, Qty: 2}) || levels[2].Side != "bid" || levels[2].Level != 0 {
+		t.Error("wrong levels", levels)
+	}
+	if _, err := BookLevels(100, ob.Book{{2}}, nil); err == nil {
+		t.Error("incomplete level should fail")
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteParquet(buf, testRows); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if string(data[:4]) != parquetMagic || string(data[len(data)-4:]) != parquetMagic {
+		t.Fatal("wrong magic")
+	}
+	metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	r := &testThriftReader{b: data[len(data)-8-metaLen : len(data)-8]}
+	meta := r.readStruct()
+	if r.pos != metaLen {
+		t.Fatal("footer is not fully read", r.pos, metaLen)
+	}
+	if meta[3] != int64(len(testRows)) {
+		t.Error("wrong num rows", meta[3])
+	}
+	schema := meta[2].([]any)
+	if len(schema) != 7 || schema[0].(map[int16]any)[5] != int64(6) || schema[3].(map[int16]any)[4] != "side" || schema[3].(map[int16]any)[6] != int64(parquetConvertedTypeUtf8) {
+		t.Fatal("wrong schema", schema)
+	}
+	columns := meta[4].([]any)[0].(map[int16]any)[1].([]any)
+	if len(columns) != 6 {
+		t.Fatal("wrong columns", len(columns))
+	}
+
+	// read values of columns from pages
+	values := make([][]byte, len(columns))
+	for i, col := range columns {
+		colMeta := col.(map[int16]any)[3].(map[int16]any)
+		offset := int(colMeta[9].(int64))
+		pr := &testThriftReader{b: data[offset:]}
+		header := pr.readStruct()
+		size := int(header[2].(int64))
+		if header[5].(map[int16]any)[1] != int64(len(testRows)) {
+			t.Error("wrong num values", i)
+		}
+		if colMeta[6] != int64(pr.pos+size) {
+			t.Error("wrong chunk size", i, colMeta[6])
+		}
+		values[i] = data[offset+pr.pos : offset+pr.pos+size]
+	}
+	for i, row := range testRows {
+		if id := int64(binary.LittleEndian.Uint64(values[0][i*8:])); id != row.Id {
+			t.Error("wrong id", id, row.Id)
+		}
+		if p := math.Float64frombits(binary.LittleEndian.Uint64(values[1][i*8:])); p != row.Price {
+			t.Error("wrong price", p, row.Price)
+		}
+		if maker := values[3][i/8]&(1<<(i%8)) != 0; maker != row.IsMaker {
+			t.Error("wrong is maker", i)
+		}
+	}
+	var names []string
+	for b := values[5]; len(b) > 0; {
+		n := binary.LittleEndian.Uint32(b)
+		names = append(names, string(b[4:4+n]))
+		b = b[4+n:]
+	}
+	if strings.Join(names, "|") != "a,b||long name" {
+		t.Error("wrong names", names)
+	}
+}
+
+// testThriftReader decodes thrift compact structs into maps of field id.
+type testThriftReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *testThriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *testThriftReader) zigzag() int64 {
+	u := r.uvarint()
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (r *testThriftReader) readStruct() map[int16]any {
+	m := map[int16]any{}
+	var last int16
+	for {
+		b := r.b[r.pos]
+		r.pos++
+		if b == 0 {
+			return m
+		}
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		last = id
+		m[id] = r.readValue(b & 0x0f)
+	}
+}
+
+func (r *testThriftReader) readValue(typ byte) any {
+	switch typ {
+	case thriftTypeI32, thriftTypeI64:
+		return r.zigzag()
+	case thriftTypeBinary:
+		n := int(r.uvarint())
+		s := string(r.b[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case thriftTypeList:
+		h := r.b[r.pos]
+		r.pos++
+		size := int(h >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.readValue(h & 0x0f)
+		}
+		return list
+	case thriftTypeStruct:
+		return r.readStruct()
+	}
+	panic("unsupported thrift type")
+}
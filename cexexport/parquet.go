@@ -0,0 +1,271 @@
+package cexexport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// Parquet is written in one row group with one uncompressed PLAIN data page per column,
+// all columns are required, strings are UTF8 byte arrays.
+// It is readable by common tools, ex. pyarrow, duckdb, spark.
+
+const parquetMagic = "PAR1"
+
+const parquetCreatedBy = "github.com/dwdwow/cex/cexexport"
+
+// parquet physical types
+const (
+	parquetTypeBoolean   = 0
+	parquetTypeInt64     = 2
+	parquetTypeDouble    = 5
+	parquetTypeByteArray = 6
+)
+
+const (
+	parquetEncodingPlain = 0
+	parquetEncodingRle   = 3
+
+	parquetRepetitionRequired = 0
+	parquetConvertedTypeUtf8  = 0
+	parquetPageTypeData       = 0
+	parquetCodecUncompressed  = 0
+)
+
+func parquetType(t ColumnType) int32 {
+	switch t {
+	case ColumnTypeInt64:
+		return parquetTypeInt64
+	case ColumnTypeFloat64:
+		return parquetTypeDouble
+	case ColumnTypeBool:
+		return parquetTypeBoolean
+	default:
+		return parquetTypeByteArray
+	}
+}
+
+type parquetColumnChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+// WriteParquet writes rows to w as parquet file.
+func WriteParquet[T any](w io.Writer, rows []T) error {
+	cols, err := Schema[T]()
+	if err != nil {
+		return err
+	}
+	values := make([]reflect.Value, len(rows))
+	for i, row := range rows {
+		values[i] = reflect.ValueOf(row)
+	}
+
+	file := &bytes.Buffer{}
+	file.WriteString(parquetMagic)
+	chunks := make([]parquetColumnChunk, len(cols))
+	var totalSize int64
+	for i, c := range cols {
+		data := plainValues(c, values)
+		header := &thriftWriter{}
+		header.begin()
+		header.i32(1, parquetPageTypeData)
+		header.i32(2, int32(len(data)))
+		header.i32(3, int32(len(data)))
+		header.structBegin(5)
+		header.i32(1, int32(len(rows)))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRle)
+		header.i32(4, parquetEncodingRle)
+		header.structEnd()
+		header.end()
+		chunks[i] = parquetColumnChunk{
+			offset:    int64(file.Len()),
+			size:      int64(header.buf.Len() + len(data)),
+			numValues: int64(len(rows)),
+		}
+		totalSize += chunks[i].size
+		file.Write(header.buf.Bytes())
+		file.Write(data)
+	}
+
+	meta := &thriftWriter{}
+	meta.begin()
+	meta.i32(1, 1)
+	meta.listBegin(2, thriftTypeStruct, len(cols)+1)
+	meta.begin()
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(cols)))
+	meta.end()
+	for _, c := range cols {
+		meta.begin()
+		meta.i32(1, parquetType(c.Type))
+		meta.i32(3, parquetRepetitionRequired)
+		meta.binary(4, c.Name)
+		if c.Type == ColumnTypeString {
+			meta.i32(6, parquetConvertedTypeUtf8)
+		}
+		meta.end()
+	}
+	meta.i64(3, int64(len(rows)))
+	meta.listBegin(4, thriftTypeStruct, 1)
+	meta.begin()
+	meta.listBegin(1, thriftTypeStruct, len(cols))
+	for i, c := range cols {
+		chunk := chunks[i]
+		meta.begin()
+		meta.i64(2, chunk.offset)
+		meta.structBegin(3)
+		meta.i32(1, parquetType(c.Type))
+		meta.listBegin(2, thriftTypeI32, 2)
+		meta.listI32(parquetEncodingPlain)
+		meta.listI32(parquetEncodingRle)
+		meta.listBegin(3, thriftTypeBinary, 1)
+		meta.listBinary(c.Name)
+		meta.i32(4, parquetCodecUncompressed)
+		meta.i64(5, chunk.numValues)
+		meta.i64(6, chunk.size)
+		meta.i64(7, chunk.size)
+		meta.i64(9, chunk.offset)
+		meta.structEnd()
+		meta.end()
+	}
+	meta.i64(2, totalSize)
+	meta.i64(3, int64(len(rows)))
+	meta.end()
+	meta.binary(6, parquetCreatedBy)
+	meta.end()
+
+	file.Write(meta.buf.Bytes())
+	_ = binary.Write(file, binary.LittleEndian, uint32(meta.buf.Len()))
+	file.WriteString(parquetMagic)
+	if _, err := w.Write(file.Bytes()); err != nil {
+		return fmt.Errorf("cexexport: write parquet, %w", err)
+	}
+	return nil
+}
+
+// plainValues encodes column values of rows by PLAIN encoding.
+func plainValues(c Column, rows []reflect.Value) []byte {
+	buf := &bytes.Buffer{}
+	switch c.Type {
+	case ColumnTypeInt64:
+		for _, v := range rows {
+			_ = binary.Write(buf, binary.LittleEndian, c.int64(v))
+		}
+	case ColumnTypeFloat64:
+		for _, v := range rows {
+			_ = binary.Write(buf, binary.LittleEndian, math.Float64bits(v.Field(c.index).Float()))
+		}
+	case ColumnTypeBool:
+		bits := make([]byte, (len(rows)+7)/8)
+		for i, v := range rows {
+			if v.Field(c.index).Bool() {
+				bits[i/8] |= 1 << (i % 8)
+			}
+		}
+		buf.Write(bits)
+	default:
+		for _, v := range rows {
+			s := v.Field(c.index).String()
+			_ = binary.Write(buf, binary.LittleEndian, uint32(len(s)))
+			buf.WriteString(s)
+		}
+	}
+	return buf.Bytes()
+}
+
+// thrift compact protocol types
+const (
+	thriftTypeI32    = 5
+	thriftTypeI64    = 6
+	thriftTypeBinary = 8
+	thriftTypeList   = 9
+	thriftTypeStruct = 12
+)
+
+// thriftWriter writes structs by thrift compact protocol, which is used by parquet metadata.
+// Fields of every struct must be written in ascending order of field id.
+type thriftWriter struct {
+	buf bytes.Buffer
+	// lastIds is stack of last field id of nested structs
+	lastIds []int16
+}
+
+// begin begins struct, which is top level or list element.
+func (w *thriftWriter) begin() {
+	w.lastIds = append(w.lastIds, 0)
+}
+
+// end writes stop field of struct.
+func (w *thriftWriter) end() {
+	w.buf.WriteByte(0)
+	w.lastIds = w.lastIds[:len(w.lastIds)-1]
+}
+
+func (w *thriftWriter) structBegin(id int16) {
+	w.fieldHeader(id, thriftTypeStruct)
+	w.begin()
+}
+
+func (w *thriftWriter) structEnd() {
+	w.end()
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastIds[len(w.lastIds)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) uvarint(v uint64) {
+	w.buf.Write(binary.AppendUvarint(nil, v))
+}
+
+// varint writes zigzag varint.
+func (w *thriftWriter) varint(v int64) {
+	w.uvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.fieldHeader(id, thriftTypeI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.fieldHeader(id, thriftTypeI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) binary(id int16, s string) {
+	w.fieldHeader(id, thriftTypeBinary)
+	w.listBinary(s)
+}
+
+func (w *thriftWriter) listBegin(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftTypeList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+	w.buf.WriteByte(0xF0 | elemType)
+	w.uvarint(uint64(size))
+}
+
+func (w *thriftWriter) listI32(v int32) {
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) listBinary(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
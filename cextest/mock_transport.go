@@ -0,0 +1,227 @@
+// Package cextest helps callers to unit test strategies without live keys.
+//
+//	mock := cextest.NewMockTransport().
+//		OnJSON(http.MethodGet, "/api/v3/time", http.StatusOK, map[string]any{"serverTime": 1})
+//	mock.Install(t)
+//	serverTime, err := bnc.QuerySpotServerTime()
+//	mock.AssertCalled(t, http.MethodGet, "/api/v3/time", 1)
+//
+// Recorder records real requests to fixtures, and replays them in CI, see NewRecorder.
+package cextest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// MockResponse is a canned response of MockTransport.
+type MockResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+
+	// Latency is waited before responding.
+	Latency time.Duration
+
+	// Err is returned instead of response, if it is not nil,
+	// ex. to simulate network error.
+	Err error
+}
+
+// RecordedRequest is a request received by MockTransport.
+type RecordedRequest struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+}
+
+// MockTransport implements cex.Transport with canned responses.
+// Responses are matched by method and url path, query is ignored.
+// If more than one response is set for a route, they are responded in order,
+// and the last one is repeated.
+type MockTransport struct {
+	mux      sync.Mutex
+	routes   map[string][]MockResponse
+	requests []RecordedRequest
+
+	latency time.Duration
+	err     error
+}
+
+func NewMockTransport() *MockTransport {
+	return &MockTransport{routes: map[string][]MockResponse{}}
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// On sets responses of method and path.
+func (m *MockTransport) On(method, path string, resps ...MockResponse) *MockTransport {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.routes[routeKey(method, path)] = append(m.routes[routeKey(method, path)], resps...)
+	return m
+}
+
+// OnJSON sets json response of method and path.
+// body is marshalled, if it is not []byte or string.
+func (m *MockTransport) OnJSON(method, path string, statusCode int, body any) *MockTransport {
+	var data []byte
+	switch b := body.(type) {
+	case []byte:
+		data = b
+	case string:
+		data = []byte(b)
+	default:
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			panic(fmt.Sprintf("cextest: marshal mock body, %v", err))
+		}
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	return m.On(method, path, MockResponse{StatusCode: statusCode, Header: header, Body: data})
+}
+
+// SetLatency sets latency of all responses, added to MockResponse.Latency.
+func (m *MockTransport) SetLatency(latency time.Duration) *MockTransport {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.latency = latency
+	return m
+}
+
+// SetErr makes all requests fail with err, set nil to recover.
+func (m *MockTransport) SetErr(err error) *MockTransport {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.err = err
+	return m
+}
+
+// RoundTrip implements http.RoundTripper.
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.mux.Lock()
+	m.requests = append(m.requests, RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL,
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+	key := routeKey(req.Method, req.URL.Path)
+	resps := m.routes[key]
+	var resp MockResponse
+	ok := len(resps) > 0
+	if ok {
+		resp = resps[0]
+		if len(resps) > 1 {
+			m.routes[key] = resps[1:]
+		}
+	}
+	latency := m.latency + resp.Latency
+	errAll := m.err
+	m.mux.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(latency):
+		}
+	}
+
+	if errAll != nil {
+		return nil, errAll
+	}
+	if !ok {
+		return nil, fmt.Errorf("cextest: no mock response for %v", key)
+	}
+	if resp.Err != nil {
+		return nil, resp.Err
+	}
+
+	statusCode := resp.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	header := resp.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Requests returns all received requests in order.
+func (m *MockTransport) Requests() []RecordedRequest {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return append([]RecordedRequest(nil), m.requests...)
+}
+
+// RequestsOf returns received requests of method and path.
+func (m *MockTransport) RequestsOf(method, path string) []RecordedRequest {
+	var reqs []RecordedRequest
+	for _, r := range m.Requests() {
+		if r.Method == method && r.URL.Path == path {
+			reqs = append(reqs, r)
+		}
+	}
+	return reqs
+}
+
+// AssertCalled fails t, if method and path are not requested times times.
+func (m *MockTransport) AssertCalled(t testing.TB, method, path string, times int) {
+	t.Helper()
+	if n := len(m.RequestsOf(method, path)); n != times {
+		t.Errorf("cextest: %v %v should be requested %v times, but %v", method, path, times, n)
+	}
+}
+
+// Install sets m as shared transport of cex,
+// and restores the previous one when t finishes.
+// Tests installing transport should not run in parallel.
+func (m *MockTransport) Install(t testing.TB) {
+	prev := cex.SharedTransport()
+	cex.SetSharedTransport(m)
+	t.Cleanup(func() {
+		cex.SetSharedTransport(prev)
+	})
+}
+
+// CltOpt returns cex.CltOpt sending single request by m,
+// so tests can run in parallel.
+func (m *MockTransport) CltOpt() cex.CltOpt {
+	return cex.CltOptTransport(m)
+}
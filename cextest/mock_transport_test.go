@@ -0,0 +1,78 @@
+package cextest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/cex/bnc"
+)
+
+func TestMockTransport_Install(t *testing.T) {
+	mock := NewMockTransport().
+		OnJSON(http.MethodGet, "/api/v3/time", http.StatusOK, map[string]any{"serverTime": 1700000000000})
+	mock.Install(t)
+
+	serverTime, err := bnc.QuerySpotServerTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serverTime != 1700000000000 {
+		t.Error("server time should be mocked, but", serverTime)
+	}
+	mock.AssertCalled(t, http.MethodGet, "/api/v3/time", 1)
+}
+
+func TestMockTransport_CltOpt(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", "10")
+	mock := NewMockTransport().
+		On(http.MethodGet, "/api/v3/account",
+			MockResponse{StatusCode: http.StatusTooManyRequests, Body: []byte(`{"code":-1003,"msg":"Too many requests"}`)},
+			MockResponse{StatusCode: http.StatusOK, Header: header, Body: []byte(`{"canTrade":true}`)},
+		)
+
+	user := bnc.NewUser("key", "secret")
+	_, _, err := user.SpotAccount(mock.CltOpt())
+	if !err.IsRateLimited() {
+		t.Error("first response should be rate limited, but", err.Error())
+	}
+	_, acct, err := user.SpotAccount(mock.CltOpt())
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if !acct.CanTrade {
+		t.Error("account should be mocked", acct)
+	}
+	if err.RateLimitUsage == nil || err.RateLimitUsage.UsedWeight["1M"] != 10 {
+		t.Error("used weight should be parsed from mocked header", err.RateLimitUsage)
+	}
+
+	reqs := mock.RequestsOf(http.MethodGet, "/api/v3/account")
+	if len(reqs) != 2 {
+		t.Fatal("account should be requested 2 times, but", len(reqs))
+	}
+	if reqs[0].Header.Get("X-MBX-APIKEY") != "key" || reqs[0].URL.Query().Get("signature") == "" {
+		t.Error("request should be signed", reqs[0].URL)
+	}
+}
+
+func TestMockTransport_Injection(t *testing.T) {
+	errNetwork := errors.New("network down")
+	mock := NewMockTransport().SetErr(errNetwork)
+	user := bnc.NewUser("key", "secret")
+	_, _, err := user.SpotAccount(mock.CltOpt())
+	if !errors.Is(err.Err, errNetwork) {
+		t.Error("request should fail with injected error, but", err.Error())
+	}
+
+	mock.SetErr(nil).SetLatency(time.Second).OnJSON(http.MethodGet, "/api/v3/account", http.StatusOK, "{}")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err = user.WithContext(ctx).SpotAccount(mock.CltOpt())
+	if !errors.Is(err.Err, context.DeadlineExceeded) {
+		t.Error("request should be canceled by ctx during latency, but", err.Error())
+	}
+}
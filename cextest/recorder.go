@@ -0,0 +1,308 @@
+package cextest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+// RecorderMode decides whether Recorder sends requests or serves them from fixture.
+type RecorderMode string
+
+const (
+	// RecorderModeRecord sends requests by underlying transport,
+	// and saves sanitized request and response pairs to fixture.
+	RecorderModeRecord RecorderMode = "RECORD"
+	// RecorderModeReplay serves requests from fixture without network.
+	RecorderModeReplay RecorderMode = "REPLAY"
+)
+
+// RecorderModeEnv is env key of RecorderModeFromEnv.
+const RecorderModeEnv = "CEX_RECORDER_MODE"
+
+// RecorderModeFromEnv returns RecorderModeRecord, if env CEX_RECORDER_MODE is RECORD,
+// otherwise RecorderModeReplay, so CI never sends real requests.
+func RecorderModeFromEnv() RecorderMode {
+	if RecorderMode(strings.ToUpper(os.Getenv(RecorderModeEnv))) == RecorderModeRecord {
+		return RecorderModeRecord
+	}
+	return RecorderModeReplay
+}
+
+// ErrNoInteraction is returned by Recorder in replay mode,
+// if no unused recorded interaction matches request.
+var ErrNoInteraction = errors.New("cextest: no recorded interaction")
+
+// volatileParamKeys are ignored when matching requests,
+// because they are different in every run.
+var volatileParamKeys = []string{"timestamp", "signature", "recvWindow"}
+
+// InteractionRequest is sanitized request of Interaction.
+type InteractionRequest struct {
+	Method string `json:"method"`
+	// URL is full url, sensitive query params are redacted by cex.RedactParams.
+	URL string `json:"url"`
+	// Body is request body, sensitive params of form body are redacted.
+	Body string `json:"body,omitempty"`
+}
+
+// InteractionResponse is response of Interaction.
+type InteractionResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Interaction is one recorded request and response pair.
+type Interaction struct {
+	Request  InteractionRequest  `json:"request"`
+	Response InteractionResponse `json:"response"`
+}
+
+// Fixture is file content of Recorder.
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type RecorderOpt func(*Recorder)
+
+// RecorderOptTransport sets transport sending requests in record mode,
+// default is cex.SharedTransport() when Recorder is created.
+func RecorderOptTransport(transport cex.Transport) RecorderOpt {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// RecorderOptIgnoreParams ignores more params when matching requests,
+// ex. client order id generated in every run.
+func RecorderOptIgnoreParams(keys ...string) RecorderOpt {
+	return func(r *Recorder) {
+		r.ignoredParams = append(r.ignoredParams, keys...)
+	}
+}
+
+// Recorder implements cex.Transport, which records requests to fixture, or replays them,
+// so tests of all cex packages can run in CI deterministically.
+// Requests are matched by method, url without query, query and form body params,
+// volatile params, ex. timestamp and signature, are ignored.
+// Matched interactions are replayed in recorded order, and every interaction is replayed once.
+// Request headers are never recorded, because they may contain api keys.
+//
+//	rec, err := cextest.NewRecorder("testdata/account.json", cextest.RecorderModeFromEnv())
+//	rec.Install(t)
+//	_, acct, reqErr := user.SpotAccount()
+type Recorder struct {
+	mux           sync.Mutex
+	path          string
+	mode          RecorderMode
+	transport     cex.Transport
+	ignoredParams []string
+	fixture       Fixture
+	used          []bool
+}
+
+// NewRecorder creates Recorder of fixture file path.
+// Fixture is loaded in replay mode, and error is returned if it can not be read.
+func NewRecorder(path string, mode RecorderMode, opts ...RecorderOpt) (*Recorder, error) {
+	r := &Recorder{
+		path:          path,
+		mode:          mode,
+		transport:     cex.SharedTransport(),
+		ignoredParams: slices.Clone(volatileParamKeys),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+	if mode != RecorderModeReplay {
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cextest: read fixture, %w", err)
+	}
+	if err := json.Unmarshal(data, &r.fixture); err != nil {
+		return nil, fmt.Errorf("cextest: unmarshal fixture %v, %w", path, err)
+	}
+	r.used = make([]bool, len(r.fixture.Interactions))
+	return r, nil
+}
+
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Interactions returns recorded or loaded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return append([]Interaction(nil), r.fixture.Interactions...)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	ireq := sanitizeRequest(req, body)
+	if r.mode == RecorderModeReplay {
+		return r.replay(req, ireq)
+	}
+	return r.record(req, ireq)
+}
+
+func (r *Recorder) record(req *http.Request, ireq InteractionRequest) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.fixture.Interactions = append(r.fixture.Interactions, Interaction{
+		Request: ireq,
+		Response: InteractionResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       string(body),
+		},
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, ireq InteractionRequest) (*http.Response, error) {
+	key := r.matchKey(ireq)
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for i, it := range r.fixture.Interactions {
+		if r.used[i] || r.matchKey(it.Request) != key {
+			continue
+		}
+		r.used[i] = true
+		resp := it.Response
+		header := resp.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %v %v", ErrNoInteraction, ireq.Method, ireq.URL)
+}
+
+// matchKey is method, url without query, and query and form body without ignored params.
+func (r *Recorder) matchKey(ireq InteractionRequest) string {
+	u, err := url.Parse(ireq.URL)
+	if err != nil {
+		return ireq.Method + " " + ireq.URL + " " + ireq.Body
+	}
+	query := u.Query()
+	u.RawQuery = ""
+	body := ireq.Body
+	// json body is matched as it is
+	if body != "" && !strings.HasPrefix(body, "{") && !strings.HasPrefix(body, "[") {
+		if form, err := url.ParseQuery(body); err == nil {
+			body = r.stripIgnored(form).Encode()
+		}
+	}
+	return ireq.Method + " " + u.String() + "?" + r.stripIgnored(query).Encode() + " " + body
+}
+
+func (r *Recorder) stripIgnored(params url.Values) url.Values {
+	for _, k := range r.ignoredParams {
+		params.Del(k)
+	}
+	return params
+}
+
+func sanitizeRequest(req *http.Request, body []byte) InteractionRequest {
+	u := *req.URL
+	u.User = nil
+	u.RawQuery = cex.RedactParams(u.Query()).Encode()
+	ireq := InteractionRequest{Method: req.Method, URL: u.String(), Body: string(body)}
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			ireq.Body = cex.RedactParams(form).Encode()
+		}
+	}
+	return ireq
+}
+
+// Save writes recorded interactions to fixture file, parent dirs are created.
+// It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == RecorderModeReplay {
+		return nil
+	}
+	r.mux.Lock()
+	data, err := json.MarshalIndent(r.fixture, "", "  ")
+	r.mux.Unlock()
+	if err != nil {
+		return fmt.Errorf("cextest: marshal fixture, %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("cextest: create fixture dir, %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("cextest: write fixture, %w", err)
+	}
+	return nil
+}
+
+// Install sets r as shared transport of cex,
+// and restores the previous one and saves fixture when t finishes.
+// Tests installing transport should not run in parallel.
+func (r *Recorder) Install(t testing.TB) {
+	prev := cex.SharedTransport()
+	cex.SetSharedTransport(r)
+	t.Cleanup(func() {
+		cex.SetSharedTransport(prev)
+		if err := r.Save(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// CltOpt returns cex.CltOpt sending single request by r,
+// so tests can run in parallel.
+func (r *Recorder) CltOpt() cex.CltOpt {
+	return cex.CltOptTransport(r)
+}
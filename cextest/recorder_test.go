@@ -0,0 +1,59 @@
+package cextest
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex/bnc"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures", "account.json")
+	mock := NewMockTransport().OnJSON(http.MethodGet, "/api/v3/account", http.StatusOK, `{"canTrade":true}`)
+	user := bnc.NewUser("apikey123", "secret456")
+
+	rec, err := NewRecorder(path, RecorderModeRecord, RecorderOptTransport(mock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, acct, reqErr := user.SpotAccount(rec.CltOpt()); reqErr.IsNotNil() || !acct.CanTrade {
+		t.Fatal("request should be sent by underlying transport", reqErr.Error())
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := mock.Requests()[0].URL.Query().Get("signature")
+	if strings.Contains(string(data), "apikey123") || strings.Contains(string(data), sig) {
+		t.Error("fixture should be sanitized", string(data))
+	}
+
+	rec, err = NewRecorder(path, RecorderModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// timestamp and signature are different from recorded ones
+	if _, acct, reqErr := user.SpotAccount(rec.CltOpt()); reqErr.IsNotNil() || !acct.CanTrade {
+		t.Fatal("request should be replayed", reqErr.Error())
+	}
+	if _, _, reqErr := user.SpotAccount(rec.CltOpt()); !errors.Is(reqErr.Err, ErrNoInteraction) {
+		t.Error("interaction should be replayed once, but", reqErr.Error())
+	}
+	if _, _, reqErr := user.SpotOpenOCOs(rec.CltOpt()); !errors.Is(reqErr.Err, ErrNoInteraction) {
+		t.Error("unrecorded request should fail, but", reqErr.Error())
+	}
+	if len(mock.Requests()) != 1 {
+		t.Error("replay should not send requests")
+	}
+
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderModeReplay); err == nil {
+		t.Error("missing fixture should fail in replay mode")
+	}
+}
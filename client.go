@@ -0,0 +1,106 @@
+package cex
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++              Cex REST Core: Shared Client           +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// NewPooledTransport returns http transport tuned for frequent requests
+// to a few cex hosts, connections are kept alive and reused.
+func NewPooledTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          200,
+		MaxIdleConnsPerHost:   50,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+var (
+	muxSharedClient sync.RWMutex
+	sharedTransport http.RoundTripper = NewPooledTransport()
+	sharedResty                       = newClientWithTransport(sharedTransport)
+)
+
+func newClientWithTransport(transport http.RoundTripper) *resty.Client {
+	return resty.NewWithClient(&http.Client{Transport: transport})
+}
+
+// sharedClient returns resty client shared by all requests of BackendResty.
+// It should not be modified, use CltOpt instead.
+func sharedClient() *resty.Client {
+	muxSharedClient.RLock()
+	defer muxSharedClient.RUnlock()
+	return sharedResty
+}
+
+// Transport sends http requests for all ReqMakers.
+// It can be replaced by SetSharedTransport or CltOptTransport,
+// ex. by cextest.MockTransport in unit tests.
+type Transport = http.RoundTripper
+
+// SharedTransport returns transport of shared client.
+func SharedTransport() Transport {
+	muxSharedClient.RLock()
+	defer muxSharedClient.RUnlock()
+	return sharedTransport
+}
+
+// SetSharedTransport replaces transport of shared client,
+// ex. to set proxy or custom tls config.
+func SetSharedTransport(transport Transport) {
+	muxSharedClient.Lock()
+	defer muxSharedClient.Unlock()
+	sharedTransport = transport
+	sharedResty = newClientWithTransport(transport)
+}
+
+// newRestyRequest converts req to request of shared client, if ro does not custom client.
+// Otherwise, ro is applied to a new client, which shares the pooled transport,
+// so CltOpts do not pollute shared client, and connections are still reused.
+func newRestyRequest(ro ReqOpts, req *HTTPRequest) *resty.Request {
+	var clt *resty.Client
+	if !ro.needClient() {
+		clt = sharedClient()
+	} else {
+		clt = newClientWithTransport(SharedTransport())
+		for _, opt := range ro.clientOpts {
+			opt(clt)
+		}
+		if ro.Timeout > 0 {
+			clt.SetTimeout(ro.Timeout)
+		}
+	}
+	r := clt.R().SetContext(req.Context())
+	r.URL = req.URL
+	if len(req.Header) > 0 {
+		r.Header = req.Header.Clone()
+	}
+	if len(req.Query) > 0 {
+		r.SetQueryParamsFromValues(req.Query)
+	}
+	if len(req.Form) > 0 {
+		r.SetFormDataFromValues(req.Form)
+	}
+	if req.Body != nil {
+		r.SetBody(req.Body)
+	}
+	return r
+}
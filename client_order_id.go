@@ -0,0 +1,85 @@
+package cex
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const DefaultClientOrderIdPrefix = "cex"
+
+// OrderReconciler is implemented by Traders, which attach client order ids to new orders.
+// ReconcileOrder queries order by client order id, after new order request failed by network errors,
+// placed is false, if cex does not know the order, then it is safe to place it again.
+type OrderReconciler interface {
+	ReconcileOrder(order *Order, opts ...CltOpt) (resp *Response, placed bool, err RequestError)
+}
+
+// ClientOrderIdRegistry generates client order ids,
+// and tracks orders whose placement result is unknown, ex. after network errors,
+// so they can be reconciled by client order id instead of being placed again.
+//
+// Ids are prefix + start time + "-" + sequence, all in base36, ex. "cex-m2x1k9a7qz-1f".
+// They are unique in process, and will not collide across restarts of the same prefix.
+// Cex limits length of client order id, ex. 36 for binance, so prefix should be short.
+type ClientOrderIdRegistry struct {
+	prefix string
+	seq    atomic.Int64
+
+	mux     sync.Mutex
+	pending map[string]Order
+}
+
+func NewClientOrderIdRegistry(prefix string) *ClientOrderIdRegistry {
+	if prefix != "" {
+		prefix += "-"
+	}
+	return &ClientOrderIdRegistry{
+		prefix:  prefix + strconv.FormatInt(time.Now().UnixNano(), 36) + "-",
+		pending: map[string]Order{},
+	}
+}
+
+// Next returns a new client order id.
+func (r *ClientOrderIdRegistry) Next() string {
+	return r.prefix + strconv.FormatInt(r.seq.Add(1), 36)
+}
+
+// Owns returns true, if id is generated by r.
+func (r *ClientOrderIdRegistry) Owns(id string) bool {
+	return len(id) > len(r.prefix) && strings.HasPrefix(id, r.prefix)
+}
+
+// Register tracks ord by its ClientOrderId, before it is placed.
+func (r *ClientOrderIdRegistry) Register(ord Order) {
+	if ord.ClientOrderId == "" {
+		return
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.pending[ord.ClientOrderId] = ord
+}
+
+// Resolve stops tracking order of id, after its placement result is known.
+func (r *ClientOrderIdRegistry) Resolve(id string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	delete(r.pending, id)
+}
+
+// Pending returns orders whose placement result is unknown, sorted by client order id.
+func (r *ClientOrderIdRegistry) Pending() []Order {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	ords := make([]Order, 0, len(r.pending))
+	for _, ord := range r.pending {
+		ords = append(ords, ord)
+	}
+	slices.SortFunc(ords, func(a, b Order) int {
+		return strings.Compare(a.ClientOrderId, b.ClientOrderId)
+	})
+	return ords
+}
@@ -0,0 +1,35 @@
+package cex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClientOrderIdRegistry(t *testing.T) {
+	reg := NewClientOrderIdRegistry(DefaultClientOrderIdPrefix)
+	ids := map[string]bool{}
+	for range 100 {
+		id := reg.Next()
+		if ids[id] {
+			t.Fatal("duplicated id", id)
+		}
+		if !strings.HasPrefix(id, DefaultClientOrderIdPrefix+"-") || len(id) > 36 || !reg.Owns(id) {
+			t.Fatal("wrong id", id)
+		}
+		ids[id] = true
+	}
+	if reg.Owns("cex-1") || NewClientOrderIdRegistry("").Owns(reg.Next()) {
+		t.Error("id is not owned by registry")
+	}
+
+	reg.Register(Order{Symbol: "ETHUSDT"})
+	reg.Register(Order{Symbol: "ETHUSDT", ClientOrderId: "b"})
+	reg.Register(Order{Symbol: "BTCUSDT", ClientOrderId: "a"})
+	if pending := reg.Pending(); len(pending) != 2 || pending[0].ClientOrderId != "a" || pending[1].ClientOrderId != "b" {
+		t.Error("wrong pending orders", pending)
+	}
+	reg.Resolve("a")
+	if pending := reg.Pending(); len(pending) != 1 || pending[0].ClientOrderId != "b" {
+		t.Error("wrong pending orders after resolving", pending)
+	}
+}
@@ -0,0 +1,81 @@
+package cex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewRestyRequest(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("X-Test")))
+	}))
+	defer svr.Close()
+
+	req := NewHTTPRequest().SetHeader("X-Test", "a")
+	resp, err := newRestyRequest(NewReqOpts(CltOptRetryCount(3, time.Second)), req).Get(svr.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body()) != "a" {
+		t.Error("request header should be sent, but", string(resp.Body()))
+	}
+	if sharedClient().RetryCount != 0 {
+		t.Error("opts should not pollute shared client")
+	}
+
+	resp, err = newRestyRequest(ReqOpts{}, req).Get(svr.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body()) != "a" {
+		t.Error("request header should be sent by shared client, but", string(resp.Body()))
+	}
+	if sharedClient().Header.Get("X-Test") != "" {
+		t.Error("request header should not pollute shared client")
+	}
+
+	resp, err = newRestyRequest(ReqOpts{}, NewHTTPRequest()).Get(svr.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body()) != "" {
+		t.Error("header of previous request should not be sent, but", string(resp.Body()))
+	}
+}
+
+func TestNewHTTPRequest_ReqOpts(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sleep") != "" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(r.Header.Get("X-Test")))
+	}))
+	defer svr.Close()
+
+	for _, backend := range []Backend{BackendResty, BackendNetHTTP} {
+		req := NewHTTPRequest(CltOptHeader("X-Test", "b"))
+		req.Method, req.URL = http.MethodGet, svr.URL
+		resp, err := send(NewReqOpts(CltOptBackend(backend)), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(resp.Body()) != "b" {
+			t.Error(backend, "header of opts should be sent, but", string(resp.Body()))
+		}
+
+		req = &HTTPRequest{Method: http.MethodGet, URL: svr.URL + "?sleep=1"}
+		if _, err := send(NewReqOpts(CltOptBackend(backend), CltOptTimeout(10*time.Millisecond)), req); err == nil {
+			t.Error(backend, "request should be timeout")
+		}
+	}
+	if sharedClient().GetClient().Timeout != 0 {
+		t.Error("timeout should not pollute shared client")
+	}
+
+	ro := NewReqOpts(CltOptQuery("a", "1"), CltOptRecvWindow(3000), nil)
+	if ro.Query.Get("a") != "1" || ro.RecvWindow != 3000 {
+		t.Error("wrong req opts", ro)
+	}
+}
@@ -0,0 +1,310 @@
+package cex
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++             Cex Core: Credential Provider           +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ErrCredentialNotFound is returned by CredentialProvider, if name is not found.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialProvider resolves Api by name from secret storage,
+// so api keys are not hardcoded or kept in local files.
+// Users of cex packages resolve keys lazily by it, ex. bnc.UserOptCredentialProvider.
+type CredentialProvider interface {
+	Credential(ctx context.Context, name string) (Api, error)
+}
+
+type CredentialProviderFunc func(ctx context.Context, name string) (Api, error)
+
+func (f CredentialProviderFunc) Credential(ctx context.Context, name string) (Api, error) {
+	return f(ctx, name)
+}
+
+// unmarshalCredential unmarshals json secret of Api fields, ex. {"apiKey":"...","secretKey":"..."}.
+func unmarshalCredential(name string, data []byte) (Api, error) {
+	api := Api{}
+	if err := json.Unmarshal(data, &api); err != nil {
+		return api, fmt.Errorf("cex: unmarshal credential %v, %w", name, err)
+	}
+	if api.ApiKey == "" {
+		return api, fmt.Errorf("%w, %v has no api key", ErrCredentialNotFound, name)
+	}
+	return api, nil
+}
+
+// ===========================================================
+// Env
+// -----------------------------------------------------------
+
+// EnvCredentialProvider reads Api from environment variables,
+// ex. name "bnc-main" with prefix "CEX" reads CEX_BNC_MAIN_API_KEY, CEX_BNC_MAIN_SECRET_KEY,
+// and optional CEX_BNC_MAIN_PASSPHRASE, CEX_BNC_MAIN_CEX, CEX_BNC_MAIN_ENV, CEX_BNC_MAIN_KEY_TYPE.
+type EnvCredentialProvider struct {
+	// Prefix is "CEX", if it is empty.
+	Prefix string
+}
+
+func (p EnvCredentialProvider) Credential(ctx context.Context, name string) (Api, error) {
+	prefix := p.Prefix
+	if prefix == "" {
+		prefix = "CEX"
+	}
+	key := strings.ToUpper(prefix + "_" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name))
+	api := Api{
+		Cex:        Name(os.Getenv(key + "_CEX")),
+		ApiKey:     os.Getenv(key + "_API_KEY"),
+		SecretKey:  os.Getenv(key + "_SECRET_KEY"),
+		Passphrase: os.Getenv(key + "_PASSPHRASE"),
+		Env:        Env(os.Getenv(key + "_ENV")),
+		KeyType:    KeyType(os.Getenv(key + "_KEY_TYPE")),
+	}
+	if api.ApiKey == "" {
+		return api, fmt.Errorf("%w, %v_API_KEY is empty", ErrCredentialNotFound, key)
+	}
+	return api, nil
+}
+
+// ===========================================================
+// File
+// -----------------------------------------------------------
+
+// FileCredentialProvider reads Api from yaml file of name to Api map, same as ReadApiKey.
+// File is read on every call, so it can be rotated without restarting.
+type FileCredentialProvider struct {
+	// Path is ~/cex/key/apikey.yml, if it is empty.
+	Path string
+}
+
+func (p FileCredentialProvider) Credential(ctx context.Context, name string) (Api, error) {
+	path := p.Path
+	if path == "" {
+		dirname, err := os.UserHomeDir()
+		if err != nil {
+			return Api{}, fmt.Errorf("cex: credential file, %w", err)
+		}
+		path = dirname + apiKeyFileRelativePath
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Api{}, fmt.Errorf("cex: credential file, %w", err)
+	}
+	apis := map[string]Api{}
+	if err := yaml.Unmarshal(data, &apis); err != nil {
+		return Api{}, fmt.Errorf("cex: unmarshal credential file %v, %w", path, err)
+	}
+	api, ok := apis[name]
+	if !ok {
+		return api, fmt.Errorf("%w, %v is not in %v", ErrCredentialNotFound, name, path)
+	}
+	return api, nil
+}
+
+// ===========================================================
+// Vault
+// -----------------------------------------------------------
+
+// VaultCredentialProvider reads Api from HashiCorp Vault KV v2 secret engine,
+// secret of name is at {Mount}/data/{name}, and its data has Api fields,
+// ex. vault kv put secret/bnc-main apiKey=... secretKey=...
+type VaultCredentialProvider struct {
+	// Addr is VAULT_ADDR, if it is empty.
+	Addr string
+	// Token is VAULT_TOKEN, if it is empty.
+	Token string
+	// Namespace is optional namespace of Vault Enterprise.
+	Namespace string
+	// Mount is "secret", if it is empty.
+	Mount string
+	// Client is http.DefaultClient, if it is nil.
+	Client *http.Client
+}
+
+func (p VaultCredentialProvider) Credential(ctx context.Context, name string) (Api, error) {
+	addr, token, mount := p.Addr, p.Token, p.Mount
+	if addr == "" {
+		addr = os.Getenv("VAULT_ADDR")
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if mount == "" {
+		mount = "secret"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(addr, "/")+"/v1/"+mount+"/data/"+name, nil)
+	if err != nil {
+		return Api{}, fmt.Errorf("cex: new vault request, %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+	body, err := doCredentialRequest(p.Client, req, name)
+	if err != nil {
+		return Api{}, err
+	}
+	secret := struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return Api{}, fmt.Errorf("cex: unmarshal vault secret %v, %w", name, err)
+	}
+	return unmarshalCredential(name, secret.Data.Data)
+}
+
+func doCredentialRequest(client *http.Client, req *http.Request, name string) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cex: request credential %v, %w", name, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cex: read credential %v, %w", name, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w, %v", ErrCredentialNotFound, name)
+	case resp.StatusCode == http.StatusBadRequest && bytes.Contains(body, []byte("ResourceNotFoundException")):
+		return nil, fmt.Errorf("%w, %v", ErrCredentialNotFound, name)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("cex: request credential %v, http status %v, %s", name, resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// ===========================================================
+// AWS Secrets Manager
+// -----------------------------------------------------------
+
+// AWSSecretsManagerCredentialProvider reads Api from AWS Secrets Manager,
+// SecretString of secret id name is json of Api fields.
+// Requests are signed by AWS Signature Version 4.
+type AWSSecretsManagerCredentialProvider struct {
+	// Region is AWS_REGION, if it is empty.
+	Region string
+	// AccessKeyId, SecretAccessKey and SessionToken are
+	// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN, if AccessKeyId is empty.
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+	// Endpoint is https://secretsmanager.{Region}.amazonaws.com, if it is empty.
+	Endpoint string
+	// Client is http.DefaultClient, if it is nil.
+	Client *http.Client
+}
+
+func (p AWSSecretsManagerCredentialProvider) Credential(ctx context.Context, name string) (Api, error) {
+	region := p.Region
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	accessKeyId, secretAccessKey, sessionToken := p.AccessKeyId, p.SecretAccessKey, p.SessionToken
+	if accessKeyId == "" {
+		accessKeyId = os.Getenv("AWS_ACCESS_KEY_ID")
+		secretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		sessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = "https://secretsmanager." + region + ".amazonaws.com"
+	}
+	payload, _ := json.Marshal(map[string]string{"SecretId": name})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/", bytes.NewReader(payload))
+	if err != nil {
+		return Api{}, fmt.Errorf("cex: new secrets manager request, %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+	signAWSRequestV4(req, payload, time.Now().UTC(), region, "secretsmanager", accessKeyId, secretAccessKey)
+
+	body, err := doCredentialRequest(p.Client, req, name)
+	if err != nil {
+		return Api{}, err
+	}
+	secret := struct {
+		SecretString string `json:"SecretString"`
+	}{}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return Api{}, fmt.Errorf("cex: unmarshal secrets manager secret %v, %w", name, err)
+	}
+	return unmarshalCredential(name, []byte(secret.SecretString))
+}
+
+// signAWSRequestV4 sets X-Amz-Date and Authorization headers of req.
+// Path of req must be "/" without query, which is enough for json rpc apis of AWS.
+func signAWSRequestV4(req *http.Request, payload []byte, t time.Time, region, service, accessKeyId, secretAccessKey string) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(req.Header.Get(k))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+	canonicalHeaders := ""
+	for _, k := range names {
+		canonicalHeaders += k + ":" + headers[k] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	payloadHash := sha256.Sum256(payload)
+	canonicalRequest := req.Method + "\n/\n\n" + canonicalHeaders + "\n" + signedHeaders + "\n" + hex.EncodeToString(payloadHash[:])
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signature := awsSignatureV4(secretAccessKey, date, region, service, stringToSign)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%v/%v, SignedHeaders=%v, Signature=%v", accessKeyId, scope, signedHeaders, signature))
+}
+
+func awsSignatureV4(secretAccessKey, date, region, service, stringToSign string) string {
+	sign := func(key []byte, data string) []byte {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data))
+		return h.Sum(nil)
+	}
+	key := sign([]byte("AWS4"+secretAccessKey), date)
+	key = sign(key, region)
+	key = sign(key, service)
+	key = sign(key, "aws4_request")
+	return hex.EncodeToString(sign(key, stringToSign))
+}
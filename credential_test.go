@@ -0,0 +1,117 @@
+package cex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnvCredentialProvider(t *testing.T) {
+	t.Setenv("TEST_BNC_MAIN_API_KEY", "key")
+	t.Setenv("TEST_BNC_MAIN_SECRET_KEY", "secret")
+	t.Setenv("TEST_BNC_MAIN_ENV", string(EnvTestnet))
+	p := EnvCredentialProvider{Prefix: "test"}
+	api, err := p.Credential(context.Background(), "bnc-main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.ApiKey != "key" || api.SecretKey != "secret" || api.Env != EnvTestnet {
+		t.Error("wrong api", api)
+	}
+	if _, err = p.Credential(context.Background(), "none"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Error("missing credential should not be found", err)
+	}
+}
+
+func TestFileCredentialProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apikey.yml")
+	if err := os.WriteFile(path, []byte("main:\n  cex: BINANCE\n  apiKey: key\n  secretKey: secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := FileCredentialProvider{Path: path}
+	api, err := p.Credential(context.Background(), "main")
+	if err != nil || api.Cex != BINANCE || api.SecretKey != "secret" {
+		t.Error("wrong api", api, err)
+	}
+	if _, err = p.Credential(context.Background(), "none"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Error("missing credential should not be found", err)
+	}
+}
+
+func TestVaultCredentialProvider(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/main" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"apiKey":"key","secretKey":"secret"},"metadata":{"version":1}}}`))
+	}))
+	defer sv.Close()
+
+	p := VaultCredentialProvider{Addr: sv.URL, Token: "token", Mount: "kv"}
+	api, err := p.Credential(context.Background(), "main")
+	if err != nil || api.ApiKey != "key" || api.SecretKey != "secret" {
+		t.Error("wrong api", api, err)
+	}
+	if _, err = p.Credential(context.Background(), "none"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Error("missing credential should not be found", err)
+	}
+	p.Token = "bad"
+	if _, err = p.Credential(context.Background(), "main"); err == nil || errors.Is(err, ErrCredentialNotFound) {
+		t.Error("forbidden request should fail", err)
+	}
+}
+
+func TestAWSSignatureV4(t *testing.T) {
+	// example of AWS Signature Version 4 documentation
+	stringToSign := "AWS4-HMAC-SHA256\n20150830T123600Z\n20150830/us-east-1/iam/aws4_request\nf536975d06c0309214f805bb90ccff089219ecd68b2577efef23edd43b7e1a59"
+	sig := awsSignatureV4("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20150830", "us-east-1", "iam", stringToSign)
+	if sig != "5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7" {
+		t.Error("wrong signature", sig)
+	}
+}
+
+func TestAWSSecretsManagerCredentialProvider(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		auth := r.Header.Get("Authorization")
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(auth, "/us-east-1/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature=") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if string(body) != `{"SecretId":"main"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"Name":"main","SecretString":"{\"apiKey\":\"key\",\"secretKey\":\"secret\",\"keyType\":\"ED25519\"}"}`))
+	}))
+	defer sv.Close()
+
+	p := AWSSecretsManagerCredentialProvider{
+		Region:          "us-east-1",
+		AccessKeyId:     "AKID",
+		SecretAccessKey: "secret",
+		SessionToken:    "session",
+		Endpoint:        sv.URL,
+	}
+	api, err := p.Credential(context.Background(), "main")
+	if err != nil || api.ApiKey != "key" || api.KeyType != KeyTypeEd25519 {
+		t.Error("wrong api", api, err)
+	}
+	if _, err = p.Credential(context.Background(), "none"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Error("missing credential should not be found", err)
+	}
+}
@@ -0,0 +1,219 @@
+package cex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++               Cex REST Core: Decimal                +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// Decimal keeps exact decimal text of prices and quantities,
+// so values are not rounded by float64.
+// It can be used as field of params, because its kind is string,
+// and as field of response struct, which accepts json string and number.
+// Convert it by Float64 only when calculating, or by any decimal library, ex.
+//
+//	decimal.RequireFromString(d.String())
+type Decimal string
+
+// DecimalFromFloat formats f with the minimal digits that represent f exactly.
+func DecimalFromFloat(f float64) Decimal {
+	return Decimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// Float64 returns 0, if d is empty or invalid.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(string(d), 64)
+	return f
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Float64() == 0
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*d = Decimal(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("cex: unmarshal decimal %s, %w", data, err)
+	}
+	*d = Decimal(n)
+	return nil
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(d))
+}
+
+// NumberMode decides how StdBodyUnmarshaler decodes json numbers
+// into interface values, ex. any, map[string]any and []any.
+type NumberMode int
+
+const (
+	// NumberModeFloat decodes numbers into float64, default mode.
+	NumberModeFloat NumberMode = iota
+
+	// NumberModeDecimal decodes numbers into json.Number,
+	// which keeps exact decimal text.
+	NumberModeDecimal
+)
+
+var (
+	muxDefaultNumberMode sync.RWMutex
+	defaultNumberMode    NumberMode
+)
+
+func DefaultNumberMode() NumberMode {
+	muxDefaultNumberMode.RLock()
+	defer muxDefaultNumberMode.RUnlock()
+	return defaultNumberMode
+}
+
+// SetDefaultNumberMode sets number mode of StdBodyUnmarshaler globally.
+// Typed fields are not affected, use Decimal fields to keep exact values of them.
+func SetDefaultNumberMode(mode NumberMode) {
+	muxDefaultNumberMode.Lock()
+	defer muxDefaultNumberMode.Unlock()
+	defaultNumberMode = mode
+}
+
+func jsonUnmarshal(data []byte, v any, mode NumberMode) error {
+	if mode != NumberModeDecimal {
+		return json.Unmarshal(data, v)
+	}
+	r := bytesReaderPool.Get().(*bytes.Reader)
+	defer bytesReaderPool.Put(r)
+	r.Reset(data)
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	err := dec.Decode(v)
+	r.Reset(nil)
+	return err
+}
+
+var bytesReaderPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Reader)
+	},
+}
+
+// DecimalBodyUnmarshaler is same as StdBodyUnmarshaler in NumberModeDecimal.
+func DecimalBodyUnmarshaler[D any](data []byte) (D, *RespBodyUnmarshalerError) {
+	return stdBodyUnmarshaler[D](data, NumberModeDecimal)
+}
+
+// DecimalReqConfig returns a copy of config, whose response is unmarshalled into
+// DecimalRespDataType by DecimalBodyUnmarshaler.
+// DecimalRespDataType is usually defined by caller with Decimal fields,
+// so precision is selectable per ReqConfig, ex.
+//
+//	type Ticker struct {
+//		Symbol string      `json:"symbol"`
+//		Price  cex.Decimal `json:"price"`
+//	}
+//	config := cex.DecimalReqConfig[cex.NilReqData, []bnc.SpotPriceTicker, []Ticker](bnc.SpotPricesConfig)
+//
+// Body is still checked by the original unmarshaler firstly,
+// so cex custom errors in body are not lost.
+func DecimalReqConfig[ReqDataType, RespDataType, DecimalRespDataType any](
+	config ReqConfig[ReqDataType, RespDataType],
+) ReqConfig[ReqDataType, DecimalRespDataType] {
+	unmarshaler := config.RespBodyUnmarshaler
+	return ReqConfig[ReqDataType, DecimalRespDataType]{
+		ReqBaseConfig:         config.ReqBaseConfig,
+		HTTPStatusCodeChecker: config.HTTPStatusCodeChecker,
+		RespBodyUnmarshaler: func(body []byte) (DecimalRespDataType, *RespBodyUnmarshalerError) {
+			if unmarshaler != nil {
+				if _, err := unmarshaler(body); err != nil {
+					var d DecimalRespDataType
+					return d, err
+				}
+			}
+			return DecimalBodyUnmarshaler[DecimalRespDataType](body)
+		},
+		RetryPolicy: config.RetryPolicy,
+	}
+}
+
+// bodyKind is how response body is decoded into response data type.
+type bodyKind uint8
+
+const (
+	bodyKindUnsupported bodyKind = iota
+	bodyKindString
+	bodyKindJson
+)
+
+type bodyTypeInfo struct {
+	typ  reflect.Type
+	kind bodyKind
+}
+
+// bodyTypeInfos caches bodyTypeInfo by response data type,
+// so type is inspected only once, not on every response.
+var bodyTypeInfos sync.Map // reflect.Type -> bodyTypeInfo
+
+func bodyTypeInfoOf[D any]() bodyTypeInfo {
+	typ := reflect.TypeFor[D]()
+	if info, ok := bodyTypeInfos.Load(typ); ok {
+		return info.(bodyTypeInfo)
+	}
+	info := bodyTypeInfo{typ: typ}
+	switch typ.Kind() {
+	case reflect.String:
+		info.kind = bodyKindString
+	case reflect.Slice, reflect.Struct, reflect.Map:
+		info.kind = bodyKindJson
+	}
+	bodyTypeInfos.Store(typ, info)
+	return info
+}
+
+// stdBodyUnmarshaler decodes data into D directly,
+// no error is allocated if data is decoded successfully.
+func stdBodyUnmarshaler[D any](data []byte, mode NumberMode) (D, *RespBodyUnmarshalerError) {
+	var d D
+	info := bodyTypeInfoOf[D]()
+	switch info.kind {
+	case bodyKindString:
+		if p, ok := any(&d).(*string); ok {
+			*p = string(data)
+		} else {
+			reflect.ValueOf(&d).Elem().SetString(string(data))
+		}
+	case bodyKindJson:
+		if err := jsonUnmarshal(data, &d, mode); err != nil {
+			return d, &RespBodyUnmarshalerError{Err: fmt.Errorf("%w: unmarshal response body, %w", ErrJsonUnmarshal, err)}
+		}
+		if err := checkUnknownFields(data, info.typ, DefaultDecodeMode()); err != nil {
+			return d, &RespBodyUnmarshalerError{Err: err}
+		}
+	default:
+		return d, &RespBodyUnmarshalerError{Err: fmt.Errorf("response data type %v is not supported", info.typ.Kind())}
+	}
+	return d, nil
+}
@@ -0,0 +1,131 @@
+package cex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	var v struct {
+		Str  Decimal `json:"str"`
+		Num  Decimal `json:"num"`
+		Null Decimal `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"str":"0.10000000","num":123456789.123456789,"null":null}`), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Str != "0.10000000" {
+		t.Errorf("str: %v", v.Str)
+	}
+	if v.Num != "123456789.123456789" {
+		t.Errorf("num: %v", v.Num)
+	}
+	if v.Null != "" || !v.Null.IsZero() {
+		t.Errorf("null: %v", v.Null)
+	}
+	d, err := json.Marshal(v.Num)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(d) != `"123456789.123456789"` {
+		t.Errorf("marshal: %s", d)
+	}
+	if DecimalFromFloat(0.1) != "0.1" {
+		t.Errorf("from float: %v", DecimalFromFloat(0.1))
+	}
+}
+
+type testDecimalTicker struct {
+	Symbol string  `json:"symbol"`
+	Price  Decimal `json:"price"`
+}
+
+func TestDecimalReqConfig(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"symbol":"ETHUSDT","price":3000.123456789012345}]`))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[NilReqData, []map[string]any]{
+		ReqBaseConfig: ReqBaseConfig{
+			BaseUrl: sv.URL,
+			Path:    "/ticker",
+			Method:  http.MethodGet,
+		},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[[]map[string]any],
+	}
+
+	_, tickers, err := Request(testLoggerReqMaker{}, DecimalReqConfig[NilReqData, []map[string]any, []testDecimalTicker](config), nil)
+	if err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	if len(tickers) != 1 || tickers[0].Price != "3000.123456789012345" {
+		t.Errorf("tickers: %+v", tickers)
+	}
+}
+
+func TestSetDefaultNumberMode(t *testing.T) {
+	defer SetDefaultNumberMode(DefaultNumberMode())
+	body := []byte(`{"price":3000.123456789012345}`)
+
+	m, err := StdBodyUnmarshaler[map[string]any](body)
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, ok := m["price"].(float64); !ok {
+		t.Errorf("float mode: %T", m["price"])
+	}
+
+	SetDefaultNumberMode(NumberModeDecimal)
+	m, err = StdBodyUnmarshaler[map[string]any](body)
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	n, ok := m["price"].(json.Number)
+	if !ok || n.String() != "3000.123456789012345" {
+		t.Errorf("decimal mode: %T %v", m["price"], m["price"])
+	}
+}
+
+type testBodyString string
+
+func TestStdBodyUnmarshaler(t *testing.T) {
+	s, err := StdBodyUnmarshaler[testBodyString]([]byte("ok"))
+	if err != nil || s != "ok" {
+		t.Error("wrong string body", s, err)
+	}
+	if _, err := StdBodyUnmarshaler[int]([]byte("1")); err == nil {
+		t.Error("int body should not be supported")
+	}
+	if _, err := StdBodyUnmarshaler[map[string]int]([]byte("{")); err == nil || !err.Is(ErrJsonUnmarshal) {
+		t.Error("invalid json should fail", err)
+	}
+	body := []byte(`[{"symbol":"ETHUSDT","price":"2350.5"}]`)
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = StdBodyUnmarshaler[[]struct {
+			Symbol string  `json:"symbol"`
+			Price  float64 `json:"price,string"`
+		}](body)
+	})
+	if allocs > 6 {
+		t.Error("too many allocs", allocs)
+	}
+}
+
+func BenchmarkStdBodyUnmarshaler(b *testing.B) {
+	type ticker struct {
+		Symbol string  `json:"symbol"`
+		Price  float64 `json:"price,string"`
+	}
+	body := []byte(`[{"symbol":"ETHUSDT","price":"2350.5"},{"symbol":"BTCUSDT","price":"65000.1"}]`)
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := StdBodyUnmarshaler[[]ticker](body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
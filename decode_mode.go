@@ -0,0 +1,281 @@
+package cex
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++             Cex REST Core: Decode Mode              +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ErrUnknownFields is returned in DecodeModeStrict,
+// if response body has fields which are not in response data type.
+var ErrUnknownFields = errors.New("unknown fields in response body")
+
+// DecodeMode decides how unknown fields of response body are handled,
+// which helps to catch schema drift of cex apis early.
+type DecodeMode int
+
+const (
+	// DecodeModeLenient ignores unknown fields, default mode, used in production.
+	DecodeModeLenient DecodeMode = iota
+
+	// DecodeModeWarn reports unknown fields to UnknownFieldsHandler,
+	// response data is still returned.
+	DecodeModeWarn
+
+	// DecodeModeStrict decodes body by DisallowUnknownFields,
+	// and reports unknown fields to UnknownFieldsHandler,
+	// request fails with ErrUnknownFields.
+	DecodeModeStrict
+)
+
+var (
+	muxDefaultDecodeMode sync.RWMutex
+	defaultDecodeMode    DecodeMode
+)
+
+func DefaultDecodeMode() DecodeMode {
+	muxDefaultDecodeMode.RLock()
+	defer muxDefaultDecodeMode.RUnlock()
+	return defaultDecodeMode
+}
+
+// SetDefaultDecodeMode sets decode mode of StdBodyUnmarshaler globally, ex. DecodeModeWarn in development.
+// Use StrictReqConfig to set mode per config.
+func SetDefaultDecodeMode(mode DecodeMode) {
+	muxDefaultDecodeMode.Lock()
+	defer muxDefaultDecodeMode.Unlock()
+	defaultDecodeMode = mode
+}
+
+// UnknownFieldsHandler handles paths of unknown fields of response body, ex. data[0].newField,
+// typ is the type body is decoded into.
+type UnknownFieldsHandler func(typ reflect.Type, fields []string)
+
+var (
+	muxUnknownFieldsHandler sync.RWMutex
+	unknownFieldsHandler    UnknownFieldsHandler = slogUnknownFieldsHandler
+)
+
+func slogUnknownFieldsHandler(typ reflect.Type, fields []string) {
+	slog.Warn("Cex response has unknown fields", "type", typ.String(), "fields", fields)
+}
+
+// SetUnknownFieldsHandler replaces the default handler, which logs by slog at warn level.
+func SetUnknownFieldsHandler(handler UnknownFieldsHandler) {
+	muxUnknownFieldsHandler.Lock()
+	defer muxUnknownFieldsHandler.Unlock()
+	if handler == nil {
+		handler = slogUnknownFieldsHandler
+	}
+	unknownFieldsHandler = handler
+}
+
+func reportUnknownFields(typ reflect.Type, fields []string) {
+	muxUnknownFieldsHandler.RLock()
+	handler := unknownFieldsHandler
+	muxUnknownFieldsHandler.RUnlock()
+	handler(typ, fields)
+}
+
+// checkUnknownFields checks fields of data against typ by mode.
+// Error is returned in DecodeModeStrict only.
+func checkUnknownFields(data []byte, typ reflect.Type, mode DecodeMode) error {
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Pointer:
+	default:
+		return nil
+	}
+	switch mode {
+	case DecodeModeWarn:
+		if fields, err := UnknownFields(data, typ); err == nil && len(fields) > 0 {
+			reportUnknownFields(typ, fields)
+		}
+	case DecodeModeStrict:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(reflect.New(typ).Interface()); err == nil {
+			return nil
+		}
+		fields, err := UnknownFields(data, typ)
+		if err != nil {
+			return fmt.Errorf("%w: check unknown fields, %w", ErrJsonUnmarshal, err)
+		}
+		if len(fields) > 0 {
+			reportUnknownFields(typ, fields)
+			return fmt.Errorf("%w, %v: %v", ErrUnknownFields, typ, strings.Join(fields, ", "))
+		}
+	}
+	return nil
+}
+
+// StrictReqConfig returns a copy of config, whose response body is checked for unknown fields by mode,
+// after it is unmarshalled by the original unmarshaler, so cex custom errors in body are not lost.
+// BodyType is type of the whole body, which is RespDataType in most binance configs,
+// and the envelope type in other cex, ex.
+//
+//	config := cex.StrictReqConfig[cex.NilReqData, []bnc.SpotPriceTicker, []bnc.SpotPriceTicker](bnc.SpotPricesConfig, cex.DecodeModeStrict)
+//	config := cex.StrictReqConfig[okx.InstrumentsParams, []okx.Instrument, okx.RespData[[]okx.Instrument]](okx.InstrumentsConfig, cex.DecodeModeWarn)
+func StrictReqConfig[ReqDataType, RespDataType, BodyType any](
+	config ReqConfig[ReqDataType, RespDataType],
+	mode DecodeMode,
+) ReqConfig[ReqDataType, RespDataType] {
+	unmarshaler := config.RespBodyUnmarshaler
+	if unmarshaler == nil {
+		unmarshaler = StdBodyUnmarshaler[RespDataType]
+	}
+	bodyType := reflect.TypeFor[BodyType]()
+	config.RespBodyUnmarshaler = func(body []byte) (RespDataType, *RespBodyUnmarshalerError) {
+		d, errUnmar := unmarshaler(body)
+		if errUnmar != nil {
+			return d, errUnmar
+		}
+		if err := checkUnknownFields(body, bodyType, mode); err != nil {
+			return d, &RespBodyUnmarshalerError{Err: err}
+		}
+		return d, nil
+	}
+	return config
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// UnknownFields returns paths of fields in json data, which are not decoded into typ,
+// ex. newField, data[0].newField.
+// Fields are matched like encoding/json, exactly firstly, and then case-insensitively.
+// Values of types implementing json.Unmarshaler and interface types are not checked.
+func UnknownFields(data []byte, typ reflect.Type) ([]string, error) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	var fields []string
+	walkUnknownFields(v, typ, "", &fields)
+	return fields, nil
+}
+
+func walkUnknownFields(v any, typ reflect.Type, path string, fields *[]string) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if reflect.PointerTo(typ).Implements(jsonUnmarshalerType) {
+		return
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		known := jsonFieldsOf(typ)
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			fieldType, ok := known.lookup(k)
+			if !ok {
+				*fields = append(*fields, joinFieldPath(path, k))
+				continue
+			}
+			walkUnknownFields(obj[k], fieldType, joinFieldPath(path, k), fields)
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			walkUnknownFields(obj[k], typ.Elem(), joinFieldPath(path, k), fields)
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]any)
+		if !ok {
+			return
+		}
+		for i, item := range arr {
+			walkUnknownFields(item, typ.Elem(), fmt.Sprintf("%v[%v]", path, i), fields)
+		}
+	}
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonFields are json names of struct fields and their types.
+type jsonFields map[string]reflect.Type
+
+func (f jsonFields) lookup(key string) (reflect.Type, bool) {
+	if t, ok := f[key]; ok {
+		return t, true
+	}
+	for name, t := range f {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+var jsonFieldsCache sync.Map // reflect.Type -> jsonFields
+
+func jsonFieldsOf(typ reflect.Type) jsonFields {
+	if cached, ok := jsonFieldsCache.Load(typ); ok {
+		return cached.(jsonFields)
+	}
+	fields := jsonFields{}
+	collectJsonFields(typ, fields)
+	jsonFieldsCache.Store(typ, fields)
+	return fields
+}
+
+func collectJsonFields(typ reflect.Type, fields jsonFields) {
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && tag == "" && ft.Kind() == reflect.Struct {
+			collectJsonFields(ft, fields)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = f.Type
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cex
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testDecodeBase struct {
+	Id int64 `json:"id"`
+}
+
+type testDecodeItem struct {
+	testDecodeBase
+	Price  string            `json:"price"`
+	Extra  map[string]string `json:"extra"`
+	Raw    any               `json:"raw"`
+	Amount Decimal           `json:"amount"`
+	Skip   string            `json:"-"`
+	Name   string
+}
+
+type testDecodeEnvelope struct {
+	Code int              `json:"code"`
+	Data []testDecodeItem `json:"data"`
+}
+
+func TestUnknownFields(t *testing.T) {
+	body := `{"code":0,"data":[{"id":1,"price":"1","name":"a","extra":{"k":"v"},"raw":{"x":1},"amount":"1"},{"id":2,"new":1,"Skip":"s"}],"ts":1}`
+	fields, err := UnknownFields([]byte(body), reflect.TypeFor[testDecodeEnvelope]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(fields, ",") != "data[1].Skip,data[1].new,ts" {
+		t.Error("wrong unknown fields", fields)
+	}
+}
+
+func TestStrictReqConfig(t *testing.T) {
+	var reported []string
+	SetUnknownFieldsHandler(func(typ reflect.Type, fields []string) {
+		reported = fields
+	})
+	defer SetUnknownFieldsHandler(nil)
+
+	config := ReqConfig[NilReqData, []testDecodeItem]{
+		RespBodyUnmarshaler: func(body []byte) ([]testDecodeItem, *RespBodyUnmarshalerError) {
+			env, err := StdBodyUnmarshaler[testDecodeEnvelope](body)
+			if err != nil {
+				return nil, err
+			}
+			return env.Data, nil
+		},
+	}
+	body := []byte(`{"code":0,"data":[{"id":1,"new":2}]}`)
+
+	warn := StrictReqConfig[NilReqData, []testDecodeItem, testDecodeEnvelope](config, DecodeModeWarn)
+	items, err := warn.RespBodyUnmarshaler(body)
+	if err != nil || len(items) != 1 || items[0].Id != 1 {
+		t.Fatal("warn mode should not fail", items, err)
+	}
+	if len(reported) != 1 || reported[0] != "data[0].new" {
+		t.Error("wrong reported fields", reported)
+	}
+
+	strict := StrictReqConfig[NilReqData, []testDecodeItem, testDecodeEnvelope](config, DecodeModeStrict)
+	if _, err = strict.RespBodyUnmarshaler(body); err == nil || !errors.Is(err, ErrUnknownFields) {
+		t.Error("strict mode should fail", err)
+	}
+	if _, err = strict.RespBodyUnmarshaler([]byte(`{"code":0,"data":[{"id":1}]}`)); err != nil {
+		t.Error("known fields should not fail", err)
+	}
+
+	reported = nil
+	if _, err = config.RespBodyUnmarshaler(body); err != nil || reported != nil {
+		t.Error("lenient mode should ignore unknown fields", err, reported)
+	}
+	SetDefaultDecodeMode(DecodeModeStrict)
+	defer SetDefaultDecodeMode(DecodeModeLenient)
+	if _, err = config.RespBodyUnmarshaler(body); err == nil || !errors.Is(err, ErrUnknownFields) {
+		t.Error("default strict mode should fail", err)
+	}
+}
@@ -0,0 +1,281 @@
+package cex
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++                   Cex Diagnostics                   +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ===========================================================
+// Latency
+// -----------------------------------------------------------
+
+const defaultLatencyWindowSize = 1000
+
+// LatencyStats is summary of latency samples in window.
+type LatencyStats struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min"`
+	Max   time.Duration `json:"max"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P99   time.Duration `json:"p99"`
+}
+
+// LatencyWindow keeps the latest samples, and computes rolling percentiles.
+type LatencyWindow struct {
+	mux     sync.Mutex
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+// NewLatencyWindow creates window holding the latest size samples.
+func NewLatencyWindow(size int) *LatencyWindow {
+	return &LatencyWindow{samples: make([]time.Duration, max(size, 1))}
+}
+
+func (w *LatencyWindow) Record(d time.Duration) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.samples[w.next] = d
+	w.next++
+	if w.next == len(w.samples) {
+		w.next = 0
+		w.full = true
+	}
+}
+
+func (w *LatencyWindow) sorted() []time.Duration {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	n := w.next
+	if w.full {
+		n = len(w.samples)
+	}
+	samples := slices.Clone(w.samples[:n])
+	slices.Sort(samples)
+	return samples
+}
+
+// Percentile returns p percentile of samples by nearest rank, p is in [0, 100].
+// Returns 0 if window is empty.
+func (w *LatencyWindow) Percentile(p float64) time.Duration {
+	return percentile(w.sorted(), p)
+}
+
+func (w *LatencyWindow) Stats() LatencyStats {
+	samples := w.sorted()
+	if len(samples) == 0 {
+		return LatencyStats{}
+	}
+	var sum time.Duration
+	for _, d := range samples {
+		sum += d
+	}
+	return LatencyStats{
+		Count: len(samples),
+		Min:   samples[0],
+		Max:   samples[len(samples)-1],
+		Mean:  sum / time.Duration(len(samples)),
+		P50:   percentile(samples, 50),
+		P90:   percentile(samples, 90),
+		P99:   percentile(samples, 99),
+	}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(sorted))+0.999999) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+type LatencyMonitorOpt func(*LatencyMonitor)
+
+// LatencyMonitorOptWindowSize sets sample number of every window, default 1000.
+func LatencyMonitorOptWindowSize(size int) LatencyMonitorOpt {
+	return func(m *LatencyMonitor) {
+		m.windowSize = size
+	}
+}
+
+// LatencyMonitorOptTimeSync corrects ws event latency by offset of TimeSync,
+// so skewed local clock does not affect latency.
+func LatencyMonitorOptTimeSync(timeSync *TimeSync) LatencyMonitorOpt {
+	return func(m *LatencyMonitor) {
+		m.timeSync = timeSync
+	}
+}
+
+// LatencyMonitor keeps latency windows by name.
+// REST round trip latency is recorded by LogRequest,
+// so LatencyMonitor can be used as Logger, ex. with MultiLogger.
+// Name of REST latency is base url + path.
+// WebSocket event latency is exchange event time to local receipt time,
+// and is recorded by RecordWsEvent with any name, ex. stream topic.
+type LatencyMonitor struct {
+	mux        sync.Mutex
+	windows    map[string]*LatencyWindow
+	windowSize int
+	timeSync   *TimeSync
+}
+
+func NewLatencyMonitor(opts ...LatencyMonitorOpt) *LatencyMonitor {
+	m := &LatencyMonitor{
+		windows:    map[string]*LatencyWindow{},
+		windowSize: defaultLatencyWindowSize,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func (m *LatencyMonitor) window(name string) *LatencyWindow {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	w, ok := m.windows[name]
+	if !ok {
+		w = NewLatencyWindow(m.windowSize)
+		m.windows[name] = w
+	}
+	return w
+}
+
+func (m *LatencyMonitor) Record(name string, d time.Duration) {
+	m.window(name).Record(d)
+}
+
+// LogRequest records round trip latency of request with response.
+func (m *LatencyMonitor) LogRequest(_ context.Context, log RequestLog) {
+	if log.StatusCode == 0 {
+		return
+	}
+	m.Record(log.BaseUrl+log.Path, log.Latency)
+}
+
+// RecordWsEvent records latency from exchange event time, unit is millisecond, to receivedAt.
+func (m *LatencyMonitor) RecordWsEvent(name string, eventTime int64, receivedAt time.Time) {
+	m.Record(name, receivedAt.Add(m.timeSync.Offset()).Sub(time.UnixMilli(eventTime)))
+}
+
+// Stats returns stats of window, or empty stats if name is not recorded.
+func (m *LatencyMonitor) Stats(name string) LatencyStats {
+	m.mux.Lock()
+	w, ok := m.windows[name]
+	m.mux.Unlock()
+	if !ok {
+		return LatencyStats{}
+	}
+	return w.Stats()
+}
+
+// AllStats returns stats of all windows by name.
+func (m *LatencyMonitor) AllStats() map[string]LatencyStats {
+	m.mux.Lock()
+	windows := make(map[string]*LatencyWindow, len(m.windows))
+	for name, w := range m.windows {
+		windows[name] = w
+	}
+	m.mux.Unlock()
+	stats := make(map[string]LatencyStats, len(windows))
+	for name, w := range windows {
+		stats[name] = w.Stats()
+	}
+	return stats
+}
+
+type multiLogger []Logger
+
+// MultiLogger logs requests by all loggers in order,
+// ex. MultiLogger(SlogLogger(logger), latencyMonitor).
+func MultiLogger(loggers ...Logger) Logger {
+	return multiLogger(loggers)
+}
+
+func (l multiLogger) LogRequest(ctx context.Context, log RequestLog) {
+	for _, logger := range l {
+		logger.LogRequest(ctx, log)
+	}
+}
+
+// -----------------------------------------------------------
+// Latency
+// ===========================================================
+
+// ===========================================================
+// Ping
+// -----------------------------------------------------------
+
+// PingResult is result of pinging cex by server time endpoint.
+type PingResult struct {
+	// Latency is round trip latency.
+	Latency time.Duration `json:"latency"`
+	// ServerTime is 0 if Err is not nil, unit is millisecond.
+	ServerTime int64 `json:"serverTime"`
+	// ClockOffset is server time minus local time, network latency is compensated by half of round trip.
+	ClockOffset time.Duration `json:"clockOffset"`
+	Err         error         `json:"err"`
+}
+
+func (r PingResult) Healthy() bool {
+	return r.Err == nil
+}
+
+// Ping queries server time once by querier.
+// Every cex package provides its querier, ex. bnc.QuerySpotServerTime.
+func Ping(querier ServerTimeQuerier) PingResult {
+	start := time.Now()
+	serverTime, err := querier()
+	end := time.Now()
+	result := PingResult{Latency: end.Sub(start), Err: err}
+	if err != nil {
+		return result
+	}
+	result.ServerTime = serverTime
+	result.ClockOffset = time.UnixMilli(serverTime).Sub(start.Add(result.Latency / 2))
+	return result
+}
+
+// HealthCheck pings all queriers concurrently, and returns results by the same names.
+// Result of querier which does not return before ctx is done has ctx error.
+func HealthCheck(ctx context.Context, queriers map[string]ServerTimeQuerier) map[string]PingResult {
+	type namedResult struct {
+		name   string
+		result PingResult
+	}
+	ch := make(chan namedResult, len(queriers))
+	for name, querier := range queriers {
+		go func() {
+			ch <- namedResult{name, Ping(querier)}
+		}()
+	}
+	results := make(map[string]PingResult, len(queriers))
+	for len(results) < len(queriers) {
+		select {
+		case r := <-ch:
+			results[r.name] = r.result
+		case <-ctx.Done():
+			for name := range queriers {
+				if _, ok := results[name]; !ok {
+					results[name] = PingResult{Err: ctx.Err()}
+				}
+			}
+		}
+	}
+	return results
+}
+
+// -----------------------------------------------------------
+// Ping
+// ===========================================================
@@ -0,0 +1,86 @@
+package cex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLatencyWindow(t *testing.T) {
+	w := NewLatencyWindow(100)
+	if w.Stats().Count != 0 || w.Percentile(50) != 0 {
+		t.Error("empty window should have empty stats")
+	}
+	// 1ms..150ms, only the latest 100 samples 51ms..150ms are kept
+	for i := range 150 {
+		w.Record(time.Duration(i+1) * time.Millisecond)
+	}
+	stats := w.Stats()
+	if stats.Count != 100 || stats.Min != 51*time.Millisecond || stats.Max != 150*time.Millisecond {
+		t.Error("wrong window", stats)
+	}
+	if stats.P50 != 100*time.Millisecond || stats.P90 != 140*time.Millisecond || stats.P99 != 149*time.Millisecond {
+		t.Error("wrong percentiles", stats)
+	}
+	if stats.Mean != 100500*time.Microsecond {
+		t.Error("wrong mean", stats.Mean)
+	}
+}
+
+func TestLatencyMonitor(t *testing.T) {
+	ts := NewTimeSync(func() (int64, error) {
+		return time.Now().Add(time.Hour).UnixMilli(), nil
+	})
+	if err := ts.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	m := NewLatencyMonitor(LatencyMonitorOptWindowSize(10), LatencyMonitorOptTimeSync(ts))
+	logger := MultiLogger(m)
+	logger.LogRequest(context.Background(), RequestLog{BaseUrl: "https://api.binance.com", Path: "/api/v3/time", Latency: time.Millisecond, StatusCode: 200})
+	logger.LogRequest(context.Background(), RequestLog{BaseUrl: "https://api.binance.com", Path: "/api/v3/time", Err: errors.New("timeout")})
+	if stats := m.Stats("https://api.binance.com/api/v3/time"); stats.Count != 1 || stats.Max != time.Millisecond {
+		t.Error("only requests with response should be recorded", stats)
+	}
+
+	// event is sent 20ms ago by server clock
+	now := time.Now()
+	m.RecordWsEvent("btcusdt@trade", now.Add(time.Hour-20*time.Millisecond).UnixMilli(), now)
+	if d := m.Stats("btcusdt@trade").Max - 20*time.Millisecond; d > 10*time.Millisecond || d < -10*time.Millisecond {
+		t.Error("ws latency should be corrected by clock offset", m.Stats("btcusdt@trade"))
+	}
+	if len(m.AllStats()) != 2 {
+		t.Error("wrong all stats", m.AllStats())
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	errQuery := errors.New("query failed")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	results := HealthCheck(ctx, map[string]ServerTimeQuerier{
+		"ok": func() (int64, error) {
+			return time.Now().Add(time.Hour).UnixMilli(), nil
+		},
+		"failed": func() (int64, error) {
+			return 0, errQuery
+		},
+		"stuck": func() (int64, error) {
+			time.Sleep(time.Second)
+			return 0, nil
+		},
+	})
+	ok := results["ok"]
+	if !ok.Healthy() || ok.ServerTime == 0 {
+		t.Error("ok should be healthy", ok)
+	}
+	if d := ok.ClockOffset - time.Hour; d > 10*time.Millisecond || d < -10*time.Millisecond {
+		t.Error("clock offset should be about 1h, but", ok.ClockOffset)
+	}
+	if !errors.Is(results["failed"].Err, errQuery) {
+		t.Error("failed should have query error", results["failed"])
+	}
+	if !errors.Is(results["stuck"].Err, context.DeadlineExceeded) {
+		t.Error("stuck should be timeout", results["stuck"])
+	}
+}
@@ -0,0 +1,29 @@
+package cex
+
+import "errors"
+
+// Env is the cex environment that requests are sent to.
+type Env string
+
+const (
+	// EnvMainnet is the default environment, empty Env is also mainnet.
+	EnvMainnet Env = "MAINNET"
+	// EnvTestnet is the testnet or sandbox of cex,
+	// so integration tests don't need real funds.
+	EnvTestnet Env = "TESTNET"
+)
+
+func (e Env) IsTestnet() bool {
+	return e == EnvTestnet
+}
+
+// ErrNoTestnet is returned if cex has no testnet for base url.
+var ErrNoTestnet = errors.New("no testnet")
+
+// BaseUrlRewriter may be implemented by ReqMaker.
+// If ReqMaker implements it, Request will replace ReqBaseConfig.BaseUrl
+// with the rewritten one before anything else,
+// so ReqConfigs can stay mainnet, ex. rewriting to testnet.
+type BaseUrlRewriter interface {
+	RewriteBaseUrl(baseUrl string) (string, error)
+}
@@ -0,0 +1,46 @@
+package cex
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testnetReqMaker struct {
+	testReqMaker
+	testnetBaseUrl string
+}
+
+func (m testnetReqMaker) RewriteBaseUrl(baseUrl string) (string, error) {
+	if m.testnetBaseUrl == "" {
+		return "", ErrNoTestnet
+	}
+	return m.testnetBaseUrl, nil
+}
+
+func TestRequest_BaseUrlRewriter(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"testnet":true}`))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[NilReqData, map[string]bool]{
+		ReqBaseConfig:         ReqBaseConfig{BaseUrl: "https://mainnet.invalid", Path: "/env", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]bool],
+		RetryPolicy:           &RetryPolicy{MaxAttempts: 1},
+	}
+	_, data, err := Request(testnetReqMaker{testnetBaseUrl: sv.URL}, config, nil)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if !data["testnet"] {
+		t.Error("request should be sent to testnet, but", data)
+	}
+
+	_, _, err = Request(testnetReqMaker{}, config, nil)
+	if !errors.Is(err.Err, ErrNoTestnet) {
+		t.Error("request should fail with ErrNoTestnet, but", err.Error())
+	}
+}
@@ -1,6 +1,9 @@
 package cex
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // These std errors should be wrapped by other errors,
 // which can help callers to analyse error details.
@@ -21,11 +24,34 @@ var (
 	ErrHTTPBadRequest    = errors.New("http bad request")
 	ErrHTTPForbidden     = errors.New("http forbidden")
 	ErrHTTPNotFound      = errors.New("http not found")
-	ErrHTTPTooFrequency  = errors.New("http too frequency")
-	ErrHTTPIpBanned      = errors.New("http ip is banned")
+	ErrHTTPTooFrequency  = fmt.Errorf("http too frequency, %w", ErrRateLimited)
+	ErrHTTPIpBanned      = fmt.Errorf("http ip is banned, %w", ErrRateLimited)
+
+	// ErrBanned418 is alias of ErrHTTPIpBanned,
+	// ip is banned for continuing to send requests after rate limited, ex. binance http 418.
+	ErrBanned418 = ErrHTTPIpBanned
+
+	// ErrRateLimited means request is rate limited by local rate limiter or by cex.
+	// Errors of cex rate limits, ex. ErrHTTPTooFrequency and ErrHTTPIpBanned, wrap it,
+	// so callers can branch all rate limits by errors.Is.
+	// If request is rate limited by local rate limiter, request is not sent to cex.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrInvalidApiKey means api key, ip or permissions are invalid.
+	ErrInvalidApiKey = errors.New("invalid api key, ip or permissions")
 
 	ErrInvalidTimestamp    = errors.New("invalid timestamp")
 	ErrInsufficientBalance = errors.New("insufficient balance")
 	ErrOrderRejected       = errors.New("order is rejected")
 	ErrUnknownOrder        = errors.New("unknown order")
+
+	// ErrOrderNotFound is alias of ErrUnknownOrder.
+	ErrOrderNotFound = ErrUnknownOrder
+
+	// ErrInvalidParams means request params are rejected by local validation before signing,
+	// request is not sent to cex.
+	ErrInvalidParams = errors.New("invalid params")
+
+	// ErrMinNotional means order notional is less than min notional of symbol.
+	ErrMinNotional = errors.New("order notional is less than min notional")
 )
@@ -0,0 +1,100 @@
+package cex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ===========================================================
+// HTTP Request
+// -----------------------------------------------------------
+
+// HTTPRequest is http request made by ReqMakers, and sent by Backend.
+// It does not expose the underlying http client,
+// so backends can be changed without breaking ReqMakers.
+// It is not named Request, which is the generic request function.
+type HTTPRequest struct {
+	// Method is method of ReqBaseConfig, if it is empty.
+	Method string
+
+	// URL is the whole url, ReqMakers compose signed query into it by self.
+	URL string
+
+	Header http.Header
+
+	// Query is appended to URL by backend.
+	Query url.Values
+
+	// Form is sent as url encoded body, if Body is nil.
+	Form url.Values
+
+	// Body is []byte, string, io.Reader, or any value marshaled as json.
+	Body any
+
+	ctx context.Context
+}
+
+// NewHTTPRequest should be used by ReqMakers to create request.
+// Headers of opts are set to request,
+// query and recvWindow of opts should be handled by ReqMakers.
+func NewHTTPRequest(opts ...CltOpt) *HTTPRequest {
+	req := &HTTPRequest{Header: http.Header{}}
+	return req.SetHeaders(NewReqOpts(opts...).Headers)
+}
+
+func (r *HTTPRequest) SetHeader(key, value string) *HTTPRequest {
+	if r.Header == nil {
+		r.Header = http.Header{}
+	}
+	r.Header.Set(key, value)
+	return r
+}
+
+func (r *HTTPRequest) SetHeaders(headers map[string]string) *HTTPRequest {
+	for k, v := range headers {
+		r.SetHeader(k, v)
+	}
+	return r
+}
+
+func (r *HTTPRequest) SetQueryParam(key, value string) *HTTPRequest {
+	if r.Query == nil {
+		r.Query = url.Values{}
+	}
+	r.Query.Set(key, value)
+	return r
+}
+
+func (r *HTTPRequest) SetFormData(data map[string]string) *HTTPRequest {
+	if r.Form == nil {
+		r.Form = url.Values{}
+	}
+	for k, v := range data {
+		r.Form.Set(k, v)
+	}
+	return r
+}
+
+func (r *HTTPRequest) SetBody(body any) *HTTPRequest {
+	r.Body = body
+	return r
+}
+
+// SetContext sets ctx, which cancels in-flight request.
+func (r *HTTPRequest) SetContext(ctx context.Context) *HTTPRequest {
+	r.ctx = ctx
+	return r
+}
+
+// Context returns context.Background, if ctx is not set.
+func (r *HTTPRequest) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+// -----------------------------------------------------------
+// HTTP Request
+// ===========================================================
@@ -0,0 +1,234 @@
+package cex
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++               Cex REST Core: Key Pool               +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ErrNoAvailableKey is returned by KeyPool, if all keys are quarantined.
+var ErrNoAvailableKey = errors.New("no available api key")
+
+type KeyPoolStrategy int
+
+const (
+	// KeyPoolStrategyRoundRobin picks keys in turn.
+	KeyPoolStrategyRoundRobin KeyPoolStrategy = iota
+	// KeyPoolStrategyLeastUsed picks key with the fewest in-flight requests,
+	// and then the fewest total requests.
+	KeyPoolStrategyLeastUsed
+)
+
+const defaultKeyQuarantine = time.Minute
+
+type KeyPoolOpt func(*keyPoolConfig)
+
+type keyPoolConfig struct {
+	strategy         KeyPoolStrategy
+	quarantine       time.Duration
+	shouldQuarantine func(RequestError) bool
+}
+
+func KeyPoolOptStrategy(strategy KeyPoolStrategy) KeyPoolOpt {
+	return func(c *keyPoolConfig) {
+		c.strategy = strategy
+	}
+}
+
+// KeyPoolOptQuarantine sets how long a key is quarantined, default 1 minute.
+// If cex responds rate limit reset time, key is quarantined until then at least.
+func KeyPoolOptQuarantine(d time.Duration) KeyPoolOpt {
+	return func(c *keyPoolConfig) {
+		c.quarantine = d
+	}
+}
+
+// KeyPoolOptQuarantineChecker replaces DefaultKeyQuarantineChecker.
+func KeyPoolOptQuarantineChecker(checker func(RequestError) bool) KeyPoolOpt {
+	return func(c *keyPoolConfig) {
+		c.shouldQuarantine = checker
+	}
+}
+
+// DefaultKeyQuarantineChecker returns true, if api key is invalid,
+// or cex rate limit of account is broken.
+// Ip rate limit errors are ignored, because changing key does not help.
+func DefaultKeyQuarantineChecker(err RequestError) bool {
+	if err.IsNil() {
+		return false
+	}
+	if errors.Is(&err, ErrInvalidApiKey) {
+		return true
+	}
+	return err.RateLimitError != nil && err.RateLimitError.Scope == RateLimitScopeAccount
+}
+
+type KeyStat struct {
+	ApiKey string `json:"apiKey"`
+	// InUse is count of in-flight requests.
+	InUse int `json:"inUse"`
+	// Used is count of all acquired requests.
+	Used             int64     `json:"used"`
+	QuarantinedUntil time.Time `json:"quarantinedUntil"`
+}
+
+type keyPoolEntry[U User] struct {
+	user U
+	KeyStat
+}
+
+// KeyPool holds users with different api keys,
+// so high-throughput callers can spread load across keys.
+// Keys hitting auth or account rate limit errors are quarantined automatically.
+//
+//	pool := cex.NewKeyPool([]*bnc.User{user0, user1})
+//	_, acct, err := cex.PoolRequest(pool, bnc.SpotAccountConfig, nil)
+type KeyPool[U User] struct {
+	mux     sync.Mutex
+	cfg     keyPoolConfig
+	entries []*keyPoolEntry[U]
+	next    int
+}
+
+func NewKeyPool[U User](users []U, opts ...KeyPoolOpt) *KeyPool[U] {
+	cfg := keyPoolConfig{
+		strategy:         KeyPoolStrategyRoundRobin,
+		quarantine:       defaultKeyQuarantine,
+		shouldQuarantine: DefaultKeyQuarantineChecker,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	pool := &KeyPool[U]{cfg: cfg}
+	for _, user := range users {
+		pool.entries = append(pool.entries, &keyPoolEntry[U]{
+			user:    user,
+			KeyStat: KeyStat{ApiKey: user.Api().ApiKey},
+		})
+	}
+	return pool
+}
+
+func (p *KeyPool[U]) Len() int {
+	return len(p.entries)
+}
+
+// Acquire picks an available user by strategy.
+// Release must be called with request error after request is done.
+// Returned error wraps ErrNoAvailableKey, if all keys are quarantined.
+func (p *KeyPool[U]) Acquire() (U, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	now := time.Now()
+	var picked *keyPoolEntry[U]
+	var earliest time.Time
+	n := len(p.entries)
+	for i := 0; i < n; i++ {
+		idx := (p.next + i) % n
+		e := p.entries[idx]
+		if now.Before(e.QuarantinedUntil) {
+			if earliest.IsZero() || e.QuarantinedUntil.Before(earliest) {
+				earliest = e.QuarantinedUntil
+			}
+			continue
+		}
+		if p.cfg.strategy == KeyPoolStrategyRoundRobin {
+			picked = e
+			p.next = idx + 1
+			break
+		}
+		if picked == nil || e.InUse < picked.InUse || (e.InUse == picked.InUse && e.Used < picked.Used) {
+			picked = e
+		}
+	}
+	if picked == nil {
+		var u U
+		if n == 0 {
+			return u, fmt.Errorf("cex: key pool, %w, pool is empty", ErrNoAvailableKey)
+		}
+		return u, fmt.Errorf("cex: key pool, %w, until %v", ErrNoAvailableKey, earliest.Format(time.RFC3339))
+	}
+	picked.InUse++
+	picked.Used++
+	return picked.user, nil
+}
+
+// Release releases user acquired from pool,
+// and quarantines its key, if err should be quarantined.
+func (p *KeyPool[U]) Release(user U, err RequestError) {
+	apiKey := user.Api().ApiKey
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	e := p.entry(apiKey)
+	if e == nil {
+		return
+	}
+	if e.InUse > 0 {
+		e.InUse--
+	}
+	if p.cfg.shouldQuarantine == nil || !p.cfg.shouldQuarantine(err) {
+		return
+	}
+	until := time.Now().Add(p.cfg.quarantine)
+	if err.RateLimitError != nil && err.RateLimitError.ResetsAt.After(until) {
+		until = err.RateLimitError.ResetsAt
+	}
+	if until.After(e.QuarantinedUntil) {
+		e.QuarantinedUntil = until
+	}
+}
+
+// Quarantine quarantines key until t manually.
+// Zero t releases key from quarantine.
+func (p *KeyPool[U]) Quarantine(apiKey string, until time.Time) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if e := p.entry(apiKey); e != nil {
+		e.QuarantinedUntil = until
+	}
+}
+
+func (p *KeyPool[U]) Stats() []KeyStat {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	stats := make([]KeyStat, len(p.entries))
+	for i, e := range p.entries {
+		stats[i] = e.KeyStat
+	}
+	return stats
+}
+
+func (p *KeyPool[U]) entry(apiKey string) *keyPoolEntry[U] {
+	for _, e := range p.entries {
+		if e.ApiKey == apiKey {
+			return e
+		}
+	}
+	return nil
+}
+
+// PoolRequest is same as Request, but user is acquired from pool,
+// and released with request error after request is done.
+func PoolRequest[U User, ReqDataType, RespDataType any](
+	pool *KeyPool[U],
+	config ReqConfig[ReqDataType, RespDataType],
+	reqData ReqDataType,
+	opts ...CltOpt,
+) (*Response, RespDataType, RequestError) {
+	user, err := pool.Acquire()
+	if err != nil {
+		var respData RespDataType
+		reqErr := RequestError{ReqBaseConfig: config.ReqBaseConfig}
+		return nil, respData, *reqErr.SetErr(err)
+	}
+	resp, respData, reqErr := Request(user, config, reqData, opts...)
+	pool.Release(user, reqErr)
+	return resp, respData, reqErr
+}
@@ -0,0 +1,146 @@
+package cex
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testPoolUser struct {
+	api Api
+}
+
+func (u testPoolUser) Api() Api {
+	return u.api
+}
+
+func (u testPoolUser) Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error) {
+	req := NewHTTPRequest(opts...).SetHeader("X-KEY", u.api.ApiKey)
+	req.URL = config.BaseUrl + config.Path
+	return req, nil
+}
+
+func newTestPoolUsers(keys ...string) []testPoolUser {
+	var users []testPoolUser
+	for _, k := range keys {
+		users = append(users, testPoolUser{Api{ApiKey: k}})
+	}
+	return users
+}
+
+func TestKeyPool_RoundRobin(t *testing.T) {
+	pool := NewKeyPool(newTestPoolUsers("a", "b", "c"))
+	var got []string
+	for i := 0; i < 6; i++ {
+		u, err := pool.Acquire()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, u.Api().ApiKey)
+		pool.Release(u, RequestError{})
+	}
+	want := []string{"a", "b", "c", "a", "b", "c"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatal("wrong round robin order", got)
+		}
+	}
+}
+
+func TestKeyPool_LeastUsed(t *testing.T) {
+	pool := NewKeyPool(newTestPoolUsers("a", "b"), KeyPoolOptStrategy(KeyPoolStrategyLeastUsed))
+	u0, _ := pool.Acquire()
+	u1, _ := pool.Acquire()
+	if u0.Api().ApiKey == u1.Api().ApiKey {
+		t.Fatal("in-flight key should not be picked", u0, u1)
+	}
+	pool.Release(u1, RequestError{})
+	u2, _ := pool.Acquire()
+	if u2.Api().ApiKey != u1.Api().ApiKey {
+		t.Error("key with fewer in-flight requests should be picked", u2)
+	}
+}
+
+func TestKeyPool_Quarantine(t *testing.T) {
+	pool := NewKeyPool(newTestPoolUsers("a", "b"), KeyPoolOptQuarantine(50*time.Millisecond))
+
+	u, _ := pool.Acquire()
+	pool.Release(u, RequestError{Err: ErrInvalidApiKey})
+	for i := 0; i < 3; i++ {
+		u, err := pool.Acquire()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Api().ApiKey != "b" {
+			t.Fatal("quarantined key is picked")
+		}
+		pool.Release(u, RequestError{})
+	}
+
+	// ip rate limit does not quarantine key
+	u, _ = pool.Acquire()
+	pool.Release(u, RequestError{Err: ErrHTTPTooFrequency, RateLimitError: &RateLimitError{Scope: RateLimitScopeIp}})
+
+	// account rate limit quarantines key until resets
+	u, _ = pool.Acquire()
+	resetsAt := time.Now().Add(time.Hour)
+	pool.Release(u, RequestError{Err: ErrHTTPTooFrequency, RateLimitError: &RateLimitError{Scope: RateLimitScopeAccount, ResetsAt: resetsAt}})
+	if _, err := pool.Acquire(); !errors.Is(err, ErrNoAvailableKey) {
+		t.Fatal("all keys should be quarantined", err)
+	}
+	for _, stat := range pool.Stats() {
+		if stat.ApiKey == "b" && !stat.QuarantinedUntil.Equal(resetsAt) {
+			t.Error("key should be quarantined until resets", stat)
+		}
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	u, err := pool.Acquire()
+	if err != nil || u.Api().ApiKey != "a" {
+		t.Error("key should be released from quarantine", u, err)
+	}
+
+	pool.Quarantine("b", time.Time{})
+	pool.Release(u, RequestError{})
+	if stats := pool.Stats(); stats[0].InUse != 0 || !stats[1].QuarantinedUntil.IsZero() {
+		t.Error("wrong stats", stats)
+	}
+}
+
+func TestPoolRequest(t *testing.T) {
+	var keys []string
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("X-KEY"))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[NilReqData, map[string]bool]{
+		ReqBaseConfig: ReqBaseConfig{
+			BaseUrl:    sv.URL,
+			Path:       "/account",
+			Method:     http.MethodGet,
+			IsUserData: true,
+		},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]bool],
+	}
+
+	pool := NewKeyPool(newTestPoolUsers("a", "b"))
+	for i := 0; i < 2; i++ {
+		_, data, err := PoolRequest(pool, config, nil)
+		if err.IsNotNil() || !data["ok"] {
+			t.Fatal(err.Err, data)
+		}
+	}
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Error("keys are not spread", keys)
+	}
+
+	empty := NewKeyPool[testPoolUser](nil)
+	if _, _, err := PoolRequest(empty, config, nil); !errors.Is(err.Err, ErrNoAvailableKey) {
+		t.Error("empty pool should return ErrNoAvailableKey", err.Err)
+	}
+}
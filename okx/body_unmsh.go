@@ -0,0 +1,91 @@
+package okx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dwdwow/cex"
+)
+
+// RespData is the envelope of all okx responses.
+type RespData[D any] struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data D      `json:"data"`
+}
+
+// SCodeMsg is contained in data items of order endpoints.
+type SCodeMsg struct {
+	SCode string `json:"sCode"`
+	SMsg  string `json:"sMsg"`
+}
+
+func newCodeMsgErr(code, msg string) *cex.RespBodyUnmarshalerError {
+	intCode, _ := strconv.Atoi(code)
+	errCtm := cexCustomErrCodes[intCode]
+	if errCtm == nil {
+		errCtm = fmt.Errorf("%v, %v", code, msg)
+	}
+	return &cex.RespBodyUnmarshalerError{
+		CexErrCode: intCode,
+		CexErrMsg:  msg,
+		Err:        fmt.Errorf("okx: %w", errCtm),
+	}
+}
+
+// bodyUnmsh unmarshals okx envelope, and returns data.
+func bodyUnmsh[D any](body []byte) (D, *cex.RespBodyUnmarshalerError) {
+	resp := RespData[D]{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp.Data, &cex.RespBodyUnmarshalerError{
+			Err: fmt.Errorf("okx: %w: unmarshal response body, %w", cex.ErrJsonUnmarshal, err),
+		}
+	}
+	if resp.Code != "0" {
+		return resp.Data, newCodeMsgErr(resp.Code, resp.Msg)
+	}
+	return resp.Data, nil
+}
+
+// firstItemBodyUnmsh unmarshals okx envelope, which data is a list with only one item.
+func firstItemBodyUnmsh[D any](body []byte) (D, *cex.RespBodyUnmarshalerError) {
+	var d D
+	items, err := bodyUnmsh[[]D](body)
+	if err != nil {
+		return d, err
+	}
+	if len(items) == 0 {
+		return d, &cex.RespBodyUnmarshalerError{
+			Err: fmt.Errorf("okx: %w: empty response data", cex.ErrUnexpected),
+		}
+	}
+	return items[0], nil
+}
+
+// orderBodyUnmsh unmarshals responses of order operations.
+// If operation failed, okx responds code "1", and the real code is sCode of data item.
+func orderBodyUnmsh(body []byte) (OrderResult, *cex.RespBodyUnmarshalerError) {
+	resp := RespData[[]OrderResult]{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return OrderResult{}, &cex.RespBodyUnmarshalerError{
+			Err: fmt.Errorf("okx: %w: unmarshal response body, %w", cex.ErrJsonUnmarshal, err),
+		}
+	}
+	var result OrderResult
+	if len(resp.Data) > 0 {
+		result = resp.Data[0]
+	}
+	if result.SCode != "" && result.SCode != "0" {
+		return result, newCodeMsgErr(result.SCode, result.SMsg)
+	}
+	if resp.Code != "0" {
+		return result, newCodeMsgErr(resp.Code, resp.Msg)
+	}
+	if len(resp.Data) == 0 {
+		return result, &cex.RespBodyUnmarshalerError{
+			Err: fmt.Errorf("okx: %w: empty response data", cex.ErrUnexpected),
+		}
+	}
+	return result, nil
+}
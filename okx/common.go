@@ -0,0 +1,124 @@
+package okx
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+const (
+	BaseUrl = "https://www.okx.com"
+	ApiV5   = "/api/v5"
+)
+
+const (
+	SpotSymbolMid    = "-"
+	SwapSymbolSuffix = "-SWAP"
+)
+
+type InstType string
+
+const (
+	InstTypeSpot    InstType = "SPOT"
+	InstTypeMargin  InstType = "MARGIN"
+	InstTypeSwap    InstType = "SWAP"
+	InstTypeFutures InstType = "FUTURES"
+	InstTypeOption  InstType = "OPTION"
+)
+
+// TradeMode is tdMode of okx order.
+type TradeMode string
+
+const (
+	TradeModeCash     TradeMode = "cash"
+	TradeModeCross    TradeMode = "cross"
+	TradeModeIsolated TradeMode = "isolated"
+)
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "buy"
+	OrderSideSell OrderSide = "sell"
+)
+
+type OrderType string
+
+const (
+	OrderTypeLimit    OrderType = "limit"
+	OrderTypeMarket   OrderType = "market"
+	OrderTypePostOnly OrderType = "post_only"
+	OrderTypeFok      OrderType = "fok"
+	OrderTypeIoc      OrderType = "ioc"
+)
+
+type PositionSide string
+
+const (
+	PositionSideNet   PositionSide = "net"
+	PositionSideLong  PositionSide = "long"
+	PositionSideShort PositionSide = "short"
+)
+
+type OrderState string
+
+const (
+	OrderStateLive            OrderState = "live"
+	OrderStatePartiallyFilled OrderState = "partially_filled"
+	OrderStateFilled          OrderState = "filled"
+	OrderStateCanceled        OrderState = "canceled"
+	OrderStateMmpCanceled     OrderState = "mmp_canceled"
+)
+
+// TargetCurrency is tgtCcy of spot market order.
+// Default of okx is quote_ccy for market buy order.
+type TargetCurrency string
+
+const (
+	TargetCurrencyBase  TargetCurrency = "base_ccy"
+	TargetCurrencyQuote TargetCurrency = "quote_ccy"
+)
+
+// Num is number responded as string by okx.
+// okx responds empty string if number is not available,
+// which can not be unmarshalled by ",string" tag.
+type Num float64
+
+func (n *Num) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var f float64
+		if err := json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		*n = Num(f)
+		return nil
+	}
+	if s == "" {
+		*n = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Num(f)
+	return nil
+}
+
+func (n Num) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatFloat(float64(n), 'f', -1, 64))
+}
+
+func (n Num) Float64() float64 {
+	return float64(n)
+}
+
+// SpotInstId returns okx spot instrument id, ex. BTC-USDT.
+func SpotInstId(asset, quote string) string {
+	return asset + SpotSymbolMid + quote
+}
+
+// SwapInstId returns okx perpetual swap instrument id, ex. BTC-USDT-SWAP.
+func SwapInstId(asset, quote string) string {
+	return asset + SpotSymbolMid + quote + SwapSymbolSuffix
+}
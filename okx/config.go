@@ -0,0 +1,305 @@
+package okx
+
+import (
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+// ============================================================
+// Public
+// ------------------------------------------------------------
+
+type InstrumentsParams struct {
+	InstType   InstType `s2m:"instType,omitempty"`
+	InstFamily string   `s2m:"instFamily,omitempty"`
+	InstId     string   `s2m:"instId,omitempty"`
+}
+
+type Instrument struct {
+	InstType  InstType `json:"instType" bson:"instType"`
+	InstId    string   `json:"instId" bson:"instId"`
+	Uly       string   `json:"uly" bson:"uly"`
+	BaseCcy   string   `json:"baseCcy" bson:"baseCcy"`
+	QuoteCcy  string   `json:"quoteCcy" bson:"quoteCcy"`
+	SettleCcy string   `json:"settleCcy" bson:"settleCcy"`
+	CtVal     Num      `json:"ctVal" bson:"ctVal"`
+	CtMult    Num      `json:"ctMult" bson:"ctMult"`
+	CtValCcy  string   `json:"ctValCcy" bson:"ctValCcy"`
+	CtType    string   `json:"ctType" bson:"ctType"`
+	Lever     Num      `json:"lever" bson:"lever"`
+	TickSz    Num      `json:"tickSz" bson:"tickSz"`
+	LotSz     Num      `json:"lotSz" bson:"lotSz"`
+	MinSz     Num      `json:"minSz" bson:"minSz"`
+	State     string   `json:"state" bson:"state"`
+	ListTime  Num      `json:"listTime" bson:"listTime"`
+}
+
+var InstrumentsConfig = cex.ReqConfig[InstrumentsParams, []Instrument]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV5 + "/public/instruments",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   100,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[[]Instrument],
+}
+
+type ServerTime struct {
+	// Ts is server time, unit is millisecond
+	Ts Num `json:"ts" bson:"ts"`
+}
+
+var ServerTimeConfig = cex.ReqConfig[cex.NilReqData, []ServerTime]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV5 + "/public/time",
+		Method:           http.MethodGet,
+		IsUserData:       false,
+		UserTimeInterval: 0,
+		IpTimeInterval:   200,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[[]ServerTime],
+}
+
+// ------------------------------------------------------------
+// Public
+// ============================================================
+
+// ============================================================
+// Account
+// ------------------------------------------------------------
+
+type BalanceParams struct {
+	// Ccy is multiple currencies separated by comma, ex. BTC,USDT
+	Ccy string `s2m:"ccy,omitempty"`
+}
+
+type BalanceDetail struct {
+	Ccy       string `json:"ccy" bson:"ccy"`
+	Eq        Num    `json:"eq" bson:"eq"`
+	CashBal   Num    `json:"cashBal" bson:"cashBal"`
+	AvailBal  Num    `json:"availBal" bson:"availBal"`
+	AvailEq   Num    `json:"availEq" bson:"availEq"`
+	FrozenBal Num    `json:"frozenBal" bson:"frozenBal"`
+	OrdFrozen Num    `json:"ordFrozen" bson:"ordFrozen"`
+	Upl       Num    `json:"upl" bson:"upl"`
+	Liab      Num    `json:"liab" bson:"liab"`
+	EqUsd     Num    `json:"eqUsd" bson:"eqUsd"`
+	UTime     Num    `json:"uTime" bson:"uTime"`
+}
+
+// Balance is balance of trading account,
+// which contains spot and swap assets under unified account.
+type Balance struct {
+	TotalEq     Num             `json:"totalEq" bson:"totalEq"`
+	AdjEq       Num             `json:"adjEq" bson:"adjEq"`
+	Imr         Num             `json:"imr" bson:"imr"`
+	Mmr         Num             `json:"mmr" bson:"mmr"`
+	MgnRatio    Num             `json:"mgnRatio" bson:"mgnRatio"`
+	NotionalUsd Num             `json:"notionalUsd" bson:"notionalUsd"`
+	UTime       Num             `json:"uTime" bson:"uTime"`
+	Details     []BalanceDetail `json:"details" bson:"details"`
+}
+
+var BalanceConfig = cex.ReqConfig[BalanceParams, Balance]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV5 + "/account/balance",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 200,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   firstItemBodyUnmsh[Balance],
+}
+
+type PositionsParams struct {
+	InstType InstType `s2m:"instType,omitempty"`
+	InstId   string   `s2m:"instId,omitempty"`
+	PosId    string   `s2m:"posId,omitempty"`
+}
+
+type Position struct {
+	InstType    InstType     `json:"instType" bson:"instType"`
+	InstId      string       `json:"instId" bson:"instId"`
+	PosId       string       `json:"posId" bson:"posId"`
+	MgnMode     TradeMode    `json:"mgnMode" bson:"mgnMode"`
+	PosSide     PositionSide `json:"posSide" bson:"posSide"`
+	Pos         Num          `json:"pos" bson:"pos"`
+	PosCcy      string       `json:"posCcy" bson:"posCcy"`
+	AvailPos    Num          `json:"availPos" bson:"availPos"`
+	AvgPx       Num          `json:"avgPx" bson:"avgPx"`
+	MarkPx      Num          `json:"markPx" bson:"markPx"`
+	LiqPx       Num          `json:"liqPx" bson:"liqPx"`
+	Lever       Num          `json:"lever" bson:"lever"`
+	Upl         Num          `json:"upl" bson:"upl"`
+	UplRatio    Num          `json:"uplRatio" bson:"uplRatio"`
+	Margin      Num          `json:"margin" bson:"margin"`
+	Imr         Num          `json:"imr" bson:"imr"`
+	Mmr         Num          `json:"mmr" bson:"mmr"`
+	NotionalUsd Num          `json:"notionalUsd" bson:"notionalUsd"`
+	Ccy         string       `json:"ccy" bson:"ccy"`
+	CTime       Num          `json:"cTime" bson:"cTime"`
+	UTime       Num          `json:"uTime" bson:"uTime"`
+}
+
+var PositionsConfig = cex.ReqConfig[PositionsParams, []Position]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV5 + "/account/positions",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 200,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[[]Position],
+}
+
+// FundingBalance is balance of funding account.
+type FundingBalance struct {
+	Ccy       string `json:"ccy" bson:"ccy"`
+	Bal       Num    `json:"bal" bson:"bal"`
+	FrozenBal Num    `json:"frozenBal" bson:"frozenBal"`
+	AvailBal  Num    `json:"availBal" bson:"availBal"`
+}
+
+var FundingBalancesConfig = cex.ReqConfig[BalanceParams, []FundingBalance]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV5 + "/asset/balances",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 200,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   bodyUnmsh[[]FundingBalance],
+}
+
+// ------------------------------------------------------------
+// Account
+// ============================================================
+
+// ============================================================
+// Trade
+// ------------------------------------------------------------
+
+// NewOrderParams is sent as json body.
+// Sz of SWAP order is number of contracts, not asset qty.
+type NewOrderParams struct {
+	InstId     string         `json:"instId,omitempty"`
+	TdMode     TradeMode      `json:"tdMode,omitempty"`
+	Ccy        string         `json:"ccy,omitempty"`
+	ClOrdId    string         `json:"clOrdId,omitempty"`
+	Tag        string         `json:"tag,omitempty"`
+	Side       OrderSide      `json:"side,omitempty"`
+	PosSide    PositionSide   `json:"posSide,omitempty"`
+	OrdType    OrderType      `json:"ordType,omitempty"`
+	Sz         float64        `json:"sz,string,omitempty"`
+	Px         float64        `json:"px,string,omitempty"`
+	ReduceOnly bool           `json:"reduceOnly,omitempty"`
+	TgtCcy     TargetCurrency `json:"tgtCcy,omitempty"`
+}
+
+// OrderResult is responded by order place and cancel.
+type OrderResult struct {
+	OrdId   string `json:"ordId" bson:"ordId"`
+	ClOrdId string `json:"clOrdId" bson:"clOrdId"`
+	Tag     string `json:"tag" bson:"tag"`
+	SCodeMsg
+}
+
+var NewOrderConfig = cex.ReqConfig[NewOrderParams, OrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV5 + "/trade/order",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   orderBodyUnmsh,
+}
+
+type CancelOrderParams struct {
+	InstId  string `json:"instId,omitempty"`
+	OrdId   string `json:"ordId,omitempty"`
+	ClOrdId string `json:"clOrdId,omitempty"`
+}
+
+var CancelOrderConfig = cex.ReqConfig[CancelOrderParams, OrderResult]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV5 + "/trade/cancel-order",
+		Method:           http.MethodPost,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   orderBodyUnmsh,
+}
+
+type QueryOrderParams struct {
+	InstId  string `s2m:"instId,omitempty"`
+	OrdId   string `s2m:"ordId,omitempty"`
+	ClOrdId string `s2m:"clOrdId,omitempty"`
+}
+
+type Order struct {
+	InstType     InstType     `json:"instType" bson:"instType"`
+	InstId       string       `json:"instId" bson:"instId"`
+	Ccy          string       `json:"ccy" bson:"ccy"`
+	OrdId        string       `json:"ordId" bson:"ordId"`
+	ClOrdId      string       `json:"clOrdId" bson:"clOrdId"`
+	Tag          string       `json:"tag" bson:"tag"`
+	Px           Num          `json:"px" bson:"px"`
+	Sz           Num          `json:"sz" bson:"sz"`
+	Pnl          Num          `json:"pnl" bson:"pnl"`
+	OrdType      OrderType    `json:"ordType" bson:"ordType"`
+	Side         OrderSide    `json:"side" bson:"side"`
+	PosSide      PositionSide `json:"posSide" bson:"posSide"`
+	TdMode       TradeMode    `json:"tdMode" bson:"tdMode"`
+	TgtCcy       string       `json:"tgtCcy" bson:"tgtCcy"`
+	AccFillSz    Num          `json:"accFillSz" bson:"accFillSz"`
+	FillPx       Num          `json:"fillPx" bson:"fillPx"`
+	TradeId      string       `json:"tradeId" bson:"tradeId"`
+	FillSz       Num          `json:"fillSz" bson:"fillSz"`
+	FillTime     Num          `json:"fillTime" bson:"fillTime"`
+	AvgPx        Num          `json:"avgPx" bson:"avgPx"`
+	State        OrderState   `json:"state" bson:"state"`
+	Lever        Num          `json:"lever" bson:"lever"`
+	Fee          Num          `json:"fee" bson:"fee"`
+	FeeCcy       string       `json:"feeCcy" bson:"feeCcy"`
+	Rebate       Num          `json:"rebate" bson:"rebate"`
+	RebateCcy    string       `json:"rebateCcy" bson:"rebateCcy"`
+	ReduceOnly   string       `json:"reduceOnly" bson:"reduceOnly"`
+	CancelSource string       `json:"cancelSource" bson:"cancelSource"`
+	CTime        Num          `json:"cTime" bson:"cTime"`
+	UTime        Num          `json:"uTime" bson:"uTime"`
+}
+
+var QueryOrderConfig = cex.ReqConfig[QueryOrderParams, Order]{
+	ReqBaseConfig: cex.ReqBaseConfig{
+		BaseUrl:          BaseUrl,
+		Path:             ApiV5 + "/trade/order",
+		Method:           http.MethodGet,
+		IsUserData:       true,
+		UserTimeInterval: 0,
+		IpTimeInterval:   0,
+	},
+	HTTPStatusCodeChecker: HTTPStatusCodeChecker,
+	RespBodyUnmarshaler:   firstItemBodyUnmsh[Order],
+}
+
+// ------------------------------------------------------------
+// Trade
+// ============================================================
@@ -0,0 +1,83 @@
+package okx
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dwdwow/cex"
+)
+
+/**
+HTTP Ref:
+	https://www.okx.com/docs-v5/en/#overview-rest-authentication
+Error Codes Ref:
+	https://www.okx.com/docs-v5/en/#error-code
+
+All Endpoints:
+	okx responds {"code":"0","msg":"","data":[]}, code "0" means success.
+	Order endpoints respond code "1" if operation failed,
+	and the detail is in sCode and sMsg of data items.
+	HTTP 429 return code is used when breaking a request rate limit.
+	HTTP 5XX return codes are used for internal errors, the execution status is UNKNOWN.
+*/
+
+// =============================================
+// HTTP Errors
+// ---------------------------------------------
+
+var httpErrCodes = map[int]error{
+	http.StatusBadRequest:      cex.ErrHTTPBadRequest,
+	http.StatusUnauthorized:    cex.ErrHTTPForbidden,
+	http.StatusForbidden:       cex.ErrHTTPForbidden,
+	http.StatusNotFound:        cex.ErrHTTPNotFound,
+	http.StatusTooManyRequests: cex.ErrHTTPTooFrequency,
+}
+
+func HTTPStatusCodeChecker(code int) error {
+	if code == 200 {
+		return nil
+	}
+	if code >= 500 {
+		return cex.ErrHTTPCexInnerUnknownStatus
+	}
+	err := httpErrCodes[code]
+	if err != nil {
+		return err
+	}
+	return cex.ErrHTTPCodeNotInEnum
+}
+
+// ---------------------------------------------
+// HTTP Errors
+// =============================================
+
+// =============================================
+// Custom Errors
+// ---------------------------------------------
+
+var (
+	ErrOperationFailed  = errors.New("operation failed")
+	ErrInvalidSign      = errors.New("invalid sign")
+	ErrInvalidApiKey    = errors.New("invalid api key")
+	ErrInvalidParameter = errors.New("invalid parameter")
+)
+
+var cexCustomErrCodes = map[int]error{
+	1:     ErrOperationFailed,
+	50004: cex.ErrHTTPCexInnerUnknownStatus,
+	50011: cex.ErrHTTPTooFrequency,
+	50013: cex.ErrHTTPCexInnerUnknownStatus,
+	50014: ErrInvalidParameter,
+	50102: cex.ErrInvalidTimestamp,
+	50111: ErrInvalidApiKey,
+	50112: cex.ErrInvalidTimestamp,
+	50113: ErrInvalidSign,
+	51000: ErrInvalidParameter,
+	51008: cex.ErrInsufficientBalance,
+	51400: cex.ErrUnknownOrder,
+	51603: cex.ErrUnknownOrder,
+}
+
+// ---------------------------------------------
+// Custom Errors
+// =============================================
@@ -0,0 +1,662 @@
+package okx
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/s2m"
+)
+
+type UserConfig struct {
+	// tdMode of swap orders, default is cross
+	swapTdMode TradeMode
+	// set true if account is in long/short mode
+	isLongShortMode bool
+	simulated       bool
+	// timeSync corrects signed timestamp, may be nil
+	timeSync *cex.TimeSync
+	// waitOrderOpts are used by WaitOrder
+	waitOrderOpts []cex.WaitOrderOpt
+	// logger logs requests made by user, cex.DefaultLogger is used if it is nil
+	logger cex.Logger
+	// transport sends requests made by user, shared transport is used if it is nil
+	transport     cex.Transport
+	transportOpts []cex.TransportOpt
+	// backend sends requests made by user, cex.DefaultBackend is used if it is empty
+	backend cex.Backend
+}
+
+type User struct {
+	api cex.Api
+	cfg UserConfig
+
+	// ctx is set to every request made by user, if it is not nil.
+	ctx context.Context
+}
+
+type UserOpt func(*User)
+
+func UserOptSwapTradeMode(mode TradeMode) UserOpt {
+	return func(user *User) {
+		user.cfg.swapTdMode = mode
+	}
+}
+
+// UserOptLongShortMode should be set if account position mode is long/short mode,
+// so posSide will be set for swap orders.
+// posSide of generic futures orders is decided by side, buy is long and sell is short,
+// so they only open positions, and positions should be closed by CloseFuturesLong or CloseFuturesShort.
+func UserOptLongShortMode() UserOpt {
+	return func(user *User) {
+		user.cfg.isLongShortMode = true
+	}
+}
+
+// UserOptSimulated makes requests to okx demo trading.
+func UserOptSimulated() UserOpt {
+	return func(user *User) {
+		user.cfg.simulated = true
+	}
+}
+
+// UserOptTestnet is same as UserOptSimulated,
+// okx demo trading shares base url with mainnet.
+func UserOptTestnet() UserOpt {
+	return func(user *User) {
+		user.api.Env = cex.EnvTestnet
+		user.cfg.simulated = true
+	}
+}
+
+// UserOptTimeSync makes user sign requests with server time of ts.
+func UserOptTimeSync(ts *cex.TimeSync) UserOpt {
+	return func(user *User) {
+		user.cfg.timeSync = ts
+	}
+}
+
+// UserOptWaitOrder sets options of WaitOrder, ex. poll interval,
+// transition callback and user data stream as update source.
+func UserOptWaitOrder(opts ...cex.WaitOrderOpt) UserOpt {
+	return func(user *User) {
+		user.cfg.waitOrderOpts = append(user.cfg.waitOrderOpts, opts...)
+	}
+}
+
+// UserOptLogger logs requests made by user with logger,
+// ex. cex.SlogLogger(slog.Default()).
+func UserOptLogger(logger cex.Logger) UserOpt {
+	return func(user *User) {
+		user.cfg.logger = logger
+	}
+}
+
+// UserOptTransport sends requests made by user by transport,
+// ex. cex.NewTransport(cex.TransportOptProxy(proxy)).
+// It can be overridden by cex.CltOptTransport per call.
+func UserOptTransport(transport cex.Transport) UserOpt {
+	return func(user *User) {
+		user.cfg.transport = transport
+	}
+}
+
+// UserOptBackend sends requests made by user by backend,
+// ex. cex.BackendNetHTTP for latency sensitive callers.
+// It can be overridden by cex.CltOptBackend per call.
+func UserOptBackend(backend cex.Backend) UserOpt {
+	return func(user *User) {
+		user.cfg.backend = backend
+	}
+}
+
+// UserOptProxy routes requests made by user through http, https or socks5 proxy,
+// ex. to send requests from the egress ip whitelisted by api key.
+// proxy can be parsed by cex.ParseProxyUrl.
+func UserOptProxy(proxy *url.URL) UserOpt {
+	return func(user *User) {
+		user.cfg.transportOpts = append(user.cfg.transportOpts, cex.TransportOptProxy(proxy))
+	}
+}
+
+// UserOptTLSConfig sets tls config of requests made by user.
+func UserOptTLSConfig(cfg *tls.Config) UserOpt {
+	return func(user *User) {
+		user.cfg.transportOpts = append(user.cfg.transportOpts, cex.TransportOptTLSConfig(cfg))
+	}
+}
+
+func NewUser(apiKey, secretKey, passphrase string, opts ...UserOpt) *User {
+	user := &User{
+		api: cex.Api{Cex: cex.OKX, ApiKey: apiKey, SecretKey: secretKey, Passphrase: passphrase},
+		cfg: UserConfig{swapTdMode: TradeModeCross},
+	}
+	for _, opt := range opts {
+		opt(user)
+	}
+	user.cfg.transport = cex.ApplyTransportOpts(user.cfg.transport, user.cfg.transportOpts...)
+	return user
+}
+
+var emptyUser = &User{}
+
+func EmptyUser() *User {
+	return emptyUser
+}
+
+// ============================================================
+// User Getter
+// ------------------------------------------------------------
+
+func (u *User) Api() cex.Api {
+	return u.api
+}
+
+func (u *User) Config() UserConfig {
+	return u.cfg
+}
+
+// Context returns context bound to user, may be nil.
+func (u *User) Context() context.Context {
+	return u.ctx
+}
+
+// WithContext returns a shallow copy of user bound to ctx.
+func (u *User) WithContext(ctx context.Context) *User {
+	nu := *u
+	nu.ctx = ctx
+	return &nu
+}
+
+// ------------------------------------------------------------
+// User Getter
+// ============================================================
+
+// ============================================================
+// Public API
+// ------------------------------------------------------------
+
+func (u *User) Instruments(instType InstType, opts ...cex.CltOpt) (*cex.Response, []Instrument, cex.RequestError) {
+	return cex.Request(u, InstrumentsConfig, InstrumentsParams{InstType: instType}, opts...)
+}
+
+func (u *User) ServerTime(opts ...cex.CltOpt) (*cex.Response, []ServerTime, cex.RequestError) {
+	return cex.Request(u, ServerTimeConfig, nil, opts...)
+}
+
+// QueryServerTime returns okx server time, unit is millisecond.
+func QueryServerTime() (int64, error) {
+	_, times, err := emptyUser.ServerTime()
+	if err.IsNotNil() {
+		return 0, errors.New(err.Error())
+	}
+	if len(times) == 0 {
+		return 0, errors.New("okx: server time is empty")
+	}
+	return int64(times[0].Ts), nil
+}
+
+// NewTimeSync creates cex.TimeSync by okx server time.
+// Should call Sync or Start before using it.
+func NewTimeSync() *cex.TimeSync {
+	return cex.NewTimeSync(QueryServerTime)
+}
+
+// Ping pings okx by server time endpoint.
+func Ping() cex.PingResult {
+	return cex.Ping(QueryServerTime)
+}
+
+// ------------------------------------------------------------
+// Public API
+// ============================================================
+
+// ============================================================
+// Account API
+// ------------------------------------------------------------
+
+// Balance queries trading account balance, ccy can be empty or multiple currencies separated by comma.
+func (u *User) Balance(ccy string, opts ...cex.CltOpt) (*cex.Response, Balance, cex.RequestError) {
+	return cex.Request(u, BalanceConfig, BalanceParams{Ccy: ccy}, opts...)
+}
+
+func (u *User) Positions(instType InstType, instId string, opts ...cex.CltOpt) (*cex.Response, []Position, cex.RequestError) {
+	return cex.Request(u, PositionsConfig, PositionsParams{InstType: instType, InstId: instId}, opts...)
+}
+
+func (u *User) FundingBalances(ccy string, opts ...cex.CltOpt) (*cex.Response, []FundingBalance, cex.RequestError) {
+	return cex.Request(u, FundingBalancesConfig, BalanceParams{Ccy: ccy}, opts...)
+}
+
+// QueryBalances implements cex.BalanceQuerier.
+// It queries trading account as unified wallet, and funding account.
+func (u *User) QueryBalances(opts ...cex.CltOpt) ([]cex.Balance, cex.RequestError) {
+	var balances []cex.Balance
+	var firstErr cex.RequestError
+	if _, bal, err := u.Balance("", opts...); err.IsNil() {
+		for _, d := range bal.Details {
+			if d.CashBal == 0 && d.Upl == 0 {
+				continue
+			}
+			balances = append(balances, cex.Balance{
+				Cex:           cex.OKX,
+				Wallet:        cex.WalletTypeUnified,
+				Asset:         d.Ccy,
+				Total:         d.CashBal.Float64(),
+				Free:          d.AvailBal.Float64(),
+				UnrealizedPnl: d.Upl.Float64(),
+			})
+		}
+	} else {
+		firstErr = err
+	}
+	if _, fundings, err := u.FundingBalances("", opts...); err.IsNil() {
+		for _, f := range fundings {
+			if f.Bal == 0 {
+				continue
+			}
+			balances = append(balances, cex.Balance{
+				Cex:    cex.OKX,
+				Wallet: cex.WalletTypeFunding,
+				Asset:  f.Ccy,
+				Total:  f.Bal.Float64(),
+				Free:   f.AvailBal.Float64(),
+			})
+		}
+	} else if firstErr.IsNil() {
+		firstErr = err
+	}
+	return balances, firstErr
+}
+
+// ------------------------------------------------------------
+// Account API
+// ============================================================
+
+// ============================================================
+// Trade API
+// ------------------------------------------------------------
+
+func (u *User) NewOrder(params NewOrderParams, opts ...cex.CltOpt) (*cex.Response, OrderResult, cex.RequestError) {
+	return cex.Request(u, NewOrderConfig, params, opts...)
+}
+
+func (u *User) CancelRawOrder(instId, ordId, clOrdId string, opts ...cex.CltOpt) (*cex.Response, OrderResult, cex.RequestError) {
+	return cex.Request(u, CancelOrderConfig, CancelOrderParams{InstId: instId, OrdId: ordId, ClOrdId: clOrdId}, opts...)
+}
+
+func (u *User) QueryRawOrder(instId, ordId, clOrdId string, opts ...cex.CltOpt) (*cex.Response, Order, cex.RequestError) {
+	return cex.Request(u, QueryOrderConfig, QueryOrderParams{InstId: instId, OrdId: ordId, ClOrdId: clOrdId}, opts...)
+}
+
+// ------------------------------------------------------------
+// Trade API
+// ============================================================
+
+// ============================================================
+// Trader Implementation
+// ------------------------------------------------------------
+
+func (u *User) QueryOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	return u.queryOrd(order, opts...)
+}
+
+func (u *User) CancelOrder(order *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	return u.cancelOrd(order, opts...)
+}
+
+func (u *User) WaitOrder(ctx context.Context, order *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
+	return u.waitOrd(ctx, order, opts...)
+}
+
+func (u *User) NewSpotOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeSpot, asset, quote, tradeType, orderSide, qty, price, false, opts...)
+}
+
+func (u *User) NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (u *User) NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (u *User) NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (u *User) NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewSpotOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+// NewFuturesOrder places perpetual swap order.
+// qty is number of contracts, okx swap order size is not asset qty.
+func (u *User) NewFuturesOrder(asset, quote string, tradeType cex.OrderType, orderSide cex.OrderSide, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeFutures, asset, quote, tradeType, orderSide, qty, price, false, opts...)
+}
+
+// CloseFuturesLong places sell order to close long swap position, qty is number of contracts.
+// posSide is long in long/short mode, otherwise order is reduce only.
+func (u *User) CloseFuturesLong(asset, quote string, tradeType cex.OrderType, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeFutures, asset, quote, tradeType, cex.OrderSideSell, qty, price, true, opts...)
+}
+
+// CloseFuturesShort places buy order to close short swap position, qty is number of contracts.
+// posSide is short in long/short mode, otherwise order is reduce only.
+func (u *User) CloseFuturesShort(asset, quote string, tradeType cex.OrderType, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.newOrd(cex.PairTypeFutures, asset, quote, tradeType, cex.OrderSideBuy, qty, price, true, opts...)
+}
+
+func (u *User) NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideBuy, qty, price, opts...)
+}
+
+func (u *User) NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeLimit, cex.OrderSideSell, qty, price, opts...)
+}
+
+func (u *User) NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideBuy, qty, 0, opts...)
+}
+
+func (u *User) NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	return u.NewFuturesOrder(asset, quote, cex.OrderTypeMarket, cex.OrderSideSell, qty, 0, opts...)
+}
+
+// ------------------------------------------------------------
+// Trader Implementation
+// ============================================================
+
+// ============================================================
+// Private Trade Functions
+// ------------------------------------------------------------
+
+var ordTypeByCexOrdType = map[cex.OrderType]OrderType{
+	cex.OrderTypeLimit:  OrderTypeLimit,
+	cex.OrderTypeMarket: OrderTypeMarket,
+}
+
+var cexOrdTypeByOrdType = map[OrderType]cex.OrderType{
+	OrderTypeLimit:    cex.OrderTypeLimit,
+	OrderTypeMarket:   cex.OrderTypeMarket,
+	OrderTypePostOnly: cex.OrderTypeLimit,
+	OrderTypeFok:      cex.OrderTypeLimit,
+	OrderTypeIoc:      cex.OrderTypeLimit,
+}
+
+var ordSideByCexOrdSide = map[cex.OrderSide]OrderSide{
+	cex.OrderSideBuy:  OrderSideBuy,
+	cex.OrderSideSell: OrderSideSell,
+}
+
+var cexOrdSideByOrdSide = map[OrderSide]cex.OrderSide{
+	OrderSideBuy:  cex.OrderSideBuy,
+	OrderSideSell: cex.OrderSideSell,
+}
+
+var cexOrdStatusByOrdState = map[OrderState]cex.OrderStatus{
+	OrderStateLive:            cex.OrderStatusNew,
+	OrderStatePartiallyFilled: cex.OrderStatusPartiallyFilled,
+	OrderStateFilled:          cex.OrderStatusFilled,
+	OrderStateCanceled:        cex.OrderStatusCanceled,
+	OrderStateMmpCanceled:     cex.OrderStatusCanceled,
+}
+
+var cexPairTypeByInstType = map[InstType]cex.PairType{
+	InstTypeSpot:   cex.PairTypeSpot,
+	InstTypeMargin: cex.PairTypeSpot,
+	InstTypeSwap:   cex.PairTypeFutures,
+}
+
+// newOrd places order, futures order reduces position of the opposite side of orderSide, if closing is true.
+func (u *User) newOrd(pairType cex.PairType, asset, quote string, orderType cex.OrderType, orderSide cex.OrderSide, qty, price float64, closing bool, opts ...cex.CltOpt) (*cex.Response, *cex.Order, cex.RequestError) {
+	params := NewOrderParams{
+		OrdType: ordTypeByCexOrdType[orderType],
+		Side:    ordSideByCexOrdSide[orderSide],
+		Sz:      qty,
+		Px:      price,
+	}
+	switch pairType {
+	case cex.PairTypeSpot:
+		params.InstId = SpotInstId(asset, quote)
+		params.TdMode = TradeModeCash
+		if orderType == cex.OrderTypeMarket {
+			// keep qty as asset qty for market buy order
+			params.TgtCcy = TargetCurrencyBase
+		}
+	case cex.PairTypeFutures:
+		params.InstId = SwapInstId(asset, quote)
+		params.TdMode = u.cfg.swapTdMode
+		switch {
+		case u.cfg.isLongShortMode:
+			// buy opens long and sell opens short, closing order is on the opposite side
+			if (orderSide == cex.OrderSideBuy) != closing {
+				params.PosSide = PositionSideLong
+			} else {
+				params.PosSide = PositionSideShort
+			}
+		case closing:
+			params.ReduceOnly = true
+		}
+	}
+	ord := &cex.Order{
+		Cex:       cex.OKX,
+		PairType:  pairType,
+		OrderType: orderType,
+		OrderSide: orderSide,
+		Symbol:    params.InstId,
+		ApiKey:    u.api.ApiKey,
+		OriQty:    qty,
+		OriPrice:  price,
+	}
+	resp, result, err := u.NewOrder(params, opts...)
+	if err.IsNil() {
+		ord.OrderId = result.OrdId
+		ord.ClientOrderId = result.ClOrdId
+		ord.Status = cex.OrderStatusNew
+	}
+	return resp, ord, err
+}
+
+func (u *User) cancelOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	resp, _, err := u.CancelRawOrder(ord.Symbol, ord.OrderId, ord.ClientOrderId, opts...)
+	if err.IsNotNil() {
+		return resp, err
+	}
+	// okx cancel response does not contain order state
+	return u.queryOrd(ord, opts...)
+}
+
+func (u *User) queryOrd(ord *cex.Order, opts ...cex.CltOpt) (*cex.Response, cex.RequestError) {
+	if ord == nil {
+		return nil, cex.RequestError{Err: errors.New("nil order")}
+	}
+	resp, rawOrd, err := u.QueryRawOrder(ord.Symbol, ord.OrderId, ord.ClientOrderId, opts...)
+	if err.IsNil() {
+		UpdateOrderWithRawOrder(ord, rawOrd)
+	}
+	return resp, err
+}
+
+func (u *User) waitOrd(ctx context.Context, ord *cex.Order, opts ...cex.CltOpt) chan cex.RequestError {
+	query := func(ctx context.Context, ord *cex.Order) cex.RequestError {
+		_, err := u.WithContext(ctx).queryOrd(ord, opts...)
+		return err
+	}
+	return cex.WaitOrder(ctx, ord, query, u.cfg.waitOrderOpts...)
+}
+
+func SwitchOrderToCexOrder(rawOrd Order) cex.Order {
+	filledQty := rawOrd.AccFillSz.Float64()
+	avgPx := rawOrd.AvgPx.Float64()
+	ord := cex.Order{
+		Cex:            cex.OKX,
+		PairType:       cexPairTypeByInstType[rawOrd.InstType],
+		OrderType:      cexOrdTypeByOrdType[rawOrd.OrdType],
+		OrderSide:      cexOrdSideByOrdSide[rawOrd.Side],
+		Symbol:         rawOrd.InstId,
+		ClientOrderId:  rawOrd.ClOrdId,
+		OriQty:         rawOrd.Sz.Float64(),
+		OriPrice:       rawOrd.Px.Float64(),
+		OrderId:        rawOrd.OrdId,
+		Status:         cexOrdStatusByOrdState[rawOrd.State],
+		FilledQty:      filledQty,
+		FilledQuote:    filledQty * avgPx,
+		FilledAvgPrice: avgPx,
+		RawOrder:       rawOrd,
+	}
+	if rawOrd.FeeCcy != "" && rawOrd.Fee != 0 {
+		// okx fee is negative if it is charged
+		ord.Commissions = map[string]float64{rawOrd.FeeCcy: -rawOrd.Fee.Float64()}
+	}
+	return ord
+}
+
+// ToCexOrder implements cex.RawOrder.
+func (o Order) ToCexOrder() cex.Order {
+	return SwitchOrderToCexOrder(o)
+}
+
+// UpdateOrderWithRawOrder merges raw order into ord by cex.MergeOrderUpdate,
+// so out-of-order responses will not make ord state regress.
+func UpdateOrderWithRawOrder(ord *cex.Order, rawOrd Order) {
+	if ord == nil {
+		return
+	}
+	update := SwitchOrderToCexOrder(rawOrd)
+	// okx responds accumulated fee, which is stale if update is older
+	if update.Commissions != nil && update.FilledQty >= ord.FilledQty {
+		ord.Commissions = update.Commissions
+	}
+	cex.MergeOrderUpdate(ord, update)
+}
+
+// ------------------------------------------------------------
+// Private Trade Functions
+// ============================================================
+
+// ============================================================
+// ReqMaker
+// ------------------------------------------------------------
+
+// Logger implements cex.LoggerProvider.
+func (u *User) Logger() cex.Logger {
+	return u.cfg.logger
+}
+
+// CltOpts implements cex.CltOptsProvider.
+func (u *User) CltOpts() []cex.CltOpt {
+	var opts []cex.CltOpt
+	if u.cfg.transport != nil {
+		opts = append(opts, cex.CltOptTransport(u.cfg.transport))
+	}
+	if u.cfg.backend != "" {
+		opts = append(opts, cex.CltOptBackend(u.cfg.backend))
+	}
+	return opts
+}
+
+func (u *User) Make(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*cex.HTTPRequest, error) {
+	if err := cex.ValidateParams(reqData); err != nil {
+		return nil, err
+	}
+	// per call options override them, because they are applied later
+	opts = append(u.CltOpts(), opts...)
+	requestPath, body, err := composePathAndBody(config, reqData)
+	if err != nil {
+		return nil, err
+	}
+	requestPath = appendQuery(config, requestPath, cex.NewReqOpts(opts...).Query)
+	req := cex.NewHTTPRequest(opts...).
+		SetHeader("Content-Type", "application/json")
+	req.URL = config.BaseUrl + requestPath
+	if u.cfg.simulated || u.api.Env.IsTestnet() {
+		req.SetHeader("x-simulated-trading", "1")
+	}
+	if config.IsUserData {
+		timestamp := u.cfg.timeSync.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+		req.SetHeaders(map[string]string{
+			"OK-ACCESS-KEY":        u.api.ApiKey,
+			"OK-ACCESS-SIGN":       sign(timestamp, config.Method, requestPath, body, u.api.SecretKey),
+			"OK-ACCESS-TIMESTAMP":  timestamp,
+			"OK-ACCESS-PASSPHRASE": u.api.Passphrase,
+		})
+	}
+	if body != "" {
+		req.SetBody(body)
+	}
+	if u.ctx != nil {
+		req.SetContext(u.ctx)
+	}
+	return req, nil
+}
+
+// composePathAndBody composes request path with query for GET and DELETE,
+// and json body for other methods.
+// okx signs request path with query, so query must be composed by self.
+func composePathAndBody(config cex.ReqBaseConfig, reqData any) (requestPath, body string, err error) {
+	requestPath = config.Path
+	if reqData == nil {
+		return
+	}
+	switch config.Method {
+	case http.MethodGet, http.MethodDelete:
+		m, err := s2m.ToStrMap(reqData)
+		if err != nil {
+			return "", "", fmt.Errorf("okx: make request, %w: %w", cex.ErrS2M, err)
+		}
+		val := url.Values{}
+		for k, v := range m {
+			val.Set(k, v)
+		}
+		if len(val) > 0 {
+			requestPath += "?" + val.Encode()
+		}
+	default:
+		b, err := json.Marshal(reqData)
+		if err != nil {
+			return "", "", fmt.Errorf("okx: make request, %w: %w", cex.ErrJsonMarshal, err)
+		}
+		body = string(b)
+	}
+	return
+}
+
+// appendQuery appends extra query of CltOpts to request path of GET and DELETE,
+// before request path is signed.
+func appendQuery(config cex.ReqBaseConfig, requestPath string, query url.Values) string {
+	if len(query) == 0 || (config.Method != http.MethodGet && config.Method != http.MethodDelete) {
+		return requestPath
+	}
+	if strings.Contains(requestPath, "?") {
+		return requestPath + "&" + query.Encode()
+	}
+	return requestPath + "?" + query.Encode()
+}
+
+// ------------------------------------------------------------
+// ReqMaker
+// ============================================================
+
+// ============================================================
+// Signer
+// ------------------------------------------------------------
+
+// sign signs timestamp + method + requestPath + body by HmacSHA256,
+// and encodes it by base64.
+func sign(timestamp, method, requestPath, body, key string) string {
+	return cex.SignByHmacSHA256ToBase64(timestamp+method+requestPath+body, key)
+}
+
+// ------------------------------------------------------------
+// Signer
+// ============================================================
@@ -0,0 +1,133 @@
+package okx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dwdwow/cex"
+	"github.com/dwdwow/props"
+)
+
+func readApiKey() cex.Api {
+	apiKeys := cex.MustReadApiKey()
+	apiKey, ok := apiKeys["OKXTEST"]
+	if !ok {
+		panic("no okx api key")
+	}
+	return apiKey
+}
+
+func newTestUser() *User {
+	apiKey := readApiKey()
+	return NewUser(apiKey.ApiKey, apiKey.SecretKey, apiKey.Passphrase)
+}
+
+func TestSign(t *testing.T) {
+	s := sign("2020-12-08T09:08:57.715Z", http.MethodGet, "/api/v5/account/balance?ccy=BTC", "", "22582BD0CFF14C41EDBF1AB98506286D")
+	if s != cex.SignByHmacSHA256ToBase64("2020-12-08T09:08:57.715ZGET/api/v5/account/balance?ccy=BTC", "22582BD0CFF14C41EDBF1AB98506286D") {
+		t.Error("wrong sign", s)
+	}
+}
+
+func TestComposePathAndBody(t *testing.T) {
+	path, body, err := composePathAndBody(QueryOrderConfig.ReqBaseConfig, QueryOrderParams{InstId: "BTC-USDT", OrdId: "1"})
+	props.PanicIfNotNil(err)
+	if path != "/api/v5/trade/order?instId=BTC-USDT&ordId=1" || body != "" {
+		t.Error("wrong get path or body", path, body)
+	}
+	path, body, err = composePathAndBody(NewOrderConfig.ReqBaseConfig, NewOrderParams{InstId: "BTC-USDT", TdMode: TradeModeCash, Side: OrderSideBuy, OrdType: OrderTypeLimit, Sz: 0.01, Px: 30000})
+	props.PanicIfNotNil(err)
+	if path != "/api/v5/trade/order" || body != `{"instId":"BTC-USDT","tdMode":"cash","side":"buy","ordType":"limit","sz":"0.01","px":"30000"}` {
+		t.Error("wrong post path or body", path, body)
+	}
+}
+
+func TestOrderBodyUnmsh(t *testing.T) {
+	result, err := orderBodyUnmsh([]byte(`{"code":"1","msg":"","data":[{"clOrdId":"","ordId":"","tag":"","sCode":"51008","sMsg":"Order failed. Insufficient balance."}]}`))
+	if err == nil || !errors.Is(err.Err, cex.ErrInsufficientBalance) {
+		t.Fatal("err should be insufficient balance, but", err)
+	}
+	result, err = orderBodyUnmsh([]byte(`{"code":"0","msg":"","data":[{"clOrdId":"","ordId":"312269865356374016","tag":"","sCode":"0","sMsg":""}]}`))
+	if err != nil || result.OrdId != "312269865356374016" {
+		t.Error("wrong result", result, err)
+	}
+}
+
+func TestSwitchOrderToCexOrder(t *testing.T) {
+	rawOrd, err := firstItemBodyUnmsh[Order]([]byte(`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","ordId":"1","clOrdId":"","px":"30000","sz":"0.01","ordType":"limit","side":"buy","accFillSz":"0.005","avgPx":"30000","state":"partially_filled","fee":"-0.000005","feeCcy":"BTC","fillPx":""}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ord := SwitchOrderToCexOrder(rawOrd)
+	props.PrintlnIndent(ord)
+	if ord.Status != cex.OrderStatusPartiallyFilled || ord.PairType != cex.PairTypeSpot || ord.FilledQuote != 150 || ord.Commissions["BTC"] != 0.000005 {
+		t.Error("wrong order", ord)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestUser_CloseFutures(t *testing.T) {
+	var bodies []NewOrderParams
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var params NewOrderParams
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &params)
+		bodies = append(bodies, params)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"code":"0","msg":"","data":[{"ordId":"1","sCode":"0"}]}`)),
+			Request:    r,
+		}, nil
+	})
+	user := NewUser("key", "secret", "pass", UserOptTransport(transport), UserOptLongShortMode())
+	if _, _, err := user.NewFuturesMarketSellOrder("ETH", "USDT", 1); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if _, _, err := user.CloseFuturesLong("ETH", "USDT", cex.OrderTypeMarket, 1, 0); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if _, _, err := user.CloseFuturesShort("ETH", "USDT", cex.OrderTypeMarket, 1, 0); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(bodies) != 3 || bodies[0].PosSide != PositionSideShort || bodies[1].Side != OrderSideSell || bodies[1].PosSide != PositionSideLong ||
+		bodies[2].Side != OrderSideBuy || bodies[2].PosSide != PositionSideShort || bodies[1].ReduceOnly {
+		t.Error("closing order should be on position side of opposite order side", bodies)
+	}
+
+	bodies = nil
+	user = NewUser("key", "secret", "pass", UserOptTransport(transport))
+	if _, _, err := user.CloseFuturesLong("ETH", "USDT", cex.OrderTypeMarket, 1, 0); err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(bodies) != 1 || bodies[0].PosSide != "" || !bodies[0].ReduceOnly {
+		t.Error("closing order should be reduce only in net mode", bodies)
+	}
+}
+
+func TestUser_Instruments(t *testing.T) {
+	_, data, err := EmptyUser().Instruments(InstTypeSpot)
+	props.PanicIfNotNil(err.Err)
+	props.PrintlnIndent(data[:2])
+}
+
+func TestUser_Balance(t *testing.T) {
+	_, data, err := newTestUser().Balance("")
+	props.PanicIfNotNil(err.Err)
+	props.PrintlnIndent(data)
+}
+
+func TestUser_Positions(t *testing.T) {
+	_, data, err := newTestUser().Positions(InstTypeSwap, "")
+	props.PanicIfNotNil(err.Err)
+	props.PrintlnIndent(data)
+}
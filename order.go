@@ -1,5 +1,7 @@
 package cex
 
+import "sort"
+
 type OrderType string
 
 const (
@@ -39,6 +41,8 @@ type Order struct {
 	TimeInForce   string `json:"timeInForce" bson:"timeInForce"`
 	ClientOrderId string `json:"clientOrderId" bson:"clientOrderId"`
 	ApiKey        string `json:"apiKey" bson:"apiKey"`
+	// SelfTradePreventionMode is cex specific, ex. EXPIRE_TAKER of binance
+	SelfTradePreventionMode string `json:"selfTradePreventionMode" bson:"selfTradePreventionMode"`
 
 	// popular by user self
 	OriQty   float64 `json:"oriQty" bson:"oriQty"`
@@ -53,6 +57,14 @@ type Order struct {
 	FilledQuote    float64 `json:"filledQuote" bson:"filledQuote"`
 	FilledAvgPrice float64 `json:"filledAvgPrice" bson:"filledAvgPrice"`
 
+	// popular by fills
+	// Fills may be empty, if cex does not respond fills.
+	Fills       []Fill             `json:"fills" bson:"fills"`
+	Commissions map[string]float64 `json:"commissions" bson:"commissions"` // total commission by asset
+
+	// popular by modifications of price or qty, sorted by Count
+	Amendments []OrderAmendment `json:"amendments" bson:"amendments"`
+
 	RawOrder any `json:"rawOrder" bson:"rawOrder"`
 
 	//Asset    string  `json:"asset" bson:"asset"`
@@ -63,9 +75,310 @@ func (o *Order) IsFinished() bool {
 	if o == nil {
 		return false
 	}
-	switch o.Status {
-	case OrderStatusRejected, OrderStatusExpired, OrderStatusFilled, OrderStatusCanceled:
-		return true
+	return o.Status.IsFinished()
+}
+
+// orderStatusRank is used to keep order status transitions monotonic.
+// Finished statuses have the same highest rank.
+func orderStatusRank(status OrderStatus) int {
+	switch status {
+	case OrderStatusNew:
+		return 1
+	case OrderStatusPartiallyFilled:
+		return 2
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired:
+		return 3
+	}
+	return 0
+}
+
+// MergeOrderUpdate merges update, which may be a QueryOrder response
+// or a user stream event, into ord.
+// Responses may arrive out of order, so merging is monotonic:
+//   - status only transits as NextOrderStatuses, ex. FILLED will not be replaced by NEW
+//   - cumulative filled fields only increase
+//   - empty identity fields of ord are popular by update
+//
+// Returns true if ord is changed.
+func MergeOrderUpdate(ord *Order, update Order) (changed bool) {
+	if ord == nil {
+		return false
+	}
+
+	setStr := func(dst *string, src string) {
+		if *dst == "" && src != "" {
+			*dst = src
+			changed = true
+		}
+	}
+	setStr(&ord.Symbol, update.Symbol)
+	setStr(&ord.OrderId, update.OrderId)
+	setStr(&ord.ClientOrderId, update.ClientOrderId)
+	setStr(&ord.TimeInForce, update.TimeInForce)
+	setStr(&ord.ApiKey, update.ApiKey)
+	setStr(&ord.SelfTradePreventionMode, update.SelfTradePreventionMode)
+	if ord.Cex == "" && update.Cex != "" {
+		ord.Cex = update.Cex
+		changed = true
+	}
+	if ord.PairType == "" && update.PairType != "" {
+		ord.PairType = update.PairType
+		changed = true
+	}
+	if ord.OrderType == "" && update.OrderType != "" {
+		ord.OrderType = update.OrderType
+		changed = true
+	}
+	if ord.OrderSide == "" && update.OrderSide != "" {
+		ord.OrderSide = update.OrderSide
+		changed = true
+	}
+	if ord.OriQty == 0 && update.OriQty != 0 {
+		ord.OriQty = update.OriQty
+		changed = true
+	}
+	if ord.OriPrice == 0 && update.OriPrice != 0 {
+		ord.OriPrice = update.OriPrice
+		changed = true
+	}
+
+	crtRank := orderStatusRank(ord.Status)
+	newRank := orderStatusRank(update.Status)
+
+	// finished status is final, should not be replaced by other finished status
+	if CanTransitOrderStatus(ord.Status, update.Status) {
+		ord.Status = update.Status
+		changed = true
+	}
+
+	if update.FilledQty > ord.FilledQty {
+		ord.FilledQty = update.FilledQty
+		ord.FilledQuote = update.FilledQuote
+		ord.FilledAvgPrice = update.FilledAvgPrice
+		changed = true
+	}
+
+	if len(update.Amendments) > 0 && ord.AddAmendments(update.Amendments...) {
+		changed = true
+	}
+
+	if len(update.Fills) > 0 {
+		n := len(ord.Fills)
+		ord.AddFills(update.Fills...)
+		if len(ord.Fills) != n {
+			changed = true
+		}
+	}
+
+	// raw order is replaced only if update is not older than ord
+	if update.RawOrder != nil && (ord.RawOrder == nil || (newRank >= crtRank && update.FilledQty >= ord.FilledQty)) {
+		ord.RawOrder = update.RawOrder
+	}
+
+	return
+}
+
+// OrderAmendment is one modification of price or qty of open order.
+type OrderAmendment struct {
+	Id          string  `json:"id" bson:"id"`
+	Time        int64   `json:"time" bson:"time"`
+	PriceBefore float64 `json:"priceBefore" bson:"priceBefore"`
+	PriceAfter  float64 `json:"priceAfter" bson:"priceAfter"`
+	QtyBefore   float64 `json:"qtyBefore" bson:"qtyBefore"`
+	QtyAfter    float64 `json:"qtyAfter" bson:"qtyAfter"`
+	// Count is number of modifications of order, including this one.
+	Count int `json:"count" bson:"count"`
+}
+
+// AddAmendments adds amendments to order, ignoring amendments with duplicate id,
+// and sets OriPrice and OriQty to the latest amendment.
+// Returns true if any amendment is added.
+func (o *Order) AddAmendments(amendments ...OrderAmendment) (changed bool) {
+	if o == nil {
+		return false
+	}
+	existed := map[string]bool{}
+	for _, a := range o.Amendments {
+		if a.Id != "" {
+			existed[a.Id] = true
+		}
+	}
+	for _, a := range amendments {
+		if a.Id != "" {
+			if existed[a.Id] {
+				continue
+			}
+			existed[a.Id] = true
+		}
+		o.Amendments = append(o.Amendments, a)
+		changed = true
+	}
+	if !changed {
+		return false
+	}
+	sort.SliceStable(o.Amendments, func(i, j int) bool {
+		a, b := o.Amendments[i], o.Amendments[j]
+		if a.Count != b.Count {
+			return a.Count < b.Count
+		}
+		return a.Time < b.Time
+	})
+	latest := o.Amendments[len(o.Amendments)-1]
+	if latest.PriceAfter != 0 {
+		o.OriPrice = latest.PriceAfter
+	}
+	if latest.QtyAfter != 0 {
+		o.OriQty = latest.QtyAfter
+	}
+	return true
+}
+
+// Fill is one trade of an order.
+type Fill struct {
+	TradeId         string  `json:"tradeId" bson:"tradeId"`
+	Price           float64 `json:"price" bson:"price"`
+	Qty             float64 `json:"qty" bson:"qty"`
+	Commission      float64 `json:"commission" bson:"commission"`
+	CommissionAsset string  `json:"commissionAsset" bson:"commissionAsset"`
+	IsMaker         bool    `json:"isMaker" bson:"isMaker"`
+	Time            int64   `json:"time" bson:"time"`
+
+	// Fields below may be empty, if fill is got from order response,
+	// because they are the same as fields of order.
+	Cex       Name      `json:"cex,omitempty" bson:"cex,omitempty"`
+	PairType  PairType  `json:"pairType,omitempty" bson:"pairType,omitempty"`
+	Symbol    string    `json:"symbol,omitempty" bson:"symbol,omitempty"`
+	OrderId   string    `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	OrderSide OrderSide `json:"orderSide,omitempty" bson:"orderSide,omitempty"`
+	QuoteQty  float64   `json:"quoteQty,omitempty" bson:"quoteQty,omitempty"`
+	// RealizedPnl is responded by futures trades only.
+	RealizedPnl float64 `json:"realizedPnl,omitempty" bson:"realizedPnl,omitempty"`
+}
+
+// FillsSummary is aggregated result of fills.
+type FillsSummary struct {
+	Qty         float64            `json:"qty" bson:"qty"`
+	Quote       float64            `json:"quote" bson:"quote"`
+	AvgPrice    float64            `json:"avgPrice" bson:"avgPrice"`
+	Commissions map[string]float64 `json:"commissions" bson:"commissions"`
+}
+
+// AggregateFills aggregates fills into cumulative qty, quote,
+// average price and total commission per asset.
+func AggregateFills(fills []Fill) FillsSummary {
+	summary := FillsSummary{Commissions: map[string]float64{}}
+	for _, f := range fills {
+		summary.Qty += f.Qty
+		summary.Quote += f.Qty * f.Price
+		if f.CommissionAsset != "" {
+			summary.Commissions[f.CommissionAsset] += f.Commission
+		}
+	}
+	if summary.Qty != 0 {
+		summary.AvgPrice = summary.Quote / summary.Qty
+	}
+	return summary
+}
+
+// AddFills appends fills to order, ignoring fills with duplicate trade id,
+// and updates commissions.
+// Filled qty, quote and average price are updated only if fills cover
+// more qty than current filled qty, because fills may be partial.
+func (o *Order) AddFills(fills ...Fill) {
+	if o == nil {
+		return
+	}
+	existed := map[string]bool{}
+	for _, f := range o.Fills {
+		if f.TradeId != "" {
+			existed[f.TradeId] = true
+		}
+	}
+	for _, f := range fills {
+		if f.TradeId != "" {
+			if existed[f.TradeId] {
+				continue
+			}
+			existed[f.TradeId] = true
+		}
+		o.Fills = append(o.Fills, f)
+	}
+	summary := AggregateFills(o.Fills)
+	o.Commissions = summary.Commissions
+	if summary.Qty > o.FilledQty {
+		o.FilledQty = summary.Qty
+		o.FilledQuote = summary.Quote
+		o.FilledAvgPrice = summary.AvgPrice
+	}
+}
+
+// FillsSummary returns aggregated result of order fills.
+func (o *Order) FillsSummary() FillsSummary {
+	if o == nil {
+		return FillsSummary{Commissions: map[string]float64{}}
+	}
+	return AggregateFills(o.Fills)
+}
+
+// Commission returns total commission of asset.
+func (o *Order) Commission(asset string) float64 {
+	if o == nil {
+		return 0
+	}
+	return o.Commissions[asset]
+}
+
+// OrderInfo is exchange-agnostic read-only view of order.
+// Order implements it, so order management code can depend on OrderInfo
+// instead of raw orders of cex packages.
+type OrderInfo interface {
+	GetCex() Name
+	GetPairType() PairType
+	GetSymbol() string
+	GetOrderId() string
+	GetClientOrderId() string
+	GetSide() OrderSide
+	GetType() OrderType
+	GetStatus() OrderStatus
+	GetOriQty() float64
+	GetOriPrice() float64
+	GetFilledQty() float64
+	GetFilledAvgPrice() float64
+	GetRawOrder() any
+	IsFinished() bool
+}
+
+func (o *Order) GetCex() Name               { return o.Cex }
+func (o *Order) GetPairType() PairType      { return o.PairType }
+func (o *Order) GetSymbol() string          { return o.Symbol }
+func (o *Order) GetOrderId() string         { return o.OrderId }
+func (o *Order) GetClientOrderId() string   { return o.ClientOrderId }
+func (o *Order) GetSide() OrderSide         { return o.OrderSide }
+func (o *Order) GetType() OrderType         { return o.OrderType }
+func (o *Order) GetStatus() OrderStatus     { return o.Status }
+func (o *Order) GetOriQty() float64         { return o.OriQty }
+func (o *Order) GetOriPrice() float64       { return o.OriPrice }
+func (o *Order) GetFilledQty() float64      { return o.FilledQty }
+func (o *Order) GetFilledAvgPrice() float64 { return o.FilledAvgPrice }
+func (o *Order) GetRawOrder() any           { return o.RawOrder }
+
+// RawOrder is implemented by raw orders of cex packages,
+// ex. bnc.SpotOrder, bnc.FuturesOrder, okx.Order.
+type RawOrder interface {
+	ToCexOrder() Order
+}
+
+// ToOrderInfo converts raw order to OrderInfo.
+func ToOrderInfo(raw RawOrder) OrderInfo {
+	ord := raw.ToCexOrder()
+	return &ord
+}
+
+// ToCexOrders converts raw orders to cex orders.
+func ToCexOrders[R RawOrder](raws []R) []Order {
+	ords := make([]Order, 0, len(raws))
+	for _, raw := range raws {
+		ords = append(ords, raw.ToCexOrder())
 	}
-	return false
+	return ords
 }
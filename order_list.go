@@ -0,0 +1,103 @@
+package cex
+
+type OrderListType string
+
+const (
+	// OrderListTypeOCO one-cancels-the-other,
+	// if one leg is filled or partially filled, the other legs are canceled.
+	OrderListTypeOCO OrderListType = "OCO"
+)
+
+// OrderList is a multi-leg order, ex. OCO.
+// Every leg is a normal Order, and shares the same OrderListId.
+type OrderList struct {
+	Cex      Name          `json:"cex" bson:"cex"`
+	PairType PairType      `json:"pairType" bson:"pairType"`
+	ListType OrderListType `json:"listType" bson:"listType"`
+	Symbol   string        `json:"symbol" bson:"symbol"`
+	ApiKey   string        `json:"apiKey" bson:"apiKey"`
+
+	OrderListId       string `json:"orderListId" bson:"orderListId"`
+	ClientOrderListId string `json:"clientOrderListId" bson:"clientOrderListId"`
+
+	// Legs may only contain identity fields, ex. Symbol, OrderId and ClientOrderId,
+	// if cex does not respond order details.
+	Legs []*Order `json:"legs" bson:"legs"`
+
+	RawOrderList any `json:"rawOrderList" bson:"rawOrderList"`
+}
+
+// IsFinished returns true, if all legs are finished.
+func (l *OrderList) IsFinished() bool {
+	if l == nil || len(l.Legs) == 0 {
+		return false
+	}
+	for _, leg := range l.Legs {
+		if !leg.IsFinished() {
+			return false
+		}
+	}
+	return true
+}
+
+// Leg returns leg by order id.
+func (l *OrderList) Leg(orderId string) (*Order, bool) {
+	if l == nil {
+		return nil, false
+	}
+	for _, leg := range l.Legs {
+		if leg != nil && leg.OrderId == orderId {
+			return leg, true
+		}
+	}
+	return nil, false
+}
+
+// FilledQty is total filled qty of all legs.
+func (l *OrderList) FilledQty() float64 {
+	if l == nil {
+		return 0
+	}
+	var qty float64
+	for _, leg := range l.Legs {
+		if leg != nil {
+			qty += leg.FilledQty
+		}
+	}
+	return qty
+}
+
+// MergeOrderListUpdate merges legs of update into l by MergeOrderUpdate.
+// Legs not in l are appended.
+// Returns true if l is changed.
+func MergeOrderListUpdate(l *OrderList, update OrderList) (changed bool) {
+	if l == nil {
+		return false
+	}
+	if l.OrderListId == "" && update.OrderListId != "" {
+		l.OrderListId = update.OrderListId
+		changed = true
+	}
+	if l.ClientOrderListId == "" && update.ClientOrderListId != "" {
+		l.ClientOrderListId = update.ClientOrderListId
+		changed = true
+	}
+	for _, upd := range update.Legs {
+		if upd == nil {
+			continue
+		}
+		if leg, ok := l.Leg(upd.OrderId); ok {
+			if MergeOrderUpdate(leg, *upd) {
+				changed = true
+			}
+			continue
+		}
+		leg := *upd
+		l.Legs = append(l.Legs, &leg)
+		changed = true
+	}
+	if update.RawOrderList != nil {
+		l.RawOrderList = update.RawOrderList
+	}
+	return
+}
@@ -0,0 +1,44 @@
+package cex
+
+import "testing"
+
+func TestMergeOrderListUpdate(t *testing.T) {
+	list := &OrderList{
+		ListType: OrderListTypeOCO,
+		Legs: []*Order{
+			{OrderId: "1", Status: OrderStatusNew},
+			{OrderId: "2", Status: OrderStatusNew},
+		},
+	}
+	if list.IsFinished() {
+		t.Fatal("list should not be finished")
+	}
+
+	changed := MergeOrderListUpdate(list, OrderList{
+		OrderListId: "10",
+		Legs: []*Order{
+			{OrderId: "1", Status: OrderStatusFilled, FilledQty: 1},
+			{OrderId: "2", Status: OrderStatusExpired},
+			{OrderId: "3", Status: OrderStatusNew},
+		},
+	})
+	if !changed || list.OrderListId != "10" || len(list.Legs) != 3 {
+		t.Fatal("wrong merged list", list)
+	}
+	if list.FilledQty() != 1 {
+		t.Error("wrong filled qty", list.FilledQty())
+	}
+	if list.IsFinished() {
+		t.Error("leg 3 is not finished")
+	}
+
+	// stale update should not regress legs
+	MergeOrderListUpdate(list, OrderList{Legs: []*Order{{OrderId: "1", Status: OrderStatusNew}}})
+	if leg, _ := list.Leg("1"); leg.Status != OrderStatusFilled {
+		t.Error("leg status regressed", leg.Status)
+	}
+
+	if new(OrderList).IsFinished() {
+		t.Error("empty list should not be finished")
+	}
+}
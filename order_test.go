@@ -0,0 +1,91 @@
+package cex
+
+import (
+	"testing"
+)
+
+func TestOrder_AddFills(t *testing.T) {
+	ord := &Order{}
+	ord.AddFills(
+		Fill{TradeId: "1", Price: 100, Qty: 1, Commission: 0.001, CommissionAsset: "BNB"},
+		Fill{TradeId: "2", Price: 200, Qty: 1, Commission: 0.2, CommissionAsset: "USDT"},
+	)
+	// duplicate fill should be ignored
+	ord.AddFills(Fill{TradeId: "2", Price: 200, Qty: 1, Commission: 0.2, CommissionAsset: "USDT"})
+	if len(ord.Fills) != 2 {
+		t.Fatal("fills len should be 2, but", len(ord.Fills))
+	}
+	if ord.FilledQty != 2 || ord.FilledQuote != 300 || ord.FilledAvgPrice != 150 {
+		t.Error("wrong filled result", ord.FilledQty, ord.FilledQuote, ord.FilledAvgPrice)
+	}
+	if ord.Commission("BNB") != 0.001 || ord.Commission("USDT") != 0.2 {
+		t.Error("wrong commissions", ord.Commissions)
+	}
+}
+
+func TestMergeOrderUpdate(t *testing.T) {
+	ord := &Order{OrderId: "1", Status: OrderStatusPartiallyFilled, FilledQty: 1, FilledQuote: 100, FilledAvgPrice: 100}
+
+	// stale response should not regress order
+	changed := MergeOrderUpdate(ord, Order{OrderId: "1", Status: OrderStatusNew})
+	if changed {
+		t.Error("stale update should not change order")
+	}
+	if ord.Status != OrderStatusPartiallyFilled || ord.FilledQty != 1 {
+		t.Error("order regressed", ord.Status, ord.FilledQty)
+	}
+
+	changed = MergeOrderUpdate(ord, Order{OrderId: "1", Status: OrderStatusFilled, FilledQty: 2, FilledQuote: 210, FilledAvgPrice: 105})
+	if !changed {
+		t.Error("newer update should change order")
+	}
+	if ord.Status != OrderStatusFilled || ord.FilledQty != 2 || ord.FilledAvgPrice != 105 {
+		t.Error("order is not updated", ord.Status, ord.FilledQty, ord.FilledAvgPrice)
+	}
+
+	// finished status is final
+	MergeOrderUpdate(ord, Order{OrderId: "1", Status: OrderStatusCanceled})
+	if ord.Status != OrderStatusFilled {
+		t.Error("finished status should not be replaced, but", ord.Status)
+	}
+}
+
+func TestOrder_AddAmendments(t *testing.T) {
+	ord := &Order{OrderId: "1", OriQty: 1, OriPrice: 100}
+	changed := ord.AddAmendments(
+		OrderAmendment{Id: "b", Time: 2, PriceBefore: 101, PriceAfter: 102, QtyBefore: 1, QtyAfter: 2, Count: 2},
+		OrderAmendment{Id: "a", Time: 1, PriceBefore: 100, PriceAfter: 101, QtyBefore: 1, QtyAfter: 1, Count: 1},
+	)
+	if !changed || len(ord.Amendments) != 2 || ord.Amendments[0].Id != "a" {
+		t.Fatal("amendments should be added in order", ord.Amendments)
+	}
+	if ord.OriPrice != 102 || ord.OriQty != 2 {
+		t.Error("price and qty should be the latest amended ones", ord.OriPrice, ord.OriQty)
+	}
+	if MergeOrderUpdate(ord, Order{OrderId: "1", Amendments: []OrderAmendment{{Id: "a", Count: 1}}}) {
+		t.Error("duplicated amendment should not change order")
+	}
+}
+
+type testRawOrder struct {
+	id     string
+	status OrderStatus
+}
+
+func (o testRawOrder) ToCexOrder() Order {
+	return Order{OrderId: o.id, Status: o.status, RawOrder: o}
+}
+
+func TestToCexOrders(t *testing.T) {
+	ords := ToCexOrders([]testRawOrder{{"1", OrderStatusNew}, {"2", OrderStatusFilled}})
+	if len(ords) != 2 || ords[1].OrderId != "2" {
+		t.Fatal("wrong orders", ords)
+	}
+	var info OrderInfo = ToOrderInfo(testRawOrder{"2", OrderStatusFilled})
+	if !info.IsFinished() || info.GetOrderId() != "2" {
+		t.Error("wrong order info", info)
+	}
+	if _, ok := info.GetRawOrder().(testRawOrder); !ok {
+		t.Error("raw order should be kept")
+	}
+}
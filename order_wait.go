@@ -0,0 +1,240 @@
+package cex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++                Cex Order: Wait Order                +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// IsFinished returns true if status is final.
+func (s OrderStatus) IsFinished() bool {
+	switch s {
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired:
+		return true
+	}
+	return false
+}
+
+// NextOrderStatuses returns statuses which status can transit to.
+// Order status state machine:
+//
+//	"" -> New -> PartiallyFilled -> Filled/Canceled/Expired
+//	"" -> New -> Filled/Canceled/Rejected/Expired
+//
+// Empty status means unknown status, ex. order is just created locally,
+// so it can transit to any status.
+// Finished statuses can not transit.
+func NextOrderStatuses(status OrderStatus) []OrderStatus {
+	switch status {
+	case "":
+		return []OrderStatus{OrderStatusNew, OrderStatusPartiallyFilled, OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired}
+	case OrderStatusNew:
+		return []OrderStatus{OrderStatusPartiallyFilled, OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired}
+	case OrderStatusPartiallyFilled:
+		return []OrderStatus{OrderStatusFilled, OrderStatusCanceled, OrderStatusExpired}
+	}
+	return nil
+}
+
+// CanTransitOrderStatus returns true if from can transit to to.
+func CanTransitOrderStatus(from, to OrderStatus) bool {
+	for _, s := range NextOrderStatuses(from) {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderStatusTransition is passed to callback of WaitOrder,
+// Order is a copy of the order after transition.
+type OrderStatusTransition struct {
+	From  OrderStatus
+	To    OrderStatus
+	Order Order
+}
+
+// OrderQuerier queries ord and updates it in place, ex. User.QueryOrder of cex packages.
+type OrderQuerier func(ctx context.Context, ord *Order) RequestError
+
+// OrderUpdateSource pushes order updates, ex. orders parsed from user data stream.
+// Channel returned by SubOrderUpdates should be closed, when ctx is done.
+type OrderUpdateSource interface {
+	SubOrderUpdates(ctx context.Context) <-chan Order
+}
+
+type WaitOrderOpt func(*waitOrderConfig)
+
+type waitOrderConfig struct {
+	pollInterval time.Duration
+	onTransition func(OrderStatusTransition)
+	updateSource OrderUpdateSource
+}
+
+// WaitOrderOptPollInterval sets interval of querying order, default is 1 second.
+func WaitOrderOptPollInterval(interval time.Duration) WaitOrderOpt {
+	return func(c *waitOrderConfig) {
+		if interval > 0 {
+			c.pollInterval = interval
+		}
+	}
+}
+
+// WaitOrderOptOnTransition sets callback called on every status transition.
+// Callback is called in waiting goroutine, so it should not block.
+func WaitOrderOptOnTransition(callback func(OrderStatusTransition)) WaitOrderOpt {
+	return func(c *waitOrderConfig) {
+		c.onTransition = callback
+	}
+}
+
+// WaitOrderOptUpdateSource makes WaitOrder driven by pushed updates, ex. user data stream.
+// Order is still polled as fallback, so poll interval can be set longer.
+func WaitOrderOptUpdateSource(source OrderUpdateSource) WaitOrderOpt {
+	return func(c *waitOrderConfig) {
+		c.updateSource = source
+	}
+}
+
+// WaitOrder waits ord until it is finished or ctx is done.
+// ord is updated in place by query and pushed updates.
+// The returned channel receives one error, which is nil if ord is finished.
+// Per call usage, ex.
+//
+//	cex.WaitOrder(ctx, ord, func(ctx context.Context, ord *cex.Order) cex.RequestError {
+//		_, err := user.WithContext(ctx).QueryOrder(ord)
+//		return err
+//	}, cex.WaitOrderOptPollInterval(time.Second*5))
+func WaitOrder(ctx context.Context, ord *Order, query OrderQuerier, opts ...WaitOrderOpt) chan RequestError {
+	ch := make(chan RequestError, 1)
+	if ord == nil {
+		ch <- RequestError{Err: errors.New("nil order")}
+		return ch
+	}
+	if ord.IsFinished() {
+		ch <- RequestError{}
+		return ch
+	}
+	cfg := waitOrderConfig{pollInterval: time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	go func() {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var updates <-chan Order
+		if cfg.updateSource != nil {
+			updates = cfg.updateSource.SubOrderUpdates(ctx)
+		}
+
+		status := ord.Status
+		checkTransition := func() {
+			if ord.Status == status {
+				return
+			}
+			t := OrderStatusTransition{From: status, To: ord.Status, Order: *ord}
+			status = ord.Status
+			if cfg.onTransition != nil {
+				cfg.onTransition(t)
+			}
+		}
+
+		for {
+			err := query(ctx, ord)
+			checkTransition()
+			if err.IsNil() && ord.IsFinished() {
+				ch <- RequestError{}
+				return
+			}
+			timer := time.NewTimer(cfg.pollInterval)
+		waiting:
+			for {
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					ch <- RequestError{Err: fmt.Errorf("ctxerr: %w, requesterr: %w", ctx.Err(), err.Err)}
+					return
+				case update, ok := <-updates:
+					if !ok {
+						updates = nil
+						continue
+					}
+					if !isSameOrder(ord, update) {
+						continue
+					}
+					MergeOrderUpdate(ord, update)
+					checkTransition()
+					if ord.IsFinished() {
+						timer.Stop()
+						ch <- RequestError{}
+						return
+					}
+				case <-timer.C:
+					break waiting
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+func isSameOrder(ord *Order, update Order) bool {
+	if ord.Symbol != "" && update.Symbol != "" && ord.Symbol != update.Symbol {
+		return false
+	}
+	if ord.OrderId != "" && update.OrderId != "" {
+		return ord.OrderId == update.OrderId
+	}
+	return ord.ClientOrderId != "" && ord.ClientOrderId == update.ClientOrderId
+}
+
+// OrderUpdateHub is an OrderUpdateSource fanning out published orders to subscribers.
+// User data stream handler can publish orders into it,
+// and then WaitOrder can be driven by the stream.
+type OrderUpdateHub struct {
+	mux     sync.Mutex
+	subs    map[chan Order]struct{}
+	bufSize int
+}
+
+func NewOrderUpdateHub(bufSize int) *OrderUpdateHub {
+	return &OrderUpdateHub{subs: map[chan Order]struct{}{}, bufSize: bufSize}
+}
+
+// Publish sends ord to all subscribers.
+// Ord is dropped for subscribers whose buffer is full,
+// because WaitOrder polls order as fallback.
+func (h *OrderUpdateHub) Publish(ord Order) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	for sub := range h.subs {
+		select {
+		case sub <- ord:
+		default:
+		}
+	}
+}
+
+func (h *OrderUpdateHub) SubOrderUpdates(ctx context.Context) <-chan Order {
+	sub := make(chan Order, h.bufSize)
+	h.mux.Lock()
+	h.subs[sub] = struct{}{}
+	h.mux.Unlock()
+	go func() {
+		<-ctx.Done()
+		h.mux.Lock()
+		delete(h.subs, sub)
+		close(sub)
+		h.mux.Unlock()
+	}()
+	return sub
+}
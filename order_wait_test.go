@@ -0,0 +1,106 @@
+package cex
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCanTransitOrderStatus(t *testing.T) {
+	cases := []struct {
+		from, to OrderStatus
+		can      bool
+	}{
+		{"", OrderStatusFilled, true},
+		{OrderStatusNew, OrderStatusPartiallyFilled, true},
+		{OrderStatusNew, OrderStatusRejected, true},
+		{OrderStatusPartiallyFilled, OrderStatusFilled, true},
+		{OrderStatusPartiallyFilled, OrderStatusNew, false},
+		{OrderStatusPartiallyFilled, OrderStatusRejected, false},
+		{OrderStatusFilled, OrderStatusCanceled, false},
+		{OrderStatusNew, OrderStatusNew, false},
+	}
+	for _, c := range cases {
+		if CanTransitOrderStatus(c.from, c.to) != c.can {
+			t.Errorf("%q -> %q should be %v", c.from, c.to, c.can)
+		}
+	}
+}
+
+func TestWaitOrder_Poll(t *testing.T) {
+	statuses := []OrderStatus{OrderStatusNew, OrderStatusPartiallyFilled, OrderStatusFilled}
+	var queried int
+	query := func(ctx context.Context, ord *Order) RequestError {
+		MergeOrderUpdate(ord, Order{Status: statuses[queried]})
+		queried++
+		return RequestError{}
+	}
+	var transitions []OrderStatusTransition
+	ord := &Order{OrderId: "1"}
+	err := <-WaitOrder(context.Background(), ord, query,
+		WaitOrderOptPollInterval(time.Millisecond),
+		WaitOrderOptOnTransition(func(t OrderStatusTransition) {
+			transitions = append(transitions, t)
+		}),
+	)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if queried != 3 || ord.Status != OrderStatusFilled {
+		t.Error("order should be queried until filled", queried, ord.Status)
+	}
+	if len(transitions) != 3 || transitions[0].From != "" || transitions[2].From != OrderStatusPartiallyFilled || transitions[2].To != OrderStatusFilled {
+		t.Error("wrong transitions", transitions)
+	}
+}
+
+func TestWaitOrder_UpdateSource(t *testing.T) {
+	hub := NewOrderUpdateHub(10)
+	var mux sync.Mutex
+	var queried int
+	query := func(ctx context.Context, ord *Order) RequestError {
+		mux.Lock()
+		queried++
+		mux.Unlock()
+		MergeOrderUpdate(ord, Order{Status: OrderStatusNew})
+		return RequestError{}
+	}
+	ord := &Order{Symbol: "ETHUSDT", OrderId: "1"}
+	ch := WaitOrder(context.Background(), ord, query,
+		WaitOrderOptPollInterval(time.Hour),
+		WaitOrderOptUpdateSource(hub),
+	)
+	// wait subscribing
+	time.Sleep(10 * time.Millisecond)
+	hub.Publish(Order{Symbol: "ETHUSDT", OrderId: "2", Status: OrderStatusFilled})
+	hub.Publish(Order{Symbol: "ETHUSDT", OrderId: "1", Status: OrderStatusFilled, FilledQty: 1})
+	select {
+	case err := <-ch:
+		if err.IsNotNil() {
+			t.Fatal(err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("order should be finished by pushed update")
+	}
+	if ord.Status != OrderStatusFilled || ord.FilledQty != 1 {
+		t.Error("order should be merged with pushed update", ord.Status, ord.FilledQty)
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	if queried != 1 {
+		t.Error("order should be queried once, but", queried)
+	}
+}
+
+func TestWaitOrder_Ctx(t *testing.T) {
+	query := func(ctx context.Context, ord *Order) RequestError {
+		return RequestError{}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := <-WaitOrder(ctx, &Order{OrderId: "1"}, query, WaitOrderOptPollInterval(time.Millisecond))
+	if err.IsNil() {
+		t.Error("waiting should be stopped by ctx")
+	}
+}
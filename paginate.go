@@ -0,0 +1,177 @@
+package cex
+
+import (
+	"context"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++               Cex REST Core: Paginate               +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// NextPage advances cursor of paginated endpoint,
+// ex. current/limit, fromId, startTime.
+// params is the params of the current page, resp is its response.
+// Returns params of the next page, and false if there is no next page.
+type NextPage[ReqDataType, RespDataType any] func(params ReqDataType, resp RespDataType) (ReqDataType, bool)
+
+// Paginator requests pages of paginated endpoint one by one.
+//
+//	p := cex.Paginate(user, config, params, next)
+//	for p.Next() {
+//		page := p.Page()
+//	}
+//	if err := p.Err(); err.IsNotNil() {
+//	}
+type Paginator[ReqDataType, RespDataType any] struct {
+	ctx      context.Context
+	reqMaker ReqMaker
+	config   ReqConfig[ReqDataType, RespDataType]
+	params   ReqDataType
+	next     NextPage[ReqDataType, RespDataType]
+	opts     []CltOpt
+
+	interval time.Duration
+	maxPages int
+
+	pages int
+	page  RespDataType
+	done  bool
+	err   RequestError
+}
+
+// Paginate creates Paginator, params is the params of the first page.
+func Paginate[ReqDataType, RespDataType any](
+	reqMaker ReqMaker,
+	config ReqConfig[ReqDataType, RespDataType],
+	params ReqDataType,
+	next NextPage[ReqDataType, RespDataType],
+	opts ...CltOpt,
+) *Paginator[ReqDataType, RespDataType] {
+	return &Paginator[ReqDataType, RespDataType]{
+		reqMaker: reqMaker,
+		config:   config,
+		params:   params,
+		next:     next,
+		opts:     opts,
+	}
+}
+
+// PaginateCtx is same as Paginate, but ctx is propagated to every page request,
+// and paginating stops when ctx is done.
+func PaginateCtx[ReqDataType, RespDataType any](
+	ctx context.Context,
+	reqMaker ReqMaker,
+	config ReqConfig[ReqDataType, RespDataType],
+	params ReqDataType,
+	next NextPage[ReqDataType, RespDataType],
+	opts ...CltOpt,
+) *Paginator[ReqDataType, RespDataType] {
+	p := Paginate(reqMaker, config, params, next, opts...)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p.ctx = ctx
+	return p
+}
+
+// SetInterval sets sleep duration between pages.
+func (p *Paginator[ReqDataType, RespDataType]) SetInterval(interval time.Duration) *Paginator[ReqDataType, RespDataType] {
+	p.interval = interval
+	return p
+}
+
+// SetMaxPages stops paginating after max pages, 0 means no limit.
+func (p *Paginator[ReqDataType, RespDataType]) SetMaxPages(maxPages int) *Paginator[ReqDataType, RespDataType] {
+	p.maxPages = maxPages
+	return p
+}
+
+// Next requests the next page.
+// Returns false if there is no next page, or request failed.
+// Should check Err after Next returns false.
+func (p *Paginator[ReqDataType, RespDataType]) Next() bool {
+	if p.done || (p.maxPages > 0 && p.pages >= p.maxPages) {
+		return false
+	}
+	if p.pages > 0 && p.interval > 0 {
+		if err := sleepCtx(p.ctx, p.interval); err != nil {
+			p.done = true
+			p.err = RequestError{ReqBaseConfig: p.config.ReqBaseConfig, Err: err}
+			return false
+		}
+	}
+	_, page, err := requestWithRetry(p.ctx, p.reqMaker, p.config, p.params, p.opts...)
+	if err.IsNotNil() {
+		p.done = true
+		p.err = err
+		return false
+	}
+	p.pages++
+	p.page = page
+	var hasNext bool
+	p.params, hasNext = p.next(p.params, page)
+	p.done = !hasNext
+	return true
+}
+
+// Page returns the page requested by the last Next.
+func (p *Paginator[ReqDataType, RespDataType]) Page() RespDataType {
+	return p.page
+}
+
+// Params returns params of the next page.
+func (p *Paginator[ReqDataType, RespDataType]) Params() ReqDataType {
+	return p.params
+}
+
+// Err returns the error that stopped paginating.
+func (p *Paginator[ReqDataType, RespDataType]) Err() RequestError {
+	return p.err
+}
+
+// All requests all remaining pages.
+// Pages requested before error are returned with error.
+func (p *Paginator[ReqDataType, RespDataType]) All() ([]RespDataType, RequestError) {
+	var pages []RespDataType
+	for p.Next() {
+		pages = append(pages, p.Page())
+	}
+	return pages, p.Err()
+}
+
+// PaginateAll requests all remaining pages of p,
+// and flattens items of pages into one slice.
+// items extracts items from page, ex. Page.Rows of binance.
+func PaginateAll[ReqDataType, RespDataType, Item any](
+	p *Paginator[ReqDataType, RespDataType],
+	items func(RespDataType) []Item,
+) ([]Item, RequestError) {
+	var all []Item
+	for p.Next() {
+		all = append(all, items(p.Page())...)
+	}
+	return all, p.Err()
+}
+
+// TimeRange is [Start, End] in milliseconds.
+type TimeRange struct {
+	Start int64 `json:"start" bson:"start"`
+	End   int64 `json:"end" bson:"end"`
+}
+
+// SplitTimeRange splits [start, end] into continuous ranges no longer than window(ms),
+// because many cex endpoints limit time span of one request.
+// Returns nil, if start is after end or window is not positive.
+func SplitTimeRange(start, end, window int64) []TimeRange {
+	if start > end || window <= 0 {
+		return nil
+	}
+	var ranges []TimeRange
+	for s := start; s <= end; s += window {
+		ranges = append(ranges, TimeRange{Start: s, End: min(s+window-1, end)})
+	}
+	return ranges
+}
@@ -0,0 +1,90 @@
+package cex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type testPageReqMaker struct{}
+
+func (testPageReqMaker) Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error) {
+	req := NewHTTPRequest(opts...)
+	req.URL = config.BaseUrl + config.Path + "?current=" + strconv.Itoa(reqData.(int))
+	return req, nil
+}
+
+func TestPaginate(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current, _ := strconv.Atoi(r.URL.Query().Get("current"))
+		if current > 3 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("[" + strconv.Itoa(current*10) + "," + strconv.Itoa(current*10+1) + "]"))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[int, []int]{
+		ReqBaseConfig: ReqBaseConfig{BaseUrl: sv.URL, Path: "/page", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error {
+			if code != http.StatusOK {
+				return ErrHTTPNotFound
+			}
+			return nil
+		},
+		RespBodyUnmarshaler: StdBodyUnmarshaler[[]int],
+		RetryPolicy:         &RetryPolicy{MaxAttempts: 1},
+	}
+	next := func(current int, page []int) (int, bool) {
+		return current + 1, current < 3
+	}
+
+	pages, err := Paginate(testPageReqMaker{}, config, 1, next).All()
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(pages) != 3 || pages[2][0] != 30 {
+		t.Error("should get 3 pages, but", pages)
+	}
+
+	items, err := PaginateAll(Paginate(testPageReqMaker{}, config, 2, next), func(page []int) []int { return page })
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if len(items) != 4 || items[0] != 20 || items[3] != 31 {
+		t.Error("should get 4 flattened items, but", items)
+	}
+
+	pages, err = Paginate(testPageReqMaker{}, config, 1, next).SetMaxPages(2).All()
+	if err.IsNotNil() || len(pages) != 2 {
+		t.Error("should stop after 2 pages, but", len(pages), err.Error())
+	}
+
+	pages, err = Paginate(testPageReqMaker{}, config, 3, func(current int, page []int) (int, bool) {
+		return current + 1, true
+	}).All()
+	if !err.Is(ErrHTTPNotFound) || len(pages) != 1 {
+		t.Error("should stop at error with 1 page, but", len(pages), err.Error())
+	}
+}
+
+func TestSplitTimeRange(t *testing.T) {
+	ranges := SplitTimeRange(0, 25, 10)
+	want := []TimeRange{{0, 9}, {10, 19}, {20, 25}}
+	if len(ranges) != len(want) {
+		t.Fatal("wrong ranges", ranges)
+	}
+	for i := range want {
+		if ranges[i] != want[i] {
+			t.Error("wrong ranges", ranges)
+		}
+	}
+	if ranges := SplitTimeRange(10, 0, 10); ranges != nil {
+		t.Error("start after end should return nil, but", ranges)
+	}
+	if ranges := SplitTimeRange(0, 10, 0); ranges != nil {
+		t.Error("zero window should return nil, but", ranges)
+	}
+}
@@ -0,0 +1,76 @@
+package cex
+
+import (
+	"fmt"
+)
+
+// ===========================================================
+// Params Validation
+// -----------------------------------------------------------
+
+// ParamsValidator is implemented by request params,
+// which can check required fields, mutually exclusive fields and ranges.
+// Validate should return error wrapping ErrInvalidParams.
+type ParamsValidator interface {
+	Validate() error
+}
+
+// ValidateParams validates reqData, if it implements ParamsValidator.
+// ReqMakers should call it in Make before signing,
+// so malformed requests are not sent to cex.
+func ValidateParams(reqData any) error {
+	v, ok := reqData.(ParamsValidator)
+	if !ok {
+		return nil
+	}
+	if err := v.Validate(); err != nil {
+		return fmt.Errorf("cex: validate params %T, %w", reqData, err)
+	}
+	return nil
+}
+
+// RequireParam returns error, if param is not set.
+func RequireParam(name string, set bool) error {
+	if !set {
+		return fmt.Errorf("%w, %v is required", ErrInvalidParams, name)
+	}
+	return nil
+}
+
+// RequireOneOfParams returns error, if neither a nor b is set.
+func RequireOneOfParams(a string, aSet bool, b string, bSet bool) error {
+	if !aSet && !bSet {
+		return fmt.Errorf("%w, %v or %v is required", ErrInvalidParams, a, b)
+	}
+	return nil
+}
+
+// ExclusiveParams returns error, if a and b are both set.
+func ExclusiveParams(a string, aSet bool, b string, bSet bool) error {
+	if aSet && bSet {
+		return fmt.Errorf("%w, %v and %v can not be sent together", ErrInvalidParams, a, b)
+	}
+	return nil
+}
+
+// ParamInRange returns error, if v is out of [min, max].
+func ParamInRange[T int | int64 | float64](name string, v, min, max T) error {
+	if v < min || v > max {
+		return fmt.Errorf("%w, %v %v is out of [%v, %v]", ErrInvalidParams, name, v, min, max)
+	}
+	return nil
+}
+
+// FirstParamsErr returns the first not nil error of errs.
+func FirstParamsErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------
+// Params Validation
+// ===========================================================
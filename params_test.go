@@ -0,0 +1,44 @@
+package cex
+
+import (
+	"errors"
+	"testing"
+)
+
+type testParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+	Limit  int    `s2m:"limit,omitempty"`
+}
+
+func (p testParams) Validate() error {
+	return FirstParamsErr(
+		RequireParam("symbol", p.Symbol != ""),
+		ParamInRange("limit", p.Limit, 0, 100),
+	)
+}
+
+func TestValidateParams(t *testing.T) {
+	if err := ValidateParams(testParams{Symbol: "ETHUSDT", Limit: 10}); err != nil {
+		t.Error("valid params should pass", err)
+	}
+	if err := ValidateParams(testParams{Limit: 10}); !errors.Is(err, ErrInvalidParams) {
+		t.Error("missing symbol should fail", err)
+	}
+	if err := ValidateParams(testParams{Symbol: "ETHUSDT", Limit: 101}); !errors.Is(err, ErrInvalidParams) {
+		t.Error("limit out of range should fail", err)
+	}
+	if err := ValidateParams(nil); err != nil {
+		t.Error("params without validator should pass", err)
+	}
+	if err := ExclusiveParams("price", true, "priceMatch", true); !errors.Is(err, ErrInvalidParams) {
+		t.Error("exclusive params should fail", err)
+	}
+	if err := RequireOneOfParams("orderId", false, "origClientOrderId", true); err != nil {
+		t.Error("one of params is set", err)
+	}
+
+	m := NewPublicReqMaker()
+	if _, err := m.Make(ReqBaseConfig{BaseUrl: "https://api.binance.com", Path: "/api/v3/klines"}, testParams{Limit: 1}); !errors.Is(err, ErrInvalidParams) {
+		t.Error("public req maker should validate params", err)
+	}
+}
@@ -0,0 +1,373 @@
+package cex
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++                   Cex PnL Tracker                   +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// CostBasisMethod decides which cost is matched when position is reduced.
+type CostBasisMethod string
+
+const (
+	// CostBasisFIFO matches the earliest opened lots first.
+	CostBasisFIFO CostBasisMethod = "FIFO"
+	// CostBasisWeightedAverage matches weighted average cost of all open qty.
+	CostBasisWeightedAverage CostBasisMethod = "WEIGHTED_AVERAGE"
+)
+
+// pnlQtyEpsilon is qty treated as zero, to avoid dust lots of float errors.
+const pnlQtyEpsilon = 1e-12
+
+// PnlKey identifies position of PnlTracker.
+type PnlKey struct {
+	Cex      Name     `json:"cex" bson:"cex"`
+	PairType PairType `json:"pairType" bson:"pairType"`
+	Symbol   string   `json:"symbol" bson:"symbol"`
+}
+
+// SymbolPnl is PnL of one position.
+// Qty is signed, negative qty is short position.
+// PnL is in quote asset, and commissions are not deducted,
+// because commission asset may be different from quote asset.
+type SymbolPnl struct {
+	PnlKey
+	Qty           float64            `json:"qty" bson:"qty"`
+	AvgCost       float64            `json:"avgCost" bson:"avgCost"`
+	MarkPrice     float64            `json:"markPrice" bson:"markPrice"`
+	RealizedPnl   float64            `json:"realizedPnl" bson:"realizedPnl"`
+	UnrealizedPnl float64            `json:"unrealizedPnl" bson:"unrealizedPnl"`
+	Commissions   map[string]float64 `json:"commissions" bson:"commissions"`
+	FillCount     int                `json:"fillCount" bson:"fillCount"`
+}
+
+// PnlSnapshot is PnL of all positions at Time, unit is millisecond.
+type PnlSnapshot struct {
+	Time          int64       `json:"time" bson:"time"`
+	Method        string      `json:"method" bson:"method"`
+	RealizedPnl   float64     `json:"realizedPnl" bson:"realizedPnl"`
+	UnrealizedPnl float64     `json:"unrealizedPnl" bson:"unrealizedPnl"`
+	Symbols       []SymbolPnl `json:"symbols" bson:"symbols"`
+}
+
+type PnlTrackerOpt func(*PnlTracker)
+
+// PnlTrackerOptMethod sets cost basis method, default CostBasisFIFO.
+func PnlTrackerOptMethod(method CostBasisMethod) PnlTrackerOpt {
+	return func(t *PnlTracker) {
+		t.method = method
+	}
+}
+
+// PnlTrackerOptSnapshotInterval sets interval of Run, default time.Minute.
+func PnlTrackerOptSnapshotInterval(interval time.Duration) PnlTrackerOpt {
+	return func(t *PnlTracker) {
+		t.interval = interval
+	}
+}
+
+// PnlTrackerOptSnapshotHandler sets handler of snapshots taken by Run.
+func PnlTrackerOptSnapshotHandler(handler func(PnlSnapshot)) PnlTrackerOpt {
+	return func(t *PnlTracker) {
+		t.onSnapshot = handler
+	}
+}
+
+// PnlTrackerOptMaxSnapshots sets max number of snapshots kept by Run, default 1440.
+func PnlTrackerOptMaxSnapshots(n int) PnlTrackerOpt {
+	return func(t *PnlTracker) {
+		t.maxSnapshots = n
+	}
+}
+
+type pnlLot struct {
+	qty   float64
+	price float64
+}
+
+type pnlPosition struct {
+	key PnlKey
+	// fills are sorted by time and trade id
+	fills    []Fill
+	tradeIds map[string]bool
+	// lots are open lots, all lots have the same sign,
+	// and there is at most one lot in weighted average method
+	lots        []pnlLot
+	realized    float64
+	markPrice   float64
+	commissions map[string]float64
+}
+
+// PnlTracker computes realized and unrealized PnL of positions by fills,
+// which are ingested from REST, ex. by Backfill, and from user data stream.
+// Fills with duplicate trade id are ignored, so REST and stream fills can overlap.
+// Position is recomputed from all its fills, if a fill is older than the latest one,
+// so fills can be ingested in any order.
+// Spot fills are long only normally, futures fills can open short positions,
+// and hedge mode positions of the same symbol are netted.
+//
+//	t := NewPnlTracker(PnlTrackerOptMethod(CostBasisWeightedAverage))
+//	err := t.Backfill(user, PairTypeSpot, "ETHUSDT", since, 0)
+//	t.Ingest(fill)
+//	t.SetMarkPrice(key, price)
+//	snapshot := t.Snapshot()
+type PnlTracker struct {
+	method       CostBasisMethod
+	interval     time.Duration
+	onSnapshot   func(PnlSnapshot)
+	maxSnapshots int
+
+	mux       sync.RWMutex
+	positions map[PnlKey]*pnlPosition
+	snapshots []PnlSnapshot
+}
+
+func NewPnlTracker(opts ...PnlTrackerOpt) *PnlTracker {
+	t := &PnlTracker{
+		method:       CostBasisFIFO,
+		interval:     time.Minute,
+		maxSnapshots: 1440,
+		positions:    map[PnlKey]*pnlPosition{},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *PnlTracker) Method() CostBasisMethod {
+	return t.method
+}
+
+func fillPnlKey(f Fill) PnlKey {
+	return PnlKey{Cex: f.Cex, PairType: f.PairType, Symbol: f.Symbol}
+}
+
+// Ingest adds fills, and returns number of fills which are not ingested before.
+// Fills without side or qty are ignored.
+func (t *PnlTracker) Ingest(fills ...Fill) int {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	n := 0
+	for _, f := range fills {
+		if f.Qty <= 0 || (f.OrderSide != OrderSideBuy && f.OrderSide != OrderSideSell) {
+			continue
+		}
+		key := fillPnlKey(f)
+		p, ok := t.positions[key]
+		if !ok {
+			p = &pnlPosition{key: key, tradeIds: map[string]bool{}, commissions: map[string]float64{}}
+			t.positions[key] = p
+		}
+		if f.TradeId != "" {
+			if p.tradeIds[f.TradeId] {
+				continue
+			}
+			p.tradeIds[f.TradeId] = true
+		}
+		n++
+		if len(p.fills) > 0 && fillBefore(f, p.fills[len(p.fills)-1]) {
+			p.fills = append(p.fills, f)
+			sort.SliceStable(p.fills, func(i, j int) bool { return fillBefore(p.fills[i], p.fills[j]) })
+			t.recompute(p)
+			continue
+		}
+		p.fills = append(p.fills, f)
+		t.apply(p, f)
+	}
+	return n
+}
+
+func fillBefore(a, b Fill) bool {
+	if a.Time != b.Time {
+		return a.Time < b.Time
+	}
+	return tradeIdLess(a.TradeId, b.TradeId)
+}
+
+// tradeIdLess compares ids numerically if both are integers, so "9" is before "10".
+func tradeIdLess(a, b string) bool {
+	if isDigits(a) && isDigits(b) && len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *PnlTracker) recompute(p *pnlPosition) {
+	p.lots, p.realized, p.commissions = nil, 0, map[string]float64{}
+	for _, f := range p.fills {
+		t.apply(p, f)
+	}
+}
+
+// apply matches fill with open lots of the opposite side, and opens a lot by remaining qty.
+func (t *PnlTracker) apply(p *pnlPosition, f Fill) {
+	if f.CommissionAsset != "" {
+		p.commissions[f.CommissionAsset] += f.Commission
+	}
+	qty := f.Qty
+	if f.OrderSide == OrderSideSell {
+		qty = -qty
+	}
+	for len(p.lots) > 0 && math.Abs(qty) > pnlQtyEpsilon && p.lots[0].qty*qty < 0 {
+		lot := &p.lots[0]
+		matched := math.Min(math.Abs(qty), math.Abs(lot.qty))
+		if lot.qty > 0 {
+			// long lot is closed by selling
+			p.realized += (f.Price - lot.price) * matched
+			lot.qty -= matched
+			qty += matched
+		} else {
+			p.realized += (lot.price - f.Price) * matched
+			lot.qty += matched
+			qty -= matched
+		}
+		if math.Abs(lot.qty) <= pnlQtyEpsilon {
+			p.lots = p.lots[1:]
+		}
+	}
+	if math.Abs(qty) <= pnlQtyEpsilon {
+		return
+	}
+	if t.method == CostBasisWeightedAverage && len(p.lots) > 0 {
+		lot := &p.lots[0]
+		total := lot.qty + qty
+		lot.price = (lot.price*lot.qty + f.Price*qty) / total
+		lot.qty = total
+		return
+	}
+	p.lots = append(p.lots, pnlLot{qty: qty, price: f.Price})
+}
+
+// SetMarkPrice sets price of unrealized PnL of position.
+func (t *PnlTracker) SetMarkPrice(key PnlKey, price float64) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if p, ok := t.positions[key]; ok {
+		p.markPrice = price
+	}
+}
+
+func (p *pnlPosition) pnl() SymbolPnl {
+	s := SymbolPnl{
+		PnlKey:      p.key,
+		MarkPrice:   p.markPrice,
+		RealizedPnl: p.realized,
+		Commissions: make(map[string]float64, len(p.commissions)),
+		FillCount:   len(p.fills),
+	}
+	for asset, c := range p.commissions {
+		s.Commissions[asset] = c
+	}
+	var cost float64
+	for _, lot := range p.lots {
+		s.Qty += lot.qty
+		cost += lot.qty * lot.price
+	}
+	if s.Qty != 0 {
+		s.AvgCost = cost / s.Qty
+		if p.markPrice != 0 {
+			s.UnrealizedPnl = p.markPrice*s.Qty - cost
+		}
+	}
+	return s
+}
+
+// Pnl returns PnL of position, ok is false if position has no fills.
+func (t *PnlTracker) Pnl(key PnlKey) (pnl SymbolPnl, ok bool) {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	p, ok := t.positions[key]
+	if !ok {
+		return
+	}
+	return p.pnl(), true
+}
+
+// Snapshot returns PnL of all positions sorted by key.
+func (t *PnlTracker) Snapshot() PnlSnapshot {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	snapshot := PnlSnapshot{Time: time.Now().UnixMilli(), Method: string(t.method)}
+	for _, p := range t.positions {
+		s := p.pnl()
+		snapshot.RealizedPnl += s.RealizedPnl
+		snapshot.UnrealizedPnl += s.UnrealizedPnl
+		snapshot.Symbols = append(snapshot.Symbols, s)
+	}
+	sort.Slice(snapshot.Symbols, func(i, j int) bool {
+		a, b := snapshot.Symbols[i].PnlKey, snapshot.Symbols[j].PnlKey
+		if a.Cex != b.Cex {
+			return a.Cex < b.Cex
+		}
+		if a.PairType != b.PairType {
+			return a.PairType < b.PairType
+		}
+		return a.Symbol < b.Symbol
+	})
+	return snapshot
+}
+
+// Snapshots returns snapshots taken by Run, the oldest first.
+func (t *PnlTracker) Snapshots() []PnlSnapshot {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	return append([]PnlSnapshot(nil), t.snapshots...)
+}
+
+func (t *PnlTracker) takeSnapshot() {
+	snapshot := t.Snapshot()
+	t.mux.Lock()
+	t.snapshots = append(t.snapshots, snapshot)
+	if over := len(t.snapshots) - t.maxSnapshots; over > 0 {
+		t.snapshots = t.snapshots[over:]
+	}
+	t.mux.Unlock()
+	if t.onSnapshot != nil {
+		t.onSnapshot(snapshot)
+	}
+}
+
+// Run takes snapshot every interval until ctx is done.
+func (t *PnlTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.takeSnapshot()
+		}
+	}
+}
+
+// Backfill ingests fills queried by querier, ex. REST myTrades of binance.
+func (t *PnlTracker) Backfill(querier FillQuerier, pairType PairType, symbol string, startTime, endTime int64, opts ...CltOpt) RequestError {
+	fills, err := querier.QueryFills(pairType, symbol, startTime, endTime, opts...)
+	if err.IsNotNil() {
+		return err
+	}
+	t.Ingest(fills...)
+	return RequestError{}
+}
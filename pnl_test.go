@@ -0,0 +1,102 @@
+package cex
+
+import (
+	"math"
+	"testing"
+)
+
+func pnlFill(id string, t int64, side OrderSide, qty, price float64) Fill {
+	return Fill{TradeId: id, Time: t, OrderSide: side, Qty: qty, Price: price, Cex: BINANCE, PairType: PairTypeSpot, Symbol: "ETHUSDT", Commission: 0.1, CommissionAsset: "BNB"}
+}
+
+func pnlNear(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPnlTracker(t *testing.T) {
+	key := PnlKey{Cex: BINANCE, PairType: PairTypeSpot, Symbol: "ETHUSDT"}
+	fills := []Fill{
+		pnlFill("1", 1, OrderSideBuy, 1, 100),
+		pnlFill("2", 2, OrderSideBuy, 1, 200),
+		pnlFill("3", 3, OrderSideSell, 1.5, 300),
+	}
+	cases := []struct {
+		method     CostBasisMethod
+		realized   float64
+		avgCost    float64
+		unrealized float64
+	}{
+		// sells 1@100 and 0.5@200
+		{CostBasisFIFO, 200 + 50, 200, 50 * 0.5},
+		// average cost is 150
+		{CostBasisWeightedAverage, 150 * 1.5, 150, 100 * 0.5},
+	}
+	for _, c := range cases {
+		tracker := NewPnlTracker(PnlTrackerOptMethod(c.method))
+		if n := tracker.Ingest(fills...); n != 3 {
+			t.Fatal("wrong ingested number", n)
+		}
+		tracker.SetMarkPrice(key, 250)
+		pnl, ok := tracker.Pnl(key)
+		if !ok || !pnlNear(pnl.Qty, 0.5) || !pnlNear(pnl.RealizedPnl, c.realized) || !pnlNear(pnl.AvgCost, c.avgCost) || !pnlNear(pnl.UnrealizedPnl, c.unrealized) {
+			t.Error("wrong pnl", c.method, pnl)
+		}
+		if !pnlNear(pnl.Commissions["BNB"], 0.3) {
+			t.Error("wrong commissions", pnl.Commissions)
+		}
+	}
+
+	// out of order and duplicate fills
+	tracker := NewPnlTracker()
+	tracker.Ingest(fills[2], fills[0])
+	if n := tracker.Ingest(fills...); n != 1 {
+		t.Error("duplicate fills should be ignored", n)
+	}
+	pnl, _ := tracker.Pnl(key)
+	if !pnlNear(pnl.RealizedPnl, 250) || !pnlNear(pnl.Qty, 0.5) || pnl.FillCount != 3 {
+		t.Error("out of order fills should be recomputed", pnl)
+	}
+
+	// short position
+	short := NewPnlTracker()
+	short.Ingest(pnlFill("1", 1, OrderSideSell, 2, 300), pnlFill("2", 2, OrderSideBuy, 3, 200))
+	pnl, _ = short.Pnl(key)
+	if !pnlNear(pnl.RealizedPnl, 200) || !pnlNear(pnl.Qty, 1) || !pnlNear(pnl.AvgCost, 200) {
+		t.Error("wrong short pnl", pnl)
+	}
+
+	// fills at the same time are ordered by numeric trade id
+	sameTime := NewPnlTracker()
+	sameTime.Ingest(pnlFill("9", 1, OrderSideBuy, 1, 100), pnlFill("10", 1, OrderSideBuy, 1, 200), pnlFill("11", 2, OrderSideSell, 1, 300))
+	pnl, _ = sameTime.Pnl(key)
+	if !pnlNear(pnl.RealizedPnl, 200) || !pnlNear(pnl.AvgCost, 200) {
+		t.Error("fill 9 should be before fill 10", pnl)
+	}
+
+	snapshot := tracker.Snapshot()
+	if len(snapshot.Symbols) != 1 || !pnlNear(snapshot.RealizedPnl, 250) || snapshot.Method != string(CostBasisFIFO) {
+		t.Error("wrong snapshot", snapshot)
+	}
+}
+
+type testFillQuerier []Fill
+
+func (q testFillQuerier) QueryFills(pairType PairType, symbol string, startTime, endTime int64, opts ...CltOpt) ([]Fill, RequestError) {
+	return q, RequestError{}
+}
+
+func TestPnlTracker_Backfill(t *testing.T) {
+	var snapshots []PnlSnapshot
+	tracker := NewPnlTracker(PnlTrackerOptMaxSnapshots(1), PnlTrackerOptSnapshotHandler(func(s PnlSnapshot) {
+		snapshots = append(snapshots, s)
+	}))
+	querier := testFillQuerier{pnlFill("1", 1, OrderSideBuy, 1, 100), pnlFill("2", 2, OrderSideSell, 1, 150)}
+	if err := tracker.Backfill(querier, PairTypeSpot, "ETHUSDT", 0, 0); err.IsNotNil() {
+		t.Fatal(err.Err)
+	}
+	tracker.takeSnapshot()
+	tracker.takeSnapshot()
+	if len(snapshots) != 2 || len(tracker.Snapshots()) != 1 || !pnlNear(tracker.Snapshots()[0].RealizedPnl, 50) {
+		t.Error("wrong snapshots", snapshots, tracker.Snapshots())
+	}
+}
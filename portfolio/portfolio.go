@@ -0,0 +1,128 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/cex"
+)
+
+// ErrBalanceQuerierNotImplemented is set to Account.Err,
+// if user does not implement cex.BalanceQuerier.
+var ErrBalanceQuerierNotImplemented = errors.New("portfolio: user does not implement cex.BalanceQuerier")
+
+// Account is balances of one cex user.
+type Account struct {
+	Cex      cex.Name      `json:"cex" bson:"cex"`
+	ApiKey   string        `json:"apiKey" bson:"apiKey"`
+	Balances []cex.Balance `json:"balances" bson:"balances"`
+	// Err is error of querying balances.
+	// Balances may be partial if Err is not nil.
+	Err error `json:"-" bson:"-"`
+}
+
+// Asset is consolidated balance of one asset across all accounts.
+type Asset struct {
+	Asset         string  `json:"asset" bson:"asset"`
+	Total         float64 `json:"total" bson:"total"`
+	Free          float64 `json:"free" bson:"free"`
+	UnrealizedPnl float64 `json:"unrealizedPnl" bson:"unrealizedPnl"`
+
+	// ByCex is total by cex.
+	ByCex map[cex.Name]float64 `json:"byCex" bson:"byCex"`
+	// ByWallet is total by wallet type.
+	ByWallet map[cex.WalletType]float64 `json:"byWallet" bson:"byWallet"`
+}
+
+func (a Asset) Equity() float64 {
+	return a.Total + a.UnrealizedPnl
+}
+
+// Portfolio is consolidated balances of multiple cex users.
+type Portfolio struct {
+	// Accounts are in the same order as users.
+	Accounts []Account `json:"accounts" bson:"accounts"`
+	// Assets are sorted by asset name.
+	Assets []Asset `json:"assets" bson:"assets"`
+	// Time is fetched time, unit is millisecond.
+	Time int64 `json:"time" bson:"time"`
+}
+
+// Asset returns consolidated balance of asset.
+func (p Portfolio) Asset(asset string) (Asset, bool) {
+	i := sort.Search(len(p.Assets), func(i int) bool { return p.Assets[i].Asset >= asset })
+	if i < len(p.Assets) && p.Assets[i].Asset == asset {
+		return p.Assets[i], true
+	}
+	return Asset{}, false
+}
+
+// Err joins errors of all accounts, returns nil if all accounts are succeeded.
+func (p Portfolio) Err() error {
+	var errs []error
+	for _, a := range p.Accounts {
+		if a.Err != nil {
+			errs = append(errs, fmt.Errorf("%v %v, %w", a.Cex, a.ApiKey, a.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Fetch concurrently queries balances of users, which should implement cex.BalanceQuerier,
+// and consolidates them into portfolio.
+// Failed accounts are kept with Err, and their partial balances are consolidated.
+//
+//	p := portfolio.Fetch([]cex.User{bncUser, okxUser, bybitUser})
+//	usdt, _ := p.Asset("USDT")
+func Fetch(users []cex.User, opts ...cex.CltOpt) Portfolio {
+	accounts := make([]Account, len(users))
+	var wg sync.WaitGroup
+	for i, user := range users {
+		api := user.Api()
+		accounts[i] = Account{Cex: api.Cex, ApiKey: api.ApiKey}
+		querier, ok := user.(cex.BalanceQuerier)
+		if !ok {
+			accounts[i].Err = ErrBalanceQuerierNotImplemented
+			continue
+		}
+		wg.Add(1)
+		go func(acct *Account) {
+			defer wg.Done()
+			balances, err := querier.QueryBalances(opts...)
+			acct.Balances = balances
+			if err.IsNotNil() {
+				acct.Err = &err
+			}
+		}(&accounts[i])
+	}
+	wg.Wait()
+	return Consolidate(accounts)
+}
+
+// Consolidate consolidates balances of accounts by asset.
+func Consolidate(accounts []Account) Portfolio {
+	byAsset := map[string]*Asset{}
+	for _, acct := range accounts {
+		for _, b := range acct.Balances {
+			a := byAsset[b.Asset]
+			if a == nil {
+				a = &Asset{Asset: b.Asset, ByCex: map[cex.Name]float64{}, ByWallet: map[cex.WalletType]float64{}}
+				byAsset[b.Asset] = a
+			}
+			a.Total += b.Total
+			a.Free += b.Free
+			a.UnrealizedPnl += b.UnrealizedPnl
+			a.ByCex[acct.Cex] += b.Total
+			a.ByWallet[b.Wallet] += b.Total
+		}
+	}
+	assets := make([]Asset, 0, len(byAsset))
+	for _, a := range byAsset {
+		assets = append(assets, *a)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Asset < assets[j].Asset })
+	return Portfolio{Accounts: accounts, Assets: assets, Time: time.Now().UnixMilli()}
+}
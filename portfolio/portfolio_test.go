@@ -0,0 +1,73 @@
+package portfolio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dwdwow/cex"
+)
+
+type testUser struct {
+	api      cex.Api
+	balances []cex.Balance
+	err      error
+}
+
+func (u testUser) Api() cex.Api {
+	return u.api
+}
+
+func (u testUser) Make(config cex.ReqBaseConfig, reqData any, opts ...cex.CltOpt) (*cex.HTTPRequest, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (u testUser) QueryBalances(opts ...cex.CltOpt) ([]cex.Balance, cex.RequestError) {
+	return u.balances, cex.RequestError{Err: u.err}
+}
+
+type testUserWithoutBalances struct {
+	testUser
+}
+
+func (u testUserWithoutBalances) QueryBalances() {}
+
+func TestFetch(t *testing.T) {
+	errFailed := errors.New("failed")
+	users := []cex.User{
+		testUser{api: cex.Api{Cex: cex.BINANCE, ApiKey: "a"}, balances: []cex.Balance{
+			{Cex: cex.BINANCE, Wallet: cex.WalletTypeSpot, Asset: "USDT", Total: 100, Free: 80},
+			{Cex: cex.BINANCE, Wallet: cex.WalletTypeFutures, Asset: "USDT", Total: 50, Free: 20, UnrealizedPnl: -5},
+			{Cex: cex.BINANCE, Wallet: cex.WalletTypeEarn, Asset: "BTC", Total: 1},
+		}},
+		testUser{api: cex.Api{Cex: cex.OKX, ApiKey: "b"}, err: errFailed, balances: []cex.Balance{
+			{Cex: cex.OKX, Wallet: cex.WalletTypeUnified, Asset: "USDT", Total: 10, Free: 10},
+		}},
+		testUserWithoutBalances{testUser{api: cex.Api{Cex: cex.BYBIT, ApiKey: "c"}}},
+	}
+	p := Fetch(users)
+	if len(p.Accounts) != 3 || p.Accounts[0].Cex != cex.BINANCE || p.Accounts[2].Cex != cex.BYBIT {
+		t.Fatal("accounts should be in the same order as users", p.Accounts)
+	}
+	if !errors.Is(p.Accounts[1].Err, errFailed) || !errors.Is(p.Accounts[2].Err, ErrBalanceQuerierNotImplemented) {
+		t.Error("wrong account errors", p.Accounts[1].Err, p.Accounts[2].Err)
+	}
+	if err := p.Err(); !errors.Is(err, errFailed) || !errors.Is(err, ErrBalanceQuerierNotImplemented) {
+		t.Error("portfolio error should join account errors", err)
+	}
+	if len(p.Assets) != 2 || p.Assets[0].Asset != "BTC" {
+		t.Fatal("assets should be sorted", p.Assets)
+	}
+	usdt, ok := p.Asset("USDT")
+	if !ok {
+		t.Fatal("no usdt")
+	}
+	if usdt.Total != 160 || usdt.Free != 110 || usdt.Equity() != 155 {
+		t.Error("wrong usdt", usdt)
+	}
+	if usdt.ByCex[cex.BINANCE] != 150 || usdt.ByCex[cex.OKX] != 10 || usdt.ByWallet[cex.WalletTypeFutures] != 50 {
+		t.Error("wrong usdt breakdowns", usdt.ByCex, usdt.ByWallet)
+	}
+	if _, ok := p.Asset("ETH"); ok {
+		t.Error("eth should not exist")
+	}
+}
@@ -0,0 +1,164 @@
+package cex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/dwdwow/s2m"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++           Cex REST Core: Public Req Maker           +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ErrApiKeyRequired is returned by PublicReqMaker, if ReqConfig is user data.
+var ErrApiKeyRequired = errors.New("api key is required")
+
+type PublicReqMakerOpt func(*PublicReqMaker)
+
+// PublicReqMakerOptBaseUrlRewriter sets base url rewriter, ex. rewriting to testnet.
+func PublicReqMakerOptBaseUrlRewriter(rewrite func(baseUrl string) (string, error)) PublicReqMakerOpt {
+	return func(m *PublicReqMaker) {
+		m.rewrite = rewrite
+	}
+}
+
+// PublicReqMakerOptBaseUrlSelector sends requests to base urls selected by selector,
+// ex. BaseUrlFailover.
+func PublicReqMakerOptBaseUrlSelector(selector BaseUrlSelector) PublicReqMakerOpt {
+	return func(m *PublicReqMaker) {
+		m.selector = selector
+	}
+}
+
+// PublicReqMakerOptLogger logs requests made by PublicReqMaker with logger.
+func PublicReqMakerOptLogger(logger Logger) PublicReqMakerOpt {
+	return func(m *PublicReqMaker) {
+		m.logger = logger
+	}
+}
+
+// PublicReqMakerOptTransport sends requests made by PublicReqMaker by transport,
+// ex. cex.NewTransport(cex.TransportOptProxy(proxy)).
+// It can be overridden by CltOptTransport per call.
+func PublicReqMakerOptTransport(transport Transport) PublicReqMakerOpt {
+	return func(m *PublicReqMaker) {
+		m.transport = transport
+	}
+}
+
+// PublicReqMakerOptBackend sends requests made by PublicReqMaker by backend.
+// It can be overridden by CltOptBackend per call.
+func PublicReqMakerOptBackend(backend Backend) PublicReqMakerOpt {
+	return func(m *PublicReqMaker) {
+		m.backend = backend
+	}
+}
+
+// PublicReqMaker makes requests of public endpoints without api keys.
+// reqData is encoded into url query by s2m tags,
+// which fits public endpoints of most cex.
+// Cex packages can wrap it into anonymous clients, ex. bnc.PublicClient.
+type PublicReqMaker struct {
+	rewrite  func(baseUrl string) (string, error)
+	selector BaseUrlSelector
+	logger   Logger
+	// transport sends requests, shared transport is used if it is nil
+	transport Transport
+	// backend sends requests, DefaultBackend is used if it is empty
+	backend Backend
+
+	// ctx is set to every request, if it is not nil.
+	ctx context.Context
+}
+
+func NewPublicReqMaker(opts ...PublicReqMakerOpt) *PublicReqMaker {
+	m := &PublicReqMaker{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// WithContext returns a shallow copy of m bound to ctx.
+func (m *PublicReqMaker) WithContext(ctx context.Context) *PublicReqMaker {
+	nm := *m
+	nm.ctx = ctx
+	return &nm
+}
+
+// CltOpts implements CltOptsProvider.
+func (m *PublicReqMaker) CltOpts() []CltOpt {
+	var opts []CltOpt
+	if m.transport != nil {
+		opts = append(opts, CltOptTransport(m.transport))
+	}
+	if m.backend != "" {
+		opts = append(opts, CltOptBackend(m.backend))
+	}
+	return opts
+}
+
+func (m *PublicReqMaker) Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error) {
+	if config.IsUserData {
+		return nil, fmt.Errorf("cex: public req maker, %v%v, %w", config.BaseUrl, config.Path, ErrApiKeyRequired)
+	}
+	if err := ValidateParams(reqData); err != nil {
+		return nil, err
+	}
+	strMap, err := s2m.ToStrMap(reqData)
+	if err != nil {
+		return nil, fmt.Errorf("cex: public req maker, %w", err)
+	}
+	// per call options override them, because they are applied later
+	opts = append(m.CltOpts(), opts...)
+	ro := NewReqOpts(opts...)
+	val := url.Values{}
+	for k, v := range strMap {
+		val.Set(k, v)
+	}
+	for k, vs := range ro.Query {
+		val[k] = vs
+	}
+	req := NewHTTPRequest(opts...)
+	req.URL = config.BaseUrl + config.Path
+	if len(val) > 0 {
+		req.URL += "?" + val.Encode()
+	}
+	if m.ctx != nil {
+		req.SetContext(m.ctx)
+	}
+	return req, nil
+}
+
+// RewriteBaseUrl implements BaseUrlRewriter.
+func (m *PublicReqMaker) RewriteBaseUrl(baseUrl string) (string, error) {
+	if m.rewrite == nil {
+		return baseUrl, nil
+	}
+	return m.rewrite(baseUrl)
+}
+
+// SelectBaseUrl implements BaseUrlSelector.
+func (m *PublicReqMaker) SelectBaseUrl(baseUrl string) string {
+	if m.selector == nil {
+		return baseUrl
+	}
+	return m.selector.SelectBaseUrl(baseUrl)
+}
+
+// ReportBaseUrl implements BaseUrlSelector.
+func (m *PublicReqMaker) ReportBaseUrl(selected string, failed bool) {
+	if m.selector != nil {
+		m.selector.ReportBaseUrl(selected, failed)
+	}
+}
+
+// Logger implements LoggerProvider.
+func (m *PublicReqMaker) Logger() Logger {
+	return m.logger
+}
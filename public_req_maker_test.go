@@ -0,0 +1,42 @@
+package cex
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testPublicParams struct {
+	Symbol string `s2m:"symbol,omitempty"`
+	Limit  int    `s2m:"limit,omitempty"`
+}
+
+func TestPublicReqMaker(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"symbol":"` + r.URL.Query().Get("symbol") + `"}`))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[testPublicParams, map[string]string]{
+		ReqBaseConfig:         ReqBaseConfig{BaseUrl: "https://example.com", Path: "/depth", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]string],
+	}
+	maker := NewPublicReqMaker(PublicReqMakerOptBaseUrlRewriter(func(baseUrl string) (string, error) {
+		return sv.URL, nil
+	}))
+	_, data, err := Request(maker, config, testPublicParams{Symbol: "ETHUSDT"})
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if data["symbol"] != "ETHUSDT" {
+		t.Error("params should be encoded into query", data)
+	}
+
+	config.IsUserData = true
+	_, _, err = Request(maker, config, testPublicParams{})
+	if !errors.Is(err.Err, ErrApiKeyRequired) {
+		t.Error("user data config should be rejected, but", err.Error())
+	}
+}
@@ -0,0 +1,198 @@
+package cex
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++               Cex REST Core: Rate Limit             +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ===========================================================
+// Rate Limit Error
+// -----------------------------------------------------------
+
+type RateLimitType string
+
+const (
+	RateLimitTypeRequestWeight RateLimitType = "REQUEST_WEIGHT"
+	RateLimitTypeOrders        RateLimitType = "ORDERS"
+	RateLimitTypeRawRequests   RateLimitType = "RAW_REQUESTS"
+	RateLimitTypeIpBanned      RateLimitType = "IP_BANNED"
+)
+
+// RateLimitScope indicates who is limited, ip or account(api key).
+type RateLimitScope string
+
+const (
+	RateLimitScopeIp      RateLimitScope = "IP"
+	RateLimitScopeAccount RateLimitScope = "ACCOUNT"
+)
+
+// RateLimitError contains structured info about a broken rate limit.
+// Callers can get it from RequestError.RateLimitError,
+// and wait until ResetsAt instead of parsing cex messages.
+type RateLimitError struct {
+	LimitType RateLimitType  `json:"limitType"`
+	Scope     RateLimitScope `json:"scope"`
+
+	// http status code, ex. 429, 418
+	StatusCode int `json:"statusCode"`
+
+	// cex custom error code, ex. binance -1003
+	CexErrCode int    `json:"cexErrCode"`
+	CexErrMsg  string `json:"cexErrMsg"`
+
+	// RetryAfter is parsed from http header Retry-After.
+	// Zero if cex does not respond it.
+	RetryAfter time.Duration `json:"retryAfter"`
+
+	// ResetsAt is the time when requests can be sent again.
+	// If cex responds ban time, ResetsAt is the ban time.
+	// Otherwise, ResetsAt is response time + RetryAfter.
+	ResetsAt time.Time `json:"resetsAt"`
+
+	// UsedWeight is the used weight or order count reported by cex,
+	// key is header interval tag, ex. 1M, 10S, 1D.
+	UsedWeight map[string]int64 `json:"usedWeight"`
+
+	// Err is the std error, ex. ErrHTTPTooFrequency, ErrHTTPIpBanned.
+	Err error `json:"err"`
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf(
+		"rate limit: type: %v, scope: %v, status code: %v, cex code: %v, resets at: %v, err: %v",
+		e.LimitType, e.Scope, e.StatusCode, e.CexErrCode, e.ResetsAt.Format(time.RFC3339Nano), e.Err,
+	)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// Wait returns the duration from now until ResetsAt.
+// Returns 0 if ResetsAt is zero or passed.
+func (e *RateLimitError) Wait() time.Duration {
+	if e == nil || e.ResetsAt.IsZero() {
+		return 0
+	}
+	d := time.Until(e.ResetsAt)
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// RateLimitErrorParser may be implemented by ReqMaker.
+// If ReqMaker implements it, Request will call it when request failed,
+// and set result to RequestError.RateLimitError.
+// Should return nil if the error is not caused by rate limit.
+type RateLimitErrorParser interface {
+	ParseRateLimitError(resp *Response, reqErr RequestError) *RateLimitError
+}
+
+// -----------------------------------------------------------
+// Rate Limit Error
+// ===========================================================
+
+// ===========================================================
+// Rate Limit Usage
+// -----------------------------------------------------------
+
+// RateLimitUsage is the rate limit usage reported by cex in response,
+// ex. binance headers X-MBX-USED-WEIGHT-1M, X-MBX-ORDER-COUNT-10S.
+// Key of maps is interval tag, ex. 1M, 10S, 1D.
+type RateLimitUsage struct {
+	// UsedWeight is the used request weight of ip.
+	UsedWeight map[string]int64 `json:"usedWeight"`
+
+	// OrderCount is the order count of account.
+	OrderCount map[string]int64 `json:"orderCount"`
+
+	// ReceivedAt is the time when response is received.
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// IsEmpty returns true, if cex reports nothing.
+func (u *RateLimitUsage) IsEmpty() bool {
+	return u == nil || (len(u.UsedWeight) == 0 && len(u.OrderCount) == 0)
+}
+
+// RateLimitUsageParser may be implemented by ReqMaker.
+// If ReqMaker implements it, Request will call it after every response,
+// set result to RequestError.RateLimitUsage, and update DefaultWeightBudget.
+// Should return nil if response contains no usage info.
+type RateLimitUsageParser interface {
+	ParseRateLimitUsage(resp *Response) *RateLimitUsage
+}
+
+var intervalTagUnits = map[byte]time.Duration{
+	'S': time.Second,
+	'M': time.Minute,
+	'H': time.Hour,
+	'D': 24 * time.Hour,
+}
+
+// ParseIntervalTag parses interval tag, ex. 1M, 10S, 1D, into duration.
+func ParseIntervalTag(tag string) (time.Duration, error) {
+	if len(tag) < 2 {
+		return 0, fmt.Errorf("cex: invalid interval tag %q", tag)
+	}
+	unit, ok := intervalTagUnits[tag[len(tag)-1]]
+	if !ok {
+		return 0, fmt.Errorf("cex: invalid interval tag %q", tag)
+	}
+	num, err := strconv.ParseInt(tag[:len(tag)-1], 10, 64)
+	if err != nil || num <= 0 {
+		return 0, fmt.Errorf("cex: invalid interval tag %q", tag)
+	}
+	return unit * time.Duration(num), nil
+}
+
+// -----------------------------------------------------------
+// Rate Limit Usage
+// ===========================================================
+
+// ===========================================================
+// Rate Limit Budget
+// -----------------------------------------------------------
+
+// RateLimitBudget is one rate limit rule of cex,
+// ex. binance spot REQUEST_WEIGHT 6000 per 1 minute.
+type RateLimitBudget struct {
+	LimitType RateLimitType  `json:"limitType" bson:"limitType"`
+	Scope     RateLimitScope `json:"scope" bson:"scope"`
+	Interval  time.Duration  `json:"interval" bson:"interval"`
+	Limit     int64          `json:"limit" bson:"limit"`
+}
+
+var (
+	muxRateLimitBudgets sync.RWMutex
+	rateLimitBudgets    = map[string][]RateLimitBudget{}
+)
+
+// SetRateLimitBudgets sets rate limit budgets of base url.
+// Exchange packages should call it after loading limits from cex,
+// so limits stay correct when cex changes them.
+func SetRateLimitBudgets(baseUrl string, budgets []RateLimitBudget) {
+	muxRateLimitBudgets.Lock()
+	defer muxRateLimitBudgets.Unlock()
+	rateLimitBudgets[baseUrl] = append([]RateLimitBudget(nil), budgets...)
+}
+
+// RateLimitBudgets returns a copy of rate limit budgets of base url.
+func RateLimitBudgets(baseUrl string) []RateLimitBudget {
+	muxRateLimitBudgets.RLock()
+	defer muxRateLimitBudgets.RUnlock()
+	return append([]RateLimitBudget(nil), rateLimitBudgets[baseUrl]...)
+}
+
+// -----------------------------------------------------------
+// Rate Limit Budget
+// ===========================================================
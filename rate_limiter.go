@@ -0,0 +1,276 @@
+package cex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++              Cex REST Core: Rate Limiter            +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ===========================================================
+// Token Bucket
+// -----------------------------------------------------------
+
+type tokenBucket struct {
+	capacity float64
+	tokens   float64
+	// tokens per nanosecond
+	rate float64
+	last time.Time
+	// blockedUntil is set when cex responds rate limit error
+	blockedUntil time.Time
+}
+
+func newTokenBucket(capacity int64, interval time.Duration, now time.Time) *tokenBucket {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &tokenBucket{
+		capacity: float64(capacity),
+		tokens:   float64(capacity),
+		rate:     float64(capacity) / float64(interval),
+		last:     now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += float64(now.Sub(b.last)) * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+	}
+}
+
+// wait returns duration to wait until n tokens are available.
+func (b *tokenBucket) wait(now time.Time, n float64) time.Duration {
+	b.refill(now)
+	var d time.Duration
+	if now.Before(b.blockedUntil) {
+		d = b.blockedUntil.Sub(now)
+	}
+	if n > b.capacity {
+		n = b.capacity
+	}
+	if b.tokens < n {
+		dt := time.Duration((n - b.tokens) / b.rate)
+		if dt > d {
+			d = dt
+		}
+	}
+	return d
+}
+
+func (b *tokenBucket) take(n float64) {
+	b.tokens -= n
+}
+
+// -----------------------------------------------------------
+// Token Bucket
+// ===========================================================
+
+// ===========================================================
+// Rate Limiter
+// -----------------------------------------------------------
+
+type RateLimitMode int
+
+const (
+	// RateLimitModeBlock blocks request until it is allowed or context is done.
+	RateLimitModeBlock RateLimitMode = iota
+	// RateLimitModeReject returns ErrRateLimited immediately, if request is not allowed.
+	RateLimitModeReject
+)
+
+// RateLimiter limits requests locally before sending to cex.
+// There are three kinds of token buckets:
+//   - per endpoint per ip, by ReqBaseConfig.IpTimeInterval
+//   - per endpoint per api key, by ReqBaseConfig.UserTimeInterval
+//   - per base url per ip, by RateLimitBudgets of base url
+//
+// If cex responds ip rate limit error, requests to base url are blocked until it resets.
+type RateLimiter struct {
+	mux     sync.Mutex
+	mode    RateLimitMode
+	buckets map[string]*tokenBucket
+}
+
+func NewRateLimiter(mode RateLimitMode) *RateLimiter {
+	return &RateLimiter{mode: mode, buckets: map[string]*tokenBucket{}}
+}
+
+var (
+	muxDefaultRateLimiter sync.RWMutex
+	defaultRateLimiter    = NewRateLimiter(RateLimitModeBlock)
+)
+
+// DefaultRateLimiter is consulted by Request automatically.
+// Returns nil if it is disabled.
+func DefaultRateLimiter() *RateLimiter {
+	muxDefaultRateLimiter.RLock()
+	defer muxDefaultRateLimiter.RUnlock()
+	return defaultRateLimiter
+}
+
+// SetDefaultRateLimiter sets limiter consulted by Request.
+// Set nil to disable local rate limiting.
+func SetDefaultRateLimiter(limiter *RateLimiter) {
+	muxDefaultRateLimiter.Lock()
+	defer muxDefaultRateLimiter.Unlock()
+	defaultRateLimiter = limiter
+}
+
+type rateLimitKey struct {
+	key      string
+	capacity int64
+	interval time.Duration
+	cost     float64
+}
+
+func endpointKey(config ReqBaseConfig) string {
+	return config.Method + " " + config.BaseUrl + config.Path
+}
+
+func baseUrlBucketPrefix(baseUrl string) string {
+	return "budget|" + baseUrl + "|"
+}
+
+func blockKey(baseUrl string) string {
+	return "block|" + baseUrl
+}
+
+func (l *RateLimiter) keys(config ReqBaseConfig, apiKey string, cost int64) []rateLimitKey {
+	// block key is always checked, cost nothing
+	keys := []rateLimitKey{{
+		key:      blockKey(config.BaseUrl),
+		capacity: 1,
+		interval: time.Nanosecond,
+		cost:     0,
+	}}
+	ep := endpointKey(config)
+	if config.IpTimeInterval > 0 {
+		keys = append(keys, rateLimitKey{
+			key:      "ip|" + ep,
+			capacity: 1,
+			interval: time.Duration(config.IpTimeInterval) * time.Millisecond,
+			cost:     1,
+		})
+	}
+	if config.UserTimeInterval > 0 && apiKey != "" {
+		keys = append(keys, rateLimitKey{
+			key:      "user|" + apiKey + "|" + ep,
+			capacity: 1,
+			interval: time.Duration(config.UserTimeInterval) * time.Millisecond,
+			cost:     1,
+		})
+	}
+	for _, b := range RateLimitBudgets(config.BaseUrl) {
+		if b.Scope != RateLimitScopeIp || b.Limit <= 0 || b.Interval <= 0 {
+			continue
+		}
+		c := float64(1)
+		if b.LimitType == RateLimitTypeRequestWeight {
+			c = float64(cost)
+		}
+		keys = append(keys, rateLimitKey{
+			key:      fmt.Sprintf("%v%v|%v|%v", baseUrlBucketPrefix(config.BaseUrl), b.LimitType, b.Interval, b.Limit),
+			capacity: b.Limit,
+			interval: b.Interval,
+			cost:     c,
+		})
+	}
+	return keys
+}
+
+// Acquire waits or rejects until request of config is allowed.
+// apiKey can be empty, if request is public.
+// cost is request weight, should be 1 if unknown.
+func (l *RateLimiter) Acquire(ctx context.Context, config ReqBaseConfig, apiKey string, cost int64) error {
+	if l == nil {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if cost < 1 {
+		cost = 1
+	}
+	keys := l.keys(config, apiKey, cost)
+	for {
+		wait := l.tryTake(keys)
+		if wait <= 0 {
+			return nil
+		}
+		if l.mode == RateLimitModeReject {
+			return &RateLimitError{
+				LimitType: RateLimitTypeRequestWeight,
+				Scope:     RateLimitScopeIp,
+				ResetsAt:  time.Now().Add(wait),
+				Err:       ErrRateLimited,
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ErrRateLimited, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// tryTake takes tokens from all buckets if all are available,
+// otherwise returns max duration to wait.
+func (l *RateLimiter) tryTake(keys []rateLimitKey) time.Duration {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	now := time.Now()
+	var maxWait time.Duration
+	buckets := make([]*tokenBucket, len(keys))
+	for i, k := range keys {
+		b, ok := l.buckets[k.key]
+		if !ok {
+			b = newTokenBucket(k.capacity, k.interval, now)
+			l.buckets[k.key] = b
+		}
+		buckets[i] = b
+		if w := b.wait(now, k.cost); w > maxWait {
+			maxWait = w
+		}
+	}
+	if maxWait > 0 {
+		return maxWait
+	}
+	for i, b := range buckets {
+		b.take(keys[i].cost)
+	}
+	return 0
+}
+
+// Block blocks all requests to base url until t.
+// Request calls it automatically when cex responds rate limit error.
+func (l *RateLimiter) Block(baseUrl string, until time.Time) {
+	if l == nil {
+		return
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	key := blockKey(baseUrl)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newTokenBucket(1, time.Nanosecond, time.Now())
+		l.buckets[key] = b
+	}
+	if until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// -----------------------------------------------------------
+// Rate Limiter
+// ===========================================================
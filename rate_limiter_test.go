@@ -0,0 +1,41 @@
+package cex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Acquire(t *testing.T) {
+	config := ReqBaseConfig{BaseUrl: "https://test.limiter", Path: "/a", Method: "GET", IpTimeInterval: 100}
+
+	limiter := NewRateLimiter(RateLimitModeReject)
+	if err := limiter.Acquire(context.TODO(), config, "", 1); err != nil {
+		t.Fatal(err)
+	}
+	err := limiter.Acquire(context.TODO(), config, "", 1)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatal("second request should be rate limited, but", err)
+	}
+
+	limiter = NewRateLimiter(RateLimitModeBlock)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Acquire(context.TODO(), config, "", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 150*time.Millisecond {
+		t.Error("requests should be blocked, but only take", d)
+	}
+}
+
+func TestRateLimiter_Block(t *testing.T) {
+	config := ReqBaseConfig{BaseUrl: "https://test.limiter", Path: "/b", Method: "GET"}
+	limiter := NewRateLimiter(RateLimitModeReject)
+	limiter.Block(config.BaseUrl, time.Now().Add(time.Minute))
+	if err := limiter.Acquire(context.TODO(), config, "", 1); !errors.Is(err, ErrRateLimited) {
+		t.Fatal("blocked base url should be rate limited, but", err)
+	}
+}
@@ -1,18 +1,145 @@
 package cex
 
 import (
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
-func CltOptRetryCount(count int, waitTime time.Duration) CltOpt {
-	return func(client *resty.Client) {
-		if client == nil {
-			return
+// ReqOpts is collected from CltOpts of one request by NewReqOpts.
+// ReqMakers read it to custom requests they make.
+type ReqOpts struct {
+	// Headers are set to request by NewHTTPRequest.
+	Headers map[string]string
+
+	// Query is merged into request query by ReqMakers before signing,
+	// so it is covered by signature of user data requests.
+	Query url.Values
+
+	// RecvWindow is how long signed request is valid after its timestamp.
+	// unit is millisecond, 0 means default of cex user.
+	// It is ignored by cex without recvWindow.
+	RecvWindow int64
+
+	// Timeout is timeout of http request, 0 means no timeout.
+	Timeout time.Duration
+
+	// Debug captures payloads of failed request into RequestError.Payload.
+	Debug bool
+
+	// Backend sends request, DefaultBackend if empty.
+	Backend Backend
+
+	// clientOpts custom resty client of BackendResty
+	clientOpts []func(*resty.Client)
+
+	// transport and httpClientOpts are used by BackendNetHTTP, which does not build resty client.
+	transport      Transport
+	httpClientOpts []func(*http.Client)
+}
+
+func NewReqOpts(opts ...CltOpt) ReqOpts {
+	ro := ReqOpts{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&ro)
 		}
+	}
+	return ro
+}
+
+// needClient returns true, if request can not be made by shared client.
+func (ro ReqOpts) needClient() bool {
+	return len(ro.clientOpts) > 0 || ro.Timeout > 0
+}
+
+// CltOptHTTPClient customs http client of one request with f, ex. cookie jar or redirect policy.
+// f is never applied to the shared client.
+func CltOptHTTPClient(f func(*http.Client)) CltOpt {
+	restyOpt := cltOptClient(func(client *resty.Client) {
+		f(client.GetClient())
+	})
+	return func(ro *ReqOpts) {
+		restyOpt(ro)
+		ro.httpClientOpts = append(ro.httpClientOpts, f)
+	}
+}
+
+// cltOptClient customs resty client of BackendResty with f.
+// Resty is not exposed by CltOpts, so the underlying http client can be replaced.
+func cltOptClient(f func(*resty.Client)) CltOpt {
+	return func(ro *ReqOpts) {
+		ro.clientOpts = append(ro.clientOpts, func(client *resty.Client) {
+			if client == nil {
+				return
+			}
+			f(client)
+		})
+	}
+}
+
+func CltOptRetryCount(count int, waitTime time.Duration) CltOpt {
+	return cltOptClient(func(client *resty.Client) {
 		client.SetRetryCount(count)
 		client.SetRetryWaitTime(waitTime)
 		client.SetRetryMaxWaitTime(waitTime)
+	})
+}
+
+// CltOptTransport sends request by transport instead of shared transport,
+// ex. cextest.MockTransport.
+func CltOptTransport(transport Transport) CltOpt {
+	restyOpt := cltOptClient(func(client *resty.Client) {
+		client.SetTransport(transport)
+	})
+	return func(ro *ReqOpts) {
+		restyOpt(ro)
+		ro.transport = transport
+	}
+}
+
+// CltOptHeader sets header of one request.
+// Headers set by ReqMaker, ex. api key, can not be overridden.
+func CltOptHeader(key, value string) CltOpt {
+	return func(ro *ReqOpts) {
+		if ro.Headers == nil {
+			ro.Headers = map[string]string{}
+		}
+		ro.Headers[key] = value
+	}
+}
+
+// CltOptQuery sets query param of one request,
+// ex. params which are not in params struct yet.
+func CltOptQuery(key, value string) CltOpt {
+	return func(ro *ReqOpts) {
+		if ro.Query == nil {
+			ro.Query = url.Values{}
+		}
+		ro.Query.Set(key, value)
+	}
+}
+
+// CltOptRecvWindow sets recvWindow of one signed request, unit is millisecond.
+func CltOptRecvWindow(recvWindow int64) CltOpt {
+	return func(ro *ReqOpts) {
+		ro.RecvWindow = recvWindow
+	}
+}
+
+// CltOptTimeout sets timeout of one request.
+func CltOptTimeout(timeout time.Duration) CltOpt {
+	return func(ro *ReqOpts) {
+		ro.Timeout = timeout
+	}
+}
+
+// CltOptDebug captures payloads of one request into RequestError.Payload, if it fails.
+// See SetDebugPayloads to capture payloads of all requests.
+func CltOptDebug() CltOpt {
+	return func(ro *ReqOpts) {
+		ro.Debug = true
 	}
 }
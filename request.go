@@ -1,13 +1,11 @@
 package cex
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"reflect"
-
-	"github.com/go-resty/resty/v2"
+	"time"
 )
 
 // =========================================================== \\
@@ -42,51 +40,212 @@ func Request[ReqDataType, RespDataType any](
 	config ReqConfig[ReqDataType, RespDataType],
 	reqData ReqDataType,
 	opts ...CltOpt,
-) (*resty.Response, RespDataType, RequestError) {
-	var resp *resty.Response
+) (*Response, RespDataType, RequestError) {
+	return requestWithRetry(nil, reqMaker, config, reqData, opts...)
+}
+
+// RequestCtx is same as Request, but ctx is propagated to the underlying
+// http request, so callers can set deadline and cancel in-flight request.
+// ctx overrides the context set by ReqMaker.
+func RequestCtx[ReqDataType, RespDataType any](
+	ctx context.Context,
+	reqMaker ReqMaker,
+	config ReqConfig[ReqDataType, RespDataType],
+	reqData ReqDataType,
+	opts ...CltOpt,
+) (*Response, RespDataType, RequestError) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return requestWithRetry(ctx, reqMaker, config, reqData, opts...)
+}
+
+func requestWithRetry[ReqDataType, RespDataType any](
+	ctx context.Context,
+	reqMaker ReqMaker,
+	config ReqConfig[ReqDataType, RespDataType],
+	reqData ReqDataType,
+	opts ...CltOpt,
+) (*Response, RespDataType, RequestError) {
+	policy := DefaultRetryPolicy()
+	if config.RetryPolicy != nil {
+		policy = *config.RetryPolicy
+	}
+	var resp *Response
 	var data RespDataType
 	var err RequestError
-	for i := 0; i < 3; i++ {
-		resp, data, err = request(reqMaker, config, reqData, opts...)
-		if err.Is(ErrInvalidTimestamp) {
-			continue
+	for attempt := 1; ; attempt++ {
+		resp, data, err = requestWithFailover(ctx, reqMaker, config, reqData, opts...)
+		if !policy.shouldRetry(attempt, err) {
+			break
+		}
+		if ctx != nil && ctx.Err() != nil {
+			break
+		}
+		if sleepCtx(ctx, policy.Delay(attempt, err)) != nil {
+			break
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err)
 		}
-		break
 	}
 	return resp, data, err
 }
 
+// requestWithFailover resends GET request to the next selected base url,
+// if ReqMaker implements BaseUrlSelector, and the selected base url failed and is switched.
+// Other methods are not resent, because their request status is unknown.
+func requestWithFailover[ReqDataType, RespDataType any](
+	ctx context.Context,
+	reqMaker ReqMaker,
+	config ReqConfig[ReqDataType, RespDataType],
+	reqData ReqDataType,
+	opts ...CltOpt,
+) (resp *Response, data RespDataType, err RequestError) {
+	for failovers := 0; ; failovers++ {
+		resp, data, err = request(ctx, reqMaker, config, reqData, opts...)
+		if err.failoverBaseUrl == "" || config.Method != http.MethodGet || failovers >= maxBaseUrlFailovers {
+			return
+		}
+		if ctx != nil && ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// request sets ctx to request made by ReqMaker, if ctx is not nil.
 func request[ReqDataType, RespDataType any](
+	ctx context.Context,
 	reqMaker ReqMaker,
 	config ReqConfig[ReqDataType, RespDataType],
 	reqData ReqDataType,
 	opts ...CltOpt,
-) (*resty.Response, RespDataType, RequestError) {
-	reqErr := RequestError{ReqBaseConfig: config.ReqBaseConfig}
-	var respData RespDataType
+) (resp *Response, respData RespDataType, reqErr RequestError) {
+	reqErr = RequestError{ReqBaseConfig: config.ReqBaseConfig}
 
-	req, err := reqMaker.Make(config.ReqBaseConfig, reqData, opts...)
-	if err != nil {
-		return nil, respData, *reqErr.SetErr(fmt.Errorf("cex: make request, %w", err))
+	if rewriter, ok := reqMaker.(BaseUrlRewriter); ok {
+		baseUrl, err := rewriter.RewriteBaseUrl(config.BaseUrl)
+		if err != nil {
+			return nil, respData, *reqErr.SetErr(fmt.Errorf("cex: rewrite base url, %w", err))
+		}
+		config.BaseUrl = baseUrl
+		reqErr.ReqBaseConfig = config.ReqBaseConfig
+	}
+
+	// makeConfig is config with the selected base url,
+	// rate limits and cache still use config
+	makeConfig := config.ReqBaseConfig
+	selector, _ := reqMaker.(BaseUrlSelector)
+	if selector != nil {
+		makeConfig.BaseUrl = selector.SelectBaseUrl(config.BaseUrl)
+	}
+
+	var req *HTTPRequest
+	var cacheKey string
+	cache := responseCacheStore(config.ReqBaseConfig, config.CacheTTL)
+	if cache != nil {
+		// public request is made before rate limiting,
+		// because cached response does not consume rate limits
+		var err error
+		req, err = makeRequest(ctx, reqMaker, makeConfig, reqData, opts...)
+		if err != nil {
+			return nil, respData, *reqErr.SetErr(err)
+		}
+		cacheKey = responseCacheKey(config.ReqBaseConfig, req)
+		if body, ok := cacheGet(cache, cacheKey, req.Context()); ok && config.RespBodyUnmarshaler != nil {
+			if data, errBody := config.RespBodyUnmarshaler(body); errBody == nil {
+				resp = cachedResponse(body)
+				reqErr.Meta = NewResponseMeta(resp)
+				reqErr.Meta.Cached = true
+				return resp, data, reqErr
+			}
+		}
 	}
 
-	// here sets empty url
-	// request maker should compose the whole url
-	var resp *resty.Response
-	switch config.Method {
-	case http.MethodGet:
-		resp, err = req.Get("")
-	case http.MethodPost:
-		resp, err = req.Post("")
-	case http.MethodPut:
-		resp, err = req.Put("")
-	case http.MethodDelete:
-		resp, err = req.Delete("")
+	limiter := DefaultRateLimiter()
+	var apiKey string
+	if apiGetter, ok := reqMaker.(interface{ Api() Api }); ok && config.IsUserData {
+		apiKey = apiGetter.Api().ApiKey
+	}
+	if err := limiter.Acquire(ctx, config.ReqBaseConfig, apiKey, 1); err != nil {
+		var rlErr *RateLimitError
+		if errors.As(err, &rlErr) {
+			reqErr.RateLimitError = rlErr
+		}
+		return nil, respData, *reqErr.SetErr(fmt.Errorf("cex: local rate limiter, %w", err))
+	}
+
+	budget := DefaultWeightBudget()
+	if err := budget.Acquire(ctx, config.ReqBaseConfig, apiKey, 1); err != nil {
+		var rlErr *RateLimitError
+		if errors.As(err, &rlErr) {
+			reqErr.RateLimitError = rlErr
+		}
+		return nil, respData, *reqErr.SetErr(fmt.Errorf("cex: weight budget, %w", err))
+	}
+
+	if req == nil {
+		var err error
+		req, err = makeRequest(ctx, reqMaker, makeConfig, reqData, opts...)
+		if err != nil {
+			return nil, respData, *reqErr.SetErr(err)
+		}
+	}
+
+	if DebugPayloads() || NewReqOpts(opts...).Debug {
+		defer func() {
+			if reqErr.IsNotNil() {
+				reqErr.Payload = newRequestPayload(req, resp)
+			}
+		}()
+	}
+
+	// request maker should compose the whole url, and set it to req.URL
+	if req.Method == "" {
+		req.Method = config.Method
+	}
+	var err error
+
+	if logger := requestLogger(reqMaker); logger != nil {
+		start := time.Now()
+		defer func() {
+			log := RequestLog{
+				Method:  config.Method,
+				BaseUrl: makeConfig.BaseUrl,
+				Path:    config.Path,
+				Params:  reqParams(req),
+				Latency: time.Since(start),
+				Err:     reqErr.Err,
+			}
+			if resp != nil {
+				log.StatusCode = resp.StatusCode()
+				log.Latency = resp.Time()
+			}
+			if reqErr.RateLimitUsage != nil {
+				log.UsedWeight = reqErr.RateLimitUsage.UsedWeight
+			}
+			logger.LogRequest(req.Context(), log)
+		}()
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete:
+		resp, err = send(newReqOpts(reqMaker, opts...), req)
 	default:
-		return resp, respData, *reqErr.SetErr(fmt.Errorf("cex: http method %v is not supported", config.Method))
+		return resp, respData, *reqErr.SetErr(fmt.Errorf("cex: http method %v is not supported", req.Method))
 	}
 
-	// Ignore resty error, if response is not nil.
+	// ctx error is not failure of base url,
+	// backends return response with status code 0, if no response is received
+	if selector != nil && req.Context().Err() == nil {
+		failed := resp == nil || resp.StatusCode() == 0 || resp.StatusCode() >= http.StatusInternalServerError
+		selector.ReportBaseUrl(makeConfig.BaseUrl, failed)
+		if next := selector.SelectBaseUrl(config.BaseUrl); failed && next != makeConfig.BaseUrl {
+			reqErr.failoverBaseUrl = next
+		}
+	}
+
+	// Ignore backend error, if response is not nil.
 	// Resty will return err if status code > 399.
 	// But the request with a response status code that bigger than 399
 	// may not be failed.
@@ -103,8 +262,8 @@ func request[ReqDataType, RespDataType any](
 	if resp == nil {
 		// Should not get here.
 		// If getting here, err and resp are all nil.
-		// Resty may have bugs.
-		return resp, respData, *reqErr.SetErr(fmt.Errorf("cex: resp and err are all nil, resty may have bugs"))
+		// Backend may have bugs.
+		return resp, respData, *reqErr.SetErr(fmt.Errorf("cex: resp and err are all nil, backend may have bugs"))
 	}
 
 	var errResty error
@@ -112,6 +271,15 @@ func request[ReqDataType, RespDataType any](
 		errResty = err
 	}
 
+	reqErr.Meta = newResponseMeta(reqMaker, resp)
+
+	if parser, ok := reqMaker.(RateLimitUsageParser); ok {
+		if usage := parser.ParseRateLimitUsage(resp); !usage.IsEmpty() {
+			reqErr.RateLimitUsage = usage
+			budget.Update(config.ReqBaseConfig, apiKey, usage)
+		}
+	}
+
 	if config.HTTPStatusCodeChecker == nil {
 		return resp, respData, *reqErr.SetErr(fmt.Errorf("cex: config http status code checker is nil"))
 	}
@@ -125,6 +293,9 @@ func request[ReqDataType, RespDataType any](
 	respData, errBodyUnmarshal := config.RespBodyUnmarshaler(resp.Body())
 
 	if errHttp == nil && errBodyUnmarshal == nil {
+		if cache != nil {
+			cache.Set(cacheKey, resp.Body(), config.CacheTTL)
+		}
 		return resp, respData, reqErr
 	}
 
@@ -144,9 +315,29 @@ func request[ReqDataType, RespDataType any](
 
 	reqErr.Err = fmt.Errorf("cex: request, resty err: %w, http err: %w, body unmarshal err: %w", errResty, errHttp, errBodyUnmarshal)
 
+	if parser, ok := reqMaker.(RateLimitErrorParser); ok {
+		reqErr.RateLimitError = parser.ParseRateLimitError(resp, reqErr)
+	}
+
+	if rlErr := reqErr.RateLimitError; rlErr != nil && rlErr.Scope == RateLimitScopeIp && !rlErr.ResetsAt.IsZero() {
+		limiter.Block(config.BaseUrl, rlErr.ResetsAt)
+	}
+
 	return resp, respData, reqErr
 }
 
+// makeRequest sets ctx to request made by reqMaker, if ctx is not nil.
+func makeRequest(ctx context.Context, reqMaker ReqMaker, config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error) {
+	req, err := reqMaker.Make(config, reqData, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cex: make request, %w", err)
+	}
+	if ctx != nil {
+		req.SetContext(ctx)
+	}
+	return req, nil
+}
+
 // -----------------------------------------------------------
 // Request
 // ===========================================================
@@ -211,15 +402,24 @@ type ReqConfig[ReqDataType, RespDataType any] struct {
 	// status code and its status message
 	HTTPStatusCodeChecker HTTPStatusCodeChecker
 	RespBodyUnmarshaler   RespBodyUnmarshaler[RespDataType]
+	// RetryPolicy overrides DefaultRetryPolicy, if it is not nil.
+	RetryPolicy *RetryPolicy
+	// CacheTTL is how long response is cached by DefaultResponseCache.
+	// Only public GET requests are cached, 0 means no cache.
+	CacheTTL time.Duration
 }
 
-// CltOpt is function option that can custom request.
-type CltOpt func(*resty.Client)
+// CltOpt is the only function option that can custom request,
+// accepted by Request and all ReqMakers.
+// It can custom client of one request, never the shared client,
+// and per-request values, ex. headers, query params, recvWindow and timeout.
+// CltOpts of one request are collected into ReqOpts.
+type CltOpt func(*ReqOpts)
 
 // ReqMaker should be implemented in all cex package
 type ReqMaker interface {
-	Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*resty.Request, error)
-	//HandleResp(*resty.Response, *resty.Request) error
+	Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error)
+	//HandleResp(*Response, *HTTPRequest) error
 }
 
 // -----------------------------------------------------------
@@ -277,7 +477,20 @@ type RequestError struct {
 	ReqBaseConfig            ReqBaseConfig             `json:"reqBaseConfig"`
 	HTTPError                *HTTPError                `json:"HTTPError"`
 	RespBodyUnmarshalerError *RespBodyUnmarshalerError `json:"respBodyUnmarshalerError"`
-	Err                      error                     `json:"err"`
+	// RateLimitError is not nil, if request is rejected by cex rate limit.
+	RateLimitError *RateLimitError `json:"rateLimitError"`
+	// RateLimitUsage is set even if request succeeded,
+	// if ReqMaker implements RateLimitUsageParser.
+	RateLimitUsage *RateLimitUsage `json:"rateLimitUsage"`
+	// Meta is set whenever a response is received, even if request succeeded.
+	Meta *ResponseMeta `json:"meta,omitempty"`
+	// Payload is set if request failed in debug mode, see CltOptDebug and SetDebugPayloads.
+	Payload *RequestPayload `json:"payload,omitempty"`
+	Err     error           `json:"err"`
+
+	// failoverBaseUrl is the next base url selected by BaseUrlSelector,
+	// if the selected base url failed.
+	failoverBaseUrl string
 }
 
 func (e *RequestError) Error() string {
@@ -303,6 +516,11 @@ func (e *RequestError) SetErr(err error) *RequestError {
 	return e
 }
 
+// IsRateLimited returns true, if request is rejected by cex rate limit.
+func (e *RequestError) IsRateLimited() bool {
+	return e.RateLimitError != nil
+}
+
 func (e *RequestError) IsNotNil() bool {
 	return e.Err != nil
 }
@@ -319,35 +537,10 @@ func (e *RequestError) IsNil() bool {
 // Resp Data Unmarshaler
 // -----------------------------------------------------------
 
+// StdBodyUnmarshaler unmarshals json body by DefaultNumberMode,
+// unknown fields of body are handled by DefaultDecodeMode.
 func StdBodyUnmarshaler[D any](data []byte) (D, *RespBodyUnmarshalerError) {
-	errUnmar := new(RespBodyUnmarshalerError)
-	respData := new(D)
-
-	respType := reflect.TypeOf(respData).Elem()
-
-	var anyRes any
-
-	switch respType.Kind() {
-	case reflect.String:
-		anyRes = any(string(data))
-	case reflect.Slice, reflect.Struct, reflect.Map:
-		if err := json.Unmarshal(data, respData); err != nil {
-			return *respData, errUnmar.SetErr(fmt.Errorf("%w: unmarshal response body, %w", ErrJsonUnmarshal, err))
-		}
-		anyRes = any(*respData)
-	default:
-		return *respData, errUnmar.SetErr(fmt.Errorf("response data type %v is not supported", respType.Kind()))
-	}
-
-	res, ok := anyRes.(D)
-
-	if !ok {
-		errUnmar.Err = fmt.Errorf("cex: cannot convert to %T", res)
-	} else {
-		errUnmar = nil
-	}
-
-	return res, errUnmar
+	return stdBodyUnmarshaler[D](data, DefaultNumberMode())
 }
 
 // -----------------------------------------------------------
@@ -0,0 +1,254 @@
+package cex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++               Cex REST Core: Request Log            +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// RequestLog is passed to Logger after every sent request.
+type RequestLog struct {
+	Method  string `json:"method"`
+	BaseUrl string `json:"baseUrl"`
+	Path    string `json:"path"`
+
+	// Params are query, form and top level json body params,
+	// sensitive params are redacted, see RedactParams.
+	Params url.Values `json:"params"`
+
+	Latency time.Duration `json:"latency"`
+
+	// StatusCode is 0, if no response is received.
+	StatusCode int `json:"statusCode"`
+
+	// UsedWeight is reported by cex, may be nil.
+	UsedWeight map[string]int64 `json:"usedWeight"`
+
+	Err error `json:"err"`
+}
+
+// Logger logs requests.
+// Implement it to plug any logging library, ex. zap.
+// SlogLogger adapts log/slog.
+type Logger interface {
+	LogRequest(ctx context.Context, log RequestLog)
+}
+
+// LoggerProvider may be implemented by ReqMaker.
+// If ReqMaker implements it and returns a non-nil Logger,
+// Request logs by it instead of DefaultLogger.
+type LoggerProvider interface {
+	Logger() Logger
+}
+
+var (
+	muxDefaultLogger sync.RWMutex
+	defaultLogger    Logger
+)
+
+// DefaultLogger is nil by default, and no request is logged.
+func DefaultLogger() Logger {
+	muxDefaultLogger.RLock()
+	defer muxDefaultLogger.RUnlock()
+	return defaultLogger
+}
+
+// SetDefaultLogger sets global logger, set nil to disable logging.
+func SetDefaultLogger(logger Logger) {
+	muxDefaultLogger.Lock()
+	defer muxDefaultLogger.Unlock()
+	defaultLogger = logger
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// SlogLogger logs requests by logger.
+// Failed requests are logged at error level, others at debug level.
+func SlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger}
+}
+
+func (l slogLogger) LogRequest(ctx context.Context, log RequestLog) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	level := slog.LevelDebug
+	if log.Err != nil {
+		level = slog.LevelError
+	}
+	attrs := []slog.Attr{
+		slog.String("method", log.Method),
+		slog.String("path", log.Path),
+		slog.Duration("latency", log.Latency),
+		slog.Int("statusCode", log.StatusCode),
+		slog.String("params", log.Params.Encode()),
+	}
+	if len(log.UsedWeight) > 0 {
+		attrs = append(attrs, slog.Any("usedWeight", log.UsedWeight))
+	}
+	if log.Err != nil {
+		attrs = append(attrs, slog.String("err", log.Err.Error()))
+	}
+	l.logger.LogAttrs(ctx, level, "Cex request", attrs...)
+}
+
+var redactedParamKeys = map[string]bool{
+	"signature":  true,
+	"sign":       true,
+	"apikey":     true,
+	"api_key":    true,
+	"secretkey":  true,
+	"secret":     true,
+	"passphrase": true,
+}
+
+// RedactParams returns a copy of params, whose sensitive values,
+// ex. signature, api key and passphrase, are replaced by "***".
+func RedactParams(params url.Values) url.Values {
+	redacted := url.Values{}
+	for k, vs := range params {
+		if redactedParamKeys[strings.ToLower(k)] {
+			redacted[k] = []string{"***"}
+			continue
+		}
+		redacted[k] = append([]string(nil), vs...)
+	}
+	return redacted
+}
+
+func requestLogger(reqMaker ReqMaker) Logger {
+	if provider, ok := reqMaker.(LoggerProvider); ok {
+		if logger := provider.Logger(); logger != nil {
+			return logger
+		}
+	}
+	return DefaultLogger()
+}
+
+// reqParams collects params of req, after ReqMaker made it.
+func reqParams(req *HTTPRequest) url.Values {
+	params := url.Values{}
+	if u, err := url.Parse(req.URL); err == nil {
+		for k, vs := range u.Query() {
+			params[k] = append(params[k], vs...)
+		}
+	}
+	for k, vs := range req.Query {
+		params[k] = append(params[k], vs...)
+	}
+	for k, vs := range req.Form {
+		params[k] = append(params[k], vs...)
+	}
+	var body []byte
+	switch b := req.Body.(type) {
+	case []byte:
+		body = b
+	case string:
+		body = []byte(b)
+	}
+	if len(body) > 0 {
+		m := map[string]any{}
+		if json.Unmarshal(body, &m) == nil {
+			for k, v := range m {
+				params.Add(k, fmt.Sprint(v))
+			}
+		}
+	}
+	return RedactParams(params)
+}
+
+// RequestPayload is copy of payloads of failed request, to reproduce it.
+// Sensitive params are redacted, see RedactParams.
+type RequestPayload struct {
+	// Url contains all query params, ex. signed query.
+	Url string `json:"url"`
+	// Body is json body or encoded form data.
+	Body string `json:"body,omitempty"`
+	// RespBody is raw response body, empty if no response is received.
+	RespBody string `json:"respBody,omitempty"`
+}
+
+var (
+	muxDebugPayloads sync.RWMutex
+	debugPayloads    bool
+)
+
+func DebugPayloads() bool {
+	muxDebugPayloads.RLock()
+	defer muxDebugPayloads.RUnlock()
+	return debugPayloads
+}
+
+// SetDebugPayloads captures payloads of all failed requests into RequestError.Payload,
+// which copies request and response bodies, so it is for debugging only.
+func SetDebugPayloads(debug bool) {
+	muxDebugPayloads.Lock()
+	defer muxDebugPayloads.Unlock()
+	debugPayloads = debug
+}
+
+func newRequestPayload(req *HTTPRequest, resp *Response) *RequestPayload {
+	payload := &RequestPayload{Url: req.URL}
+	if u, err := url.Parse(req.URL); err == nil {
+		// backends append query params to url, when request is sent
+		query := u.Query()
+		for k, vs := range req.Query {
+			if !query.Has(k) {
+				query[k] = vs
+			}
+		}
+		if len(query) > 0 {
+			u.RawQuery = RedactParams(query).Encode()
+			payload.Url = u.String()
+		}
+	}
+	switch b := req.Body.(type) {
+	case []byte:
+		payload.Body = redactJsonBody(b)
+	case string:
+		payload.Body = redactJsonBody([]byte(b))
+	}
+	if payload.Body == "" && len(req.Form) > 0 {
+		payload.Body = RedactParams(req.Form).Encode()
+	}
+	if resp != nil {
+		payload.RespBody = string(resp.Body())
+	}
+	return payload
+}
+
+// redactJsonBody redacts sensitive top level keys of json object body.
+func redactJsonBody(body []byte) string {
+	m := map[string]json.RawMessage{}
+	if json.Unmarshal(body, &m) != nil {
+		return string(body)
+	}
+	redacted := false
+	for k := range m {
+		if redactedParamKeys[strings.ToLower(k)] {
+			m[k] = json.RawMessage(`"***"`)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return string(body)
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
@@ -0,0 +1,136 @@
+package cex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type testLogger struct {
+	logs []RequestLog
+}
+
+func (l *testLogger) LogRequest(ctx context.Context, log RequestLog) {
+	l.logs = append(l.logs, log)
+}
+
+type testLoggerReqMaker struct {
+	logger Logger
+}
+
+func (m testLoggerReqMaker) Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error) {
+	req := NewHTTPRequest(opts...)
+	req.URL = config.BaseUrl + config.Path + "?symbol=ETHUSDT&signature=abc"
+	return req, nil
+}
+
+func (m testLoggerReqMaker) Logger() Logger {
+	return m.logger
+}
+
+func TestRequest_Logger(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[NilReqData, map[string]bool]{
+		ReqBaseConfig:         ReqBaseConfig{BaseUrl: sv.URL, Path: "/log", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]bool],
+	}
+
+	logger := &testLogger{}
+	_, _, _ = Request(testLoggerReqMaker{logger}, config, nil)
+	if len(logger.logs) != 1 {
+		t.Fatal("request should be logged once, but", len(logger.logs))
+	}
+	log := logger.logs[0]
+	if log.Method != http.MethodGet || log.Path != "/log" || log.StatusCode != http.StatusTeapot {
+		t.Error("wrong log", log)
+	}
+	if log.Params.Get("symbol") != "ETHUSDT" || log.Params.Get("signature") != "***" {
+		t.Error("params should be logged and redacted", log.Params)
+	}
+	if log.Err == nil {
+		t.Error("body unmarshal err should be logged")
+	}
+
+	// default logger is used, if ReqMaker provides nil logger
+	defaultLogger := &testLogger{}
+	SetDefaultLogger(defaultLogger)
+	defer SetDefaultLogger(nil)
+	_, _, _ = Request(testLoggerReqMaker{}, config, nil)
+	if len(defaultLogger.logs) != 1 {
+		t.Error("request should be logged by default logger")
+	}
+}
+
+func TestRedactParams(t *testing.T) {
+	params := url.Values{"apiKey": {"key"}, "sign": {"s"}, "qty": {"1"}}
+	redacted := RedactParams(params)
+	if redacted.Get("apiKey") != "***" || redacted.Get("sign") != "***" || redacted.Get("qty") != "1" {
+		t.Error("wrong redacted params", redacted)
+	}
+	if params.Get("apiKey") != "key" {
+		t.Error("params should not be modified")
+	}
+}
+
+type testPayloadReqMaker struct{}
+
+func (testPayloadReqMaker) Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error) {
+	req := NewHTTPRequest(opts...)
+	req.URL = config.BaseUrl + config.Path + "?symbol=ETHUSDT&signature=abc"
+	req.SetQueryParam("recvWindow", "5000")
+	req.SetBody([]byte(`{"qty":"1","apiKey":"key"}`))
+	return req, nil
+}
+
+func TestRequest_Payload(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":-1100,"msg":"illegal characters"}`))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[NilReqData, map[string]any]{
+		ReqBaseConfig: ReqBaseConfig{BaseUrl: sv.URL, Path: "/payload", Method: http.MethodPost},
+		HTTPStatusCodeChecker: func(code int) error {
+			if code != http.StatusOK {
+				return ErrHTTPBadRequest
+			}
+			return nil
+		},
+		RespBodyUnmarshaler: StdBodyUnmarshaler[map[string]any],
+		RetryPolicy:         &RetryPolicy{MaxAttempts: 1},
+	}
+
+	_, _, err := Request(testPayloadReqMaker{}, config, nil)
+	if err.IsNil() || err.Payload != nil {
+		t.Fatal("payload should not be captured without debug", err.Payload)
+	}
+
+	_, _, err = Request(testPayloadReqMaker{}, config, nil, CltOptDebug())
+	payload := err.Payload
+	if payload == nil {
+		t.Fatal("payload should be captured in debug mode")
+	}
+	if payload.Url != sv.URL+"/payload?recvWindow=5000&signature=%2A%2A%2A&symbol=ETHUSDT" {
+		t.Error("wrong url", payload.Url)
+	}
+	if payload.Body != `{"apiKey":"***","qty":"1"}` {
+		t.Error("wrong body", payload.Body)
+	}
+	if payload.RespBody != `{"code":-1100,"msg":"illegal characters"}` {
+		t.Error("wrong resp body", payload.RespBody)
+	}
+
+	SetDebugPayloads(true)
+	defer SetDebugPayloads(false)
+	if _, _, err = Request(testPayloadReqMaker{}, config, nil); err.Payload == nil {
+		t.Error("payload should be captured globally")
+	}
+}
@@ -0,0 +1,155 @@
+package cex
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++             Cex REST Core: Response Cache           +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ResponseCacheStore stores response bodies of idempotent public requests,
+// ex. exchange info, tickers and klines.
+// It can be implemented by any store, ex. redis, to share cache between processes.
+type ResponseCacheStore interface {
+	// Get returns body, and false if key is not found or expired.
+	Get(key string) ([]byte, bool)
+	Set(key string, body []byte, ttl time.Duration)
+}
+
+const memoryCachePurgeInterval = time.Minute
+
+type memoryCacheItem struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+// MemoryCacheStore is in-memory ResponseCacheStore with TTL.
+// Expired items are purged lazily.
+type MemoryCacheStore struct {
+	mux       sync.Mutex
+	items     map[string]memoryCacheItem
+	nextPurge time.Time
+}
+
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{items: map[string]memoryCacheItem{}}
+}
+
+func (s *MemoryCacheStore) Get(key string) ([]byte, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	item, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(item.expiresAt) {
+		delete(s.items, key)
+		return nil, false
+	}
+	return item.body, true
+}
+
+func (s *MemoryCacheStore) Set(key string, body []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	now := time.Now()
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if now.After(s.nextPurge) {
+		s.purge(now)
+		s.nextPurge = now.Add(memoryCachePurgeInterval)
+	}
+	s.items[key] = memoryCacheItem{body: body, expiresAt: now.Add(ttl)}
+}
+
+// Len returns count of items, including expired items not purged.
+func (s *MemoryCacheStore) Len() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return len(s.items)
+}
+
+func (s *MemoryCacheStore) purge(now time.Time) {
+	for key, item := range s.items {
+		if !now.Before(item.expiresAt) {
+			delete(s.items, key)
+		}
+	}
+}
+
+var (
+	muxDefaultResponseCache sync.RWMutex
+	defaultResponseCache    ResponseCacheStore
+)
+
+// DefaultResponseCache is consulted by Request for configs with CacheTTL.
+// Returns nil if it is disabled, which is default.
+func DefaultResponseCache() ResponseCacheStore {
+	muxDefaultResponseCache.RLock()
+	defer muxDefaultResponseCache.RUnlock()
+	return defaultResponseCache
+}
+
+// SetDefaultResponseCache sets cache consulted by Request,
+// ex. cex.SetDefaultResponseCache(cex.NewMemoryCacheStore()).
+// Set nil to disable it.
+func SetDefaultResponseCache(store ResponseCacheStore) {
+	muxDefaultResponseCache.Lock()
+	defer muxDefaultResponseCache.Unlock()
+	defaultResponseCache = store
+}
+
+type ctxKeyNoCache struct{}
+
+// CtxNoCache returns ctx, with which Request does not read cached response,
+// but still caches the fresh response.
+//
+//	_, info, err := cex.RequestCtx(cex.CtxNoCache(ctx), clt, bnc.SpotExchangeInfoConfig, nil)
+func CtxNoCache(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, ctxKeyNoCache{}, true)
+}
+
+func isCacheBypassed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	bypassed, _ := ctx.Value(ctxKeyNoCache{}).(bool)
+	return bypassed
+}
+
+// responseCacheStore returns DefaultResponseCache,
+// if response of config can be cached.
+// Only public GET requests are cached.
+func responseCacheStore(config ReqBaseConfig, ttl time.Duration) ResponseCacheStore {
+	if ttl <= 0 || config.IsUserData || config.Method != http.MethodGet {
+		return nil
+	}
+	return DefaultResponseCache()
+}
+
+func responseCacheKey(config ReqBaseConfig, req *HTTPRequest) string {
+	return strings.Join([]string{config.Method, config.BaseUrl + config.Path, req.URL, req.Query.Encode()}, "|")
+}
+
+// cachedResponse creates response of cached body.
+func cachedResponse(body []byte) *Response {
+	return NewResponse(http.StatusOK, nil, body)
+}
+
+func cacheGet(store ResponseCacheStore, key string, ctx context.Context) ([]byte, bool) {
+	if isCacheBypassed(ctx) {
+		return nil, false
+	}
+	return store.Get(key)
+}
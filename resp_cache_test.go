@@ -0,0 +1,76 @@
+package cex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheStore(t *testing.T) {
+	store := NewMemoryCacheStore()
+	store.Set("a", []byte("1"), 30*time.Millisecond)
+	store.Set("b", []byte("2"), 0)
+	if body, ok := store.Get("a"); !ok || string(body) != "1" {
+		t.Error("a should be cached", string(body), ok)
+	}
+	if _, ok := store.Get("b"); ok {
+		t.Error("zero ttl should not be cached")
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, ok := store.Get("a"); ok {
+		t.Error("a should be expired")
+	}
+	if store.Len() != 0 {
+		t.Error("expired item should be deleted", store.Len())
+	}
+}
+
+func TestRequest_ResponseCache(t *testing.T) {
+	var hits int
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write([]byte(`{"symbol":"` + r.URL.Query().Get("symbol") + `"}`))
+	}))
+	defer sv.Close()
+
+	SetDefaultResponseCache(NewMemoryCacheStore())
+	defer SetDefaultResponseCache(nil)
+
+	config := ReqConfig[testPublicParams, map[string]string]{
+		ReqBaseConfig:         ReqBaseConfig{BaseUrl: sv.URL, Path: "/exchangeInfo", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]string],
+		CacheTTL:              time.Minute,
+	}
+	maker := NewPublicReqMaker()
+
+	for i := 0; i < 2; i++ {
+		resp, data, err := Request(maker, config, testPublicParams{Symbol: "ETHUSDT"})
+		if err.IsNotNil() {
+			t.Fatal(err.Error())
+		}
+		if data["symbol"] != "ETHUSDT" || resp.StatusCode() != http.StatusOK {
+			t.Error("wrong response", data, resp.StatusCode())
+		}
+	}
+	if hits != 1 {
+		t.Error("the second request should hit cache, but server hits", hits)
+	}
+
+	// different params are cached separately
+	if _, data, _ := Request(maker, config, testPublicParams{Symbol: "BTCUSDT"}); data["symbol"] != "BTCUSDT" || hits != 2 {
+		t.Error("wrong response of different params", data, hits)
+	}
+
+	if _, _, err := RequestCtx(CtxNoCache(context.Background()), maker, config, testPublicParams{Symbol: "ETHUSDT"}); err.IsNotNil() || hits != 3 {
+		t.Error("bypassed request should not hit cache", hits, err.Error())
+	}
+
+	config.CacheTTL = 0
+	Request(maker, config, testPublicParams{Symbol: "ETHUSDT"})
+	if hits != 4 {
+		t.Error("config without cache ttl should not be cached", hits)
+	}
+}
@@ -0,0 +1,100 @@
+package cex
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ===========================================================
+// Response
+// -----------------------------------------------------------
+
+// Response is http response returned by Request.
+// It does not expose the underlying http client,
+// so transport can be changed without breaking callers.
+// StatusCode is 0, if request is sent, but no response is received.
+type Response struct {
+	statusCode int
+	status     string
+	header     http.Header
+	body       []byte
+	time       time.Duration
+	receivedAt time.Time
+}
+
+// NewResponse returns response received now, ex. for fake ReqMakers and tests.
+func NewResponse(statusCode int, header http.Header, body []byte) *Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &Response{
+		statusCode: statusCode,
+		status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		header:     header,
+		body:       body,
+		receivedAt: time.Now(),
+	}
+}
+
+// newResponse returns nil, if resp is nil.
+func newResponse(resp *resty.Response) *Response {
+	if resp == nil {
+		return nil
+	}
+	r := &Response{header: http.Header{}, body: resp.Body(), receivedAt: resp.ReceivedAt()}
+	if resp.RawResponse != nil {
+		r.statusCode, r.status, r.header = resp.StatusCode(), resp.Status(), resp.Header()
+	}
+	// resty panics, if request is nil
+	if resp.Request != nil {
+		r.time = resp.Time()
+	}
+	return r
+}
+
+func (r *Response) StatusCode() int {
+	return r.statusCode
+}
+
+// Status is status text, ex. "200 OK".
+func (r *Response) Status() string {
+	return r.status
+}
+
+func (r *Response) Header() http.Header {
+	return r.header
+}
+
+func (r *Response) Body() []byte {
+	return r.body
+}
+
+func (r *Response) String() string {
+	return string(r.body)
+}
+
+// Time is duration from sending request to receiving response.
+func (r *Response) Time() time.Duration {
+	return r.time
+}
+
+func (r *Response) ReceivedAt() time.Time {
+	return r.receivedAt
+}
+
+// IsSuccess returns true, if status code is 2xx.
+func (r *Response) IsSuccess() bool {
+	return r.statusCode >= 200 && r.statusCode < 300
+}
+
+// IsError returns true, if status code is not less than 400.
+func (r *Response) IsError() bool {
+	return r.statusCode >= 400
+}
+
+// -----------------------------------------------------------
+// Response
+// ===========================================================
@@ -0,0 +1,85 @@
+package cex
+
+import (
+	"net/http"
+	"time"
+)
+
+// ===========================================================
+// Response Meta
+// -----------------------------------------------------------
+
+// ResponseMeta is metadata of http response,
+// so callers do not need to keep the Response.
+// It is set to RequestError.Meta, whenever a response is received, even if request succeeded.
+type ResponseMeta struct {
+	StatusCode int    `json:"statusCode"`
+	Status     string `json:"status"`
+
+	// Date is parsed from http header Date, which is server time of response.
+	// Zero if cex does not respond it.
+	Date time.Time `json:"date"`
+
+	// RequestId is set by ResponseMetaParser, empty if cex does not respond it.
+	RequestId string `json:"requestId,omitempty"`
+
+	// Headers are headers of interest, ex. used weight, set by ResponseMetaParser.
+	Headers http.Header `json:"headers,omitempty"`
+
+	// Latency is duration from sending request to receiving response.
+	Latency    time.Duration `json:"latency"`
+	ReceivedAt time.Time     `json:"receivedAt"`
+
+	// Cached is true, if response is from ResponseCacheStore.
+	Cached bool `json:"cached"`
+}
+
+// ServerTimeOffset returns server time - local time, when response is received.
+// Header Date is in seconds, so offset is only accurate to a second.
+func (m *ResponseMeta) ServerTimeOffset() (time.Duration, bool) {
+	if m == nil || m.Date.IsZero() || m.ReceivedAt.IsZero() {
+		return 0, false
+	}
+	return m.Date.Sub(m.ReceivedAt), true
+}
+
+// ResponseMetaParser may be implemented by ReqMaker.
+// If ReqMaker implements it, Request will call it after every response,
+// to set cex specific fields of meta, ex. RequestId and Headers.
+type ResponseMetaParser interface {
+	ParseResponseMeta(resp *Response, meta *ResponseMeta)
+}
+
+// NewResponseMeta returns common metadata of resp, nil if resp is nil.
+func NewResponseMeta(resp *Response) *ResponseMeta {
+	if resp == nil {
+		return nil
+	}
+	meta := &ResponseMeta{
+		StatusCode: resp.StatusCode(),
+		Status:     resp.Status(),
+		Latency:    resp.Time(),
+		ReceivedAt: resp.ReceivedAt(),
+	}
+	if date := resp.Header().Get("Date"); date != "" {
+		if t, err := http.ParseTime(date); err == nil {
+			meta.Date = t
+		}
+	}
+	return meta
+}
+
+func newResponseMeta(reqMaker ReqMaker, resp *Response) *ResponseMeta {
+	meta := NewResponseMeta(resp)
+	if meta == nil {
+		return nil
+	}
+	if parser, ok := reqMaker.(ResponseMetaParser); ok {
+		parser.ParseResponseMeta(resp, meta)
+	}
+	return meta
+}
+
+// -----------------------------------------------------------
+// Response Meta
+// ===========================================================
@@ -0,0 +1,51 @@
+package cex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseMeta(t *testing.T) {
+	serverTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := http.StatusOK
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[testPublicParams, map[string]string]{
+		ReqBaseConfig: ReqBaseConfig{BaseUrl: "https://example.com", Path: "/meta", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error {
+			if code != http.StatusOK {
+				return ErrHTTPBadRequest
+			}
+			return nil
+		},
+		RespBodyUnmarshaler: StdBodyUnmarshaler[map[string]string],
+		RetryPolicy:         &RetryPolicy{MaxAttempts: 1},
+	}
+	maker := NewPublicReqMaker(PublicReqMakerOptBaseUrlRewriter(func(baseUrl string) (string, error) {
+		return sv.URL, nil
+	}))
+	_, _, err := Request(maker, config, testPublicParams{})
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	meta := err.Meta
+	if meta == nil || meta.StatusCode != http.StatusOK || !meta.Date.Equal(serverTime) || meta.ReceivedAt.IsZero() {
+		t.Fatal("meta should be set even if request succeeded", meta)
+	}
+	if offset, ok := meta.ServerTimeOffset(); !ok || offset >= 0 {
+		t.Error("server time is in the past", offset, ok)
+	}
+
+	status = http.StatusBadRequest
+	_, _, err = Request(maker, config, testPublicParams{})
+	if err.IsNil() || err.Meta == nil || err.Meta.StatusCode != http.StatusBadRequest {
+		t.Error("meta should be attached to request error", err.Meta)
+	}
+}
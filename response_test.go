@@ -0,0 +1,40 @@
+package cex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResponse(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test", "a")
+		_, _ = w.Write([]byte(`{"symbol":"ETHUSDT"}`))
+	}))
+	defer sv.Close()
+
+	config := ReqConfig[testPublicParams, map[string]string]{
+		ReqBaseConfig:         ReqBaseConfig{BaseUrl: "https://example.com", Path: "/resp", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error { return nil },
+		RespBodyUnmarshaler:   StdBodyUnmarshaler[map[string]string],
+	}
+	maker := NewPublicReqMaker(PublicReqMakerOptBaseUrlRewriter(func(baseUrl string) (string, error) {
+		return sv.URL, nil
+	}))
+	var httpClient *http.Client
+	resp, _, err := Request(maker, config, testPublicParams{}, CltOptHTTPClient(func(c *http.Client) { httpClient = c }))
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if httpClient == nil {
+		t.Error("http client should be customized")
+	}
+	if !resp.IsSuccess() || resp.Status() != "200 OK" || resp.Header().Get("X-Test") != "a" || resp.String() != `{"symbol":"ETHUSDT"}` || resp.ReceivedAt().IsZero() {
+		t.Error("wrong response", resp.StatusCode(), resp.Status(), resp.Header(), resp.String())
+	}
+
+	resp = NewResponse(http.StatusTeapot, nil, nil)
+	if !resp.IsError() || resp.Header() == nil || resp.Status() != "418 I'm a teapot" {
+		t.Error("wrong new response", resp.StatusCode(), resp.Status())
+	}
+}
@@ -0,0 +1,141 @@
+package cex
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++               Cex REST Core: Retry Policy           +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// RetryPolicy decides whether and when Request retries.
+// Be careful to retry non-idempotent requests, ex. placing order,
+// with errors whose request status is unknown.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt.
+	// Request is sent only once, if MaxAttempts < 2.
+	MaxAttempts int
+
+	// Retryable classifies request error.
+	// Request is not retried, if it is nil.
+	Retryable func(err RequestError) bool
+
+	// BaseDelay is delay before the first retry,
+	// and it is doubled on every next retry.
+	// Zero means retrying immediately.
+	BaseDelay time.Duration
+
+	// MaxDelay caps backoff delay, zero means no cap.
+	MaxDelay time.Duration
+
+	// Jitter is in [0, 1], delay is randomized in [delay*(1-Jitter), delay].
+	Jitter float64
+
+	// HonorRetryAfter makes Request wait until RateLimitError.ResetsAt,
+	// if it is later than backoff delay.
+	HonorRetryAfter bool
+
+	// OnRetry is called before every retry with failed attempt and its error,
+	// so retries are not silent, ex. to log or tune recvWindow on ErrInvalidTimestamp.
+	OnRetry func(attempt int, err RequestError)
+}
+
+// RetryOnErrs returns a classifier which retries if request error is one of errs.
+func RetryOnErrs(errs ...error) func(RequestError) bool {
+	return func(err RequestError) bool {
+		for _, e := range errs {
+			if err.Is(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RetryOnRateLimited retries if request is rate limited by cex or local limiter.
+func RetryOnRateLimited(err RequestError) bool {
+	return err.IsRateLimited()
+}
+
+// Delay returns backoff delay before retry attempt, attempt starts from 1.
+func (p RetryPolicy) Delay(attempt int, err RequestError) time.Duration {
+	var d time.Duration
+	if p.BaseDelay > 0 && attempt > 0 {
+		d = p.BaseDelay
+		for i := 1; i < attempt; i++ {
+			d *= 2
+			if p.MaxDelay > 0 && d >= p.MaxDelay {
+				break
+			}
+		}
+		if p.MaxDelay > 0 && d > p.MaxDelay {
+			d = p.MaxDelay
+		}
+		if p.Jitter > 0 {
+			j := p.Jitter
+			if j > 1 {
+				j = 1
+			}
+			d -= time.Duration(float64(d) * j * rand.Float64())
+		}
+	}
+	if p.HonorRetryAfter && err.RateLimitError != nil {
+		if w := err.RateLimitError.Wait(); w > d {
+			d = w
+		}
+	}
+	return d
+}
+
+func (p RetryPolicy) shouldRetry(attempt int, err RequestError) bool {
+	if err.IsNil() || attempt >= p.MaxAttempts || p.Retryable == nil {
+		return false
+	}
+	return p.Retryable(err)
+}
+
+var (
+	muxDefaultRetryPolicy sync.RWMutex
+	defaultRetryPolicy    = RetryPolicy{
+		MaxAttempts: 3,
+		Retryable:   RetryOnErrs(ErrInvalidTimestamp),
+	}
+)
+
+// DefaultRetryPolicy is used by Request, if ReqConfig.RetryPolicy is nil.
+// Default retries 3 times immediately on ErrInvalidTimestamp.
+// Set a policy without ErrInvalidTimestamp retrying to get it directly,
+// ex. to sync time or enlarge recvWindow by self.
+func DefaultRetryPolicy() RetryPolicy {
+	muxDefaultRetryPolicy.RLock()
+	defer muxDefaultRetryPolicy.RUnlock()
+	return defaultRetryPolicy
+}
+
+func SetDefaultRetryPolicy(policy RetryPolicy) {
+	muxDefaultRetryPolicy.Lock()
+	defer muxDefaultRetryPolicy.Unlock()
+	defaultRetryPolicy = policy
+}
+
+// sleepCtx sleeps d, returns ctx error if ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
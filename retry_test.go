@@ -0,0 +1,89 @@
+package cex
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testReqMaker struct{}
+
+func (testReqMaker) Make(config ReqBaseConfig, reqData any, opts ...CltOpt) (*HTTPRequest, error) {
+	return &HTTPRequest{URL: config.BaseUrl + config.Path}, nil
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	if d := p.Delay(1, RequestError{}); d != 100*time.Millisecond {
+		t.Error("delay of attempt 1 should be 100ms, but", d)
+	}
+	if d := p.Delay(2, RequestError{}); d != 200*time.Millisecond {
+		t.Error("delay of attempt 2 should be 200ms, but", d)
+	}
+	if d := p.Delay(5, RequestError{}); d != 300*time.Millisecond {
+		t.Error("delay of attempt 5 should be capped to 300ms, but", d)
+	}
+	p.Jitter = 0.5
+	if d := p.Delay(1, RequestError{}); d < 50*time.Millisecond || d > 100*time.Millisecond {
+		t.Error("jittered delay should be in [50ms, 100ms], but", d)
+	}
+	p.HonorRetryAfter = true
+	err := RequestError{RateLimitError: &RateLimitError{ResetsAt: time.Now().Add(time.Second)}}
+	if d := p.Delay(1, err); d < 900*time.Millisecond {
+		t.Error("delay should honor retry after, but", d)
+	}
+}
+
+func TestRequest_RetryPolicy(t *testing.T) {
+	var count atomic.Int64
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer sv.Close()
+
+	errUnavailable := errors.New("unavailable")
+	config := ReqConfig[NilReqData, map[string]bool]{
+		ReqBaseConfig: ReqBaseConfig{BaseUrl: sv.URL, Path: "/retry", Method: http.MethodGet},
+		HTTPStatusCodeChecker: func(code int) error {
+			if code != http.StatusOK {
+				return errUnavailable
+			}
+			return nil
+		},
+		RespBodyUnmarshaler: StdBodyUnmarshaler[map[string]bool],
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts: 3,
+			Retryable:   RetryOnErrs(errUnavailable),
+			BaseDelay:   10 * time.Millisecond,
+		},
+	}
+	_, data, err := Request(testReqMaker{}, config, nil)
+	if err.IsNotNil() {
+		t.Fatal(err.Error())
+	}
+	if !data["ok"] || count.Load() != 3 {
+		t.Error("request should succeed at attempt 3, but", count.Load(), data)
+	}
+
+	count.Store(0)
+	var retried []int
+	config.RetryPolicy = &RetryPolicy{MaxAttempts: 2, Retryable: RetryOnErrs(errUnavailable), OnRetry: func(attempt int, err RequestError) {
+		if err.Is(errUnavailable) {
+			retried = append(retried, attempt)
+		}
+	}}
+	_, _, err = Request(testReqMaker{}, config, nil)
+	if !err.Is(errUnavailable) || count.Load() != 2 {
+		t.Error("request should fail after 2 attempts, but", count.Load(), err.Error())
+	}
+	if len(retried) != 1 || retried[0] != 1 {
+		t.Error("OnRetry should be called once after attempt 1, but", retried)
+	}
+}
@@ -0,0 +1,190 @@
+package cex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"time"
+)
+
+// ===========================================================
+// Schedule
+// -----------------------------------------------------------
+
+// ErrScheduleMissed is returned if fire time has passed by more than max lateness,
+// fire func is not called.
+var ErrScheduleMissed = errors.New("cex: schedule missed")
+
+// NextAligned returns the first exchange time after now, which is aligned to interval plus offset since unix epoch,
+// ex. interval 8h for funding settlement at 00:00, 08:00 and 16:00 UTC, and interval 1m for minute candle open.
+// Weekly and monthly candles are not aligned to epoch, so their open times should be computed by caller.
+func NextAligned(now time.Time, interval, offset time.Duration) time.Time {
+	if interval <= 0 {
+		return now
+	}
+	n := now.UnixNano() - offset.Nanoseconds()
+	n = n - n%interval.Nanoseconds() + interval.Nanoseconds()
+	return time.Unix(0, n).Add(offset).In(now.Location())
+}
+
+// ScheduleResult is result of ScheduleAt, times are exchange times.
+type ScheduleResult struct {
+	// At is scheduled exchange time.
+	At time.Time `json:"at" bson:"at"`
+	// FiredAt is estimated exchange time, when fire func is called.
+	FiredAt time.Time `json:"firedAt" bson:"firedAt"`
+	// Late is FiredAt minus expected fire time, which is At minus lead plus jitter.
+	Late time.Duration `json:"late" bson:"late"`
+	// WarmupErr is error of warmup func or time sync, fire func is called anyway.
+	WarmupErr error `json:"warmupErr" bson:"warmupErr"`
+	// Err is error of fire func.
+	Err error `json:"err" bson:"err"`
+}
+
+type ScheduleOpt func(*scheduleConfig)
+
+type scheduleConfig struct {
+	lead       time.Duration
+	warmup     func(ctx context.Context) error
+	warmupLead time.Duration
+	sync       bool
+	spin       time.Duration
+	jitter     time.Duration
+	maxLate    time.Duration
+}
+
+// ScheduleOptLead fires lead earlier than scheduled time,
+// ex. one-way network latency, so orders arrive at exchange on time.
+func ScheduleOptLead(lead time.Duration) ScheduleOpt {
+	return func(c *scheduleConfig) {
+		c.lead = lead
+	}
+}
+
+// ScheduleOptWarmup calls warmup lead earlier than fire time, ex. bnc User.Warmup,
+// so connections are established and idle in pool when orders are placed.
+// Error of warmup is set to result, and does not stop firing.
+func ScheduleOptWarmup(lead time.Duration, warmup func(ctx context.Context) error) ScheduleOpt {
+	return func(c *scheduleConfig) {
+		c.warmupLead = lead
+		c.warmup = warmup
+	}
+}
+
+// ScheduleOptSync syncs time sync at warmup time,
+// so offset is fresh at fire time. It does nothing without ScheduleOptWarmup.
+func ScheduleOptSync() ScheduleOpt {
+	return func(c *scheduleConfig) {
+		c.sync = true
+	}
+}
+
+// ScheduleOptSpin busy waits the last d before fire time, instead of sleeping,
+// because wakeup of timers may be late by up to milliseconds.
+// It costs one cpu core for d, default is 1ms.
+func ScheduleOptSpin(d time.Duration) ScheduleOpt {
+	return func(c *scheduleConfig) {
+		c.spin = d
+	}
+}
+
+// ScheduleOptJitter delays fire time by random duration in [0, jitter),
+// so fire times of many schedules or clients are spread.
+func ScheduleOptJitter(jitter time.Duration) ScheduleOpt {
+	return func(c *scheduleConfig) {
+		c.jitter = jitter
+	}
+}
+
+// ScheduleOptMaxLate sets max lateness of fire time, default is 100ms.
+// If fire time has passed by more than max lateness, ex. process was suspended,
+// fire func is not called and ErrScheduleMissed is returned.
+// Negative value disables the check.
+func ScheduleOptMaxLate(d time.Duration) ScheduleOpt {
+	return func(c *scheduleConfig) {
+		c.maxLate = d
+	}
+}
+
+// ScheduleAt blocks until exchange time at, estimated by ts, and calls fire.
+// Nil ts uses local clock.
+// Offset of ts is read again while sleeping, so syncs during sleep are applied.
+//
+//	at := cex.NextAligned(ts.Now(), 8*time.Hour, 0)
+//	res, err := cex.ScheduleAt(ctx, ts, at, placeOrders,
+//		cex.ScheduleOptLead(5*time.Millisecond),
+//		cex.ScheduleOptWarmup(10*time.Second, func(ctx context.Context) error {
+//			_ = user.Warmup(ctx)
+//			return nil
+//		}))
+//
+// Error is ctx error or ErrScheduleMissed, and error of fire func is set to result.
+func ScheduleAt(ctx context.Context, ts *TimeSync, at time.Time, fire func(ctx context.Context) error, opts ...ScheduleOpt) (ScheduleResult, error) {
+	cfg := scheduleConfig{spin: time.Millisecond, maxLate: 100 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	result := ScheduleResult{At: at}
+	fireAt := at.Add(-cfg.lead)
+	if cfg.jitter > 0 {
+		fireAt = fireAt.Add(rand.N(cfg.jitter))
+	}
+
+	if cfg.warmup != nil {
+		if err := sleepUntil(ctx, ts, fireAt.Add(-cfg.warmupLead), 0); err != nil {
+			return result, err
+		}
+		if cfg.sync && ts != nil {
+			result.WarmupErr = ts.Sync()
+		}
+		if err := cfg.warmup(ctx); err != nil {
+			result.WarmupErr = errors.Join(result.WarmupErr, err)
+		}
+	}
+
+	if err := sleepUntil(ctx, ts, fireAt, cfg.spin); err != nil {
+		return result, err
+	}
+	result.FiredAt = ts.Now()
+	result.Late = result.FiredAt.Sub(fireAt)
+	if cfg.maxLate >= 0 && result.Late > cfg.maxLate {
+		return result, fmt.Errorf("%w, late %v", ErrScheduleMissed, result.Late)
+	}
+	result.Err = fire(ctx)
+	return result, nil
+}
+
+// scheduleMaxSleep bounds every sleep, so new offset of time sync is applied.
+const scheduleMaxSleep = time.Second
+
+// sleepUntil sleeps until exchange time t, and busy waits the last spin.
+func sleepUntil(ctx context.Context, ts *TimeSync, t time.Time, spin time.Duration) error {
+	timer := time.NewTimer(0)
+	<-timer.C
+	defer timer.Stop()
+	for {
+		remaining := t.Sub(ts.Now())
+		if remaining <= spin {
+			break
+		}
+		timer.Reset(min(remaining-spin, scheduleMaxSleep))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	for ts.Now().Before(t) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		runtime.Gosched()
+	}
+	return nil
+}
+
+// -----------------------------------------------------------
+// Schedule
+// ===========================================================
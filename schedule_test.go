@@ -0,0 +1,68 @@
+package cex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNextAligned(t *testing.T) {
+	now := time.Date(2024, 1, 1, 7, 59, 59, 0, time.UTC)
+	if next := NextAligned(now, 8*time.Hour, 0); !next.Equal(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Error("wrong funding time", next)
+	}
+	if next := NextAligned(now.Add(time.Second), 8*time.Hour, 0); !next.Equal(time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC)) {
+		t.Error("aligned now should return the next one", next)
+	}
+	if next := NextAligned(now, time.Minute, 30*time.Second); !next.Equal(time.Date(2024, 1, 1, 8, 0, 30, 0, time.UTC)) {
+		t.Error("wrong time with offset", next)
+	}
+}
+
+func TestScheduleAt(t *testing.T) {
+	ts := NewTimeSync(func() (int64, error) {
+		return time.Now().Add(time.Hour).UnixMilli(), nil
+	})
+	if err := ts.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	at := ts.Now().Add(50 * time.Millisecond)
+	var warmupAt, firedAt time.Time
+	errFire := errors.New("fire")
+	result, err := ScheduleAt(context.Background(), ts, at, func(ctx context.Context) error {
+		firedAt = ts.Now()
+		return errFire
+	}, ScheduleOptLead(10*time.Millisecond), ScheduleOptSync(), ScheduleOptWarmup(20*time.Millisecond, func(ctx context.Context) error {
+		warmupAt = ts.Now()
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := firedAt.Sub(at) + 10*time.Millisecond; d < 0 || d > 5*time.Millisecond {
+		t.Error("fire should be lead earlier than at, but", d)
+	}
+	if d := firedAt.Sub(warmupAt) - 20*time.Millisecond; d < -5*time.Millisecond || d > 5*time.Millisecond {
+		t.Error("warmup should be warmup lead earlier than fire, but", d)
+	}
+	if !errors.Is(result.Err, errFire) || result.WarmupErr != nil || result.Late < 0 {
+		t.Error("wrong result", result)
+	}
+
+	if _, err := ScheduleAt(context.Background(), ts, ts.Now().Add(-time.Second), func(ctx context.Context) error {
+		t.Error("missed schedule should not fire")
+		return nil
+	}); !errors.Is(err, ErrScheduleMissed) {
+		t.Error("passed time should be missed, but", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := ScheduleAt(ctx, nil, time.Now().Add(time.Hour), func(ctx context.Context) error {
+		t.Error("canceled schedule should not fire")
+		return nil
+	}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("schedule should be canceled by ctx, but", err)
+	}
+}
@@ -1,11 +1,20 @@
 package cex
 
 import (
+	"crypto"
+	"crypto/ed25519"
 	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
 )
 
 type Signer func(payload, key string) []byte
@@ -38,3 +47,78 @@ func Sha512ToHex(payload string) string {
 	res := sha512.Sum512([]byte(payload))
 	return hex.EncodeToString(res[:])
 }
+
+// SignByRSA signs payload by RSASSA-PKCS1-v1_5 with SHA256.
+func SignByRSA(payload string, key *rsa.PrivateKey) ([]byte, error) {
+	hashed := sha256.Sum256([]byte(payload))
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+}
+
+func SignByEd25519(payload string, key ed25519.PrivateKey) []byte {
+	return ed25519.Sign(key, []byte(payload))
+}
+
+// ParsePrivateKeyPEM parses the first PEM block of data,
+// which can be PKCS #8 private key, or PKCS #1 RSA private key.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("cex: parse private key, no pem block")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cex: parse private key, %w", err)
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cex: parse private key, %w", err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("cex: parse private key, unsupported key %T", key)
+		}
+		return signer, nil
+	}
+	return nil, fmt.Errorf("cex: parse private key, unsupported pem type %q", block.Type)
+}
+
+func ParseRSAPrivateKeyPEM(data []byte) (*rsa.PrivateKey, error) {
+	key, err := ParsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("cex: parse private key, %T is not rsa key", key)
+	}
+	return rsaKey, nil
+}
+
+func ParseEd25519PrivateKeyPEM(data []byte) (ed25519.PrivateKey, error) {
+	key, err := ParsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("cex: parse private key, %T is not ed25519 key", key)
+	}
+	return edKey, nil
+}
+
+// ReadPrivateKeyPEM reads PEM private key file,
+// and returns its content, which can be used as secret key of Api.
+func ReadPrivateKeyPEM(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("cex: read private key, %w", err)
+	}
+	if _, err := ParsePrivateKeyPEM(data); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
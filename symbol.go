@@ -0,0 +1,218 @@
+package cex
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++            Cex Core: Symbol Normalization           +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ErrUnknownSymbol is returned by SymbolRegistry,
+// if symbol is neither registered nor parsable by symbol format of cex.
+var ErrUnknownSymbol = errors.New("unknown symbol")
+
+// CanonicalPair is exchange independent pair, ex. ETH/USDT of spot.
+// Base and Quote are upper case.
+type CanonicalPair struct {
+	Base   string   `json:"base" bson:"base"`
+	Quote  string   `json:"quote" bson:"quote"`
+	Market PairType `json:"market" bson:"market"`
+}
+
+func NewCanonicalPair(base, quote string, market PairType) CanonicalPair {
+	return CanonicalPair{Base: strings.ToUpper(base), Quote: strings.ToUpper(quote), Market: market}
+}
+
+// String returns BASE/QUOTE:MARKET, ex. ETH/USDT:SPOT.
+func (p CanonicalPair) String() string {
+	return p.Base + "/" + p.Quote + ":" + string(p.Market)
+}
+
+// DefaultSymbolQuotes are quotes to split symbols without separator, ex. ETHUSDT.
+var DefaultSymbolQuotes = []string{
+	"USDT", "USDC", "FDUSD", "TUSD", "BUSD", "DAI", "USD",
+	"EUR", "TRY", "BRL", "JPY",
+	"BTC", "ETH", "BNB",
+}
+
+// SymbolFormat formats canonical pairs to exchange symbols and parses them back,
+// exchange symbol is Prefix + Base + Separator + Quote + Suffixes[Market].
+type SymbolFormat struct {
+	// Prefix, ex. "t" of tETHUSD
+	Prefix    string
+	Separator string
+	// Suffixes by market, ex. "-SWAP" of okx perpetual futures
+	Suffixes map[PairType]string
+	// Quotes to split symbols, if Separator is empty, longer quotes are matched first.
+	// DefaultSymbolQuotes is used, if Quotes is empty.
+	Quotes []string
+}
+
+func (f SymbolFormat) Format(pair CanonicalPair) string {
+	return f.Prefix + pair.Base + f.Separator + pair.Quote + f.Suffixes[pair.Market]
+}
+
+func (f SymbolFormat) Parse(symbol string, market PairType) (CanonicalPair, error) {
+	s, ok := strings.CutPrefix(symbol, f.Prefix)
+	if !ok {
+		return CanonicalPair{}, fmt.Errorf("%w, %v has no prefix %v", ErrUnknownSymbol, symbol, f.Prefix)
+	}
+	if s, ok = strings.CutSuffix(s, f.Suffixes[market]); !ok {
+		return CanonicalPair{}, fmt.Errorf("%w, %v has no suffix %v", ErrUnknownSymbol, symbol, f.Suffixes[market])
+	}
+	if f.Separator != "" {
+		base, quote, ok := strings.Cut(s, f.Separator)
+		if !ok || base == "" || quote == "" {
+			return CanonicalPair{}, fmt.Errorf("%w, %v is not separated by %v", ErrUnknownSymbol, symbol, f.Separator)
+		}
+		return NewCanonicalPair(base, quote, market), nil
+	}
+	quotes := f.Quotes
+	if len(quotes) == 0 {
+		quotes = DefaultSymbolQuotes
+	}
+	quotes = slices.Clone(quotes)
+	slices.SortStableFunc(quotes, func(a, b string) int {
+		return len(b) - len(a)
+	})
+	upper := strings.ToUpper(s)
+	for _, quote := range quotes {
+		if base, ok := strings.CutSuffix(upper, strings.ToUpper(quote)); ok && base != "" {
+			return NewCanonicalPair(base, quote, market), nil
+		}
+	}
+	return CanonicalPair{}, fmt.Errorf("%w, %v has no known quote", ErrUnknownSymbol, symbol)
+}
+
+// DefaultSymbolFormats are symbol formats of supported cex.
+var DefaultSymbolFormats = map[Name]SymbolFormat{
+	BINANCE: {},
+	OKX:     {Separator: "-", Suffixes: map[PairType]string{PairTypeFutures: "-SWAP"}},
+	BYBIT:   {},
+	BITGET:  {},
+}
+
+type symbolKey struct {
+	cex    Name
+	market PairType
+	symbol string
+}
+
+type canonicalKey struct {
+	cex  Name
+	pair CanonicalPair
+}
+
+// SymbolRegistry maps exchange symbols to canonical pairs and back,
+// so multi-exchange callers do not hardcode symbol formats.
+//
+// Registered symbols take precedence, ex. 1000PEPEUSDT of binance futures,
+// which can be registered from exchange info by RegisterPairs.
+// Unregistered symbols are converted by symbol format of cex.
+//
+//	reg := NewSymbolRegistry()
+//	pair, err := reg.Canonical(BINANCE, PairTypeSpot, "ETHUSDT")
+//	symbol, err := reg.Symbol(OKX, pair) // ETH-USDT
+type SymbolRegistry struct {
+	mux         sync.RWMutex
+	formats     map[Name]SymbolFormat
+	toCanonical map[symbolKey]CanonicalPair
+	toSymbol    map[canonicalKey]string
+}
+
+// NewSymbolRegistry returns registry with DefaultSymbolFormats.
+func NewSymbolRegistry() *SymbolRegistry {
+	r := &SymbolRegistry{
+		formats:     map[Name]SymbolFormat{},
+		toCanonical: map[symbolKey]CanonicalPair{},
+		toSymbol:    map[canonicalKey]string{},
+	}
+	for cex, f := range DefaultSymbolFormats {
+		r.formats[cex] = f
+	}
+	return r
+}
+
+// SetFormat sets symbol format of cex, which may be not in Name enum.
+func (r *SymbolRegistry) SetFormat(cex Name, format SymbolFormat) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.formats[cex] = format
+}
+
+// Register maps symbol of cex to pair in both directions.
+func (r *SymbolRegistry) Register(cex Name, symbol string, pair CanonicalPair) {
+	pair = NewCanonicalPair(pair.Base, pair.Quote, pair.Market)
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.toCanonical[symbolKey{cex, pair.Market, symbol}] = pair
+	r.toSymbol[canonicalKey{cex, pair}] = symbol
+}
+
+// RegisterPairs registers PairSymbol of pairs, ex. pairs of exchange info.
+func (r *SymbolRegistry) RegisterPairs(pairs ...Pair) {
+	for _, p := range pairs {
+		if p.PairSymbol == "" || p.Asset == "" || p.Quote == "" {
+			continue
+		}
+		r.Register(p.Cex, p.PairSymbol, NewCanonicalPair(p.Asset, p.Quote, p.Type))
+	}
+}
+
+// Canonical returns canonical pair of symbol of cex.
+func (r *SymbolRegistry) Canonical(cex Name, market PairType, symbol string) (CanonicalPair, error) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	if pair, ok := r.toCanonical[symbolKey{cex, market, symbol}]; ok {
+		return pair, nil
+	}
+	f, ok := r.formats[cex]
+	if !ok {
+		return CanonicalPair{}, fmt.Errorf("%w, %v has no symbol format", ErrUnknownSymbol, cex)
+	}
+	return f.Parse(symbol, market)
+}
+
+// Symbol returns symbol of pair of cex.
+func (r *SymbolRegistry) Symbol(cex Name, pair CanonicalPair) (string, error) {
+	pair = NewCanonicalPair(pair.Base, pair.Quote, pair.Market)
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	if symbol, ok := r.toSymbol[canonicalKey{cex, pair}]; ok {
+		return symbol, nil
+	}
+	f, ok := r.formats[cex]
+	if !ok {
+		return "", fmt.Errorf("%w, %v has no symbol format", ErrUnknownSymbol, cex)
+	}
+	return f.Format(pair), nil
+}
+
+// Convert converts symbol of market from one cex to another, ex. ETHUSDT of binance to ETH-USDT of okx.
+func (r *SymbolRegistry) Convert(from Name, market PairType, symbol string, to Name) (string, error) {
+	pair, err := r.Canonical(from, market, symbol)
+	if err != nil {
+		return "", err
+	}
+	return r.Symbol(to, pair)
+}
+
+// DefaultSymbolRegistry is shared registry of package level functions.
+var DefaultSymbolRegistry = NewSymbolRegistry()
+
+// CanonicalPairOf is DefaultSymbolRegistry.Canonical.
+func CanonicalPairOf(cex Name, market PairType, symbol string) (CanonicalPair, error) {
+	return DefaultSymbolRegistry.Canonical(cex, market, symbol)
+}
+
+// SymbolOf is DefaultSymbolRegistry.Symbol.
+func SymbolOf(cex Name, pair CanonicalPair) (string, error) {
+	return DefaultSymbolRegistry.Symbol(cex, pair)
+}
@@ -0,0 +1,58 @@
+package cex
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSymbolRegistry(t *testing.T) {
+	reg := NewSymbolRegistry()
+	ethSpot := NewCanonicalPair("eth", "usdt", PairTypeSpot)
+	ethSwap := NewCanonicalPair("ETH", "USDT", PairTypeFutures)
+
+	symbols := map[Name]map[CanonicalPair]string{
+		BINANCE: {ethSpot: "ETHUSDT", ethSwap: "ETHUSDT"},
+		OKX:     {ethSpot: "ETH-USDT", ethSwap: "ETH-USDT-SWAP"},
+		BYBIT:   {ethSpot: "ETHUSDT"},
+		BITGET:  {ethSwap: "ETHUSDT"},
+	}
+	for cex, pairs := range symbols {
+		for pair, want := range pairs {
+			symbol, err := reg.Symbol(cex, pair)
+			if err != nil || symbol != want {
+				t.Error("wrong symbol", cex, pair, symbol, err)
+			}
+			got, err := reg.Canonical(cex, pair.Market, symbol)
+			if err != nil || got != pair {
+				t.Error("wrong canonical pair", cex, symbol, got, err)
+			}
+		}
+	}
+
+	if pair, err := reg.Canonical(BINANCE, PairTypeSpot, "ETHFDUSD"); err != nil || pair.Quote != "FDUSD" || pair.Base != "ETH" {
+		t.Error("longer quote should be matched first", pair, err)
+	}
+	if _, err := reg.Canonical(BINANCE, PairTypeSpot, "ETHXYZ"); !errors.Is(err, ErrUnknownSymbol) {
+		t.Error("unknown quote should fail", err)
+	}
+	if _, err := reg.Canonical(OKX, PairTypeFutures, "ETH-USDT"); !errors.Is(err, ErrUnknownSymbol) {
+		t.Error("swap without suffix should fail", err)
+	}
+
+	reg.SetFormat("BITFINEX", SymbolFormat{Prefix: "t", Quotes: []string{"USD", "BTC"}})
+	if symbol, err := reg.Convert(OKX, PairTypeSpot, "ETH-USD", "BITFINEX"); err != nil || symbol != "tETHUSD" {
+		t.Error("wrong converted symbol", symbol, err)
+	}
+
+	pepe := NewCanonicalPair("PEPE", "USDT", PairTypeFutures)
+	reg.RegisterPairs(Pair{Cex: BINANCE, Type: PairTypeFutures, Asset: "PEPE", Quote: "USDT", PairSymbol: "1000PEPEUSDT"})
+	if symbol, err := reg.Symbol(BINANCE, pepe); err != nil || symbol != "1000PEPEUSDT" {
+		t.Error("registered symbol should be used", symbol, err)
+	}
+	if symbol, err := reg.Convert(BINANCE, PairTypeFutures, "1000PEPEUSDT", OKX); err != nil || symbol != "PEPE-USDT-SWAP" {
+		t.Error("wrong converted symbol", symbol, err)
+	}
+	if _, err := reg.Symbol("UNKNOWN", pepe); !errors.Is(err, ErrUnknownSymbol) {
+		t.Error("cex without format should fail", err)
+	}
+}
@@ -0,0 +1,101 @@
+package cex
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++               Cex REST Core: Time Sync              +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ServerTimeQuerier queries cex server time, unit is millisecond.
+type ServerTimeQuerier func() (int64, error)
+
+// TimeSync keeps offset between local clock and cex server clock,
+// so signed timestamps are correct on skewed local clocks.
+// Nil TimeSync is valid, and its Now is time.Now.
+type TimeSync struct {
+	mux     sync.RWMutex
+	querier ServerTimeQuerier
+	offset  time.Duration
+	synced  time.Time
+	err     error
+}
+
+func NewTimeSync(querier ServerTimeQuerier) *TimeSync {
+	return &TimeSync{querier: querier}
+}
+
+// Sync queries server time once and updates offset.
+// Network latency is compensated by half of round trip.
+func (s *TimeSync) Sync() error {
+	start := time.Now()
+	serverTime, err := s.querier()
+	end := time.Now()
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.err = err
+	if err != nil {
+		return err
+	}
+	local := start.Add(end.Sub(start) / 2)
+	s.offset = time.UnixMilli(serverTime).Sub(local)
+	s.synced = end
+	return nil
+}
+
+// Start syncs every interval in a new goroutine until ctx is done.
+// Failed syncs keep the last offset, and error can be got by Err.
+func (s *TimeSync) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = s.Sync()
+			}
+		}
+	}()
+}
+
+// Offset is server time minus local time.
+func (s *TimeSync) Offset() time.Duration {
+	if s == nil {
+		return 0
+	}
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.offset
+}
+
+// SyncedAt returns local time of the last successful sync.
+func (s *TimeSync) SyncedAt() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.synced
+}
+
+// Err returns error of the last sync.
+func (s *TimeSync) Err() error {
+	if s == nil {
+		return nil
+	}
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.err
+}
+
+// Now returns estimated server time.
+func (s *TimeSync) Now() time.Time {
+	return time.Now().Add(s.Offset())
+}
@@ -0,0 +1,37 @@
+package cex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeSync(t *testing.T) {
+	ts := NewTimeSync(func() (int64, error) {
+		return time.Now().Add(time.Hour).UnixMilli(), nil
+	})
+	if err := ts.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if d := ts.Offset() - time.Hour; d > 10*time.Millisecond || d < -10*time.Millisecond {
+		t.Error("offset should be about 1h, but", ts.Offset())
+	}
+	if d := time.Until(ts.Now()) - time.Hour; d > 10*time.Millisecond || d < -10*time.Millisecond {
+		t.Error("now should be about 1h later, but", ts.Now())
+	}
+
+	errQuery := errors.New("query failed")
+	offset := ts.Offset()
+	ts.querier = func() (int64, error) { return 0, errQuery }
+	if err := ts.Sync(); !errors.Is(err, errQuery) || !errors.Is(ts.Err(), errQuery) {
+		t.Error("sync should fail, but", err)
+	}
+	if ts.Offset() != offset {
+		t.Error("failed sync should keep the last offset")
+	}
+
+	var nilTs *TimeSync
+	if nilTs.Offset() != 0 {
+		t.Error("offset of nil time sync should be 0")
+	}
+}
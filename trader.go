@@ -1,35 +1,38 @@
 package cex
 
-import (
-	"context"
+import "context"
 
-	"github.com/go-resty/resty/v2"
-)
-
-type SimpleTraderFunc func(OrderType, OrderSide, string, string, float64, float64, ...CltOpt) (*resty.Response, *Order, RequestError)
-type LimitTraderFunc func(string, string, float64, float64, ...CltOpt) (*resty.Response, *Order, RequestError)
-type MarketTraderFunc func(string, string, float64, ...CltOpt) (*resty.Response, *Order, RequestError)
+type SimpleTraderFunc func(OrderType, OrderSide, string, string, float64, float64, ...CltOpt) (*Response, *Order, RequestError)
+type LimitTraderFunc func(string, string, float64, float64, ...CltOpt) (*Response, *Order, RequestError)
+type MarketTraderFunc func(string, string, float64, ...CltOpt) (*Response, *Order, RequestError)
 
 type SpotTrader interface {
-	NewSpotOrder(asset, quote string, tradeType OrderType, side OrderSide, qty, price float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
-	NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
-	NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
-	NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
-	NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
+	NewSpotOrder(asset, quote string, tradeType OrderType, side OrderSide, qty, price float64, opts ...CltOpt) (*Response, *Order, RequestError)
+	NewSpotLimitBuyOrder(asset, quote string, qty, price float64, opts ...CltOpt) (*Response, *Order, RequestError)
+	NewSpotLimitSellOrder(asset, quote string, qty, price float64, opts ...CltOpt) (*Response, *Order, RequestError)
+	NewSpotMarketBuyOrder(asset, quote string, qty float64, opts ...CltOpt) (*Response, *Order, RequestError)
+	NewSpotMarketSellOrder(asset, quote string, qty float64, opts ...CltOpt) (*Response, *Order, RequestError)
 }
 
 type FuTrader interface {
-	NewFuturesOrder(asset, quote string, tradeType OrderType, side OrderSide, qty, price float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
-	NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
-	NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
-	NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
-	NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...CltOpt) (*resty.Response, *Order, RequestError)
+	NewFuturesOrder(asset, quote string, tradeType OrderType, side OrderSide, qty, price float64, opts ...CltOpt) (*Response, *Order, RequestError)
+	NewFuturesLimitBuyOrder(asset, quote string, qty, price float64, opts ...CltOpt) (*Response, *Order, RequestError)
+	NewFuturesLimitSellOrder(asset, quote string, qty, price float64, opts ...CltOpt) (*Response, *Order, RequestError)
+	NewFuturesMarketBuyOrder(asset, quote string, qty float64, opts ...CltOpt) (*Response, *Order, RequestError)
+	NewFuturesMarketSellOrder(asset, quote string, qty float64, opts ...CltOpt) (*Response, *Order, RequestError)
 }
 
 type Trader interface {
-	QueryOrder(*Order, ...CltOpt) (*resty.Response, RequestError)
-	CancelOrder(*Order, ...CltOpt) (*resty.Response, RequestError)
+	QueryOrder(*Order, ...CltOpt) (*Response, RequestError)
+	CancelOrder(*Order, ...CltOpt) (*Response, RequestError)
 	WaitOrder(context.Context, *Order, ...CltOpt) chan RequestError
 	SpotTrader
 	FuTrader
 }
+
+// FillQuerier queries normalized executions of account,
+// so PnL calculators can pull spot and futures fills by one interface.
+// startTime and endTime are in milliseconds.
+type FillQuerier interface {
+	QueryFills(pairType PairType, symbol string, startTime, endTime int64, opts ...CltOpt) ([]Fill, RequestError)
+}
@@ -0,0 +1,170 @@
+package cex
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// ===========================================================
+// Warmup
+// -----------------------------------------------------------
+
+// WarmupResult is result of warming up one url.
+// Durations are the slowest of new connections, 0 if connections are reused.
+type WarmupResult struct {
+	Url string `json:"url"`
+	// DNS is duration of resolving host, 0 if host is an ip or requests are proxied.
+	DNS     time.Duration `json:"dns"`
+	Connect time.Duration `json:"connect"`
+	TLS     time.Duration `json:"tls"`
+	// NewConns is count of connections established by warmup, others were idle in pool already.
+	NewConns int `json:"newConns"`
+	// Ping is the slowest round trip latency of ping requests, 0 if WarmupOptPing is not set.
+	Ping time.Duration `json:"ping"`
+	Err  error         `json:"err"`
+}
+
+type WarmupOpt func(*warmupConfig)
+
+type warmupConfig struct {
+	transport Transport
+	conns     int
+	ping      bool
+}
+
+// WarmupOptTransport warms up connections of transport instead of shared transport,
+// ex. transport set by UserOptTransport.
+func WarmupOptTransport(transport Transport) WarmupOpt {
+	return func(c *warmupConfig) {
+		c.transport = transport
+	}
+}
+
+// WarmupOptConns establishes n connections to every url, default is 1.
+// Connections of http2 hosts are multiplexed, so they may share one connection.
+func WarmupOptConns(n int) WarmupOpt {
+	return func(c *warmupConfig) {
+		c.conns = n
+	}
+}
+
+// WarmupOptPing sends GET request to url, and fails if response is not 2xx,
+// so urls should be lightweight endpoints, ex. bnc spot ping.
+// Otherwise, HEAD request is sent, and response is ignored.
+func WarmupOptPing() WarmupOpt {
+	return func(c *warmupConfig) {
+		c.ping = true
+	}
+}
+
+// Warmup resolves hosts, establishes tls connections to urls concurrently,
+// and leaves connections idle in pool of transport,
+// so first orders do not pay for dns, tcp and tls handshakes.
+// It should be called before trading, and again if connections may be closed by idle timeout.
+// Go does not cache dns, resolving warms cache of system resolver only.
+// Results are in the same order as urls.
+func Warmup(ctx context.Context, urls []string, opts ...WarmupOpt) []WarmupResult {
+	cfg := warmupConfig{conns: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.transport == nil {
+		cfg.transport = SharedTransport()
+	}
+	if cfg.conns < 1 {
+		cfg.conns = 1
+	}
+	results := make([]WarmupResult, len(urls))
+	wg := sync.WaitGroup{}
+	for i, u := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = warmup(ctx, cfg, u)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func warmup(ctx context.Context, cfg warmupConfig, rawUrl string) WarmupResult {
+	result := WarmupResult{Url: rawUrl}
+	mux := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for range cfg.conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := warmupConn(ctx, cfg, rawUrl)
+			mux.Lock()
+			defer mux.Unlock()
+			if err != nil {
+				if result.Err == nil {
+					result.Err = err
+				}
+				return
+			}
+			if !conn.reused {
+				result.NewConns++
+			}
+			result.DNS = max(result.DNS, conn.dns)
+			result.Connect = max(result.Connect, conn.connect)
+			result.TLS = max(result.TLS, conn.tls)
+			if cfg.ping {
+				result.Ping = max(result.Ping, conn.ping)
+			}
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+type warmupConnResult struct {
+	reused                  bool
+	dns, connect, tls, ping time.Duration
+}
+
+func warmupConn(ctx context.Context, cfg warmupConfig, rawUrl string) (warmupConnResult, error) {
+	var conn warmupConnResult
+	var dnsStart, connectStart, tlsStart time.Time
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:           func(httptrace.DNSDoneInfo) { conn.dns = time.Since(dnsStart) },
+		ConnectStart:      func(string, string) { connectStart = time.Now() },
+		ConnectDone:       func(string, string, error) { conn.connect = time.Since(connectStart) },
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { conn.tls = time.Since(tlsStart) },
+		GotConn:           func(info httptrace.GotConnInfo) { conn.reused = info.Reused },
+	}
+	method := http.MethodHead
+	if cfg.ping {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), method, rawUrl, nil)
+	if err != nil {
+		return conn, fmt.Errorf("cex: warmup %v, %w", rawUrl, err)
+	}
+	start := time.Now()
+	resp, err := cfg.transport.RoundTrip(req)
+	if err != nil {
+		return conn, fmt.Errorf("cex: warmup %v, %w", rawUrl, err)
+	}
+	// body is drained, so connection is put back to pool
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	conn.ping = time.Since(start)
+	if cfg.ping && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+		return conn, fmt.Errorf("cex: warmup %v, ping status %v", rawUrl, resp.Status)
+	}
+	return conn, nil
+}
+
+// -----------------------------------------------------------
+// Warmup
+// ===========================================================
@@ -0,0 +1,49 @@
+package cex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWarmup(t *testing.T) {
+	sv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ping" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer sv.Close()
+	transport := sv.Client().Transport
+
+	results := Warmup(context.Background(), []string{sv.URL + "/ping", sv.URL + "/404"},
+		WarmupOptTransport(transport), WarmupOptConns(2))
+	if len(results) != 2 || results[0].Url != sv.URL+"/ping" {
+		t.Fatal("results should be in order of urls", results)
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Error("head request should ignore status", r.Err)
+		}
+	}
+	if results[0].NewConns == 0 || results[0].TLS == 0 || results[0].Ping != 0 {
+		t.Error("tls connections should be established", results[0])
+	}
+
+	results = Warmup(context.Background(), []string{sv.URL + "/ping"}, WarmupOptTransport(transport), WarmupOptPing())
+	if results[0].Err != nil || results[0].NewConns != 0 || results[0].Ping == 0 {
+		t.Error("idle connection should be reused by ping", results[0])
+	}
+	results = Warmup(context.Background(), []string{sv.URL + "/404"}, WarmupOptTransport(transport), WarmupOptPing())
+	if results[0].Err == nil {
+		t.Error("ping should fail if status is not 2xx")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if results = Warmup(ctx, []string{sv.URL + "/ping"}, WarmupOptTransport(transport)); results[0].Err == nil {
+		t.Error("canceled warmup should fail")
+	}
+}
@@ -0,0 +1,243 @@
+package cex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++              Cex REST Core: Weight Budget           +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// ===========================================================
+// Weight Budget
+// -----------------------------------------------------------
+
+// DefaultWeightBudgetThreshold is the ratio of limit,
+// at which WeightBudget starts throttling.
+const DefaultWeightBudgetThreshold = 0.9
+
+type weightUsage struct {
+	used int64
+	// cex counts usage in fixed windows,
+	// usage resets at windowEnd
+	windowEnd time.Time
+}
+
+// WeightBudget throttles requests by usage reported by cex,
+// ex. binance X-MBX-USED-WEIGHT-1M, before cex responds 429 or 418.
+// Limits are RateLimitBudgets of base url,
+// so it does nothing until budgets are loaded, ex. by bnc.LoadRateLimits.
+//
+// RateLimiter counts requests sent by this process only,
+// while WeightBudget uses usage of cex, which contains requests
+// sent by other processes using the same ip or account.
+type WeightBudget struct {
+	mux       sync.Mutex
+	mode      RateLimitMode
+	threshold float64
+	usages    map[string]*weightUsage
+	// orderEndpoints are endpoints responding order count,
+	// only them are throttled by ORDERS budgets
+	orderEndpoints map[string]bool
+}
+
+// NewWeightBudget creates WeightBudget.
+// threshold is the ratio of limit, ex. 0.9,
+// DefaultWeightBudgetThreshold is used if threshold is not in (0, 1].
+func NewWeightBudget(mode RateLimitMode, threshold float64) *WeightBudget {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultWeightBudgetThreshold
+	}
+	return &WeightBudget{
+		mode:           mode,
+		threshold:      threshold,
+		usages:         map[string]*weightUsage{},
+		orderEndpoints: map[string]bool{},
+	}
+}
+
+var (
+	muxDefaultWeightBudget sync.RWMutex
+	defaultWeightBudget    = NewWeightBudget(RateLimitModeBlock, DefaultWeightBudgetThreshold)
+)
+
+// DefaultWeightBudget is consulted and updated by Request automatically.
+// Returns nil if it is disabled.
+func DefaultWeightBudget() *WeightBudget {
+	muxDefaultWeightBudget.RLock()
+	defer muxDefaultWeightBudget.RUnlock()
+	return defaultWeightBudget
+}
+
+// SetDefaultWeightBudget sets budget consulted by Request.
+// Set nil to disable it.
+func SetDefaultWeightBudget(budget *WeightBudget) {
+	muxDefaultWeightBudget.Lock()
+	defer muxDefaultWeightBudget.Unlock()
+	defaultWeightBudget = budget
+}
+
+func weightUsageKey(baseUrl string, limitType RateLimitType, apiKey string, interval time.Duration) string {
+	return fmt.Sprintf("%v|%v|%v|%v", baseUrl, limitType, apiKey, interval)
+}
+
+// Update records usage reported by cex.
+// apiKey is used for order count, can be empty if request is public.
+func (w *WeightBudget) Update(config ReqBaseConfig, apiKey string, usage *RateLimitUsage) {
+	if w == nil || usage.IsEmpty() {
+		return
+	}
+	at := usage.ReceivedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	for tag, used := range usage.UsedWeight {
+		interval, err := ParseIntervalTag(tag)
+		if err != nil {
+			continue
+		}
+		w.update(weightUsageKey(config.BaseUrl, RateLimitTypeRequestWeight, "", interval), interval, used, at)
+	}
+	if apiKey == "" || len(usage.OrderCount) == 0 {
+		return
+	}
+	w.orderEndpoints[endpointKey(config)] = true
+	for tag, used := range usage.OrderCount {
+		interval, err := ParseIntervalTag(tag)
+		if err != nil {
+			continue
+		}
+		w.update(weightUsageKey(config.BaseUrl, RateLimitTypeOrders, apiKey, interval), interval, used, at)
+	}
+}
+
+func (w *WeightBudget) update(key string, interval time.Duration, used int64, at time.Time) {
+	windowEnd := at.Truncate(interval).Add(interval)
+	u, ok := w.usages[key]
+	if !ok {
+		w.usages[key] = &weightUsage{used: used, windowEnd: windowEnd}
+		return
+	}
+	switch {
+	case windowEnd.After(u.windowEnd):
+		u.used, u.windowEnd = used, windowEnd
+	case windowEnd.Equal(u.windowEnd) && used > u.used:
+		// responses may arrive out of order
+		u.used = used
+	}
+}
+
+// Used returns recorded usage of base url, limit type and interval.
+// apiKey is only used for RateLimitTypeOrders.
+// Returns 0 if nothing is recorded, or window is passed.
+func (w *WeightBudget) Used(baseUrl string, limitType RateLimitType, apiKey string, interval time.Duration) int64 {
+	if w == nil {
+		return 0
+	}
+	if limitType != RateLimitTypeOrders {
+		apiKey = ""
+	}
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	u, ok := w.usages[weightUsageKey(baseUrl, limitType, apiKey, interval)]
+	if !ok || !time.Now().Before(u.windowEnd) {
+		return 0
+	}
+	return u.used
+}
+
+// Acquire waits or rejects until usage of cex is under threshold.
+// apiKey can be empty, if request is public.
+// cost is request weight, should be 1 if unknown.
+func (w *WeightBudget) Acquire(ctx context.Context, config ReqBaseConfig, apiKey string, cost int64) error {
+	if w == nil {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if cost < 1 {
+		cost = 1
+	}
+	budgets := RateLimitBudgets(config.BaseUrl)
+	if len(budgets) == 0 {
+		return nil
+	}
+	for {
+		limitType, wait := w.tryTake(config, apiKey, cost, budgets)
+		if wait <= 0 {
+			return nil
+		}
+		scope := RateLimitScopeIp
+		if limitType == RateLimitTypeOrders {
+			scope = RateLimitScopeAccount
+		}
+		if w.mode == RateLimitModeReject {
+			return &RateLimitError{
+				LimitType: limitType,
+				Scope:     scope,
+				ResetsAt:  time.Now().Add(wait),
+				Err:       ErrRateLimited,
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ErrRateLimited, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// tryTake adds cost to usages if all are under threshold,
+// otherwise returns limit type and max duration to wait.
+func (w *WeightBudget) tryTake(config ReqBaseConfig, apiKey string, cost int64, budgets []RateLimitBudget) (RateLimitType, time.Duration) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	now := time.Now()
+	var limitType RateLimitType
+	var maxWait time.Duration
+	var usages []*weightUsage
+	for _, b := range budgets {
+		if b.Limit <= 0 || b.Interval <= 0 {
+			continue
+		}
+		var key string
+		switch {
+		case b.LimitType == RateLimitTypeRequestWeight && b.Scope == RateLimitScopeIp:
+			key = weightUsageKey(config.BaseUrl, b.LimitType, "", b.Interval)
+		case b.LimitType == RateLimitTypeOrders && apiKey != "" && w.orderEndpoints[endpointKey(config)]:
+			key = weightUsageKey(config.BaseUrl, b.LimitType, apiKey, b.Interval)
+		default:
+			continue
+		}
+		u, ok := w.usages[key]
+		if !ok || !now.Before(u.windowEnd) {
+			continue
+		}
+		usages = append(usages, u)
+		if float64(u.used+cost) > float64(b.Limit)*w.threshold {
+			if d := u.windowEnd.Sub(now); d > maxWait {
+				limitType, maxWait = b.LimitType, d
+			}
+		}
+	}
+	if maxWait > 0 {
+		return limitType, maxWait
+	}
+	// count in-flight requests, before cex responds new usage
+	for _, u := range usages {
+		u.used += cost
+	}
+	return "", 0
+}
+
+// -----------------------------------------------------------
+// Weight Budget
+// ===========================================================
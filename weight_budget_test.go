@@ -0,0 +1,64 @@
+package cex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWeightBudget_Acquire(t *testing.T) {
+	config := ReqBaseConfig{BaseUrl: "https://test.weight.budget", Path: "/a", Method: "GET"}
+	SetRateLimitBudgets(config.BaseUrl, []RateLimitBudget{
+		{LimitType: RateLimitTypeRequestWeight, Scope: RateLimitScopeIp, Interval: time.Minute, Limit: 100},
+		{LimitType: RateLimitTypeOrders, Scope: RateLimitScopeAccount, Interval: 10 * time.Second, Limit: 10},
+	})
+
+	budget := NewWeightBudget(RateLimitModeReject, 0.9)
+	if err := budget.Acquire(context.TODO(), config, "", 1); err != nil {
+		t.Fatal("no usage is recorded, request should be allowed, but", err)
+	}
+
+	budget.Update(config, "", &RateLimitUsage{UsedWeight: map[string]int64{"1M": 80}})
+	if err := budget.Acquire(context.TODO(), config, "", 1); err != nil {
+		t.Fatal("usage is under threshold, request should be allowed, but", err)
+	}
+	if used := budget.Used(config.BaseUrl, RateLimitTypeRequestWeight, "", time.Minute); used != 81 {
+		t.Error("in-flight request should be counted, used should be 81, but", used)
+	}
+
+	budget.Update(config, "", &RateLimitUsage{UsedWeight: map[string]int64{"1M": 90}})
+	err := budget.Acquire(context.TODO(), config, "", 1)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatal("usage is over threshold, request should be rate limited, but", err)
+	}
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.Wait() <= 0 || rlErr.Wait() > time.Minute {
+		t.Error("rate limit error should wait until window end, but", err)
+	}
+
+	order := ReqBaseConfig{BaseUrl: config.BaseUrl, Path: "/order", Method: "POST", IsUserData: true}
+	budget = NewWeightBudget(RateLimitModeReject, 0.9)
+	budget.Update(order, "key", &RateLimitUsage{OrderCount: map[string]int64{"10S": 10}})
+	if err := budget.Acquire(context.TODO(), order, "key", 1); !errors.Is(err, ErrRateLimited) {
+		t.Error("order count is over threshold, request should be rate limited, but", err)
+	}
+	if err := budget.Acquire(context.TODO(), order, "other", 1); err != nil {
+		t.Error("order count of other key is not recorded, request should be allowed, but", err)
+	}
+	if err := budget.Acquire(context.TODO(), config, "key", 1); err != nil {
+		t.Error("endpoint does not count orders, request should be allowed, but", err)
+	}
+}
+
+func TestParseIntervalTag(t *testing.T) {
+	for tag, want := range map[string]time.Duration{"1M": time.Minute, "10S": 10 * time.Second, "1D": 24 * time.Hour} {
+		d, err := ParseIntervalTag(tag)
+		if err != nil || d != want {
+			t.Error("wrong interval of", tag, d, err)
+		}
+	}
+	if _, err := ParseIntervalTag("1X"); err == nil {
+		t.Error("invalid tag should return error")
+	}
+}
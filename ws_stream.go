@@ -0,0 +1,134 @@
+package cex
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/dwdwow/ws/wsclt"
+)
+
+// =========================================================== \\
+// +++                                                     +++ \\
+// +++                 Cex WebSocket Stream                +++ \\
+// +++                                                     +++ \\
+// =========================================================== \\
+
+// WsStreamHandler should be implemented in cex packages.
+// Client returns a merged client, which has been set topic suber, unsuber, ping and pong,
+// so connection management, auto-reconnect and re-subscribing are done by wsclt.
+// Handle parses raw ws msg into typed events.
+type WsStreamHandler[E any] interface {
+	Client() *wsclt.MergedClient
+	Handle(msg wsclt.MergedClientMsg) ([]E, error)
+}
+
+// WsStreamEvent is delivered by WsStream.
+// Err is not nil, if connection is broken or msg can not be handled.
+// Connection will be reconnected and topics will be re-subscribed automatically.
+type WsStreamEvent[E any] struct {
+	Data E
+	Err  error
+}
+
+type WsStreamOpt func(*wsStreamConfig)
+
+type wsStreamConfig struct {
+	msgBufSize   int
+	eventBufSize int
+	logger       *slog.Logger
+}
+
+func WsStreamOptBufSize(msgBufSize, eventBufSize int) WsStreamOpt {
+	return func(c *wsStreamConfig) {
+		c.msgBufSize = msgBufSize
+		c.eventBufSize = eventBufSize
+	}
+}
+
+func WsStreamOptLogger(logger *slog.Logger) WsStreamOpt {
+	return func(c *wsStreamConfig) {
+		c.logger = logger
+	}
+}
+
+// WsStream delivers typed events of subscribed topics over channel.
+type WsStream[E any] struct {
+	handler WsStreamHandler[E]
+	mgClt   *wsclt.MergedClient
+	msgCh   chan wsclt.MergedClientMsg
+	events  chan WsStreamEvent[E]
+	logger  *slog.Logger
+}
+
+func NewWsStream[E any](handler WsStreamHandler[E], opts ...WsStreamOpt) *WsStream[E] {
+	cfg := wsStreamConfig{msgBufSize: 1000, eventBufSize: 1000}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.logger == nil {
+		cfg.logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+	}
+	msgCh := make(chan wsclt.MergedClientMsg, cfg.msgBufSize)
+	return &WsStream[E]{
+		handler: handler,
+		mgClt:   handler.Client().SetMsgCh(msgCh),
+		msgCh:   msgCh,
+		events:  make(chan WsStreamEvent[E], cfg.eventBufSize),
+		logger:  cfg.logger,
+	}
+}
+
+// Start starts handling msgs until ctx is done.
+// Should be called before Sub, or msgs may be blocked.
+func (s *WsStream[E]) Start(ctx context.Context) {
+	go s.receive(ctx)
+}
+
+// Events returns event channel, which is never closed.
+func (s *WsStream[E]) Events() <-chan WsStreamEvent[E] {
+	return s.events
+}
+
+func (s *WsStream[E]) Sub(topics ...string) error {
+	return s.mgClt.Sub(topics)
+}
+
+func (s *WsStream[E]) Unsub(topics ...string) error {
+	return s.mgClt.Unsub(topics)
+}
+
+func (s *WsStream[E]) Client() *wsclt.MergedClient {
+	return s.mgClt
+}
+
+func (s *WsStream[E]) receive(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-s.msgCh:
+			if msg.Err != nil {
+				s.logger.Error("Ws msg error", "err", msg.Err)
+				s.deliver(ctx, WsStreamEvent[E]{Err: msg.Err})
+				continue
+			}
+			events, err := s.handler.Handle(msg)
+			if err != nil {
+				s.logger.Error("Can not handle ws msg", "err", err)
+				s.deliver(ctx, WsStreamEvent[E]{Err: err})
+				continue
+			}
+			for _, e := range events {
+				s.deliver(ctx, WsStreamEvent[E]{Data: e})
+			}
+		}
+	}
+}
+
+func (s *WsStream[E]) deliver(ctx context.Context, e WsStreamEvent[E]) {
+	select {
+	case <-ctx.Done():
+	case s.events <- e:
+	}
+}